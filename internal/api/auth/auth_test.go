@@ -0,0 +1,260 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wassef911/eventually/internal/infrastructure/es"
+	"github.com/wassef911/eventually/pkg/config"
+	httpErrors "github.com/wassef911/eventually/pkg/errors"
+)
+
+const secret = "test-secret"
+
+func signHS256(t *testing.T, claims Claims) string {
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(secret))
+	require.NoError(t, err)
+	return token
+}
+
+func customerClaims(expiresIn time.Duration) Claims {
+	return Claims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   "customer-1",
+			Issuer:    "shop",
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(expiresIn)),
+		},
+		Email: "jane@example.com",
+		Role:  RoleCustomer,
+	}
+}
+
+func TestAuthenticator_HS256(t *testing.T) {
+	authenticator, err := NewAuthenticator(config.Auth{Enabled: true, Algorithm: "HS256", Secret: secret, Issuer: "shop"})
+	require.NoError(t, err)
+
+	principal, err := authenticator.AuthenticateHeader("Bearer " + signHS256(t, customerClaims(time.Hour)))
+	require.NoError(t, err)
+	assert.Equal(t, &Principal{Subject: "customer-1", Email: "jane@example.com", Role: RoleCustomer}, principal)
+
+	_, err = authenticator.AuthenticateHeader("")
+	assert.True(t, errors.Is(err, httpErrors.Unauthorized))
+
+	tenantClaims := customerClaims(time.Hour)
+	tenantClaims.Tenant = "acme"
+	principal, err = authenticator.Authenticate(signHS256(t, tenantClaims))
+	require.NoError(t, err)
+	assert.Equal(t, "acme", principal.Tenant)
+
+	_, err = authenticator.Authenticate(signHS256(t, customerClaims(-time.Minute)))
+	assert.True(t, errors.Is(err, httpErrors.Unauthorized), "expired")
+
+	noExpiry := customerClaims(time.Hour)
+	noExpiry.ExpiresAt = nil
+	_, err = authenticator.Authenticate(signHS256(t, noExpiry))
+	assert.True(t, errors.Is(err, httpErrors.Unauthorized), "no expiry")
+
+	otherIssuer := customerClaims(time.Hour)
+	otherIssuer.Issuer = "other"
+	_, err = authenticator.Authenticate(signHS256(t, otherIssuer))
+	assert.True(t, errors.Is(err, httpErrors.Unauthorized), "issuer")
+
+	unknownRole := customerClaims(time.Hour)
+	unknownRole.Role = "root"
+	_, err = authenticator.Authenticate(signHS256(t, unknownRole))
+	assert.True(t, errors.Is(err, httpErrors.Forbidden), "role")
+
+	// the parser only accepts the configured algorithm
+	none, err := jwt.NewWithClaims(jwt.SigningMethodNone, customerClaims(time.Hour)).SignedString(jwt.UnsafeAllowNoneSignatureType)
+	require.NoError(t, err)
+	_, err = authenticator.Authenticate(none)
+	assert.True(t, errors.Is(err, httpErrors.Unauthorized), "none")
+}
+
+func TestAuthenticator_RS256JWKS(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	keySet, err := json.Marshal(jsonWebKeySet{Keys: []jsonWebKey{{
+		Kty: "RSA",
+		Kid: "key-1",
+		Use: "sig",
+		N:   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+		E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+	}}})
+	require.NoError(t, err)
+	jwksFile := filepath.Join(t.TempDir(), "jwks.json")
+	require.NoError(t, os.WriteFile(jwksFile, keySet, 0o600))
+
+	authenticator, err := NewAuthenticator(config.Auth{Enabled: true, Algorithm: "RS256", JWKSFile: jwksFile})
+	require.NoError(t, err)
+
+	claims := customerClaims(time.Hour)
+	claims.Role = RoleSupport
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = "key-1"
+	signed, err := token.SignedString(key)
+	require.NoError(t, err)
+
+	principal, err := authenticator.Authenticate(signed)
+	require.NoError(t, err)
+	assert.Equal(t, RoleSupport, principal.Role)
+
+	token.Header["kid"] = "key-2"
+	signed, err = token.SignedString(key)
+	require.NoError(t, err)
+	_, err = authenticator.Authenticate(signed)
+	assert.True(t, errors.Is(err, httpErrors.Unauthorized))
+}
+
+func TestNewAuthenticator_Config(t *testing.T) {
+	authenticator, err := NewAuthenticator(config.Auth{})
+	require.NoError(t, err)
+	principal, err := authenticator.AuthenticateHeader("")
+	require.NoError(t, err)
+	assert.Equal(t, Anonymous, principal)
+
+	_, err = NewAuthenticator(config.Auth{Enabled: true, Algorithm: "HS256"})
+	assert.True(t, errors.Is(err, ErrMissingKey))
+
+	_, err = NewAuthenticator(config.Auth{Enabled: true, Algorithm: "ES256"})
+	assert.True(t, errors.Is(err, ErrUnsupportedAlgorithm))
+}
+
+func TestAuthorize(t *testing.T) {
+	customer := &Principal{Subject: "customer-1", Email: "jane@example.com", Role: RoleCustomer}
+	ctx := ContextWithPrincipal(context.Background(), customer)
+
+	_, err := Authorize(context.Background(), PermissionReadOrder)
+	assert.True(t, errors.Is(err, httpErrors.Unauthorized))
+
+	_, err = Authorize(ctx, PermissionSearchOrders)
+	assert.True(t, errors.Is(err, httpErrors.Forbidden))
+
+	assert.NoError(t, AuthorizeOrder(ctx, PermissionCancelOrder, "Jane@Example.com", ""))
+	assert.True(t, errors.Is(AuthorizeOrder(ctx, PermissionCancelOrder, "john@example.com", ""), httpErrors.Forbidden))
+
+	support := ContextWithPrincipal(context.Background(), &Principal{Subject: "agent-1", Role: RoleSupport})
+	assert.NoError(t, AuthorizeOrder(support, PermissionFulfilOrder, "john@example.com", ""))
+	assert.True(t, errors.Is(AuthorizeOrder(support, PermissionPlaceOrder, "john@example.com", ""), httpErrors.Forbidden))
+
+	actor, ok := es.ActorFromContext(ctx)
+	require.True(t, ok)
+	assert.Equal(t, es.Actor{ID: "customer-1", Email: "jane@example.com", Role: "customer"}, actor)
+}
+
+func TestPrincipal_Can(t *testing.T) {
+	tests := []struct {
+		role       Role
+		permission Permission
+		allowed    bool
+	}{
+		{role: RoleCustomer, permission: PermissionManageCustomer, allowed: true},
+		{role: RoleCustomer, permission: PermissionReadCatalog, allowed: true},
+		{role: RoleCustomer, permission: PermissionManageCatalog, allowed: false},
+		{role: RoleCustomer, permission: PermissionManageWebhooks, allowed: false},
+		{role: RoleSupport, permission: PermissionManageCustomer, allowed: true},
+		{role: RoleSupport, permission: PermissionManageCatalog, allowed: false},
+		{role: RoleSupport, permission: PermissionManageWebhooks, allowed: false},
+		{role: RoleAdmin, permission: PermissionManageCatalog, allowed: true},
+		{role: RoleAdmin, permission: PermissionManageWebhooks, allowed: true},
+		{role: "", permission: PermissionReadCatalog, allowed: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.role)+" "+string(tt.permission), func(t *testing.T) {
+			principal := &Principal{Subject: "user-1", Role: tt.role}
+			assert.Equal(t, tt.allowed, principal.Can(tt.permission))
+		})
+	}
+}
+
+func TestPrincipal_CanAccessCustomer(t *testing.T) {
+	customer := &Principal{Subject: "customer-1", Email: "jane@example.com", Role: RoleCustomer}
+	assert.True(t, customer.CanAccessCustomer("Jane@Example.com"))
+	assert.False(t, customer.CanAccessCustomer("john@example.com"))
+	assert.False(t, (&Principal{Subject: "customer-2", Role: RoleCustomer}).CanAccessCustomer(""))
+
+	support := &Principal{Subject: "agent-1", Role: RoleSupport}
+	assert.True(t, support.CanAccessCustomer("john@example.com"))
+}
+
+func TestPrincipal_CanAccessOrder(t *testing.T) {
+	customer := &Principal{Subject: "customer-1", Email: "jane@example.com", CustomerID: "customer1", Role: RoleCustomer}
+	assert.True(t, customer.CanAccessOrder("Jane@Example.com", ""))
+	assert.True(t, customer.CanAccessOrder("jane.doe@example.com", "customer1"))
+	assert.False(t, customer.CanAccessOrder("john@example.com", "customer2"))
+	assert.False(t, (&Principal{Subject: "customer-2", Email: "john@example.com", Role: RoleCustomer}).CanAccessOrder("jane@example.com", ""))
+
+	support := &Principal{Subject: "agent-1", Role: RoleSupport}
+	assert.True(t, support.CanAccessOrder("john@example.com", "customer2"))
+}
+
+func TestOrderAccountEmail(t *testing.T) {
+	ctx := ContextWithPrincipal(context.Background(), &Principal{Subject: "customer-1", Email: "jane@example.com", Role: RoleCustomer})
+
+	email, err := OrderAccountEmail(ctx, "")
+	require.NoError(t, err)
+	assert.Equal(t, "jane@example.com", email)
+
+	_, err = OrderAccountEmail(ctx, "john@example.com")
+	assert.True(t, errors.Is(err, httpErrors.Forbidden))
+
+	admin := ContextWithPrincipal(context.Background(), Anonymous)
+	email, err = OrderAccountEmail(admin, "john@example.com")
+	require.NoError(t, err)
+	assert.Equal(t, "john@example.com", email)
+}
+
+func TestResolveTenant(t *testing.T) {
+	tenancy := config.Tenancy{Enabled: true, Tenants: map[string]config.Tenant{"acme": {}, "globex": {}}}
+
+	tenant, err := ResolveTenant(config.Tenancy{}, "acme", nil)
+	require.NoError(t, err)
+	assert.Empty(t, tenant, "tenancy disabled")
+
+	tenant, err = ResolveTenant(tenancy, "ACME", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "acme", tenant)
+
+	_, err = ResolveTenant(tenancy, "", nil)
+	assert.True(t, errors.Is(err, httpErrors.BadRequest), "missing")
+	_, err = ResolveTenant(tenancy, "initech", nil)
+	assert.True(t, errors.Is(err, httpErrors.BadRequest), "unknown")
+
+	customer := &Principal{Subject: "customer-1", Email: "jane@example.com", Role: RoleCustomer, Tenant: "globex"}
+	tenant, err = ResolveTenant(tenancy, "", customer)
+	require.NoError(t, err)
+	assert.Equal(t, "globex", tenant, "claim")
+	_, err = ResolveTenant(tenancy, "acme", customer)
+	assert.True(t, errors.Is(err, httpErrors.Forbidden), "other tenant")
+
+	_, err = ResolveTenant(tenancy, "acme", &Principal{Subject: "agent-1", Role: RoleSupport})
+	assert.True(t, errors.Is(err, httpErrors.Forbidden), "support without tenant")
+
+	tenant, err = ResolveTenant(tenancy, "acme", Anonymous)
+	require.NoError(t, err)
+	assert.Equal(t, "acme", tenant, "admin without tenant")
+}
+
+func TestSubdomainTenant(t *testing.T) {
+	assert.Equal(t, "acme", SubdomainTenant("acme.shop.example.com", "shop.example.com"))
+	assert.Equal(t, "acme", SubdomainTenant("Acme.shop.example.com:5007", "shop.example.com"))
+	assert.Empty(t, SubdomainTenant("shop.example.com", "shop.example.com"))
+	assert.Empty(t, SubdomainTenant("a.acme.shop.example.com", "shop.example.com"))
+	assert.Empty(t, SubdomainTenant("acme.other.com", "shop.example.com"))
+	assert.Empty(t, SubdomainTenant("acme.shop.example.com", ""))
+}
@@ -0,0 +1,154 @@
+package auth
+
+import (
+	"crypto/rsa"
+	"os"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/pkg/errors"
+
+	"github.com/wassef911/eventually/pkg/config"
+	httpErrors "github.com/wassef911/eventually/pkg/errors"
+)
+
+const bearerPrefix = "Bearer "
+
+var (
+	ErrUnsupportedAlgorithm = errors.New("unsupported token algorithm")
+	ErrMissingKey           = errors.New("no key configured for the token algorithm")
+)
+
+// Anonymous principal of every request while authentication is disabled.
+var Anonymous = &Principal{Subject: "anonymous", Role: RoleAdmin}
+
+// Claims of the bearer tokens, the subject identifies the actor of the events.
+type Claims struct {
+	jwt.RegisteredClaims
+	Email      string `json:"email,omitempty"`
+	CustomerID string `json:"customerId,omitempty"`
+	Role       Role   `json:"role"`
+	Tenant     string `json:"tenant,omitempty"`
+}
+
+type Authenticator struct {
+	config    config.Auth
+	parser    *jwt.Parser
+	secret    []byte
+	publicKey *rsa.PublicKey
+	jwks      map[string]*rsa.PublicKey
+}
+
+// NewAuthenticator loads the verification keys of the configured algorithm.
+func NewAuthenticator(cfg config.Auth) (*Authenticator, error) {
+	a := &Authenticator{config: cfg}
+	if !cfg.Enabled {
+		return a, nil
+	}
+
+	switch {
+	case strings.HasPrefix(cfg.Algorithm, "HS"):
+		if cfg.Secret == "" {
+			return nil, errors.Wrapf(ErrMissingKey, "algorithm: {%s}", cfg.Algorithm)
+		}
+		a.secret = []byte(cfg.Secret)
+
+	case strings.HasPrefix(cfg.Algorithm, "RS"):
+		if cfg.PublicKeyFile != "" {
+			pemBytes, err := os.ReadFile(cfg.PublicKeyFile)
+			if err != nil {
+				return nil, errors.Wrap(err, "os.ReadFile")
+			}
+			publicKey, err := jwt.ParseRSAPublicKeyFromPEM(pemBytes)
+			if err != nil {
+				return nil, errors.Wrap(err, "jwt.ParseRSAPublicKeyFromPEM")
+			}
+			a.publicKey = publicKey
+		}
+		if cfg.JWKSFile != "" {
+			jwks, err := LoadJWKS(cfg.JWKSFile)
+			if err != nil {
+				return nil, err
+			}
+			a.jwks = jwks
+		}
+		if a.publicKey == nil && len(a.jwks) == 0 {
+			return nil, errors.Wrapf(ErrMissingKey, "algorithm: {%s}", cfg.Algorithm)
+		}
+
+	default:
+		return nil, errors.Wrapf(ErrUnsupportedAlgorithm, "algorithm: {%s}", cfg.Algorithm)
+	}
+
+	if jwt.GetSigningMethod(cfg.Algorithm) == nil {
+		return nil, errors.Wrapf(ErrUnsupportedAlgorithm, "algorithm: {%s}", cfg.Algorithm)
+	}
+	a.parser = jwt.NewParser(jwt.WithValidMethods([]string{cfg.Algorithm}))
+	return a, nil
+}
+
+func (a *Authenticator) Enabled() bool {
+	return a.config.Enabled
+}
+
+// AuthenticateHeader authenticates the value of an Authorization header.
+func (a *Authenticator) AuthenticateHeader(header string) (*Principal, error) {
+	if !a.config.Enabled {
+		return Anonymous, nil
+	}
+	if !strings.HasPrefix(header, bearerPrefix) {
+		return nil, errors.Wrap(httpErrors.Unauthorized, "missing bearer token")
+	}
+	return a.Authenticate(strings.TrimSpace(strings.TrimPrefix(header, bearerPrefix)))
+}
+
+// Authenticate verifies the signature and the registered claims of the token, tokens must expire.
+func (a *Authenticator) Authenticate(tokenString string) (*Principal, error) {
+	if !a.config.Enabled {
+		return Anonymous, nil
+	}
+
+	claims := &Claims{}
+	if _, err := a.parser.ParseWithClaims(tokenString, claims, a.keyFunc); err != nil {
+		return nil, errors.Wrap(httpErrors.Unauthorized, err.Error())
+	}
+
+	switch {
+	case claims.ExpiresAt == nil:
+		return nil, errors.Wrap(httpErrors.Unauthorized, "token has no expiry")
+	case claims.Subject == "":
+		return nil, errors.Wrap(httpErrors.Unauthorized, "token has no subject")
+	case a.config.Issuer != "" && !claims.VerifyIssuer(a.config.Issuer, true):
+		return nil, errors.Wrap(httpErrors.Unauthorized, "unexpected token issuer")
+	case a.config.Audience != "" && !claims.VerifyAudience(a.config.Audience, true):
+		return nil, errors.Wrap(httpErrors.Unauthorized, "unexpected token audience")
+	}
+
+	if _, ok := rolePermissions[claims.Role]; !ok {
+		return nil, errors.Wrapf(httpErrors.Forbidden, "unknown role {%s}", claims.Role)
+	}
+	if claims.Role == RoleCustomer && claims.Email == "" {
+		return nil, errors.Wrap(httpErrors.Unauthorized, "customer token has no email")
+	}
+
+	return &Principal{Subject: claims.Subject, Email: claims.Email, CustomerID: claims.CustomerID, Role: claims.Role, Tenant: claims.Tenant}, nil
+}
+
+func (a *Authenticator) keyFunc(token *jwt.Token) (interface{}, error) {
+	if a.secret != nil {
+		return a.secret, nil
+	}
+
+	if kid, ok := token.Header["kid"].(string); ok && kid != "" {
+		if key, ok := a.jwks[kid]; ok {
+			return key, nil
+		}
+		if a.publicKey == nil {
+			return nil, errors.Errorf("unknown key id {%s}", kid)
+		}
+	}
+	if a.publicKey == nil {
+		return nil, errors.New("token has no key id")
+	}
+	return a.publicKey, nil
+}
@@ -0,0 +1,152 @@
+// Package auth authenticates the callers of the order apis with JWT bearer tokens and checks what their role allows.
+package auth
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/wassef911/eventually/internal/infrastructure/es"
+	httpErrors "github.com/wassef911/eventually/pkg/errors"
+)
+
+type Role string
+
+const (
+	RoleCustomer Role = "customer"
+	RoleSupport  Role = "support"
+	RoleAdmin    Role = "admin"
+)
+
+type Permission string
+
+const (
+	// PermissionReadOrder get and follow an order.
+	PermissionReadOrder Permission = "orders:read"
+	// PermissionSearchOrders search across the orders of every customer.
+	PermissionSearchOrders Permission = "orders:search"
+	// PermissionPlaceOrder create an order and change it before fulfillment: cart, coupons, address, payment, returns.
+	PermissionPlaceOrder Permission = "orders:place"
+	// PermissionCancelOrder cancel an order.
+	PermissionCancelOrder Permission = "orders:cancel"
+	// PermissionFulfilOrder ship, complete, refund and handle the returns of an order.
+	PermissionFulfilOrder Permission = "orders:fulfil"
+	// PermissionManageCustomer register and change a customer account, read its orders.
+	PermissionManageCustomer Permission = "customers:manage"
+	// PermissionReadCatalog read products, coupons and stock levels.
+	PermissionReadCatalog Permission = "catalog:read"
+	// PermissionManageCatalog create and change products and coupons, receive stock.
+	PermissionManageCatalog Permission = "catalog:manage"
+	// PermissionManageWebhooks subscribe endpoints to the order events of the tenant.
+	PermissionManageWebhooks Permission = "webhooks:manage"
+)
+
+var rolePermissions = map[Role][]Permission{
+	RoleCustomer: {PermissionReadOrder, PermissionPlaceOrder, PermissionCancelOrder, PermissionManageCustomer, PermissionReadCatalog},
+	RoleSupport: {
+		PermissionReadOrder, PermissionSearchOrders, PermissionCancelOrder, PermissionFulfilOrder,
+		PermissionManageCustomer, PermissionReadCatalog,
+	},
+	RoleAdmin: {
+		PermissionReadOrder, PermissionSearchOrders, PermissionPlaceOrder, PermissionCancelOrder, PermissionFulfilOrder,
+		PermissionManageCustomer, PermissionReadCatalog, PermissionManageCatalog, PermissionManageWebhooks,
+	},
+}
+
+// Principal authenticated caller, customers are matched to their orders by Email or by CustomerID when the token carries one.
+// Tenant is empty for principals of the default tenant and for admins of every tenant.
+type Principal struct {
+	Subject    string
+	Email      string
+	CustomerID string
+	Role       Role
+	Tenant     string
+}
+
+func (p *Principal) Can(permission Permission) bool {
+	for _, granted := range rolePermissions[p.Role] {
+		if granted == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// OwnsOrdersOnly customers are limited to their own orders, support and admins reach every order.
+func (p *Principal) OwnsOrdersOnly() bool {
+	return p.Role == RoleCustomer
+}
+
+// CanAccessOrder checks the order placed with accountEmail for customerID belongs to the principal.
+func (p *Principal) CanAccessOrder(accountEmail string, customerID string) bool {
+	if !p.OwnsOrdersOnly() {
+		return true
+	}
+	if p.CustomerID != "" && p.CustomerID == customerID {
+		return true
+	}
+	return p.Email != "" && strings.EqualFold(p.Email, accountEmail)
+}
+
+// CanAccessCustomer checks the customer account registered with email belongs to the principal.
+func (p *Principal) CanAccessCustomer(email string) bool {
+	return !p.OwnsOrdersOnly() || (p.Email != "" && strings.EqualFold(p.Email, email))
+}
+
+// Actor recorded in the metadata of the events the principal causes.
+func (p *Principal) Actor() es.Actor {
+	return es.Actor{ID: p.Subject, Email: p.Email, Role: string(p.Role)}
+}
+
+type principalKey struct{}
+
+// ContextWithPrincipal events saved with the returned context record the principal as their actor.
+func ContextWithPrincipal(ctx context.Context, principal *Principal) context.Context {
+	ctx = context.WithValue(ctx, principalKey{}, principal)
+	return es.ContextWithActor(ctx, principal.Actor())
+}
+
+func PrincipalFromContext(ctx context.Context) (*Principal, bool) {
+	principal, ok := ctx.Value(principalKey{}).(*Principal)
+	return principal, ok && principal != nil
+}
+
+// Authorize the principal of ctx holds the permission.
+func Authorize(ctx context.Context, permission Permission) (*Principal, error) {
+	principal, ok := PrincipalFromContext(ctx)
+	if !ok {
+		return nil, errors.Wrap(httpErrors.Unauthorized, "not authenticated")
+	}
+	if !principal.Can(permission) {
+		return nil, errors.Wrapf(httpErrors.Forbidden, "role {%s} lacks permission {%s}", principal.Role, permission)
+	}
+	return principal, nil
+}
+
+// AuthorizeOrder the principal of ctx holds the permission on the order placed with accountEmail for customerID.
+func AuthorizeOrder(ctx context.Context, permission Permission, accountEmail string, customerID string) error {
+	principal, err := Authorize(ctx, permission)
+	if err != nil {
+		return err
+	}
+	if !principal.CanAccessOrder(accountEmail, customerID) {
+		return errors.Wrap(httpErrors.Forbidden, "order belongs to another customer")
+	}
+	return nil
+}
+
+// OrderAccountEmail account email of an order placed by the principal of ctx, customers place orders for themselves.
+func OrderAccountEmail(ctx context.Context, accountEmail string) (string, error) {
+	principal, ok := PrincipalFromContext(ctx)
+	if !ok || !principal.OwnsOrdersOnly() {
+		return accountEmail, nil
+	}
+	if accountEmail == "" {
+		return principal.Email, nil
+	}
+	if !strings.EqualFold(accountEmail, principal.Email) {
+		return "", errors.Wrap(httpErrors.Forbidden, "customers place orders with their own email")
+	}
+	return accountEmail, nil
+}
@@ -0,0 +1,56 @@
+package auth
+
+import (
+	"net"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/wassef911/eventually/pkg/config"
+	httpErrors "github.com/wassef911/eventually/pkg/errors"
+)
+
+// ResolveTenant tenant a request acts for, from the tenant it asked for by header or subdomain and the tenant claim
+// of its principal. Principals are bound to the tenant of their token, only admins without a tenant claim reach every
+// tenant. Requests of the default tenant are rejected while tenancy is enabled.
+func ResolveTenant(tenancy config.Tenancy, requested string, principal *Principal) (string, error) {
+	if !tenancy.Enabled {
+		return "", nil
+	}
+
+	tenant := strings.ToLower(requested)
+	if principal != nil {
+		switch {
+		case principal.Tenant != "" && tenant != "" && tenant != principal.Tenant:
+			return "", errors.Wrapf(httpErrors.Forbidden, "token belongs to tenant {%s}", principal.Tenant)
+		case principal.Tenant != "":
+			tenant = principal.Tenant
+		case principal.Role != RoleAdmin:
+			return "", errors.Wrap(httpErrors.Forbidden, "token has no tenant")
+		}
+	}
+
+	if tenant == "" {
+		return "", errors.Wrap(httpErrors.BadRequest, "missing tenant")
+	}
+	if !tenancy.HasTenant(tenant) {
+		return "", errors.Wrapf(httpErrors.BadRequest, "unknown tenant {%s}", tenant)
+	}
+	return tenant, nil
+}
+
+// SubdomainTenant tenant named by the subdomain of host under domain, acme.shop.example.com is tenant acme.
+func SubdomainTenant(host string, domain string) string {
+	if domain == "" {
+		return ""
+	}
+	if hostname, _, err := net.SplitHostPort(host); err == nil {
+		host = hostname
+	}
+
+	subdomain, ok := strings.CutSuffix(strings.ToLower(host), "."+strings.ToLower(domain))
+	if !ok || strings.Contains(subdomain, ".") {
+		return ""
+	}
+	return subdomain
+}
@@ -5,6 +5,7 @@ const (
 	URI      = "URI"
 	STATUS   = "STATUS"
 	HTTP     = "HTTP"
+	GRPC     = "GRPC"
 	ERROR    = "ERROR"
 	METHOD   = "METHOD"
 	METADATA = "METADATA"
@@ -26,35 +27,90 @@ const (
 	CreatedDate  = "CreatedDate"
 	UserMetadata = "UserMetadata"
 
-	Page   = "page"
-	Size   = "size"
-	Search = "search"
-	ID     = "id"
+	Page        = "page"
+	Size        = "size"
+	Search      = "search"
+	ID          = "id"
+	LastEventID = "lastEventId"
+	SKU         = "sku"
+
+	Sort          = "sort"
+	SortOrder     = "order"
+	Cursor        = "cursor"
+	AccountEmail  = "accountEmail"
+	CreatedFrom   = "createdFrom"
+	CreatedTo     = "createdTo"
+	PaidFrom      = "paidFrom"
+	PaidTo        = "paidTo"
+	DeliveredFrom = "deliveredFrom"
+	DeliveredTo   = "deliveredTo"
+	MinTotal      = "minTotal"
+	MaxTotal      = "maxTotal"
 
 	EsAll = "$all"
 
 	Validate        = "validate"
 	FieldValidation = "field validation"
 	RequiredHeaders = "required header"
-	Base64          = "base64"
-	Unmarshal       = "unmarshal"
-	Uuid            = "uuid"
-	Cookie          = "cookie"
-	Token           = "token"
-	Bcrypt          = "bcrypt"
-	SQLState        = "sqlstate"
 
 	MongoProjection   = "(MongoDB Projection)"
 	ElasticProjection = "(Elastic Projection)"
 
-	OrderIdIndex    = "orderId"
-	OrderId         = "orderId"
-	DeliveryAddress = "deliveryAddress"
-	Submitted       = "submitted"
-	Completed       = "completed"
-	DeliveredTime   = "deliveredTime"
-	Payment         = "payment"
-	Paid            = "paid"
-	Canceled        = "canceled"
-	CancelReason    = "cancelReason"
+	OrderIdIndex     = "orderId"
+	OrderId          = "orderId"
+	DeliveryAddress  = "deliveryAddress"
+	Submitted        = "submitted"
+	Completed        = "completed"
+	DeliveredTime    = "deliveredTime"
+	Payment          = "payment"
+	Paid             = "paid"
+	Canceled         = "canceled"
+	CancelReason     = "cancelReason"
+	RejectReason     = "rejectReason"
+	PaymentReminders = "paymentReminders"
+	PaymentAttempts  = "paymentAttempts"
+	AttemptID        = "attemptId"
+	PaymentID        = "paymentId"
+	Refunds          = "refunds"
+	RefundedAmount   = "refundedAmount"
+	Refunded         = "refunded"
+	Returns          = "returns"
+	ReturnID         = "returnId"
+	Shipments        = "shipments"
+	ShipmentID       = "shipmentId"
+	Status           = "status"
+	City             = "city"
+	Country          = "country"
+	ShopItems        = "shopItems"
+	TotalPrice       = "totalPrice"
+	ItemID           = "itemId"
+	Code             = "code"
+	Coupons          = "coupons"
+	Discounts        = "discounts"
+	DiscountTotal    = "discountTotal"
+	Subtotal         = "subtotal"
+	ShippingPrice    = "shippingPrice"
+	TaxTotal         = "taxTotal"
+	Taxes            = "taxes"
+	TaxRegion        = "taxRegion"
+	Saga             = "saga"
+	CorrelationID    = "correlationId"
+	Deadline         = "deadline"
+	CustomerID       = "customerId"
+	Email            = "email"
+	AddressID        = "addressId"
+	ProductID        = "productId"
+	Discontinued     = "discontinued"
+	WebhookID        = "webhookId"
+	Disabled         = "disabled"
+	DisabledAt       = "disabledAt"
+	Failures         = "consecutiveFailures"
+	UpdatedAt        = "updatedAt"
+	AttemptedAt      = "attemptedAt"
+	NextAttemptAt    = "nextAttemptAt"
+	Payload          = "payload"
+	DeliveryID       = "deliveryId"
+	CreatedAt        = "createdAt"
+	Amount           = "amount"
+	Timestamp        = "timestamp"
 )
@@ -1,5 +1,16 @@
 package dto
 
+type Address struct {
+	Recipient  string `json:"recipient" validate:"required"`
+	Line1      string `json:"line1" validate:"required"`
+	Line2      string `json:"line2,omitempty"`
+	City       string `json:"city" validate:"required"`
+	PostalCode string `json:"postalCode,omitempty"`
+	Region     string `json:"region,omitempty"`
+	Country    string `json:"country" validate:"required,len=2"`
+	Phone      string `json:"phone,omitempty"`
+}
+
 type ChangeDeliveryAddressReqDto struct {
-	DeliveryAddress string `json:"deliveryAddress" bson:"deliveryAddress,omitempty" validate:"required"`
+	DeliveryAddress Address `json:"deliveryAddress" bson:"deliveryAddress,omitempty"`
 }
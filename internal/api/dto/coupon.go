@@ -0,0 +1,28 @@
+package dto
+
+import "time"
+
+type Coupon struct {
+	Code        string    `json:"code" validate:"required"`
+	Type        string    `json:"type" validate:"required,oneof=PERCENTAGE FIXED_AMOUNT BUY_X_GET_Y FREE_SHIPPING"`
+	Percentage  uint64    `json:"percentage,omitempty"`
+	Amount      Money     `json:"amount,omitempty"`
+	ShopItemID  string    `json:"shopItemId,omitempty"`
+	BuyQuantity uint64    `json:"buyQuantity,omitempty"`
+	GetQuantity uint64    `json:"getQuantity,omitempty"`
+	ExpiresAt   time.Time `json:"expiresAt,omitempty"`
+	Disabled    bool      `json:"disabled,omitempty"`
+}
+
+type CreateCouponReqDto struct {
+	Coupon
+}
+
+type ApplyCouponReqDto struct {
+	Code string `json:"code" validate:"required"`
+}
+
+type Discount struct {
+	Code   string `json:"code"`
+	Amount Money  `json:"amount"`
+}
@@ -0,0 +1,40 @@
+package dto
+
+import "time"
+
+type RegisterCustomerReqDto struct {
+	Email string `json:"email" validate:"required,email"`
+	Name  string `json:"name" validate:"required"`
+}
+
+type ChangeCustomerEmailReqDto struct {
+	Email string `json:"email" validate:"required,email"`
+}
+
+type SaveCustomerAddressReqDto struct {
+	Label   string  `json:"label,omitempty"`
+	Address Address `json:"address"`
+	Default bool    `json:"default"`
+}
+
+type CustomerPreferences struct {
+	Language       string `json:"language,omitempty"`
+	Currency       string `json:"currency,omitempty" validate:"omitempty,len=3"`
+	MarketingOptIn bool   `json:"marketingOptIn"`
+}
+
+type SavedAddress struct {
+	AddressID string  `json:"addressId"`
+	Label     string  `json:"label,omitempty"`
+	Address   Address `json:"address"`
+	Default   bool    `json:"default"`
+}
+
+type CustomerResponseDto struct {
+	CustomerID   string              `json:"customerId"`
+	Email        string              `json:"email"`
+	Name         string              `json:"name"`
+	Addresses    []SavedAddress      `json:"addresses"`
+	Preferences  CustomerPreferences `json:"preferences"`
+	RegisteredAt time.Time           `json:"registeredAt"`
+}
@@ -0,0 +1,7 @@
+package dto
+
+type GraphQLReqDto struct {
+	Query         string                 `json:"query" validate:"required"`
+	OperationName string                 `json:"operationName,omitempty"`
+	Variables     map[string]interface{} `json:"variables,omitempty"`
+}
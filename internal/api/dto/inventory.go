@@ -0,0 +1,12 @@
+package dto
+
+type AddStockReqDto struct {
+	Quantity uint64 `json:"quantity" validate:"required,gt=0"`
+}
+
+type InventoryResponseDto struct {
+	SKU       string `json:"sku"`
+	OnHand    uint64 `json:"onHand"`
+	Reserved  uint64 `json:"reserved"`
+	Available uint64 `json:"available"`
+}
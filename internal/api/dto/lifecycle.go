@@ -0,0 +1,12 @@
+package dto
+
+type OrderTransition struct {
+	Action string   `json:"action"`
+	From   []string `json:"from"`
+	To     string   `json:"to,omitempty"`
+}
+
+type OrderLifecycleResponseDto struct {
+	Statuses    []string          `json:"statuses"`
+	Transitions []OrderTransition `json:"transitions"`
+}
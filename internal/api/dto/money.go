@@ -0,0 +1,6 @@
+package dto
+
+type Money struct {
+	Amount   int64  `json:"amount" bson:"amount"`
+	Currency string `json:"currency" bson:"currency,omitempty"`
+}
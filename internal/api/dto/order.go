@@ -6,10 +6,13 @@ import (
 	"github.com/wassef911/eventually/internal/delivery/models"
 )
 
+// CreateOrderReqDto AccountEmail defaults to the email of the customer when CustomerID is given,
+// title and price of the items come from the product catalog.
 type CreateOrderReqDto struct {
-	ShopItems       []*models.ShopItem `json:"shopItems" bson:"shopItems,omitempty" validate:"required"`
-	AccountEmail    string             `json:"accountEmail" bson:"accountEmail,omitempty" validate:"required,email"`
-	DeliveryAddress string             `json:"deliveryAddress" bson:"deliveryAddress,omitempty" validate:"required"`
+	Items           []*models.OrderItem `json:"items" bson:"items,omitempty" validate:"required,min=1,dive,required"`
+	CustomerID      string              `json:"customerId,omitempty" bson:"customerId,omitempty" validate:"omitempty,uuid"`
+	AccountEmail    string              `json:"accountEmail" bson:"accountEmail,omitempty" validate:"required_without=CustomerID,omitempty,email"`
+	DeliveryAddress Address             `json:"deliveryAddress" bson:"deliveryAddress,omitempty"`
 }
 
 type CancelOrderReqDto struct {
@@ -17,18 +20,37 @@ type CancelOrderReqDto struct {
 }
 
 type OrderResponseDto struct {
-	ID              string     `json:"id" bson:"_id,omitempty"`
-	OrderID         string     `json:"orderId,omitempty" bson:"orderId,omitempty"`
-	ShopItems       []ShopItem `json:"shopItems,omitempty" bson:"shopItems,omitempty"`
-	AccountEmail    string     `json:"accountEmail,omitempty" bson:"accountEmail,omitempty" validate:"required,email"`
-	DeliveryAddress string     `json:"deliveryAddress,omitempty" bson:"deliveryAddress,omitempty"`
-	CancelReason    string     `json:"cancelReason,omitempty" bson:"cancelReason,omitempty"`
-	TotalPrice      float64    `json:"totalPrice,omitempty" bson:"totalPrice,omitempty"`
-	DeliveredTime   time.Time  `json:"deliveredTime,omitempty" bson:"deliveredTime,omitempty"`
-	Created         bool       `json:"created,omitempty" bson:"created,omitempty"`
-	Paid            bool       `json:"paid,omitempty" bson:"paid,omitempty"`
-	Submitted       bool       `json:"submitted,omitempty" bson:"submitted,omitempty"`
-	Completed       bool       `json:"completed,omitempty" bson:"completed,omitempty"`
-	Canceled        bool       `json:"canceled,omitempty" bson:"canceled,omitempty"`
-	Payment         Payment    `json:"payment,omitempty" bson:"payment,omitempty"`
+	ID               string           `json:"id" bson:"_id,omitempty"`
+	OrderID          string           `json:"orderId,omitempty" bson:"orderId,omitempty"`
+	ShopItems        []ShopItem       `json:"shopItems,omitempty" bson:"shopItems,omitempty"`
+	CustomerID       string           `json:"customerId,omitempty" bson:"customerId,omitempty"`
+	AccountEmail     string           `json:"accountEmail,omitempty" bson:"accountEmail,omitempty" validate:"required,email"`
+	DeliveryAddress  Address          `json:"deliveryAddress,omitempty" bson:"deliveryAddress,omitempty"`
+	CancelReason     string           `json:"cancelReason,omitempty" bson:"cancelReason,omitempty"`
+	RejectReason     string           `json:"rejectReason,omitempty" bson:"rejectReason,omitempty"`
+	Subtotal         Money            `json:"subtotal,omitempty" bson:"subtotal,omitempty"`
+	Discounts        []Discount       `json:"discounts,omitempty" bson:"discounts,omitempty"`
+	DiscountTotal    Money            `json:"discountTotal,omitempty" bson:"discountTotal,omitempty"`
+	ShippingPrice    Money            `json:"shippingPrice,omitempty" bson:"shippingPrice,omitempty"`
+	TaxTotal         Money            `json:"taxTotal,omitempty" bson:"taxTotal,omitempty"`
+	Taxes            []TaxLine        `json:"taxes,omitempty" bson:"taxes,omitempty"`
+	TaxRegion        string           `json:"taxRegion,omitempty" bson:"taxRegion,omitempty"`
+	Coupons          []Coupon         `json:"coupons,omitempty" bson:"coupons,omitempty"`
+	TotalPrice       Money            `json:"totalPrice,omitempty" bson:"totalPrice,omitempty"`
+	CreatedAt        time.Time        `json:"createdAt,omitempty" bson:"createdAt,omitempty"`
+	DeliveredTime    time.Time        `json:"deliveredTime,omitempty" bson:"deliveredTime,omitempty"`
+	Status           string           `json:"status,omitempty" bson:"status,omitempty"`
+	Created          bool             `json:"created,omitempty" bson:"created,omitempty"`
+	Paid             bool             `json:"paid,omitempty" bson:"paid,omitempty"`
+	Submitted        bool             `json:"submitted,omitempty" bson:"submitted,omitempty"`
+	Completed        bool             `json:"completed,omitempty" bson:"completed,omitempty"`
+	Canceled         bool             `json:"canceled,omitempty" bson:"canceled,omitempty"`
+	Payment          Payment          `json:"payment,omitempty" bson:"payment,omitempty"`
+	Refunds          []Refund         `json:"refunds,omitempty" bson:"refunds,omitempty"`
+	RefundedAmount   Money            `json:"refundedAmount,omitempty" bson:"refundedAmount,omitempty"`
+	Refunded         bool             `json:"refunded,omitempty" bson:"refunded,omitempty"`
+	Returns          []OrderReturn    `json:"returns,omitempty" bson:"returns,omitempty"`
+	Shipments        []Shipment       `json:"shipments,omitempty" bson:"shipments,omitempty"`
+	PaymentReminders int              `json:"paymentReminders,omitempty" bson:"paymentReminders,omitempty"`
+	PaymentAttempts  []PaymentAttempt `json:"paymentAttempts,omitempty" bson:"paymentAttempts,omitempty"`
 }
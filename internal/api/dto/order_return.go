@@ -0,0 +1,28 @@
+package dto
+
+import "time"
+
+type ReturnItem struct {
+	ShopItemID string `json:"shopItemId" validate:"required"`
+	Quantity   uint64 `json:"quantity" validate:"required,gt=0"`
+}
+
+type RequestReturnReqDto struct {
+	Items  []ReturnItem `json:"items" validate:"required,min=1,dive"`
+	Reason string       `json:"reason" validate:"required"`
+}
+
+type RejectReturnReqDto struct {
+	RejectReason string `json:"rejectReason" validate:"required"`
+}
+
+type OrderReturn struct {
+	ReturnID     string       `json:"returnId"`
+	Items        []ReturnItem `json:"items"`
+	Reason       string       `json:"reason"`
+	Status       string       `json:"status"`
+	RejectReason string       `json:"rejectReason,omitempty"`
+	RefundID     string       `json:"refundId,omitempty"`
+	RequestedAt  time.Time    `json:"requestedAt"`
+	ReceivedAt   time.Time    `json:"receivedAt,omitempty"`
+}
@@ -2,7 +2,24 @@ package dto
 
 import "time"
 
+// PayOrderReqDto the token is issued by the payment provider when the customer enters their payment method.
+type PayOrderReqDto struct {
+	PaymentToken string `json:"paymentToken" validate:"required"`
+}
+
 type Payment struct {
-	PaymentID string    `json:"paymentID" bson:"paymentID,omitempty" validate:"required"`
-	Timestamp time.Time `json:"timestamp" bson:"timestamp,omitempty" validate:"required"`
+	PaymentID string    `json:"paymentID" bson:"paymentID,omitempty"`
+	Timestamp time.Time `json:"timestamp" bson:"timestamp,omitempty"`
+	Provider  string    `json:"provider,omitempty" bson:"provider,omitempty"`
+	Amount    Money     `json:"amount,omitempty" bson:"amount,omitempty"`
+}
+
+type PaymentAttempt struct {
+	AttemptID     string    `json:"attemptId"`
+	PaymentID     string    `json:"paymentId,omitempty"`
+	Provider      string    `json:"provider"`
+	Amount        Money     `json:"amount"`
+	Status        string    `json:"status"`
+	FailureReason string    `json:"failureReason,omitempty"`
+	Timestamp     time.Time `json:"timestamp"`
 }
@@ -0,0 +1,36 @@
+package dto
+
+import "time"
+
+type CreateProductReqDto struct {
+	ProductID   string `json:"productId" validate:"required"`
+	Title       string `json:"title" validate:"required"`
+	Description string `json:"description"`
+	Price       Money  `json:"price" validate:"required"`
+	TaxCategory string `json:"taxCategory,omitempty"`
+}
+
+type RepriceProductReqDto struct {
+	Price Money `json:"price" validate:"required"`
+}
+
+type DiscontinueProductReqDto struct {
+	Reason string `json:"reason,omitempty"`
+}
+
+type ProductResponseDto struct {
+	ProductID      string     `json:"productId"`
+	Title          string     `json:"title"`
+	Description    string     `json:"description"`
+	Price          Money      `json:"price"`
+	TaxCategory    string     `json:"taxCategory,omitempty"`
+	Discontinued   bool       `json:"discontinued"`
+	DiscontinuedAt *time.Time `json:"discontinuedAt,omitempty"`
+	CreatedAt      time.Time  `json:"createdAt"`
+	UpdatedAt      time.Time  `json:"updatedAt"`
+}
+
+type ProductListResponseDto struct {
+	Pagination Pagination           `json:"pagination"`
+	Products   []ProductResponseDto `json:"products"`
+}
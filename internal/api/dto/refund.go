@@ -0,0 +1,23 @@
+package dto
+
+import "time"
+
+type RefundItem struct {
+	ShopItemID string `json:"shopItemId" validate:"required"`
+	Quantity   uint64 `json:"quantity" validate:"required,gt=0"`
+}
+
+// RefundOrderReqDto refunds the given line items, the given amount, or the whole remaining paid amount when both are empty.
+type RefundOrderReqDto struct {
+	Items  []RefundItem `json:"items,omitempty" validate:"omitempty,dive"`
+	Amount *Money       `json:"amount,omitempty"`
+	Reason string       `json:"reason" validate:"required"`
+}
+
+type Refund struct {
+	RefundID  string       `json:"refundId"`
+	Amount    Money        `json:"amount"`
+	Items     []RefundItem `json:"items,omitempty"`
+	Reason    string       `json:"reason"`
+	Timestamp time.Time    `json:"timestamp"`
+}
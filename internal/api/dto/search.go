@@ -12,3 +12,10 @@ type Pagination struct {
 	Size       int64 `json:"size"`
 	HasMore    bool  `json:"hasMore"`
 }
+
+type OrderListResponseDto struct {
+	Orders     []OrderResponseDto `json:"orders"`
+	Size       int64              `json:"size"`
+	HasMore    bool               `json:"hasMore"`
+	NextCursor string             `json:"nextCursor,omitempty"`
+}
@@ -0,0 +1,29 @@
+package dto
+
+import "time"
+
+type ShipmentItem struct {
+	ShopItemID string `json:"shopItemId" validate:"required"`
+	Quantity   uint64 `json:"quantity" validate:"required,gt=0"`
+}
+
+type CreateShipmentReqDto struct {
+	Items []ShipmentItem `json:"items" validate:"required,min=1,dive"`
+}
+
+type DispatchShipmentReqDto struct {
+	Carrier        string `json:"carrier" validate:"required"`
+	TrackingNumber string `json:"trackingNumber" validate:"required"`
+}
+
+type Shipment struct {
+	ShipmentID     string         `json:"shipmentId"`
+	Items          []ShipmentItem `json:"items"`
+	Status         string         `json:"status"`
+	Carrier        string         `json:"carrier,omitempty"`
+	TrackingNumber string         `json:"trackingNumber,omitempty"`
+	CreatedAt      time.Time      `json:"createdAt"`
+	PackedAt       time.Time      `json:"packedAt,omitempty"`
+	DispatchedAt   time.Time      `json:"dispatchedAt,omitempty"`
+	DeliveredAt    time.Time      `json:"deliveredAt,omitempty"`
+}
@@ -3,13 +3,22 @@ package dto
 import "github.com/wassef911/eventually/internal/delivery/models"
 
 type ShopItem struct {
-	ID          string  `json:"id" bson:"id,omitempty"`
-	Title       string  `json:"title" bson:"title,omitempty"`
-	Description string  `json:"description" bson:"description,omitempty"`
-	Quantity    uint64  `json:"quantity" bson:"quantity,omitempty"`
-	Price       float64 `json:"price" bson:"price,omitempty"`
+	ID          string `json:"id" bson:"id,omitempty"`
+	Title       string `json:"title" bson:"title,omitempty"`
+	Description string `json:"description" bson:"description,omitempty"`
+	Quantity    uint64 `json:"quantity" bson:"quantity,omitempty"`
+	Price       Money  `json:"price" bson:"price,omitempty"`
+	TaxCategory string `json:"taxCategory,omitempty" bson:"taxCategory,omitempty"`
 }
 
 type UpdateShoppingItemsReqDto struct {
-	ShopItems []*models.ShopItem `json:"shopItems" bson:"shopItems,omitempty" validate:"required"`
+	Items []*models.OrderItem `json:"items" bson:"items,omitempty" validate:"required,min=1,dive,required"`
+}
+
+type AddShopItemReqDto struct {
+	Item *models.OrderItem `json:"item" validate:"required"`
+}
+
+type ChangeItemQuantityReqDto struct {
+	Quantity uint64 `json:"quantity" validate:"required,gt=0"`
 }
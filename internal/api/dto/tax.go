@@ -0,0 +1,8 @@
+package dto
+
+type TaxLine struct {
+	ShopItemID  string `json:"shopItemId"`
+	TaxCategory string `json:"taxCategory"`
+	Rate        uint64 `json:"rate"`
+	Amount      Money  `json:"amount"`
+}
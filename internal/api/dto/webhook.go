@@ -0,0 +1,39 @@
+package dto
+
+import "time"
+
+// CreateWebhookReqDto EventTypes are integration event types (e.g. order.paid), every event is sent when empty.
+type CreateWebhookReqDto struct {
+	URL        string   `json:"url" validate:"required,url"`
+	EventTypes []string `json:"eventTypes,omitempty" validate:"omitempty,dive,required"`
+	Secret     string   `json:"secret" validate:"required,min=16"`
+}
+
+type WebhookResponseDto struct {
+	WebhookID           string     `json:"webhookId"`
+	URL                 string     `json:"url"`
+	EventTypes          []string   `json:"eventTypes,omitempty"`
+	Disabled            bool       `json:"disabled"`
+	DisabledAt          *time.Time `json:"disabledAt,omitempty"`
+	ConsecutiveFailures int        `json:"consecutiveFailures"`
+	CreatedAt           time.Time  `json:"createdAt"`
+	UpdatedAt           time.Time  `json:"updatedAt"`
+}
+
+type WebhookDeliveryResponseDto struct {
+	DeliveryID    string     `json:"deliveryId"`
+	EventID       string     `json:"eventId"`
+	EventType     string     `json:"eventType"`
+	Attempt       int        `json:"attempt"`
+	StatusCode    int        `json:"statusCode,omitempty"`
+	Error         string     `json:"error,omitempty"`
+	Succeeded     bool       `json:"succeeded"`
+	DurationMs    int64      `json:"durationMs"`
+	AttemptedAt   time.Time  `json:"attemptedAt"`
+	NextAttemptAt *time.Time `json:"nextAttemptAt,omitempty"`
+}
+
+type WebhookDeliveryListResponseDto struct {
+	Pagination Pagination                   `json:"pagination"`
+	Deliveries []WebhookDeliveryResponseDto `json:"deliveries"`
+}
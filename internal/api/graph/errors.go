@@ -0,0 +1,120 @@
+package graph
+
+import (
+	"context"
+
+	"github.com/go-playground/validator"
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"github.com/wassef911/eventually/internal/delivery/aggregate"
+	"github.com/wassef911/eventually/internal/delivery/domain"
+	"github.com/wassef911/eventually/internal/delivery/models"
+	"github.com/wassef911/eventually/internal/delivery/payment"
+	httpErrors "github.com/wassef911/eventually/pkg/errors"
+)
+
+// Error codes sent in the extensions of the GraphQL errors.
+const (
+	CodeBadUserInput       = "BAD_USER_INPUT"
+	CodeUnauthenticated    = "UNAUTHENTICATED"
+	CodeForbidden          = "FORBIDDEN"
+	CodeNotFound           = "NOT_FOUND"
+	CodeConflict           = "CONFLICT"
+	CodeFailedPrecondition = "FAILED_PRECONDITION"
+	CodeTimeout            = "TIMEOUT"
+	CodeInternal           = "INTERNAL_SERVER_ERROR"
+)
+
+var errInvalidArgument = errors.New("invalid argument")
+
+// errorCodes domain errors and their code, the first match wins.
+var errorCodes = []struct {
+	err  error
+	code string
+}{
+	{err: context.DeadlineExceeded, code: CodeTimeout},
+	{err: errInvalidArgument, code: CodeBadUserInput},
+	{err: httpErrors.Unauthorized, code: CodeUnauthenticated},
+	{err: httpErrors.Forbidden, code: CodeForbidden},
+
+	{err: aggregate.ErrOrderNotFound, code: CodeNotFound},
+	{err: mongo.ErrNoDocuments, code: CodeNotFound},
+
+	{err: aggregate.ErrAlreadyCreated, code: CodeConflict},
+
+	{err: aggregate.ErrInvalidStatusTransition, code: CodeFailedPrecondition},
+	{err: aggregate.ErrAlreadyPaid, code: CodeFailedPrecondition},
+	{err: aggregate.ErrAlreadySubmitted, code: CodeFailedPrecondition},
+	{err: aggregate.ErrOrderAlreadyCompleted, code: CodeFailedPrecondition},
+	{err: aggregate.ErrOrderAlreadyCanceled, code: CodeFailedPrecondition},
+	{err: aggregate.ErrOrderAlreadyCancelled, code: CodeFailedPrecondition},
+	{err: aggregate.ErrOrderNotPaid, code: CodeFailedPrecondition},
+	{err: aggregate.ErrOrderMustBePaidBeforeDelivered, code: CodeFailedPrecondition},
+	{err: aggregate.ErrPaymentPending, code: CodeFailedPrecondition},
+	{err: payment.ErrPaymentDeclined, code: CodeFailedPrecondition},
+
+	{err: aggregate.ErrCancelReasonRequired, code: CodeBadUserInput},
+	{err: aggregate.ErrOrderShopItemsIsRequired, code: CodeBadUserInput},
+	{err: aggregate.ErrInvalidDeliveryAddress, code: CodeBadUserInput},
+	{err: models.ErrInvalidAddress, code: CodeBadUserInput},
+	{err: aggregate.ErrOrderCurrencyMismatch, code: CodeBadUserInput},
+	{err: aggregate.ErrTooManyShopItems, code: CodeBadUserInput},
+	{err: aggregate.ErrInvalidShopItemQuantity, code: CodeBadUserInput},
+	{err: aggregate.ErrDuplicateShopItem, code: CodeBadUserInput},
+	{err: aggregate.ErrProductNotFound, code: CodeBadUserInput},
+	{err: aggregate.ErrProductDiscontinued, code: CodeBadUserInput},
+}
+
+// domainCodes code of the domain errors missing from errorCodes.
+var domainCodes = map[domain.Kind]string{
+	domain.KindNotFound: CodeNotFound,
+	domain.KindConflict: CodeFailedPrecondition,
+	domain.KindInvalid:  CodeBadUserInput,
+}
+
+// resolverError is listed in the errors of the response with its code in the extensions.
+type resolverError struct {
+	code    string
+	message string
+	err     error
+}
+
+func (e *resolverError) Error() string {
+	return e.message
+}
+
+func (e *resolverError) Unwrap() error {
+	return e.err
+}
+
+func (e *resolverError) Extensions() map[string]interface{} {
+	return map[string]interface{}{"code": e.code}
+}
+
+// resolveError classifies err, internal errors only carry their message in debug mode.
+func resolveError(err error, debug bool) error {
+	if err == nil {
+		return nil
+	}
+
+	var validationErrors validator.ValidationErrors
+	if errors.As(err, &validationErrors) {
+		return &resolverError{code: CodeBadUserInput, message: validationErrors.Error(), err: err}
+	}
+
+	for _, errorCode := range errorCodes {
+		if errors.Is(err, errorCode.err) {
+			return &resolverError{code: errorCode.code, message: err.Error(), err: err}
+		}
+	}
+
+	if domainErr, ok := domain.AsError(err); ok {
+		return &resolverError{code: domainCodes[domainErr.Kind], message: err.Error(), err: err}
+	}
+
+	if debug {
+		return &resolverError{code: CodeInternal, message: err.Error(), err: err}
+	}
+	return &resolverError{code: CodeInternal, message: "internal error", err: err}
+}
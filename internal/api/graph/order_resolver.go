@@ -0,0 +1,297 @@
+package graph
+
+import (
+	"context"
+
+	graphql "github.com/graph-gophers/graphql-go"
+	"github.com/opentracing/opentracing-go"
+
+	"github.com/wassef911/eventually/internal/api/dto"
+	"github.com/wassef911/eventually/internal/delivery/queries"
+	"github.com/wassef911/eventually/internal/infrastructure/es"
+)
+
+type orderResolver struct {
+	root  *Resolver
+	order dto.OrderResponseDto
+}
+
+func (r *orderResolver) ID() graphql.ID {
+	return graphql.ID(r.order.OrderID)
+}
+
+func (r *orderResolver) CustomerID() *string {
+	return optional(r.order.CustomerID)
+}
+
+func (r *orderResolver) AccountEmail() string {
+	return r.order.AccountEmail
+}
+
+func (r *orderResolver) Status() string {
+	return r.order.Status
+}
+
+func (r *orderResolver) ShopItems() []*shopItemResolver {
+	result := make([]*shopItemResolver, 0, len(r.order.ShopItems))
+	for _, item := range r.order.ShopItems {
+		result = append(result, &shopItemResolver{item: item})
+	}
+	return result
+}
+
+func (r *orderResolver) DeliveryAddress() *addressResolver {
+	return &addressResolver{address: r.order.DeliveryAddress}
+}
+
+func (r *orderResolver) Subtotal() *moneyResolver {
+	return &moneyResolver{money: r.order.Subtotal}
+}
+
+func (r *orderResolver) DiscountTotal() *moneyResolver {
+	return &moneyResolver{money: r.order.DiscountTotal}
+}
+
+func (r *orderResolver) ShippingPrice() *moneyResolver {
+	return &moneyResolver{money: r.order.ShippingPrice}
+}
+
+func (r *orderResolver) TaxTotal() *moneyResolver {
+	return &moneyResolver{money: r.order.TaxTotal}
+}
+
+func (r *orderResolver) TotalPrice() *moneyResolver {
+	return &moneyResolver{money: r.order.TotalPrice}
+}
+
+func (r *orderResolver) RefundedAmount() *moneyResolver {
+	return &moneyResolver{money: r.order.RefundedAmount}
+}
+
+func (r *orderResolver) CancelReason() *string {
+	return optional(r.order.CancelReason)
+}
+
+func (r *orderResolver) RejectReason() *string {
+	return optional(r.order.RejectReason)
+}
+
+func (r *orderResolver) Paid() bool {
+	return r.order.Paid
+}
+
+func (r *orderResolver) Submitted() bool {
+	return r.order.Submitted
+}
+
+func (r *orderResolver) Completed() bool {
+	return r.order.Completed
+}
+
+func (r *orderResolver) Canceled() bool {
+	return r.order.Canceled
+}
+
+func (r *orderResolver) Refunded() bool {
+	return r.order.Refunded
+}
+
+func (r *orderResolver) Payment() *paymentResolver {
+	if r.order.Payment.PaymentID == "" {
+		return nil
+	}
+	return &paymentResolver{payment: r.order.Payment}
+}
+
+func (r *orderResolver) DeliveredTime() *graphql.Time {
+	if r.order.DeliveredTime.IsZero() {
+		return nil
+	}
+	return &graphql.Time{Time: r.order.DeliveredTime}
+}
+
+// Events reads the history from the event store, only when the field is selected.
+func (r *orderResolver) Events(ctx context.Context) ([]*orderEventResolver, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "orderResolver.Events")
+	defer span.Finish()
+
+	events, err := r.root.os.Queries.GetOrderEvents.Handle(ctx, queries.NewGetOrderEventsQuery(r.order.OrderID))
+	if err != nil {
+		return nil, r.root.error(err)
+	}
+
+	result := make([]*orderEventResolver, 0, len(events))
+	for _, event := range events {
+		result = append(result, &orderEventResolver{event: event})
+	}
+	return result, nil
+}
+
+type shopItemResolver struct {
+	item dto.ShopItem
+}
+
+func (r *shopItemResolver) ID() string {
+	return r.item.ID
+}
+
+func (r *shopItemResolver) Title() string {
+	return r.item.Title
+}
+
+func (r *shopItemResolver) Description() string {
+	return r.item.Description
+}
+
+func (r *shopItemResolver) Quantity() Int64 {
+	return Int64(r.item.Quantity)
+}
+
+func (r *shopItemResolver) Price() *moneyResolver {
+	return &moneyResolver{money: r.item.Price}
+}
+
+func (r *shopItemResolver) TaxCategory() *string {
+	return optional(r.item.TaxCategory)
+}
+
+type moneyResolver struct {
+	money dto.Money
+}
+
+func (r *moneyResolver) Amount() Int64 {
+	return Int64(r.money.Amount)
+}
+
+func (r *moneyResolver) Currency() string {
+	return r.money.Currency
+}
+
+type addressResolver struct {
+	address dto.Address
+}
+
+func (r *addressResolver) Recipient() string {
+	return r.address.Recipient
+}
+
+func (r *addressResolver) Line1() string {
+	return r.address.Line1
+}
+
+func (r *addressResolver) Line2() *string {
+	return optional(r.address.Line2)
+}
+
+func (r *addressResolver) City() string {
+	return r.address.City
+}
+
+func (r *addressResolver) PostalCode() *string {
+	return optional(r.address.PostalCode)
+}
+
+func (r *addressResolver) Region() *string {
+	return optional(r.address.Region)
+}
+
+func (r *addressResolver) Country() string {
+	return r.address.Country
+}
+
+func (r *addressResolver) Phone() *string {
+	return optional(r.address.Phone)
+}
+
+type paymentResolver struct {
+	payment dto.Payment
+}
+
+func (r *paymentResolver) PaymentID() string {
+	return r.payment.PaymentID
+}
+
+func (r *paymentResolver) Provider() *string {
+	return optional(r.payment.Provider)
+}
+
+func (r *paymentResolver) Amount() *moneyResolver {
+	return &moneyResolver{money: r.payment.Amount}
+}
+
+func (r *paymentResolver) Timestamp() graphql.Time {
+	return graphql.Time{Time: r.payment.Timestamp}
+}
+
+type orderEventResolver struct {
+	event es.Event
+}
+
+func (r *orderEventResolver) ID() graphql.ID {
+	return graphql.ID(r.event.GetEventID())
+}
+
+func (r *orderEventResolver) Type() string {
+	return r.event.GetEventType()
+}
+
+func (r *orderEventResolver) Version() Int64 {
+	return Int64(r.event.GetVersion())
+}
+
+func (r *orderEventResolver) Timestamp() graphql.Time {
+	return graphql.Time{Time: r.event.GetTimeStamp()}
+}
+
+func (r *orderEventResolver) Data() string {
+	return string(r.event.GetData())
+}
+
+type orderSearchResolver struct {
+	root   *Resolver
+	result *dto.OrderSearchResponseDto
+}
+
+func (r *orderSearchResolver) Pagination() *paginationResolver {
+	return &paginationResolver{pagination: r.result.Pagination}
+}
+
+func (r *orderSearchResolver) Orders() []*orderResolver {
+	result := make([]*orderResolver, 0, len(r.result.Orders))
+	for _, order := range r.result.Orders {
+		result = append(result, &orderResolver{root: r.root, order: order})
+	}
+	return result
+}
+
+type paginationResolver struct {
+	pagination dto.Pagination
+}
+
+func (r *paginationResolver) TotalCount() Int64 {
+	return Int64(r.pagination.TotalCount)
+}
+
+func (r *paginationResolver) TotalPages() Int64 {
+	return Int64(r.pagination.TotalPages)
+}
+
+func (r *paginationResolver) Page() Int64 {
+	return Int64(r.pagination.Page)
+}
+
+func (r *paginationResolver) Size() Int64 {
+	return Int64(r.pagination.Size)
+}
+
+func (r *paginationResolver) HasMore() bool {
+	return r.pagination.HasMore
+}
+
+// optional empty strings resolve to null.
+func optional(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
@@ -0,0 +1,405 @@
+// Package graph serves the orders over GraphQL, queries and mutations go through the order service like the REST api.
+package graph
+
+import (
+	"context"
+	_ "embed"
+	"time"
+
+	"github.com/go-playground/validator"
+	graphql "github.com/graph-gophers/graphql-go"
+	gqlTracing "github.com/graph-gophers/graphql-go/trace/opentracing"
+	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+	uuid "github.com/satori/go.uuid"
+
+	"github.com/wassef911/eventually/internal/api/auth"
+	"github.com/wassef911/eventually/internal/api/dto"
+	"github.com/wassef911/eventually/internal/api/utils"
+	"github.com/wassef911/eventually/internal/delivery/commands"
+	"github.com/wassef911/eventually/internal/delivery/models"
+	"github.com/wassef911/eventually/internal/delivery/queries"
+	service "github.com/wassef911/eventually/internal/delivery/services"
+	"github.com/wassef911/eventually/pkg/config"
+	"github.com/wassef911/eventually/pkg/logger"
+)
+
+const (
+	maxDepth       = 10
+	maxParallelism = 10
+)
+
+//go:embed schema.graphql
+var schemaString string
+
+// NewSchema parses the order schema, resolvers are traced per field.
+func NewSchema(log logger.Logger, config *config.Config, v *validator.Validate, os *service.OrderService) (*graphql.Schema, error) {
+	schema, err := graphql.ParseSchema(
+		schemaString,
+		NewResolver(log, config, v, os),
+		graphql.Tracer(gqlTracing.Tracer{}),
+		graphql.MaxDepth(maxDepth),
+		graphql.MaxParallelism(maxParallelism),
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "graphql.ParseSchema")
+	}
+	return schema, nil
+}
+
+// Resolver root of the Query and Mutation types.
+type Resolver struct {
+	log    logger.Logger
+	config *config.Config
+	v      *validator.Validate
+	os     *service.OrderService
+}
+
+func NewResolver(log logger.Logger, config *config.Config, v *validator.Validate, os *service.OrderService) *Resolver {
+	return &Resolver{log: log, config: config, v: v, os: os}
+}
+
+type orderItemInput struct {
+	ProductID string
+	Quantity  Int64
+}
+
+type addressInput struct {
+	Recipient  string
+	Line1      string
+	Line2      *string
+	City       string
+	PostalCode *string
+	Region     *string
+	Country    string
+	Phone      *string
+}
+
+type createOrderInput struct {
+	Items           []orderItemInput
+	CustomerID      *string
+	AccountEmail    *string
+	DeliveryAddress addressInput
+}
+
+func (r *Resolver) Order(ctx context.Context, args struct{ ID graphql.ID }) (*orderResolver, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "Resolver.Order")
+	defer span.Finish()
+
+	if _, err := auth.Authorize(ctx, auth.PermissionReadOrder); err != nil {
+		return nil, r.error(err)
+	}
+
+	orderID, err := parseOrderID(args.ID)
+	if err != nil {
+		return nil, r.error(err)
+	}
+
+	query := queries.NewGetOrderByIDQuery(orderID)
+	if err := r.v.StructCtx(ctx, query); err != nil {
+		return nil, r.error(err)
+	}
+
+	orderProjection, err := r.os.Queries.GetOrderByID.Handle(ctx, query)
+	if err != nil {
+		return nil, r.error(err)
+	}
+	if err := auth.AuthorizeOrder(ctx, auth.PermissionReadOrder, orderProjection.AccountEmail, orderProjection.CustomerID); err != nil {
+		return nil, r.error(err)
+	}
+
+	return &orderResolver{root: r, order: utils.OrderResponseFrom(orderProjection)}, nil
+}
+
+func (r *Resolver) SearchOrders(ctx context.Context, args struct {
+	Search  *string
+	Status  *string
+	City    *string
+	Country *string
+	Page    *int32
+	Size    *int32
+}) (*orderSearchResolver, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "Resolver.SearchOrders")
+	defer span.Finish()
+
+	if _, err := auth.Authorize(ctx, auth.PermissionSearchOrders); err != nil {
+		return nil, r.error(err)
+	}
+
+	pq := utils.NewPaginationQuery(int(valueOf(args.Size)), int(valueOf(args.Page)))
+	if pq.Page == 0 {
+		pq.Page = 1
+	}
+
+	query := queries.NewSearchOrdersQuery(valueOf(args.Search), valueOf(args.Status), valueOf(args.City), valueOf(args.Country), pq)
+	if err := r.v.StructCtx(ctx, query); err != nil {
+		return nil, r.error(err)
+	}
+
+	searchRes, err := r.os.Queries.SearchOrders.Handle(ctx, query)
+	if err != nil {
+		return nil, r.error(err)
+	}
+
+	return &orderSearchResolver{root: r, result: searchRes}, nil
+}
+
+func (r *Resolver) CreateOrder(ctx context.Context, args struct{ Input createOrderInput }) (graphql.ID, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "Resolver.CreateOrder")
+	defer span.Finish()
+
+	if _, err := auth.Authorize(ctx, auth.PermissionPlaceOrder); err != nil {
+		return "", r.error(err)
+	}
+
+	accountEmail, err := auth.OrderAccountEmail(ctx, valueOf(args.Input.AccountEmail))
+	if err != nil {
+		return "", r.error(err)
+	}
+
+	reqDto := dto.CreateOrderReqDto{
+		Items:           orderItemsFromInput(args.Input.Items),
+		CustomerID:      valueOf(args.Input.CustomerID),
+		AccountEmail:    accountEmail,
+		DeliveryAddress: addressFromInput(args.Input.DeliveryAddress),
+	}
+	if err := r.v.StructCtx(ctx, reqDto); err != nil {
+		return "", r.error(err)
+	}
+
+	id := uuid.NewV4().String()
+	command := commands.NewCreateOrderCommand(id, reqDto.Items, reqDto.CustomerID, reqDto.AccountEmail, utils.AddressFromDto(reqDto.DeliveryAddress))
+	if err := r.os.Commands.CreateOrder.Handle(ctx, command); err != nil {
+		return "", r.error(err)
+	}
+
+	return graphql.ID(id), nil
+}
+
+func (r *Resolver) PayOrder(ctx context.Context, args struct {
+	ID           graphql.ID
+	PaymentToken string
+}) (graphql.ID, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "Resolver.PayOrder")
+	defer span.Finish()
+
+	orderID, err := parseOrderID(args.ID)
+	if err != nil {
+		return "", r.error(err)
+	}
+
+	if err := r.authorizeOrder(ctx, auth.PermissionPlaceOrder, orderID); err != nil {
+		return "", r.error(err)
+	}
+
+	command := commands.NewPayOrderCommand(orderID, args.PaymentToken)
+	if err := r.v.StructCtx(ctx, command); err != nil {
+		return "", r.error(err)
+	}
+
+	if err := r.os.Commands.OrderPaid.Handle(ctx, command); err != nil {
+		return "", r.error(err)
+	}
+
+	return graphql.ID(orderID), nil
+}
+
+func (r *Resolver) SubmitOrder(ctx context.Context, args struct{ ID graphql.ID }) (graphql.ID, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "Resolver.SubmitOrder")
+	defer span.Finish()
+
+	orderID, err := parseOrderID(args.ID)
+	if err != nil {
+		return "", r.error(err)
+	}
+
+	if err := r.authorizeOrder(ctx, auth.PermissionPlaceOrder, orderID); err != nil {
+		return "", r.error(err)
+	}
+
+	command := commands.NewSubmitOrderCommand(orderID)
+	if err := r.v.StructCtx(ctx, command); err != nil {
+		return "", r.error(err)
+	}
+
+	if err := r.os.Commands.SubmitOrder.Handle(ctx, command); err != nil {
+		return "", r.error(err)
+	}
+
+	return graphql.ID(orderID), nil
+}
+
+func (r *Resolver) UpdateShoppingCart(ctx context.Context, args struct {
+	ID    graphql.ID
+	Items []orderItemInput
+}) (graphql.ID, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "Resolver.UpdateShoppingCart")
+	defer span.Finish()
+
+	orderID, err := parseOrderID(args.ID)
+	if err != nil {
+		return "", r.error(err)
+	}
+
+	if err := r.authorizeOrder(ctx, auth.PermissionPlaceOrder, orderID); err != nil {
+		return "", r.error(err)
+	}
+
+	reqDto := dto.UpdateShoppingItemsReqDto{Items: orderItemsFromInput(args.Items)}
+	if err := r.v.StructCtx(ctx, reqDto); err != nil {
+		return "", r.error(err)
+	}
+
+	command := commands.NewUpdateShoppingCartCommand(orderID, reqDto.Items)
+	if err := r.os.Commands.UpdateOrder.Handle(ctx, command); err != nil {
+		return "", r.error(err)
+	}
+
+	return graphql.ID(orderID), nil
+}
+
+func (r *Resolver) CancelOrder(ctx context.Context, args struct {
+	ID           graphql.ID
+	CancelReason string
+}) (graphql.ID, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "Resolver.CancelOrder")
+	defer span.Finish()
+
+	orderID, err := parseOrderID(args.ID)
+	if err != nil {
+		return "", r.error(err)
+	}
+
+	if err := r.authorizeOrder(ctx, auth.PermissionCancelOrder, orderID); err != nil {
+		return "", r.error(err)
+	}
+
+	command := commands.NewCancelOrderCommand(orderID, args.CancelReason)
+	if err := r.v.StructCtx(ctx, command); err != nil {
+		return "", r.error(err)
+	}
+
+	if err := r.os.Commands.CancelOrder.Handle(ctx, command); err != nil {
+		return "", r.error(err)
+	}
+
+	return graphql.ID(orderID), nil
+}
+
+func (r *Resolver) CompleteOrder(ctx context.Context, args struct{ ID graphql.ID }) (graphql.ID, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "Resolver.CompleteOrder")
+	defer span.Finish()
+
+	orderID, err := parseOrderID(args.ID)
+	if err != nil {
+		return "", r.error(err)
+	}
+
+	if err := r.authorizeOrder(ctx, auth.PermissionFulfilOrder, orderID); err != nil {
+		return "", r.error(err)
+	}
+
+	command := commands.NewCompleteOrderCommand(orderID, time.Now())
+	if err := r.v.StructCtx(ctx, command); err != nil {
+		return "", r.error(err)
+	}
+
+	if err := r.os.Commands.CompleteOrder.Handle(ctx, command); err != nil {
+		return "", r.error(err)
+	}
+
+	return graphql.ID(orderID), nil
+}
+
+func (r *Resolver) ChangeDeliveryAddress(ctx context.Context, args struct {
+	ID              graphql.ID
+	DeliveryAddress addressInput
+}) (graphql.ID, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "Resolver.ChangeDeliveryAddress")
+	defer span.Finish()
+
+	orderID, err := parseOrderID(args.ID)
+	if err != nil {
+		return "", r.error(err)
+	}
+
+	if err := r.authorizeOrder(ctx, auth.PermissionPlaceOrder, orderID); err != nil {
+		return "", r.error(err)
+	}
+
+	reqDto := dto.ChangeDeliveryAddressReqDto{DeliveryAddress: addressFromInput(args.DeliveryAddress)}
+	if err := r.v.StructCtx(ctx, reqDto); err != nil {
+		return "", r.error(err)
+	}
+
+	command := commands.NewChangeDeliveryAddressCommand(orderID, utils.AddressFromDto(reqDto.DeliveryAddress))
+	if err := r.v.StructCtx(ctx, command); err != nil {
+		return "", r.error(err)
+	}
+
+	if err := r.os.Commands.ChangeOrderDeliveryAddress.Handle(ctx, command); err != nil {
+		return "", r.error(err)
+	}
+
+	return graphql.ID(orderID), nil
+}
+
+// authorizeOrder checks the permission, customers also have to own the order.
+func (r *Resolver) authorizeOrder(ctx context.Context, permission auth.Permission, orderID string) error {
+	principal, err := auth.Authorize(ctx, permission)
+	if err != nil || !principal.OwnsOrdersOnly() {
+		return err
+	}
+
+	orderProjection, err := r.os.Queries.GetOrderByID.Handle(ctx, queries.NewGetOrderByIDQuery(orderID))
+	if err != nil {
+		return err
+	}
+	return auth.AuthorizeOrder(ctx, permission, orderProjection.AccountEmail, orderProjection.CustomerID)
+}
+
+func (r *Resolver) error(err error) error {
+	return resolveError(err, r.config.Logger.Debug)
+}
+
+func parseOrderID(id graphql.ID) (string, error) {
+	orderID, err := uuid.FromString(string(id))
+	if err != nil {
+		return "", errors.Wrap(errInvalidArgument, err.Error())
+	}
+	return orderID.String(), nil
+}
+
+func orderItemsFromInput(items []orderItemInput) []*models.OrderItem {
+	result := make([]*models.OrderItem, 0, len(items))
+	for _, item := range items {
+		// negative quantities are left to the validation instead of wrapping around
+		quantity := uint64(0)
+		if item.Quantity > 0 {
+			quantity = uint64(item.Quantity)
+		}
+		result = append(result, &models.OrderItem{ProductID: item.ProductID, Quantity: quantity})
+	}
+	return result
+}
+
+func addressFromInput(address addressInput) dto.Address {
+	return dto.Address{
+		Recipient:  address.Recipient,
+		Line1:      address.Line1,
+		Line2:      valueOf(address.Line2),
+		City:       address.City,
+		PostalCode: valueOf(address.PostalCode),
+		Region:     valueOf(address.Region),
+		Country:    address.Country,
+		Phone:      valueOf(address.Phone),
+	}
+}
+
+func valueOf[T any](value *T) T {
+	var zero T
+	if value == nil {
+		return zero
+	}
+	return *value
+}
@@ -0,0 +1,152 @@
+package graph
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/go-playground/validator"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wassef911/eventually/internal/api/auth"
+	"github.com/wassef911/eventually/internal/api/dto"
+	"github.com/wassef911/eventually/internal/delivery/aggregate"
+	"github.com/wassef911/eventually/internal/delivery/models"
+	"github.com/wassef911/eventually/internal/delivery/queries"
+	service "github.com/wassef911/eventually/internal/delivery/services"
+	"github.com/wassef911/eventually/internal/infrastructure/es"
+	"github.com/wassef911/eventually/pkg/config"
+	"github.com/wassef911/eventually/pkg/logger"
+)
+
+const orderID = "7b5e3a4c-1f7e-4c36-9a54-5d3c6f1f2b10"
+
+type getOrderByIDStub struct{}
+
+func (getOrderByIDStub) Handle(_ context.Context, query *queries.GetOrderByIDQuery) (*models.OrderProjection, error) {
+	if query.ID != orderID {
+		return nil, aggregate.ErrOrderNotFound
+	}
+	return &models.OrderProjection{
+		OrderID:      orderID,
+		AccountEmail: "jane@example.com",
+		Status:       models.OrderStatusPaid,
+		ShopItems:    []*models.ShopItem{{ID: "sku-1", Title: "Mug", Quantity: 2, Price: models.NewMoney(1250, "EUR")}},
+		TotalPrice:   models.NewMoney(2500, "EUR"),
+		Payment:      models.Payment{PaymentID: "pay-1"},
+	}, nil
+}
+
+type searchOrdersStub struct{}
+
+func (searchOrdersStub) Handle(_ context.Context, query *queries.SearchOrdersQuery) (*dto.OrderSearchResponseDto, error) {
+	return &dto.OrderSearchResponseDto{
+		Pagination: dto.Pagination{TotalCount: 1, TotalPages: 1, Page: int64(query.Pq.Page), Size: int64(query.Pq.Size)},
+		Orders:     []dto.OrderResponseDto{{OrderID: orderID, Status: query.Status}},
+	}, nil
+}
+
+type getOrderEventsStub struct{}
+
+func (getOrderEventsStub) Handle(context.Context, *queries.GetOrderEventsQuery) ([]es.Event, error) {
+	return []es.Event{{EventID: "evt-1", EventType: "ORDER_CREATED", Version: 0, Timestamp: time.Now(), Data: []byte(`{"accountEmail":"jane@example.com"}`)}}, nil
+}
+
+func execute(t *testing.T, query string, variables map[string]interface{}) (map[string]interface{}, []map[string]interface{}) {
+	return executeAs(t, auth.ContextWithPrincipal(context.Background(), auth.Anonymous), query, variables)
+}
+
+func executeAs(t *testing.T, ctx context.Context, query string, variables map[string]interface{}) (map[string]interface{}, []map[string]interface{}) {
+	appLogger := logger.NewAppLogger(&logger.Config{LogLevel: "fatal"})
+	appLogger.InitLogger()
+
+	os := &service.OrderService{Queries: queries.NewOrderQueries(getOrderByIDStub{}, searchOrdersStub{}, nil, getOrderEventsStub{})}
+	schema, err := NewSchema(appLogger, &config.Config{Logger: &logger.Config{}}, validator.New(), os)
+	require.NoError(t, err)
+
+	response := schema.Exec(ctx, query, "", variables)
+	raw, err := json.Marshal(response)
+	require.NoError(t, err)
+
+	var decoded struct {
+		Data   map[string]interface{}   `json:"data"`
+		Errors []map[string]interface{} `json:"errors"`
+	}
+	require.NoError(t, json.Unmarshal(raw, &decoded))
+	return decoded.Data, decoded.Errors
+}
+
+func TestResolver_Order(t *testing.T) {
+	data, errs := execute(t, `query($id: ID!) {
+		order(id: $id) {
+			id status paid cancelReason
+			totalPrice { amount currency }
+			shopItems { id quantity price { amount } }
+			events { id type version data }
+		}
+	}`, map[string]interface{}{"id": orderID})
+	require.Empty(t, errs)
+
+	order := data["order"].(map[string]interface{})
+	assert.Equal(t, orderID, order["id"])
+	assert.Equal(t, true, order["paid"])
+	assert.Nil(t, order["cancelReason"])
+	assert.Equal(t, map[string]interface{}{"amount": float64(2500), "currency": "EUR"}, order["totalPrice"])
+	assert.Len(t, order["shopItems"], 1)
+
+	events := order["events"].([]interface{})
+	require.Len(t, events, 1)
+	assert.Equal(t, "ORDER_CREATED", events[0].(map[string]interface{})["type"])
+}
+
+func TestResolver_OrderNotFound(t *testing.T) {
+	_, errs := execute(t, `{ order(id: "0b7a3a8e-7f4e-4f8b-9f41-64f1c1f3f1aa") { id } }`, nil)
+	require.Len(t, errs, 1)
+	assert.Equal(t, map[string]interface{}{"code": CodeNotFound}, errs[0]["extensions"])
+}
+
+func TestResolver_SearchOrders(t *testing.T) {
+	data, errs := execute(t, `{ searchOrders(status: PAID, size: 5) { pagination { page size } orders { id status } } }`, nil)
+	require.Empty(t, errs)
+
+	result := data["searchOrders"].(map[string]interface{})
+	assert.Equal(t, map[string]interface{}{"page": float64(1), "size": float64(5)}, result["pagination"])
+	assert.Equal(t, []interface{}{map[string]interface{}{"id": orderID, "status": "PAID"}}, result["orders"])
+}
+
+func TestResolver_MutationInvalidInput(t *testing.T) {
+	_, errs := execute(t, `mutation { submitOrder(id: "not-a-uuid") }`, nil)
+	require.Len(t, errs, 1)
+	assert.Equal(t, map[string]interface{}{"code": CodeBadUserInput}, errs[0]["extensions"])
+
+	_, errs = execute(t, `mutation($items: [OrderItemInput!]!) { updateShoppingCart(id: "`+orderID+`", items: $items) }`,
+		map[string]interface{}{"items": []interface{}{map[string]interface{}{"productId": "sku-1", "quantity": float64(-1)}}})
+	require.Len(t, errs, 1)
+	assert.Equal(t, map[string]interface{}{"code": CodeBadUserInput}, errs[0]["extensions"])
+}
+
+func TestResolver_Authorization(t *testing.T) {
+	_, errs := executeAs(t, context.Background(), `{ order(id: "`+orderID+`") { id } }`, nil)
+	require.Len(t, errs, 1)
+	assert.Equal(t, map[string]interface{}{"code": CodeUnauthenticated}, errs[0]["extensions"])
+
+	owner := auth.ContextWithPrincipal(context.Background(), &auth.Principal{Subject: "c-1", Email: "Jane@example.com", Role: auth.RoleCustomer})
+	data, errs := executeAs(t, owner, `{ order(id: "`+orderID+`") { id } }`, nil)
+	require.Empty(t, errs)
+	assert.Equal(t, orderID, data["order"].(map[string]interface{})["id"])
+
+	_, errs = executeAs(t, owner, `{ searchOrders { orders { id } } }`, nil)
+	require.Len(t, errs, 1)
+	assert.Equal(t, map[string]interface{}{"code": CodeForbidden}, errs[0]["extensions"])
+
+	other := auth.ContextWithPrincipal(context.Background(), &auth.Principal{Subject: "c-2", Email: "john@example.com", Role: auth.RoleCustomer})
+	_, errs = executeAs(t, other, `{ order(id: "`+orderID+`") { id } }`, nil)
+	require.Len(t, errs, 1)
+	assert.Equal(t, map[string]interface{}{"code": CodeForbidden}, errs[0]["extensions"])
+
+	_, errs = executeAs(t, other, `mutation { cancelOrder(id: "`+orderID+`", cancelReason: "changed my mind") }`, nil)
+	require.Len(t, errs, 1)
+	assert.Equal(t, map[string]interface{}{"code": CodeForbidden}, errs[0]["extensions"])
+}
@@ -0,0 +1,42 @@
+package graph
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+)
+
+// Int64 GraphQL Int is 32 bit, money amounts and counters need the full range.
+type Int64 int64
+
+func (Int64) ImplementsGraphQLType(name string) bool {
+	return name == "Int64"
+}
+
+func (i *Int64) UnmarshalGraphQL(input interface{}) error {
+	switch input := input.(type) {
+	case int32:
+		*i = Int64(input)
+	case int64:
+		*i = Int64(input)
+	case float64:
+		if input != math.Trunc(input) {
+			return fmt.Errorf("Int64 must be an integer, got: %v", input)
+		}
+		*i = Int64(input)
+	case string:
+		value, err := strconv.ParseInt(input, 10, 64)
+		if err != nil {
+			return fmt.Errorf("Int64 must be an integer, got: %q", input)
+		}
+		*i = Int64(value)
+	default:
+		return fmt.Errorf("wrong type for Int64: %T", input)
+	}
+	return nil
+}
+
+func (i Int64) MarshalJSON() ([]byte, error) {
+	return json.Marshal(int64(i))
+}
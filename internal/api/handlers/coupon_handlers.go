@@ -0,0 +1,112 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/go-playground/validator"
+	"github.com/labstack/echo/v4"
+	"github.com/opentracing/opentracing-go"
+
+	"github.com/wassef911/eventually/internal/api/auth"
+	"github.com/wassef911/eventually/internal/api/constants"
+	"github.com/wassef911/eventually/internal/api/dto"
+	api "github.com/wassef911/eventually/internal/api/middlewares"
+	"github.com/wassef911/eventually/internal/api/utils"
+	"github.com/wassef911/eventually/internal/delivery/repository"
+	"github.com/wassef911/eventually/pkg/config"
+	"github.com/wassef911/eventually/pkg/logger"
+)
+
+type CouponHandlersI interface {
+	CreateCoupon() echo.HandlerFunc
+	GetCouponByCode() echo.HandlerFunc
+	MapRoutes()
+}
+
+var _ CouponHandlersI = &couponHandlers{}
+
+type couponHandlers struct {
+	group      *echo.Group
+	log        logger.Logger
+	mw         api.MiddlewareManager
+	config     *config.Config
+	v          *validator.Validate
+	couponRepo repository.CouponRepository
+}
+
+func NewCouponHandlers(
+	group *echo.Group,
+	log logger.Logger,
+	mw api.MiddlewareManager,
+	config *config.Config,
+	v *validator.Validate,
+	couponRepo repository.CouponRepository,
+) *couponHandlers {
+	return &couponHandlers{group: group, log: log, mw: mw, config: config, v: v, couponRepo: couponRepo}
+}
+
+func (h *couponHandlers) MapRoutes() {
+	h.group.POST("", h.CreateCoupon(), h.mw.Authorize(auth.PermissionManageCatalog))
+	h.group.GET("/:code", h.GetCouponByCode(), h.mw.Authorize(auth.PermissionReadCatalog))
+}
+
+// CreateCoupon
+// @Tags Coupons
+// @Summary Create coupon
+// @Description Create a new promotion redeemable by code
+// @Param coupon body dto.CreateCouponReqDto true "create coupon"
+// @Accept json
+// @Produce json
+// @Success 201 {string} code ""
+// @Router /coupons [post]
+func (h *couponHandlers) CreateCoupon() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		span, _ := opentracing.StartSpanFromContext(ctx, "couponHandlers.CreateCoupon")
+		defer span.Finish()
+
+		var reqDto dto.CreateCouponReqDto
+		if err := c.Bind(&reqDto); err != nil {
+			return err
+		}
+
+		if err := h.v.StructCtx(ctx, reqDto); err != nil {
+			return err
+		}
+
+		coupon := utils.CouponFromDto(reqDto.Coupon)
+		if err := coupon.Validate(); err != nil {
+			return err
+		}
+
+		if err := h.couponRepo.Insert(ctx, coupon); err != nil {
+			return err
+		}
+
+		return c.JSON(http.StatusCreated, coupon.Code)
+	}
+}
+
+// GetCouponByCode
+// @Tags Coupons
+// @Summary Get coupon
+// @Description Get coupon by code
+// @Accept json
+// @Produce json
+// @Param code path string true "Coupon code"
+// @Success 200 {object} dto.Coupon
+// @Router /coupons/{code} [get]
+func (h *couponHandlers) GetCouponByCode() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		span, _ := opentracing.StartSpanFromContext(ctx, "couponHandlers.GetCouponByCode")
+		defer span.Finish()
+
+		coupon, err := h.couponRepo.GetByCode(ctx, c.Param(constants.Code))
+		if err != nil {
+			return err
+		}
+
+		return c.JSON(http.StatusOK, utils.CouponResponseFromModel(coupon))
+	}
+}
@@ -0,0 +1,48 @@
+package handlers
+
+import (
+	"github.com/labstack/echo/v4"
+	pkgErrors "github.com/pkg/errors"
+	uuid "github.com/satori/go.uuid"
+
+	"github.com/wassef911/eventually/internal/api/auth"
+	"github.com/wassef911/eventually/internal/api/constants"
+	"github.com/wassef911/eventually/internal/delivery/aggregate"
+	"github.com/wassef911/eventually/internal/delivery/queries"
+	service "github.com/wassef911/eventually/internal/delivery/services"
+	"github.com/wassef911/eventually/pkg/config"
+	"github.com/wassef911/eventually/pkg/errors"
+)
+
+// customerOwner customers only reach the account registered with their email, the customer id is the id path param.
+// It runs after the Authorize middleware, support and admins are let through without loading the customer.
+func customerOwner(cs *service.CustomerService, config *config.Config) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			ctx := c.Request().Context()
+			principal, ok := auth.PrincipalFromContext(ctx)
+			if !ok || !principal.OwnsOrdersOnly() {
+				return next(c)
+			}
+
+			customerID, err := uuid.FromString(c.Param(constants.ID))
+			if err != nil {
+				// the handler answers malformed ids
+				return next(c)
+			}
+
+			customerProjection, err := cs.Queries.GetCustomerByID.Handle(ctx, queries.NewGetCustomerByIDQuery(customerID.String()))
+			if pkgErrors.Is(err, aggregate.ErrCustomerNotFound) {
+				return errors.NewNotFoundError(c, err, config.Logger.Debug)
+			}
+			if err != nil {
+				return err
+			}
+
+			if !principal.CanAccessCustomer(customerProjection.Email) {
+				return pkgErrors.Wrap(errors.Forbidden, "customer account belongs to another customer")
+			}
+			return next(c)
+		}
+	}
+}
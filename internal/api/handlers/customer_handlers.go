@@ -0,0 +1,335 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/go-playground/validator"
+	"github.com/labstack/echo/v4"
+	"github.com/opentracing/opentracing-go"
+	pkgErrors "github.com/pkg/errors"
+	uuid "github.com/satori/go.uuid"
+
+	"github.com/wassef911/eventually/internal/api/auth"
+	"github.com/wassef911/eventually/internal/api/constants"
+	"github.com/wassef911/eventually/internal/api/dto"
+	api "github.com/wassef911/eventually/internal/api/middlewares"
+	"github.com/wassef911/eventually/internal/api/utils"
+	"github.com/wassef911/eventually/internal/delivery/aggregate"
+	"github.com/wassef911/eventually/internal/delivery/commands"
+	"github.com/wassef911/eventually/internal/delivery/models"
+	"github.com/wassef911/eventually/internal/delivery/queries"
+	service "github.com/wassef911/eventually/internal/delivery/services"
+	"github.com/wassef911/eventually/pkg/config"
+	"github.com/wassef911/eventually/pkg/errors"
+	"github.com/wassef911/eventually/pkg/logger"
+)
+
+type CustomerHandlersI interface {
+	RegisterCustomer() echo.HandlerFunc
+	ChangeCustomerEmail() echo.HandlerFunc
+	SaveCustomerAddress() echo.HandlerFunc
+	RemoveCustomerAddress() echo.HandlerFunc
+	UpdateCustomerPreferences() echo.HandlerFunc
+	GetCustomerByID() echo.HandlerFunc
+	GetCustomerOrders() echo.HandlerFunc
+	MapRoutes()
+}
+
+var _ CustomerHandlersI = &customerHandlers{}
+
+type customerHandlers struct {
+	group  *echo.Group
+	log    logger.Logger
+	mw     api.MiddlewareManager
+	config *config.Config
+	v      *validator.Validate
+	cs     *service.CustomerService
+}
+
+func NewCustomerHandlers(
+	group *echo.Group,
+	log logger.Logger,
+	mw api.MiddlewareManager,
+	config *config.Config,
+	v *validator.Validate,
+	cs *service.CustomerService,
+) *customerHandlers {
+	return &customerHandlers{group: group, log: log, mw: mw, config: config, v: v, cs: cs}
+}
+
+func (h *customerHandlers) MapRoutes() {
+	manage := h.mw.Authorize(auth.PermissionManageCustomer)
+	owner := customerOwner(h.cs, h.config)
+
+	h.group.POST("", h.RegisterCustomer(), manage)
+	h.group.PUT("/:id/email", h.ChangeCustomerEmail(), manage, owner)
+	h.group.PUT("/:id/addresses/:addressId", h.SaveCustomerAddress(), manage, owner)
+	h.group.DELETE("/:id/addresses/:addressId", h.RemoveCustomerAddress(), manage, owner)
+	h.group.PUT("/:id/preferences", h.UpdateCustomerPreferences(), manage, owner)
+	h.group.GET("/:id", h.GetCustomerByID(), manage, owner)
+	h.group.GET("/:id/orders", h.GetCustomerOrders(), manage, owner)
+}
+
+// RegisterCustomer
+// @Tags Customers
+// @Summary Register customer
+// @Description Register a new customer account
+// @Param customer body dto.RegisterCustomerReqDto true "register customer"
+// @Accept json
+// @Produce json
+// @Success 201 {string} id ""
+// @Router /customers [post]
+func (h *customerHandlers) RegisterCustomer() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		span, _ := opentracing.StartSpanFromContext(ctx, "customerHandlers.RegisterCustomer")
+		defer span.Finish()
+
+		var reqDto dto.RegisterCustomerReqDto
+		if err := c.Bind(&reqDto); err != nil {
+			return err
+		}
+
+		if err := h.v.StructCtx(ctx, reqDto); err != nil {
+			return err
+		}
+
+		email, err := auth.OrderAccountEmail(ctx, reqDto.Email)
+		if err != nil {
+			return err
+		}
+
+		id := uuid.NewV4().String()
+		command := commands.NewRegisterCustomerCommand(id, email, reqDto.Name)
+		if err := h.cs.Commands.RegisterCustomer.Handle(ctx, command); err != nil {
+			return err
+		}
+
+		return c.JSON(http.StatusCreated, id)
+	}
+}
+
+// ChangeCustomerEmail
+// @Tags Customers
+// @Summary Change customer email
+// @Description Change the account email, orders stay linked to the customer
+// @Param email body dto.ChangeCustomerEmailReqDto true "new email"
+// @Param id path string true "Customer ID"
+// @Accept json
+// @Produce json
+// @Success 200 {string} id ""
+// @Router /customers/{id}/email [put]
+func (h *customerHandlers) ChangeCustomerEmail() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		span, _ := opentracing.StartSpanFromContext(ctx, "customerHandlers.ChangeCustomerEmail")
+		defer span.Finish()
+
+		customerID, err := uuid.FromString(c.Param(constants.ID))
+		if err != nil {
+			return errors.NewBadRequestError(c, err.Error(), h.config.Logger.Debug)
+		}
+
+		var reqDto dto.ChangeCustomerEmailReqDto
+		if err := c.Bind(&reqDto); err != nil {
+			return err
+		}
+
+		if err := h.v.StructCtx(ctx, reqDto); err != nil {
+			return err
+		}
+
+		command := commands.NewChangeCustomerEmailCommand(customerID.String(), reqDto.Email)
+		if err := h.cs.Commands.ChangeCustomerEmail.Handle(ctx, command); err != nil {
+			return h.customerError(c, err)
+		}
+
+		return c.JSON(http.StatusOK, customerID.String())
+	}
+}
+
+// SaveCustomerAddress
+// @Tags Customers
+// @Summary Save customer address
+// @Description Add or replace a saved address, a new default address replaces the previous default
+// @Param address body dto.SaveCustomerAddressReqDto true "saved address"
+// @Param id path string true "Customer ID"
+// @Param addressId path string true "Address ID"
+// @Accept json
+// @Produce json
+// @Success 200 {string} id ""
+// @Router /customers/{id}/addresses/{addressId} [put]
+func (h *customerHandlers) SaveCustomerAddress() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		span, _ := opentracing.StartSpanFromContext(ctx, "customerHandlers.SaveCustomerAddress")
+		defer span.Finish()
+
+		customerID, err := uuid.FromString(c.Param(constants.ID))
+		if err != nil {
+			return errors.NewBadRequestError(c, err.Error(), h.config.Logger.Debug)
+		}
+
+		var reqDto dto.SaveCustomerAddressReqDto
+		if err := c.Bind(&reqDto); err != nil {
+			return err
+		}
+
+		if err := h.v.StructCtx(ctx, reqDto); err != nil {
+			return err
+		}
+
+		address := models.SavedAddress{
+			AddressID: c.Param(constants.AddressID),
+			Label:     reqDto.Label,
+			Address:   utils.AddressFromDto(reqDto.Address),
+			Default:   reqDto.Default,
+		}
+		command := commands.NewSaveCustomerAddressCommand(customerID.String(), address)
+		if err := h.cs.Commands.SaveCustomerAddress.Handle(ctx, command); err != nil {
+			return h.customerError(c, err)
+		}
+
+		return c.JSON(http.StatusOK, customerID.String())
+	}
+}
+
+// RemoveCustomerAddress
+// @Tags Customers
+// @Summary Remove customer address
+// @Description Remove a saved address
+// @Param id path string true "Customer ID"
+// @Param addressId path string true "Address ID"
+// @Accept json
+// @Produce json
+// @Success 200 {string} id ""
+// @Router /customers/{id}/addresses/{addressId} [delete]
+func (h *customerHandlers) RemoveCustomerAddress() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		span, _ := opentracing.StartSpanFromContext(ctx, "customerHandlers.RemoveCustomerAddress")
+		defer span.Finish()
+
+		customerID, err := uuid.FromString(c.Param(constants.ID))
+		if err != nil {
+			return errors.NewBadRequestError(c, err.Error(), h.config.Logger.Debug)
+		}
+
+		command := commands.NewRemoveCustomerAddressCommand(customerID.String(), c.Param(constants.AddressID))
+		if err := h.cs.Commands.RemoveCustomerAddress.Handle(ctx, command); err != nil {
+			return h.customerError(c, err)
+		}
+
+		return c.JSON(http.StatusOK, customerID.String())
+	}
+}
+
+// UpdateCustomerPreferences
+// @Tags Customers
+// @Summary Update customer preferences
+// @Description Replace the customer language, currency and marketing preferences
+// @Param preferences body dto.CustomerPreferences true "preferences"
+// @Param id path string true "Customer ID"
+// @Accept json
+// @Produce json
+// @Success 200 {string} id ""
+// @Router /customers/{id}/preferences [put]
+func (h *customerHandlers) UpdateCustomerPreferences() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		span, _ := opentracing.StartSpanFromContext(ctx, "customerHandlers.UpdateCustomerPreferences")
+		defer span.Finish()
+
+		customerID, err := uuid.FromString(c.Param(constants.ID))
+		if err != nil {
+			return errors.NewBadRequestError(c, err.Error(), h.config.Logger.Debug)
+		}
+
+		var reqDto dto.CustomerPreferences
+		if err := c.Bind(&reqDto); err != nil {
+			return err
+		}
+
+		if err := h.v.StructCtx(ctx, reqDto); err != nil {
+			return err
+		}
+
+		command := commands.NewUpdateCustomerPreferencesCommand(customerID.String(), utils.CustomerPreferencesFromDto(reqDto))
+		if err := h.cs.Commands.UpdateCustomerPreferences.Handle(ctx, command); err != nil {
+			return h.customerError(c, err)
+		}
+
+		return c.JSON(http.StatusOK, customerID.String())
+	}
+}
+
+// GetCustomerByID
+// @Tags Customers
+// @Summary Get customer
+// @Description Get customer by id
+// @Accept json
+// @Produce json
+// @Param id path string true "Customer ID"
+// @Success 200 {object} dto.CustomerResponseDto
+// @Router /customers/{id} [get]
+func (h *customerHandlers) GetCustomerByID() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		span, _ := opentracing.StartSpanFromContext(ctx, "customerHandlers.GetCustomerByID")
+		defer span.Finish()
+
+		customerID, err := uuid.FromString(c.Param(constants.ID))
+		if err != nil {
+			return errors.NewBadRequestError(c, err.Error(), h.config.Logger.Debug)
+		}
+
+		query := queries.NewGetCustomerByIDQuery(customerID.String())
+		customer, err := h.cs.Queries.GetCustomerByID.Handle(ctx, query)
+		if err != nil {
+			return h.customerError(c, err)
+		}
+
+		return c.JSON(http.StatusOK, utils.CustomerResponseFromModel(customer))
+	}
+}
+
+// GetCustomerOrders
+// @Tags Customers
+// @Summary Customer order history
+// @Description Orders placed by the customer, newest first
+// @Accept json
+// @Produce json
+// @Param id path string true "Customer ID"
+// @Param page query string false "page number"
+// @Param size query string false "number of elements"
+// @Success 200 {object} dto.OrderSearchResponseDto
+// @Router /customers/{id}/orders [get]
+func (h *customerHandlers) GetCustomerOrders() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		span, _ := opentracing.StartSpanFromContext(ctx, "customerHandlers.GetCustomerOrders")
+		defer span.Finish()
+
+		customerID, err := uuid.FromString(c.Param(constants.ID))
+		if err != nil {
+			return errors.NewBadRequestError(c, err.Error(), h.config.Logger.Debug)
+		}
+
+		pq := utils.NewPaginationFromQueryParams(c.QueryParam(constants.Size), c.QueryParam(constants.Page))
+
+		query := queries.NewGetCustomerOrdersQuery(customerID.String(), pq)
+		ordersRes, err := h.cs.Queries.GetCustomerOrders.Handle(ctx, query)
+		if err != nil {
+			return h.customerError(c, err)
+		}
+
+		return c.JSON(http.StatusOK, ordersRes)
+	}
+}
+
+func (h *customerHandlers) customerError(c echo.Context, err error) error {
+	if pkgErrors.Is(err, aggregate.ErrCustomerNotFound) || pkgErrors.Is(err, aggregate.ErrCustomerAddressNotFound) {
+		return errors.NewNotFoundError(c, err, h.config.Logger.Debug)
+	}
+	return err
+}
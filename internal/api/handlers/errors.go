@@ -0,0 +1,33 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/wassef911/eventually/internal/delivery/domain"
+	"github.com/wassef911/eventually/pkg/errors"
+)
+
+// domainStatuses http status of the domain errors of each kind.
+var domainStatuses = map[domain.Kind]int{
+	domain.KindNotFound: http.StatusNotFound,
+	domain.KindConflict: http.StatusConflict,
+	domain.KindInvalid:  http.StatusUnprocessableEntity,
+}
+
+func init() {
+	errors.RegisterClassifier(classifyDomainError)
+}
+
+// classifyDomainError domain errors are answered with the status of their kind and keep their code.
+func classifyDomainError(err error) (errors.Classification, bool) {
+	domainErr, ok := domain.AsError(err)
+	if !ok {
+		return errors.Classification{}, false
+	}
+
+	status, ok := domainStatuses[domainErr.Kind]
+	if !ok {
+		status = http.StatusInternalServerError
+	}
+	return errors.Classification{Status: status, Code: domainErr.Code, Message: domainErr.Message}, true
+}
@@ -0,0 +1,39 @@
+package handlers
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/wassef911/eventually/internal/api/utils"
+	"github.com/wassef911/eventually/internal/delivery/aggregate"
+	"github.com/wassef911/eventually/internal/delivery/payment"
+	"github.com/wassef911/eventually/internal/infrastructure/es"
+	httpErrors "github.com/wassef911/eventually/pkg/errors"
+)
+
+func TestDomainErrorProblem(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		status int
+		code   string
+		detail string
+	}{
+		{name: "not found", err: errors.Wrap(aggregate.ErrOrderNotFound, "Load"), status: http.StatusNotFound, code: "order_not_found", detail: "order not found"},
+		{name: "payment declined", err: errors.Wrapf(payment.ErrPaymentDeclined, "token: {%s}", payment.FakeTokenDeclined), status: http.StatusConflict, code: "payment_declined", detail: "payment declined"},
+		{name: "tenant mismatch", err: errors.Wrap(es.ErrTenantMismatch, "Load"), status: http.StatusConflict, code: "tenant_mismatch", detail: "aggregate belongs to another tenant"},
+		{name: "invalid cursor", err: errors.Wrap(utils.ErrInvalidCursor, "order id is required"), status: http.StatusUnprocessableEntity, code: "invalid_cursor", detail: "invalid cursor"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			problem := httpErrors.ErrorToProblem(tt.err, false)
+			assert.Equal(t, tt.status, problem.Status)
+			assert.Equal(t, tt.code, problem.Code)
+			assert.Equal(t, tt.detail, problem.Detail)
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/go-playground/validator"
+	graphql "github.com/graph-gophers/graphql-go"
+	"github.com/labstack/echo/v4"
+	"github.com/opentracing/opentracing-go"
+
+	"github.com/wassef911/eventually/internal/api/dto"
+	api "github.com/wassef911/eventually/internal/api/middlewares"
+	"github.com/wassef911/eventually/pkg/config"
+	"github.com/wassef911/eventually/pkg/errors"
+	"github.com/wassef911/eventually/pkg/logger"
+)
+
+type GraphQLHandlersI interface {
+	Query() echo.HandlerFunc
+	MapRoutes()
+}
+
+var _ GraphQLHandlersI = &graphQLHandlers{}
+
+type graphQLHandlers struct {
+	group  *echo.Group
+	log    logger.Logger
+	mw     api.MiddlewareManager
+	config *config.Config
+	v      *validator.Validate
+	schema *graphql.Schema
+}
+
+func NewGraphQLHandlers(
+	group *echo.Group,
+	log logger.Logger,
+	mw api.MiddlewareManager,
+	config *config.Config,
+	v *validator.Validate,
+	schema *graphql.Schema,
+) *graphQLHandlers {
+	return &graphQLHandlers{group: group, log: log, mw: mw, config: config, v: v, schema: schema}
+}
+
+func (h *graphQLHandlers) MapRoutes() {
+	h.group.POST("", h.Query(), h.mw.Authenticate)
+}
+
+// Query
+// @Tags GraphQL
+// @Summary GraphQL endpoint
+// @Description Order queries and mutations, resolver errors are listed in the errors of the response with their code in the extensions
+// @Param request body dto.GraphQLReqDto true "GraphQL request"
+// @Accept json
+// @Produce json
+// @Success 200 {object} graphql.Response
+// @Router /graphql [post]
+func (h *graphQLHandlers) Query() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		span, ctx := opentracing.StartSpanFromContext(ctx, "graphQLHandlers.Query")
+		defer span.Finish()
+
+		var reqDto dto.GraphQLReqDto
+		if err := c.Bind(&reqDto); err != nil {
+			return errors.NewBadRequestError(c, err.Error(), h.config.Logger.Debug)
+		}
+
+		if err := h.v.StructCtx(ctx, reqDto); err != nil {
+			return err
+		}
+
+		response := h.schema.Exec(ctx, reqDto.Query, reqDto.OperationName, reqDto.Variables)
+		return c.JSON(http.StatusOK, response)
+	}
+}
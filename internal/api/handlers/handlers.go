@@ -7,12 +7,15 @@ import (
 	"github.com/go-playground/validator"
 	"github.com/labstack/echo/v4"
 	"github.com/opentracing/opentracing-go"
+	pkgErrors "github.com/pkg/errors"
 	uuid "github.com/satori/go.uuid"
 
+	"github.com/wassef911/eventually/internal/api/auth"
 	"github.com/wassef911/eventually/internal/api/constants"
 	"github.com/wassef911/eventually/internal/api/dto"
 	api "github.com/wassef911/eventually/internal/api/middlewares"
 	"github.com/wassef911/eventually/internal/api/utils"
+	"github.com/wassef911/eventually/internal/delivery/aggregate"
 	"github.com/wassef911/eventually/internal/delivery/commands"
 	"github.com/wassef911/eventually/internal/delivery/models"
 	"github.com/wassef911/eventually/internal/delivery/queries"
@@ -28,9 +31,25 @@ type OrderHandlersI interface {
 	PayOrder() echo.HandlerFunc
 	SubmitOrder() echo.HandlerFunc
 	UpdateShoppingCart() echo.HandlerFunc
+	AddItem() echo.HandlerFunc
+	RemoveItem() echo.HandlerFunc
+	ChangeItemQuantity() echo.HandlerFunc
+	RefundOrder() echo.HandlerFunc
+	RequestReturn() echo.HandlerFunc
+	ApproveReturn() echo.HandlerFunc
+	RejectReturn() echo.HandlerFunc
+	ReceiveReturn() echo.HandlerFunc
+	CreateShipment() echo.HandlerFunc
+	PackShipment() echo.HandlerFunc
+	DispatchShipment() echo.HandlerFunc
+	DeliverShipment() echo.HandlerFunc
+	ApplyCoupon() echo.HandlerFunc
+	RemoveCoupon() echo.HandlerFunc
 	MapRoutes()
 	GetOrderByID() echo.HandlerFunc
 	Search() echo.HandlerFunc
+	ListOrders() echo.HandlerFunc
+	Lifecycle() echo.HandlerFunc
 }
 
 var _ OrderHandlersI = &orderHandlers{}
@@ -56,16 +75,38 @@ func NewOrderHandlers(
 }
 
 func (h *orderHandlers) MapRoutes() {
-	h.group.POST("", h.CreateOrder())
-	h.group.PUT("/pay/:id", h.PayOrder())
-	h.group.PUT("/submit/:id", h.SubmitOrder())
-	h.group.PUT("/cart/:id", h.UpdateShoppingCart())
-	h.group.POST("/cancel/:id", h.CancelOrder())
-	h.group.POST("/complete/:id", h.CompleteOrder())
-	h.group.PUT("/address/:id", h.ChangeDeliveryAddress())
-
-	h.group.GET("/:id", h.GetOrderByID())
-	h.group.GET("/search", h.Search())
+	place := h.mw.Authorize(auth.PermissionPlaceOrder)
+	cancel := h.mw.Authorize(auth.PermissionCancelOrder)
+	fulfil := h.mw.Authorize(auth.PermissionFulfilOrder)
+	read := h.mw.Authorize(auth.PermissionReadOrder)
+	owner := orderOwner(h.os, h.config)
+
+	h.group.POST("", h.CreateOrder(), place)
+	h.group.GET("", h.ListOrders(), h.mw.Authorize(auth.PermissionSearchOrders))
+	h.group.PUT("/pay/:id", h.PayOrder(), place, owner)
+	h.group.PUT("/submit/:id", h.SubmitOrder(), place, owner)
+	h.group.PUT("/cart/:id", h.UpdateShoppingCart(), place, owner)
+	h.group.POST("/cart/:id/items", h.AddItem(), place, owner)
+	h.group.DELETE("/cart/:id/items/:itemId", h.RemoveItem(), place, owner)
+	h.group.PUT("/cart/:id/items/:itemId", h.ChangeItemQuantity(), place, owner)
+	h.group.POST("/cancel/:id", h.CancelOrder(), cancel, owner)
+	h.group.POST("/complete/:id", h.CompleteOrder(), fulfil)
+	h.group.PUT("/address/:id", h.ChangeDeliveryAddress(), place, owner)
+	h.group.POST("/refund/:id", h.RefundOrder(), fulfil)
+	h.group.POST("/return/:id", h.RequestReturn(), place, owner)
+	h.group.PUT("/return/:id/approve/:returnId", h.ApproveReturn(), fulfil)
+	h.group.PUT("/return/:id/reject/:returnId", h.RejectReturn(), fulfil)
+	h.group.PUT("/return/:id/receive/:returnId", h.ReceiveReturn(), fulfil)
+	h.group.POST("/shipment/:id", h.CreateShipment(), fulfil)
+	h.group.PUT("/shipment/:id/pack/:shipmentId", h.PackShipment(), fulfil)
+	h.group.PUT("/shipment/:id/dispatch/:shipmentId", h.DispatchShipment(), fulfil)
+	h.group.PUT("/shipment/:id/deliver/:shipmentId", h.DeliverShipment(), fulfil)
+	h.group.POST("/coupon/:id", h.ApplyCoupon(), place, owner)
+	h.group.DELETE("/coupon/:id/:code", h.RemoveCoupon(), place, owner)
+
+	h.group.GET("/:id", h.GetOrderByID(), read, owner)
+	h.group.GET("/search", h.Search(), h.mw.Authorize(auth.PermissionSearchOrders))
+	h.group.GET("/lifecycle", h.Lifecycle(), read)
 }
 
 // CreateOrder
@@ -88,15 +129,21 @@ func (h *orderHandlers) CreateOrder() echo.HandlerFunc {
 			return err
 		}
 
+		accountEmail, err := auth.OrderAccountEmail(ctx, reqDto.AccountEmail)
+		if err != nil {
+			return err
+		}
+		reqDto.AccountEmail = accountEmail
+
 		if err := h.v.StructCtx(ctx, reqDto); err != nil {
 			return err
 		}
 
 		id := uuid.NewV4().String()
-		command := commands.NewCreateOrderCommand(id, reqDto.ShopItems, reqDto.AccountEmail, reqDto.DeliveryAddress)
-		err := h.os.Commands.CreateOrder.Handle(ctx, command)
+		command := commands.NewCreateOrderCommand(id, reqDto.Items, reqDto.CustomerID, reqDto.AccountEmail, utils.AddressFromDto(reqDto.DeliveryAddress))
+		err = h.os.Commands.CreateOrder.Handle(ctx, command)
 		if err != nil {
-			return err
+			return h.catalogError(c, err)
 		}
 
 		return c.JSON(http.StatusCreated, id)
@@ -106,10 +153,10 @@ func (h *orderHandlers) CreateOrder() echo.HandlerFunc {
 // PayOrder
 // @Tags Orders
 // @Summary Pay order
-// @Description Pay existing order
+// @Description Authorize and capture the order total with the payment provider, pending payments are confirmed by the provider webhook
 // @Accept json
 // @Produce json
-// @Param order body dto.Payment true "create order"
+// @Param order body dto.PayOrderReqDto true "payment token"
 // @Param id path string true "Order ID"
 // @Success 200 {string} id ""
 // @Router /orders/pay/{id} [put]
@@ -121,15 +168,15 @@ func (h *orderHandlers) PayOrder() echo.HandlerFunc {
 
 		orderID, err := uuid.FromString(c.Param(constants.ID))
 		if err != nil {
-			return err
+			return errors.NewBadRequestError(c, err.Error(), h.config.Logger.Debug)
 		}
 
-		var payment dto.Payment
-		if err := c.Bind(&payment); err != nil {
+		var reqDto dto.PayOrderReqDto
+		if err := c.Bind(&reqDto); err != nil {
 			return err
 		}
 
-		command := commands.NewPayOrderCommand(models.Payment{PaymentID: payment.PaymentID, Timestamp: payment.Timestamp}, orderID.String())
+		command := commands.NewPayOrderCommand(orderID.String(), reqDto.PaymentToken)
 		if err := h.v.StructCtx(ctx, command); err != nil {
 			return err
 		}
@@ -160,7 +207,7 @@ func (h *orderHandlers) SubmitOrder() echo.HandlerFunc {
 
 		orderID, err := uuid.FromString(c.Param(constants.ID))
 		if err != nil {
-			return err
+			return errors.NewBadRequestError(c, err.Error(), h.config.Logger.Debug)
 		}
 
 		command := commands.NewSubmitOrderCommand(orderID.String())
@@ -195,7 +242,7 @@ func (h *orderHandlers) CancelOrder() echo.HandlerFunc {
 
 		orderID, err := uuid.FromString(c.Param(constants.ID))
 		if err != nil {
-			return err
+			return errors.NewBadRequestError(c, err.Error(), h.config.Logger.Debug)
 		}
 
 		var data dto.CancelOrderReqDto
@@ -234,7 +281,7 @@ func (h *orderHandlers) CompleteOrder() echo.HandlerFunc {
 
 		orderID, err := uuid.FromString(c.Param(constants.ID))
 		if err != nil {
-			return err
+			return errors.NewBadRequestError(c, err.Error(), h.config.Logger.Debug)
 		}
 
 		command := commands.NewCompleteOrderCommand(orderID.String(), time.Now())
@@ -270,7 +317,7 @@ func (h *orderHandlers) ChangeDeliveryAddress() echo.HandlerFunc {
 		param := c.Param(constants.ID)
 		orderID, err := uuid.FromString(param)
 		if err != nil {
-			return err
+			return errors.NewBadRequestError(c, err.Error(), h.config.Logger.Debug)
 		}
 
 		var data dto.ChangeDeliveryAddressReqDto
@@ -279,7 +326,11 @@ func (h *orderHandlers) ChangeDeliveryAddress() echo.HandlerFunc {
 			return errors.ErrorCtxResponse(c, err, h.config.Logger.Debug)
 		}
 
-		command := commands.NewChangeDeliveryAddressCommand(orderID.String(), data.DeliveryAddress)
+		if err := h.v.StructCtx(ctx, data); err != nil {
+			return err
+		}
+
+		command := commands.NewChangeDeliveryAddressCommand(orderID.String(), utils.AddressFromDto(data.DeliveryAddress))
 		if err := h.v.StructCtx(ctx, command); err != nil {
 			return err
 		}
@@ -311,7 +362,7 @@ func (h *orderHandlers) UpdateShoppingCart() echo.HandlerFunc {
 
 		orderID, err := uuid.FromString(c.Param(constants.ID))
 		if err != nil {
-			return err
+			return errors.NewBadRequestError(c, err.Error(), h.config.Logger.Debug)
 		}
 
 		var reqDto dto.UpdateShoppingItemsReqDto
@@ -323,8 +374,575 @@ func (h *orderHandlers) UpdateShoppingCart() echo.HandlerFunc {
 			return err
 		}
 
-		command := commands.NewUpdateShoppingCartCommand(orderID.String(), reqDto.ShopItems)
+		command := commands.NewUpdateShoppingCartCommand(orderID.String(), reqDto.Items)
 		err = h.os.Commands.UpdateOrder.Handle(ctx, command)
+		if err != nil {
+			return h.catalogError(c, err)
+		}
+
+		return c.JSON(http.StatusOK, orderID.String())
+	}
+}
+
+// AddItem
+// @Tags Orders
+// @Summary Add shopping cart item
+// @Description Add a single item to the order shopping cart
+// @Accept json
+// @Produce json
+// @Param id path string true "Order ID"
+// @Param order body dto.AddShopItemReqDto true "shop item"
+// @Success 200 {string} id ""
+// @Router /orders/cart/{id}/items [post]
+func (h *orderHandlers) AddItem() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		span, _ := opentracing.StartSpanFromContext(ctx, "orderHandlers.AddItem")
+		defer span.Finish()
+
+		orderID, err := uuid.FromString(c.Param(constants.ID))
+		if err != nil {
+			return errors.NewBadRequestError(c, err.Error(), h.config.Logger.Debug)
+		}
+
+		var reqDto dto.AddShopItemReqDto
+		if err := c.Bind(&reqDto); err != nil {
+			return err
+		}
+
+		if err := h.v.StructCtx(ctx, reqDto); err != nil {
+			return err
+		}
+
+		command := commands.NewAddItemCommand(orderID.String(), reqDto.Item)
+		err = h.os.Commands.AddItem.Handle(ctx, command)
+		if err != nil {
+			return h.catalogError(c, err)
+		}
+
+		return c.JSON(http.StatusOK, orderID.String())
+	}
+}
+
+// RemoveItem
+// @Tags Orders
+// @Summary Remove shopping cart item
+// @Description Remove a single item from the order shopping cart
+// @Accept json
+// @Produce json
+// @Param id path string true "Order ID"
+// @Param itemId path string true "Shop item ID"
+// @Success 200 {string} id ""
+// @Router /orders/cart/{id}/items/{itemId} [delete]
+func (h *orderHandlers) RemoveItem() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		span, _ := opentracing.StartSpanFromContext(ctx, "orderHandlers.RemoveItem")
+		defer span.Finish()
+
+		orderID, err := uuid.FromString(c.Param(constants.ID))
+		if err != nil {
+			return errors.NewBadRequestError(c, err.Error(), h.config.Logger.Debug)
+		}
+
+		command := commands.NewRemoveItemCommand(orderID.String(), c.Param(constants.ItemID))
+		if err := h.v.StructCtx(ctx, command); err != nil {
+			return err
+		}
+
+		err = h.os.Commands.RemoveItem.Handle(ctx, command)
+		if err != nil {
+			return err
+		}
+
+		return c.JSON(http.StatusOK, orderID.String())
+	}
+}
+
+// ChangeItemQuantity
+// @Tags Orders
+// @Summary Change shopping cart item quantity
+// @Description Change quantity of a single item in the order shopping cart
+// @Accept json
+// @Produce json
+// @Param id path string true "Order ID"
+// @Param itemId path string true "Shop item ID"
+// @Param order body dto.ChangeItemQuantityReqDto true "quantity"
+// @Success 200 {string} id ""
+// @Router /orders/cart/{id}/items/{itemId} [put]
+func (h *orderHandlers) ChangeItemQuantity() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		span, _ := opentracing.StartSpanFromContext(ctx, "orderHandlers.ChangeItemQuantity")
+		defer span.Finish()
+
+		orderID, err := uuid.FromString(c.Param(constants.ID))
+		if err != nil {
+			return errors.NewBadRequestError(c, err.Error(), h.config.Logger.Debug)
+		}
+
+		var reqDto dto.ChangeItemQuantityReqDto
+		if err := c.Bind(&reqDto); err != nil {
+			return err
+		}
+
+		command := commands.NewChangeItemQuantityCommand(orderID.String(), c.Param(constants.ItemID), reqDto.Quantity)
+		if err := h.v.StructCtx(ctx, command); err != nil {
+			return err
+		}
+
+		err = h.os.Commands.ChangeItemQuantity.Handle(ctx, command)
+		if err != nil {
+			return err
+		}
+
+		return c.JSON(http.StatusOK, orderID.String())
+	}
+}
+
+// RefundOrder
+// @Tags Orders
+// @Summary Refund order
+// @Description Refund paid order fully, by line items or by amount
+// @Accept json
+// @Produce json
+// @Param order body dto.RefundOrderReqDto true "refund order"
+// @Param id path string true "Order ID"
+// @Success 201 {string} refundId ""
+// @Router /orders/refund/{id} [post]
+func (h *orderHandlers) RefundOrder() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		span, _ := opentracing.StartSpanFromContext(ctx, "orderHandlers.RefundOrder")
+		defer span.Finish()
+
+		orderID, err := uuid.FromString(c.Param(constants.ID))
+		if err != nil {
+			return errors.NewBadRequestError(c, err.Error(), h.config.Logger.Debug)
+		}
+
+		var reqDto dto.RefundOrderReqDto
+		if err := c.Bind(&reqDto); err != nil {
+			return err
+		}
+
+		if err := h.v.StructCtx(ctx, reqDto); err != nil {
+			return err
+		}
+
+		refundID := uuid.NewV4().String()
+		command := commands.NewRefundOrderCommand(orderID.String(), refundID, utils.RefundItemsFromDto(reqDto.Items), utils.MoneyFromDto(reqDto.Amount), reqDto.Reason)
+		err = h.os.Commands.RefundOrder.Handle(ctx, command)
+		if err != nil {
+			return err
+		}
+
+		return c.JSON(http.StatusCreated, refundID)
+	}
+}
+
+// RequestReturn
+// @Tags Orders
+// @Summary Request return
+// @Description Request return of completed order items within the return window
+// @Accept json
+// @Produce json
+// @Param order body dto.RequestReturnReqDto true "request return"
+// @Param id path string true "Order ID"
+// @Success 201 {string} returnId ""
+// @Router /orders/return/{id} [post]
+func (h *orderHandlers) RequestReturn() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		span, _ := opentracing.StartSpanFromContext(ctx, "orderHandlers.RequestReturn")
+		defer span.Finish()
+
+		orderID, err := uuid.FromString(c.Param(constants.ID))
+		if err != nil {
+			return errors.NewBadRequestError(c, err.Error(), h.config.Logger.Debug)
+		}
+
+		var reqDto dto.RequestReturnReqDto
+		if err := c.Bind(&reqDto); err != nil {
+			return err
+		}
+
+		if err := h.v.StructCtx(ctx, reqDto); err != nil {
+			return err
+		}
+
+		returnID := uuid.NewV4().String()
+		command := commands.NewRequestReturnCommand(orderID.String(), returnID, utils.ReturnItemsFromDto(reqDto.Items), reqDto.Reason)
+		err = h.os.Commands.RequestReturn.Handle(ctx, command)
+		if err != nil {
+			return err
+		}
+
+		return c.JSON(http.StatusCreated, returnID)
+	}
+}
+
+// ApproveReturn
+// @Tags Orders
+// @Summary Approve return
+// @Description Approve requested order return
+// @Accept json
+// @Produce json
+// @Param id path string true "Order ID"
+// @Param returnId path string true "Return ID"
+// @Success 200 {string} returnId ""
+// @Router /orders/return/{id}/approve/{returnId} [put]
+func (h *orderHandlers) ApproveReturn() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		span, _ := opentracing.StartSpanFromContext(ctx, "orderHandlers.ApproveReturn")
+		defer span.Finish()
+
+		orderID, err := uuid.FromString(c.Param(constants.ID))
+		if err != nil {
+			return errors.NewBadRequestError(c, err.Error(), h.config.Logger.Debug)
+		}
+
+		returnID, err := uuid.FromString(c.Param(constants.ReturnID))
+		if err != nil {
+			return errors.NewBadRequestError(c, err.Error(), h.config.Logger.Debug)
+		}
+
+		command := commands.NewApproveReturnCommand(orderID.String(), returnID.String())
+		if err := h.v.StructCtx(ctx, command); err != nil {
+			return err
+		}
+
+		err = h.os.Commands.ApproveReturn.Handle(ctx, command)
+		if err != nil {
+			return err
+		}
+
+		return c.JSON(http.StatusOK, returnID.String())
+	}
+}
+
+// RejectReturn
+// @Tags Orders
+// @Summary Reject return
+// @Description Reject requested order return
+// @Accept json
+// @Produce json
+// @Param order body dto.RejectReturnReqDto true "reject reason"
+// @Param id path string true "Order ID"
+// @Param returnId path string true "Return ID"
+// @Success 200 {string} returnId ""
+// @Router /orders/return/{id}/reject/{returnId} [put]
+func (h *orderHandlers) RejectReturn() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		span, _ := opentracing.StartSpanFromContext(ctx, "orderHandlers.RejectReturn")
+		defer span.Finish()
+
+		orderID, err := uuid.FromString(c.Param(constants.ID))
+		if err != nil {
+			return errors.NewBadRequestError(c, err.Error(), h.config.Logger.Debug)
+		}
+
+		returnID, err := uuid.FromString(c.Param(constants.ReturnID))
+		if err != nil {
+			return errors.NewBadRequestError(c, err.Error(), h.config.Logger.Debug)
+		}
+
+		var reqDto dto.RejectReturnReqDto
+		if err := c.Bind(&reqDto); err != nil {
+			return err
+		}
+
+		command := commands.NewRejectReturnCommand(orderID.String(), returnID.String(), reqDto.RejectReason)
+		if err := h.v.StructCtx(ctx, command); err != nil {
+			return err
+		}
+
+		err = h.os.Commands.RejectReturn.Handle(ctx, command)
+		if err != nil {
+			return err
+		}
+
+		return c.JSON(http.StatusOK, returnID.String())
+	}
+}
+
+// ReceiveReturn
+// @Tags Orders
+// @Summary Receive return
+// @Description Mark approved return items as received and refund them
+// @Accept json
+// @Produce json
+// @Param id path string true "Order ID"
+// @Param returnId path string true "Return ID"
+// @Success 200 {string} refundId ""
+// @Router /orders/return/{id}/receive/{returnId} [put]
+func (h *orderHandlers) ReceiveReturn() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		span, _ := opentracing.StartSpanFromContext(ctx, "orderHandlers.ReceiveReturn")
+		defer span.Finish()
+
+		orderID, err := uuid.FromString(c.Param(constants.ID))
+		if err != nil {
+			return errors.NewBadRequestError(c, err.Error(), h.config.Logger.Debug)
+		}
+
+		returnID, err := uuid.FromString(c.Param(constants.ReturnID))
+		if err != nil {
+			return errors.NewBadRequestError(c, err.Error(), h.config.Logger.Debug)
+		}
+
+		refundID := uuid.NewV4().String()
+		command := commands.NewReceiveReturnCommand(orderID.String(), returnID.String(), refundID)
+		if err := h.v.StructCtx(ctx, command); err != nil {
+			return err
+		}
+
+		err = h.os.Commands.ReceiveReturn.Handle(ctx, command)
+		if err != nil {
+			return err
+		}
+
+		return c.JSON(http.StatusOK, refundID)
+	}
+}
+
+// CreateShipment
+// @Tags Orders
+// @Summary Create shipment
+// @Description Create shipment for submitted order items
+// @Accept json
+// @Produce json
+// @Param order body dto.CreateShipmentReqDto true "create shipment"
+// @Param id path string true "Order ID"
+// @Success 201 {string} shipmentId ""
+// @Router /orders/shipment/{id} [post]
+func (h *orderHandlers) CreateShipment() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		span, _ := opentracing.StartSpanFromContext(ctx, "orderHandlers.CreateShipment")
+		defer span.Finish()
+
+		orderID, err := uuid.FromString(c.Param(constants.ID))
+		if err != nil {
+			return errors.NewBadRequestError(c, err.Error(), h.config.Logger.Debug)
+		}
+
+		var reqDto dto.CreateShipmentReqDto
+		if err := c.Bind(&reqDto); err != nil {
+			return err
+		}
+
+		if err := h.v.StructCtx(ctx, reqDto); err != nil {
+			return err
+		}
+
+		shipmentID := uuid.NewV4().String()
+		command := commands.NewCreateShipmentCommand(orderID.String(), shipmentID, utils.ShipmentItemsFromDto(reqDto.Items))
+		err = h.os.Commands.CreateShipment.Handle(ctx, command)
+		if err != nil {
+			return err
+		}
+
+		return c.JSON(http.StatusCreated, shipmentID)
+	}
+}
+
+// PackShipment
+// @Tags Orders
+// @Summary Pack shipment
+// @Description Mark shipment as packed
+// @Accept json
+// @Produce json
+// @Param id path string true "Order ID"
+// @Param shipmentId path string true "Shipment ID"
+// @Success 200 {string} shipmentId ""
+// @Router /orders/shipment/{id}/pack/{shipmentId} [put]
+func (h *orderHandlers) PackShipment() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		span, _ := opentracing.StartSpanFromContext(ctx, "orderHandlers.PackShipment")
+		defer span.Finish()
+
+		orderID, err := uuid.FromString(c.Param(constants.ID))
+		if err != nil {
+			return errors.NewBadRequestError(c, err.Error(), h.config.Logger.Debug)
+		}
+
+		shipmentID, err := uuid.FromString(c.Param(constants.ShipmentID))
+		if err != nil {
+			return errors.NewBadRequestError(c, err.Error(), h.config.Logger.Debug)
+		}
+
+		command := commands.NewPackShipmentCommand(orderID.String(), shipmentID.String())
+		if err := h.v.StructCtx(ctx, command); err != nil {
+			return err
+		}
+
+		err = h.os.Commands.PackShipment.Handle(ctx, command)
+		if err != nil {
+			return err
+		}
+
+		return c.JSON(http.StatusOK, shipmentID.String())
+	}
+}
+
+// DispatchShipment
+// @Tags Orders
+// @Summary Dispatch shipment
+// @Description Hand packed shipment over to the carrier
+// @Accept json
+// @Produce json
+// @Param order body dto.DispatchShipmentReqDto true "carrier and tracking number"
+// @Param id path string true "Order ID"
+// @Param shipmentId path string true "Shipment ID"
+// @Success 200 {string} shipmentId ""
+// @Router /orders/shipment/{id}/dispatch/{shipmentId} [put]
+func (h *orderHandlers) DispatchShipment() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		span, _ := opentracing.StartSpanFromContext(ctx, "orderHandlers.DispatchShipment")
+		defer span.Finish()
+
+		orderID, err := uuid.FromString(c.Param(constants.ID))
+		if err != nil {
+			return errors.NewBadRequestError(c, err.Error(), h.config.Logger.Debug)
+		}
+
+		shipmentID, err := uuid.FromString(c.Param(constants.ShipmentID))
+		if err != nil {
+			return errors.NewBadRequestError(c, err.Error(), h.config.Logger.Debug)
+		}
+
+		var reqDto dto.DispatchShipmentReqDto
+		if err := c.Bind(&reqDto); err != nil {
+			return err
+		}
+
+		command := commands.NewDispatchShipmentCommand(orderID.String(), shipmentID.String(), reqDto.Carrier, reqDto.TrackingNumber)
+		if err := h.v.StructCtx(ctx, command); err != nil {
+			return err
+		}
+
+		err = h.os.Commands.DispatchShipment.Handle(ctx, command)
+		if err != nil {
+			return err
+		}
+
+		return c.JSON(http.StatusOK, shipmentID.String())
+	}
+}
+
+// DeliverShipment
+// @Tags Orders
+// @Summary Deliver shipment
+// @Description Mark shipment as delivered, completes the order once every item is delivered
+// @Accept json
+// @Produce json
+// @Param id path string true "Order ID"
+// @Param shipmentId path string true "Shipment ID"
+// @Success 200 {string} shipmentId ""
+// @Router /orders/shipment/{id}/deliver/{shipmentId} [put]
+func (h *orderHandlers) DeliverShipment() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		span, _ := opentracing.StartSpanFromContext(ctx, "orderHandlers.DeliverShipment")
+		defer span.Finish()
+
+		orderID, err := uuid.FromString(c.Param(constants.ID))
+		if err != nil {
+			return errors.NewBadRequestError(c, err.Error(), h.config.Logger.Debug)
+		}
+
+		shipmentID, err := uuid.FromString(c.Param(constants.ShipmentID))
+		if err != nil {
+			return errors.NewBadRequestError(c, err.Error(), h.config.Logger.Debug)
+		}
+
+		command := commands.NewDeliverShipmentCommand(orderID.String(), shipmentID.String(), time.Now())
+		if err := h.v.StructCtx(ctx, command); err != nil {
+			return err
+		}
+
+		err = h.os.Commands.DeliverShipment.Handle(ctx, command)
+		if err != nil {
+			return err
+		}
+
+		return c.JSON(http.StatusOK, shipmentID.String())
+	}
+}
+
+// ApplyCoupon
+// @Tags Orders
+// @Summary Apply coupon
+// @Description Redeem a coupon code on the order
+// @Accept json
+// @Produce json
+// @Param id path string true "Order ID"
+// @Param order body dto.ApplyCouponReqDto true "coupon code"
+// @Success 200 {string} id ""
+// @Router /orders/coupon/{id} [post]
+func (h *orderHandlers) ApplyCoupon() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		span, _ := opentracing.StartSpanFromContext(ctx, "orderHandlers.ApplyCoupon")
+		defer span.Finish()
+
+		orderID, err := uuid.FromString(c.Param(constants.ID))
+		if err != nil {
+			return errors.NewBadRequestError(c, err.Error(), h.config.Logger.Debug)
+		}
+
+		var reqDto dto.ApplyCouponReqDto
+		if err := c.Bind(&reqDto); err != nil {
+			return err
+		}
+
+		if err := h.v.StructCtx(ctx, reqDto); err != nil {
+			return err
+		}
+
+		command := commands.NewApplyCouponCommand(orderID.String(), reqDto.Code)
+		err = h.os.Commands.ApplyCoupon.Handle(ctx, command)
+		if err != nil {
+			return err
+		}
+
+		return c.JSON(http.StatusOK, orderID.String())
+	}
+}
+
+// RemoveCoupon
+// @Tags Orders
+// @Summary Remove coupon
+// @Description Remove a coupon previously applied to the order
+// @Accept json
+// @Produce json
+// @Param id path string true "Order ID"
+// @Param code path string true "Coupon code"
+// @Success 200 {string} id ""
+// @Router /orders/coupon/{id}/{code} [delete]
+func (h *orderHandlers) RemoveCoupon() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		span, _ := opentracing.StartSpanFromContext(ctx, "orderHandlers.RemoveCoupon")
+		defer span.Finish()
+
+		orderID, err := uuid.FromString(c.Param(constants.ID))
+		if err != nil {
+			return errors.NewBadRequestError(c, err.Error(), h.config.Logger.Debug)
+		}
+
+		command := commands.NewRemoveCouponCommand(orderID.String(), c.Param(constants.Code))
+		if err := h.v.StructCtx(ctx, command); err != nil {
+			return err
+		}
+
+		err = h.os.Commands.RemoveCoupon.Handle(ctx, command)
 		if err != nil {
 			return err
 		}
@@ -351,7 +969,7 @@ func (h *orderHandlers) GetOrderByID() echo.HandlerFunc {
 		param := c.Param(constants.ID)
 		orderID, err := uuid.FromString(param)
 		if err != nil {
-			return err
+			return errors.NewBadRequestError(c, err.Error(), h.config.Logger.Debug)
 		}
 
 		query := queries.NewGetOrderByIDQuery(orderID.String())
@@ -375,6 +993,9 @@ func (h *orderHandlers) GetOrderByID() echo.HandlerFunc {
 // @Accept json
 // @Produce json
 // @Param search query string false "search text"
+// @Param status query string false "order status"
+// @Param city query string false "delivery city"
+// @Param country query string false "delivery country ISO code"
 // @Param page query string false "page number"
 // @Param size query string false "number of elements"
 // @Success 200 {object} dto.OrderSearchResponseDto
@@ -387,7 +1008,7 @@ func (h *orderHandlers) Search() echo.HandlerFunc {
 
 		pq := utils.NewPaginationFromQueryParams(c.QueryParam(constants.Size), c.QueryParam(constants.Page))
 
-		query := queries.NewSearchOrdersQuery(c.QueryParam(constants.Search), pq)
+		query := queries.NewSearchOrdersQuery(c.QueryParam(constants.Search), c.QueryParam(constants.Status), c.QueryParam(constants.City), c.QueryParam(constants.Country), pq)
 		if err := h.v.StructCtx(ctx, query); err != nil {
 			return err
 		}
@@ -400,3 +1021,80 @@ func (h *orderHandlers) Search() echo.HandlerFunc {
 		return c.JSON(http.StatusOK, searchRes)
 	}
 }
+
+// ListOrders
+// @Tags Orders
+// @Summary List orders
+// @Description Orders of the mongo read model matching the filters, one page after the cursor
+// @Accept json
+// @Produce json
+// @Param accountEmail query string false "account email"
+// @Param status query string false "order status"
+// @Param createdFrom query string false "created at or after, RFC 3339"
+// @Param createdTo query string false "created before, RFC 3339"
+// @Param paidFrom query string false "paid at or after, RFC 3339"
+// @Param paidTo query string false "paid before, RFC 3339"
+// @Param deliveredFrom query string false "delivered at or after, RFC 3339"
+// @Param deliveredTo query string false "delivered before, RFC 3339"
+// @Param minTotal query int false "minimum total price in minor units"
+// @Param maxTotal query int false "maximum total price in minor units"
+// @Param sort query string false "createdAt or totalPrice, createdAt by default"
+// @Param order query string false "asc or desc, desc by default"
+// @Param size query int false "number of elements, 100 at most"
+// @Param cursor query string false "nextCursor of the previous page"
+// @Success 200 {object} dto.OrderListResponseDto
+// @Router /orders [get]
+func (h *orderHandlers) ListOrders() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		span, _ := opentracing.StartSpanFromContext(ctx, "orderHandlers.ListOrders")
+		defer span.Finish()
+
+		filter, err := orderListFilterFromQueryParams(c)
+		if err != nil {
+			return errors.NewBadRequestError(c, err.Error(), h.config.Logger.Debug)
+		}
+
+		cp, err := utils.NewCursorPaginationFromQueryParams(c.QueryParam(constants.Size), c.QueryParam(constants.Sort), c.QueryParam(constants.SortOrder), c.QueryParam(constants.Cursor))
+		if err != nil {
+			return errors.NewBadRequestError(c, err.Error(), h.config.Logger.Debug)
+		}
+
+		query := queries.NewListOrdersQuery(filter, cp)
+		if err := h.v.StructCtx(ctx, query); err != nil {
+			return err
+		}
+
+		listRes, err := h.os.Queries.ListOrders.Handle(ctx, query)
+		if err != nil {
+			return err
+		}
+
+		return c.JSON(http.StatusOK, listRes)
+	}
+}
+
+// Lifecycle
+// @Tags Orders
+// @Summary Order lifecycle
+// @Description Order statuses and the transitions allowed between them
+// @Accept json
+// @Produce json
+// @Success 200 {object} dto.OrderLifecycleResponseDto
+// @Router /orders/lifecycle [get]
+func (h *orderHandlers) Lifecycle() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, _ := opentracing.StartSpanFromContext(c.Request().Context(), "orderHandlers.Lifecycle")
+		defer span.Finish()
+
+		return c.JSON(http.StatusOK, utils.OrderLifecycleResponseFrom(models.OrderStatuses, aggregate.OrderTransitions))
+	}
+}
+
+// catalogError items referencing unknown or discontinued products break an invariant of the order: 422.
+func (h *orderHandlers) catalogError(c echo.Context, err error) error {
+	if pkgErrors.Is(err, aggregate.ErrProductNotFound) || pkgErrors.Is(err, aggregate.ErrProductDiscontinued) {
+		return errors.NewUnprocessableEntityError(c, err, h.config.Logger.Debug)
+	}
+	return err
+}
@@ -0,0 +1,118 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/go-playground/validator"
+	"github.com/labstack/echo/v4"
+	"github.com/opentracing/opentracing-go"
+	pkgErrors "github.com/pkg/errors"
+
+	"github.com/wassef911/eventually/internal/api/auth"
+	"github.com/wassef911/eventually/internal/api/constants"
+	"github.com/wassef911/eventually/internal/api/dto"
+	api "github.com/wassef911/eventually/internal/api/middlewares"
+	"github.com/wassef911/eventually/internal/api/utils"
+	"github.com/wassef911/eventually/internal/delivery/aggregate"
+	"github.com/wassef911/eventually/internal/delivery/commands"
+	"github.com/wassef911/eventually/internal/delivery/queries"
+	service "github.com/wassef911/eventually/internal/delivery/services"
+	"github.com/wassef911/eventually/pkg/config"
+	"github.com/wassef911/eventually/pkg/errors"
+	"github.com/wassef911/eventually/pkg/logger"
+)
+
+type InventoryHandlersI interface {
+	AddStock() echo.HandlerFunc
+	GetInventoryBySKU() echo.HandlerFunc
+	MapRoutes()
+}
+
+var _ InventoryHandlersI = &inventoryHandlers{}
+
+type inventoryHandlers struct {
+	group  *echo.Group
+	log    logger.Logger
+	mw     api.MiddlewareManager
+	config *config.Config
+	v      *validator.Validate
+	is     *service.InventoryService
+}
+
+func NewInventoryHandlers(
+	group *echo.Group,
+	log logger.Logger,
+	mw api.MiddlewareManager,
+	config *config.Config,
+	v *validator.Validate,
+	is *service.InventoryService,
+) *inventoryHandlers {
+	return &inventoryHandlers{group: group, log: log, mw: mw, config: config, v: v, is: is}
+}
+
+func (h *inventoryHandlers) MapRoutes() {
+	h.group.POST("/:sku/stock", h.AddStock(), h.mw.Authorize(auth.PermissionManageCatalog))
+	h.group.GET("/:sku", h.GetInventoryBySKU(), h.mw.Authorize(auth.PermissionReadCatalog))
+}
+
+// AddStock
+// @Tags Inventory
+// @Summary Add stock
+// @Description Add received units to the stock of a SKU, the first stock added starts tracking the SKU
+// @Param stock body dto.AddStockReqDto true "received quantity"
+// @Param sku path string true "SKU"
+// @Accept json
+// @Produce json
+// @Success 200 {string} sku ""
+// @Router /inventory/{sku}/stock [post]
+func (h *inventoryHandlers) AddStock() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		span, _ := opentracing.StartSpanFromContext(ctx, "inventoryHandlers.AddStock")
+		defer span.Finish()
+
+		var reqDto dto.AddStockReqDto
+		if err := c.Bind(&reqDto); err != nil {
+			return err
+		}
+
+		command := commands.NewAddStockCommand(c.Param(constants.SKU), reqDto.Quantity)
+		if err := h.v.StructCtx(ctx, command); err != nil {
+			return err
+		}
+
+		if err := h.is.Commands.AddStock.Handle(ctx, command); err != nil {
+			return err
+		}
+
+		return c.JSON(http.StatusOK, command.GetAggregateID())
+	}
+}
+
+// GetInventoryBySKU
+// @Tags Inventory
+// @Summary Get stock levels
+// @Description Get stock on hand, reserved and available units of a SKU
+// @Accept json
+// @Produce json
+// @Param sku path string true "SKU"
+// @Success 200 {object} dto.InventoryResponseDto
+// @Router /inventory/{sku} [get]
+func (h *inventoryHandlers) GetInventoryBySKU() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		span, _ := opentracing.StartSpanFromContext(ctx, "inventoryHandlers.GetInventoryBySKU")
+		defer span.Finish()
+
+		query := queries.NewGetInventoryBySKUQuery(c.Param(constants.SKU))
+		inventory, err := h.is.Queries.GetInventoryBySKU.Handle(ctx, query)
+		if pkgErrors.Is(err, aggregate.ErrInventoryNotFound) {
+			return errors.NewNotFoundError(c, err, h.config.Logger.Debug)
+		}
+		if err != nil {
+			return err
+		}
+
+		return c.JSON(http.StatusOK, utils.InventoryResponseFromModel(inventory))
+	}
+}
@@ -0,0 +1,48 @@
+package handlers
+
+import (
+	"github.com/labstack/echo/v4"
+	pkgErrors "github.com/pkg/errors"
+	uuid "github.com/satori/go.uuid"
+
+	"github.com/wassef911/eventually/internal/api/auth"
+	"github.com/wassef911/eventually/internal/api/constants"
+	"github.com/wassef911/eventually/internal/delivery/aggregate"
+	"github.com/wassef911/eventually/internal/delivery/queries"
+	service "github.com/wassef911/eventually/internal/delivery/services"
+	"github.com/wassef911/eventually/pkg/config"
+	"github.com/wassef911/eventually/pkg/errors"
+)
+
+// orderOwner customers only reach the orders placed with their account email or customer id, the order id is the id path param.
+// It runs after the Authorize middleware, support and admins are let through without loading the order.
+func orderOwner(os *service.OrderService, config *config.Config) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			ctx := c.Request().Context()
+			principal, ok := auth.PrincipalFromContext(ctx)
+			if !ok || !principal.OwnsOrdersOnly() {
+				return next(c)
+			}
+
+			orderID, err := uuid.FromString(c.Param(constants.ID))
+			if err != nil {
+				// the handler answers malformed ids
+				return next(c)
+			}
+
+			orderProjection, err := os.Queries.GetOrderByID.Handle(ctx, queries.NewGetOrderByIDQuery(orderID.String()))
+			if pkgErrors.Is(err, aggregate.ErrOrderNotFound) {
+				return errors.NewNotFoundError(c, err, config.Logger.Debug)
+			}
+			if err != nil {
+				return err
+			}
+
+			if !principal.CanAccessOrder(orderProjection.AccountEmail, orderProjection.CustomerID) {
+				return pkgErrors.Wrap(errors.Forbidden, "order belongs to another customer")
+			}
+			return next(c)
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package handlers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wassef911/eventually/internal/api/auth"
+	"github.com/wassef911/eventually/internal/delivery/aggregate"
+	"github.com/wassef911/eventually/internal/delivery/commands"
+	"github.com/wassef911/eventually/internal/delivery/models"
+	"github.com/wassef911/eventually/internal/infrastructure/es"
+	"github.com/wassef911/eventually/pkg/config"
+	"github.com/wassef911/eventually/pkg/logger"
+)
+
+func TestCreateOrder_RejectsCustomerOfAnotherAccount(t *testing.T) {
+	ctx := context.Background()
+	appLogger := logger.NewAppLogger(&logger.Config{LogLevel: "fatal"})
+	appLogger.InitLogger()
+	cfg := &config.Config{Logger: &logger.Config{}}
+	store := &memoryStore{streams: make(map[string][]es.Event)}
+
+	customer := aggregate.NewCustomerAggregateWithID("customer2")
+	require.NoError(t, customer.Register(ctx, "john@example.com", "John"))
+	require.NoError(t, store.Save(ctx, customer))
+
+	principal := &auth.Principal{Subject: "customer-1", Email: "jane@example.com", Role: auth.RoleCustomer}
+	ctx = auth.ContextWithPrincipal(ctx, principal)
+	accountEmail, err := auth.OrderAccountEmail(ctx, "")
+	require.NoError(t, err)
+
+	items := []*models.OrderItem{{ProductID: "product1", Quantity: 1}}
+	address := models.Address{Recipient: "Jane Doe", Line1: "1 Main St", City: "Springfield", PostalCode: "62701", Region: "IL", Country: "US"}
+	createOrder := commands.NewCreateOrderHandler(appLogger, cfg, store, nil)
+	err = createOrder.Handle(ctx, commands.NewCreateOrderCommand(uuid.NewV4().String(), items, "customer2", accountEmail, address))
+	assert.True(t, errors.Is(err, aggregate.ErrCustomerEmailMismatch))
+}
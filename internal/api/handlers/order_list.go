@@ -0,0 +1,64 @@
+package handlers
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+
+	"github.com/wassef911/eventually/internal/api/constants"
+	"github.com/wassef911/eventually/internal/delivery/models"
+)
+
+// orderListFilterFromQueryParams dates are RFC 3339 timestamps, totals are amounts in minor units.
+func orderListFilterFromQueryParams(c echo.Context) (models.OrderListFilter, error) {
+	filter := models.OrderListFilter{
+		AccountEmail: c.QueryParam(constants.AccountEmail),
+		Status:       models.OrderStatus(c.QueryParam(constants.Status)),
+	}
+
+	times := []struct {
+		param string
+		value *time.Time
+	}{
+		{param: constants.CreatedFrom, value: &filter.CreatedFrom},
+		{param: constants.CreatedTo, value: &filter.CreatedTo},
+		{param: constants.PaidFrom, value: &filter.PaidFrom},
+		{param: constants.PaidTo, value: &filter.PaidTo},
+		{param: constants.DeliveredFrom, value: &filter.DeliveredFrom},
+		{param: constants.DeliveredTo, value: &filter.DeliveredTo},
+	}
+	for _, t := range times {
+		param := c.QueryParam(t.param)
+		if param == "" {
+			continue
+		}
+		value, err := time.Parse(time.RFC3339, param)
+		if err != nil {
+			return filter, errors.Wrapf(err, "query param {%s}", t.param)
+		}
+		*t.value = value.UTC()
+	}
+
+	totals := []struct {
+		param string
+		value **int64
+	}{
+		{param: constants.MinTotal, value: &filter.MinTotal},
+		{param: constants.MaxTotal, value: &filter.MaxTotal},
+	}
+	for _, t := range totals {
+		param := c.QueryParam(t.param)
+		if param == "" {
+			continue
+		}
+		value, err := strconv.ParseInt(param, 10, 64)
+		if err != nil {
+			return filter, errors.Wrapf(err, "query param {%s}", t.param)
+		}
+		*t.value = &value
+	}
+
+	return filter, nil
+}
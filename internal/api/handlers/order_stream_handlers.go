@@ -0,0 +1,252 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/go-playground/validator"
+	"github.com/gorilla/websocket"
+	"github.com/labstack/echo/v4"
+	"github.com/opentracing/opentracing-go"
+	pkgErrors "github.com/pkg/errors"
+	uuid "github.com/satori/go.uuid"
+
+	"github.com/wassef911/eventually/internal/api/auth"
+	"github.com/wassef911/eventually/internal/api/constants"
+	api "github.com/wassef911/eventually/internal/api/middlewares"
+	"github.com/wassef911/eventually/internal/delivery/aggregate"
+	"github.com/wassef911/eventually/internal/delivery/live"
+	"github.com/wassef911/eventually/internal/delivery/queries"
+	service "github.com/wassef911/eventually/internal/delivery/services"
+	"github.com/wassef911/eventually/pkg/config"
+	"github.com/wassef911/eventually/pkg/errors"
+	"github.com/wassef911/eventually/pkg/logger"
+)
+
+const (
+	heartbeatInterval  = 15 * time.Second
+	websocketWriteWait = 10 * time.Second
+)
+
+type OrderStreamHandlersI interface {
+	StreamOrderEvents() echo.HandlerFunc
+	StreamOrderEventsWebSocket() echo.HandlerFunc
+	MapRoutes()
+}
+
+var _ OrderStreamHandlersI = &orderStreamHandlers{}
+
+type orderStreamHandlers struct {
+	group    *echo.Group
+	log      logger.Logger
+	mw       api.MiddlewareManager
+	config   *config.Config
+	v        *validator.Validate
+	os       *service.OrderService
+	stream   *live.OrderStream
+	upgrader websocket.Upgrader
+}
+
+func NewOrderStreamHandlers(
+	group *echo.Group,
+	log logger.Logger,
+	mw api.MiddlewareManager,
+	config *config.Config,
+	v *validator.Validate,
+	os *service.OrderService,
+	stream *live.OrderStream,
+) *orderStreamHandlers {
+	return &orderStreamHandlers{
+		group:  group,
+		log:    log,
+		mw:     mw,
+		config: config,
+		v:      v,
+		os:     os,
+		stream: stream,
+		// the stream is read only and served to storefronts on other origins
+		upgrader: websocket.Upgrader{CheckOrigin: func(r *http.Request) bool { return true }},
+	}
+}
+
+func (h *orderStreamHandlers) MapRoutes() {
+	h.group.GET("/:id/stream", h.StreamOrderEvents(), h.mw.Authorize(auth.PermissionReadOrder), orderOwner(h.os, h.config))
+	h.group.GET("/:id/ws", h.StreamOrderEventsWebSocket(), h.mw.Authorize(auth.PermissionReadOrder), orderOwner(h.os, h.config))
+}
+
+// StreamOrderEvents
+// @Tags Orders
+// @Summary Stream order events
+// @Description Server-sent events of the order, from its first event or after the version in the Last-Event-ID header
+// @Produce text/event-stream
+// @Param id path string true "Order ID"
+// @Param Last-Event-ID header string false "version of the last event received"
+// @Success 200 {object} integration.Event
+// @Router /orders/{id}/stream [get]
+func (h *orderStreamHandlers) StreamOrderEvents() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		span, ctx := opentracing.StartSpanFromContext(ctx, "orderStreamHandlers.StreamOrderEvents")
+		defer span.Finish()
+
+		lastEventID := c.Request().Header.Get(live.LastEventIDHeader)
+		if lastEventID == "" {
+			lastEventID = c.QueryParam(constants.LastEventID)
+		}
+		orderID, after, err := h.parseStreamRequest(ctx, c, lastEventID)
+		if err != nil {
+			return h.streamError(c, err)
+		}
+
+		// the server write timeout would cut the stream
+		if err := http.NewResponseController(c.Response().Writer).SetWriteDeadline(time.Time{}); err != nil {
+			h.log.Warnf("(StreamOrderEvents) [SetWriteDeadline] err: {%v}", err)
+		}
+
+		res := c.Response()
+		res.Header().Set(echo.HeaderContentType, "text/event-stream")
+		res.Header().Set("Cache-Control", "no-cache")
+		res.Header().Set("Connection", "keep-alive")
+		res.Header().Set("X-Accel-Buffering", "no")
+		res.WriteHeader(http.StatusOK)
+		res.Flush()
+
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		events, errCh := h.stream.Subscribe(ctx, orderID, after)
+
+		ticker := time.NewTicker(heartbeatInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case evt, ok := <-events:
+				if !ok {
+					if err := <-errCh; err != nil && ctx.Err() == nil {
+						h.log.Warnf("(StreamOrderEvents) orderID: {%s}, err: {%v}", orderID, err)
+					}
+					return nil
+				}
+				if err := live.WriteEvent(res, evt); err != nil {
+					return nil
+				}
+				res.Flush()
+
+			case <-ticker.C:
+				if err := live.WriteHeartbeat(res); err != nil {
+					return nil
+				}
+				res.Flush()
+
+			case <-ctx.Done():
+				return nil
+			}
+		}
+	}
+}
+
+// StreamOrderEventsWebSocket
+// @Tags Orders
+// @Summary Stream order events over a WebSocket
+// @Description Each message is an order event, the lastEventId query parameter resumes after the given version
+// @Param id path string true "Order ID"
+// @Param lastEventId query string false "version of the last event received"
+// @Success 101 {object} integration.Event
+// @Router /orders/{id}/ws [get]
+func (h *orderStreamHandlers) StreamOrderEventsWebSocket() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		span, ctx := opentracing.StartSpanFromContext(ctx, "orderStreamHandlers.StreamOrderEventsWebSocket")
+		defer span.Finish()
+
+		orderID, after, err := h.parseStreamRequest(ctx, c, c.QueryParam(constants.LastEventID))
+		if err != nil {
+			return h.streamError(c, err)
+		}
+
+		conn, err := h.upgrader.Upgrade(c.Response(), c.Request(), nil)
+		if err != nil {
+			// the upgrader already replied
+			h.log.Warnf("(StreamOrderEventsWebSocket) [Upgrade] err: {%v}", err)
+			return nil
+		}
+		defer conn.Close()
+
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		// clients only send control frames, reading handles them and notices when the client leaves
+		go func() {
+			defer cancel()
+			for {
+				if _, _, err := conn.ReadMessage(); err != nil {
+					return
+				}
+			}
+		}()
+
+		events, errCh := h.stream.Subscribe(ctx, orderID, after)
+
+		ticker := time.NewTicker(heartbeatInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case evt, ok := <-events:
+				if !ok {
+					closeCode, closeText := websocket.CloseNormalClosure, ""
+					if err := <-errCh; err != nil && ctx.Err() == nil {
+						h.log.Warnf("(StreamOrderEventsWebSocket) orderID: {%s}, err: {%v}", orderID, err)
+						closeCode, closeText = websocket.CloseInternalServerErr, "stream interrupted"
+					}
+					_ = conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(closeCode, closeText), time.Now().Add(websocketWriteWait))
+					return nil
+				}
+				_ = conn.SetWriteDeadline(time.Now().Add(websocketWriteWait))
+				if err := conn.WriteJSON(evt); err != nil {
+					return nil
+				}
+
+			case <-ticker.C:
+				if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(websocketWriteWait)); err != nil {
+					return nil
+				}
+
+			case <-ctx.Done():
+				return nil
+			}
+		}
+	}
+}
+
+var errInvalidStreamRequest = pkgErrors.New("invalid stream request")
+
+// parseStreamRequest checks the order exists before the response is turned into a stream.
+func (h *orderStreamHandlers) parseStreamRequest(ctx context.Context, c echo.Context, lastEventID string) (string, int64, error) {
+	orderID, err := uuid.FromString(c.Param(constants.ID))
+	if err != nil {
+		return "", 0, pkgErrors.Wrap(errInvalidStreamRequest, err.Error())
+	}
+
+	after, err := live.ParseLastEventID(lastEventID)
+	if err != nil {
+		return "", 0, pkgErrors.Wrap(errInvalidStreamRequest, err.Error())
+	}
+
+	if _, err := h.os.Queries.GetOrderByID.Handle(ctx, queries.NewGetOrderByIDQuery(orderID.String())); err != nil {
+		return "", 0, err
+	}
+
+	return orderID.String(), after, nil
+}
+
+func (h *orderStreamHandlers) streamError(c echo.Context, err error) error {
+	if pkgErrors.Is(err, errInvalidStreamRequest) {
+		return errors.NewBadRequestError(c, err.Error(), h.config.Logger.Debug)
+	}
+	if pkgErrors.Is(err, aggregate.ErrOrderNotFound) {
+		return errors.NewNotFoundError(c, err, h.config.Logger.Debug)
+	}
+	return err
+}
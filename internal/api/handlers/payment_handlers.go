@@ -0,0 +1,99 @@
+package handlers
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/go-playground/validator"
+	"github.com/labstack/echo/v4"
+	"github.com/opentracing/opentracing-go"
+	pkgErrors "github.com/pkg/errors"
+
+	api "github.com/wassef911/eventually/internal/api/middlewares"
+	"github.com/wassef911/eventually/internal/delivery/aggregate"
+	"github.com/wassef911/eventually/internal/delivery/commands"
+	"github.com/wassef911/eventually/internal/delivery/payment"
+	service "github.com/wassef911/eventually/internal/delivery/services"
+	"github.com/wassef911/eventually/pkg/config"
+	"github.com/wassef911/eventually/pkg/errors"
+	"github.com/wassef911/eventually/pkg/logger"
+)
+
+type PaymentHandlersI interface {
+	Webhook() echo.HandlerFunc
+	MapRoutes()
+}
+
+var _ PaymentHandlersI = &paymentHandlers{}
+
+type paymentHandlers struct {
+	group   *echo.Group
+	log     logger.Logger
+	mw      api.MiddlewareManager
+	config  *config.Config
+	v       *validator.Validate
+	os      *service.OrderService
+	gateway payment.Gateway
+}
+
+func NewPaymentHandlers(
+	group *echo.Group,
+	log logger.Logger,
+	mw api.MiddlewareManager,
+	config *config.Config,
+	v *validator.Validate,
+	os *service.OrderService,
+	gateway payment.Gateway,
+) *paymentHandlers {
+	return &paymentHandlers{group: group, log: log, mw: mw, config: config, v: v, os: os, gateway: gateway}
+}
+
+func (h *paymentHandlers) MapRoutes() {
+	h.group.POST("/webhook", h.Webhook())
+}
+
+// Webhook
+// @Tags Payments
+// @Summary Payment provider webhook
+// @Description Receive the outcome of a pending payment, the raw body must be signed by the provider
+// @Accept json
+// @Produce json
+// @Param X-Payment-Signature header string true "provider signature of the body"
+// @Success 200 {string} id ""
+// @Router /payments/webhook [post]
+func (h *paymentHandlers) Webhook() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		span, _ := opentracing.StartSpanFromContext(ctx, "paymentHandlers.Webhook")
+		defer span.Finish()
+
+		// the signature covers the exact bytes sent by the provider
+		payload, err := io.ReadAll(c.Request().Body)
+		if err != nil {
+			return err
+		}
+
+		event, err := h.gateway.VerifyWebhook(payload, c.Request().Header.Get(payment.SignatureHeader))
+		if pkgErrors.Is(err, payment.ErrInvalidSignature) {
+			return errors.NewUnauthorizedError(c, err.Error(), h.config.Logger.Debug)
+		}
+		if err != nil {
+			return errors.NewBadRequestError(c, err.Error(), h.config.Logger.Debug)
+		}
+
+		command := commands.NewPaymentWebhookCommand(event.OrderID, event.Type, event.PaymentID, event.Reason)
+		if err := h.v.StructCtx(ctx, command); err != nil {
+			return err
+		}
+
+		err = h.os.Commands.PaymentWebhook.Handle(ctx, command)
+		if pkgErrors.Is(err, aggregate.ErrPaymentAttemptNotFound) {
+			return errors.NewNotFoundError(c, err, h.config.Logger.Debug)
+		}
+		if err != nil {
+			return err
+		}
+
+		return c.JSON(http.StatusOK, event.PaymentID)
+	}
+}
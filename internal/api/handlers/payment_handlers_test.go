@@ -0,0 +1,137 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/EventStore/EventStore-Client-Go/esdb"
+	"github.com/go-playground/validator"
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wassef911/eventually/internal/delivery/aggregate"
+	"github.com/wassef911/eventually/internal/delivery/commands"
+	"github.com/wassef911/eventually/internal/delivery/models"
+	"github.com/wassef911/eventually/internal/delivery/payment"
+	service "github.com/wassef911/eventually/internal/delivery/services"
+	"github.com/wassef911/eventually/internal/infrastructure/es"
+	"github.com/wassef911/eventually/pkg/config"
+	"github.com/wassef911/eventually/pkg/logger"
+)
+
+type memoryStore struct {
+	streams map[string][]es.Event
+}
+
+func (m *memoryStore) Load(ctx context.Context, aggregate es.Aggregate) error {
+	for _, event := range m.streams[aggregate.GetID()] {
+		if err := aggregate.RaiseEvent(event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *memoryStore) Save(ctx context.Context, aggregate es.Aggregate) error {
+	m.streams[aggregate.GetID()] = append(m.streams[aggregate.GetID()], aggregate.GetUncommittedEvents()...)
+	aggregate.ClearUncommittedEvents()
+	return nil
+}
+
+func (m *memoryStore) Exists(ctx context.Context, streamID string) error {
+	if _, ok := m.streams[streamID]; !ok {
+		return errors.Wrap(esdb.ErrStreamNotFound, "Exists")
+	}
+	return nil
+}
+
+func TestPaymentHandlers_WebhookCapturesPendingPayment(t *testing.T) {
+	ctx := context.Background()
+	appLogger := logger.NewAppLogger(&logger.Config{LogLevel: "fatal"})
+	appLogger.InitLogger()
+	cfg := &config.Config{Logger: &logger.Config{}}
+	store := &memoryStore{streams: make(map[string][]es.Event)}
+	gateway := payment.NewFakeGateway("secret")
+
+	orderID := uuid.NewV4().String()
+	order := aggregate.NewOrderAggregateWithID(orderID)
+	shopItems := []*models.ShopItem{{ID: "item1", Title: "Item 1", Quantity: 1, Price: models.NewMoney(1000, "USD")}}
+	require.NoError(t, order.CreateOrder(ctx, shopItems, "", "jane@example.com", models.Address{Recipient: "Jane Doe", Line1: "1 Main St", City: "Springfield", PostalCode: "62701", Region: "IL", Country: "US"}, 0))
+	require.NoError(t, store.Save(ctx, order))
+
+	pay := commands.NewOrderPaidHandler(appLogger, cfg, store, gateway)
+	require.NoError(t, pay.Handle(ctx, commands.NewPayOrderCommand(orderID, payment.FakeTokenPending)))
+
+	pending, err := aggregate.LoadOrderAggregate(ctx, store, orderID)
+	require.NoError(t, err)
+	require.Len(t, pending.Order.PaymentAttempts, 1)
+	require.False(t, pending.Order.Paid())
+
+	os := &service.OrderService{Commands: &commands.OrderCommand{PaymentWebhook: *commands.NewPaymentWebhookCommandHandler(appLogger, cfg, store, gateway)}}
+	e := echo.New()
+	NewPaymentHandlers(e.Group("/api/payments"), appLogger, nil, cfg, validator.New(), os, gateway).MapRoutes()
+
+	payload, err := gateway.Webhook(pending.Order.PaymentAttempts[0].PaymentID, payment.WebhookPaymentCaptured, "")
+	require.NoError(t, err)
+	req := httptest.NewRequest(http.MethodPost, "/api/payments/webhook", bytes.NewReader(payload))
+	req.Header.Set(payment.SignatureHeader, gateway.SignWebhook(payload))
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	paid, err := aggregate.LoadOrderAggregate(ctx, store, orderID)
+	require.NoError(t, err)
+	assert.True(t, paid.Order.Paid())
+	assert.Equal(t, models.OrderStatusPaid, paid.Order.Status)
+}
+
+func TestPaymentHandlers_WebhookRefundsCaptureOfCanceledOrder(t *testing.T) {
+	ctx := context.Background()
+	appLogger := logger.NewAppLogger(&logger.Config{LogLevel: "fatal"})
+	appLogger.InitLogger()
+	cfg := &config.Config{Logger: &logger.Config{}}
+	store := &memoryStore{streams: make(map[string][]es.Event)}
+	gateway := payment.NewFakeGateway("secret")
+
+	orderID := uuid.NewV4().String()
+	order := aggregate.NewOrderAggregateWithID(orderID)
+	shopItems := []*models.ShopItem{{ID: "item1", Title: "Item 1", Quantity: 1, Price: models.NewMoney(1000, "USD")}}
+	require.NoError(t, order.CreateOrder(ctx, shopItems, "", "jane@example.com", models.Address{Recipient: "Jane Doe", Line1: "1 Main St", City: "Springfield", PostalCode: "62701", Region: "IL", Country: "US"}, 0))
+	require.NoError(t, store.Save(ctx, order))
+
+	pay := commands.NewOrderPaidHandler(appLogger, cfg, store, gateway)
+	require.NoError(t, pay.Handle(ctx, commands.NewPayOrderCommand(orderID, payment.FakeTokenPending)))
+
+	pending, err := aggregate.LoadOrderAggregate(ctx, store, orderID)
+	require.NoError(t, err)
+	require.NoError(t, pending.CancelOrder(ctx, "changed my mind"))
+	require.NoError(t, store.Save(ctx, pending))
+
+	os := &service.OrderService{Commands: &commands.OrderCommand{PaymentWebhook: *commands.NewPaymentWebhookCommandHandler(appLogger, cfg, store, gateway)}}
+	e := echo.New()
+	NewPaymentHandlers(e.Group("/api/payments"), appLogger, nil, cfg, validator.New(), os, gateway).MapRoutes()
+
+	payload, err := gateway.Webhook(pending.Order.PaymentAttempts[0].PaymentID, payment.WebhookPaymentCaptured, "")
+	require.NoError(t, err)
+	for range 2 {
+		req := httptest.NewRequest(http.MethodPost, "/api/payments/webhook", bytes.NewReader(payload))
+		req.Header.Set(payment.SignatureHeader, gateway.SignWebhook(payload))
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	}
+
+	canceled, err := aggregate.LoadOrderAggregate(ctx, store, orderID)
+	require.NoError(t, err)
+	assert.False(t, canceled.Order.Paid())
+	assert.Equal(t, models.OrderStatusCanceled, canceled.Order.Status)
+	require.Len(t, canceled.Order.PaymentAttempts, 1)
+	assert.Equal(t, models.PaymentAttemptFailed, canceled.Order.PaymentAttempts[0].Status)
+	assert.Equal(t, commands.PaymentCapturedTooLateReason, canceled.Order.PaymentAttempts[0].FailureReason)
+}
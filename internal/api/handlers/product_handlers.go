@@ -0,0 +1,236 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/go-playground/validator"
+	"github.com/labstack/echo/v4"
+	"github.com/opentracing/opentracing-go"
+	pkgErrors "github.com/pkg/errors"
+
+	"github.com/wassef911/eventually/internal/api/auth"
+	"github.com/wassef911/eventually/internal/api/constants"
+	"github.com/wassef911/eventually/internal/api/dto"
+	api "github.com/wassef911/eventually/internal/api/middlewares"
+	"github.com/wassef911/eventually/internal/api/utils"
+	"github.com/wassef911/eventually/internal/delivery/aggregate"
+	"github.com/wassef911/eventually/internal/delivery/commands"
+	"github.com/wassef911/eventually/internal/delivery/models"
+	"github.com/wassef911/eventually/internal/delivery/queries"
+	service "github.com/wassef911/eventually/internal/delivery/services"
+	"github.com/wassef911/eventually/pkg/config"
+	"github.com/wassef911/eventually/pkg/errors"
+	"github.com/wassef911/eventually/pkg/logger"
+)
+
+type ProductHandlersI interface {
+	CreateProduct() echo.HandlerFunc
+	RepriceProduct() echo.HandlerFunc
+	DiscontinueProduct() echo.HandlerFunc
+	GetProductByID() echo.HandlerFunc
+	ListProducts() echo.HandlerFunc
+	MapRoutes()
+}
+
+var _ ProductHandlersI = &productHandlers{}
+
+type productHandlers struct {
+	group  *echo.Group
+	log    logger.Logger
+	mw     api.MiddlewareManager
+	config *config.Config
+	v      *validator.Validate
+	ps     *service.ProductService
+}
+
+func NewProductHandlers(
+	group *echo.Group,
+	log logger.Logger,
+	mw api.MiddlewareManager,
+	config *config.Config,
+	v *validator.Validate,
+	ps *service.ProductService,
+) *productHandlers {
+	return &productHandlers{group: group, log: log, mw: mw, config: config, v: v, ps: ps}
+}
+
+func (h *productHandlers) MapRoutes() {
+	read := h.mw.Authorize(auth.PermissionReadCatalog)
+	manage := h.mw.Authorize(auth.PermissionManageCatalog)
+
+	h.group.POST("", h.CreateProduct(), manage)
+	h.group.PUT("/:id/price", h.RepriceProduct(), manage)
+	h.group.PUT("/:id/discontinue", h.DiscontinueProduct(), manage)
+	h.group.GET("/:id", h.GetProductByID(), read)
+	h.group.GET("", h.ListProducts(), read)
+}
+
+// CreateProduct
+// @Tags Products
+// @Summary Create product
+// @Description Add a product to the catalog, the product id is the SKU of the ordered shop items
+// @Param product body dto.CreateProductReqDto true "create product"
+// @Accept json
+// @Produce json
+// @Success 201 {string} id ""
+// @Router /products [post]
+func (h *productHandlers) CreateProduct() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		span, _ := opentracing.StartSpanFromContext(ctx, "productHandlers.CreateProduct")
+		defer span.Finish()
+
+		var reqDto dto.CreateProductReqDto
+		if err := c.Bind(&reqDto); err != nil {
+			return err
+		}
+
+		if err := h.v.StructCtx(ctx, reqDto); err != nil {
+			return err
+		}
+
+		price := models.NewMoney(reqDto.Price.Amount, reqDto.Price.Currency)
+		command := commands.NewCreateProductCommand(reqDto.ProductID, reqDto.Title, reqDto.Description, price, reqDto.TaxCategory)
+		if err := h.ps.Commands.CreateProduct.Handle(ctx, command); err != nil {
+			return h.productError(c, err)
+		}
+
+		return c.JSON(http.StatusCreated, command.GetAggregateID())
+	}
+}
+
+// RepriceProduct
+// @Tags Products
+// @Summary Reprice product
+// @Description Change the catalog price, orders already placed keep their price
+// @Param price body dto.RepriceProductReqDto true "new price"
+// @Param id path string true "Product ID"
+// @Accept json
+// @Produce json
+// @Success 200 {string} id ""
+// @Router /products/{id}/price [put]
+func (h *productHandlers) RepriceProduct() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		span, _ := opentracing.StartSpanFromContext(ctx, "productHandlers.RepriceProduct")
+		defer span.Finish()
+
+		var reqDto dto.RepriceProductReqDto
+		if err := c.Bind(&reqDto); err != nil {
+			return err
+		}
+
+		if err := h.v.StructCtx(ctx, reqDto); err != nil {
+			return err
+		}
+
+		price := models.NewMoney(reqDto.Price.Amount, reqDto.Price.Currency)
+		command := commands.NewRepriceProductCommand(c.Param(constants.ID), price)
+		if err := h.ps.Commands.RepriceProduct.Handle(ctx, command); err != nil {
+			return h.productError(c, err)
+		}
+
+		return c.JSON(http.StatusOK, command.GetAggregateID())
+	}
+}
+
+// DiscontinueProduct
+// @Tags Products
+// @Summary Discontinue product
+// @Description Discontinued products can no longer be added to orders
+// @Param reason body dto.DiscontinueProductReqDto false "discontinue reason"
+// @Param id path string true "Product ID"
+// @Accept json
+// @Produce json
+// @Success 200 {string} id ""
+// @Router /products/{id}/discontinue [put]
+func (h *productHandlers) DiscontinueProduct() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		span, _ := opentracing.StartSpanFromContext(ctx, "productHandlers.DiscontinueProduct")
+		defer span.Finish()
+
+		var reqDto dto.DiscontinueProductReqDto
+		if err := c.Bind(&reqDto); err != nil {
+			return err
+		}
+
+		command := commands.NewDiscontinueProductCommand(c.Param(constants.ID), reqDto.Reason)
+		if err := h.ps.Commands.DiscontinueProduct.Handle(ctx, command); err != nil {
+			return h.productError(c, err)
+		}
+
+		return c.JSON(http.StatusOK, command.GetAggregateID())
+	}
+}
+
+// GetProductByID
+// @Tags Products
+// @Summary Get product
+// @Description Get catalog product by id
+// @Accept json
+// @Produce json
+// @Param id path string true "Product ID"
+// @Success 200 {object} dto.ProductResponseDto
+// @Router /products/{id} [get]
+func (h *productHandlers) GetProductByID() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		span, _ := opentracing.StartSpanFromContext(ctx, "productHandlers.GetProductByID")
+		defer span.Finish()
+
+		query := queries.NewGetProductByIDQuery(c.Param(constants.ID))
+		product, err := h.ps.Queries.GetProductByID.Handle(ctx, query)
+		if err != nil {
+			return h.productError(c, err)
+		}
+
+		return c.JSON(http.StatusOK, utils.ProductResponseFromModel(product))
+	}
+}
+
+// ListProducts
+// @Tags Products
+// @Summary List products
+// @Description Catalog products ordered by id
+// @Accept json
+// @Produce json
+// @Param discontinued query bool false "include discontinued products"
+// @Param page query string false "page number"
+// @Param size query string false "number of elements"
+// @Success 200 {object} dto.ProductListResponseDto
+// @Router /products [get]
+func (h *productHandlers) ListProducts() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		span, _ := opentracing.StartSpanFromContext(ctx, "productHandlers.ListProducts")
+		defer span.Finish()
+
+		includeDiscontinued := false
+		if param := c.QueryParam(constants.Discontinued); param != "" {
+			value, err := strconv.ParseBool(param)
+			if err != nil {
+				return errors.NewBadRequestError(c, err.Error(), h.config.Logger.Debug)
+			}
+			includeDiscontinued = value
+		}
+
+		pq := utils.NewPaginationFromQueryParams(c.QueryParam(constants.Size), c.QueryParam(constants.Page))
+
+		query := queries.NewListProductsQuery(includeDiscontinued, pq)
+		productsRes, err := h.ps.Queries.ListProducts.Handle(ctx, query)
+		if err != nil {
+			return err
+		}
+
+		return c.JSON(http.StatusOK, productsRes)
+	}
+}
+
+func (h *productHandlers) productError(c echo.Context, err error) error {
+	if pkgErrors.Is(err, aggregate.ErrProductNotFound) {
+		return errors.NewNotFoundError(c, err, h.config.Logger.Debug)
+	}
+	return err
+}
@@ -0,0 +1,228 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-playground/validator"
+	"github.com/labstack/echo/v4"
+	"github.com/opentracing/opentracing-go"
+	pkgErrors "github.com/pkg/errors"
+	uuid "github.com/satori/go.uuid"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"github.com/wassef911/eventually/internal/api/auth"
+	"github.com/wassef911/eventually/internal/api/constants"
+	"github.com/wassef911/eventually/internal/api/dto"
+	api "github.com/wassef911/eventually/internal/api/middlewares"
+	"github.com/wassef911/eventually/internal/api/utils"
+	"github.com/wassef911/eventually/internal/delivery/integration"
+	"github.com/wassef911/eventually/internal/delivery/models"
+	"github.com/wassef911/eventually/internal/delivery/repository"
+	"github.com/wassef911/eventually/pkg/config"
+	"github.com/wassef911/eventually/pkg/errors"
+	"github.com/wassef911/eventually/pkg/logger"
+)
+
+type WebhookHandlersI interface {
+	CreateWebhook() echo.HandlerFunc
+	GetWebhookByID() echo.HandlerFunc
+	DeleteWebhook() echo.HandlerFunc
+	EnableWebhook() echo.HandlerFunc
+	GetWebhookDeliveries() echo.HandlerFunc
+	MapRoutes()
+}
+
+var _ WebhookHandlersI = &webhookHandlers{}
+
+type webhookHandlers struct {
+	group        *echo.Group
+	log          logger.Logger
+	mw           api.MiddlewareManager
+	config       *config.Config
+	v            *validator.Validate
+	webhookRepo  repository.WebhookRepository
+	deliveryRepo repository.WebhookDeliveryRepository
+}
+
+func NewWebhookHandlers(
+	group *echo.Group,
+	log logger.Logger,
+	mw api.MiddlewareManager,
+	config *config.Config,
+	v *validator.Validate,
+	webhookRepo repository.WebhookRepository,
+	deliveryRepo repository.WebhookDeliveryRepository,
+) *webhookHandlers {
+	return &webhookHandlers{group: group, log: log, mw: mw, config: config, v: v, webhookRepo: webhookRepo, deliveryRepo: deliveryRepo}
+}
+
+func (h *webhookHandlers) MapRoutes() {
+	manage := h.mw.Authorize(auth.PermissionManageWebhooks)
+
+	h.group.POST("", h.CreateWebhook(), manage)
+	h.group.GET("/:id", h.GetWebhookByID(), manage)
+	h.group.DELETE("/:id", h.DeleteWebhook(), manage)
+	h.group.PUT("/:id/enable", h.EnableWebhook(), manage)
+	h.group.GET("/:id/deliveries", h.GetWebhookDeliveries(), manage)
+}
+
+// CreateWebhook
+// @Tags Webhooks
+// @Summary Create webhook
+// @Description Subscribe an endpoint to order events, requests are signed with the secret in the X-Webhook-Signature header
+// @Param webhook body dto.CreateWebhookReqDto true "create webhook"
+// @Accept json
+// @Produce json
+// @Success 201 {string} id ""
+// @Router /webhooks [post]
+func (h *webhookHandlers) CreateWebhook() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		span, _ := opentracing.StartSpanFromContext(ctx, "webhookHandlers.CreateWebhook")
+		defer span.Finish()
+
+		var reqDto dto.CreateWebhookReqDto
+		if err := c.Bind(&reqDto); err != nil {
+			return err
+		}
+
+		if err := h.v.StructCtx(ctx, reqDto); err != nil {
+			return err
+		}
+
+		for _, eventType := range reqDto.EventTypes {
+			if !integration.IsEventType(eventType) {
+				return errors.NewBadRequestError(c, fmt.Sprintf("unknown event type: {%s}", eventType), h.config.Logger.Debug)
+			}
+		}
+
+		now := time.Now().UTC()
+		webhook := &models.Webhook{
+			WebhookID:  uuid.NewV4().String(),
+			URL:        reqDto.URL,
+			EventTypes: reqDto.EventTypes,
+			Secret:     reqDto.Secret,
+			CreatedAt:  now,
+			UpdatedAt:  now,
+		}
+		if err := h.webhookRepo.Insert(ctx, webhook); err != nil {
+			return err
+		}
+
+		return c.JSON(http.StatusCreated, webhook.WebhookID)
+	}
+}
+
+// GetWebhookByID
+// @Tags Webhooks
+// @Summary Get webhook
+// @Description Get webhook by id, the secret is never returned
+// @Accept json
+// @Produce json
+// @Param id path string true "Webhook ID"
+// @Success 200 {object} dto.WebhookResponseDto
+// @Router /webhooks/{id} [get]
+func (h *webhookHandlers) GetWebhookByID() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		span, _ := opentracing.StartSpanFromContext(ctx, "webhookHandlers.GetWebhookByID")
+		defer span.Finish()
+
+		webhook, err := h.webhookRepo.GetByID(ctx, c.Param(constants.ID))
+		if err != nil {
+			return h.webhookError(c, err)
+		}
+
+		return c.JSON(http.StatusOK, utils.WebhookResponseFromModel(webhook))
+	}
+}
+
+// DeleteWebhook
+// @Tags Webhooks
+// @Summary Delete webhook
+// @Description Stop sending events to the webhook, its delivery log is kept
+// @Accept json
+// @Produce json
+// @Param id path string true "Webhook ID"
+// @Success 200 {string} id ""
+// @Router /webhooks/{id} [delete]
+func (h *webhookHandlers) DeleteWebhook() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		span, _ := opentracing.StartSpanFromContext(ctx, "webhookHandlers.DeleteWebhook")
+		defer span.Finish()
+
+		webhookID := c.Param(constants.ID)
+		if err := h.webhookRepo.Delete(ctx, webhookID); err != nil {
+			return h.webhookError(c, err)
+		}
+
+		return c.JSON(http.StatusOK, webhookID)
+	}
+}
+
+// EnableWebhook
+// @Tags Webhooks
+// @Summary Enable webhook
+// @Description Turn a webhook disabled after repeated failures back on, events published while it was disabled are not sent
+// @Accept json
+// @Produce json
+// @Param id path string true "Webhook ID"
+// @Success 200 {object} dto.WebhookResponseDto
+// @Router /webhooks/{id}/enable [put]
+func (h *webhookHandlers) EnableWebhook() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		span, _ := opentracing.StartSpanFromContext(ctx, "webhookHandlers.EnableWebhook")
+		defer span.Finish()
+
+		webhook, err := h.webhookRepo.GetByID(ctx, c.Param(constants.ID))
+		if err != nil {
+			return h.webhookError(c, err)
+		}
+
+		webhook.Enable(time.Now().UTC())
+		if err := h.webhookRepo.Update(ctx, webhook); err != nil {
+			return h.webhookError(c, err)
+		}
+
+		return c.JSON(http.StatusOK, utils.WebhookResponseFromModel(webhook))
+	}
+}
+
+// GetWebhookDeliveries
+// @Tags Webhooks
+// @Summary Get webhook deliveries
+// @Description Delivery attempts of the webhook, latest first
+// @Accept json
+// @Produce json
+// @Param id path string true "Webhook ID"
+// @Param page query string false "page number"
+// @Param size query string false "number of elements"
+// @Success 200 {object} dto.WebhookDeliveryListResponseDto
+// @Router /webhooks/{id}/deliveries [get]
+func (h *webhookHandlers) GetWebhookDeliveries() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		span, _ := opentracing.StartSpanFromContext(ctx, "webhookHandlers.GetWebhookDeliveries")
+		defer span.Finish()
+
+		pq := utils.NewPaginationFromQueryParams(c.QueryParam(constants.Size), c.QueryParam(constants.Page))
+
+		deliveriesRes, err := h.deliveryRepo.ListByWebhookID(ctx, c.Param(constants.ID), pq)
+		if err != nil {
+			return err
+		}
+
+		return c.JSON(http.StatusOK, deliveriesRes)
+	}
+}
+
+func (h *webhookHandlers) webhookError(c echo.Context, err error) error {
+	if pkgErrors.Is(err, mongo.ErrNoDocuments) {
+		return errors.NewNotFoundError(c, err.Error(), h.config.Logger.Debug)
+	}
+	return err
+}
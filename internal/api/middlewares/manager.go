@@ -2,28 +2,108 @@ package middlewares
 
 import (
 	"fmt"
+	"net/http"
 	"time"
 
 	"github.com/labstack/echo/v4"
 	"github.com/opentracing/opentracing-go/log"
 
+	"github.com/wassef911/eventually/internal/api/auth"
+	"github.com/wassef911/eventually/internal/infrastructure/es"
+	"github.com/wassef911/eventually/internal/infrastructure/ratelimit"
 	"github.com/wassef911/eventually/internal/infrastructure/tracing"
 	"github.com/wassef911/eventually/pkg/config"
 	"github.com/wassef911/eventually/pkg/errors"
 	"github.com/wassef911/eventually/pkg/logger"
 )
 
+// accessTokenParam carries the token of EventSource and WebSocket clients, which cannot set headers.
+const accessTokenParam = "access_token"
+
 type MiddlewareManager interface {
 	Apply(next echo.HandlerFunc) echo.HandlerFunc
+	Authenticate(next echo.HandlerFunc) echo.HandlerFunc
+	Authorize(permission auth.Permission) echo.MiddlewareFunc
+	Tenant(next echo.HandlerFunc) echo.HandlerFunc
+	RateLimit(next echo.HandlerFunc) echo.HandlerFunc
 }
 
 type middlewareManager struct {
-	log    logger.Logger
-	config *config.Config
+	log           logger.Logger
+	config        *config.Config
+	authenticator *auth.Authenticator
+	limiter       ratelimit.Limiter
+}
+
+func NewMiddlewareManager(log logger.Logger, config *config.Config, authenticator *auth.Authenticator, limiter ratelimit.Limiter) *middlewareManager {
+	return &middlewareManager{log: log, config: config, authenticator: authenticator, limiter: limiter}
 }
 
-func NewMiddlewareManager(log logger.Logger, config *config.Config) *middlewareManager {
-	return &middlewareManager{log: log, config: config}
+// Tenant puts the tenant of the request in its context, from the tenancy header, the subdomain of the host or the
+// tenant claim of the bearer token. Every stream, collection and index the request touches belongs to that tenant.
+func (mw *middlewareManager) Tenant(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		if !mw.config.Tenancy.Enabled {
+			return next(c)
+		}
+
+		req := c.Request()
+		requested := mw.requestedTenant(c)
+
+		// an invalid token is rejected by Authenticate, routes without authentication go by the requested tenant
+		var principal *auth.Principal
+		if header := authorizationHeader(c); header != "" {
+			principal, _ = mw.authenticator.AuthenticateHeader(header)
+		}
+
+		tenant, err := auth.ResolveTenant(mw.config.Tenancy, requested, principal)
+		if err != nil {
+			return err
+		}
+
+		c.SetRequest(req.WithContext(es.ContextWithTenant(req.Context(), tenant)))
+		return next(c)
+	}
+}
+
+// Authenticate puts the principal of the bearer token in the request context, the events it causes record it as actor.
+// The principal must belong to the tenant of the request.
+func (mw *middlewareManager) Authenticate(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		req := c.Request()
+		principal, err := mw.authenticator.AuthenticateHeader(authorizationHeader(c))
+		if err != nil {
+			c.Response().Header().Set(echo.HeaderWWWAuthenticate, "Bearer")
+			return err
+		}
+		if _, err := auth.ResolveTenant(mw.config.Tenancy, es.TenantFromContext(req.Context()), principal); err != nil {
+			return err
+		}
+
+		c.SetRequest(req.WithContext(auth.ContextWithPrincipal(req.Context(), principal)))
+		return next(c)
+	}
+}
+
+// Authorize authenticates the request and checks the role of the principal holds the permission.
+func (mw *middlewareManager) Authorize(permission auth.Permission) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return mw.Authenticate(func(c echo.Context) error {
+			if _, err := auth.Authorize(c.Request().Context(), permission); err != nil {
+				return err
+			}
+			return next(c)
+		})
+	}
+}
+
+func authorizationHeader(c echo.Context) string {
+	req := c.Request()
+	header := req.Header.Get(echo.HeaderAuthorization)
+	if header == "" && req.Method == http.MethodGet && c.QueryParam(accessTokenParam) != "" {
+		header = "Bearer " + c.QueryParam(accessTokenParam)
+	}
+	return header
 }
 
 func (mw *middlewareManager) Apply(next echo.HandlerFunc) echo.HandlerFunc {
@@ -0,0 +1,133 @@
+package middlewares
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+
+	"github.com/wassef911/eventually/internal/api/auth"
+	"github.com/wassef911/eventually/internal/infrastructure/ratelimit"
+	httpErrors "github.com/wassef911/eventually/pkg/errors"
+)
+
+const (
+	headerRateLimitLimit     = "RateLimit-Limit"
+	headerRateLimitRemaining = "RateLimit-Remaining"
+	headerRateLimitReset     = "RateLimit-Reset"
+	headerRateLimitPolicy    = "RateLimit-Policy"
+	headerRetryAfter         = "Retry-After"
+
+	commandsBucket = "commands"
+	queriesBucket  = "queries"
+)
+
+// RateLimit takes a token of the bucket of the client for every api request, commands and queries have their own
+// buckets. The RateLimit-* headers tell the client what is left, a rejected request gets a 429 with Retry-After.
+func (mw *middlewareManager) RateLimit(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		req := c.Request()
+		if !mw.config.RateLimit.Enabled || !isAPIPath(req.URL.Path) {
+			return next(c)
+		}
+
+		bucket, limit := mw.routeLimit(req.Method)
+		if limit.Unlimited() {
+			return next(c)
+		}
+
+		result, err := mw.limiter.Allow(req.Context(), bucket+":"+mw.rateLimitKey(c), limit)
+		if err != nil {
+			// an unavailable backend must not take the api down with it
+			mw.log.Warnf("(RateLimit) [Allow] err: {%v}", err)
+			return next(c)
+		}
+
+		header := c.Response().Header()
+		header.Set(headerRateLimitLimit, strconv.Itoa(result.Limit))
+		header.Set(headerRateLimitRemaining, strconv.Itoa(result.Remaining))
+		header.Set(headerRateLimitReset, seconds(result.Reset))
+		header.Set(headerRateLimitPolicy, fmt.Sprintf("%d;w=%s;burst=%d", limit.Requests, seconds(limit.Period), result.Limit))
+		if !result.Allowed {
+			header.Set(headerRetryAfter, seconds(result.RetryAfter))
+			return errors.Wrapf(httpErrors.TooManyRequests, "%s limit of %d requests per %s reached", bucket, limit.Requests, limit.Period)
+		}
+		return next(c)
+	}
+}
+
+// routeLimit reads are limited as queries, every other method as commands.
+func (mw *middlewareManager) routeLimit(method string) (string, ratelimit.Limit) {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return queriesBucket, mw.config.RateLimit.Queries
+	default:
+		return commandsBucket, mw.config.RateLimit.Commands
+	}
+}
+
+// rateLimitKey client of the request inside its tenant, api keys are hashed so they are not kept in the backend.
+// Requests without an issued api key are limited by the ip echo extracts, see NewIPExtractor.
+func (mw *middlewareManager) rateLimitKey(c echo.Context) string {
+	req := c.Request()
+	tenant := ""
+	if requested := mw.requestedTenant(c); mw.config.Tenancy.HasTenant(requested) {
+		tenant = requested
+	}
+
+	switch mw.config.RateLimit.KeyBy {
+	case ratelimit.KeyByTenant:
+		return tenant
+	case ratelimit.KeyByAPIKey:
+		if apiKey := req.Header.Get(mw.config.RateLimit.APIKeyHeader); mw.config.RateLimit.IssuedAPIKey(apiKey) {
+			sum := sha256.Sum256([]byte(apiKey))
+			return tenant + ":apikey:" + hex.EncodeToString(sum[:16])
+		}
+	}
+	return tenant + ":ip:" + c.RealIP()
+}
+
+// NewIPExtractor ip of the client of a request. Without trusted proxies it is the peer address, the X-Real-IP and
+// X-Forwarded-For headers are set by the client. Behind trusted proxies it is the first address of X-Forwarded-For
+// that is not one of them.
+func NewIPExtractor(trustedProxies []string) (echo.IPExtractor, error) {
+	if len(trustedProxies) == 0 {
+		return echo.ExtractIPDirect(), nil
+	}
+
+	options := []echo.TrustOption{echo.TrustLoopback(false), echo.TrustLinkLocal(false), echo.TrustPrivateNet(false)}
+	for _, proxy := range trustedProxies {
+		_, ipRange, err := net.ParseCIDR(proxy)
+		if err != nil {
+			return nil, errors.Wrapf(err, "trusted proxy: {%s}", proxy)
+		}
+		options = append(options, echo.TrustIPRange(ipRange))
+	}
+	return echo.ExtractIPFromXFFHeader(options...), nil
+}
+
+// requestedTenant tenant the request names by header or subdomain, not checked against the token yet.
+func (mw *middlewareManager) requestedTenant(c echo.Context) string {
+	req := c.Request()
+	if tenant := req.Header.Get(mw.config.Tenancy.Header); tenant != "" {
+		return strings.ToLower(tenant)
+	}
+	return auth.SubdomainTenant(req.Host, mw.config.Tenancy.Domain)
+}
+
+func isAPIPath(path string) bool {
+	return strings.HasPrefix(path, "/api/") || path == "/graphql"
+}
+
+// seconds whole seconds rounded up, the unit of the RateLimit-* and Retry-After headers.
+func seconds(d time.Duration) string {
+	return strconv.FormatInt(int64(math.Ceil(d.Seconds())), 10)
+}
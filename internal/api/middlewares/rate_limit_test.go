@@ -0,0 +1,121 @@
+package middlewares
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/wassef911/eventually/internal/infrastructure/ratelimit"
+	"github.com/wassef911/eventually/pkg/config"
+	"github.com/wassef911/eventually/pkg/logger"
+)
+
+func newRateLimitedEcho(rateLimit ratelimit.Config) *echo.Echo {
+	appLogger := logger.NewAppLogger(&logger.Config{LogLevel: "fatal"})
+	appLogger.InitLogger()
+	cfg := &config.Config{Logger: &logger.Config{}, RateLimit: rateLimit}
+	mw := NewMiddlewareManager(appLogger, cfg, nil, ratelimit.NewMemoryLimiter())
+
+	e := echo.New()
+	e.IPExtractor, _ = NewIPExtractor(rateLimit.TrustedProxies)
+	e.Use(mw.Apply, mw.RateLimit)
+	ok := func(c echo.Context) error { return c.NoContent(http.StatusOK) }
+	e.GET("/api/orders/:id", ok)
+	e.POST("/api/orders", ok)
+	e.GET("/swagger/index.html", ok)
+	return e
+}
+
+func serve(e *echo.Echo, method string, target string, header http.Header) *httptest.ResponseRecorder {
+	return serveFrom(e, "192.0.2.1:1234", method, target, header)
+}
+
+func serveFrom(e *echo.Echo, remoteAddr string, method string, target string, header http.Header) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, target, nil)
+	req.RemoteAddr = remoteAddr
+	for key, values := range header {
+		req.Header[key] = values
+	}
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestRateLimit(t *testing.T) {
+	e := newRateLimitedEcho(ratelimit.Config{
+		Enabled:  true,
+		KeyBy:    ratelimit.KeyByIP,
+		Commands: ratelimit.Limit{Requests: 1, Period: time.Minute},
+		Queries:  ratelimit.Limit{Requests: 2, Period: time.Minute},
+	})
+
+	rec := serve(e, http.MethodPost, "/api/orders", nil)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "1", rec.Header().Get(headerRateLimitLimit))
+	assert.Equal(t, "0", rec.Header().Get(headerRateLimitRemaining))
+	assert.Equal(t, "60", rec.Header().Get(headerRateLimitReset))
+	assert.Equal(t, "1;w=60;burst=1", rec.Header().Get(headerRateLimitPolicy))
+
+	rec = serve(e, http.MethodPost, "/api/orders", nil)
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+	assert.Equal(t, "60", rec.Header().Get(headerRetryAfter))
+
+	rec = serve(e, http.MethodGet, "/api/orders/1", nil)
+	assert.Equal(t, http.StatusOK, rec.Code, "queries have their own bucket")
+	assert.Equal(t, "1", rec.Header().Get(headerRateLimitRemaining))
+
+	rec = serve(e, http.MethodPost, "/api/orders", http.Header{"X-Real-Ip": {"10.0.0.2"}, "X-Forwarded-For": {"10.0.0.3"}})
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code, "the client cannot name its own ip")
+
+	rec = serveFrom(e, "192.0.2.2:1234", http.MethodPost, "/api/orders", nil)
+	assert.Equal(t, http.StatusOK, rec.Code, "every ip has its own bucket")
+
+	for i := 0; i < 3; i++ {
+		rec = serve(e, http.MethodGet, "/swagger/index.html", nil)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Empty(t, rec.Header().Get(headerRateLimitLimit), "only the api is limited")
+	}
+}
+
+func TestRateLimit_APIKey(t *testing.T) {
+	e := newRateLimitedEcho(ratelimit.Config{
+		Enabled:      true,
+		KeyBy:        ratelimit.KeyByAPIKey,
+		APIKeyHeader: "X-API-Key",
+		APIKeys:      []string{"key-1", "key-2"},
+		Commands:     ratelimit.Limit{Requests: 1, Period: time.Minute},
+	})
+
+	assert.Equal(t, http.StatusOK, serve(e, http.MethodPost, "/api/orders", http.Header{"X-Api-Key": {"key-1"}}).Code)
+	assert.Equal(t, http.StatusTooManyRequests, serve(e, http.MethodPost, "/api/orders", http.Header{"X-Api-Key": {"key-1"}}).Code)
+	assert.Equal(t, http.StatusOK, serve(e, http.MethodPost, "/api/orders", http.Header{"X-Api-Key": {"key-2"}}).Code)
+	assert.Equal(t, http.StatusOK, serve(e, http.MethodGet, "/api/orders/1", nil).Code, "unlimited queries")
+
+	assert.Equal(t, http.StatusOK, serve(e, http.MethodPost, "/api/orders", http.Header{"X-Api-Key": {"made-up-1"}}).Code)
+	assert.Equal(t, http.StatusTooManyRequests, serve(e, http.MethodPost, "/api/orders", http.Header{"X-Api-Key": {"made-up-2"}}).Code,
+		"keys that were not issued share the bucket of the ip")
+}
+
+func TestRateLimit_TrustedProxies(t *testing.T) {
+	e := newRateLimitedEcho(ratelimit.Config{
+		Enabled:        true,
+		KeyBy:          ratelimit.KeyByIP,
+		TrustedProxies: []string{"10.0.0.0/8"},
+		Commands:       ratelimit.Limit{Requests: 1, Period: time.Minute},
+	})
+
+	forwarded := func(clientIP string) http.Header { return http.Header{"X-Forwarded-For": {clientIP}} }
+	assert.Equal(t, http.StatusOK, serveFrom(e, "10.0.0.1:1234", http.MethodPost, "/api/orders", forwarded("198.51.100.1")).Code)
+	assert.Equal(t, http.StatusTooManyRequests, serveFrom(e, "10.0.0.2:1234", http.MethodPost, "/api/orders", forwarded("198.51.100.1")).Code)
+	assert.Equal(t, http.StatusOK, serveFrom(e, "10.0.0.1:1234", http.MethodPost, "/api/orders", forwarded("198.51.100.2")).Code,
+		"the proxy names the client")
+	assert.Equal(t, http.StatusOK, serveFrom(e, "192.0.2.9:1234", http.MethodPost, "/api/orders", forwarded("198.51.100.1")).Code,
+		"other peers cannot name the client")
+
+	_, err := NewIPExtractor([]string{"not-a-cidr"})
+	assert.Error(t, err)
+}
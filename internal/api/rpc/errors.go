@@ -0,0 +1,96 @@
+package rpc
+
+import (
+	"context"
+
+	"github.com/go-playground/validator"
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/mongo"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/wassef911/eventually/internal/delivery/aggregate"
+	"github.com/wassef911/eventually/internal/delivery/domain"
+	"github.com/wassef911/eventually/internal/delivery/models"
+	"github.com/wassef911/eventually/internal/delivery/payment"
+	httpErrors "github.com/wassef911/eventually/pkg/errors"
+)
+
+var errInvalidArgument = errors.New("invalid argument")
+
+// errorCodes domain errors and their status code, the first match wins.
+var errorCodes = []struct {
+	err  error
+	code codes.Code
+}{
+	{err: context.Canceled, code: codes.Canceled},
+	{err: context.DeadlineExceeded, code: codes.DeadlineExceeded},
+	{err: errInvalidArgument, code: codes.InvalidArgument},
+	{err: httpErrors.Unauthorized, code: codes.Unauthenticated},
+	{err: httpErrors.Forbidden, code: codes.PermissionDenied},
+	{err: httpErrors.BadRequest, code: codes.InvalidArgument},
+
+	{err: aggregate.ErrOrderNotFound, code: codes.NotFound},
+	{err: mongo.ErrNoDocuments, code: codes.NotFound},
+
+	{err: aggregate.ErrAlreadyCreated, code: codes.AlreadyExists},
+
+	{err: aggregate.ErrInvalidStatusTransition, code: codes.FailedPrecondition},
+	{err: aggregate.ErrAlreadyPaid, code: codes.FailedPrecondition},
+	{err: aggregate.ErrAlreadySubmitted, code: codes.FailedPrecondition},
+	{err: aggregate.ErrOrderAlreadyCompleted, code: codes.FailedPrecondition},
+	{err: aggregate.ErrOrderAlreadyCanceled, code: codes.FailedPrecondition},
+	{err: aggregate.ErrOrderAlreadyCancelled, code: codes.FailedPrecondition},
+	{err: aggregate.ErrOrderNotPaid, code: codes.FailedPrecondition},
+	{err: aggregate.ErrOrderMustBePaidBeforeDelivered, code: codes.FailedPrecondition},
+	{err: aggregate.ErrPaymentPending, code: codes.FailedPrecondition},
+	{err: payment.ErrPaymentDeclined, code: codes.FailedPrecondition},
+
+	{err: aggregate.ErrCancelReasonRequired, code: codes.InvalidArgument},
+	{err: aggregate.ErrOrderShopItemsIsRequired, code: codes.InvalidArgument},
+	{err: aggregate.ErrInvalidDeliveryAddress, code: codes.InvalidArgument},
+	{err: models.ErrInvalidAddress, code: codes.InvalidArgument},
+	{err: aggregate.ErrOrderCurrencyMismatch, code: codes.InvalidArgument},
+	{err: aggregate.ErrTooManyShopItems, code: codes.InvalidArgument},
+	{err: aggregate.ErrInvalidShopItemQuantity, code: codes.InvalidArgument},
+	{err: aggregate.ErrDuplicateShopItem, code: codes.InvalidArgument},
+	{err: aggregate.ErrProductNotFound, code: codes.InvalidArgument},
+	{err: aggregate.ErrProductDiscontinued, code: codes.InvalidArgument},
+}
+
+// domainCodes status code of the domain errors missing from errorCodes.
+var domainCodes = map[domain.Kind]codes.Code{
+	domain.KindNotFound: codes.NotFound,
+	domain.KindConflict: codes.FailedPrecondition,
+	domain.KindInvalid:  codes.InvalidArgument,
+}
+
+// errorToStatus status of err, internal errors only carry their message in debug mode.
+func errorToStatus(err error, debug bool) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+
+	var validationErrors validator.ValidationErrors
+	if errors.As(err, &validationErrors) {
+		return status.Error(codes.InvalidArgument, validationErrors.Error())
+	}
+
+	for _, errorCode := range errorCodes {
+		if errors.Is(err, errorCode.err) {
+			return status.Error(errorCode.code, err.Error())
+		}
+	}
+
+	if domainErr, ok := domain.AsError(err); ok {
+		return status.Error(domainCodes[domainErr.Kind], err.Error())
+	}
+
+	if debug {
+		return status.Error(codes.Internal, err.Error())
+	}
+	return status.Error(codes.Internal, "internal error")
+}
@@ -0,0 +1,121 @@
+package rpc
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/wassef911/eventually/internal/api/auth"
+	"github.com/wassef911/eventually/internal/infrastructure/es"
+	"github.com/wassef911/eventually/internal/infrastructure/tracing"
+	"github.com/wassef911/eventually/pkg/config"
+	"github.com/wassef911/eventually/pkg/logger"
+)
+
+// reflectionService methods are served without a token, they only describe the api.
+const (
+	reflectionService     = "/grpc.reflection."
+	authorizationMetadata = "authorization"
+)
+
+// InterceptorManager authenticates, traces and logs every call and turns the returned errors into grpc statuses.
+type InterceptorManager interface {
+	Unary(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error)
+	Stream(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error
+}
+
+type interceptorManager struct {
+	log           logger.Logger
+	config        *config.Config
+	authenticator *auth.Authenticator
+}
+
+func NewInterceptorManager(log logger.Logger, config *config.Config, authenticator *auth.Authenticator) *interceptorManager {
+	return &interceptorManager{log: log, config: config, authenticator: authenticator}
+}
+
+func (im *interceptorManager) Unary(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	start := time.Now()
+	ctx, span := tracing.StartGrpcServerTracerSpan(ctx, info.FullMethod)
+	defer span.Finish()
+
+	var reply interface{}
+	ctx, err := im.authenticate(ctx, info.FullMethod)
+	if err == nil {
+		reply, err = handler(ctx, req)
+	}
+	if err != nil {
+		tracing.TraceErr(span, err)
+	}
+
+	im.log.GrpcMiddlewareAccessLogger(info.FullMethod, time.Since(start), loggedMetadata(ctx), err)
+	return reply, errorToStatus(err, im.config.Logger.Debug)
+}
+
+func (im *interceptorManager) Stream(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	start := time.Now()
+	ctx, span := tracing.StartGrpcServerTracerSpan(stream.Context(), info.FullMethod)
+	defer span.Finish()
+
+	ctx, err := im.authenticate(ctx, info.FullMethod)
+	if err == nil {
+		err = handler(srv, &tracedServerStream{ServerStream: stream, ctx: ctx})
+	}
+	if err != nil {
+		tracing.TraceErr(span, err)
+	}
+
+	im.log.GrpcMiddlewareAccessLogger(info.FullMethod, time.Since(start), loggedMetadata(ctx), err)
+	return errorToStatus(err, im.config.Logger.Debug)
+}
+
+// authenticate puts the principal of the bearer token of the authorization metadata in ctx,
+// with the tenant of the tenancy header metadata or of the tenant claim of the token.
+func (im *interceptorManager) authenticate(ctx context.Context, fullMethod string) (context.Context, error) {
+	if strings.HasPrefix(fullMethod, reflectionService) {
+		return ctx, nil
+	}
+
+	principal, err := im.authenticator.AuthenticateHeader(incomingMetadata(ctx, authorizationMetadata))
+	if err != nil {
+		return ctx, err
+	}
+
+	tenant, err := auth.ResolveTenant(im.config.Tenancy, incomingMetadata(ctx, im.config.Tenancy.Header), principal)
+	if err != nil {
+		return ctx, err
+	}
+	return auth.ContextWithPrincipal(es.ContextWithTenant(ctx, tenant), principal), nil
+}
+
+func incomingMetadata(ctx context.Context, key string) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok || key == "" {
+		return ""
+	}
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// loggedMetadata incoming metadata without the bearer token.
+func loggedMetadata(ctx context.Context) metadata.MD {
+	md, _ := metadata.FromIncomingContext(ctx)
+	md = md.Copy()
+	md.Delete(authorizationMetadata)
+	return md
+}
+
+// tracedServerStream hands the traced context to stream handlers.
+type tracedServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *tracedServerStream) Context() context.Context {
+	return s.ctx
+}
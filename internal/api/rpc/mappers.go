@@ -0,0 +1,173 @@
+package rpc
+
+import (
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/wassef911/eventually/internal/api/dto"
+	"github.com/wassef911/eventually/internal/delivery/integration"
+	"github.com/wassef911/eventually/internal/delivery/models"
+	"github.com/wassef911/eventually/proto/orders"
+)
+
+func orderItemsFromProto(items []*orders.OrderItem) []*models.OrderItem {
+	result := make([]*models.OrderItem, 0, len(items))
+	for _, item := range items {
+		result = append(result, &models.OrderItem{ProductID: item.GetProductId(), Quantity: item.GetQuantity()})
+	}
+	return result
+}
+
+func addressFromProto(address *orders.Address) dto.Address {
+	return dto.Address{
+		Recipient:  address.GetRecipient(),
+		Line1:      address.GetLine1(),
+		Line2:      address.GetLine2(),
+		City:       address.GetCity(),
+		PostalCode: address.GetPostalCode(),
+		Region:     address.GetRegion(),
+		Country:    address.GetCountry(),
+		Phone:      address.GetPhone(),
+	}
+}
+
+func addressToProto(address dto.Address) *orders.Address {
+	return &orders.Address{
+		Recipient:  address.Recipient,
+		Line1:      address.Line1,
+		Line2:      address.Line2,
+		City:       address.City,
+		PostalCode: address.PostalCode,
+		Region:     address.Region,
+		Country:    address.Country,
+		Phone:      address.Phone,
+	}
+}
+
+func moneyToProto(money dto.Money) *orders.Money {
+	return &orders.Money{Amount: money.Amount, Currency: money.Currency}
+}
+
+// timeToProto unset times stay unset instead of becoming the unix epoch.
+func timeToProto(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
+
+func orderToProto(order dto.OrderResponseDto) *orders.Order {
+	shopItems := make([]*orders.ShopItem, 0, len(order.ShopItems))
+	for _, item := range order.ShopItems {
+		shopItems = append(shopItems, &orders.ShopItem{
+			Id:          item.ID,
+			Title:       item.Title,
+			Description: item.Description,
+			Quantity:    item.Quantity,
+			Price:       moneyToProto(item.Price),
+			TaxCategory: item.TaxCategory,
+		})
+	}
+
+	return &orders.Order{
+		Id:              order.OrderID,
+		CustomerId:      order.CustomerID,
+		AccountEmail:    order.AccountEmail,
+		ShopItems:       shopItems,
+		DeliveryAddress: addressToProto(order.DeliveryAddress),
+		Status:          order.Status,
+		Subtotal:        moneyToProto(order.Subtotal),
+		DiscountTotal:   moneyToProto(order.DiscountTotal),
+		ShippingPrice:   moneyToProto(order.ShippingPrice),
+		TaxTotal:        moneyToProto(order.TaxTotal),
+		TotalPrice:      moneyToProto(order.TotalPrice),
+		CancelReason:    order.CancelReason,
+		RejectReason:    order.RejectReason,
+		Paid:            order.Paid,
+		Submitted:       order.Submitted,
+		Completed:       order.Completed,
+		Canceled:        order.Canceled,
+		Refunded:        order.Refunded,
+		PaymentId:       order.Payment.PaymentID,
+		DeliveredTime:   timeToProto(order.DeliveredTime),
+	}
+}
+
+func searchResponseToProto(searchRes *dto.OrderSearchResponseDto) *orders.SearchOrdersRes {
+	result := make([]*orders.Order, 0, len(searchRes.Orders))
+	for _, order := range searchRes.Orders {
+		result = append(result, orderToProto(order))
+	}
+
+	return &orders.SearchOrdersRes{
+		Pagination: &orders.Pagination{
+			TotalCount: searchRes.Pagination.TotalCount,
+			TotalPages: searchRes.Pagination.TotalPages,
+			Page:       searchRes.Pagination.Page,
+			Size:       searchRes.Pagination.Size,
+			HasMore:    searchRes.Pagination.HasMore,
+		},
+		Orders: result,
+	}
+}
+
+func orderEventToProto(evt *integration.Event) *orders.OrderEvent {
+	return &orders.OrderEvent{
+		Id:         evt.ID,
+		Type:       evt.Type,
+		Version:    int32(evt.Version),
+		Source:     evt.Source,
+		OrderId:    evt.OrderID,
+		Sequence:   evt.Sequence,
+		OccurredAt: timestamppb.New(evt.OccurredAt),
+		Data:       evt.Data,
+	}
+}
+
+// orderItemFromProto a missing item stays nil so that validation rejects it.
+func orderItemFromProto(item *orders.OrderItem) *models.OrderItem {
+	if item == nil {
+		return nil
+	}
+	return &models.OrderItem{ProductID: item.GetProductId(), Quantity: item.GetQuantity()}
+}
+
+func moneyFromProto(money *orders.Money) *dto.Money {
+	if money == nil {
+		return nil
+	}
+	return &dto.Money{Amount: money.GetAmount(), Currency: money.GetCurrency()}
+}
+
+func refundItemsFromProto(items []*orders.RefundItem) []dto.RefundItem {
+	result := make([]dto.RefundItem, 0, len(items))
+	for _, item := range items {
+		result = append(result, dto.RefundItem{ShopItemID: item.GetShopItemId(), Quantity: item.GetQuantity()})
+	}
+	return result
+}
+
+func returnItemsFromProto(items []*orders.ReturnItem) []dto.ReturnItem {
+	result := make([]dto.ReturnItem, 0, len(items))
+	for _, item := range items {
+		result = append(result, dto.ReturnItem{ShopItemID: item.GetShopItemId(), Quantity: item.GetQuantity()})
+	}
+	return result
+}
+
+func shipmentItemsFromProto(items []*orders.ShipmentItem) []dto.ShipmentItem {
+	result := make([]dto.ShipmentItem, 0, len(items))
+	for _, item := range items {
+		result = append(result, dto.ShipmentItem{ShopItemID: item.GetShopItemId(), Quantity: item.GetQuantity()})
+	}
+	return result
+}
+
+func lifecycleToProto(lifecycle dto.OrderLifecycleResponseDto) *orders.GetOrderLifecycleRes {
+	transitions := make([]*orders.OrderTransition, 0, len(lifecycle.Transitions))
+	for _, transition := range lifecycle.Transitions {
+		transitions = append(transitions, &orders.OrderTransition{Action: transition.Action, From: transition.From, To: transition.To})
+	}
+	return &orders.GetOrderLifecycleRes{Statuses: lifecycle.Statuses, Transitions: transitions}
+}
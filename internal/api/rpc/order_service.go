@@ -0,0 +1,759 @@
+// Package rpc serves the order commands and queries over gRPC, next to the REST api.
+package rpc
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-playground/validator"
+	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+	uuid "github.com/satori/go.uuid"
+
+	"github.com/wassef911/eventually/internal/api/auth"
+	"github.com/wassef911/eventually/internal/api/dto"
+	"github.com/wassef911/eventually/internal/api/utils"
+	"github.com/wassef911/eventually/internal/delivery/aggregate"
+	"github.com/wassef911/eventually/internal/delivery/commands"
+	"github.com/wassef911/eventually/internal/delivery/live"
+	"github.com/wassef911/eventually/internal/delivery/models"
+	"github.com/wassef911/eventually/internal/delivery/queries"
+	service "github.com/wassef911/eventually/internal/delivery/services"
+	"github.com/wassef911/eventually/pkg/config"
+	"github.com/wassef911/eventually/pkg/logger"
+	"github.com/wassef911/eventually/proto/orders"
+)
+
+var _ orders.OrderServiceServer = &orderGrpcService{}
+
+// orderGrpcService requests go through the same validation and order service as the REST handlers,
+// errors are turned into statuses by the InterceptorManager.
+type orderGrpcService struct {
+	orders.UnimplementedOrderServiceServer
+	log    logger.Logger
+	config *config.Config
+	v      *validator.Validate
+	os     *service.OrderService
+	stream *live.OrderStream
+}
+
+func NewOrderGrpcService(
+	log logger.Logger,
+	config *config.Config,
+	v *validator.Validate,
+	os *service.OrderService,
+	stream *live.OrderStream,
+) *orderGrpcService {
+	return &orderGrpcService{log: log, config: config, v: v, os: os, stream: stream}
+}
+
+func (s *orderGrpcService) CreateOrder(ctx context.Context, req *orders.CreateOrderReq) (*orders.CreateOrderRes, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "orderGrpcService.CreateOrder")
+	defer span.Finish()
+
+	if _, err := auth.Authorize(ctx, auth.PermissionPlaceOrder); err != nil {
+		return nil, err
+	}
+
+	accountEmail, err := auth.OrderAccountEmail(ctx, req.GetAccountEmail())
+	if err != nil {
+		return nil, err
+	}
+
+	reqDto := dto.CreateOrderReqDto{
+		Items:           orderItemsFromProto(req.GetItems()),
+		CustomerID:      req.GetCustomerId(),
+		AccountEmail:    accountEmail,
+		DeliveryAddress: addressFromProto(req.GetDeliveryAddress()),
+	}
+	if err := s.v.StructCtx(ctx, reqDto); err != nil {
+		return nil, err
+	}
+
+	id := uuid.NewV4().String()
+	command := commands.NewCreateOrderCommand(id, reqDto.Items, reqDto.CustomerID, reqDto.AccountEmail, utils.AddressFromDto(reqDto.DeliveryAddress))
+	if err := s.os.Commands.CreateOrder.Handle(ctx, command); err != nil {
+		return nil, err
+	}
+
+	return &orders.CreateOrderRes{Id: id}, nil
+}
+
+func (s *orderGrpcService) PayOrder(ctx context.Context, req *orders.PayOrderReq) (*orders.OrderIDRes, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "orderGrpcService.PayOrder")
+	defer span.Finish()
+
+	orderID, err := parseID(req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.authorizeOrder(ctx, auth.PermissionPlaceOrder, orderID); err != nil {
+		return nil, err
+	}
+
+	command := commands.NewPayOrderCommand(orderID, req.GetPaymentToken())
+	if err := s.v.StructCtx(ctx, command); err != nil {
+		return nil, err
+	}
+
+	if err := s.os.Commands.OrderPaid.Handle(ctx, command); err != nil {
+		return nil, err
+	}
+
+	return &orders.OrderIDRes{Id: orderID}, nil
+}
+
+func (s *orderGrpcService) SubmitOrder(ctx context.Context, req *orders.SubmitOrderReq) (*orders.OrderIDRes, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "orderGrpcService.SubmitOrder")
+	defer span.Finish()
+
+	orderID, err := parseID(req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.authorizeOrder(ctx, auth.PermissionPlaceOrder, orderID); err != nil {
+		return nil, err
+	}
+
+	command := commands.NewSubmitOrderCommand(orderID)
+	if err := s.v.StructCtx(ctx, command); err != nil {
+		return nil, err
+	}
+
+	if err := s.os.Commands.SubmitOrder.Handle(ctx, command); err != nil {
+		return nil, err
+	}
+
+	return &orders.OrderIDRes{Id: orderID}, nil
+}
+
+func (s *orderGrpcService) UpdateShoppingCart(ctx context.Context, req *orders.UpdateShoppingCartReq) (*orders.OrderIDRes, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "orderGrpcService.UpdateShoppingCart")
+	defer span.Finish()
+
+	orderID, err := parseID(req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.authorizeOrder(ctx, auth.PermissionPlaceOrder, orderID); err != nil {
+		return nil, err
+	}
+
+	reqDto := dto.UpdateShoppingItemsReqDto{Items: orderItemsFromProto(req.GetItems())}
+	if err := s.v.StructCtx(ctx, reqDto); err != nil {
+		return nil, err
+	}
+
+	command := commands.NewUpdateShoppingCartCommand(orderID, reqDto.Items)
+	if err := s.os.Commands.UpdateOrder.Handle(ctx, command); err != nil {
+		return nil, err
+	}
+
+	return &orders.OrderIDRes{Id: orderID}, nil
+}
+
+func (s *orderGrpcService) CancelOrder(ctx context.Context, req *orders.CancelOrderReq) (*orders.OrderIDRes, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "orderGrpcService.CancelOrder")
+	defer span.Finish()
+
+	orderID, err := parseID(req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.authorizeOrder(ctx, auth.PermissionCancelOrder, orderID); err != nil {
+		return nil, err
+	}
+
+	command := commands.NewCancelOrderCommand(orderID, req.GetCancelReason())
+	if err := s.v.StructCtx(ctx, command); err != nil {
+		return nil, err
+	}
+
+	if err := s.os.Commands.CancelOrder.Handle(ctx, command); err != nil {
+		return nil, err
+	}
+
+	return &orders.OrderIDRes{Id: orderID}, nil
+}
+
+func (s *orderGrpcService) CompleteOrder(ctx context.Context, req *orders.CompleteOrderReq) (*orders.OrderIDRes, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "orderGrpcService.CompleteOrder")
+	defer span.Finish()
+
+	orderID, err := parseID(req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.authorizeOrder(ctx, auth.PermissionFulfilOrder, orderID); err != nil {
+		return nil, err
+	}
+
+	command := commands.NewCompleteOrderCommand(orderID, time.Now())
+	if err := s.v.StructCtx(ctx, command); err != nil {
+		return nil, err
+	}
+
+	if err := s.os.Commands.CompleteOrder.Handle(ctx, command); err != nil {
+		return nil, err
+	}
+
+	return &orders.OrderIDRes{Id: orderID}, nil
+}
+
+func (s *orderGrpcService) ChangeDeliveryAddress(ctx context.Context, req *orders.ChangeDeliveryAddressReq) (*orders.OrderIDRes, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "orderGrpcService.ChangeDeliveryAddress")
+	defer span.Finish()
+
+	orderID, err := parseID(req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.authorizeOrder(ctx, auth.PermissionPlaceOrder, orderID); err != nil {
+		return nil, err
+	}
+
+	reqDto := dto.ChangeDeliveryAddressReqDto{DeliveryAddress: addressFromProto(req.GetDeliveryAddress())}
+	if err := s.v.StructCtx(ctx, reqDto); err != nil {
+		return nil, err
+	}
+
+	command := commands.NewChangeDeliveryAddressCommand(orderID, utils.AddressFromDto(reqDto.DeliveryAddress))
+	if err := s.v.StructCtx(ctx, command); err != nil {
+		return nil, err
+	}
+
+	if err := s.os.Commands.ChangeOrderDeliveryAddress.Handle(ctx, command); err != nil {
+		return nil, err
+	}
+
+	return &orders.OrderIDRes{Id: orderID}, nil
+}
+
+func (s *orderGrpcService) AddItem(ctx context.Context, req *orders.AddItemReq) (*orders.OrderIDRes, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "orderGrpcService.AddItem")
+	defer span.Finish()
+
+	orderID, err := parseID(req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.authorizeOrder(ctx, auth.PermissionPlaceOrder, orderID); err != nil {
+		return nil, err
+	}
+
+	reqDto := dto.AddShopItemReqDto{Item: orderItemFromProto(req.GetItem())}
+	if err := s.v.StructCtx(ctx, reqDto); err != nil {
+		return nil, err
+	}
+
+	command := commands.NewAddItemCommand(orderID, reqDto.Item)
+	if err := s.os.Commands.AddItem.Handle(ctx, command); err != nil {
+		return nil, err
+	}
+
+	return &orders.OrderIDRes{Id: orderID}, nil
+}
+
+func (s *orderGrpcService) RemoveItem(ctx context.Context, req *orders.RemoveItemReq) (*orders.OrderIDRes, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "orderGrpcService.RemoveItem")
+	defer span.Finish()
+
+	orderID, err := parseID(req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.authorizeOrder(ctx, auth.PermissionPlaceOrder, orderID); err != nil {
+		return nil, err
+	}
+
+	command := commands.NewRemoveItemCommand(orderID, req.GetItemId())
+	if err := s.v.StructCtx(ctx, command); err != nil {
+		return nil, err
+	}
+
+	if err := s.os.Commands.RemoveItem.Handle(ctx, command); err != nil {
+		return nil, err
+	}
+
+	return &orders.OrderIDRes{Id: orderID}, nil
+}
+
+func (s *orderGrpcService) ChangeItemQuantity(ctx context.Context, req *orders.ChangeItemQuantityReq) (*orders.OrderIDRes, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "orderGrpcService.ChangeItemQuantity")
+	defer span.Finish()
+
+	orderID, err := parseID(req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.authorizeOrder(ctx, auth.PermissionPlaceOrder, orderID); err != nil {
+		return nil, err
+	}
+
+	command := commands.NewChangeItemQuantityCommand(orderID, req.GetItemId(), req.GetQuantity())
+	if err := s.v.StructCtx(ctx, command); err != nil {
+		return nil, err
+	}
+
+	if err := s.os.Commands.ChangeItemQuantity.Handle(ctx, command); err != nil {
+		return nil, err
+	}
+
+	return &orders.OrderIDRes{Id: orderID}, nil
+}
+
+func (s *orderGrpcService) ApplyCoupon(ctx context.Context, req *orders.ApplyCouponReq) (*orders.OrderIDRes, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "orderGrpcService.ApplyCoupon")
+	defer span.Finish()
+
+	orderID, err := parseID(req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.authorizeOrder(ctx, auth.PermissionPlaceOrder, orderID); err != nil {
+		return nil, err
+	}
+
+	reqDto := dto.ApplyCouponReqDto{Code: req.GetCode()}
+	if err := s.v.StructCtx(ctx, reqDto); err != nil {
+		return nil, err
+	}
+
+	command := commands.NewApplyCouponCommand(orderID, reqDto.Code)
+	if err := s.os.Commands.ApplyCoupon.Handle(ctx, command); err != nil {
+		return nil, err
+	}
+
+	return &orders.OrderIDRes{Id: orderID}, nil
+}
+
+func (s *orderGrpcService) RemoveCoupon(ctx context.Context, req *orders.RemoveCouponReq) (*orders.OrderIDRes, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "orderGrpcService.RemoveCoupon")
+	defer span.Finish()
+
+	orderID, err := parseID(req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.authorizeOrder(ctx, auth.PermissionPlaceOrder, orderID); err != nil {
+		return nil, err
+	}
+
+	command := commands.NewRemoveCouponCommand(orderID, req.GetCode())
+	if err := s.v.StructCtx(ctx, command); err != nil {
+		return nil, err
+	}
+
+	if err := s.os.Commands.RemoveCoupon.Handle(ctx, command); err != nil {
+		return nil, err
+	}
+
+	return &orders.OrderIDRes{Id: orderID}, nil
+}
+
+func (s *orderGrpcService) RefundOrder(ctx context.Context, req *orders.RefundOrderReq) (*orders.RefundIDRes, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "orderGrpcService.RefundOrder")
+	defer span.Finish()
+
+	orderID, err := parseID(req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := auth.Authorize(ctx, auth.PermissionFulfilOrder); err != nil {
+		return nil, err
+	}
+
+	reqDto := dto.RefundOrderReqDto{Items: refundItemsFromProto(req.GetItems()), Amount: moneyFromProto(req.GetAmount()), Reason: req.GetReason()}
+	if err := s.v.StructCtx(ctx, reqDto); err != nil {
+		return nil, err
+	}
+
+	refundID := uuid.NewV4().String()
+	command := commands.NewRefundOrderCommand(orderID, refundID, utils.RefundItemsFromDto(reqDto.Items), utils.MoneyFromDto(reqDto.Amount), reqDto.Reason)
+	if err := s.os.Commands.RefundOrder.Handle(ctx, command); err != nil {
+		return nil, err
+	}
+
+	return &orders.RefundIDRes{RefundId: refundID}, nil
+}
+
+func (s *orderGrpcService) RequestReturn(ctx context.Context, req *orders.RequestReturnReq) (*orders.ReturnIDRes, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "orderGrpcService.RequestReturn")
+	defer span.Finish()
+
+	orderID, err := parseID(req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.authorizeOrder(ctx, auth.PermissionPlaceOrder, orderID); err != nil {
+		return nil, err
+	}
+
+	reqDto := dto.RequestReturnReqDto{Items: returnItemsFromProto(req.GetItems()), Reason: req.GetReason()}
+	if err := s.v.StructCtx(ctx, reqDto); err != nil {
+		return nil, err
+	}
+
+	returnID := uuid.NewV4().String()
+	command := commands.NewRequestReturnCommand(orderID, returnID, utils.ReturnItemsFromDto(reqDto.Items), reqDto.Reason)
+	if err := s.os.Commands.RequestReturn.Handle(ctx, command); err != nil {
+		return nil, err
+	}
+
+	return &orders.ReturnIDRes{ReturnId: returnID}, nil
+}
+
+func (s *orderGrpcService) ApproveReturn(ctx context.Context, req *orders.ApproveReturnReq) (*orders.ReturnIDRes, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "orderGrpcService.ApproveReturn")
+	defer span.Finish()
+
+	orderID, err := parseID(req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	returnID, err := parseID(req.GetReturnId())
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := auth.Authorize(ctx, auth.PermissionFulfilOrder); err != nil {
+		return nil, err
+	}
+
+	command := commands.NewApproveReturnCommand(orderID, returnID)
+	if err := s.v.StructCtx(ctx, command); err != nil {
+		return nil, err
+	}
+
+	if err := s.os.Commands.ApproveReturn.Handle(ctx, command); err != nil {
+		return nil, err
+	}
+
+	return &orders.ReturnIDRes{ReturnId: returnID}, nil
+}
+
+func (s *orderGrpcService) RejectReturn(ctx context.Context, req *orders.RejectReturnReq) (*orders.ReturnIDRes, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "orderGrpcService.RejectReturn")
+	defer span.Finish()
+
+	orderID, err := parseID(req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	returnID, err := parseID(req.GetReturnId())
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := auth.Authorize(ctx, auth.PermissionFulfilOrder); err != nil {
+		return nil, err
+	}
+
+	command := commands.NewRejectReturnCommand(orderID, returnID, req.GetRejectReason())
+	if err := s.v.StructCtx(ctx, command); err != nil {
+		return nil, err
+	}
+
+	if err := s.os.Commands.RejectReturn.Handle(ctx, command); err != nil {
+		return nil, err
+	}
+
+	return &orders.ReturnIDRes{ReturnId: returnID}, nil
+}
+
+func (s *orderGrpcService) ReceiveReturn(ctx context.Context, req *orders.ReceiveReturnReq) (*orders.RefundIDRes, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "orderGrpcService.ReceiveReturn")
+	defer span.Finish()
+
+	orderID, err := parseID(req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	returnID, err := parseID(req.GetReturnId())
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := auth.Authorize(ctx, auth.PermissionFulfilOrder); err != nil {
+		return nil, err
+	}
+
+	refundID := uuid.NewV4().String()
+	command := commands.NewReceiveReturnCommand(orderID, returnID, refundID)
+	if err := s.v.StructCtx(ctx, command); err != nil {
+		return nil, err
+	}
+
+	if err := s.os.Commands.ReceiveReturn.Handle(ctx, command); err != nil {
+		return nil, err
+	}
+
+	return &orders.RefundIDRes{RefundId: refundID}, nil
+}
+
+func (s *orderGrpcService) CreateShipment(ctx context.Context, req *orders.CreateShipmentReq) (*orders.ShipmentIDRes, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "orderGrpcService.CreateShipment")
+	defer span.Finish()
+
+	orderID, err := parseID(req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := auth.Authorize(ctx, auth.PermissionFulfilOrder); err != nil {
+		return nil, err
+	}
+
+	reqDto := dto.CreateShipmentReqDto{Items: shipmentItemsFromProto(req.GetItems())}
+	if err := s.v.StructCtx(ctx, reqDto); err != nil {
+		return nil, err
+	}
+
+	shipmentID := uuid.NewV4().String()
+	command := commands.NewCreateShipmentCommand(orderID, shipmentID, utils.ShipmentItemsFromDto(reqDto.Items))
+	if err := s.os.Commands.CreateShipment.Handle(ctx, command); err != nil {
+		return nil, err
+	}
+
+	return &orders.ShipmentIDRes{ShipmentId: shipmentID}, nil
+}
+
+func (s *orderGrpcService) PackShipment(ctx context.Context, req *orders.PackShipmentReq) (*orders.ShipmentIDRes, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "orderGrpcService.PackShipment")
+	defer span.Finish()
+
+	orderID, err := parseID(req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	shipmentID, err := parseID(req.GetShipmentId())
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := auth.Authorize(ctx, auth.PermissionFulfilOrder); err != nil {
+		return nil, err
+	}
+
+	command := commands.NewPackShipmentCommand(orderID, shipmentID)
+	if err := s.v.StructCtx(ctx, command); err != nil {
+		return nil, err
+	}
+
+	if err := s.os.Commands.PackShipment.Handle(ctx, command); err != nil {
+		return nil, err
+	}
+
+	return &orders.ShipmentIDRes{ShipmentId: shipmentID}, nil
+}
+
+func (s *orderGrpcService) DispatchShipment(ctx context.Context, req *orders.DispatchShipmentReq) (*orders.ShipmentIDRes, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "orderGrpcService.DispatchShipment")
+	defer span.Finish()
+
+	orderID, err := parseID(req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	shipmentID, err := parseID(req.GetShipmentId())
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := auth.Authorize(ctx, auth.PermissionFulfilOrder); err != nil {
+		return nil, err
+	}
+
+	command := commands.NewDispatchShipmentCommand(orderID, shipmentID, req.GetCarrier(), req.GetTrackingNumber())
+	if err := s.v.StructCtx(ctx, command); err != nil {
+		return nil, err
+	}
+
+	if err := s.os.Commands.DispatchShipment.Handle(ctx, command); err != nil {
+		return nil, err
+	}
+
+	return &orders.ShipmentIDRes{ShipmentId: shipmentID}, nil
+}
+
+func (s *orderGrpcService) DeliverShipment(ctx context.Context, req *orders.DeliverShipmentReq) (*orders.ShipmentIDRes, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "orderGrpcService.DeliverShipment")
+	defer span.Finish()
+
+	orderID, err := parseID(req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	shipmentID, err := parseID(req.GetShipmentId())
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := auth.Authorize(ctx, auth.PermissionFulfilOrder); err != nil {
+		return nil, err
+	}
+
+	command := commands.NewDeliverShipmentCommand(orderID, shipmentID, time.Now())
+	if err := s.v.StructCtx(ctx, command); err != nil {
+		return nil, err
+	}
+
+	if err := s.os.Commands.DeliverShipment.Handle(ctx, command); err != nil {
+		return nil, err
+	}
+
+	return &orders.ShipmentIDRes{ShipmentId: shipmentID}, nil
+}
+
+func (s *orderGrpcService) GetOrderByID(ctx context.Context, req *orders.GetOrderByIDReq) (*orders.GetOrderByIDRes, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "orderGrpcService.GetOrderByID")
+	defer span.Finish()
+
+	if _, err := auth.Authorize(ctx, auth.PermissionReadOrder); err != nil {
+		return nil, err
+	}
+
+	orderID, err := parseID(req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	query := queries.NewGetOrderByIDQuery(orderID)
+	if err := s.v.StructCtx(ctx, query); err != nil {
+		return nil, err
+	}
+
+	orderProjection, err := s.os.Queries.GetOrderByID.Handle(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	if err := auth.AuthorizeOrder(ctx, auth.PermissionReadOrder, orderProjection.AccountEmail, orderProjection.CustomerID); err != nil {
+		return nil, err
+	}
+
+	return &orders.GetOrderByIDRes{Order: orderToProto(utils.OrderResponseFrom(orderProjection))}, nil
+}
+
+func (s *orderGrpcService) SearchOrders(ctx context.Context, req *orders.SearchOrdersReq) (*orders.SearchOrdersRes, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "orderGrpcService.SearchOrders")
+	defer span.Finish()
+
+	if _, err := auth.Authorize(ctx, auth.PermissionSearchOrders); err != nil {
+		return nil, err
+	}
+
+	pq := utils.NewPaginationQuery(int(req.GetSize()), int(req.GetPage()))
+	if pq.Page == 0 {
+		pq.Page = 1
+	}
+
+	query := queries.NewSearchOrdersQuery(req.GetSearchText(), req.GetStatus(), req.GetCity(), req.GetCountry(), pq)
+	if err := s.v.StructCtx(ctx, query); err != nil {
+		return nil, err
+	}
+
+	searchRes, err := s.os.Queries.SearchOrders.Handle(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	return searchResponseToProto(searchRes), nil
+}
+
+func (s *orderGrpcService) GetOrderLifecycle(ctx context.Context, _ *orders.GetOrderLifecycleReq) (*orders.GetOrderLifecycleRes, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "orderGrpcService.GetOrderLifecycle")
+	defer span.Finish()
+
+	if _, err := auth.Authorize(ctx, auth.PermissionReadOrder); err != nil {
+		return nil, err
+	}
+
+	return lifecycleToProto(utils.OrderLifecycleResponseFrom(models.OrderStatuses, aggregate.OrderTransitions)), nil
+}
+
+// StreamOrderEvents sends the events of the order from its first event, or after last_event_id when set,
+// until the client cancels the call.
+func (s *orderGrpcService) StreamOrderEvents(req *orders.StreamOrderEventsReq, srv orders.OrderService_StreamOrderEventsServer) error {
+	span, ctx := opentracing.StartSpanFromContext(srv.Context(), "orderGrpcService.StreamOrderEvents")
+	defer span.Finish()
+
+	orderID, err := parseID(req.GetId())
+	if err != nil {
+		return err
+	}
+
+	after := live.FromStart
+	if req.LastEventId != nil {
+		if req.GetLastEventId() < 0 {
+			return errors.Wrap(errInvalidArgument, "last_event_id must not be negative")
+		}
+		after = req.GetLastEventId()
+	}
+
+	if _, err := auth.Authorize(ctx, auth.PermissionReadOrder); err != nil {
+		return err
+	}
+	orderProjection, err := s.os.Queries.GetOrderByID.Handle(ctx, queries.NewGetOrderByIDQuery(orderID))
+	if err != nil {
+		return err
+	}
+	if err := auth.AuthorizeOrder(ctx, auth.PermissionReadOrder, orderProjection.AccountEmail, orderProjection.CustomerID); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	events, errCh := s.stream.Subscribe(ctx, orderID, after)
+
+	for evt := range events {
+		if err := srv.Send(orderEventToProto(evt)); err != nil {
+			return err
+		}
+	}
+
+	if err := <-errCh; err != nil && ctx.Err() == nil {
+		return err
+	}
+	return nil
+}
+
+// authorizeOrder checks the permission, customers also have to own the order.
+func (s *orderGrpcService) authorizeOrder(ctx context.Context, permission auth.Permission, orderID string) error {
+	principal, err := auth.Authorize(ctx, permission)
+	if err != nil || !principal.OwnsOrdersOnly() {
+		return err
+	}
+
+	orderProjection, err := s.os.Queries.GetOrderByID.Handle(ctx, queries.NewGetOrderByIDQuery(orderID))
+	if err != nil {
+		return err
+	}
+	return auth.AuthorizeOrder(ctx, permission, orderProjection.AccountEmail, orderProjection.CustomerID)
+}
+
+func parseID(id string) (string, error) {
+	orderID, err := uuid.FromString(id)
+	if err != nil {
+		return "", errors.Wrap(errInvalidArgument, err.Error())
+	}
+	return orderID.String(), nil
+}
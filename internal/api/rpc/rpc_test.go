@@ -0,0 +1,183 @@
+package rpc
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/mongo"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/wassef911/eventually/internal/api/auth"
+	"github.com/wassef911/eventually/internal/delivery/aggregate"
+	"github.com/wassef911/eventually/internal/delivery/models"
+	"github.com/wassef911/eventually/internal/delivery/payment"
+	"github.com/wassef911/eventually/internal/infrastructure/es"
+	"github.com/wassef911/eventually/pkg/config"
+	httpErrors "github.com/wassef911/eventually/pkg/errors"
+	"github.com/wassef911/eventually/pkg/logger"
+	"github.com/wassef911/eventually/proto/orders"
+)
+
+func TestErrorToStatus(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		code codes.Code
+	}{
+		{name: "order not found", err: errors.Wrap(aggregate.ErrOrderNotFound, "Load"), code: codes.NotFound},
+		{name: "projection not found", err: mongo.ErrNoDocuments, code: codes.NotFound},
+		{name: "already created", err: aggregate.ErrAlreadyCreated, code: codes.AlreadyExists},
+		{name: "status transition", err: aggregate.ErrInvalidStatusTransition, code: codes.FailedPrecondition},
+		{name: "payment declined", err: errors.Wrap(payment.ErrPaymentDeclined, "Charge"), code: codes.FailedPrecondition},
+		{name: "cancel reason", err: aggregate.ErrCancelReasonRequired, code: codes.InvalidArgument},
+		{name: "invalid id", err: errors.Wrap(errInvalidArgument, "uuid: incorrect UUID length"), code: codes.InvalidArgument},
+		{name: "deadline", err: context.DeadlineExceeded, code: codes.DeadlineExceeded},
+		{name: "unauthenticated", err: errors.Wrap(httpErrors.Unauthorized, "missing bearer token"), code: codes.Unauthenticated},
+		{name: "forbidden", err: errors.Wrap(httpErrors.Forbidden, "order belongs to another customer"), code: codes.PermissionDenied},
+		{name: "unknown tenant", err: errors.Wrap(httpErrors.BadRequest, "unknown tenant {initech}"), code: codes.InvalidArgument},
+		{name: "domain not found", err: errors.Wrap(aggregate.ErrReturnNotFound, "Handle"), code: codes.NotFound},
+		{name: "domain conflict", err: aggregate.ErrOrderAlreadyRefunded, code: codes.FailedPrecondition},
+		{name: "domain invariant", err: aggregate.ErrInvalidRefundAmount, code: codes.InvalidArgument},
+		{name: "unknown", err: errors.New("boom"), code: codes.Internal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.code, status.Code(errorToStatus(tt.err, false)))
+		})
+	}
+
+	assert.NoError(t, errorToStatus(nil, false))
+	assert.Equal(t, "internal error", status.Convert(errorToStatus(errors.New("boom"), false)).Message())
+	assert.Equal(t, "boom", status.Convert(errorToStatus(errors.New("boom"), true)).Message())
+
+	existing := status.Error(codes.Unauthenticated, "token")
+	assert.Equal(t, existing, errorToStatus(existing, false))
+}
+
+type notFoundOrderService struct {
+	orders.UnimplementedOrderServiceServer
+}
+
+func (notFoundOrderService) GetOrderByID(context.Context, *orders.GetOrderByIDReq) (*orders.GetOrderByIDRes, error) {
+	return nil, errors.Wrap(aggregate.ErrOrderNotFound, "GetOrderByID")
+}
+
+// tenantOrderService answers with the tenant of the call.
+type tenantOrderService struct {
+	orders.UnimplementedOrderServiceServer
+}
+
+func (tenantOrderService) GetOrderByID(ctx context.Context, req *orders.GetOrderByIDReq) (*orders.GetOrderByIDRes, error) {
+	return nil, status.Error(codes.NotFound, es.TenantFromContext(ctx))
+}
+
+func newTestClient(t *testing.T, srv orders.OrderServiceServer) orders.OrderServiceClient {
+	authenticator, err := auth.NewAuthenticator(config.Auth{})
+	require.NoError(t, err)
+	return newAuthenticatedTestClient(t, srv, authenticator)
+}
+
+func newAuthenticatedTestClient(t *testing.T, srv orders.OrderServiceServer, authenticator *auth.Authenticator) orders.OrderServiceClient {
+	return newConfiguredTestClient(t, srv, authenticator, &config.Config{Logger: &logger.Config{}})
+}
+
+func newConfiguredTestClient(t *testing.T, srv orders.OrderServiceServer, authenticator *auth.Authenticator, cfg *config.Config) orders.OrderServiceClient {
+	appLogger := logger.NewAppLogger(&logger.Config{LogLevel: "fatal"})
+	appLogger.InitLogger()
+
+	listener := bufconn.Listen(1 << 20)
+	im := NewInterceptorManager(appLogger, cfg, authenticator)
+	grpcServer := grpc.NewServer(grpc.ChainUnaryInterceptor(im.Unary), grpc.ChainStreamInterceptor(im.Stream))
+	orders.RegisterOrderServiceServer(grpcServer, srv)
+	go grpcServer.Serve(listener)
+	t.Cleanup(grpcServer.Stop)
+
+	conn, err := grpc.Dial("bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	return orders.NewOrderServiceClient(conn)
+}
+
+func TestInterceptorManager_MapsDomainErrors(t *testing.T) {
+	client := newTestClient(t, notFoundOrderService{})
+
+	_, err := client.GetOrderByID(context.Background(), &orders.GetOrderByIDReq{Id: "7b5e3a4c-1f7e-4c36-9a54-5d3c6f1f2b10"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestOrderGrpcService_InvalidOrderID(t *testing.T) {
+	client := newTestClient(t, NewOrderGrpcService(nil, nil, nil, nil, nil))
+
+	_, err := client.SubmitOrder(context.Background(), &orders.SubmitOrderReq{Id: "not-a-uuid"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	stream, err := client.StreamOrderEvents(context.Background(), &orders.StreamOrderEventsReq{Id: "not-a-uuid"})
+	require.NoError(t, err)
+	_, err = stream.Recv()
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = client.ApproveReturn(context.Background(), &orders.ApproveReturnReq{Id: "7b5e3a4c-1f7e-4c36-9a54-5d3c6f1f2b10", ReturnId: "not-a-uuid"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err), "invalid return id")
+
+	_, err = client.PackShipment(context.Background(), &orders.PackShipmentReq{Id: "7b5e3a4c-1f7e-4c36-9a54-5d3c6f1f2b10", ShipmentId: "not-a-uuid"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err), "invalid shipment id")
+}
+
+func TestOrderGrpcService_GetOrderLifecycle(t *testing.T) {
+	client := newTestClient(t, NewOrderGrpcService(nil, nil, nil, nil, nil))
+
+	res, err := client.GetOrderLifecycle(context.Background(), &orders.GetOrderLifecycleReq{})
+	require.NoError(t, err)
+	assert.Len(t, res.GetStatuses(), len(models.OrderStatuses))
+	require.Len(t, res.GetTransitions(), len(aggregate.OrderTransitions))
+	assert.True(t, proto.Equal(&orders.OrderTransition{Action: "CREATE_ORDER", From: []string{string(models.OrderStatusNew)}, To: string(models.OrderStatusCreated)}, res.GetTransitions()[0]))
+}
+
+func TestInterceptorManager_Authenticates(t *testing.T) {
+	authenticator, err := auth.NewAuthenticator(config.Auth{Enabled: true, Algorithm: "HS256", Secret: "secret"})
+	require.NoError(t, err)
+	client := newAuthenticatedTestClient(t, NewOrderGrpcService(nil, nil, nil, nil, nil), authenticator)
+
+	_, err = client.SubmitOrder(context.Background(), &orders.SubmitOrderReq{Id: "7b5e3a4c-1f7e-4c36-9a54-5d3c6f1f2b10"})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer not-a-token")
+	_, err = client.SubmitOrder(ctx, &orders.SubmitOrderReq{Id: "7b5e3a4c-1f7e-4c36-9a54-5d3c6f1f2b10"})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+}
+
+func TestInterceptorManager_ResolvesTenant(t *testing.T) {
+	authenticator, err := auth.NewAuthenticator(config.Auth{})
+	require.NoError(t, err)
+	cfg := &config.Config{
+		Logger:  &logger.Config{},
+		Tenancy: config.Tenancy{Enabled: true, Header: "X-Tenant-ID", Tenants: map[string]config.Tenant{"acme": {}}},
+	}
+	client := newConfiguredTestClient(t, tenantOrderService{}, authenticator, cfg)
+	req := &orders.GetOrderByIDReq{Id: "7b5e3a4c-1f7e-4c36-9a54-5d3c6f1f2b10"}
+
+	_, err = client.GetOrderByID(metadata.AppendToOutgoingContext(context.Background(), "x-tenant-id", "acme"), req)
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	assert.Equal(t, "acme", status.Convert(err).Message())
+
+	_, err = client.GetOrderByID(context.Background(), req)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err), "missing tenant")
+
+	_, err = client.GetOrderByID(metadata.AppendToOutgoingContext(context.Background(), "x-tenant-id", "initech"), req)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err), "unknown tenant")
+}
@@ -2,14 +2,18 @@ package api
 
 import (
 	"context"
+	"fmt"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
+	"strconv"
 	"strings"
 	"syscall"
 	"time"
 
 	"github.com/go-playground/validator"
+	graphql "github.com/graph-gophers/graphql-go"
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
 	v7 "github.com/olivere/elastic/v7"
@@ -19,22 +23,38 @@ import (
 	"go.mongodb.org/mongo-driver/bson"
 	mongoDriver "go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
 
 	"github.com/wassef911/eventually/docs"
+	"github.com/wassef911/eventually/internal/api/auth"
 	"github.com/wassef911/eventually/internal/api/constants"
+	"github.com/wassef911/eventually/internal/api/graph"
 	"github.com/wassef911/eventually/internal/api/handlers"
 	"github.com/wassef911/eventually/internal/api/middlewares"
+	"github.com/wassef911/eventually/internal/api/rpc"
+	"github.com/wassef911/eventually/internal/delivery/integration"
+	"github.com/wassef911/eventually/internal/delivery/live"
+	"github.com/wassef911/eventually/internal/delivery/models"
+	"github.com/wassef911/eventually/internal/delivery/payment"
 	"github.com/wassef911/eventually/internal/delivery/projections/elastic"
 	"github.com/wassef911/eventually/internal/delivery/projections/mongo"
 	"github.com/wassef911/eventually/internal/delivery/repository"
+	"github.com/wassef911/eventually/internal/delivery/sagas"
 	service "github.com/wassef911/eventually/internal/delivery/services"
+	"github.com/wassef911/eventually/internal/delivery/tax"
+	"github.com/wassef911/eventually/internal/delivery/webhook"
 	"github.com/wassef911/eventually/internal/infrastructure/elasticsearch"
+	"github.com/wassef911/eventually/internal/infrastructure/es"
 	"github.com/wassef911/eventually/internal/infrastructure/es/store"
 	"github.com/wassef911/eventually/internal/infrastructure/eventstore"
+	"github.com/wassef911/eventually/internal/infrastructure/messaging"
 	"github.com/wassef911/eventually/internal/infrastructure/mongodb"
+	"github.com/wassef911/eventually/internal/infrastructure/ratelimit"
 	"github.com/wassef911/eventually/internal/infrastructure/tracing"
 	"github.com/wassef911/eventually/pkg/config"
 	"github.com/wassef911/eventually/pkg/logger"
+	"github.com/wassef911/eventually/proto/orders"
 )
 
 const (
@@ -48,16 +68,26 @@ const (
 )
 
 type Server struct {
-	config        *config.Config
-	log           logger.Logger
-	mw            middlewares.MiddlewareManager
-	orderService  *service.OrderService
-	validator     *validator.Validate
-	mongoClient   *mongoDriver.Client
-	elasticClient *v7.Client
-	echo          *echo.Echo
-	httpServer    *http.Server
-	doneCh        chan struct{}
+	config           *config.Config
+	log              logger.Logger
+	mw               middlewares.MiddlewareManager
+	authenticator    *auth.Authenticator
+	orderService     *service.OrderService
+	inventoryService *service.InventoryService
+	customerService  *service.CustomerService
+	productService   *service.ProductService
+	couponRepo       repository.CouponRepository
+	webhookRepo      repository.WebhookRepository
+	deliveryRepo     repository.WebhookDeliveryRepository
+	orderStream      *live.OrderStream
+	graphqlSchema    *graphql.Schema
+	paymentGateway   payment.Gateway
+	validator        *validator.Validate
+	mongoClient      *mongoDriver.Client
+	elasticClient    *v7.Client
+	echo             *echo.Echo
+	httpServer       *http.Server
+	doneCh           chan struct{}
 }
 
 func New(config *config.Config, log logger.Logger) *Server {
@@ -66,7 +96,6 @@ func New(config *config.Config, log logger.Logger) *Server {
 		log:       log,
 		validator: validator.New(),
 		echo:      echo.New(),
-		mw:        middlewares.NewMiddlewareManager(log, config),
 		doneCh:    make(chan struct{}),
 	}
 }
@@ -79,6 +108,28 @@ func (s *Server) Run() error {
 		return err
 	}
 
+	authenticator, err := auth.NewAuthenticator(s.config.Auth)
+	if err != nil {
+		return errors.Wrap(err, "auth.NewAuthenticator")
+	}
+	if !authenticator.Enabled() {
+		s.log.Warnf("(NewAuthenticator) authentication is disabled, every request acts as admin")
+	}
+	s.authenticator = authenticator
+
+	limiter, err := s.newRateLimiter()
+	if err != nil {
+		return err
+	}
+	defer limiter.Close()
+	s.mw = middlewares.NewMiddlewareManager(s.log, s.config, authenticator, limiter)
+
+	ipExtractor, err := middlewares.NewIPExtractor(s.config.RateLimit.TrustedProxies)
+	if err != nil {
+		return errors.Wrap(err, "middlewares.NewIPExtractor")
+	}
+	s.echo.IPExtractor = ipExtractor
+
 	tracer, closer, err := tracing.New(s.config.Jaeger)
 	if err != nil {
 		return err
@@ -92,6 +143,21 @@ func (s *Server) Run() error {
 
 	mongoRepo := repository.NewMongoRepository(s.log, s.config, s.mongoClient)
 	elasticRepo := repository.NewElasticRepository(s.log, s.config, s.elasticClient)
+	s.couponRepo = repository.NewMongoCouponRepository(s.log, s.config, s.mongoClient)
+	customerRepo := repository.NewMongoCustomerRepository(s.log, s.config, s.mongoClient)
+	productRepo := repository.NewMongoProductRepository(s.log, s.config, s.mongoClient)
+	s.webhookRepo = repository.NewMongoWebhookRepository(s.log, s.config, s.mongoClient)
+	s.deliveryRepo = repository.NewMongoWebhookDeliveryRepository(s.log, s.config, s.mongoClient)
+
+	taxCalculator, err := s.newTaxCalculator()
+	if err != nil {
+		return err
+	}
+
+	s.paymentGateway, err = s.newPaymentGateway()
+	if err != nil {
+		return err
+	}
 
 	db, err := eventstore.NewEventStoreClient(s.config.EventStoreConfig)
 	if err != nil {
@@ -100,7 +166,12 @@ func (s *Server) Run() error {
 	defer db.Close()
 
 	aggregateStore := store.NewAggregateStore(s.log, db)
-	s.orderService = service.New(s.log, s.config, aggregateStore, mongoRepo, elasticRepo)
+	go s.backfillOrders(ctx, mongoRepo, aggregateStore)
+	s.orderService = service.New(s.log, s.config, aggregateStore, store.NewEventStore(s.log, db), mongoRepo, elasticRepo, s.couponRepo, taxCalculator, s.paymentGateway)
+	s.inventoryService = service.NewInventoryService(s.log, s.config, aggregateStore)
+	s.customerService = service.NewCustomerService(s.log, s.config, aggregateStore, customerRepo, mongoRepo)
+	s.productService = service.NewProductService(s.log, s.config, aggregateStore, productRepo)
+	s.orderStream = live.NewOrderStream(s.log, db)
 	mongoProjection := mongo.NewOrderProjection(s.log, db, *mongoRepo, s.config)
 	elasticProjection := elastic.NewElasticProjection(s.log, db, elasticRepo, s.config)
 	go func() {
@@ -119,6 +190,109 @@ func (s *Server) Run() error {
 		}
 	}()
 
+	customerProjection := mongo.NewCustomerProjection(s.log, db, customerRepo, s.config)
+	go func() {
+		err := customerProjection.Subscribe(ctx, []string{s.config.Subscriptions.CustomerPrefix}, s.config.Subscriptions.PoolSize, customerProjection.ProcessEvents)
+		if err != nil {
+			s.log.Errorf("(customerProjection.Subscribe) err: {%v}", err)
+			stop()
+		}
+	}()
+
+	productProjection := mongo.NewProductProjection(s.log, db, productRepo, s.config)
+	go func() {
+		err := productProjection.Subscribe(ctx, []string{s.config.Subscriptions.ProductPrefix}, s.config.Subscriptions.PoolSize, productProjection.ProcessEvents)
+		if err != nil {
+			s.log.Errorf("(productProjection.Subscribe) err: {%v}", err)
+			stop()
+		}
+	}()
+
+	sagaDeadlineRepo := repository.NewMongoSagaDeadlineRepository(s.log, s.config, s.mongoClient)
+	sagaDispatcher := sagas.NewCommandDispatcher(s.log, aggregateStore, s.orderService, s.inventoryService)
+	inventoryProcessManager := es.NewProcessManager[models.OrderReservation](
+		s.log,
+		db,
+		aggregateStore,
+		sagaDeadlineRepo,
+		sagaDispatcher,
+		sagas.NewInventorySaga(),
+		es.ProcessManagerConfig{
+			Name:             sagas.InventorySagaName,
+			GroupName:        s.config.Subscriptions.InventorySagaGroupName,
+			Prefixes:         []string{s.config.Subscriptions.OrderPrefix, s.config.Subscriptions.InventoryPrefix},
+			DeadlineInterval: s.config.Subscriptions.SagaDeadlineInterval,
+		},
+	)
+	go func() {
+		err := inventoryProcessManager.Run(ctx)
+		if err != nil {
+			s.log.Errorf("(inventoryProcessManager.Run) err: {%v}", err)
+			stop()
+		}
+	}()
+
+	clock := es.SystemClock{}
+	paymentProcessManager := es.NewProcessManager[models.PaymentDeadline](
+		s.log,
+		db,
+		aggregateStore,
+		sagaDeadlineRepo,
+		sagaDispatcher,
+		sagas.NewPaymentSaga(clock, s.config.Orders.PaymentTimeout, s.config.Orders.PaymentReminderInterval),
+		es.ProcessManagerConfig{
+			Name:             sagas.PaymentSagaName,
+			GroupName:        s.config.Subscriptions.PaymentSagaGroupName,
+			Prefixes:         []string{s.config.Subscriptions.OrderPrefix},
+			DeadlineInterval: s.config.Subscriptions.SagaDeadlineInterval,
+			Clock:            clock,
+		},
+	)
+	go func() {
+		err := paymentProcessManager.Run(ctx)
+		if err != nil {
+			s.log.Errorf("(paymentProcessManager.Run) err: {%v}", err)
+			stop()
+		}
+	}()
+
+	publisher, err := s.newPublisher()
+	if err != nil {
+		return err
+	}
+	defer publisher.Close()
+
+	outboxRelay := integration.NewOutboxRelay(s.log, db, publisher, s.config)
+	go func() {
+		err := outboxRelay.Subscribe(ctx)
+		if err != nil {
+			s.log.Errorf("(outboxRelay.Subscribe) err: {%v}", err)
+			stop()
+		}
+	}()
+
+	webhookDispatcher := webhook.NewDispatcher(s.log, db, s.webhookRepo, s.deliveryRepo, s.config)
+	go func() {
+		err := webhookDispatcher.Run(ctx)
+		if err != nil {
+			s.log.Errorf("(webhookDispatcher.Run) err: {%v}", err)
+			stop()
+		}
+	}()
+
+	s.graphqlSchema, err = graph.NewSchema(s.log, s.config, s.validator, s.orderService)
+	if err != nil {
+		return err
+	}
+
+	go func() {
+		err := s.runGrpcServer(ctx)
+		if err != nil {
+			s.log.Errorf("(runGrpcServer) err: {%v}", err)
+			stop()
+		}
+	}()
+
 	s.configureServer()
 	s.log.Infof("%s is listening on PORT: {%s}", s.config.ServiceName, s.config.Port)
 	if err := s.echo.Start(s.config.Port); err != nil {
@@ -134,6 +308,96 @@ func (s *Server) validateConfig(ctx context.Context) error {
 	if err := s.validator.StructCtx(ctx, s.config); err != nil {
 		return errors.Wrap(err, "config validate")
 	}
+	if s.config.Tenancy.Enabled && len(s.config.Tenancy.Tenants) == 0 {
+		return errors.Wrap(config.ErrNoTenants, "config validate")
+	}
+	return nil
+}
+
+func (s *Server) newTaxCalculator() (*tax.TableCalculator, error) {
+	if s.config.Orders.TaxRulesFile == "" {
+		s.log.Warnf("(newTaxCalculator) no tax rules file configured, orders are not taxed")
+		return tax.NewTableCalculator(&tax.Rules{}), nil
+	}
+
+	rules, err := tax.LoadRules(s.config.Orders.TaxRulesFile)
+	if err != nil {
+		return nil, errors.Wrap(err, "tax.LoadRules")
+	}
+	return tax.NewTableCalculator(rules), nil
+}
+
+func (s *Server) newPaymentGateway() (payment.Gateway, error) {
+	switch s.config.Payments.Provider {
+	case payment.FakeProvider:
+		s.log.Warnf("(newPaymentGateway) using the fake payment provider, no payment is charged")
+		return payment.NewFakeGateway(s.config.Payments.WebhookSecret), nil
+	default:
+		return nil, errors.Wrapf(payment.ErrUnknownProvider, "provider: {%s}", s.config.Payments.Provider)
+	}
+}
+
+func (s *Server) newRateLimiter() (ratelimit.Limiter, error) {
+	if !s.config.RateLimit.Enabled {
+		s.log.Warnf("(newRateLimiter) rate limiting is disabled")
+	} else if s.config.RateLimit.Backend == ratelimit.MemoryBackend {
+		s.log.Warnf("(newRateLimiter) using the in memory backend, every instance limits its clients on its own")
+	}
+
+	limiter, err := ratelimit.NewLimiter(s.config.RateLimit)
+	if err != nil {
+		return nil, errors.Wrap(err, "ratelimit.NewLimiter")
+	}
+	return limiter, nil
+}
+
+func (s *Server) newPublisher() (messaging.Publisher, error) {
+	if s.config.Outbox.Broker == messaging.MemoryBroker {
+		s.log.Warnf("(newPublisher) using the in memory broker, integration events are not delivered")
+	}
+
+	publisher, err := messaging.NewPublisher(s.config.Outbox)
+	if err != nil {
+		return nil, errors.Wrap(err, "messaging.NewPublisher")
+	}
+	return publisher, nil
+}
+
+// runGrpcServer serves the order api over gRPC until ctx is done, open streams are cut once waitShotDownDuration passed.
+func (s *Server) runGrpcServer(ctx context.Context) error {
+	listener, err := net.Listen("tcp", s.config.Grpc.Port)
+	if err != nil {
+		return errors.Wrap(err, "net.Listen")
+	}
+
+	im := rpc.NewInterceptorManager(s.log, s.config, s.authenticator)
+	grpcServer := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(im.Unary),
+		grpc.ChainStreamInterceptor(im.Stream),
+	)
+	orders.RegisterOrderServiceServer(grpcServer, rpc.NewOrderGrpcService(s.log, s.config, s.validator, s.orderService, s.orderStream))
+	if s.config.Grpc.Reflection {
+		reflection.Register(grpcServer)
+	}
+
+	go func() {
+		<-ctx.Done()
+		stopped := make(chan struct{})
+		go func() {
+			grpcServer.GracefulStop()
+			close(stopped)
+		}()
+		select {
+		case <-stopped:
+		case <-time.After(waitShotDownDuration):
+			grpcServer.Stop()
+		}
+	}()
+
+	s.log.Infof("%s gRPC is listening on PORT: {%s}", s.config.ServiceName, s.config.Grpc.Port)
+	if err := grpcServer.Serve(listener); err != nil {
+		return errors.Wrap(err, "grpcServer.Serve")
+	}
 	return nil
 }
 
@@ -180,9 +444,123 @@ func (s *Server) initEngine(ctx context.Context) error {
 	}
 	s.log.Infof("Elasticsearch version {%s}", esVersion)
 
+	return s.initElasticIndexes(ctx)
+}
+
+func (s *Server) initElasticIndexes(ctx context.Context) error {
+	for _, tenant := range s.tenants() {
+		if err := s.initOrdersIndex(ctx, es.TenantName(tenant, s.config.ElasticIndexes.Orders)); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
+// initOrdersIndex the orders are searched through an alias of the index of the current mapping version. The index of
+// an older version, or a legacy index named like the alias, is reindexed into a new one which then takes its place.
+func (s *Server) initOrdersIndex(ctx context.Context, alias string) error {
+	target := ordersIndexName(alias, repository.OrdersIndexVersion)
+
+	current, err := s.currentOrdersIndex(ctx, alias)
+	if err != nil {
+		return err
+	}
+	if current == target {
+		return nil
+	}
+	if ordersIndexVersion(alias, current) > repository.OrdersIndexVersion {
+		// an instance of a newer release migrated the index already
+		s.log.Warnf("(initOrdersIndex) elastic index: {%s} is newer than mapping version {%d}, kept", current, repository.OrdersIndexVersion)
+		return nil
+	}
+
+	exists, err := s.elasticClient.IndexExists(target).Do(ctx)
+	if err != nil {
+		return errors.Wrap(err, "client.IndexExists")
+	}
+	if !exists {
+		index, err := s.elasticClient.CreateIndex(target).BodyString(repository.OrdersIndexMapping).Do(ctx)
+		if err != nil {
+			return errors.Wrap(err, "client.CreateIndex")
+		}
+		s.log.Infof("(CreatedIndex) elastic index: {%s}, acknowledged: {%v}", index.Index, index.Acknowledged)
+	}
+
+	if current == "" {
+		if _, err := s.elasticClient.Alias().Add(target, alias).Do(ctx); err != nil {
+			return errors.Wrap(err, "client.Alias")
+		}
+		return nil
+	}
+
+	res, err := s.elasticClient.Reindex().SourceIndex(current).DestinationIndex(target).WaitForCompletion(true).Refresh("true").Do(ctx)
+	if err != nil {
+		return errors.Wrap(err, "client.Reindex")
+	}
+	if len(res.Failures) > 0 {
+		return errors.Errorf("reindex of elastic index {%s} into {%s} failed for {%d} documents", current, target, len(res.Failures))
+	}
+	s.log.Infof("(Reindex) elastic index: {%s} into {%s}, documents: {%d}", current, target, res.Total)
+
+	swap := s.elasticClient.Alias().Add(target, alias)
+	if current == alias {
+		// the legacy index is dropped in the same request, an alias cannot be named like an index
+		swap = swap.Action(v7.NewAliasRemoveIndexAction(current))
+	} else {
+		swap = swap.Remove(current, alias)
+	}
+	if _, err := swap.Do(ctx); err != nil {
+		return errors.Wrap(err, "client.Alias")
+	}
+	s.log.Infof("(SwappedAlias) elastic alias: {%s} from {%s} to {%s}", alias, current, target)
+
+	if current == alias {
+		return nil
+	}
+	if _, err := s.elasticClient.DeleteIndex(current).Do(ctx); err != nil {
+		return errors.Wrap(err, "client.DeleteIndex")
+	}
+	return nil
+}
+
+// currentOrdersIndex index the alias points at, the alias itself for a legacy index of that name, empty when neither exists.
+func (s *Server) currentOrdersIndex(ctx context.Context, alias string) (string, error) {
+	res, err := s.elasticClient.Aliases().Index(alias).Do(ctx)
+	if v7.IsNotFound(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", errors.Wrap(err, "client.Aliases")
+	}
+
+	if _, ok := res.Indices[alias]; ok {
+		return alias, nil
+	}
+	indices := res.IndicesByAlias(alias)
+	if len(indices) != 1 {
+		return "", errors.Errorf("elastic alias {%s} points at {%d} indices", alias, len(indices))
+	}
+	return indices[0], nil
+}
+
+func ordersIndexName(alias string, version int) string {
+	return fmt.Sprintf("%s_v%d", alias, version)
+}
+
+// ordersIndexVersion mapping version of the index, 0 for the legacy index or none.
+func ordersIndexVersion(alias string, index string) int {
+	version, err := strconv.Atoi(strings.TrimPrefix(index, alias+"_v"))
+	if err != nil {
+		return 0
+	}
+	return version
+}
+
+// tenants served by the deployment, the default tenant while tenancy is disabled.
+func (s *Server) tenants() []string {
+	return s.config.Tenancy.TenantIDs()
+}
+
 func (s *Server) configureServer() {
 	s.setupAPIHandlers()
 	s.setupSwagger()
@@ -203,20 +581,23 @@ func (s *Server) waitForShutdown(ctx context.Context) {
 }
 
 func (s *Server) initMongoCollections(ctx context.Context) {
-	err := s.mongoClient.Database(s.config.Mongo.Db).CreateCollection(ctx, s.config.MongoCollections.Orders)
+	for _, tenant := range s.tenants() {
+		s.initTenantMongoCollections(ctx, tenant)
+	}
+
+	// deadlines of every tenant share the collection, their correlation ids carry the tenant
+	err := s.mongoClient.Database(s.config.Mongo.Db).CreateCollection(ctx, s.config.MongoCollections.SagaDeadlines)
 	if err != nil {
 		s.log.Warnf("(CreateCollection) err: {%v}", err)
 	}
 
-	indexOptions := options.Index().SetSparse(true).SetUnique(true)
-	index, err := s.mongoClient.Database(s.config.Mongo.Db).Collection(s.config.MongoCollections.Orders).Indexes().CreateOne(ctx, mongoDriver.IndexModel{
-		Keys:    bson.D{{Key: constants.OrderIdIndex, Value: 1}},
-		Options: indexOptions,
+	deadlineIndex, err := s.mongoClient.Database(s.config.Mongo.Db).Collection(s.config.MongoCollections.SagaDeadlines).Indexes().CreateOne(ctx, mongoDriver.IndexModel{
+		Keys: bson.D{{Key: constants.Saga, Value: 1}, {Key: constants.Deadline, Value: 1}},
 	})
 	if err != nil {
 		s.log.Warnf("(CreateOne) err: {%v}", err)
 	}
-	s.log.Infof("(CreatedIndex) index: {%s}", index)
+	s.log.Infof("(CreatedIndex) index: {%s}", deadlineIndex)
 
 	list, err := s.mongoClient.Database(s.config.Mongo.Db).Collection(s.config.MongoCollections.Orders).Indexes().List(ctx)
 	if err != nil {
@@ -238,9 +619,152 @@ func (s *Server) initMongoCollections(ctx context.Context) {
 	s.log.Infof("(Collections) created collections: {%v}", collections)
 }
 
+// backfillOrders orders projected before their creation time or their status was recorded get them from their stream.
+func (s *Server) backfillOrders(ctx context.Context, mongoRepo *repository.MongoRepository, aggregateStore store.AggregateStore) {
+	for _, tenant := range s.tenants() {
+		tenantCtx := es.ContextWithTenant(ctx, tenant)
+		backfilled, err := mongo.BackfillCreatedAt(tenantCtx, s.log, mongoRepo, aggregateStore)
+		if err != nil {
+			s.log.Warnf("(BackfillCreatedAt) tenant: {%s}, err: {%v}", tenant, err)
+		} else if backfilled > 0 {
+			s.log.Infof("(BackfillCreatedAt) tenant: {%s}, orders: {%d}", tenant, backfilled)
+		}
+
+		backfilled, err = mongo.BackfillStatus(tenantCtx, s.log, mongoRepo, aggregateStore)
+		if err != nil {
+			s.log.Warnf("(BackfillStatus) tenant: {%s}, err: {%v}", tenant, err)
+		} else if backfilled > 0 {
+			s.log.Infof("(BackfillStatus) tenant: {%s}, orders: {%d}", tenant, backfilled)
+		}
+	}
+}
+
+// initTenantMongoCollections creates the projection collections of the tenant.
+func (s *Server) initTenantMongoCollections(ctx context.Context, tenant string) {
+	err := s.mongoClient.Database(s.config.Mongo.Db).CreateCollection(ctx, es.TenantName(tenant, s.config.MongoCollections.Orders))
+	if err != nil {
+		s.log.Warnf("(CreateCollection) err: {%v}", err)
+	}
+
+	indexOptions := options.Index().SetSparse(true).SetUnique(true)
+	index, err := s.mongoClient.Database(s.config.Mongo.Db).Collection(es.TenantName(tenant, s.config.MongoCollections.Orders)).Indexes().CreateOne(ctx, mongoDriver.IndexModel{
+		Keys:    bson.D{{Key: constants.OrderIdIndex, Value: 1}},
+		Options: indexOptions,
+	})
+	if err != nil {
+		s.log.Warnf("(CreateOne) err: {%v}", err)
+	}
+	s.log.Infof("(CreatedIndex) index: {%s}", index)
+
+	customerOrdersIndex, err := s.mongoClient.Database(s.config.Mongo.Db).Collection(es.TenantName(tenant, s.config.MongoCollections.Orders)).Indexes().CreateOne(ctx, mongoDriver.IndexModel{
+		Keys: bson.D{{Key: constants.CustomerID, Value: 1}},
+	})
+	if err != nil {
+		s.log.Warnf("(CreateOne) err: {%v}", err)
+	}
+	s.log.Infof("(CreatedIndex) index: {%s}", customerOrdersIndex)
+
+	// listings sort by a key then the order id, equality filters come first so they share the sort
+	totalPriceKey := constants.TotalPrice + "." + constants.Amount
+	listIndexes, err := s.mongoClient.Database(s.config.Mongo.Db).Collection(es.TenantName(tenant, s.config.MongoCollections.Orders)).Indexes().CreateMany(ctx, []mongoDriver.IndexModel{
+		{Keys: bson.D{{Key: constants.CreatedAt, Value: -1}, {Key: constants.OrderId, Value: -1}}},
+		{Keys: bson.D{{Key: totalPriceKey, Value: -1}, {Key: constants.OrderId, Value: -1}}},
+		{Keys: bson.D{{Key: constants.AccountEmail, Value: 1}, {Key: constants.CreatedAt, Value: -1}, {Key: constants.OrderId, Value: -1}}},
+		{Keys: bson.D{{Key: constants.Status, Value: 1}, {Key: constants.CreatedAt, Value: -1}, {Key: constants.OrderId, Value: -1}}},
+		{Keys: bson.D{{Key: constants.Status, Value: 1}, {Key: totalPriceKey, Value: -1}, {Key: constants.OrderId, Value: -1}}},
+		{Keys: bson.D{{Key: constants.Payment + "." + constants.Timestamp, Value: -1}}, Options: options.Index().SetSparse(true)},
+		{Keys: bson.D{{Key: constants.DeliveredTime, Value: -1}}, Options: options.Index().SetSparse(true)},
+	})
+	if err != nil {
+		s.log.Warnf("(CreateMany) err: {%v}", err)
+	}
+	s.log.Infof("(CreatedIndexes) indexes: {%v}", listIndexes)
+
+	err = s.mongoClient.Database(s.config.Mongo.Db).CreateCollection(ctx, es.TenantName(tenant, s.config.MongoCollections.Customers))
+	if err != nil {
+		s.log.Warnf("(CreateCollection) err: {%v}", err)
+	}
+
+	customerIndex, err := s.mongoClient.Database(s.config.Mongo.Db).Collection(es.TenantName(tenant, s.config.MongoCollections.Customers)).Indexes().CreateOne(ctx, mongoDriver.IndexModel{
+		Keys:    bson.D{{Key: constants.CustomerID, Value: 1}},
+		Options: options.Index().SetUnique(true),
+	})
+	if err != nil {
+		s.log.Warnf("(CreateOne) err: {%v}", err)
+	}
+	s.log.Infof("(CreatedIndex) index: {%s}", customerIndex)
+
+	err = s.mongoClient.Database(s.config.Mongo.Db).CreateCollection(ctx, es.TenantName(tenant, s.config.MongoCollections.Products))
+	if err != nil {
+		s.log.Warnf("(CreateCollection) err: {%v}", err)
+	}
+
+	productIndex, err := s.mongoClient.Database(s.config.Mongo.Db).Collection(es.TenantName(tenant, s.config.MongoCollections.Products)).Indexes().CreateOne(ctx, mongoDriver.IndexModel{
+		Keys:    bson.D{{Key: constants.ProductID, Value: 1}},
+		Options: options.Index().SetUnique(true),
+	})
+	if err != nil {
+		s.log.Warnf("(CreateOne) err: {%v}", err)
+	}
+	s.log.Infof("(CreatedIndex) index: {%s}", productIndex)
+
+	err = s.mongoClient.Database(s.config.Mongo.Db).CreateCollection(ctx, es.TenantName(tenant, s.config.MongoCollections.Webhooks))
+	if err != nil {
+		s.log.Warnf("(CreateCollection) err: {%v}", err)
+	}
+
+	webhookIndex, err := s.mongoClient.Database(s.config.Mongo.Db).Collection(es.TenantName(tenant, s.config.MongoCollections.Webhooks)).Indexes().CreateOne(ctx, mongoDriver.IndexModel{
+		Keys:    bson.D{{Key: constants.WebhookID, Value: 1}},
+		Options: options.Index().SetUnique(true),
+	})
+	if err != nil {
+		s.log.Warnf("(CreateOne) err: {%v}", err)
+	}
+	s.log.Infof("(CreatedIndex) index: {%s}", webhookIndex)
+
+	err = s.mongoClient.Database(s.config.Mongo.Db).CreateCollection(ctx, es.TenantName(tenant, s.config.MongoCollections.WebhookDeliveries))
+	if err != nil {
+		s.log.Warnf("(CreateCollection) err: {%v}", err)
+	}
+
+	deliveryIndex, err := s.mongoClient.Database(s.config.Mongo.Db).Collection(es.TenantName(tenant, s.config.MongoCollections.WebhookDeliveries)).Indexes().CreateOne(ctx, mongoDriver.IndexModel{
+		Keys: bson.D{{Key: constants.WebhookID, Value: 1}, {Key: constants.AttemptedAt, Value: -1}},
+	})
+	if err != nil {
+		s.log.Warnf("(CreateOne) err: {%v}", err)
+	}
+	s.log.Infof("(CreatedIndex) index: {%s}", deliveryIndex)
+
+	retryIndex, err := s.mongoClient.Database(s.config.Mongo.Db).Collection(es.TenantName(tenant, s.config.MongoCollections.WebhookDeliveries)).Indexes().CreateOne(ctx, mongoDriver.IndexModel{
+		Keys:    bson.D{{Key: constants.NextAttemptAt, Value: 1}},
+		Options: options.Index().SetSparse(true),
+	})
+	if err != nil {
+		s.log.Warnf("(CreateOne) err: {%v}", err)
+	}
+	s.log.Infof("(CreatedIndex) index: {%s}", retryIndex)
+
+	err = s.mongoClient.Database(s.config.Mongo.Db).CreateCollection(ctx, es.TenantName(tenant, s.config.MongoCollections.Coupons))
+	if err != nil {
+		s.log.Warnf("(CreateCollection) err: {%v}", err)
+	}
+
+	couponIndex, err := s.mongoClient.Database(s.config.Mongo.Db).Collection(es.TenantName(tenant, s.config.MongoCollections.Coupons)).Indexes().CreateOne(ctx, mongoDriver.IndexModel{
+		Keys:    bson.D{{Key: constants.Code, Value: 1}},
+		Options: options.Index().SetUnique(true),
+	})
+	if err != nil {
+		s.log.Warnf("(CreateOne) err: {%v}", err)
+	}
+	s.log.Infof("(CreatedIndex) index: {%s}", couponIndex)
+}
+
 func (s *Server) setupAPIHandlers() {
+	// a group with middleware answers every path under it, both order handlers share one
+	ordersGroup := s.echo.Group("/api/orders", s.mw.Tenant)
+
 	orderHandlers := handlers.NewOrderHandlers(
-		s.echo.Group("/api/orders"),
+		ordersGroup,
 		s.log,
 		s.mw,
 		s.config,
@@ -248,6 +772,89 @@ func (s *Server) setupAPIHandlers() {
 		s.orderService,
 	)
 	orderHandlers.MapRoutes()
+
+	orderStreamHandlers := handlers.NewOrderStreamHandlers(
+		ordersGroup,
+		s.log,
+		s.mw,
+		s.config,
+		s.validator,
+		s.orderService,
+		s.orderStream,
+	)
+	orderStreamHandlers.MapRoutes()
+
+	couponHandlers := handlers.NewCouponHandlers(
+		s.echo.Group("/api/coupons", s.mw.Tenant),
+		s.log,
+		s.mw,
+		s.config,
+		s.validator,
+		s.couponRepo,
+	)
+	couponHandlers.MapRoutes()
+
+	inventoryHandlers := handlers.NewInventoryHandlers(
+		s.echo.Group("/api/inventory", s.mw.Tenant),
+		s.log,
+		s.mw,
+		s.config,
+		s.validator,
+		s.inventoryService,
+	)
+	inventoryHandlers.MapRoutes()
+
+	paymentHandlers := handlers.NewPaymentHandlers(
+		s.echo.Group("/api/payments", s.mw.Tenant),
+		s.log,
+		s.mw,
+		s.config,
+		s.validator,
+		s.orderService,
+		s.paymentGateway,
+	)
+	paymentHandlers.MapRoutes()
+
+	customerHandlers := handlers.NewCustomerHandlers(
+		s.echo.Group("/api/customers", s.mw.Tenant),
+		s.log,
+		s.mw,
+		s.config,
+		s.validator,
+		s.customerService,
+	)
+	customerHandlers.MapRoutes()
+
+	productHandlers := handlers.NewProductHandlers(
+		s.echo.Group("/api/products", s.mw.Tenant),
+		s.log,
+		s.mw,
+		s.config,
+		s.validator,
+		s.productService,
+	)
+	productHandlers.MapRoutes()
+
+	webhookHandlers := handlers.NewWebhookHandlers(
+		s.echo.Group("/api/webhooks", s.mw.Tenant),
+		s.log,
+		s.mw,
+		s.config,
+		s.validator,
+		s.webhookRepo,
+		s.deliveryRepo,
+	)
+	webhookHandlers.MapRoutes()
+
+	graphQLHandlers := handlers.NewGraphQLHandlers(
+		s.echo.Group("/graphql", s.mw.Tenant),
+		s.log,
+		s.mw,
+		s.config,
+		s.validator,
+		s.graphqlSchema,
+	)
+	graphQLHandlers.MapRoutes()
 }
 
 func (s *Server) setupSwagger() {
@@ -267,6 +874,7 @@ func (s *Server) setupGlobalMiddlewares() {
 		s.createGzipMiddleware(),
 		middleware.BodyLimit(bodyLimit),
 		s.mw.Apply,
+		s.mw.RateLimit,
 	)
 }
 
@@ -274,7 +882,9 @@ func (s *Server) createGzipMiddleware() echo.MiddlewareFunc {
 	return middleware.GzipWithConfig(middleware.GzipConfig{
 		Level: gzipLevel,
 		Skipper: func(c echo.Context) bool {
-			return strings.Contains(c.Request().URL.Path, "swagger")
+			// streams are flushed event by event
+			path := c.Request().URL.Path
+			return strings.Contains(path, "swagger") || strings.HasSuffix(path, "/stream") || strings.HasSuffix(path, "/ws")
 		},
 	})
 }
@@ -0,0 +1,124 @@
+package utils
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/wassef911/eventually/internal/delivery/domain"
+	"github.com/wassef911/eventually/internal/delivery/models"
+)
+
+const (
+	SortCreatedAt  = "createdAt"
+	SortTotalPrice = "totalPrice"
+
+	SortAsc  = "asc"
+	SortDesc = "desc"
+
+	maxCursorSize = 100
+)
+
+var ErrInvalidCursor = domain.NewInvalidError("invalid_cursor", "invalid cursor")
+
+// CursorPagination keyset pagination, a page starts right after the order its cursor points to.
+type CursorPagination struct {
+	Size   int          `json:"size" validate:"min=1,max=100"`
+	Sort   string       `json:"sort" validate:"oneof=createdAt totalPrice"`
+	Order  string       `json:"order" validate:"oneof=asc desc"`
+	Cursor *OrderCursor `json:"cursor,omitempty"`
+}
+
+// OrderCursor position of an order in a listing, only valid for the sort it was issued for.
+type OrderCursor struct {
+	Sort       string    `json:"s"`
+	CreatedAt  time.Time `json:"c,omitempty"`
+	TotalPrice int64     `json:"t,omitempty"`
+	OrderID    string    `json:"o"`
+}
+
+// NewCursorPaginationFromQueryParams newest orders first unless sort and order say otherwise.
+func NewCursorPaginationFromQueryParams(size string, sort string, order string, cursor string) (*CursorPagination, error) {
+	cp := &CursorPagination{Size: defaultSize, Sort: SortCreatedAt, Order: SortDesc}
+
+	if size != "" {
+		sizeNum, err := strconv.Atoi(size)
+		if err != nil {
+			return nil, errors.Wrap(err, "strconv.Atoi")
+		}
+		cp.Size = sizeNum
+	}
+	if sort != "" {
+		cp.Sort = sort
+	}
+	if order != "" {
+		cp.Order = order
+	}
+
+	if cursor != "" {
+		orderCursor, err := DecodeOrderCursor(cursor)
+		if err != nil {
+			return nil, err
+		}
+		if orderCursor.Sort != cp.Sort {
+			return nil, errors.Wrapf(ErrInvalidCursor, "cursor of sort {%s} used with sort {%s}", orderCursor.Sort, cp.Sort)
+		}
+		cp.Cursor = orderCursor
+	}
+
+	return cp, nil
+}
+
+func (cp *CursorPagination) GetSize() int {
+	if cp.Size <= 0 {
+		return defaultSize
+	}
+	if cp.Size > maxCursorSize {
+		return maxCursorSize
+	}
+	return cp.Size
+}
+
+func (cp *CursorPagination) IsDescending() bool {
+	return cp.Order == SortDesc
+}
+
+// NewOrderCursor cursor pointing to the order in a listing sorted by sort.
+func NewOrderCursor(sort string, order *models.OrderProjection) *OrderCursor {
+	cursor := &OrderCursor{Sort: sort, OrderID: order.OrderID}
+	switch sort {
+	case SortTotalPrice:
+		cursor.TotalPrice = order.TotalPrice.Amount
+	default:
+		cursor.CreatedAt = order.CreatedAt
+	}
+	return cursor
+}
+
+// Encode opaque url safe form of the cursor.
+func (c *OrderCursor) Encode() (string, error) {
+	data, err := json.Marshal(c)
+	if err != nil {
+		return "", errors.Wrap(err, "json.Marshal")
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+func DecodeOrderCursor(value string) (*OrderCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, errors.Wrap(ErrInvalidCursor, err.Error())
+	}
+
+	var cursor OrderCursor
+	if err := json.Unmarshal(data, &cursor); err != nil {
+		return nil, errors.Wrap(ErrInvalidCursor, err.Error())
+	}
+	if cursor.OrderID == "" {
+		return nil, errors.Wrap(ErrInvalidCursor, "order id is required")
+	}
+	return &cursor, nil
+}
@@ -0,0 +1,57 @@
+package utils_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wassef911/eventually/internal/api/utils"
+	"github.com/wassef911/eventually/internal/delivery/models"
+)
+
+func TestNewCursorPaginationFromQueryParams(t *testing.T) {
+	cp, err := utils.NewCursorPaginationFromQueryParams("", "", "", "")
+	require.NoError(t, err)
+	assert.Equal(t, &utils.CursorPagination{Size: 10, Sort: utils.SortCreatedAt, Order: utils.SortDesc}, cp)
+	assert.True(t, cp.IsDescending())
+
+	order := &models.OrderProjection{OrderID: "order-2", TotalPrice: models.NewMoney(2500, "USD"), CreatedAt: time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)}
+	cursor, err := utils.NewOrderCursor(utils.SortTotalPrice, order).Encode()
+	require.NoError(t, err)
+
+	cp, err = utils.NewCursorPaginationFromQueryParams("20", utils.SortTotalPrice, utils.SortAsc, cursor)
+	require.NoError(t, err)
+	assert.Equal(t, 20, cp.GetSize())
+	assert.False(t, cp.IsDescending())
+	assert.Equal(t, &utils.OrderCursor{Sort: utils.SortTotalPrice, TotalPrice: 2500, OrderID: "order-2"}, cp.Cursor)
+
+	_, err = utils.NewCursorPaginationFromQueryParams("", utils.SortCreatedAt, "", cursor)
+	assert.True(t, errors.Is(err, utils.ErrInvalidCursor), "cursor of another sort")
+
+	_, err = utils.NewCursorPaginationFromQueryParams("ten", "", "", "")
+	assert.Error(t, err)
+}
+
+func TestDecodeOrderCursor(t *testing.T) {
+	createdAt := time.Date(2026, 1, 2, 3, 4, 5, 6000000, time.UTC)
+	cursor, err := utils.NewOrderCursor(utils.SortCreatedAt, &models.OrderProjection{OrderID: "order-1", CreatedAt: createdAt}).Encode()
+	require.NoError(t, err)
+
+	decoded, err := utils.DecodeOrderCursor(cursor)
+	require.NoError(t, err)
+	assert.Equal(t, "order-1", decoded.OrderID)
+	assert.True(t, createdAt.Equal(decoded.CreatedAt))
+
+	for _, value := range []string{"%%%", "bm90IGpzb24", "e30"} {
+		_, err := utils.DecodeOrderCursor(value)
+		assert.True(t, errors.Is(err, utils.ErrInvalidCursor), value)
+	}
+}
+
+func TestCursorPagination_GetSize(t *testing.T) {
+	assert.Equal(t, 10, (&utils.CursorPagination{}).GetSize())
+	assert.Equal(t, 100, (&utils.CursorPagination{Size: 1000}).GetSize())
+}
@@ -8,18 +8,33 @@ import (
 
 func OrderProjectionFrom(orderAggregate *aggregate.OrderAggregate) *models.OrderProjection {
 	return &models.OrderProjection{
-		OrderID:         aggregate.GetOrderAggregateID(orderAggregate.GetID()),
-		ShopItems:       orderAggregate.Order.ShopItems,
-		Paid:            orderAggregate.Order.Paid,
-		Submitted:       orderAggregate.Order.Submitted,
-		Completed:       orderAggregate.Order.Completed,
-		Canceled:        orderAggregate.Order.Canceled,
-		AccountEmail:    orderAggregate.Order.AccountEmail,
-		TotalPrice:      orderAggregate.Order.TotalPrice,
-		DeliveredTime:   orderAggregate.Order.DeliveredTime,
-		CancelReason:    orderAggregate.Order.CancelReason,
-		DeliveryAddress: orderAggregate.Order.DeliveryAddress,
-		Payment:         orderAggregate.Order.Payment,
+		OrderID:          aggregate.GetOrderAggregateID(orderAggregate.GetID()),
+		ShopItems:        orderAggregate.Order.ShopItems,
+		CustomerID:       orderAggregate.Order.CustomerID,
+		AccountEmail:     orderAggregate.Order.AccountEmail,
+		Subtotal:         orderAggregate.Order.Subtotal,
+		Discounts:        orderAggregate.Order.Discounts,
+		DiscountTotal:    orderAggregate.Order.DiscountTotal,
+		ShippingPrice:    orderAggregate.Order.ShippingPrice,
+		TaxTotal:         orderAggregate.Order.TaxTotal,
+		Taxes:            orderAggregate.Order.Taxes,
+		TaxRegion:        orderAggregate.Order.TaxRegion,
+		Coupons:          orderAggregate.Order.Coupons,
+		TotalPrice:       orderAggregate.Order.TotalPrice,
+		CreatedAt:        orderAggregate.Order.CreatedAt,
+		DeliveredTime:    orderAggregate.Order.DeliveredTime,
+		Status:           orderAggregate.Order.Status,
+		CancelReason:     orderAggregate.Order.CancelReason,
+		RejectReason:     orderAggregate.Order.RejectReason,
+		DeliveryAddress:  orderAggregate.Order.DeliveryAddress,
+		Payment:          orderAggregate.Order.Payment,
+		Refunds:          orderAggregate.Order.Refunds,
+		RefundedAmount:   orderAggregate.Order.RefundedAmount,
+		Refunded:         orderAggregate.Order.Refunded,
+		Returns:          orderAggregate.Order.Returns,
+		Shipments:        orderAggregate.Order.Shipments,
+		PaymentReminders: orderAggregate.Order.PaymentReminders,
+		PaymentAttempts:  orderAggregate.Order.PaymentAttempts,
 	}
 }
 
@@ -28,19 +43,40 @@ func OrderResponseFrom(projection *models.OrderProjection) dto.OrderResponseDto
 		ID:              projection.ID,
 		OrderID:         projection.OrderID,
 		ShopItems:       ShopItemsResponseFromModels(projection.ShopItems),
+		CustomerID:      projection.CustomerID,
 		AccountEmail:    projection.AccountEmail,
-		DeliveryAddress: projection.DeliveryAddress,
+		DeliveryAddress: AddressResponseFromModel(projection.DeliveryAddress),
 		CancelReason:    projection.CancelReason,
-		TotalPrice:      projection.TotalPrice,
+		RejectReason:    projection.RejectReason,
+		Subtotal:        MoneyResponseFromModel(projection.Subtotal),
+		Discounts:       DiscountsResponseFromModels(projection.Discounts),
+		DiscountTotal:   MoneyResponseFromModel(projection.DiscountTotal),
+		ShippingPrice:   MoneyResponseFromModel(projection.ShippingPrice),
+		TaxTotal:        MoneyResponseFromModel(projection.TaxTotal),
+		Taxes:           TaxLinesResponseFromModels(projection.Taxes),
+		TaxRegion:       projection.TaxRegion,
+		Coupons:         CouponsResponseFromModels(projection.Coupons),
+		TotalPrice:      MoneyResponseFromModel(projection.TotalPrice),
+		CreatedAt:       projection.CreatedAt,
 		DeliveredTime:   projection.DeliveredTime,
-		Paid:            projection.Paid,
-		Submitted:       projection.Submitted,
-		Completed:       projection.Completed,
-		Canceled:        projection.Canceled,
+		Status:          string(projection.Status),
+		Paid:            projection.Paid(),
+		Submitted:       projection.Status.Submitted(),
+		Completed:       projection.Status.Completed(),
+		Canceled:        projection.Status.Canceled(),
 		Payment: dto.Payment{
 			PaymentID: projection.Payment.PaymentID,
 			Timestamp: projection.Payment.Timestamp,
+			Provider:  projection.Payment.Provider,
+			Amount:    MoneyResponseFromModel(projection.Payment.Amount),
 		},
+		Refunds:          RefundsResponseFromModels(projection.Refunds),
+		RefundedAmount:   MoneyResponseFromModel(projection.RefundedAmount),
+		Refunded:         projection.Refunded,
+		Returns:          OrderReturnsResponseFromModels(projection.Returns),
+		Shipments:        ShipmentsResponseFromModels(projection.Shipments),
+		PaymentReminders: projection.PaymentReminders,
+		PaymentAttempts:  PaymentAttemptsResponseFromModels(projection.PaymentAttempts),
 	}
 }
 
@@ -60,8 +96,334 @@ func ShopItemsResponseFromModels(items []*models.ShopItem) []dto.ShopItem {
 			Title:       item.Title,
 			Description: item.Description,
 			Quantity:    item.Quantity,
-			Price:       item.Price,
+			Price:       MoneyResponseFromModel(item.Price),
+			TaxCategory: item.TaxCategory,
 		})
 	}
 	return shopItems
 }
+
+func AddressResponseFromModel(address models.Address) dto.Address {
+	return dto.Address{
+		Recipient:  address.Recipient,
+		Line1:      address.Line1,
+		Line2:      address.Line2,
+		City:       address.City,
+		PostalCode: address.PostalCode,
+		Region:     address.Region,
+		Country:    address.Country,
+		Phone:      address.Phone,
+	}
+}
+
+func AddressFromDto(address dto.Address) models.Address {
+	return models.Address{
+		Recipient:  address.Recipient,
+		Line1:      address.Line1,
+		Line2:      address.Line2,
+		City:       address.City,
+		PostalCode: address.PostalCode,
+		Region:     address.Region,
+		Country:    address.Country,
+		Phone:      address.Phone,
+	}
+}
+
+func MoneyResponseFromModel(money models.Money) dto.Money {
+	return dto.Money{Amount: money.Amount, Currency: money.Currency}
+}
+
+func PaymentAttemptsResponseFromModels(attempts []*models.PaymentAttempt) []dto.PaymentAttempt {
+	attemptsResponse := make([]dto.PaymentAttempt, 0, len(attempts))
+	for _, attempt := range attempts {
+		attemptsResponse = append(attemptsResponse, dto.PaymentAttempt{
+			AttemptID:     attempt.AttemptID,
+			PaymentID:     attempt.PaymentID,
+			Provider:      attempt.Provider,
+			Amount:        MoneyResponseFromModel(attempt.Amount),
+			Status:        string(attempt.Status),
+			FailureReason: attempt.FailureReason,
+			Timestamp:     attempt.Timestamp,
+		})
+	}
+	return attemptsResponse
+}
+
+func RefundsResponseFromModels(refunds []*models.Refund) []dto.Refund {
+	refundsResponse := make([]dto.Refund, 0, len(refunds))
+	for _, refund := range refunds {
+		items := make([]dto.RefundItem, 0, len(refund.Items))
+		for _, item := range refund.Items {
+			items = append(items, dto.RefundItem{ShopItemID: item.ShopItemID, Quantity: item.Quantity})
+		}
+		refundsResponse = append(refundsResponse, dto.Refund{
+			RefundID:  refund.RefundID,
+			Amount:    MoneyResponseFromModel(refund.Amount),
+			Items:     items,
+			Reason:    refund.Reason,
+			Timestamp: refund.Timestamp,
+		})
+	}
+	return refundsResponse
+}
+
+func RefundItemsFromDto(items []dto.RefundItem) []*models.RefundItem {
+	refundItems := make([]*models.RefundItem, 0, len(items))
+	for _, item := range items {
+		refundItems = append(refundItems, &models.RefundItem{ShopItemID: item.ShopItemID, Quantity: item.Quantity})
+	}
+	return refundItems
+}
+
+func MoneyFromDto(money *dto.Money) *models.Money {
+	if money == nil {
+		return nil
+	}
+	return &models.Money{Amount: money.Amount, Currency: money.Currency}
+}
+
+func OrderReturnsResponseFromModels(orderReturns []*models.OrderReturn) []dto.OrderReturn {
+	returnsResponse := make([]dto.OrderReturn, 0, len(orderReturns))
+	for _, orderReturn := range orderReturns {
+		items := make([]dto.ReturnItem, 0, len(orderReturn.Items))
+		for _, item := range orderReturn.Items {
+			items = append(items, dto.ReturnItem{ShopItemID: item.ShopItemID, Quantity: item.Quantity})
+		}
+		returnsResponse = append(returnsResponse, dto.OrderReturn{
+			ReturnID:     orderReturn.ReturnID,
+			Items:        items,
+			Reason:       orderReturn.Reason,
+			Status:       string(orderReturn.Status),
+			RejectReason: orderReturn.RejectReason,
+			RefundID:     orderReturn.RefundID,
+			RequestedAt:  orderReturn.RequestedAt,
+			ReceivedAt:   orderReturn.ReceivedAt,
+		})
+	}
+	return returnsResponse
+}
+
+func ReturnItemsFromDto(items []dto.ReturnItem) []*models.ReturnItem {
+	returnItems := make([]*models.ReturnItem, 0, len(items))
+	for _, item := range items {
+		returnItems = append(returnItems, &models.ReturnItem{ShopItemID: item.ShopItemID, Quantity: item.Quantity})
+	}
+	return returnItems
+}
+
+func ShipmentsResponseFromModels(shipments []*models.Shipment) []dto.Shipment {
+	shipmentsResponse := make([]dto.Shipment, 0, len(shipments))
+	for _, shipment := range shipments {
+		items := make([]dto.ShipmentItem, 0, len(shipment.Items))
+		for _, item := range shipment.Items {
+			items = append(items, dto.ShipmentItem{ShopItemID: item.ShopItemID, Quantity: item.Quantity})
+		}
+		shipmentsResponse = append(shipmentsResponse, dto.Shipment{
+			ShipmentID:     shipment.ShipmentID,
+			Items:          items,
+			Status:         string(shipment.Status),
+			Carrier:        shipment.Carrier,
+			TrackingNumber: shipment.TrackingNumber,
+			CreatedAt:      shipment.CreatedAt,
+			PackedAt:       shipment.PackedAt,
+			DispatchedAt:   shipment.DispatchedAt,
+			DeliveredAt:    shipment.DeliveredAt,
+		})
+	}
+	return shipmentsResponse
+}
+
+func ShipmentItemsFromDto(items []dto.ShipmentItem) []*models.ShipmentItem {
+	shipmentItems := make([]*models.ShipmentItem, 0, len(items))
+	for _, item := range items {
+		shipmentItems = append(shipmentItems, &models.ShipmentItem{ShopItemID: item.ShopItemID, Quantity: item.Quantity})
+	}
+	return shipmentItems
+}
+
+func OrderLifecycleResponseFrom(statuses []models.OrderStatus, transitions []aggregate.OrderTransition) dto.OrderLifecycleResponseDto {
+	statusesResponse := make([]string, 0, len(statuses))
+	for _, status := range statuses {
+		statusesResponse = append(statusesResponse, string(status))
+	}
+
+	transitionsResponse := make([]dto.OrderTransition, 0, len(transitions))
+	for _, transition := range transitions {
+		from := make([]string, 0, len(transition.From))
+		for _, status := range transition.From {
+			from = append(from, string(status))
+		}
+		transitionsResponse = append(transitionsResponse, dto.OrderTransition{
+			Action: string(transition.Action),
+			From:   from,
+			To:     string(transition.To),
+		})
+	}
+
+	return dto.OrderLifecycleResponseDto{Statuses: statusesResponse, Transitions: transitionsResponse}
+}
+
+func DiscountsResponseFromModels(discounts []*models.Discount) []dto.Discount {
+	discountsResponse := make([]dto.Discount, 0, len(discounts))
+	for _, discount := range discounts {
+		discountsResponse = append(discountsResponse, dto.Discount{Code: discount.Code, Amount: MoneyResponseFromModel(discount.Amount)})
+	}
+	return discountsResponse
+}
+
+func TaxLinesResponseFromModels(taxes []*models.TaxLine) []dto.TaxLine {
+	taxesResponse := make([]dto.TaxLine, 0, len(taxes))
+	for _, tax := range taxes {
+		taxesResponse = append(taxesResponse, dto.TaxLine{
+			ShopItemID:  tax.ShopItemID,
+			TaxCategory: tax.TaxCategory,
+			Rate:        tax.Rate,
+			Amount:      MoneyResponseFromModel(tax.Amount),
+		})
+	}
+	return taxesResponse
+}
+
+func CouponsResponseFromModels(coupons []*models.Coupon) []dto.Coupon {
+	couponsResponse := make([]dto.Coupon, 0, len(coupons))
+	for _, coupon := range coupons {
+		couponsResponse = append(couponsResponse, CouponResponseFromModel(coupon))
+	}
+	return couponsResponse
+}
+
+func CouponResponseFromModel(coupon *models.Coupon) dto.Coupon {
+	return dto.Coupon{
+		Code:        coupon.Code,
+		Type:        string(coupon.Type),
+		Percentage:  coupon.Percentage,
+		Amount:      MoneyResponseFromModel(coupon.Amount),
+		ShopItemID:  coupon.ShopItemID,
+		BuyQuantity: coupon.BuyQuantity,
+		GetQuantity: coupon.GetQuantity,
+		ExpiresAt:   coupon.ExpiresAt,
+		Disabled:    coupon.Disabled,
+	}
+}
+
+func CouponFromDto(coupon dto.Coupon) *models.Coupon {
+	return &models.Coupon{
+		Code:        coupon.Code,
+		Type:        models.PromotionType(coupon.Type),
+		Percentage:  coupon.Percentage,
+		Amount:      models.Money{Amount: coupon.Amount.Amount, Currency: coupon.Amount.Currency},
+		ShopItemID:  coupon.ShopItemID,
+		BuyQuantity: coupon.BuyQuantity,
+		GetQuantity: coupon.GetQuantity,
+		ExpiresAt:   coupon.ExpiresAt,
+		Disabled:    coupon.Disabled,
+	}
+}
+
+func InventoryResponseFromModel(inventory *models.Inventory) dto.InventoryResponseDto {
+	return dto.InventoryResponseDto{
+		SKU:       inventory.SKU,
+		OnHand:    inventory.OnHand,
+		Reserved:  inventory.Reserved,
+		Available: inventory.Available(),
+	}
+}
+
+func CustomerResponseFromModel(customer *models.CustomerProjection) dto.CustomerResponseDto {
+	addresses := make([]dto.SavedAddress, 0, len(customer.Addresses))
+	for _, saved := range customer.Addresses {
+		addresses = append(addresses, dto.SavedAddress{
+			AddressID: saved.AddressID,
+			Label:     saved.Label,
+			Address:   AddressResponseFromModel(saved.Address),
+			Default:   saved.Default,
+		})
+	}
+
+	return dto.CustomerResponseDto{
+		CustomerID:   customer.CustomerID,
+		Email:        customer.Email,
+		Name:         customer.Name,
+		Addresses:    addresses,
+		Preferences:  CustomerPreferencesResponseFromModel(customer.Preferences),
+		RegisteredAt: customer.RegisteredAt,
+	}
+}
+
+func CustomerPreferencesResponseFromModel(preferences models.CustomerPreferences) dto.CustomerPreferences {
+	return dto.CustomerPreferences{
+		Language:       preferences.Language,
+		Currency:       preferences.Currency,
+		MarketingOptIn: preferences.MarketingOptIn,
+	}
+}
+
+func CustomerPreferencesFromDto(preferences dto.CustomerPreferences) models.CustomerPreferences {
+	return models.CustomerPreferences{
+		Language:       preferences.Language,
+		Currency:       preferences.Currency,
+		MarketingOptIn: preferences.MarketingOptIn,
+	}
+}
+
+func ProductResponseFromModel(product *models.ProductProjection) dto.ProductResponseDto {
+	productResponse := dto.ProductResponseDto{
+		ProductID:    product.ProductID,
+		Title:        product.Title,
+		Description:  product.Description,
+		Price:        MoneyResponseFromModel(product.Price),
+		TaxCategory:  product.TaxCategory,
+		Discontinued: product.Discontinued,
+		CreatedAt:    product.CreatedAt,
+		UpdatedAt:    product.UpdatedAt,
+	}
+	if product.Discontinued {
+		discontinuedAt := product.DiscontinuedAt
+		productResponse.DiscontinuedAt = &discontinuedAt
+	}
+	return productResponse
+}
+
+func ProductsResponseFrom(projections []*models.ProductProjection) []dto.ProductResponseDto {
+	products := make([]dto.ProductResponseDto, 0, len(projections))
+	for _, projection := range projections {
+		products = append(products, ProductResponseFromModel(projection))
+	}
+	return products
+}
+
+func WebhookResponseFromModel(webhook *models.Webhook) dto.WebhookResponseDto {
+	webhookResponse := dto.WebhookResponseDto{
+		WebhookID:           webhook.WebhookID,
+		URL:                 webhook.URL,
+		EventTypes:          webhook.EventTypes,
+		Disabled:            webhook.Disabled,
+		ConsecutiveFailures: webhook.ConsecutiveFailures,
+		CreatedAt:           webhook.CreatedAt,
+		UpdatedAt:           webhook.UpdatedAt,
+	}
+	if webhook.Disabled {
+		disabledAt := webhook.DisabledAt
+		webhookResponse.DisabledAt = &disabledAt
+	}
+	return webhookResponse
+}
+
+func WebhookDeliveriesResponseFrom(deliveries []*models.WebhookDelivery) []dto.WebhookDeliveryResponseDto {
+	deliveriesResponse := make([]dto.WebhookDeliveryResponseDto, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		deliveriesResponse = append(deliveriesResponse, dto.WebhookDeliveryResponseDto{
+			DeliveryID:    delivery.DeliveryID,
+			EventID:       delivery.EventID,
+			EventType:     delivery.EventType,
+			Attempt:       delivery.Attempt,
+			StatusCode:    delivery.StatusCode,
+			Error:         delivery.Error,
+			Succeeded:     delivery.Succeeded,
+			DurationMs:    delivery.Duration,
+			AttemptedAt:   delivery.AttemptedAt,
+			NextAttemptAt: delivery.NextAttemptAt,
+		})
+	}
+	return deliveriesResponse
+}
@@ -13,16 +13,13 @@ import (
 func TestOrderResponseFrom(t *testing.T) {
 	projection := &models.OrderProjection{
 		OrderID:         "order123",
-		ShopItems:       []*models.ShopItem{{ID: "item1", Title: "Item 1", Description: "Description 1", Quantity: 2, Price: 10.0}},
-		Paid:            true,
-		Submitted:       true,
-		Completed:       false,
-		Canceled:        false,
+		ShopItems:       []*models.ShopItem{{ID: "item1", Title: "Item 1", Description: "Description 1", Quantity: 2, Price: models.NewMoney(1000, "USD")}},
+		Status:          models.OrderStatusSubmitted,
 		AccountEmail:    "test@example.com",
-		TotalPrice:      20.0,
+		TotalPrice:      models.NewMoney(2000, "USD"),
 		DeliveredTime:   time.Now(),
 		CancelReason:    "",
-		DeliveryAddress: "123 Main St",
+		DeliveryAddress: models.Address{Recipient: "John Doe", Line1: "123 Main St", City: "Springfield", PostalCode: "62701", Region: "IL", Country: "US"},
 		Payment:         models.Payment{PaymentID: "pay123", Timestamp: time.Now()},
 	}
 
@@ -30,14 +27,16 @@ func TestOrderResponseFrom(t *testing.T) {
 
 	assert.Equal(t, projection.OrderID, response.OrderID)
 	assert.Equal(t, projection.AccountEmail, response.AccountEmail)
-	assert.Equal(t, projection.DeliveryAddress, response.DeliveryAddress)
+	assert.Equal(t, projection.DeliveryAddress, utils.AddressFromDto(response.DeliveryAddress))
 	assert.Equal(t, projection.CancelReason, response.CancelReason)
-	assert.Equal(t, projection.TotalPrice, response.TotalPrice)
+	assert.Equal(t, projection.TotalPrice.Amount, response.TotalPrice.Amount)
+	assert.Equal(t, projection.TotalPrice.Currency, response.TotalPrice.Currency)
 	assert.Equal(t, projection.DeliveredTime, response.DeliveredTime)
-	assert.Equal(t, projection.Paid, response.Paid)
-	assert.Equal(t, projection.Submitted, response.Submitted)
-	assert.Equal(t, projection.Completed, response.Completed)
-	assert.Equal(t, projection.Canceled, response.Canceled)
+	assert.Equal(t, string(projection.Status), response.Status)
+	assert.True(t, response.Paid)
+	assert.True(t, response.Submitted)
+	assert.False(t, response.Completed)
+	assert.False(t, response.Canceled)
 	assert.Equal(t, projection.Payment.PaymentID, response.Payment.PaymentID)
 	assert.Equal(t, projection.Payment.Timestamp, response.Payment.Timestamp)
 }
@@ -46,31 +45,25 @@ func TestOrdersResponseFrom(t *testing.T) {
 	projections := []*models.OrderProjection{
 		{
 			OrderID:         "order1",
-			ShopItems:       []*models.ShopItem{{ID: "item1", Title: "Item 1", Description: "Description 1", Quantity: 2, Price: 10.0}},
-			Paid:            true,
-			Submitted:       true,
-			Completed:       false,
-			Canceled:        false,
+			ShopItems:       []*models.ShopItem{{ID: "item1", Title: "Item 1", Description: "Description 1", Quantity: 2, Price: models.NewMoney(1000, "USD")}},
+			Status:          models.OrderStatusSubmitted,
 			AccountEmail:    "test1@example.com",
-			TotalPrice:      20.0,
+			TotalPrice:      models.NewMoney(2000, "USD"),
 			DeliveredTime:   time.Now(),
 			CancelReason:    "",
-			DeliveryAddress: "123 Main St",
+			DeliveryAddress: models.Address{Recipient: "John Doe", Line1: "123 Main St", City: "Springfield", PostalCode: "62701", Region: "IL", Country: "US"},
 			Payment:         models.Payment{PaymentID: "pay1", Timestamp: time.Now()},
 		},
 		{
 			OrderID:         "order2",
-			ShopItems:       []*models.ShopItem{{ID: "item2", Title: "Item 2", Description: "Description 2", Quantity: 1, Price: 15.0}},
-			Paid:            false,
-			Submitted:       true,
-			Completed:       false,
-			Canceled:        true,
+			ShopItems:       []*models.ShopItem{{ID: "item2", Title: "Item 2", Description: "Description 2", Quantity: 1, Price: models.NewMoney(1500, "USD")}},
+			Status:          models.OrderStatusCanceled,
 			AccountEmail:    "test2@example.com",
-			TotalPrice:      15.0,
+			TotalPrice:      models.NewMoney(1500, "USD"),
 			DeliveredTime:   time.Now(),
 			CancelReason:    "Out of stock",
-			DeliveryAddress: "456 Elm St",
-			Payment:         models.Payment{PaymentID: "pay2", Timestamp: time.Now()},
+			DeliveryAddress: models.Address{Recipient: "Jane Doe", Line1: "456 Elm St", City: "Paris", PostalCode: "75001", Country: "FR"},
+			Payment:         models.Payment{},
 		},
 	}
 
@@ -80,14 +73,15 @@ func TestOrdersResponseFrom(t *testing.T) {
 	for i, response := range responses {
 		assert.Equal(t, projections[i].OrderID, response.OrderID)
 		assert.Equal(t, projections[i].AccountEmail, response.AccountEmail)
-		assert.Equal(t, projections[i].DeliveryAddress, response.DeliveryAddress)
+		assert.Equal(t, projections[i].DeliveryAddress, utils.AddressFromDto(response.DeliveryAddress))
 		assert.Equal(t, projections[i].CancelReason, response.CancelReason)
-		assert.Equal(t, projections[i].TotalPrice, response.TotalPrice)
+		assert.Equal(t, projections[i].TotalPrice.Amount, response.TotalPrice.Amount)
+		assert.Equal(t, projections[i].TotalPrice.Currency, response.TotalPrice.Currency)
 		assert.Equal(t, projections[i].DeliveredTime, response.DeliveredTime)
-		assert.Equal(t, projections[i].Paid, response.Paid)
-		assert.Equal(t, projections[i].Submitted, response.Submitted)
-		assert.Equal(t, projections[i].Completed, response.Completed)
-		assert.Equal(t, projections[i].Canceled, response.Canceled)
+		assert.Equal(t, projections[i].Paid(), response.Paid)
+		assert.Equal(t, projections[i].Status.Submitted(), response.Submitted)
+		assert.Equal(t, projections[i].Status.Completed(), response.Completed)
+		assert.Equal(t, projections[i].Status.Canceled(), response.Canceled)
 		assert.Equal(t, projections[i].Payment.PaymentID, response.Payment.PaymentID)
 		assert.Equal(t, projections[i].Payment.Timestamp, response.Payment.Timestamp)
 	}
@@ -95,8 +89,8 @@ func TestOrdersResponseFrom(t *testing.T) {
 
 func TestShopItemsResponseFromModels(t *testing.T) {
 	items := []*models.ShopItem{
-		{ID: "item1", Title: "Item 1", Description: "Description 1", Quantity: 2, Price: 10.0},
-		{ID: "item2", Title: "Item 2", Description: "Description 2", Quantity: 1, Price: 15.0},
+		{ID: "item1", Title: "Item 1", Description: "Description 1", Quantity: 2, Price: models.NewMoney(1000, "USD")},
+		{ID: "item2", Title: "Item 2", Description: "Description 2", Quantity: 1, Price: models.NewMoney(1500, "USD")},
 	}
 
 	shopItems := utils.ShopItemsResponseFromModels(items)
@@ -107,6 +101,7 @@ func TestShopItemsResponseFromModels(t *testing.T) {
 		assert.Equal(t, item.Title, shopItems[i].Title)
 		assert.Equal(t, item.Description, shopItems[i].Description)
 		assert.Equal(t, item.Quantity, shopItems[i].Quantity)
-		assert.Equal(t, item.Price, shopItems[i].Price)
+		assert.Equal(t, item.Price.Amount, shopItems[i].Price.Amount)
+		assert.Equal(t, item.Price.Currency, shopItems[i].Price.Currency)
 	}
 }
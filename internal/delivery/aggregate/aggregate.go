@@ -13,6 +13,12 @@ import (
 
 const (
 	OrderAggregateType es.AggregateType = "order"
+
+	// DefaultReturnWindow is used when no return window is configured.
+	DefaultReturnWindow = 30 * 24 * time.Hour
+
+	// MaxShopItems is the maximum number of distinct line items in one order.
+	MaxShopItems = 100
 )
 
 type InterfaceOrderAggregate interface {
@@ -22,22 +28,65 @@ type InterfaceOrderAggregate interface {
 	onOrderSubmitted(evt es.Event) error
 	onOrderCompleted(evt es.Event) error
 	onOrderCanceled(evt es.Event) error
+	onOrderRejected(evt es.Event) error
 	onShoppingCartUpdated(evt es.Event) error
 	onChangeDeliveryAddress(evt es.Event) error
-	CreateOrder(ctx context.Context, shopItems []*models.ShopItem, accountEmail, deliveryAddress string) error
+	onOrderRefunded(evt es.Event) error
+	onRefundRequested(evt es.Event) error
+	onRefundFailed(evt es.Event) error
+	onReturnRequested(evt es.Event) error
+	onReturnApproved(evt es.Event) error
+	onReturnRejected(evt es.Event) error
+	onReturnReceived(evt es.Event) error
+	onShipmentCreated(evt es.Event) error
+	onShipmentPacked(evt es.Event) error
+	onShipmentDispatched(evt es.Event) error
+	onShipmentDelivered(evt es.Event) error
+	onShopItemAdded(evt es.Event) error
+	onShopItemRemoved(evt es.Event) error
+	onShopItemQuantityChanged(evt es.Event) error
+	onPaymentReminderSent(evt es.Event) error
+	onPaymentAttempted(evt es.Event) error
+	onPaymentAuthorized(evt es.Event) error
+	onPaymentFailed(evt es.Event) error
+	onCouponApplied(evt es.Event) error
+	onCouponRemoved(evt es.Event) error
+	CreateOrder(ctx context.Context, shopItems []*models.ShopItem, customerID string, accountEmail string, deliveryAddress models.Address, shippingFee int64) error
+	StartPayment(ctx context.Context, attemptID string, provider string) error
+	AuthorizePayment(ctx context.Context, attemptID string, paymentID string, pending bool) error
+	FailPayment(ctx context.Context, attemptID string, reason string) error
 	PayOrder(ctx context.Context, payment models.Payment) error
 	SubmitOrder(ctx context.Context) error
 	UpdateShoppingCart(ctx context.Context, shopItems []*models.ShopItem) error
 	CancelOrder(ctx context.Context, cancelReason string) error
+	RejectOrder(ctx context.Context, rejectReason string) error
+	RemindPayment(ctx context.Context, reminder int, expiresAt time.Time) error
 	CompleteOrder(ctx context.Context, deliveryTimestamp time.Time) error
-	ChangeDeliveryAddress(ctx context.Context, deliveryAddress string) error
+	ChangeDeliveryAddress(ctx context.Context, deliveryAddress models.Address) error
+	RefundOrder(ctx context.Context, refundID string, items []*models.RefundItem, amount *models.Money, reason string) error
+	CompleteRefund(ctx context.Context, refundID string) error
+	FailRefund(ctx context.Context, refundID string, reason string) error
+	RequestReturn(ctx context.Context, returnID string, items []*models.ReturnItem, reason string, returnWindow time.Duration) error
+	ApproveReturn(ctx context.Context, returnID string) error
+	RejectReturn(ctx context.Context, returnID string, rejectReason string) error
+	ReceiveReturn(ctx context.Context, returnID string, refundID string) error
+	CreateShipment(ctx context.Context, shipmentID string, items []*models.ShipmentItem) error
+	PackShipment(ctx context.Context, shipmentID string) error
+	DispatchShipment(ctx context.Context, shipmentID, carrier, trackingNumber string) error
+	DeliverShipment(ctx context.Context, shipmentID string, deliveredAt time.Time) error
+	AddItem(ctx context.Context, shopItem *models.ShopItem) error
+	RemoveItem(ctx context.Context, shopItemID string) error
+	ChangeItemQuantity(ctx context.Context, shopItemID string, quantity uint64) error
+	ApplyCoupon(ctx context.Context, coupon *models.Coupon, now time.Time) error
+	RemoveCoupon(ctx context.Context, code string) error
 }
 
 var _ InterfaceOrderAggregate = &OrderAggregate{}
 
 type OrderAggregate struct {
 	*es.AggregateBase
-	Order *models.Order
+	Order         *models.Order
+	taxCalculator TaxCalculator
 }
 
 func NewOrderAggregateWithID(id string) *OrderAggregate {
@@ -59,6 +108,12 @@ func NewOrderAggregate() *OrderAggregate {
 	return orderAggregate
 }
 
+// SetTaxCalculator sets the calculator used to price the taxes of cart changes,
+// no tax is charged when it is not set.
+func (a *OrderAggregate) SetTaxCalculator(taxCalculator TaxCalculator) {
+	a.taxCalculator = taxCalculator
+}
+
 func (a *OrderAggregate) When(evt es.Event) error {
 
 	switch evt.GetEventType() {
@@ -73,10 +128,52 @@ func (a *OrderAggregate) When(evt es.Event) error {
 		return a.onOrderCompleted(evt)
 	case events.OrderCanceled:
 		return a.onOrderCanceled(evt)
+	case events.OrderRejected:
+		return a.onOrderRejected(evt)
 	case events.ShoppingCartUpdated:
 		return a.onShoppingCartUpdated(evt)
 	case events.DeliveryAddressChanged:
 		return a.onChangeDeliveryAddress(evt)
+	case events.OrderRefunded:
+		return a.onOrderRefunded(evt)
+	case events.RefundRequested:
+		return a.onRefundRequested(evt)
+	case events.RefundFailed:
+		return a.onRefundFailed(evt)
+	case events.ReturnRequested:
+		return a.onReturnRequested(evt)
+	case events.ReturnApproved:
+		return a.onReturnApproved(evt)
+	case events.ReturnRejected:
+		return a.onReturnRejected(evt)
+	case events.ReturnReceived:
+		return a.onReturnReceived(evt)
+	case events.ShipmentCreated:
+		return a.onShipmentCreated(evt)
+	case events.ShipmentPacked:
+		return a.onShipmentPacked(evt)
+	case events.ShipmentDispatched:
+		return a.onShipmentDispatched(evt)
+	case events.ShipmentDelivered:
+		return a.onShipmentDelivered(evt)
+	case events.ShopItemAdded:
+		return a.onShopItemAdded(evt)
+	case events.ShopItemRemoved:
+		return a.onShopItemRemoved(evt)
+	case events.ShopItemQuantityChanged:
+		return a.onShopItemQuantityChanged(evt)
+	case events.CouponApplied:
+		return a.onCouponApplied(evt)
+	case events.CouponRemoved:
+		return a.onCouponRemoved(evt)
+	case events.PaymentReminderSent:
+		return a.onPaymentReminderSent(evt)
+	case events.PaymentAttempted:
+		return a.onPaymentAttempted(evt)
+	case events.PaymentAuthorized:
+		return a.onPaymentAuthorized(evt)
+	case events.PaymentFailed:
+		return a.onPaymentFailed(evt)
 
 	default:
 		return es.ErrInvalidEventType
@@ -89,10 +186,18 @@ func (a *OrderAggregate) onOrderCreated(evt es.Event) error {
 		return errors.Wrap(err, "GetJsonData")
 	}
 
+	pricing, err := GetEventPricing(eventData.Pricing, eventData.ShopItems)
+	if err != nil {
+		return err
+	}
+
+	a.Order.CustomerID = eventData.CustomerID
 	a.Order.AccountEmail = eventData.AccountEmail
 	a.Order.ShopItems = eventData.ShopItems
-	a.Order.TotalPrice = GetShopItemsTotalPrice(eventData.ShopItems)
+	a.Order.SetPricing(pricing)
 	a.Order.DeliveryAddress = eventData.DeliveryAddress
+	a.Order.Status = models.OrderStatusCreated
+	a.Order.CreatedAt = evt.GetTimeStamp()
 	return nil
 }
 
@@ -102,13 +207,65 @@ func (a *OrderAggregate) onOrderPaid(evt es.Event) error {
 		return errors.Wrap(err, "GetJsonData")
 	}
 
-	a.Order.Paid = true
+	a.Order.Status = models.OrderStatusPaid
 	a.Order.Payment = payment
+	if attempt := GetPaymentAttempt(a.Order, "", payment.PaymentID); attempt != nil {
+		attempt.Status = models.PaymentAttemptCaptured
+	}
+	return nil
+}
+
+func (a *OrderAggregate) onPaymentAttempted(evt es.Event) error {
+	var eventData events.PaymentAttemptedEvent
+	if err := evt.GetJsonData(&eventData); err != nil {
+		return errors.Wrap(err, "GetJsonData")
+	}
+
+	a.Order.PaymentAttempts = append(a.Order.PaymentAttempts, &models.PaymentAttempt{
+		AttemptID: eventData.AttemptID,
+		Provider:  eventData.Provider,
+		Amount:    eventData.Amount,
+		Status:    models.PaymentAttemptStarted,
+		Timestamp: eventData.Timestamp,
+	})
+	return nil
+}
+
+func (a *OrderAggregate) onPaymentAuthorized(evt es.Event) error {
+	var eventData events.PaymentAuthorizedEvent
+	if err := evt.GetJsonData(&eventData); err != nil {
+		return errors.Wrap(err, "GetJsonData")
+	}
+
+	attempt := GetPaymentAttempt(a.Order, eventData.AttemptID, "")
+	if attempt == nil {
+		return ErrPaymentAttemptNotFound
+	}
+	attempt.PaymentID = eventData.PaymentID
+	attempt.Status = models.PaymentAttemptAuthorized
+	if eventData.Pending {
+		attempt.Status = models.PaymentAttemptPending
+	}
+	return nil
+}
+
+func (a *OrderAggregate) onPaymentFailed(evt es.Event) error {
+	var eventData events.PaymentFailedEvent
+	if err := evt.GetJsonData(&eventData); err != nil {
+		return errors.Wrap(err, "GetJsonData")
+	}
+
+	attempt := GetPaymentAttempt(a.Order, eventData.AttemptID, "")
+	if attempt == nil {
+		return ErrPaymentAttemptNotFound
+	}
+	attempt.Status = models.PaymentAttemptFailed
+	attempt.FailureReason = eventData.Reason
 	return nil
 }
 
 func (a *OrderAggregate) onOrderSubmitted(evt es.Event) error {
-	a.Order.Submitted = true
+	a.Order.Status = models.OrderStatusSubmitted
 	return nil
 }
 
@@ -118,9 +275,8 @@ func (a *OrderAggregate) onOrderCompleted(evt es.Event) error {
 		return errors.Wrap(err, "GetJsonData")
 	}
 
-	a.Order.Completed = true
+	a.Order.Status = models.OrderStatusCompleted
 	a.Order.DeliveredTime = eventData.DeliveryTimestamp
-	a.Order.Canceled = false
 	return nil
 }
 
@@ -130,20 +286,45 @@ func (a *OrderAggregate) onOrderCanceled(evt es.Event) error {
 		return errors.Wrap(err, "GetJsonData")
 	}
 
-	a.Order.Canceled = true
-	a.Order.Completed = false
+	a.Order.Status = models.OrderStatusCanceled
 	a.Order.CancelReason = eventData.CancelReason
 	return nil
 }
 
+func (a *OrderAggregate) onOrderRejected(evt es.Event) error {
+	var eventData events.OrderRejectedEvent
+	if err := evt.GetJsonData(&eventData); err != nil {
+		return errors.Wrap(err, "GetJsonData")
+	}
+
+	a.Order.Status = models.OrderStatusRejected
+	a.Order.RejectReason = eventData.RejectReason
+	return nil
+}
+
+func (a *OrderAggregate) onPaymentReminderSent(evt es.Event) error {
+	var eventData events.PaymentReminderSentEvent
+	if err := evt.GetJsonData(&eventData); err != nil {
+		return errors.Wrap(err, "GetJsonData")
+	}
+
+	a.Order.PaymentReminders = eventData.Reminder
+	return nil
+}
+
 func (a *OrderAggregate) onShoppingCartUpdated(evt es.Event) error {
 	var eventData events.ShoppingCartUpdatedEvent
 	if err := evt.GetJsonData(&eventData); err != nil {
 		return errors.Wrap(err, "GetJsonData")
 	}
 
+	pricing, err := GetEventPricing(eventData.Pricing, eventData.ShopItems)
+	if err != nil {
+		return err
+	}
+
 	a.Order.ShopItems = eventData.ShopItems
-	a.Order.TotalPrice = GetShopItemsTotalPrice(eventData.ShopItems)
+	a.Order.SetPricing(pricing)
 	return nil
 }
 
@@ -154,5 +335,249 @@ func (a *OrderAggregate) onChangeDeliveryAddress(evt es.Event) error {
 	}
 
 	a.Order.DeliveryAddress = eventData.DeliveryAddress
+	if eventData.Pricing != nil {
+		a.Order.SetPricing(*eventData.Pricing)
+	}
+	return nil
+}
+
+func (a *OrderAggregate) onOrderRefunded(evt es.Event) error {
+	var eventData events.OrderRefundedEvent
+	if err := evt.GetJsonData(&eventData); err != nil {
+		return errors.Wrap(err, "GetJsonData")
+	}
+
+	refund := eventData.Refund
+	a.Order.PendingRefunds = removePendingRefund(a.Order.PendingRefunds, refund.RefundID)
+	a.Order.Refunds = append(a.Order.Refunds, &refund)
+	a.Order.RefundedAmount = eventData.TotalRefunded
+	a.Order.Refunded = eventData.FullyRefunded
+	if eventData.FullyRefunded {
+		a.Order.Status = models.OrderStatusRefunded
+	}
 	return nil
 }
+
+func (a *OrderAggregate) onRefundRequested(evt es.Event) error {
+	var eventData events.RefundRequestedEvent
+	if err := evt.GetJsonData(&eventData); err != nil {
+		return errors.Wrap(err, "GetJsonData")
+	}
+
+	refund := eventData.Refund
+	a.Order.PendingRefunds = append(a.Order.PendingRefunds, &refund)
+	return nil
+}
+
+func (a *OrderAggregate) onRefundFailed(evt es.Event) error {
+	var eventData events.RefundFailedEvent
+	if err := evt.GetJsonData(&eventData); err != nil {
+		return errors.Wrap(err, "GetJsonData")
+	}
+
+	a.Order.PendingRefunds = removePendingRefund(a.Order.PendingRefunds, eventData.RefundID)
+	return nil
+}
+
+func (a *OrderAggregate) onReturnRequested(evt es.Event) error {
+	var eventData events.ReturnRequestedEvent
+	if err := evt.GetJsonData(&eventData); err != nil {
+		return errors.Wrap(err, "GetJsonData")
+	}
+
+	a.Order.Returns = append(a.Order.Returns, &models.OrderReturn{
+		ReturnID:    eventData.ReturnID,
+		Items:       eventData.Items,
+		Reason:      eventData.Reason,
+		Status:      models.ReturnRequested,
+		RequestedAt: eventData.RequestedAt,
+	})
+	return nil
+}
+
+func (a *OrderAggregate) onReturnApproved(evt es.Event) error {
+	var eventData events.ReturnApprovedEvent
+	if err := evt.GetJsonData(&eventData); err != nil {
+		return errors.Wrap(err, "GetJsonData")
+	}
+
+	orderReturn := GetOrderReturn(a.Order, eventData.ReturnID)
+	if orderReturn == nil {
+		return ErrReturnNotFound
+	}
+	orderReturn.Status = models.ReturnApproved
+	return nil
+}
+
+func (a *OrderAggregate) onReturnRejected(evt es.Event) error {
+	var eventData events.ReturnRejectedEvent
+	if err := evt.GetJsonData(&eventData); err != nil {
+		return errors.Wrap(err, "GetJsonData")
+	}
+
+	orderReturn := GetOrderReturn(a.Order, eventData.ReturnID)
+	if orderReturn == nil {
+		return ErrReturnNotFound
+	}
+	orderReturn.Status = models.ReturnRejected
+	orderReturn.RejectReason = eventData.RejectReason
+	return nil
+}
+
+func (a *OrderAggregate) onReturnReceived(evt es.Event) error {
+	var eventData events.ReturnReceivedEvent
+	if err := evt.GetJsonData(&eventData); err != nil {
+		return errors.Wrap(err, "GetJsonData")
+	}
+
+	orderReturn := GetOrderReturn(a.Order, eventData.ReturnID)
+	if orderReturn == nil {
+		return ErrReturnNotFound
+	}
+	orderReturn.Status = models.ReturnReceived
+	orderReturn.RefundID = eventData.RefundID
+	orderReturn.ReceivedAt = eventData.ReceivedAt
+	return nil
+}
+
+func (a *OrderAggregate) onShipmentCreated(evt es.Event) error {
+	var eventData events.ShipmentCreatedEvent
+	if err := evt.GetJsonData(&eventData); err != nil {
+		return errors.Wrap(err, "GetJsonData")
+	}
+
+	a.Order.Shipments = append(a.Order.Shipments, &models.Shipment{
+		ShipmentID: eventData.ShipmentID,
+		Items:      eventData.Items,
+		Status:     models.ShipmentCreated,
+		CreatedAt:  eventData.CreatedAt,
+	})
+	return nil
+}
+
+func (a *OrderAggregate) onShipmentPacked(evt es.Event) error {
+	var eventData events.ShipmentPackedEvent
+	if err := evt.GetJsonData(&eventData); err != nil {
+		return errors.Wrap(err, "GetJsonData")
+	}
+
+	shipment := GetShipment(a.Order, eventData.ShipmentID)
+	if shipment == nil {
+		return ErrShipmentNotFound
+	}
+	shipment.Status = models.ShipmentPacked
+	shipment.PackedAt = eventData.PackedAt
+	return nil
+}
+
+func (a *OrderAggregate) onShipmentDispatched(evt es.Event) error {
+	var eventData events.ShipmentDispatchedEvent
+	if err := evt.GetJsonData(&eventData); err != nil {
+		return errors.Wrap(err, "GetJsonData")
+	}
+
+	shipment := GetShipment(a.Order, eventData.ShipmentID)
+	if shipment == nil {
+		return ErrShipmentNotFound
+	}
+	shipment.Status = models.ShipmentDispatched
+	shipment.Carrier = eventData.Carrier
+	shipment.TrackingNumber = eventData.TrackingNumber
+	shipment.DispatchedAt = eventData.DispatchedAt
+	return nil
+}
+
+func (a *OrderAggregate) onShipmentDelivered(evt es.Event) error {
+	var eventData events.ShipmentDeliveredEvent
+	if err := evt.GetJsonData(&eventData); err != nil {
+		return errors.Wrap(err, "GetJsonData")
+	}
+
+	shipment := GetShipment(a.Order, eventData.ShipmentID)
+	if shipment == nil {
+		return ErrShipmentNotFound
+	}
+	shipment.Status = models.ShipmentDelivered
+	shipment.DeliveredAt = eventData.DeliveredAt
+	return nil
+}
+
+func (a *OrderAggregate) onShopItemAdded(evt es.Event) error {
+	var eventData events.ShopItemAddedEvent
+	if err := evt.GetJsonData(&eventData); err != nil {
+		return errors.Wrap(err, "GetJsonData")
+	}
+
+	a.Order.ShopItems = append(a.Order.ShopItems, eventData.ShopItem)
+	a.setDeltaPricing(eventData.Pricing, eventData.TotalPrice)
+	return nil
+}
+
+func (a *OrderAggregate) onShopItemRemoved(evt es.Event) error {
+	var eventData events.ShopItemRemovedEvent
+	if err := evt.GetJsonData(&eventData); err != nil {
+		return errors.Wrap(err, "GetJsonData")
+	}
+
+	shopItems := make([]*models.ShopItem, 0, len(a.Order.ShopItems))
+	for _, item := range a.Order.ShopItems {
+		if item.ID != eventData.ShopItemID {
+			shopItems = append(shopItems, item)
+		}
+	}
+	a.Order.ShopItems = shopItems
+	a.setDeltaPricing(eventData.Pricing, eventData.TotalPrice)
+	return nil
+}
+
+func (a *OrderAggregate) onShopItemQuantityChanged(evt es.Event) error {
+	var eventData events.ShopItemQuantityChangedEvent
+	if err := evt.GetJsonData(&eventData); err != nil {
+		return errors.Wrap(err, "GetJsonData")
+	}
+
+	shopItem := getShopItem(a.Order, eventData.ShopItemID)
+	if shopItem == nil {
+		return ErrShopItemNotFound
+	}
+	shopItem.Quantity = eventData.Quantity
+	a.setDeltaPricing(eventData.Pricing, eventData.TotalPrice)
+	return nil
+}
+
+func (a *OrderAggregate) onCouponApplied(evt es.Event) error {
+	var eventData events.CouponAppliedEvent
+	if err := evt.GetJsonData(&eventData); err != nil {
+		return errors.Wrap(err, "GetJsonData")
+	}
+
+	coupon := eventData.Coupon
+	a.Order.Coupons = append(a.Order.Coupons, &coupon)
+	a.Order.SetPricing(eventData.Pricing)
+	return nil
+}
+
+func (a *OrderAggregate) onCouponRemoved(evt es.Event) error {
+	var eventData events.CouponRemovedEvent
+	if err := evt.GetJsonData(&eventData); err != nil {
+		return errors.Wrap(err, "GetJsonData")
+	}
+
+	coupons := make([]*models.Coupon, 0, len(a.Order.Coupons))
+	for _, coupon := range a.Order.Coupons {
+		if coupon.Code != eventData.Code {
+			coupons = append(coupons, coupon)
+		}
+	}
+	a.Order.Coupons = coupons
+	a.Order.SetPricing(eventData.Pricing)
+	return nil
+}
+
+func (a *OrderAggregate) setDeltaPricing(pricing *models.OrderPricing, totalPrice models.Money) {
+	if pricing == nil {
+		a.Order.TotalPrice = totalPrice
+		return
+	}
+	a.Order.SetPricing(*pricing)
+}
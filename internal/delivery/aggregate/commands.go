@@ -2,6 +2,7 @@ package aggregate
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/opentracing/opentracing-go"
@@ -13,19 +14,38 @@ import (
 	"github.com/wassef911/eventually/internal/infrastructure/tracing"
 )
 
-func (a *OrderAggregate) CreateOrder(ctx context.Context, shopItems []*models.ShopItem, accountEmail, deliveryAddress string) error {
+// CreateOrder shippingFee is in minor units of the order currency.
+func (a *OrderAggregate) CreateOrder(ctx context.Context, shopItems []*models.ShopItem, customerID string, accountEmail string, deliveryAddress models.Address, shippingFee int64) error {
 	span, _ := opentracing.StartSpanFromContext(ctx, "OrderAggregate.CreateOrder")
 	defer span.Finish()
 	span.LogFields(log.String("AggregateID", a.GetID()))
 
+	if err := a.CanTransition(ActionCreateOrder); err != nil {
+		return err
+	}
 	if shopItems == nil {
 		return ErrOrderShopItemsIsRequired
 	}
-	if deliveryAddress == "" {
-		return ErrInvalidDeliveryAddress
+	if err := deliveryAddress.Validate(); err != nil {
+		return errors.Wrap(ErrInvalidDeliveryAddress, err.Error())
+	}
+	if err := ValidateShopItems(shopItems); err != nil {
+		return err
+	}
+
+	region := GetDeliveryRegion(deliveryAddress)
+	taxes, err := a.calculateTaxes(ctx, region, shopItems)
+	if err != nil {
+		tracing.TraceErr(span, err)
+		return err
+	}
+	pricing, err := CalculateOrderPricing(shopItems, nil, models.NewMoney(shippingFee, GetShopItemsCurrency(shopItems)), taxes)
+	if err != nil {
+		return err
 	}
+	pricing.TaxRegion = region
 
-	event, err := events.NewOrderCreatedEvent(a, shopItems, accountEmail, deliveryAddress)
+	event, err := events.NewOrderCreatedEvent(a, shopItems, customerID, accountEmail, deliveryAddress, pricing)
 	if err != nil {
 		tracing.TraceErr(span, err)
 		return errors.Wrap(err, "NewOrderCreatedEvent")
@@ -39,19 +59,104 @@ func (a *OrderAggregate) CreateOrder(ctx context.Context, shopItems []*models.Sh
 	return a.Apply(event)
 }
 
+// StartPayment records an attempt to pay the order total through the payment provider,
+// only one attempt may wait for an asynchronous provider confirmation at a time.
+func (a *OrderAggregate) StartPayment(ctx context.Context, attemptID string, provider string) error {
+	span, _ := opentracing.StartSpanFromContext(ctx, "OrderAggregate.StartPayment")
+	defer span.Finish()
+	span.LogFields(log.String("AggregateID", a.GetID()), log.String("AttemptID", attemptID))
+
+	if err := a.CanTransition(ActionPayOrder); err != nil {
+		return err
+	}
+	for _, attempt := range a.Order.PaymentAttempts {
+		if attempt.Status == models.PaymentAttemptPending {
+			return ErrPaymentPending
+		}
+	}
+
+	event, err := events.NewPaymentAttemptedEvent(a, attemptID, provider, a.Order.TotalPrice, time.Now().UTC())
+	if err != nil {
+		tracing.TraceErr(span, err)
+		return errors.Wrap(err, "NewPaymentAttemptedEvent")
+	}
+
+	if err := event.SetMetadata(tracing.ExtractTextMapCarrier(span.Context())); err != nil {
+		tracing.TraceErr(span, err)
+		return errors.Wrap(err, "SetMetadata")
+	}
+
+	return a.Apply(event)
+}
+
+func (a *OrderAggregate) AuthorizePayment(ctx context.Context, attemptID string, paymentID string, pending bool) error {
+	span, _ := opentracing.StartSpanFromContext(ctx, "OrderAggregate.AuthorizePayment")
+	defer span.Finish()
+	span.LogFields(log.String("AggregateID", a.GetID()), log.String("AttemptID", attemptID))
+
+	attempt := GetPaymentAttempt(a.Order, attemptID, "")
+	if attempt == nil {
+		return ErrPaymentAttemptNotFound
+	}
+	if !attempt.IsOpen() {
+		return ErrPaymentAttemptClosed
+	}
+
+	event, err := events.NewPaymentAuthorizedEvent(a, attemptID, paymentID, pending)
+	if err != nil {
+		tracing.TraceErr(span, err)
+		return errors.Wrap(err, "NewPaymentAuthorizedEvent")
+	}
+
+	if err := event.SetMetadata(tracing.ExtractTextMapCarrier(span.Context())); err != nil {
+		tracing.TraceErr(span, err)
+		return errors.Wrap(err, "SetMetadata")
+	}
+
+	return a.Apply(event)
+}
+
+// FailPayment closes an open attempt, failing an attempt that already failed is a no-op.
+func (a *OrderAggregate) FailPayment(ctx context.Context, attemptID string, reason string) error {
+	span, _ := opentracing.StartSpanFromContext(ctx, "OrderAggregate.FailPayment")
+	defer span.Finish()
+	span.LogFields(log.String("AggregateID", a.GetID()), log.String("AttemptID", attemptID))
+
+	attempt := GetPaymentAttempt(a.Order, attemptID, "")
+	if attempt == nil {
+		return ErrPaymentAttemptNotFound
+	}
+	if attempt.Status == models.PaymentAttemptFailed {
+		return nil
+	}
+	if !attempt.IsOpen() {
+		return ErrPaymentAttemptClosed
+	}
+	if reason == "" {
+		return ErrPaymentFailureReasonRequired
+	}
+
+	event, err := events.NewPaymentFailedEvent(a, attemptID, reason)
+	if err != nil {
+		tracing.TraceErr(span, err)
+		return errors.Wrap(err, "NewPaymentFailedEvent")
+	}
+
+	if err := event.SetMetadata(tracing.ExtractTextMapCarrier(span.Context())); err != nil {
+		tracing.TraceErr(span, err)
+		return errors.Wrap(err, "SetMetadata")
+	}
+
+	return a.Apply(event)
+}
+
 func (a *OrderAggregate) PayOrder(ctx context.Context, payment models.Payment) error {
 	span, _ := opentracing.StartSpanFromContext(ctx, "OrderAggregate.PayOrder")
 	defer span.Finish()
 	span.LogFields(log.String("AggregateID", a.GetID()))
 
-	if a.Order.Canceled {
-		return ErrOrderAlreadyCancelled
-	}
-	if a.Order.Paid {
-		return ErrAlreadyPaid
-	}
-	if a.Order.Submitted {
-		return ErrAlreadySubmitted
+	if err := a.CanTransition(ActionPayOrder); err != nil {
+		return err
 	}
 
 	event, err := events.NewOrderPaidEvent(a, &payment)
@@ -73,14 +178,8 @@ func (a *OrderAggregate) SubmitOrder(ctx context.Context) error {
 	defer span.Finish()
 	span.LogFields(log.String("AggregateID", a.GetID()))
 
-	if a.Order.Canceled {
-		return ErrOrderAlreadyCancelled
-	}
-	if !a.Order.Paid {
-		return ErrOrderNotPaid
-	}
-	if a.Order.Submitted {
-		return ErrAlreadySubmitted
+	if err := a.CanTransition(ActionSubmitOrder); err != nil {
+		return err
 	}
 
 	submitOrderEvent, err := events.NewSubmitOrderEvent(a)
@@ -102,14 +201,19 @@ func (a *OrderAggregate) UpdateShoppingCart(ctx context.Context, shopItems []*mo
 	defer span.Finish()
 	span.LogFields(log.String("AggregateID", a.GetID()))
 
-	if a.Order.Canceled {
-		return ErrOrderAlreadyCancelled
+	if err := a.CanTransition(ActionUpdateShoppingCart); err != nil {
+		return err
 	}
-	if a.Order.Submitted {
-		return ErrAlreadySubmitted
+	if err := ValidateShopItems(shopItems); err != nil {
+		return err
 	}
 
-	orderUpdatedEvent, err := events.NewShoppingCartUpdatedEvent(a, shopItems)
+	pricing, err := a.calculatePricing(ctx, shopItems, a.Order.Coupons)
+	if err != nil {
+		return err
+	}
+
+	orderUpdatedEvent, err := events.NewShoppingCartUpdatedEvent(a, shopItems, pricing)
 	if err != nil {
 		tracing.TraceErr(span, err)
 		return errors.Wrap(err, "NewShoppingCartUpdatedEvent")
@@ -128,8 +232,8 @@ func (a *OrderAggregate) CancelOrder(ctx context.Context, cancelReason string) e
 	defer span.Finish()
 	span.LogFields(log.String("AggregateID", a.GetID()))
 
-	if a.Order.Completed {
-		return ErrOrderAlreadyCompleted
+	if err := a.CanTransition(ActionCancelOrder); err != nil {
+		return err
 	}
 	if cancelReason == "" {
 		return ErrCancelReasonRequired
@@ -149,19 +253,67 @@ func (a *OrderAggregate) CancelOrder(ctx context.Context, cancelReason string) e
 	return a.Apply(event)
 }
 
-func (a *OrderAggregate) CompleteOrder(ctx context.Context, deliveryTimestamp time.Time) error {
-	span, _ := opentracing.StartSpanFromContext(ctx, "OrderAggregate.CompleteOrder")
+// RejectOrder is issued by the system when the order cannot be fulfilled, customers cancel instead.
+func (a *OrderAggregate) RejectOrder(ctx context.Context, rejectReason string) error {
+	span, _ := opentracing.StartSpanFromContext(ctx, "OrderAggregate.RejectOrder")
+	defer span.Finish()
+	span.LogFields(log.String("AggregateID", a.GetID()))
+
+	if err := a.CanTransition(ActionRejectOrder); err != nil {
+		return err
+	}
+	if rejectReason == "" {
+		return ErrRejectReasonRequired
+	}
+
+	event, err := events.NewOrderRejectedEvent(a, rejectReason)
+	if err != nil {
+		tracing.TraceErr(span, err)
+		return errors.Wrap(err, "NewOrderRejectedEvent")
+	}
+
+	if err := event.SetMetadata(tracing.ExtractTextMapCarrier(span.Context())); err != nil {
+		tracing.TraceErr(span, err)
+		return errors.Wrap(err, "SetMetadata")
+	}
+
+	return a.Apply(event)
+}
+
+// RemindPayment records a reminder for an unpaid order, a reminder that was already sent is a no-op.
+func (a *OrderAggregate) RemindPayment(ctx context.Context, reminder int, expiresAt time.Time) error {
+	span, _ := opentracing.StartSpanFromContext(ctx, "OrderAggregate.RemindPayment")
 	defer span.Finish()
 	span.LogFields(log.String("AggregateID", a.GetID()))
 
-	if a.Order.Completed {
-		return ErrOrderAlreadyCompleted
+	if err := a.CanTransition(ActionRemindPayment); err != nil {
+		return err
 	}
-	if a.Order.Canceled {
-		return ErrOrderAlreadyCanceled
+	if reminder <= a.Order.PaymentReminders {
+		return nil
 	}
-	if !a.Order.Paid {
-		return ErrOrderMustBePaidBeforeDelivered
+
+	event, err := events.NewPaymentReminderSentEvent(a, reminder, expiresAt)
+	if err != nil {
+		tracing.TraceErr(span, err)
+		return errors.Wrap(err, "NewPaymentReminderSentEvent")
+	}
+
+	if err := event.SetMetadata(tracing.ExtractTextMapCarrier(span.Context())); err != nil {
+		tracing.TraceErr(span, err)
+		return errors.Wrap(err, "SetMetadata")
+	}
+
+	return a.Apply(event)
+}
+
+func (a *OrderAggregate) CompleteOrder(ctx context.Context, deliveryTimestamp time.Time) error {
+	span, _ := opentracing.StartSpanFromContext(ctx, "OrderAggregate.CompleteOrder")
+	defer span.Finish()
+	span.LogFields(log.String("AggregateID", a.GetID()))
+
+	if err := a.CanTransition(ActionCompleteOrder); err != nil {
+		return err
 	}
 
 	event, err := events.NewOrderCompletedEvent(a, deliveryTimestamp)
@@ -178,16 +330,29 @@ func (a *OrderAggregate) CompleteOrder(ctx context.Context, deliveryTimestamp ti
 	return a.Apply(event)
 }
 
-func (a *OrderAggregate) ChangeDeliveryAddress(ctx context.Context, deliveryAddress string) error {
+// ChangeDeliveryAddress reprices the taxes of the cart while it can still be updated.
+func (a *OrderAggregate) ChangeDeliveryAddress(ctx context.Context, deliveryAddress models.Address) error {
 	span, _ := opentracing.StartSpanFromContext(ctx, "OrderAggregate.ChangeDeliveryAddress")
 	defer span.Finish()
 	span.LogFields(log.String("AggregateID", a.GetID()))
 
-	if a.Order.Completed {
-		return ErrOrderAlreadyCompleted
+	if err := a.CanTransition(ActionChangeDeliveryAddress); err != nil {
+		return err
+	}
+	if err := deliveryAddress.Validate(); err != nil {
+		return errors.Wrap(ErrInvalidDeliveryAddress, err.Error())
+	}
+
+	var pricing *models.OrderPricing
+	if a.taxCalculator != nil && a.CanTransition(ActionUpdateShoppingCart) == nil && GetDeliveryRegion(deliveryAddress) != a.Order.TaxRegion {
+		repriced, err := a.calculateAddressPricing(ctx, deliveryAddress, a.Order.ShopItems, a.Order.Coupons)
+		if err != nil {
+			return err
+		}
+		pricing = &repriced
 	}
 
-	event, err := events.NewDeliveryAddressChangedEvent(a, deliveryAddress)
+	event, err := events.NewDeliveryAddressChangedEvent(a, deliveryAddress, pricing)
 	if err != nil {
 		tracing.TraceErr(span, err)
 		return errors.Wrap(err, "NewDeliveryAddressChangedEvent")
@@ -200,3 +365,670 @@ func (a *OrderAggregate) ChangeDeliveryAddress(ctx context.Context, deliveryAddr
 
 	return a.Apply(event)
 }
+
+func (a *OrderAggregate) RefundOrder(ctx context.Context, refundID string, items []*models.RefundItem, amount *models.Money, reason string) error {
+	span, _ := opentracing.StartSpanFromContext(ctx, "OrderAggregate.RefundOrder")
+	defer span.Finish()
+	span.LogFields(log.String("AggregateID", a.GetID()), log.String("RefundID", refundID))
+
+	if err := a.CanTransition(ActionRefundOrder); err != nil {
+		return err
+	}
+	if !a.Order.Paid() {
+		return ErrOrderNotPaid
+	}
+	if refundID == "" {
+		return ErrRefundIDRequired
+	}
+	if reason == "" {
+		return ErrRefundReasonRequired
+	}
+
+	alreadyRefunded, err := GetOrderRefundedAmount(a.Order)
+	if err != nil {
+		return err
+	}
+	refundable, err := a.Order.TotalPrice.Sub(alreadyRefunded)
+	if err != nil {
+		return err
+	}
+
+	refundAmount, err := GetRefundAmount(a.Order, items, amount, refundable)
+	if err != nil {
+		return err
+	}
+	if refundAmount.IsZero() {
+		return ErrInvalidRefundAmount
+	}
+	if refundAmount.GreaterThan(refundable) {
+		return ErrRefundExceedsPaidAmount
+	}
+
+	totalRefunded, err := alreadyRefunded.Add(refundAmount)
+	if err != nil {
+		return err
+	}
+
+	fullyRefunded := totalRefunded == a.Order.TotalPrice
+	if fullyRefunded {
+		if err := a.CanTransition(ActionFullyRefundOrder); err != nil {
+			return err
+		}
+	}
+
+	refund := models.Refund{
+		RefundID:  refundID,
+		Amount:    refundAmount,
+		Items:     items,
+		Reason:    reason,
+		Timestamp: time.Now().UTC(),
+	}
+
+	if a.Order.Payment.Provider != "" {
+		// the refund is saved before the provider is called, CompleteRefund records it once refunded
+		event, err := events.NewRefundRequestedEvent(a, refund)
+		if err != nil {
+			tracing.TraceErr(span, err)
+			return errors.Wrap(err, "NewRefundRequestedEvent")
+		}
+
+		if err := event.SetMetadata(tracing.ExtractTextMapCarrier(span.Context())); err != nil {
+			tracing.TraceErr(span, err)
+			return errors.Wrap(err, "SetMetadata")
+		}
+
+		return a.Apply(event)
+	}
+
+	return a.applyRefunded(span, refund)
+}
+
+// CompleteRefund records a pending refund once the payment provider refunded it.
+func (a *OrderAggregate) CompleteRefund(ctx context.Context, refundID string) error {
+	span, _ := opentracing.StartSpanFromContext(ctx, "OrderAggregate.CompleteRefund")
+	defer span.Finish()
+	span.LogFields(log.String("AggregateID", a.GetID()), log.String("RefundID", refundID))
+
+	refund := GetPendingRefund(a.Order, refundID)
+	if refund == nil {
+		return ErrRefundNotPending
+	}
+
+	return a.applyRefunded(span, *refund)
+}
+
+// FailRefund drops a pending refund the payment provider refused, its amount can be refunded again.
+func (a *OrderAggregate) FailRefund(ctx context.Context, refundID string, reason string) error {
+	span, _ := opentracing.StartSpanFromContext(ctx, "OrderAggregate.FailRefund")
+	defer span.Finish()
+	span.LogFields(log.String("AggregateID", a.GetID()), log.String("RefundID", refundID))
+
+	if GetPendingRefund(a.Order, refundID) == nil {
+		return ErrRefundNotPending
+	}
+
+	event, err := events.NewRefundFailedEvent(a, refundID, reason)
+	if err != nil {
+		tracing.TraceErr(span, err)
+		return errors.Wrap(err, "NewRefundFailedEvent")
+	}
+
+	if err := event.SetMetadata(tracing.ExtractTextMapCarrier(span.Context())); err != nil {
+		tracing.TraceErr(span, err)
+		return errors.Wrap(err, "SetMetadata")
+	}
+
+	return a.Apply(event)
+}
+
+func (a *OrderAggregate) applyRefunded(span opentracing.Span, refund models.Refund) error {
+	totalRefunded, err := getOrderSettledRefundAmount(a.Order).Add(refund.Amount)
+	if err != nil {
+		return err
+	}
+	fullyRefunded := totalRefunded == a.Order.TotalPrice
+
+	event, err := events.NewOrderRefundedEvent(a, refund, totalRefunded, fullyRefunded)
+	if err != nil {
+		tracing.TraceErr(span, err)
+		return errors.Wrap(err, "NewOrderRefundedEvent")
+	}
+
+	if err := event.SetMetadata(tracing.ExtractTextMapCarrier(span.Context())); err != nil {
+		tracing.TraceErr(span, err)
+		return errors.Wrap(err, "SetMetadata")
+	}
+
+	return a.Apply(event)
+}
+
+func (a *OrderAggregate) RequestReturn(ctx context.Context, returnID string, items []*models.ReturnItem, reason string, returnWindow time.Duration) error {
+	span, _ := opentracing.StartSpanFromContext(ctx, "OrderAggregate.RequestReturn")
+	defer span.Finish()
+	span.LogFields(log.String("AggregateID", a.GetID()), log.String("ReturnID", returnID))
+
+	if err := a.CanTransition(ActionManageReturn); err != nil {
+		return err
+	}
+	if returnID == "" {
+		return ErrReturnIDRequired
+	}
+	if reason == "" {
+		return ErrReturnReasonRequired
+	}
+	if GetOrderReturn(a.Order, returnID) != nil {
+		return ErrReturnAlreadyExists
+	}
+
+	requestedAt := time.Now().UTC()
+	if requestedAt.After(a.Order.DeliveredTime.Add(returnWindow)) {
+		return ErrReturnWindowExpired
+	}
+	if err := ValidateReturnItems(a.Order, items); err != nil {
+		return err
+	}
+
+	event, err := events.NewReturnRequestedEvent(a, returnID, items, reason, requestedAt)
+	if err != nil {
+		tracing.TraceErr(span, err)
+		return errors.Wrap(err, "NewReturnRequestedEvent")
+	}
+
+	if err := event.SetMetadata(tracing.ExtractTextMapCarrier(span.Context())); err != nil {
+		tracing.TraceErr(span, err)
+		return errors.Wrap(err, "SetMetadata")
+	}
+
+	return a.Apply(event)
+}
+
+func (a *OrderAggregate) ApproveReturn(ctx context.Context, returnID string) error {
+	span, _ := opentracing.StartSpanFromContext(ctx, "OrderAggregate.ApproveReturn")
+	defer span.Finish()
+	span.LogFields(log.String("AggregateID", a.GetID()), log.String("ReturnID", returnID))
+
+	if err := a.CanTransition(ActionManageReturn); err != nil {
+		return err
+	}
+	orderReturn := GetOrderReturn(a.Order, returnID)
+	if orderReturn == nil {
+		return ErrReturnNotFound
+	}
+	if orderReturn.Status != models.ReturnRequested {
+		return ErrInvalidReturnStatus
+	}
+
+	event, err := events.NewReturnApprovedEvent(a, returnID)
+	if err != nil {
+		tracing.TraceErr(span, err)
+		return errors.Wrap(err, "NewReturnApprovedEvent")
+	}
+
+	if err := event.SetMetadata(tracing.ExtractTextMapCarrier(span.Context())); err != nil {
+		tracing.TraceErr(span, err)
+		return errors.Wrap(err, "SetMetadata")
+	}
+
+	return a.Apply(event)
+}
+
+func (a *OrderAggregate) RejectReturn(ctx context.Context, returnID string, rejectReason string) error {
+	span, _ := opentracing.StartSpanFromContext(ctx, "OrderAggregate.RejectReturn")
+	defer span.Finish()
+	span.LogFields(log.String("AggregateID", a.GetID()), log.String("ReturnID", returnID))
+
+	if err := a.CanTransition(ActionManageReturn); err != nil {
+		return err
+	}
+	orderReturn := GetOrderReturn(a.Order, returnID)
+	if orderReturn == nil {
+		return ErrReturnNotFound
+	}
+	if orderReturn.Status != models.ReturnRequested {
+		return ErrInvalidReturnStatus
+	}
+	if rejectReason == "" {
+		return ErrReturnReasonRequired
+	}
+
+	event, err := events.NewReturnRejectedEvent(a, returnID, rejectReason)
+	if err != nil {
+		tracing.TraceErr(span, err)
+		return errors.Wrap(err, "NewReturnRejectedEvent")
+	}
+
+	if err := event.SetMetadata(tracing.ExtractTextMapCarrier(span.Context())); err != nil {
+		tracing.TraceErr(span, err)
+		return errors.Wrap(err, "SetMetadata")
+	}
+
+	return a.Apply(event)
+}
+
+// ReceiveReturn records the returned items as received and refunds them in the same commit. Earlier refunds are
+// never refunded twice: units refunded meanwhile are left out and the refund is capped at what is left to refund.
+func (a *OrderAggregate) ReceiveReturn(ctx context.Context, returnID string, refundID string) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "OrderAggregate.ReceiveReturn")
+	defer span.Finish()
+	span.LogFields(log.String("AggregateID", a.GetID()), log.String("ReturnID", returnID))
+
+	if err := a.CanTransition(ActionReceiveReturn); err != nil {
+		return err
+	}
+	orderReturn := GetOrderReturn(a.Order, returnID)
+	if orderReturn == nil {
+		return ErrReturnNotFound
+	}
+	if orderReturn.Status != models.ReturnApproved {
+		return ErrInvalidReturnStatus
+	}
+	if refundID == "" {
+		return ErrRefundIDRequired
+	}
+
+	refundItems, refundAmount, capped, err := GetReturnRefund(a.Order, orderReturn)
+	if err != nil {
+		return err
+	}
+	if refundAmount.IsZero() {
+		// everything returned was refunded already
+		refundID = ""
+	}
+
+	event, err := events.NewReturnReceivedEvent(a, returnID, refundID, time.Now().UTC())
+	if err != nil {
+		tracing.TraceErr(span, err)
+		return errors.Wrap(err, "NewReturnReceivedEvent")
+	}
+
+	if err := event.SetMetadata(tracing.ExtractTextMapCarrier(span.Context())); err != nil {
+		tracing.TraceErr(span, err)
+		return errors.Wrap(err, "SetMetadata")
+	}
+
+	if err := a.Apply(event); err != nil {
+		return err
+	}
+
+	reason := fmt.Sprintf("return %s: %s", returnID, orderReturn.Reason)
+	switch {
+	case refundAmount.IsZero():
+		return nil
+	case capped:
+		return a.RefundOrder(ctx, refundID, nil, &refundAmount, reason)
+	default:
+		return a.RefundOrder(ctx, refundID, refundItems, nil, reason)
+	}
+}
+
+func (a *OrderAggregate) CreateShipment(ctx context.Context, shipmentID string, items []*models.ShipmentItem) error {
+	span, _ := opentracing.StartSpanFromContext(ctx, "OrderAggregate.CreateShipment")
+	defer span.Finish()
+	span.LogFields(log.String("AggregateID", a.GetID()), log.String("ShipmentID", shipmentID))
+
+	if err := a.CanTransition(ActionManageShipment); err != nil {
+		return err
+	}
+	if shipmentID == "" {
+		return ErrShipmentIDRequired
+	}
+	if GetShipment(a.Order, shipmentID) != nil {
+		return ErrShipmentAlreadyExists
+	}
+	if err := ValidateShipmentItems(a.Order, items); err != nil {
+		return err
+	}
+
+	event, err := events.NewShipmentCreatedEvent(a, shipmentID, items, time.Now().UTC())
+	if err != nil {
+		tracing.TraceErr(span, err)
+		return errors.Wrap(err, "NewShipmentCreatedEvent")
+	}
+
+	if err := event.SetMetadata(tracing.ExtractTextMapCarrier(span.Context())); err != nil {
+		tracing.TraceErr(span, err)
+		return errors.Wrap(err, "SetMetadata")
+	}
+
+	return a.Apply(event)
+}
+
+func (a *OrderAggregate) PackShipment(ctx context.Context, shipmentID string) error {
+	span, _ := opentracing.StartSpanFromContext(ctx, "OrderAggregate.PackShipment")
+	defer span.Finish()
+	span.LogFields(log.String("AggregateID", a.GetID()), log.String("ShipmentID", shipmentID))
+
+	if err := a.CanTransition(ActionManageShipment); err != nil {
+		return err
+	}
+	shipment := GetShipment(a.Order, shipmentID)
+	if shipment == nil {
+		return ErrShipmentNotFound
+	}
+	if shipment.Status != models.ShipmentCreated {
+		return ErrInvalidShipmentStatus
+	}
+
+	event, err := events.NewShipmentPackedEvent(a, shipmentID, time.Now().UTC())
+	if err != nil {
+		tracing.TraceErr(span, err)
+		return errors.Wrap(err, "NewShipmentPackedEvent")
+	}
+
+	if err := event.SetMetadata(tracing.ExtractTextMapCarrier(span.Context())); err != nil {
+		tracing.TraceErr(span, err)
+		return errors.Wrap(err, "SetMetadata")
+	}
+
+	return a.Apply(event)
+}
+
+func (a *OrderAggregate) DispatchShipment(ctx context.Context, shipmentID, carrier, trackingNumber string) error {
+	span, _ := opentracing.StartSpanFromContext(ctx, "OrderAggregate.DispatchShipment")
+	defer span.Finish()
+	span.LogFields(log.String("AggregateID", a.GetID()), log.String("ShipmentID", shipmentID))
+
+	if err := a.CanTransition(ActionManageShipment); err != nil {
+		return err
+	}
+	shipment := GetShipment(a.Order, shipmentID)
+	if shipment == nil {
+		return ErrShipmentNotFound
+	}
+	if shipment.Status != models.ShipmentCreated && shipment.Status != models.ShipmentPacked {
+		return ErrInvalidShipmentStatus
+	}
+	if carrier == "" || trackingNumber == "" {
+		return ErrCarrierRequired
+	}
+
+	event, err := events.NewShipmentDispatchedEvent(a, shipmentID, carrier, trackingNumber, time.Now().UTC())
+	if err != nil {
+		tracing.TraceErr(span, err)
+		return errors.Wrap(err, "NewShipmentDispatchedEvent")
+	}
+
+	if err := event.SetMetadata(tracing.ExtractTextMapCarrier(span.Context())); err != nil {
+		tracing.TraceErr(span, err)
+		return errors.Wrap(err, "SetMetadata")
+	}
+
+	return a.Apply(event)
+}
+
+// DeliverShipment marks the shipment as delivered and completes the order once every shipment is delivered.
+func (a *OrderAggregate) DeliverShipment(ctx context.Context, shipmentID string, deliveredAt time.Time) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "OrderAggregate.DeliverShipment")
+	defer span.Finish()
+	span.LogFields(log.String("AggregateID", a.GetID()), log.String("ShipmentID", shipmentID))
+
+	if err := a.CanTransition(ActionManageShipment); err != nil {
+		return err
+	}
+	shipment := GetShipment(a.Order, shipmentID)
+	if shipment == nil {
+		return ErrShipmentNotFound
+	}
+	if shipment.Status != models.ShipmentDispatched {
+		return ErrInvalidShipmentStatus
+	}
+
+	event, err := events.NewShipmentDeliveredEvent(a, shipmentID, deliveredAt)
+	if err != nil {
+		tracing.TraceErr(span, err)
+		return errors.Wrap(err, "NewShipmentDeliveredEvent")
+	}
+
+	if err := event.SetMetadata(tracing.ExtractTextMapCarrier(span.Context())); err != nil {
+		tracing.TraceErr(span, err)
+		return errors.Wrap(err, "SetMetadata")
+	}
+
+	if err := a.Apply(event); err != nil {
+		return err
+	}
+
+	if !IsOrderFullyDelivered(a.Order) {
+		return nil
+	}
+	return a.CompleteOrder(ctx, deliveredAt)
+}
+
+func (a *OrderAggregate) AddItem(ctx context.Context, shopItem *models.ShopItem) error {
+	span, _ := opentracing.StartSpanFromContext(ctx, "OrderAggregate.AddItem")
+	defer span.Finish()
+	span.LogFields(log.String("AggregateID", a.GetID()))
+
+	if err := a.CanTransition(ActionUpdateShoppingCart); err != nil {
+		return err
+	}
+	if err := ValidateShopItem(shopItem); err != nil {
+		return err
+	}
+	if getShopItem(a.Order, shopItem.ID) != nil {
+		return errors.Wrapf(ErrDuplicateShopItem, "shop item: {%s}", shopItem.ID)
+	}
+
+	shopItems := append(append(make([]*models.ShopItem, 0, len(a.Order.ShopItems)+1), a.Order.ShopItems...), shopItem)
+	if err := ValidateShopItems(shopItems); err != nil {
+		return err
+	}
+	pricing, err := a.calculatePricing(ctx, shopItems, a.Order.Coupons)
+	if err != nil {
+		return err
+	}
+
+	event, err := events.NewShopItemAddedEvent(a, shopItem, pricing)
+	if err != nil {
+		tracing.TraceErr(span, err)
+		return errors.Wrap(err, "NewShopItemAddedEvent")
+	}
+
+	if err := event.SetMetadata(tracing.ExtractTextMapCarrier(span.Context())); err != nil {
+		tracing.TraceErr(span, err)
+		return errors.Wrap(err, "SetMetadata")
+	}
+
+	return a.Apply(event)
+}
+
+func (a *OrderAggregate) RemoveItem(ctx context.Context, shopItemID string) error {
+	span, _ := opentracing.StartSpanFromContext(ctx, "OrderAggregate.RemoveItem")
+	defer span.Finish()
+	span.LogFields(log.String("AggregateID", a.GetID()), log.String("ShopItemID", shopItemID))
+
+	if err := a.CanTransition(ActionUpdateShoppingCart); err != nil {
+		return err
+	}
+	if getShopItem(a.Order, shopItemID) == nil {
+		return errors.Wrapf(ErrShopItemNotFound, "shop item: {%s}", shopItemID)
+	}
+	if len(a.Order.ShopItems) == 1 {
+		return ErrOrderShopItemsIsRequired
+	}
+
+	shopItems := make([]*models.ShopItem, 0, len(a.Order.ShopItems)-1)
+	for _, item := range a.Order.ShopItems {
+		if item.ID != shopItemID {
+			shopItems = append(shopItems, item)
+		}
+	}
+	pricing, err := a.calculatePricing(ctx, shopItems, a.Order.Coupons)
+	if err != nil {
+		return err
+	}
+
+	event, err := events.NewShopItemRemovedEvent(a, shopItemID, pricing)
+	if err != nil {
+		tracing.TraceErr(span, err)
+		return errors.Wrap(err, "NewShopItemRemovedEvent")
+	}
+
+	if err := event.SetMetadata(tracing.ExtractTextMapCarrier(span.Context())); err != nil {
+		tracing.TraceErr(span, err)
+		return errors.Wrap(err, "SetMetadata")
+	}
+
+	return a.Apply(event)
+}
+
+func (a *OrderAggregate) ChangeItemQuantity(ctx context.Context, shopItemID string, quantity uint64) error {
+	span, _ := opentracing.StartSpanFromContext(ctx, "OrderAggregate.ChangeItemQuantity")
+	defer span.Finish()
+	span.LogFields(log.String("AggregateID", a.GetID()), log.String("ShopItemID", shopItemID))
+
+	if err := a.CanTransition(ActionUpdateShoppingCart); err != nil {
+		return err
+	}
+	if quantity == 0 {
+		return errors.Wrapf(ErrInvalidShopItemQuantity, "shop item: {%s}", shopItemID)
+	}
+	if getShopItem(a.Order, shopItemID) == nil {
+		return errors.Wrapf(ErrShopItemNotFound, "shop item: {%s}", shopItemID)
+	}
+
+	shopItems := make([]*models.ShopItem, 0, len(a.Order.ShopItems))
+	for _, item := range a.Order.ShopItems {
+		if item.ID == shopItemID {
+			changed := *item
+			changed.Quantity = quantity
+			item = &changed
+		}
+		shopItems = append(shopItems, item)
+	}
+	pricing, err := a.calculatePricing(ctx, shopItems, a.Order.Coupons)
+	if err != nil {
+		return err
+	}
+
+	event, err := events.NewShopItemQuantityChangedEvent(a, shopItemID, quantity, pricing)
+	if err != nil {
+		tracing.TraceErr(span, err)
+		return errors.Wrap(err, "NewShopItemQuantityChangedEvent")
+	}
+
+	if err := event.SetMetadata(tracing.ExtractTextMapCarrier(span.Context())); err != nil {
+		tracing.TraceErr(span, err)
+		return errors.Wrap(err, "SetMetadata")
+	}
+
+	return a.Apply(event)
+}
+
+func (a *OrderAggregate) ApplyCoupon(ctx context.Context, coupon *models.Coupon, now time.Time) error {
+	span, _ := opentracing.StartSpanFromContext(ctx, "OrderAggregate.ApplyCoupon")
+	defer span.Finish()
+	span.LogFields(log.String("AggregateID", a.GetID()), log.String("Code", coupon.Code))
+
+	if err := a.CanTransition(ActionManageCoupons); err != nil {
+		return err
+	}
+	if err := coupon.Validate(); err != nil {
+		return err
+	}
+	if !coupon.IsRedeemable(now) {
+		return ErrCouponNotRedeemable
+	}
+	if GetAppliedCoupon(a.Order, coupon.Code) != nil {
+		return ErrCouponAlreadyApplied
+	}
+	if coupon.ShopItemID != "" && getShopItem(a.Order, coupon.ShopItemID) == nil {
+		return errors.Wrapf(ErrCouponNotApplicable, "shop item: {%s}", coupon.ShopItemID)
+	}
+
+	coupons := append(append(make([]*models.Coupon, 0, len(a.Order.Coupons)+1), a.Order.Coupons...), coupon)
+	pricing, err := a.calculatePricing(ctx, a.Order.ShopItems, coupons)
+	if err != nil {
+		return err
+	}
+	if pricing.DiscountTotal.Amount == a.Order.DiscountTotal.Amount {
+		return ErrCouponNotApplicable
+	}
+
+	event, err := events.NewCouponAppliedEvent(a, *coupon, pricing)
+	if err != nil {
+		tracing.TraceErr(span, err)
+		return errors.Wrap(err, "NewCouponAppliedEvent")
+	}
+
+	if err := event.SetMetadata(tracing.ExtractTextMapCarrier(span.Context())); err != nil {
+		tracing.TraceErr(span, err)
+		return errors.Wrap(err, "SetMetadata")
+	}
+
+	return a.Apply(event)
+}
+
+func (a *OrderAggregate) RemoveCoupon(ctx context.Context, code string) error {
+	span, _ := opentracing.StartSpanFromContext(ctx, "OrderAggregate.RemoveCoupon")
+	defer span.Finish()
+	span.LogFields(log.String("AggregateID", a.GetID()), log.String("Code", code))
+
+	if err := a.CanTransition(ActionManageCoupons); err != nil {
+		return err
+	}
+	if GetAppliedCoupon(a.Order, code) == nil {
+		return ErrCouponNotApplied
+	}
+
+	coupons := make([]*models.Coupon, 0, len(a.Order.Coupons))
+	for _, coupon := range a.Order.Coupons {
+		if coupon.Code != code {
+			coupons = append(coupons, coupon)
+		}
+	}
+	pricing, err := a.calculatePricing(ctx, a.Order.ShopItems, coupons)
+	if err != nil {
+		return err
+	}
+
+	event, err := events.NewCouponRemovedEvent(a, code, pricing)
+	if err != nil {
+		tracing.TraceErr(span, err)
+		return errors.Wrap(err, "NewCouponRemovedEvent")
+	}
+
+	if err := event.SetMetadata(tracing.ExtractTextMapCarrier(span.Context())); err != nil {
+		tracing.TraceErr(span, err)
+		return errors.Wrap(err, "SetMetadata")
+	}
+
+	return a.Apply(event)
+}
+
+// calculatePricing prices the given cart and coupons with the order shipping price and delivery address.
+func (a *OrderAggregate) calculatePricing(ctx context.Context, shopItems []*models.ShopItem, coupons []*models.Coupon) (models.OrderPricing, error) {
+	return a.calculateAddressPricing(ctx, a.Order.DeliveryAddress, shopItems, coupons)
+}
+
+func (a *OrderAggregate) calculateAddressPricing(ctx context.Context, deliveryAddress models.Address, shopItems []*models.ShopItem, coupons []*models.Coupon) (models.OrderPricing, error) {
+	shippingPrice := a.Order.ShippingPrice
+	if shippingPrice.Currency == "" {
+		shippingPrice = models.Zero(GetShopItemsCurrency(shopItems))
+	}
+
+	region := GetDeliveryRegion(deliveryAddress)
+	taxes, err := a.calculateTaxes(ctx, region, shopItems)
+	if err != nil {
+		return models.OrderPricing{}, err
+	}
+
+	pricing, err := CalculateOrderPricing(shopItems, coupons, shippingPrice, taxes)
+	if err != nil {
+		return models.OrderPricing{}, err
+	}
+	pricing.TaxRegion = region
+	return pricing, nil
+}
+
+func (a *OrderAggregate) calculateTaxes(ctx context.Context, region string, shopItems []*models.ShopItem) ([]*models.TaxLine, error) {
+	if a.taxCalculator == nil {
+		return nil, nil
+	}
+
+	taxes, err := a.taxCalculator.CalculateTax(ctx, region, shopItems)
+	if err != nil {
+		return nil, errors.Wrap(err, "CalculateTax")
+	}
+	return taxes, nil
+}
@@ -0,0 +1,592 @@
+package aggregate_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wassef911/eventually/internal/delivery/aggregate"
+	"github.com/wassef911/eventually/internal/delivery/models"
+)
+
+var testAddress = models.Address{Recipient: "John Doe", Line1: "123 Main St", City: "Springfield", PostalCode: "62701", Region: "IL", Country: "US"}
+
+func TestOrderAggregateCartItems(t *testing.T) {
+	ctx := context.Background()
+	order := aggregate.NewOrderAggregateWithID("order-cart")
+	shopItems := []*models.ShopItem{{ID: "item1", Title: "Item 1", Quantity: 1, Price: models.NewMoney(1000, "USD")}}
+	require.NoError(t, order.CreateOrder(ctx, shopItems, "", "test@example.com", testAddress, 0))
+
+	require.NoError(t, order.AddItem(ctx, &models.ShopItem{ID: "item2", Title: "Item 2", Quantity: 2, Price: models.NewMoney(250, "USD")}))
+	assert.Len(t, order.Order.ShopItems, 2)
+	assert.Equal(t, models.NewMoney(1500, "USD"), order.Order.TotalPrice)
+
+	err := order.AddItem(ctx, &models.ShopItem{ID: "item2", Quantity: 1, Price: models.NewMoney(250, "USD")})
+	assert.True(t, errors.Is(err, aggregate.ErrDuplicateShopItem))
+
+	err = order.AddItem(ctx, &models.ShopItem{ID: "item3", Quantity: 0, Price: models.NewMoney(250, "USD")})
+	assert.True(t, errors.Is(err, aggregate.ErrInvalidShopItemQuantity))
+
+	require.NoError(t, order.ChangeItemQuantity(ctx, "item1", 3))
+	assert.Equal(t, models.NewMoney(3500, "USD"), order.Order.TotalPrice)
+
+	require.NoError(t, order.RemoveItem(ctx, "item2"))
+	assert.Len(t, order.Order.ShopItems, 1)
+	assert.Equal(t, models.NewMoney(3000, "USD"), order.Order.TotalPrice)
+
+	err = order.RemoveItem(ctx, "item1")
+	assert.True(t, errors.Is(err, aggregate.ErrOrderShopItemsIsRequired))
+
+	err = order.ChangeItemQuantity(ctx, "missing", 1)
+	assert.True(t, errors.Is(err, aggregate.ErrShopItemNotFound))
+}
+
+func TestOrderAggregatePaymentAttempts(t *testing.T) {
+	ctx := context.Background()
+	order := aggregate.NewOrderAggregateWithID("order-payment")
+	shopItems := []*models.ShopItem{{ID: "item1", Title: "Item 1", Quantity: 2, Price: models.NewMoney(1000, "USD")}}
+	require.NoError(t, order.CreateOrder(ctx, shopItems, "", "test@example.com", testAddress, 0))
+
+	require.NoError(t, order.StartPayment(ctx, "attempt1", "fake"))
+	require.NoError(t, order.FailPayment(ctx, "attempt1", "payment declined"))
+	require.NoError(t, order.FailPayment(ctx, "attempt1", "payment declined"))
+	assert.False(t, order.Order.PaymentAttempts[0].IsOpen())
+
+	require.NoError(t, order.StartPayment(ctx, "attempt2", "fake"))
+	require.NoError(t, order.AuthorizePayment(ctx, "attempt2", "pay2", true))
+	assert.Equal(t, models.PaymentAttemptPending, order.Order.PaymentAttempts[1].Status)
+	assert.Equal(t, models.NewMoney(2000, "USD"), order.Order.PaymentAttempts[1].Amount)
+
+	err := order.StartPayment(ctx, "attempt3", "fake")
+	assert.True(t, errors.Is(err, aggregate.ErrPaymentPending))
+
+	payment := models.Payment{PaymentID: "pay2", Provider: "fake", Amount: models.NewMoney(2000, "USD")}
+	require.NoError(t, order.PayOrder(ctx, payment))
+	assert.Equal(t, models.PaymentAttemptCaptured, order.Order.PaymentAttempts[1].Status)
+
+	err = order.FailPayment(ctx, "attempt2", "too late")
+	assert.True(t, errors.Is(err, aggregate.ErrPaymentAttemptClosed))
+
+	err = order.AuthorizePayment(ctx, "missing", "pay3", false)
+	assert.True(t, errors.Is(err, aggregate.ErrPaymentAttemptNotFound))
+}
+
+// submittedOrder paid order handed over to fulfilment.
+func submittedOrder(t *testing.T, id string, shopItems []*models.ShopItem) *aggregate.OrderAggregate {
+	ctx := context.Background()
+	order := aggregate.NewOrderAggregateWithID(id)
+	require.NoError(t, order.CreateOrder(ctx, shopItems, "", "test@example.com", testAddress, 0))
+	require.NoError(t, order.PayOrder(ctx, models.Payment{PaymentID: "pay-" + id, Amount: order.Order.TotalPrice, Timestamp: time.Now()}))
+	require.NoError(t, order.SubmitOrder(ctx))
+	return order
+}
+
+// completedOrder paid order delivered right now, open to returns.
+func completedOrder(t *testing.T, id string, shopItems []*models.ShopItem) *aggregate.OrderAggregate {
+	order := submittedOrder(t, id, shopItems)
+	require.NoError(t, order.CompleteOrder(context.Background(), time.Now()))
+	return order
+}
+
+func TestOrderAggregateReceiveReturnAfterRefunds(t *testing.T) {
+	ctx := context.Background()
+	shopItems := []*models.ShopItem{
+		{ID: "item1", Title: "Item 1", Quantity: 2, Price: models.NewMoney(1000, "USD")},
+		{ID: "item2", Title: "Item 2", Quantity: 1, Price: models.NewMoney(500, "USD")},
+	}
+	returnItems := []*models.ReturnItem{{ShopItemID: "item1", Quantity: 2}}
+
+	tests := []struct {
+		name         string
+		refund       func(order *aggregate.OrderAggregate) error
+		wantRefunded models.Money
+		wantRefundID string
+		wantStatus   models.OrderStatus
+	}{
+		{
+			name:         "no earlier refund",
+			refund:       func(order *aggregate.OrderAggregate) error { return nil },
+			wantRefunded: models.NewMoney(2000, "USD"),
+			wantRefundID: "refund-return",
+			wantStatus:   models.OrderStatusCompleted,
+		},
+		{
+			name: "amount refunded after approval",
+			refund: func(order *aggregate.OrderAggregate) error {
+				amount := models.NewMoney(1000, "USD")
+				return order.RefundOrder(ctx, "refund-goodwill", nil, &amount, "late delivery")
+			},
+			wantRefunded: models.NewMoney(2500, "USD"),
+			wantRefundID: "refund-return",
+			wantStatus:   models.OrderStatusRefunded,
+		},
+		{
+			name: "returned unit refunded after approval",
+			refund: func(order *aggregate.OrderAggregate) error {
+				return order.RefundOrder(ctx, "refund-item", []*models.RefundItem{{ShopItemID: "item1", Quantity: 1}}, nil, "damaged")
+			},
+			wantRefunded: models.NewMoney(2000, "USD"),
+			wantRefundID: "refund-return",
+			wantStatus:   models.OrderStatusCompleted,
+		},
+		{
+			name: "order refunded in full after approval",
+			refund: func(order *aggregate.OrderAggregate) error {
+				return order.RefundOrder(ctx, "refund-all", nil, nil, "lost parcel")
+			},
+			wantRefunded: models.NewMoney(2500, "USD"),
+			wantStatus:   models.OrderStatusRefunded,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			order := completedOrder(t, "order-return", shopItems)
+			require.NoError(t, order.RequestReturn(ctx, "return1", returnItems, "wrong size", time.Hour))
+			require.NoError(t, order.ApproveReturn(ctx, "return1"))
+			require.NoError(t, tt.refund(order))
+
+			require.NoError(t, order.ReceiveReturn(ctx, "return1", "refund-return"))
+			assert.Equal(t, models.ReturnReceived, order.Order.Returns[0].Status)
+			assert.Equal(t, tt.wantRefundID, order.Order.Returns[0].RefundID)
+			assert.Equal(t, tt.wantRefunded, order.Order.RefundedAmount)
+			assert.Equal(t, tt.wantStatus, order.Order.Status)
+		})
+	}
+}
+
+func TestOrderAggregateRefundOrder(t *testing.T) {
+	ctx := context.Background()
+	shopItems := []*models.ShopItem{
+		{ID: "item1", Title: "Item 1", Quantity: 2, Price: models.NewMoney(1000, "USD")},
+		{ID: "item2", Title: "Item 2", Quantity: 1, Price: models.NewMoney(500, "USD")},
+	}
+	money := func(amount int64, currency string) *models.Money {
+		m := models.NewMoney(amount, currency)
+		return &m
+	}
+	type refund struct {
+		items  []*models.RefundItem
+		amount *models.Money
+	}
+
+	tests := []struct {
+		name         string
+		earlier      []refund
+		refund       refund
+		wantErr      error
+		wantRefunded models.Money
+		wantStatus   models.OrderStatus
+	}{
+		{
+			name:         "partial amount",
+			refund:       refund{amount: money(1000, "USD")},
+			wantRefunded: models.NewMoney(1000, "USD"),
+			wantStatus:   models.OrderStatusSubmitted,
+		},
+		{
+			name:         "line item",
+			refund:       refund{items: []*models.RefundItem{{ShopItemID: "item1", Quantity: 1}}},
+			wantRefunded: models.NewMoney(1000, "USD"),
+			wantStatus:   models.OrderStatusSubmitted,
+		},
+		{
+			name:         "partial refunds adding up to the total",
+			earlier:      []refund{{amount: money(2000, "USD")}},
+			refund:       refund{items: []*models.RefundItem{{ShopItemID: "item2", Quantity: 1}}},
+			wantRefunded: models.NewMoney(2500, "USD"),
+			wantStatus:   models.OrderStatusRefunded,
+		},
+		{
+			name:         "remaining amount",
+			earlier:      []refund{{items: []*models.RefundItem{{ShopItemID: "item1", Quantity: 1}}}},
+			refund:       refund{},
+			wantRefunded: models.NewMoney(2500, "USD"),
+			wantStatus:   models.OrderStatusRefunded,
+		},
+		{
+			name:    "amount over the paid amount",
+			refund:  refund{amount: money(3000, "USD")},
+			wantErr: aggregate.ErrRefundExceedsPaidAmount,
+		},
+		{
+			name:    "amount over what is left to refund",
+			earlier: []refund{{amount: money(2000, "USD")}},
+			refund:  refund{amount: money(1000, "USD")},
+			wantErr: aggregate.ErrRefundExceedsPaidAmount,
+		},
+		{
+			name:    "items over the purchased quantity",
+			refund:  refund{items: []*models.RefundItem{{ShopItemID: "item1", Quantity: 3}}},
+			wantErr: aggregate.ErrRefundItemQuantityExceeded,
+		},
+		{
+			name:    "item refunded twice",
+			earlier: []refund{{items: []*models.RefundItem{{ShopItemID: "item2", Quantity: 1}}}},
+			refund:  refund{items: []*models.RefundItem{{ShopItemID: "item2", Quantity: 1}}},
+			wantErr: aggregate.ErrRefundItemQuantityExceeded,
+		},
+		{
+			name:    "unknown item",
+			refund:  refund{items: []*models.RefundItem{{ShopItemID: "missing", Quantity: 1}}},
+			wantErr: aggregate.ErrRefundItemNotFound,
+		},
+		{
+			name:    "items and amount",
+			refund:  refund{items: []*models.RefundItem{{ShopItemID: "item1", Quantity: 1}}, amount: money(1000, "USD")},
+			wantErr: aggregate.ErrInvalidRefundRequest,
+		},
+		{
+			name:    "zero amount",
+			refund:  refund{amount: money(0, "USD")},
+			wantErr: aggregate.ErrInvalidRefundAmount,
+		},
+		{
+			name:    "other currency",
+			refund:  refund{amount: money(1000, "EUR")},
+			wantErr: aggregate.ErrInvalidRefundAmount,
+		},
+		{
+			name:    "order refunded in full",
+			earlier: []refund{{}},
+			refund:  refund{amount: money(100, "USD")},
+			wantErr: aggregate.ErrInvalidStatusTransition,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			order := submittedOrder(t, "order-refund", shopItems)
+			for i, earlier := range tt.earlier {
+				require.NoError(t, order.RefundOrder(ctx, fmt.Sprintf("refund-%d", i), earlier.items, earlier.amount, "goodwill"))
+			}
+			refunded := order.Order.RefundedAmount
+
+			err := order.RefundOrder(ctx, "refund", tt.refund.items, tt.refund.amount, "damaged")
+			if tt.wantErr != nil {
+				assert.True(t, errors.Is(err, tt.wantErr), "got %v", err)
+				assert.Equal(t, refunded, order.Order.RefundedAmount)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantRefunded, order.Order.RefundedAmount)
+			assert.Equal(t, tt.wantStatus, order.Order.Status)
+		})
+	}
+
+	unpaid := aggregate.NewOrderAggregateWithID("order-unpaid")
+	require.NoError(t, unpaid.CreateOrder(ctx, shopItems, "", "test@example.com", testAddress, 0))
+	err := unpaid.RefundOrder(ctx, "refund", nil, nil, "damaged")
+	assert.True(t, errors.Is(err, aggregate.ErrInvalidStatusTransition))
+}
+
+func TestOrderAggregatePendingRefunds(t *testing.T) {
+	ctx := context.Background()
+	shopItems := []*models.ShopItem{{ID: "item1", Title: "Item 1", Quantity: 2, Price: models.NewMoney(1000, "USD")}}
+	order := aggregate.NewOrderAggregateWithID("order-pending-refund")
+	require.NoError(t, order.CreateOrder(ctx, shopItems, "", "test@example.com", testAddress, 0))
+	require.NoError(t, order.PayOrder(ctx, models.Payment{PaymentID: "pay1", Provider: "fake", Amount: order.Order.TotalPrice, Timestamp: time.Now()}))
+
+	require.NoError(t, order.RefundOrder(ctx, "refund1", []*models.RefundItem{{ShopItemID: "item1", Quantity: 1}}, nil, "damaged"))
+	require.Len(t, order.Order.PendingRefunds, 1)
+	assert.Empty(t, order.Order.Refunds, "a refund is recorded once the provider refunded it")
+
+	err := order.RefundOrder(ctx, "refund2", []*models.RefundItem{{ShopItemID: "item1", Quantity: 2}}, nil, "damaged")
+	assert.True(t, errors.Is(err, aggregate.ErrRefundItemQuantityExceeded), "pending refunds are reserved, got %v", err)
+	err = order.RefundOrder(ctx, "refund2", nil, nil, "damaged")
+	require.NoError(t, err, "the rest of the order can be refunded")
+	require.Len(t, order.Order.PendingRefunds, 2)
+
+	require.NoError(t, order.FailRefund(ctx, "refund2", "declined"))
+	require.NoError(t, order.CompleteRefund(ctx, "refund1"))
+	assert.Empty(t, order.Order.PendingRefunds)
+	require.Len(t, order.Order.Refunds, 1)
+	assert.Equal(t, models.NewMoney(1000, "USD"), order.Order.RefundedAmount)
+	assert.Equal(t, models.OrderStatusPaid, order.Order.Status)
+
+	err = order.CompleteRefund(ctx, "refund2")
+	assert.True(t, errors.Is(err, aggregate.ErrRefundNotPending), "got %v", err)
+
+	require.NoError(t, order.RefundOrder(ctx, "refund3", nil, nil, "damaged"))
+	require.NoError(t, order.CompleteRefund(ctx, "refund3"))
+	assert.Equal(t, order.Order.TotalPrice, order.Order.RefundedAmount)
+	assert.Equal(t, models.OrderStatusRefunded, order.Order.Status)
+}
+
+func TestOrderAggregateReturnStates(t *testing.T) {
+	ctx := context.Background()
+	shopItems := []*models.ShopItem{{ID: "item1", Title: "Item 1", Quantity: 2, Price: models.NewMoney(1000, "USD")}}
+	returnItems := []*models.ReturnItem{{ShopItemID: "item1", Quantity: 1}}
+
+	tests := []struct {
+		name       string
+		run        func(order *aggregate.OrderAggregate) error
+		wantErr    error
+		wantStatus models.ReturnStatus
+	}{
+		{
+			name:       "approve",
+			run:        func(order *aggregate.OrderAggregate) error { return order.ApproveReturn(ctx, "return1") },
+			wantStatus: models.ReturnApproved,
+		},
+		{
+			name:       "reject",
+			run:        func(order *aggregate.OrderAggregate) error { return order.RejectReturn(ctx, "return1", "worn") },
+			wantStatus: models.ReturnRejected,
+		},
+		{
+			name: "receive approved",
+			run: func(order *aggregate.OrderAggregate) error {
+				if err := order.ApproveReturn(ctx, "return1"); err != nil {
+					return err
+				}
+				return order.ReceiveReturn(ctx, "return1", "refund1")
+			},
+			wantStatus: models.ReturnReceived,
+		},
+		{
+			name:    "reject without reason",
+			run:     func(order *aggregate.OrderAggregate) error { return order.RejectReturn(ctx, "return1", "") },
+			wantErr: aggregate.ErrReturnReasonRequired,
+		},
+		{
+			name: "approve twice",
+			run: func(order *aggregate.OrderAggregate) error {
+				if err := order.ApproveReturn(ctx, "return1"); err != nil {
+					return err
+				}
+				return order.ApproveReturn(ctx, "return1")
+			},
+			wantErr: aggregate.ErrInvalidReturnStatus,
+		},
+		{
+			name: "reject approved",
+			run: func(order *aggregate.OrderAggregate) error {
+				if err := order.ApproveReturn(ctx, "return1"); err != nil {
+					return err
+				}
+				return order.RejectReturn(ctx, "return1", "worn")
+			},
+			wantErr: aggregate.ErrInvalidReturnStatus,
+		},
+		{
+			name:    "receive requested",
+			run:     func(order *aggregate.OrderAggregate) error { return order.ReceiveReturn(ctx, "return1", "refund1") },
+			wantErr: aggregate.ErrInvalidReturnStatus,
+		},
+		{
+			name: "receive rejected",
+			run: func(order *aggregate.OrderAggregate) error {
+				if err := order.RejectReturn(ctx, "return1", "worn"); err != nil {
+					return err
+				}
+				return order.ReceiveReturn(ctx, "return1", "refund1")
+			},
+			wantErr: aggregate.ErrInvalidReturnStatus,
+		},
+		{
+			name: "receive twice",
+			run: func(order *aggregate.OrderAggregate) error {
+				if err := order.ApproveReturn(ctx, "return1"); err != nil {
+					return err
+				}
+				if err := order.ReceiveReturn(ctx, "return1", "refund1"); err != nil {
+					return err
+				}
+				return order.ReceiveReturn(ctx, "return1", "refund2")
+			},
+			wantErr: aggregate.ErrInvalidReturnStatus,
+		},
+		{
+			name:    "unknown return",
+			run:     func(order *aggregate.OrderAggregate) error { return order.ApproveReturn(ctx, "missing") },
+			wantErr: aggregate.ErrReturnNotFound,
+		},
+		{
+			name: "duplicate return",
+			run: func(order *aggregate.OrderAggregate) error {
+				return order.RequestReturn(ctx, "return1", returnItems, "wrong size", time.Hour)
+			},
+			wantErr: aggregate.ErrReturnAlreadyExists,
+		},
+		{
+			name: "items of a pending return",
+			run: func(order *aggregate.OrderAggregate) error {
+				return order.RequestReturn(ctx, "return2", []*models.ReturnItem{{ShopItemID: "item1", Quantity: 2}}, "wrong size", time.Hour)
+			},
+			wantErr: aggregate.ErrReturnItemQuantityExceeded,
+		},
+		{
+			name: "return window expired",
+			run: func(order *aggregate.OrderAggregate) error {
+				return order.RequestReturn(ctx, "return2", returnItems, "wrong size", 0)
+			},
+			wantErr: aggregate.ErrReturnWindowExpired,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			order := completedOrder(t, "order-return", shopItems)
+			require.NoError(t, order.RequestReturn(ctx, "return1", returnItems, "wrong size", time.Hour))
+
+			err := tt.run(order)
+			if tt.wantErr != nil {
+				assert.True(t, errors.Is(err, tt.wantErr), "got %v", err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantStatus, order.Order.Returns[0].Status)
+		})
+	}
+
+	submitted := submittedOrder(t, "order-submitted", shopItems)
+	err := submitted.RequestReturn(ctx, "return1", returnItems, "wrong size", time.Hour)
+	assert.True(t, errors.Is(err, aggregate.ErrInvalidStatusTransition))
+}
+
+func TestOrderAggregateShipmentStates(t *testing.T) {
+	ctx := context.Background()
+	shopItems := []*models.ShopItem{
+		{ID: "item1", Title: "Item 1", Quantity: 2, Price: models.NewMoney(1000, "USD")},
+		{ID: "item2", Title: "Item 2", Quantity: 1, Price: models.NewMoney(500, "USD")},
+	}
+	allItems := []*models.ShipmentItem{{ShopItemID: "item1", Quantity: 2}, {ShopItemID: "item2", Quantity: 1}}
+	deliveredAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name            string
+		items           []*models.ShipmentItem
+		run             func(order *aggregate.OrderAggregate) error
+		wantErr         error
+		wantShipment    models.ShipmentStatus
+		wantOrderStatus models.OrderStatus
+	}{
+		{
+			name:            "pack",
+			items:           allItems,
+			run:             func(order *aggregate.OrderAggregate) error { return order.PackShipment(ctx, "shipment1") },
+			wantShipment:    models.ShipmentPacked,
+			wantOrderStatus: models.OrderStatusSubmitted,
+		},
+		{
+			name:  "dispatch packed",
+			items: allItems,
+			run: func(order *aggregate.OrderAggregate) error {
+				if err := order.PackShipment(ctx, "shipment1"); err != nil {
+					return err
+				}
+				return order.DispatchShipment(ctx, "shipment1", "ups", "1Z999")
+			},
+			wantShipment:    models.ShipmentDispatched,
+			wantOrderStatus: models.OrderStatusSubmitted,
+		},
+		{
+			name:  "dispatch unpacked",
+			items: allItems,
+			run: func(order *aggregate.OrderAggregate) error {
+				return order.DispatchShipment(ctx, "shipment1", "ups", "1Z999")
+			},
+			wantShipment:    models.ShipmentDispatched,
+			wantOrderStatus: models.OrderStatusSubmitted,
+		},
+		{
+			name:  "deliver every item",
+			items: allItems,
+			run: func(order *aggregate.OrderAggregate) error {
+				if err := order.DispatchShipment(ctx, "shipment1", "ups", "1Z999"); err != nil {
+					return err
+				}
+				return order.DeliverShipment(ctx, "shipment1", deliveredAt)
+			},
+			wantShipment:    models.ShipmentDelivered,
+			wantOrderStatus: models.OrderStatusCompleted,
+		},
+		{
+			name:  "deliver some items",
+			items: []*models.ShipmentItem{{ShopItemID: "item1", Quantity: 1}},
+			run: func(order *aggregate.OrderAggregate) error {
+				if err := order.DispatchShipment(ctx, "shipment1", "ups", "1Z999"); err != nil {
+					return err
+				}
+				return order.DeliverShipment(ctx, "shipment1", deliveredAt)
+			},
+			wantShipment:    models.ShipmentDelivered,
+			wantOrderStatus: models.OrderStatusSubmitted,
+		},
+		{
+			name:  "pack twice",
+			items: allItems,
+			run: func(order *aggregate.OrderAggregate) error {
+				if err := order.PackShipment(ctx, "shipment1"); err != nil {
+					return err
+				}
+				return order.PackShipment(ctx, "shipment1")
+			},
+			wantErr: aggregate.ErrInvalidShipmentStatus,
+		},
+		{
+			name:  "deliver before dispatch",
+			items: allItems,
+			run: func(order *aggregate.OrderAggregate) error {
+				return order.DeliverShipment(ctx, "shipment1", deliveredAt)
+			},
+			wantErr: aggregate.ErrInvalidShipmentStatus,
+		},
+		{
+			name:  "dispatch without tracking number",
+			items: allItems,
+			run: func(order *aggregate.OrderAggregate) error {
+				return order.DispatchShipment(ctx, "shipment1", "ups", "")
+			},
+			wantErr: aggregate.ErrCarrierRequired,
+		},
+		{
+			name:    "unknown shipment",
+			items:   allItems,
+			run:     func(order *aggregate.OrderAggregate) error { return order.PackShipment(ctx, "missing") },
+			wantErr: aggregate.ErrShipmentNotFound,
+		},
+		{
+			name:  "duplicate shipment",
+			items: []*models.ShipmentItem{{ShopItemID: "item1", Quantity: 1}},
+			run: func(order *aggregate.OrderAggregate) error {
+				return order.CreateShipment(ctx, "shipment1", []*models.ShipmentItem{{ShopItemID: "item2", Quantity: 1}})
+			},
+			wantErr: aggregate.ErrShipmentAlreadyExists,
+		},
+		{
+			name:  "items already shipped",
+			items: allItems,
+			run: func(order *aggregate.OrderAggregate) error {
+				return order.CreateShipment(ctx, "shipment2", []*models.ShipmentItem{{ShopItemID: "item2", Quantity: 1}})
+			},
+			wantErr: aggregate.ErrShipmentItemQuantityExceeded,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			order := submittedOrder(t, "order-shipment", shopItems)
+			require.NoError(t, order.CreateShipment(ctx, "shipment1", tt.items))
+
+			err := tt.run(order)
+			if tt.wantErr != nil {
+				assert.True(t, errors.Is(err, tt.wantErr), "got %v", err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantShipment, order.Order.Shipments[0].Status)
+			assert.Equal(t, tt.wantOrderStatus, order.Order.Status)
+		})
+	}
+
+	completed := completedOrder(t, "order-completed", shopItems)
+	err := completed.CreateShipment(ctx, "shipment1", allItems)
+	assert.True(t, errors.Is(err, aggregate.ErrInvalidStatusTransition))
+}
@@ -0,0 +1,279 @@
+package aggregate
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/log"
+	"github.com/pkg/errors"
+
+	"github.com/wassef911/eventually/internal/delivery/events"
+	"github.com/wassef911/eventually/internal/delivery/models"
+	"github.com/wassef911/eventually/internal/infrastructure/es"
+	"github.com/wassef911/eventually/internal/infrastructure/es/store"
+	"github.com/wassef911/eventually/internal/infrastructure/tracing"
+)
+
+const CustomerAggregateType es.AggregateType = "customer"
+
+// CustomerAggregate customer account, orders keep a snapshot of the email and link to the account by its id.
+type CustomerAggregate struct {
+	*es.AggregateBase
+	Customer *models.Customer
+}
+
+func NewCustomerAggregateWithID(id string) *CustomerAggregate {
+	if id == "" {
+		return nil
+	}
+
+	aggregate := NewCustomerAggregate()
+	aggregate.SetID(id)
+	aggregate.Customer.CustomerID = id
+	return aggregate
+}
+
+func NewCustomerAggregate() *CustomerAggregate {
+	customerAggregate := &CustomerAggregate{Customer: models.NewCustomer()}
+	base := es.NewAggregateBase(customerAggregate.When)
+	base.SetType(CustomerAggregateType)
+	customerAggregate.AggregateBase = base
+	return customerAggregate
+}
+
+// IsRegistered reports whether the customer stream exists.
+func (a *CustomerAggregate) IsRegistered() bool {
+	return a.GetVersion() >= 0
+}
+
+func (a *CustomerAggregate) When(evt es.Event) error {
+
+	switch evt.GetEventType() {
+
+	case events.CustomerRegistered:
+		return a.onCustomerRegistered(evt)
+	case events.CustomerEmailChanged:
+		return a.onCustomerEmailChanged(evt)
+	case events.CustomerAddressSaved:
+		return a.onCustomerAddressSaved(evt)
+	case events.CustomerAddressRemoved:
+		return a.onCustomerAddressRemoved(evt)
+	case events.CustomerPreferencesUpdated:
+		return a.onCustomerPreferencesUpdated(evt)
+
+	default:
+		return es.ErrInvalidEventType
+	}
+}
+
+func (a *CustomerAggregate) onCustomerRegistered(evt es.Event) error {
+	var eventData events.CustomerRegisteredEvent
+	if err := evt.GetJsonData(&eventData); err != nil {
+		return errors.Wrap(err, "GetJsonData")
+	}
+
+	a.Customer.Email = eventData.Email
+	a.Customer.Name = eventData.Name
+	a.Customer.RegisteredAt = eventData.RegisteredAt
+	return nil
+}
+
+func (a *CustomerAggregate) onCustomerEmailChanged(evt es.Event) error {
+	var eventData events.CustomerEmailChangedEvent
+	if err := evt.GetJsonData(&eventData); err != nil {
+		return errors.Wrap(err, "GetJsonData")
+	}
+
+	a.Customer.Email = eventData.Email
+	return nil
+}
+
+func (a *CustomerAggregate) onCustomerAddressSaved(evt es.Event) error {
+	var eventData events.CustomerAddressSavedEvent
+	if err := evt.GetJsonData(&eventData); err != nil {
+		return errors.Wrap(err, "GetJsonData")
+	}
+
+	a.Customer.SaveAddress(&eventData.SavedAddress)
+	return nil
+}
+
+func (a *CustomerAggregate) onCustomerAddressRemoved(evt es.Event) error {
+	var eventData events.CustomerAddressRemovedEvent
+	if err := evt.GetJsonData(&eventData); err != nil {
+		return errors.Wrap(err, "GetJsonData")
+	}
+
+	a.Customer.RemoveAddress(eventData.AddressID)
+	return nil
+}
+
+func (a *CustomerAggregate) onCustomerPreferencesUpdated(evt es.Event) error {
+	var eventData events.CustomerPreferencesUpdatedEvent
+	if err := evt.GetJsonData(&eventData); err != nil {
+		return errors.Wrap(err, "GetJsonData")
+	}
+
+	a.Customer.Preferences = eventData.CustomerPreferences
+	return nil
+}
+
+func (a *CustomerAggregate) Register(ctx context.Context, email string, name string) error {
+	span, _ := opentracing.StartSpanFromContext(ctx, "CustomerAggregate.Register")
+	defer span.Finish()
+	span.LogFields(log.String("AggregateID", a.GetID()))
+
+	if a.IsRegistered() {
+		return ErrCustomerAlreadyRegistered
+	}
+	if email == "" {
+		return ErrCustomerEmailRequired
+	}
+
+	event, err := events.NewCustomerRegisteredEvent(a, email, name, time.Now().UTC())
+	if err != nil {
+		tracing.TraceErr(span, err)
+		return errors.Wrap(err, "NewCustomerRegisteredEvent")
+	}
+
+	if err := event.SetMetadata(tracing.ExtractTextMapCarrier(span.Context())); err != nil {
+		tracing.TraceErr(span, err)
+		return errors.Wrap(err, "SetMetadata")
+	}
+
+	return a.Apply(event)
+}
+
+// ChangeEmail changing to the current email is a no-op, the orders already placed keep their email.
+func (a *CustomerAggregate) ChangeEmail(ctx context.Context, email string) error {
+	span, _ := opentracing.StartSpanFromContext(ctx, "CustomerAggregate.ChangeEmail")
+	defer span.Finish()
+	span.LogFields(log.String("AggregateID", a.GetID()))
+
+	if !a.IsRegistered() {
+		return ErrCustomerNotFound
+	}
+	if email == "" {
+		return ErrCustomerEmailRequired
+	}
+	if strings.EqualFold(email, a.Customer.Email) {
+		return nil
+	}
+
+	event, err := events.NewCustomerEmailChangedEvent(a, email, a.Customer.Email)
+	if err != nil {
+		tracing.TraceErr(span, err)
+		return errors.Wrap(err, "NewCustomerEmailChangedEvent")
+	}
+
+	if err := event.SetMetadata(tracing.ExtractTextMapCarrier(span.Context())); err != nil {
+		tracing.TraceErr(span, err)
+		return errors.Wrap(err, "SetMetadata")
+	}
+
+	return a.Apply(event)
+}
+
+// SaveAddress adds a new address or replaces the saved address with the same id.
+func (a *CustomerAggregate) SaveAddress(ctx context.Context, address models.SavedAddress) error {
+	span, _ := opentracing.StartSpanFromContext(ctx, "CustomerAggregate.SaveAddress")
+	defer span.Finish()
+	span.LogFields(log.String("AggregateID", a.GetID()), log.String("AddressID", address.AddressID))
+
+	if !a.IsRegistered() {
+		return ErrCustomerNotFound
+	}
+	if address.AddressID == "" {
+		return ErrCustomerAddressIDRequired
+	}
+	if err := address.Address.Validate(); err != nil {
+		return errors.Wrap(ErrInvalidCustomerAddress, err.Error())
+	}
+
+	event, err := events.NewCustomerAddressSavedEvent(a, address)
+	if err != nil {
+		tracing.TraceErr(span, err)
+		return errors.Wrap(err, "NewCustomerAddressSavedEvent")
+	}
+
+	if err := event.SetMetadata(tracing.ExtractTextMapCarrier(span.Context())); err != nil {
+		tracing.TraceErr(span, err)
+		return errors.Wrap(err, "SetMetadata")
+	}
+
+	return a.Apply(event)
+}
+
+func (a *CustomerAggregate) RemoveAddress(ctx context.Context, addressID string) error {
+	span, _ := opentracing.StartSpanFromContext(ctx, "CustomerAggregate.RemoveAddress")
+	defer span.Finish()
+	span.LogFields(log.String("AggregateID", a.GetID()), log.String("AddressID", addressID))
+
+	if !a.IsRegistered() {
+		return ErrCustomerNotFound
+	}
+	if a.Customer.GetAddress(addressID) == nil {
+		return ErrCustomerAddressNotFound
+	}
+
+	event, err := events.NewCustomerAddressRemovedEvent(a, addressID)
+	if err != nil {
+		tracing.TraceErr(span, err)
+		return errors.Wrap(err, "NewCustomerAddressRemovedEvent")
+	}
+
+	if err := event.SetMetadata(tracing.ExtractTextMapCarrier(span.Context())); err != nil {
+		tracing.TraceErr(span, err)
+		return errors.Wrap(err, "SetMetadata")
+	}
+
+	return a.Apply(event)
+}
+
+func (a *CustomerAggregate) UpdatePreferences(ctx context.Context, preferences models.CustomerPreferences) error {
+	span, _ := opentracing.StartSpanFromContext(ctx, "CustomerAggregate.UpdatePreferences")
+	defer span.Finish()
+	span.LogFields(log.String("AggregateID", a.GetID()))
+
+	if !a.IsRegistered() {
+		return ErrCustomerNotFound
+	}
+	if preferences == a.Customer.Preferences {
+		return nil
+	}
+
+	event, err := events.NewCustomerPreferencesUpdatedEvent(a, preferences)
+	if err != nil {
+		tracing.TraceErr(span, err)
+		return errors.Wrap(err, "NewCustomerPreferencesUpdatedEvent")
+	}
+
+	if err := event.SetMetadata(tracing.ExtractTextMapCarrier(span.Context())); err != nil {
+		tracing.TraceErr(span, err)
+		return errors.Wrap(err, "SetMetadata")
+	}
+
+	return a.Apply(event)
+}
+
+// LoadCustomerAggregate an unknown id is returned as an unregistered customer, see CustomerAggregate.IsRegistered.
+func LoadCustomerAggregate(ctx context.Context, eventStore store.AggregateStore, customerID string) (*CustomerAggregate, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "LoadCustomerAggregate")
+	defer span.Finish()
+	span.LogFields(log.String("CustomerID", customerID))
+
+	customer := NewCustomerAggregateWithID(customerID)
+	customer.SetTenant(es.TenantFromContext(ctx))
+	if err := loadExistingAggregate(ctx, eventStore, customer); err != nil {
+		return nil, err
+	}
+
+	return customer, nil
+}
+
+func GetCustomerAggregateID(eventAggregateID string) string {
+	_, id := es.SplitTenantAggregateID(strings.TrimPrefix(eventAggregateID, string(CustomerAggregateType)+"-"))
+	return id
+}
@@ -0,0 +1,94 @@
+package aggregate_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wassef911/eventually/internal/delivery/aggregate"
+	"github.com/wassef911/eventually/internal/delivery/models"
+)
+
+func TestCustomerRegisterAndChangeEmail(t *testing.T) {
+	ctx := context.Background()
+	customer := aggregate.NewCustomerAggregateWithID("customer1")
+	assert.False(t, customer.IsRegistered())
+
+	err := customer.ChangeEmail(ctx, "john@example.com")
+	assert.True(t, errors.Is(err, aggregate.ErrCustomerNotFound))
+
+	assert.True(t, errors.Is(customer.Register(ctx, "", "John"), aggregate.ErrCustomerEmailRequired))
+	require.NoError(t, customer.Register(ctx, "john@example.com", "John"))
+	assert.True(t, customer.IsRegistered())
+	assert.Equal(t, "customer1", customer.Customer.CustomerID)
+	assert.False(t, customer.Customer.RegisteredAt.IsZero())
+
+	err = customer.Register(ctx, "john@example.com", "John")
+	assert.True(t, errors.Is(err, aggregate.ErrCustomerAlreadyRegistered))
+
+	events := len(customer.GetUncommittedEvents())
+	require.NoError(t, customer.ChangeEmail(ctx, "JOHN@example.com"))
+	assert.Len(t, customer.GetUncommittedEvents(), events, "the email did not change")
+
+	require.NoError(t, customer.ChangeEmail(ctx, "john.doe@example.com"))
+	assert.Equal(t, "john.doe@example.com", customer.Customer.Email)
+}
+
+func TestCustomerSavedAddresses(t *testing.T) {
+	ctx := context.Background()
+	customer := aggregate.NewCustomerAggregateWithID("customer1")
+	require.NoError(t, customer.Register(ctx, "john@example.com", "John"))
+
+	address := models.Address{Recipient: "John Doe", Line1: "123 Main St", City: "Springfield", PostalCode: "62701", Region: "IL", Country: "US"}
+
+	err := customer.SaveAddress(ctx, models.SavedAddress{Address: address})
+	assert.True(t, errors.Is(err, aggregate.ErrCustomerAddressIDRequired))
+	err = customer.SaveAddress(ctx, models.SavedAddress{AddressID: "home", Address: models.Address{Recipient: "John Doe"}})
+	assert.True(t, errors.Is(err, aggregate.ErrInvalidCustomerAddress))
+
+	require.NoError(t, customer.SaveAddress(ctx, models.SavedAddress{AddressID: "home", Label: "Home", Address: address, Default: true}))
+	require.NoError(t, customer.SaveAddress(ctx, models.SavedAddress{AddressID: "work", Label: "Work", Address: address}))
+	require.Len(t, customer.Customer.Addresses, 2)
+	assert.True(t, customer.Customer.GetAddress("home").Default)
+
+	require.NoError(t, customer.SaveAddress(ctx, models.SavedAddress{AddressID: "work", Label: "Office", Address: address, Default: true}))
+	require.Len(t, customer.Customer.Addresses, 2)
+	assert.Equal(t, "Office", customer.Customer.GetAddress("work").Label)
+	assert.True(t, customer.Customer.GetAddress("work").Default)
+	assert.False(t, customer.Customer.GetAddress("home").Default, "only one address is the default")
+
+	assert.True(t, errors.Is(customer.RemoveAddress(ctx, "unknown"), aggregate.ErrCustomerAddressNotFound))
+	require.NoError(t, customer.RemoveAddress(ctx, "home"))
+	require.Len(t, customer.Customer.Addresses, 1)
+	assert.Nil(t, customer.Customer.GetAddress("home"))
+}
+
+func TestCustomerUpdatePreferences(t *testing.T) {
+	ctx := context.Background()
+	customer := aggregate.NewCustomerAggregateWithID("customer1")
+
+	preferences := models.CustomerPreferences{Language: "en", Currency: "USD", MarketingOptIn: true}
+	assert.True(t, errors.Is(customer.UpdatePreferences(ctx, preferences), aggregate.ErrCustomerNotFound))
+
+	require.NoError(t, customer.Register(ctx, "john@example.com", "John"))
+	require.NoError(t, customer.UpdatePreferences(ctx, preferences))
+	assert.Equal(t, preferences, customer.Customer.Preferences)
+
+	events := len(customer.GetUncommittedEvents())
+	require.NoError(t, customer.UpdatePreferences(ctx, preferences))
+	assert.Len(t, customer.GetUncommittedEvents(), events, "the preferences did not change")
+}
+
+func TestCustomerOfTenant(t *testing.T) {
+	customer := aggregate.NewCustomerAggregateWithID("customer1")
+	customer.SetTenant("acme")
+	require.NoError(t, customer.Register(context.Background(), "john@example.com", "John"))
+
+	assert.Equal(t, "customer-acme~customer1", customer.GetID())
+	assert.Equal(t, "customer1", customer.Customer.CustomerID)
+	assert.Equal(t, "customer1", aggregate.GetCustomerAggregateID(customer.GetID()))
+	assert.Equal(t, "1", aggregate.GetOrderAggregateID("order-acme~1"))
+}
@@ -1,18 +1,79 @@
 package aggregate
 
-import "github.com/pkg/errors"
+import "github.com/wassef911/eventually/internal/delivery/domain"
 
 var (
-	ErrOrderAlreadyCompleted          = errors.New("Order already completed")
-	ErrOrderAlreadyCanceled           = errors.New("Order is already canceled")
-	ErrOrderMustBePaidBeforeDelivered = errors.New("Order must be paid before been delivered")
-	ErrCancelReasonRequired           = errors.New("Cancel reason must be provided")
-	ErrOrderAlreadyCancelled          = errors.New("order already cancelled")
-	ErrAlreadyPaid                    = errors.New("already paid")
-	ErrAlreadySubmitted               = errors.New("already submitted")
-	ErrOrderNotPaid                   = errors.New("order not paid")
-	ErrOrderNotFound                  = errors.New("order not found")
-	ErrAlreadyCreated                 = errors.New("order with given id already created")
-	ErrOrderShopItemsIsRequired       = errors.New("order shop items is required")
-	ErrInvalidDeliveryAddress         = errors.New("Invalid delivery address")
+	ErrOrderAlreadyCompleted          = domain.NewConflictError("order_already_completed", "Order already completed")
+	ErrOrderAlreadyCanceled           = domain.NewConflictError("order_already_canceled", "Order is already canceled")
+	ErrOrderMustBePaidBeforeDelivered = domain.NewConflictError("order_not_paid_before_delivery", "Order must be paid before been delivered")
+	ErrCancelReasonRequired           = domain.NewInvalidError("cancel_reason_required", "Cancel reason must be provided")
+	ErrRejectReasonRequired           = domain.NewInvalidError("reject_reason_required", "reject reason must be provided")
+	ErrOrderAlreadyCancelled          = domain.NewConflictError("order_already_canceled", "order already cancelled")
+	ErrAlreadyPaid                    = domain.NewConflictError("order_already_paid", "already paid")
+	ErrAlreadySubmitted               = domain.NewConflictError("order_already_submitted", "already submitted")
+	ErrOrderNotPaid                   = domain.NewConflictError("order_not_paid", "order not paid")
+	ErrOrderNotFound                  = domain.NewNotFoundError("order_not_found", "order not found")
+	ErrAlreadyCreated                 = domain.NewConflictError("order_already_created", "order with given id already created")
+	ErrOrderShopItemsIsRequired       = domain.NewInvalidError("shop_items_required", "order shop items is required")
+	ErrInvalidDeliveryAddress         = domain.NewInvalidError("invalid_delivery_address", "Invalid delivery address")
+	ErrOrderCurrencyMismatch          = domain.NewInvalidError("order_currency_mismatch", "all order shop items must share the same currency")
+	ErrOrderAlreadyRefunded           = domain.NewConflictError("order_already_refunded", "order already fully refunded")
+	ErrRefundIDRequired               = domain.NewInvalidError("refund_id_required", "refund id is required")
+	ErrRefundReasonRequired           = domain.NewInvalidError("refund_reason_required", "refund reason must be provided")
+	ErrInvalidRefundRequest           = domain.NewInvalidError("invalid_refund_request", "refund must target either line items or an amount, not both")
+	ErrInvalidRefundAmount            = domain.NewInvalidError("invalid_refund_amount", "refund amount must be positive and in the order currency")
+	ErrRefundExceedsPaidAmount        = domain.NewInvalidError("refund_exceeds_paid_amount", "cannot refund more than paid")
+	ErrRefundItemNotFound             = domain.NewInvalidError("refund_item_not_found", "refund item not found in order")
+	ErrRefundItemQuantityExceeded     = domain.NewInvalidError("refund_item_quantity_exceeded", "cannot refund more items than purchased")
+	ErrOrderNotCompleted              = domain.NewConflictError("order_not_completed", "order not completed")
+	ErrReturnWindowExpired            = domain.NewConflictError("return_window_expired", "return window expired")
+	ErrReturnIDRequired               = domain.NewInvalidError("return_id_required", "return id is required")
+	ErrReturnReasonRequired           = domain.NewInvalidError("return_reason_required", "return reason must be provided")
+	ErrReturnItemsRequired            = domain.NewInvalidError("return_items_required", "return items are required")
+	ErrReturnNotFound                 = domain.NewNotFoundError("return_not_found", "return not found")
+	ErrReturnAlreadyExists            = domain.NewConflictError("return_already_exists", "return with given id already exists")
+	ErrInvalidReturnStatus            = domain.NewConflictError("invalid_return_status", "invalid return status for this operation")
+	ErrReturnItemNotFound             = domain.NewInvalidError("return_item_not_found", "return item not found in order")
+	ErrReturnItemQuantityExceeded     = domain.NewInvalidError("return_item_quantity_exceeded", "cannot return more items than purchased")
+	ErrOrderNotSubmitted              = domain.NewConflictError("order_not_submitted", "order not submitted")
+	ErrShipmentIDRequired             = domain.NewInvalidError("shipment_id_required", "shipment id is required")
+	ErrShipmentItemsRequired          = domain.NewInvalidError("shipment_items_required", "shipment items are required")
+	ErrShipmentNotFound               = domain.NewNotFoundError("shipment_not_found", "shipment not found")
+	ErrShipmentAlreadyExists          = domain.NewConflictError("shipment_already_exists", "shipment with given id already exists")
+	ErrInvalidShipmentStatus          = domain.NewConflictError("invalid_shipment_status", "invalid shipment status for this operation")
+	ErrShipmentItemNotFound           = domain.NewInvalidError("shipment_item_not_found", "shipment item not found in order")
+	ErrShipmentItemQuantityExceeded   = domain.NewInvalidError("shipment_item_quantity_exceeded", "cannot ship more items than ordered")
+	ErrCarrierRequired                = domain.NewInvalidError("carrier_required", "carrier and tracking number are required")
+	ErrInvalidStatusTransition        = domain.NewConflictError("invalid_status_transition", "action not allowed in current order status")
+	ErrShopItemIDRequired             = domain.NewInvalidError("shop_item_id_required", "shop item id is required")
+	ErrInvalidShopItemQuantity        = domain.NewInvalidError("invalid_shop_item_quantity", "shop item quantity must be positive")
+	ErrDuplicateShopItem              = domain.NewConflictError("duplicate_shop_item", "shop item already in the order")
+	ErrShopItemNotFound               = domain.NewNotFoundError("shop_item_not_found", "shop item not found in order")
+	ErrTooManyShopItems               = domain.NewInvalidError("too_many_shop_items", "too many shop items in the order")
+	ErrCouponNotFound                 = domain.NewNotFoundError("coupon_not_found", "coupon not found")
+	ErrCouponNotRedeemable            = domain.NewConflictError("coupon_not_redeemable", "coupon is disabled or expired")
+	ErrCouponAlreadyApplied           = domain.NewConflictError("coupon_already_applied", "coupon already applied to the order")
+	ErrCouponNotApplied               = domain.NewConflictError("coupon_not_applied", "coupon not applied to the order")
+	ErrCouponNotApplicable            = domain.NewInvalidError("coupon_not_applicable", "coupon does not apply to any item of the order")
+	ErrCouponCurrencyMismatch         = domain.NewInvalidError("coupon_currency_mismatch", "coupon currency does not match the order currency")
+	ErrInvalidStockQuantity           = domain.NewInvalidError("invalid_stock_quantity", "stock quantity must be positive")
+	ErrInventoryNotFound              = domain.NewNotFoundError("inventory_not_found", "inventory not found")
+	ErrCustomerNotFound               = domain.NewNotFoundError("customer_not_found", "customer not found")
+	ErrCustomerAlreadyRegistered      = domain.NewConflictError("customer_already_registered", "customer with given id already registered")
+	ErrCustomerEmailRequired          = domain.NewInvalidError("customer_email_required", "customer email is required")
+	ErrCustomerEmailMismatch          = domain.NewInvalidError("customer_email_mismatch", "account email does not match the customer email")
+	ErrCustomerAddressIDRequired      = domain.NewInvalidError("customer_address_id_required", "customer address id is required")
+	ErrCustomerAddressNotFound        = domain.NewNotFoundError("customer_address_not_found", "customer address not found")
+	ErrInvalidCustomerAddress         = domain.NewInvalidError("invalid_customer_address", "invalid customer address")
+	ErrInsufficientStock              = domain.NewConflictError("insufficient_stock", "insufficient stock")
+	ErrProductNotFound                = domain.NewNotFoundError("product_not_found", "product not found")
+	ErrProductAlreadyExists           = domain.NewConflictError("product_already_exists", "product with given id already exists")
+	ErrProductDiscontinued            = domain.NewConflictError("product_discontinued", "product is discontinued")
+	ErrProductTitleRequired           = domain.NewInvalidError("product_title_required", "product title is required")
+	ErrInvalidProductPrice            = domain.NewInvalidError("invalid_product_price", "product price must be positive")
+	ErrPaymentAttemptNotFound         = domain.NewNotFoundError("payment_attempt_not_found", "payment attempt not found")
+	ErrPaymentAttemptClosed           = domain.NewConflictError("payment_attempt_closed", "payment attempt already captured or failed")
+	ErrPaymentPending                 = domain.NewConflictError("payment_pending", "a payment of the order is waiting for the provider")
+	ErrPaymentFailureReasonRequired   = domain.NewInvalidError("payment_failure_reason_required", "payment failure reason must be provided")
+	ErrRefundNotPending               = domain.NewNotFoundError("refund_not_pending", "no pending refund with given id")
 )
@@ -0,0 +1,265 @@
+package aggregate
+
+import (
+	"context"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/log"
+	"github.com/pkg/errors"
+
+	"github.com/wassef911/eventually/internal/delivery/events"
+	"github.com/wassef911/eventually/internal/delivery/models"
+	"github.com/wassef911/eventually/internal/infrastructure/es"
+	"github.com/wassef911/eventually/internal/infrastructure/es/store"
+	"github.com/wassef911/eventually/internal/infrastructure/tracing"
+)
+
+const InventoryAggregateType es.AggregateType = "inventory"
+
+// InventoryAggregate stock of one SKU, the stream only exists once stock was added,
+// SKUs without a stream are not tracked and can always be ordered.
+type InventoryAggregate struct {
+	*es.AggregateBase
+	Inventory *models.Inventory
+}
+
+func NewInventoryAggregateWithID(sku string) *InventoryAggregate {
+	if sku == "" {
+		return nil
+	}
+
+	aggregate := NewInventoryAggregate()
+	aggregate.SetID(sku)
+	aggregate.Inventory.SKU = sku
+	return aggregate
+}
+
+func NewInventoryAggregate() *InventoryAggregate {
+	inventoryAggregate := &InventoryAggregate{Inventory: models.NewInventory()}
+	base := es.NewAggregateBase(inventoryAggregate.When)
+	base.SetType(InventoryAggregateType)
+	inventoryAggregate.AggregateBase = base
+	return inventoryAggregate
+}
+
+// IsTracked reports whether stock was ever added for the SKU.
+func (a *InventoryAggregate) IsTracked() bool {
+	return a.GetVersion() >= 0
+}
+
+func (a *InventoryAggregate) When(evt es.Event) error {
+
+	switch evt.GetEventType() {
+
+	case events.StockAdded:
+		return a.onStockAdded(evt)
+	case events.StockReserved:
+		return a.onStockReserved(evt)
+	case events.StockReleased:
+		return a.onStockReleased(evt)
+	case events.StockCommitted:
+		return a.onStockCommitted(evt)
+	case events.StockReservationRejected:
+		return nil
+
+	default:
+		return es.ErrInvalidEventType
+	}
+}
+
+func (a *InventoryAggregate) onStockAdded(evt es.Event) error {
+	var eventData events.StockAddedEvent
+	if err := evt.GetJsonData(&eventData); err != nil {
+		return errors.Wrap(err, "GetJsonData")
+	}
+
+	a.Inventory.OnHand += eventData.Quantity
+	return nil
+}
+
+func (a *InventoryAggregate) onStockReserved(evt es.Event) error {
+	var eventData events.StockReservedEvent
+	if err := evt.GetJsonData(&eventData); err != nil {
+		return errors.Wrap(err, "GetJsonData")
+	}
+
+	a.Inventory.Reserved = a.Inventory.Reserved - a.Inventory.Reservations[eventData.OrderID] + eventData.Quantity
+	a.Inventory.Reservations[eventData.OrderID] = eventData.Quantity
+	return nil
+}
+
+func (a *InventoryAggregate) onStockReleased(evt es.Event) error {
+	var eventData events.StockReleasedEvent
+	if err := evt.GetJsonData(&eventData); err != nil {
+		return errors.Wrap(err, "GetJsonData")
+	}
+
+	a.Inventory.Reserved -= a.Inventory.Reservations[eventData.OrderID]
+	delete(a.Inventory.Reservations, eventData.OrderID)
+	return nil
+}
+
+func (a *InventoryAggregate) onStockCommitted(evt es.Event) error {
+	var eventData events.StockCommittedEvent
+	if err := evt.GetJsonData(&eventData); err != nil {
+		return errors.Wrap(err, "GetJsonData")
+	}
+
+	a.Inventory.Reserved -= a.Inventory.Reservations[eventData.OrderID]
+	a.Inventory.OnHand -= eventData.Quantity
+	delete(a.Inventory.Reservations, eventData.OrderID)
+	return nil
+}
+
+func (a *InventoryAggregate) AddStock(ctx context.Context, quantity uint64) error {
+	span, _ := opentracing.StartSpanFromContext(ctx, "InventoryAggregate.AddStock")
+	defer span.Finish()
+	span.LogFields(log.String("AggregateID", a.GetID()))
+
+	if quantity == 0 {
+		return ErrInvalidStockQuantity
+	}
+
+	event, err := events.NewStockAddedEvent(a, quantity)
+	if err != nil {
+		tracing.TraceErr(span, err)
+		return errors.Wrap(err, "NewStockAddedEvent")
+	}
+
+	if err := event.SetMetadata(tracing.ExtractTextMapCarrier(span.Context())); err != nil {
+		tracing.TraceErr(span, err)
+		return errors.Wrap(err, "SetMetadata")
+	}
+
+	return a.Apply(event)
+}
+
+// ReserveStock sets the quantity reserved for the order, reserving the current quantity again is a no-op
+// and zero releases the reservation.
+func (a *InventoryAggregate) ReserveStock(ctx context.Context, orderID string, quantity uint64) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "InventoryAggregate.ReserveStock")
+	defer span.Finish()
+	span.LogFields(log.String("AggregateID", a.GetID()), log.String("OrderID", orderID))
+
+	if !a.IsTracked() {
+		return errors.Wrapf(ErrInventoryNotFound, "sku: {%s}", a.Inventory.SKU)
+	}
+	if quantity == 0 {
+		return a.ReleaseStock(ctx, orderID)
+	}
+
+	reserved := a.Inventory.Reservations[orderID]
+	if quantity == reserved {
+		return nil
+	}
+	if quantity > reserved && quantity-reserved > a.Inventory.Available() {
+		return errors.Wrapf(ErrInsufficientStock, "sku: {%s}, available: {%d}, requested: {%d}", a.Inventory.SKU, a.Inventory.Available(), quantity-reserved)
+	}
+
+	event, err := events.NewStockReservedEvent(a, orderID, quantity)
+	if err != nil {
+		tracing.TraceErr(span, err)
+		return errors.Wrap(err, "NewStockReservedEvent")
+	}
+
+	if err := event.SetMetadata(tracing.ExtractTextMapCarrier(span.Context())); err != nil {
+		tracing.TraceErr(span, err)
+		return errors.Wrap(err, "SetMetadata")
+	}
+
+	return a.Apply(event)
+}
+
+// RejectReservation records that the order asked for more than the available stock, the reservation is unchanged.
+func (a *InventoryAggregate) RejectReservation(ctx context.Context, orderID string, quantity uint64) error {
+	span, _ := opentracing.StartSpanFromContext(ctx, "InventoryAggregate.RejectReservation")
+	defer span.Finish()
+	span.LogFields(log.String("AggregateID", a.GetID()), log.String("OrderID", orderID))
+
+	event, err := events.NewStockReservationRejectedEvent(a, orderID, quantity, a.Inventory.Available())
+	if err != nil {
+		tracing.TraceErr(span, err)
+		return errors.Wrap(err, "NewStockReservationRejectedEvent")
+	}
+
+	if err := event.SetMetadata(tracing.ExtractTextMapCarrier(span.Context())); err != nil {
+		tracing.TraceErr(span, err)
+		return errors.Wrap(err, "SetMetadata")
+	}
+
+	return a.Apply(event)
+}
+
+// ReleaseStock returns the stock reserved for the order, it is a no-op when nothing is reserved.
+func (a *InventoryAggregate) ReleaseStock(ctx context.Context, orderID string) error {
+	span, _ := opentracing.StartSpanFromContext(ctx, "InventoryAggregate.ReleaseStock")
+	defer span.Finish()
+	span.LogFields(log.String("AggregateID", a.GetID()), log.String("OrderID", orderID))
+
+	reserved, ok := a.Inventory.Reservations[orderID]
+	if !ok {
+		return nil
+	}
+
+	event, err := events.NewStockReleasedEvent(a, orderID, reserved)
+	if err != nil {
+		tracing.TraceErr(span, err)
+		return errors.Wrap(err, "NewStockReleasedEvent")
+	}
+
+	if err := event.SetMetadata(tracing.ExtractTextMapCarrier(span.Context())); err != nil {
+		tracing.TraceErr(span, err)
+		return errors.Wrap(err, "SetMetadata")
+	}
+
+	return a.Apply(event)
+}
+
+// CommitStock removes the stock reserved for the order from the stock on hand, it is a no-op when nothing is reserved.
+func (a *InventoryAggregate) CommitStock(ctx context.Context, orderID string) error {
+	span, _ := opentracing.StartSpanFromContext(ctx, "InventoryAggregate.CommitStock")
+	defer span.Finish()
+	span.LogFields(log.String("AggregateID", a.GetID()), log.String("OrderID", orderID))
+
+	reserved, ok := a.Inventory.Reservations[orderID]
+	if !ok {
+		return nil
+	}
+
+	event, err := events.NewStockCommittedEvent(a, orderID, reserved)
+	if err != nil {
+		tracing.TraceErr(span, err)
+		return errors.Wrap(err, "NewStockCommittedEvent")
+	}
+
+	if err := event.SetMetadata(tracing.ExtractTextMapCarrier(span.Context())); err != nil {
+		tracing.TraceErr(span, err)
+		return errors.Wrap(err, "SetMetadata")
+	}
+
+	return a.Apply(event)
+}
+
+// LoadInventoryAggregate an untracked SKU is returned as an empty inventory, see InventoryAggregate.IsTracked.
+func LoadInventoryAggregate(ctx context.Context, eventStore store.AggregateStore, sku string) (*InventoryAggregate, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "LoadInventoryAggregate")
+	defer span.Finish()
+	span.LogFields(log.String("SKU", sku))
+
+	inventory := NewInventoryAggregateWithID(sku)
+	inventory.SetTenant(es.TenantFromContext(ctx))
+	if err := loadExistingAggregate(ctx, eventStore, inventory); err != nil {
+		return nil, err
+	}
+
+	return inventory, nil
+}
+
+// GetStockItems quantity per SKU needed to fulfil the shop items.
+func GetStockItems(shopItems []*models.ShopItem) map[string]uint64 {
+	items := make(map[string]uint64, len(shopItems))
+	for _, item := range shopItems {
+		items[item.ID] += item.Quantity
+	}
+	return items
+}
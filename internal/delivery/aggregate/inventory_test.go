@@ -0,0 +1,72 @@
+package aggregate_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wassef911/eventually/internal/delivery/aggregate"
+	"github.com/wassef911/eventually/internal/delivery/models"
+)
+
+func TestInventoryReserveStock(t *testing.T) {
+	ctx := context.Background()
+	inventory := aggregate.NewInventoryAggregateWithID("item1")
+	assert.False(t, inventory.IsTracked())
+
+	err := inventory.ReserveStock(ctx, "order1", 1)
+	assert.True(t, errors.Is(err, aggregate.ErrInventoryNotFound))
+
+	assert.True(t, errors.Is(inventory.AddStock(ctx, 0), aggregate.ErrInvalidStockQuantity))
+	require.NoError(t, inventory.AddStock(ctx, 5))
+	assert.True(t, inventory.IsTracked())
+
+	require.NoError(t, inventory.ReserveStock(ctx, "order1", 3))
+	assert.Equal(t, uint64(3), inventory.Inventory.Reserved)
+	assert.Equal(t, uint64(2), inventory.Inventory.Available())
+
+	events := len(inventory.GetUncommittedEvents())
+	require.NoError(t, inventory.ReserveStock(ctx, "order1", 3))
+	assert.Len(t, inventory.GetUncommittedEvents(), events, "reserving the same quantity again is a no-op")
+
+	err = inventory.ReserveStock(ctx, "order2", 3)
+	assert.True(t, errors.Is(err, aggregate.ErrInsufficientStock))
+
+	require.NoError(t, inventory.ReserveStock(ctx, "order1", 1))
+	require.NoError(t, inventory.ReserveStock(ctx, "order2", 4))
+	assert.Equal(t, uint64(5), inventory.Inventory.Reserved)
+	assert.Equal(t, uint64(0), inventory.Inventory.Available())
+}
+
+func TestInventoryReleaseAndCommitStock(t *testing.T) {
+	ctx := context.Background()
+	inventory := aggregate.NewInventoryAggregateWithID("item1")
+	require.NoError(t, inventory.AddStock(ctx, 10))
+	require.NoError(t, inventory.ReserveStock(ctx, "order1", 2))
+	require.NoError(t, inventory.ReserveStock(ctx, "order2", 3))
+
+	require.NoError(t, inventory.ReleaseStock(ctx, "order1"))
+	assert.Equal(t, uint64(3), inventory.Inventory.Reserved)
+	assert.Equal(t, uint64(10), inventory.Inventory.OnHand)
+
+	events := len(inventory.GetUncommittedEvents())
+	require.NoError(t, inventory.ReleaseStock(ctx, "order1"))
+	require.NoError(t, inventory.CommitStock(ctx, "order1"))
+	assert.Len(t, inventory.GetUncommittedEvents(), events, "nothing is reserved for the order anymore")
+
+	require.NoError(t, inventory.CommitStock(ctx, "order2"))
+	assert.Equal(t, uint64(0), inventory.Inventory.Reserved)
+	assert.Equal(t, uint64(7), inventory.Inventory.OnHand)
+	assert.Empty(t, inventory.Inventory.Reservations)
+}
+
+func TestGetStockItems(t *testing.T) {
+	shopItems := []*models.ShopItem{
+		{ID: "item1", Quantity: 2, Price: models.NewMoney(100, "USD")},
+		{ID: "item2", Quantity: 1, Price: models.NewMoney(100, "USD")},
+	}
+	assert.Equal(t, map[string]uint64{"item1": 2, "item2": 1}, aggregate.GetStockItems(shopItems))
+}
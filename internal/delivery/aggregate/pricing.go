@@ -0,0 +1,154 @@
+package aggregate
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/wassef911/eventually/internal/delivery/models"
+)
+
+const percentBase = 100
+
+// TaxCalculator computes the tax of every line item delivered to a region.
+// Taxes are charged on undiscounted line totals.
+type TaxCalculator interface {
+	CalculateTax(ctx context.Context, region string, shopItems []*models.ShopItem) ([]*models.TaxLine, error)
+}
+
+// CalculateOrderPricing applies coupons in order to the cart and adds the line taxes. Each discount
+// is capped so the running discount total never exceeds subtotal plus shipping.
+func CalculateOrderPricing(shopItems []*models.ShopItem, coupons []*models.Coupon, shippingPrice models.Money, taxes []*models.TaxLine) (models.OrderPricing, error) {
+	subtotal, err := GetShopItemsTotalPrice(shopItems)
+	if err != nil {
+		return models.OrderPricing{}, errors.Wrap(err, "GetShopItemsTotalPrice")
+	}
+
+	currency := subtotal.Currency
+	if currency == "" {
+		currency = shippingPrice.Currency
+	}
+	subtotal.Currency = currency
+	if shippingPrice.Currency == "" {
+		shippingPrice = models.Zero(currency)
+	}
+	if !shippingPrice.SameCurrency(subtotal) {
+		return models.OrderPricing{}, ErrOrderCurrencyMismatch
+	}
+
+	discountable := subtotal.Amount + shippingPrice.Amount
+	discountTotal := models.Zero(currency)
+	discounts := make([]*models.Discount, 0, len(coupons))
+	for _, coupon := range coupons {
+		discount, err := GetCouponDiscount(coupon, shopItems, subtotal, shippingPrice)
+		if err != nil {
+			return models.OrderPricing{}, err
+		}
+		if remaining := discountable - discountTotal.Amount; discount.Amount > remaining {
+			discount.Amount = remaining
+		}
+		discountTotal.Amount += discount.Amount
+		discounts = append(discounts, &models.Discount{Code: coupon.Code, Amount: discount})
+	}
+
+	taxTotal := models.Zero(currency)
+	for _, tax := range taxes {
+		if tax.Amount.Currency != "" && tax.Amount.Currency != currency {
+			return models.OrderPricing{}, ErrOrderCurrencyMismatch
+		}
+		taxTotal.Amount += tax.Amount.Amount
+	}
+	if taxes == nil {
+		taxes = make([]*models.TaxLine, 0)
+	}
+
+	return models.OrderPricing{
+		Subtotal:      subtotal,
+		Discounts:     discounts,
+		DiscountTotal: discountTotal,
+		ShippingPrice: shippingPrice,
+		TaxTotal:      taxTotal,
+		Taxes:         taxes,
+		Total:         models.NewMoney(subtotal.Amount-discountTotal.Amount+shippingPrice.Amount+taxTotal.Amount, currency),
+	}, nil
+}
+
+// GetCouponDiscount computes the discount of a single coupon, ignoring other coupons.
+func GetCouponDiscount(coupon *models.Coupon, shopItems []*models.ShopItem, subtotal, shippingPrice models.Money) (models.Money, error) {
+	discount := models.Zero(subtotal.Currency)
+
+	switch coupon.Type {
+	case models.PromotionPercentage:
+		base := subtotal
+		if coupon.ShopItemID != "" {
+			base = lineTotal(shopItems, coupon.ShopItemID, subtotal.Currency)
+		}
+		discount.Amount = base.Amount * int64(coupon.Percentage) / percentBase
+
+	case models.PromotionFixedAmount:
+		if !coupon.Amount.SameCurrency(subtotal) {
+			return models.Money{}, ErrCouponCurrencyMismatch
+		}
+		base := subtotal
+		if coupon.ShopItemID != "" {
+			base = lineTotal(shopItems, coupon.ShopItemID, subtotal.Currency)
+		}
+		discount.Amount = min(coupon.Amount.Amount, base.Amount)
+
+	case models.PromotionBuyXGetY:
+		for _, item := range shopItems {
+			if coupon.ShopItemID != "" && item.ID != coupon.ShopItemID {
+				continue
+			}
+			free := item.Quantity / (coupon.BuyQuantity + coupon.GetQuantity) * coupon.GetQuantity
+			discount.Amount += item.Price.Multiply(free).Amount
+		}
+
+	case models.PromotionFreeShipping:
+		discount.Amount = shippingPrice.Amount
+
+	default:
+		return models.Money{}, errors.Wrapf(models.ErrInvalidCoupon, "unknown type: {%s}", coupon.Type)
+	}
+
+	return discount, nil
+}
+
+// GetEventPricing returns the pricing recorded in the event, events recorded before
+// pricing was introduced are priced as the plain sum of their shop items.
+func GetEventPricing(pricing *models.OrderPricing, shopItems []*models.ShopItem) (models.OrderPricing, error) {
+	if pricing != nil {
+		return *pricing, nil
+	}
+
+	subtotal, err := GetShopItemsTotalPrice(shopItems)
+	if err != nil {
+		return models.OrderPricing{}, errors.Wrap(err, "GetShopItemsTotalPrice")
+	}
+	return models.OrderPricing{Subtotal: subtotal, Discounts: make([]*models.Discount, 0), Total: subtotal}, nil
+}
+
+// GetAppliedCoupon find applied coupon by code.
+func GetAppliedCoupon(order *models.Order, code string) *models.Coupon {
+	for _, coupon := range order.Coupons {
+		if coupon.Code == code {
+			return coupon
+		}
+	}
+	return nil
+}
+
+func lineTotal(shopItems []*models.ShopItem, shopItemID string, currency string) models.Money {
+	for _, item := range shopItems {
+		if item.ID == shopItemID {
+			return item.Price.Multiply(item.Quantity)
+		}
+	}
+	return models.Zero(currency)
+}
+
+// GetDeliveryRegion returns the tax region of a delivery address, its country code.
+func GetDeliveryRegion(deliveryAddress models.Address) string {
+	return strings.ToUpper(deliveryAddress.Country)
+}
@@ -0,0 +1,76 @@
+package aggregate_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wassef911/eventually/internal/delivery/aggregate"
+	"github.com/wassef911/eventually/internal/delivery/models"
+)
+
+func TestCalculateOrderPricing(t *testing.T) {
+	shopItems := []*models.ShopItem{
+		{ID: "item1", Quantity: 2, Price: models.NewMoney(1000, "USD")},
+		{ID: "item2", Quantity: 3, Price: models.NewMoney(500, "USD")},
+	}
+
+	tests := []struct {
+		name     string
+		coupons  []*models.Coupon
+		discount int64
+		total    int64
+	}{
+		{name: "no coupons", discount: 0, total: 4000},
+		{name: "percentage", coupons: []*models.Coupon{{Code: "P10", Type: models.PromotionPercentage, Percentage: 10}}, discount: 350, total: 3650},
+		{name: "line item percentage", coupons: []*models.Coupon{{Code: "P50", Type: models.PromotionPercentage, Percentage: 50, ShopItemID: "item1"}}, discount: 1000, total: 3000},
+		{name: "fixed amount", coupons: []*models.Coupon{{Code: "F5", Type: models.PromotionFixedAmount, Amount: models.NewMoney(500, "USD")}}, discount: 500, total: 3500},
+		{name: "buy two get one", coupons: []*models.Coupon{{Code: "B2G1", Type: models.PromotionBuyXGetY, BuyQuantity: 2, GetQuantity: 1, ShopItemID: "item2"}}, discount: 500, total: 3500},
+		{name: "free shipping", coupons: []*models.Coupon{{Code: "SHIP", Type: models.PromotionFreeShipping}}, discount: 500, total: 3500},
+		{name: "capped at total", coupons: []*models.Coupon{{Code: "F100", Type: models.PromotionFixedAmount, Amount: models.NewMoney(10000, "USD")}, {Code: "SHIP", Type: models.PromotionFreeShipping}}, discount: 4000, total: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pricing, err := aggregate.CalculateOrderPricing(shopItems, tt.coupons, models.NewMoney(500, "USD"), nil)
+			require.NoError(t, err)
+			assert.Equal(t, models.NewMoney(3500, "USD"), pricing.Subtotal)
+			assert.Equal(t, models.NewMoney(tt.discount, "USD"), pricing.DiscountTotal)
+			assert.Equal(t, models.NewMoney(tt.total, "USD"), pricing.Total)
+		})
+	}
+}
+
+func TestOrderAggregateCoupons(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	order := aggregate.NewOrderAggregateWithID("order-coupons")
+	shopItems := []*models.ShopItem{{ID: "item1", Title: "Item 1", Quantity: 1, Price: models.NewMoney(1000, "USD")}}
+	require.NoError(t, order.CreateOrder(ctx, shopItems, "", "test@example.com", testAddress, 200))
+	assert.Equal(t, models.NewMoney(1200, "USD"), order.Order.TotalPrice)
+
+	coupon := &models.Coupon{Code: "P10", Type: models.PromotionPercentage, Percentage: 10}
+	require.NoError(t, order.ApplyCoupon(ctx, coupon, now))
+	assert.Equal(t, models.NewMoney(1100, "USD"), order.Order.TotalPrice)
+
+	err := order.ApplyCoupon(ctx, coupon, now)
+	assert.True(t, errors.Is(err, aggregate.ErrCouponAlreadyApplied))
+
+	expired := &models.Coupon{Code: "OLD", Type: models.PromotionFreeShipping, ExpiresAt: now.Add(-time.Hour)}
+	err = order.ApplyCoupon(ctx, expired, now)
+	assert.True(t, errors.Is(err, aggregate.ErrCouponNotRedeemable))
+
+	require.NoError(t, order.AddItem(ctx, &models.ShopItem{ID: "item2", Title: "Item 2", Quantity: 1, Price: models.NewMoney(1000, "USD")}))
+	assert.Equal(t, models.NewMoney(2000, "USD"), order.Order.Subtotal)
+	assert.Equal(t, models.NewMoney(2000, "USD"), order.Order.TotalPrice)
+
+	require.NoError(t, order.RemoveCoupon(ctx, "P10"))
+	assert.Equal(t, models.NewMoney(2200, "USD"), order.Order.TotalPrice)
+
+	err = order.RemoveCoupon(ctx, "P10")
+	assert.True(t, errors.Is(err, aggregate.ErrCouponNotApplied))
+}
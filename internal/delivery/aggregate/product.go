@@ -0,0 +1,233 @@
+package aggregate
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/log"
+	"github.com/pkg/errors"
+
+	"github.com/wassef911/eventually/internal/delivery/events"
+	"github.com/wassef911/eventually/internal/delivery/models"
+	"github.com/wassef911/eventually/internal/infrastructure/es"
+	"github.com/wassef911/eventually/internal/infrastructure/es/store"
+	"github.com/wassef911/eventually/internal/infrastructure/tracing"
+)
+
+const ProductAggregateType es.AggregateType = "product"
+
+// ProductAggregate catalog entry, orders snapshot its title and price when an item is added.
+type ProductAggregate struct {
+	*es.AggregateBase
+	Product *models.Product
+}
+
+func NewProductAggregateWithID(id string) *ProductAggregate {
+	if id == "" {
+		return nil
+	}
+
+	aggregate := NewProductAggregate()
+	aggregate.SetID(id)
+	aggregate.Product.ProductID = id
+	return aggregate
+}
+
+func NewProductAggregate() *ProductAggregate {
+	productAggregate := &ProductAggregate{Product: models.NewProduct()}
+	base := es.NewAggregateBase(productAggregate.When)
+	base.SetType(ProductAggregateType)
+	productAggregate.AggregateBase = base
+	return productAggregate
+}
+
+// IsCreated reports whether the product stream exists.
+func (a *ProductAggregate) IsCreated() bool {
+	return a.GetVersion() >= 0
+}
+
+func (a *ProductAggregate) When(evt es.Event) error {
+
+	switch evt.GetEventType() {
+
+	case events.ProductCreated:
+		return a.onProductCreated(evt)
+	case events.ProductRepriced:
+		return a.onProductRepriced(evt)
+	case events.ProductDiscontinued:
+		return a.onProductDiscontinued(evt)
+
+	default:
+		return es.ErrInvalidEventType
+	}
+}
+
+func (a *ProductAggregate) onProductCreated(evt es.Event) error {
+	var eventData events.ProductCreatedEvent
+	if err := evt.GetJsonData(&eventData); err != nil {
+		return errors.Wrap(err, "GetJsonData")
+	}
+
+	a.Product.Title = eventData.Title
+	a.Product.Description = eventData.Description
+	a.Product.Price = eventData.Price
+	a.Product.TaxCategory = eventData.TaxCategory
+	a.Product.CreatedAt = eventData.CreatedAt
+	a.Product.UpdatedAt = eventData.CreatedAt
+	return nil
+}
+
+func (a *ProductAggregate) onProductRepriced(evt es.Event) error {
+	var eventData events.ProductRepricedEvent
+	if err := evt.GetJsonData(&eventData); err != nil {
+		return errors.Wrap(err, "GetJsonData")
+	}
+
+	a.Product.Price = eventData.Price
+	a.Product.UpdatedAt = eventData.RepricedAt
+	return nil
+}
+
+func (a *ProductAggregate) onProductDiscontinued(evt es.Event) error {
+	var eventData events.ProductDiscontinuedEvent
+	if err := evt.GetJsonData(&eventData); err != nil {
+		return errors.Wrap(err, "GetJsonData")
+	}
+
+	a.Product.Discontinued = true
+	a.Product.DiscontinuedAt = eventData.DiscontinuedAt
+	a.Product.UpdatedAt = eventData.DiscontinuedAt
+	return nil
+}
+
+func (a *ProductAggregate) CreateProduct(ctx context.Context, title string, description string, price models.Money, taxCategory string) error {
+	span, _ := opentracing.StartSpanFromContext(ctx, "ProductAggregate.CreateProduct")
+	defer span.Finish()
+	span.LogFields(log.String("AggregateID", a.GetID()))
+
+	if a.IsCreated() {
+		return ErrProductAlreadyExists
+	}
+	if strings.TrimSpace(title) == "" {
+		return ErrProductTitleRequired
+	}
+	if err := validateProductPrice(price); err != nil {
+		return err
+	}
+
+	event, err := events.NewProductCreatedEvent(a, title, description, price, taxCategory, time.Now().UTC())
+	if err != nil {
+		tracing.TraceErr(span, err)
+		return errors.Wrap(err, "NewProductCreatedEvent")
+	}
+
+	if err := event.SetMetadata(tracing.ExtractTextMapCarrier(span.Context())); err != nil {
+		tracing.TraceErr(span, err)
+		return errors.Wrap(err, "SetMetadata")
+	}
+
+	return a.Apply(event)
+}
+
+// Reprice orders already placed keep the price they were created with, repricing to the current price is a no-op.
+func (a *ProductAggregate) Reprice(ctx context.Context, price models.Money) error {
+	span, _ := opentracing.StartSpanFromContext(ctx, "ProductAggregate.Reprice")
+	defer span.Finish()
+	span.LogFields(log.String("AggregateID", a.GetID()), log.String("Price", price.String()))
+
+	if !a.IsCreated() {
+		return ErrProductNotFound
+	}
+	if a.Product.Discontinued {
+		return ErrProductDiscontinued
+	}
+	if err := validateProductPrice(price); err != nil {
+		return err
+	}
+	if price == a.Product.Price {
+		return nil
+	}
+
+	event, err := events.NewProductRepricedEvent(a, price, a.Product.Price, time.Now().UTC())
+	if err != nil {
+		tracing.TraceErr(span, err)
+		return errors.Wrap(err, "NewProductRepricedEvent")
+	}
+
+	if err := event.SetMetadata(tracing.ExtractTextMapCarrier(span.Context())); err != nil {
+		tracing.TraceErr(span, err)
+		return errors.Wrap(err, "SetMetadata")
+	}
+
+	return a.Apply(event)
+}
+
+// Discontinue the product can no longer be ordered, discontinuing twice is a no-op.
+func (a *ProductAggregate) Discontinue(ctx context.Context, reason string) error {
+	span, _ := opentracing.StartSpanFromContext(ctx, "ProductAggregate.Discontinue")
+	defer span.Finish()
+	span.LogFields(log.String("AggregateID", a.GetID()))
+
+	if !a.IsCreated() {
+		return ErrProductNotFound
+	}
+	if a.Product.Discontinued {
+		return nil
+	}
+
+	event, err := events.NewProductDiscontinuedEvent(a, reason, time.Now().UTC())
+	if err != nil {
+		tracing.TraceErr(span, err)
+		return errors.Wrap(err, "NewProductDiscontinuedEvent")
+	}
+
+	if err := event.SetMetadata(tracing.ExtractTextMapCarrier(span.Context())); err != nil {
+		tracing.TraceErr(span, err)
+		return errors.Wrap(err, "SetMetadata")
+	}
+
+	return a.Apply(event)
+}
+
+// ResolveShopItem snapshot of the product for an order line, unknown and discontinued products can't be ordered.
+func (a *ProductAggregate) ResolveShopItem(quantity uint64) (*models.ShopItem, error) {
+	if !a.IsCreated() {
+		return nil, errors.Wrapf(ErrProductNotFound, "product: {%s}", a.Product.ProductID)
+	}
+	if a.Product.Discontinued {
+		return nil, errors.Wrapf(ErrProductDiscontinued, "product: {%s}", a.Product.ProductID)
+	}
+	return a.Product.ShopItem(quantity), nil
+}
+
+func validateProductPrice(price models.Money) error {
+	if err := price.Validate(); err != nil {
+		return errors.Wrap(ErrInvalidProductPrice, err.Error())
+	}
+	if price.Amount == 0 {
+		return ErrInvalidProductPrice
+	}
+	return nil
+}
+
+// LoadProductAggregate an unknown id is returned as a product not created yet, see ProductAggregate.IsCreated.
+func LoadProductAggregate(ctx context.Context, eventStore store.AggregateStore, productID string) (*ProductAggregate, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "LoadProductAggregate")
+	defer span.Finish()
+	span.LogFields(log.String("ProductID", productID))
+
+	product := NewProductAggregateWithID(productID)
+	product.SetTenant(es.TenantFromContext(ctx))
+	if err := loadExistingAggregate(ctx, eventStore, product); err != nil {
+		return nil, err
+	}
+
+	return product, nil
+}
+
+func GetProductAggregateID(eventAggregateID string) string {
+	_, id := es.SplitTenantAggregateID(strings.TrimPrefix(eventAggregateID, string(ProductAggregateType)+"-"))
+	return id
+}
@@ -0,0 +1,59 @@
+package aggregate_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wassef911/eventually/internal/delivery/aggregate"
+	"github.com/wassef911/eventually/internal/delivery/models"
+)
+
+func TestProductCreateAndReprice(t *testing.T) {
+	ctx := context.Background()
+	product := aggregate.NewProductAggregateWithID("sku1")
+	assert.False(t, product.IsCreated())
+
+	err := product.Reprice(ctx, models.NewMoney(1500, "USD"))
+	assert.True(t, errors.Is(err, aggregate.ErrProductNotFound))
+
+	assert.True(t, errors.Is(product.CreateProduct(ctx, " ", "", models.NewMoney(1000, "USD"), ""), aggregate.ErrProductTitleRequired))
+	assert.True(t, errors.Is(product.CreateProduct(ctx, "Mug", "", models.NewMoney(0, "USD"), ""), aggregate.ErrInvalidProductPrice))
+	assert.True(t, errors.Is(product.CreateProduct(ctx, "Mug", "", models.NewMoney(1000, "usd"), ""), aggregate.ErrInvalidProductPrice))
+
+	require.NoError(t, product.CreateProduct(ctx, "Mug", "Ceramic mug", models.NewMoney(1000, "USD"), "standard"))
+	assert.True(t, product.IsCreated())
+	assert.True(t, errors.Is(product.CreateProduct(ctx, "Mug", "", models.NewMoney(1000, "USD"), ""), aggregate.ErrProductAlreadyExists))
+
+	events := len(product.GetUncommittedEvents())
+	require.NoError(t, product.Reprice(ctx, models.NewMoney(1000, "USD")))
+	assert.Len(t, product.GetUncommittedEvents(), events, "the price did not change")
+
+	require.NoError(t, product.Reprice(ctx, models.NewMoney(1200, "USD")))
+	assert.Equal(t, models.NewMoney(1200, "USD"), product.Product.Price)
+}
+
+func TestProductResolveShopItem(t *testing.T) {
+	ctx := context.Background()
+	product := aggregate.NewProductAggregateWithID("sku1")
+
+	_, err := product.ResolveShopItem(1)
+	assert.True(t, errors.Is(err, aggregate.ErrProductNotFound))
+
+	require.NoError(t, product.CreateProduct(ctx, "Mug", "Ceramic mug", models.NewMoney(1000, "USD"), "standard"))
+	shopItem, err := product.ResolveShopItem(2)
+	require.NoError(t, err)
+	assert.Equal(t, &models.ShopItem{ID: "sku1", Title: "Mug", Description: "Ceramic mug", Quantity: 2, Price: models.NewMoney(1000, "USD"), TaxCategory: "standard"}, shopItem)
+
+	require.NoError(t, product.Discontinue(ctx, "out of range"))
+	events := len(product.GetUncommittedEvents())
+	require.NoError(t, product.Discontinue(ctx, "out of range"))
+	assert.Len(t, product.GetUncommittedEvents(), events, "already discontinued")
+
+	_, err = product.ResolveShopItem(1)
+	assert.True(t, errors.Is(err, aggregate.ErrProductDiscontinued))
+	assert.True(t, errors.Is(product.Reprice(ctx, models.NewMoney(900, "USD")), aggregate.ErrProductDiscontinued))
+}
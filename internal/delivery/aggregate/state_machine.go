@@ -0,0 +1,97 @@
+package aggregate
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/wassef911/eventually/internal/delivery/models"
+)
+
+// OrderAction is a command that may be executed against an order in some statuses.
+type OrderAction string
+
+const (
+	ActionCreateOrder           OrderAction = "CREATE_ORDER"
+	ActionPayOrder              OrderAction = "PAY_ORDER"
+	ActionSubmitOrder           OrderAction = "SUBMIT_ORDER"
+	ActionUpdateShoppingCart    OrderAction = "UPDATE_SHOPPING_CART"
+	ActionManageCoupons         OrderAction = "MANAGE_COUPONS"
+	ActionChangeDeliveryAddress OrderAction = "CHANGE_DELIVERY_ADDRESS"
+	ActionCancelOrder           OrderAction = "CANCEL_ORDER"
+	ActionRejectOrder           OrderAction = "REJECT_ORDER"
+	ActionCompleteOrder         OrderAction = "COMPLETE_ORDER"
+	ActionRefundOrder           OrderAction = "REFUND_ORDER"
+	ActionFullyRefundOrder      OrderAction = "FULLY_REFUND_ORDER"
+	ActionManageReturn          OrderAction = "MANAGE_RETURN"
+	ActionReceiveReturn         OrderAction = "RECEIVE_RETURN"
+	ActionManageShipment        OrderAction = "MANAGE_SHIPMENT"
+	ActionRemindPayment         OrderAction = "REMIND_PAYMENT"
+)
+
+// OrderTransition allows Action from any of the From statuses, moving the order to To.
+// An empty To keeps the current status.
+type OrderTransition struct {
+	Action OrderAction
+	From   []models.OrderStatus
+	To     models.OrderStatus
+}
+
+// OrderTransitions is the order lifecycle, every command consults it before applying events.
+var OrderTransitions = []OrderTransition{
+	{Action: ActionCreateOrder, From: []models.OrderStatus{models.OrderStatusNew}, To: models.OrderStatusCreated},
+	{Action: ActionUpdateShoppingCart, From: []models.OrderStatus{models.OrderStatusCreated}},
+	{Action: ActionManageCoupons, From: []models.OrderStatus{models.OrderStatusCreated}},
+	{Action: ActionPayOrder, From: []models.OrderStatus{models.OrderStatusCreated}, To: models.OrderStatusPaid},
+	{Action: ActionRemindPayment, From: []models.OrderStatus{models.OrderStatusCreated}},
+	{Action: ActionSubmitOrder, From: []models.OrderStatus{models.OrderStatusPaid}, To: models.OrderStatusSubmitted},
+	{
+		Action: ActionChangeDeliveryAddress,
+		From:   []models.OrderStatus{models.OrderStatusCreated, models.OrderStatusPaid, models.OrderStatusSubmitted},
+	},
+	{
+		Action: ActionCancelOrder,
+		From:   []models.OrderStatus{models.OrderStatusCreated, models.OrderStatusPaid},
+		To:     models.OrderStatusCanceled,
+	},
+	{
+		Action: ActionRejectOrder,
+		From:   []models.OrderStatus{models.OrderStatusCreated, models.OrderStatusPaid},
+		To:     models.OrderStatusRejected,
+	},
+	{Action: ActionManageShipment, From: []models.OrderStatus{models.OrderStatusSubmitted}},
+	{Action: ActionCompleteOrder, From: []models.OrderStatus{models.OrderStatusSubmitted}, To: models.OrderStatusCompleted},
+	{Action: ActionManageReturn, From: []models.OrderStatus{models.OrderStatusCompleted}},
+	// an approved return is still received once the order was refunded in full meanwhile
+	{Action: ActionReceiveReturn, From: []models.OrderStatus{models.OrderStatusCompleted, models.OrderStatusRefunded}},
+	{
+		Action: ActionRefundOrder,
+		From:   []models.OrderStatus{models.OrderStatusPaid, models.OrderStatusSubmitted, models.OrderStatusCompleted, models.OrderStatusCanceled, models.OrderStatusRejected},
+	},
+	{
+		Action: ActionFullyRefundOrder,
+		From:   []models.OrderStatus{models.OrderStatusPaid, models.OrderStatusSubmitted, models.OrderStatusCompleted, models.OrderStatusCanceled, models.OrderStatusRejected},
+		To:     models.OrderStatusRefunded,
+	},
+}
+
+// GetOrderTransition returns the transition for the action, or nil if the action is not allowed from status.
+func GetOrderTransition(status models.OrderStatus, action OrderAction) *OrderTransition {
+	for i := range OrderTransitions {
+		if OrderTransitions[i].Action != action {
+			continue
+		}
+		for _, from := range OrderTransitions[i].From {
+			if from == status {
+				return &OrderTransitions[i]
+			}
+		}
+	}
+	return nil
+}
+
+// CanTransition checks the action against the current order status.
+func (a *OrderAggregate) CanTransition(action OrderAction) error {
+	if GetOrderTransition(a.Order.Status, action) == nil {
+		return errors.Wrapf(ErrInvalidStatusTransition, "status: {%s}, action: {%s}", a.Order.Status, action)
+	}
+	return nil
+}
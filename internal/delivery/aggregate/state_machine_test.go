@@ -0,0 +1,85 @@
+package aggregate_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wassef911/eventually/internal/delivery/aggregate"
+	"github.com/wassef911/eventually/internal/delivery/models"
+)
+
+func TestGetOrderTransition(t *testing.T) {
+	transition := aggregate.GetOrderTransition(models.OrderStatusCreated, aggregate.ActionPayOrder)
+	require.NotNil(t, transition)
+	assert.Equal(t, models.OrderStatusPaid, transition.To)
+
+	assert.Nil(t, aggregate.GetOrderTransition(models.OrderStatusSubmitted, aggregate.ActionCancelOrder))
+	assert.Nil(t, aggregate.GetOrderTransition(models.OrderStatusNew, aggregate.ActionPayOrder))
+}
+
+func TestOrderAggregateStatusTransitions(t *testing.T) {
+	ctx := context.Background()
+	order := aggregate.NewOrderAggregateWithID("order-test")
+	assert.Equal(t, models.OrderStatusNew, order.Order.Status)
+
+	shopItems := []*models.ShopItem{{ID: "item1", Title: "Item 1", Quantity: 1, Price: models.NewMoney(1000, "USD")}}
+	require.NoError(t, order.CreateOrder(ctx, shopItems, "", "test@example.com", testAddress, 0))
+	assert.Equal(t, models.OrderStatusCreated, order.Order.Status)
+
+	err := order.SubmitOrder(ctx)
+	assert.True(t, errors.Is(err, aggregate.ErrInvalidStatusTransition))
+
+	require.NoError(t, order.PayOrder(ctx, models.Payment{PaymentID: "pay123", Timestamp: time.Now()}))
+	assert.Equal(t, models.OrderStatusPaid, order.Order.Status)
+
+	require.NoError(t, order.SubmitOrder(ctx))
+	assert.Equal(t, models.OrderStatusSubmitted, order.Order.Status)
+
+	err = order.CancelOrder(ctx, "changed my mind")
+	assert.True(t, errors.Is(err, aggregate.ErrInvalidStatusTransition))
+
+	require.NoError(t, order.CompleteOrder(ctx, time.Now()))
+	assert.Equal(t, models.OrderStatusCompleted, order.Order.Status)
+
+	require.NoError(t, order.RefundOrder(ctx, "refund1", nil, nil, "damaged"))
+	assert.Equal(t, models.OrderStatusRefunded, order.Order.Status)
+}
+
+func TestOrderAggregateRejectOrder(t *testing.T) {
+	ctx := context.Background()
+	order := aggregate.NewOrderAggregateWithID("order-test")
+	shopItems := []*models.ShopItem{{ID: "item1", Title: "Item 1", Quantity: 1, Price: models.NewMoney(1000, "USD")}}
+	require.NoError(t, order.CreateOrder(ctx, shopItems, "", "test@example.com", testAddress, 0))
+
+	err := order.RejectOrder(ctx, "")
+	assert.True(t, errors.Is(err, aggregate.ErrRejectReasonRequired))
+
+	require.NoError(t, order.RejectOrder(ctx, "out of stock"))
+	assert.Equal(t, models.OrderStatusRejected, order.Order.Status)
+	assert.Equal(t, "out of stock", order.Order.RejectReason)
+
+	err = order.CancelOrder(ctx, "changed my mind")
+	assert.True(t, errors.Is(err, aggregate.ErrInvalidStatusTransition))
+}
+
+func TestOrderAggregateRemindPayment(t *testing.T) {
+	ctx := context.Background()
+	order := aggregate.NewOrderAggregateWithID("order-test")
+	shopItems := []*models.ShopItem{{ID: "item1", Title: "Item 1", Quantity: 1, Price: models.NewMoney(1000, "USD")}}
+	require.NoError(t, order.CreateOrder(ctx, shopItems, "", "test@example.com", testAddress, 0))
+
+	expiresAt := time.Now().Add(time.Hour)
+	require.NoError(t, order.RemindPayment(ctx, 1, expiresAt))
+	require.NoError(t, order.RemindPayment(ctx, 1, expiresAt))
+	assert.Equal(t, 1, order.Order.PaymentReminders)
+	assert.Len(t, order.GetUncommittedEvents(), 2, "a reminder that was already sent is a no-op")
+
+	require.NoError(t, order.PayOrder(ctx, models.Payment{PaymentID: "payment1", Timestamp: time.Now()}))
+	err := order.RemindPayment(ctx, 2, expiresAt)
+	assert.True(t, errors.Is(err, aggregate.ErrInvalidStatusTransition))
+}
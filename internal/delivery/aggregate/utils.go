@@ -2,6 +2,7 @@ package aggregate
 
 import (
 	"context"
+	"slices"
 	"strings"
 
 	"github.com/EventStore/EventStore-Client-Go/esdb"
@@ -14,17 +15,363 @@ import (
 	"github.com/wassef911/eventually/internal/infrastructure/es/store"
 )
 
-func GetShopItemsTotalPrice(shopItems []*models.ShopItem) float64 {
-	var totalPrice float64 = 0
+// GetShopItemsTotalPrice sums price × quantity of all items, all items must share the same currency.
+func GetShopItemsTotalPrice(shopItems []*models.ShopItem) (models.Money, error) {
+	if len(shopItems) == 0 {
+		return models.Money{}, nil
+	}
+
+	totalPrice := models.Zero(shopItems[0].Price.Currency)
+	for _, item := range shopItems {
+		subtotal, err := totalPrice.Add(item.Price.Multiply(item.Quantity))
+		if err != nil {
+			return models.Money{}, err
+		}
+		totalPrice = subtotal
+	}
+	return totalPrice, nil
+}
+
+// GetShopItemsCurrency returns the currency of the cart, the default currency for an empty cart.
+func GetShopItemsCurrency(shopItems []*models.ShopItem) string {
+	if len(shopItems) == 0 || shopItems[0].Price.Currency == "" {
+		return models.DefaultCurrency
+	}
+	return shopItems[0].Price.Currency
+}
+
+// ValidateShopItemsPrices checks every item has a valid price and that all items share one currency.
+func ValidateShopItemsPrices(shopItems []*models.ShopItem) error {
 	for _, item := range shopItems {
-		totalPrice += item.Price * float64(item.Quantity)
+		if err := item.Price.Validate(); err != nil {
+			return errors.Wrapf(err, "shop item: {%s}", item.ID)
+		}
+		if !item.Price.SameCurrency(shopItems[0].Price) {
+			return ErrOrderCurrencyMismatch
+		}
+	}
+	return nil
+}
+
+// ValidateShopItems checks ids, quantities, the number of line items and prices.
+func ValidateShopItems(shopItems []*models.ShopItem) error {
+	if len(shopItems) > MaxShopItems {
+		return ErrTooManyShopItems
+	}
+
+	ids := make(map[string]struct{}, len(shopItems))
+	for _, item := range shopItems {
+		if err := ValidateShopItem(item); err != nil {
+			return err
+		}
+		if _, ok := ids[item.ID]; ok {
+			return errors.Wrapf(ErrDuplicateShopItem, "shop item: {%s}", item.ID)
+		}
+		ids[item.ID] = struct{}{}
+	}
+
+	return ValidateShopItemsPrices(shopItems)
+}
+
+func ValidateShopItem(item *models.ShopItem) error {
+	if item == nil || item.ID == "" {
+		return ErrShopItemIDRequired
+	}
+	if item.Quantity == 0 {
+		return errors.Wrapf(ErrInvalidShopItemQuantity, "shop item: {%s}", item.ID)
+	}
+	return nil
+}
+
+// GetOrderRefundedAmount returns the amount refunded so far in the order currency, pending refunds included.
+func GetOrderRefundedAmount(order *models.Order) (models.Money, error) {
+	refunded := getOrderSettledRefundAmount(order)
+	for _, refund := range order.PendingRefunds {
+		total, err := refunded.Add(refund.Amount)
+		if err != nil {
+			return models.Money{}, err
+		}
+		refunded = total
 	}
-	return totalPrice
+	return refunded, nil
 }
 
-// GetOrderAggregateID get order aggregate id for eventstoredb
+// getOrderSettledRefundAmount the amount the payment provider confirmed refunding.
+func getOrderSettledRefundAmount(order *models.Order) models.Money {
+	if order.RefundedAmount.Currency == "" {
+		return models.Zero(order.TotalPrice.Currency)
+	}
+	return order.RefundedAmount
+}
+
+// GetRefundedQuantity returns how many units of the shop item were already refunded or are being refunded.
+func GetRefundedQuantity(order *models.Order, shopItemID string) uint64 {
+	var quantity uint64
+	for _, refunds := range [][]*models.Refund{order.Refunds, order.PendingRefunds} {
+		for _, refund := range refunds {
+			for _, item := range refund.Items {
+				if item.ShopItemID == shopItemID {
+					quantity += item.Quantity
+				}
+			}
+		}
+	}
+	return quantity
+}
+
+// GetPendingRefund find a refund waiting for the payment provider by id.
+func GetPendingRefund(order *models.Order, refundID string) *models.Refund {
+	for _, refund := range order.PendingRefunds {
+		if refund.RefundID == refundID {
+			return refund
+		}
+	}
+	return nil
+}
+
+func removePendingRefund(refunds []*models.Refund, refundID string) []*models.Refund {
+	return slices.DeleteFunc(refunds, func(refund *models.Refund) bool {
+		return refund.RefundID == refundID
+	})
+}
+
+// GetRefundAmount computes the amount of a refund request: the price of the given line items,
+// the given partial amount, or the whole refundable amount when neither is given.
+func GetRefundAmount(order *models.Order, items []*models.RefundItem, amount *models.Money, refundable models.Money) (models.Money, error) {
+	switch {
+	case len(items) > 0 && amount != nil:
+		return models.Money{}, ErrInvalidRefundRequest
+	case amount != nil:
+		if amount.Amount <= 0 || !amount.SameCurrency(order.TotalPrice) {
+			return models.Money{}, ErrInvalidRefundAmount
+		}
+		return *amount, nil
+	case len(items) == 0:
+		return refundable, nil
+	}
+
+	requested := make(map[string]uint64, len(items))
+	refundAmount := models.Zero(order.TotalPrice.Currency)
+	for _, item := range items {
+		shopItem := getShopItem(order, item.ShopItemID)
+		if shopItem == nil {
+			return models.Money{}, errors.Wrapf(ErrRefundItemNotFound, "shop item: {%s}", item.ShopItemID)
+		}
+		if item.Quantity == 0 {
+			return models.Money{}, ErrInvalidRefundAmount
+		}
+
+		requested[item.ShopItemID] += item.Quantity
+		if requested[item.ShopItemID]+GetRefundedQuantity(order, item.ShopItemID) > shopItem.Quantity {
+			return models.Money{}, errors.Wrapf(ErrRefundItemQuantityExceeded, "shop item: {%s}", item.ShopItemID)
+		}
+
+		subtotal, err := refundAmount.Add(shopItem.Price.Multiply(item.Quantity))
+		if err != nil {
+			return models.Money{}, err
+		}
+		refundAmount = subtotal
+	}
+
+	return refundAmount, nil
+}
+
+func getShopItem(order *models.Order, shopItemID string) *models.ShopItem {
+	for _, item := range order.ShopItems {
+		if item.ID == shopItemID {
+			return item
+		}
+	}
+	return nil
+}
+
+// GetOrderReturn find order return by id.
+func GetOrderReturn(order *models.Order, returnID string) *models.OrderReturn {
+	for _, orderReturn := range order.Returns {
+		if orderReturn.ReturnID == returnID {
+			return orderReturn
+		}
+	}
+	return nil
+}
+
+// GetPaymentAttempt finds the attempt by attempt id or, for provider callbacks, by payment id.
+func GetPaymentAttempt(order *models.Order, attemptID string, paymentID string) *models.PaymentAttempt {
+	for _, attempt := range order.PaymentAttempts {
+		if attemptID != "" && attempt.AttemptID == attemptID {
+			return attempt
+		}
+		if attemptID == "" && paymentID != "" && attempt.PaymentID == paymentID {
+			return attempt
+		}
+	}
+	return nil
+}
+
+// GetPendingReturnQuantity returns how many units of the shop item are in requested or approved returns.
+func GetPendingReturnQuantity(order *models.Order, shopItemID string) uint64 {
+	var quantity uint64
+	for _, orderReturn := range order.Returns {
+		if orderReturn.Status != models.ReturnRequested && orderReturn.Status != models.ReturnApproved {
+			continue
+		}
+		for _, item := range orderReturn.Items {
+			if item.ShopItemID == shopItemID {
+				quantity += item.Quantity
+			}
+		}
+	}
+	return quantity
+}
+
+// GetReturnRefund items and amount refunded when the return is received: the returned units that were not refunded
+// meanwhile, for no more than what is left to refund after earlier refunds. Capped is set when the units are worth
+// more than what is left, the return is then refunded by amount.
+func GetReturnRefund(order *models.Order, orderReturn *models.OrderReturn) ([]*models.RefundItem, models.Money, bool, error) {
+	refunded, err := GetOrderRefundedAmount(order)
+	if err != nil {
+		return nil, models.Money{}, false, err
+	}
+	refundable, err := order.TotalPrice.Sub(refunded)
+	if err != nil {
+		return nil, models.Money{}, false, err
+	}
+
+	items := make([]*models.RefundItem, 0, len(orderReturn.Items))
+	amount := models.Zero(order.TotalPrice.Currency)
+	for _, item := range orderReturn.Items {
+		shopItem := getShopItem(order, item.ShopItemID)
+		if shopItem == nil {
+			return nil, models.Money{}, false, errors.Wrapf(ErrRefundItemNotFound, "shop item: {%s}", item.ShopItemID)
+		}
+
+		quantity := item.Quantity
+		if refunded := GetRefundedQuantity(order, item.ShopItemID); refunded+quantity > shopItem.Quantity {
+			quantity = shopItem.Quantity - min(refunded, shopItem.Quantity)
+		}
+		if quantity == 0 {
+			continue
+		}
+
+		subtotal, err := amount.Add(shopItem.Price.Multiply(quantity))
+		if err != nil {
+			return nil, models.Money{}, false, err
+		}
+		amount = subtotal
+		items = append(items, &models.RefundItem{ShopItemID: item.ShopItemID, Quantity: quantity})
+	}
+
+	if amount.GreaterThan(refundable) {
+		return items, refundable, true, nil
+	}
+	return items, amount, false, nil
+}
+
+// ValidateReturnItems checks returned items belong to the order and were neither refunded nor already being returned.
+func ValidateReturnItems(order *models.Order, items []*models.ReturnItem) error {
+	if len(items) == 0 {
+		return ErrReturnItemsRequired
+	}
+
+	requested := make(map[string]uint64, len(items))
+	for _, item := range items {
+		shopItem := getShopItem(order, item.ShopItemID)
+		if shopItem == nil {
+			return errors.Wrapf(ErrReturnItemNotFound, "shop item: {%s}", item.ShopItemID)
+		}
+		if item.Quantity == 0 {
+			return errors.Wrapf(ErrReturnItemsRequired, "shop item: {%s}", item.ShopItemID)
+		}
+
+		requested[item.ShopItemID] += item.Quantity
+		alreadyUsed := GetRefundedQuantity(order, item.ShopItemID) + GetPendingReturnQuantity(order, item.ShopItemID)
+		if requested[item.ShopItemID]+alreadyUsed > shopItem.Quantity {
+			return errors.Wrapf(ErrReturnItemQuantityExceeded, "shop item: {%s}", item.ShopItemID)
+		}
+	}
+	return nil
+}
+
+// GetShipment find order shipment by id.
+func GetShipment(order *models.Order, shipmentID string) *models.Shipment {
+	for _, shipment := range order.Shipments {
+		if shipment.ShipmentID == shipmentID {
+			return shipment
+		}
+	}
+	return nil
+}
+
+// GetShippedQuantity returns how many units of the shop item are in shipments, when delivered is set only delivered shipments count.
+func GetShippedQuantity(order *models.Order, shopItemID string, delivered bool) uint64 {
+	var quantity uint64
+	for _, shipment := range order.Shipments {
+		if delivered && shipment.Status != models.ShipmentDelivered {
+			continue
+		}
+		for _, item := range shipment.Items {
+			if item.ShopItemID == shopItemID {
+				quantity += item.Quantity
+			}
+		}
+	}
+	return quantity
+}
+
+// ValidateShipmentItems checks shipped items belong to the order and are not already in another shipment.
+func ValidateShipmentItems(order *models.Order, items []*models.ShipmentItem) error {
+	if len(items) == 0 {
+		return ErrShipmentItemsRequired
+	}
+
+	requested := make(map[string]uint64, len(items))
+	for _, item := range items {
+		shopItem := getShopItem(order, item.ShopItemID)
+		if shopItem == nil {
+			return errors.Wrapf(ErrShipmentItemNotFound, "shop item: {%s}", item.ShopItemID)
+		}
+		if item.Quantity == 0 {
+			return errors.Wrapf(ErrShipmentItemsRequired, "shop item: {%s}", item.ShopItemID)
+		}
+
+		requested[item.ShopItemID] += item.Quantity
+		if requested[item.ShopItemID]+GetShippedQuantity(order, item.ShopItemID, false) > shopItem.Quantity {
+			return errors.Wrapf(ErrShipmentItemQuantityExceeded, "shop item: {%s}", item.ShopItemID)
+		}
+	}
+	return nil
+}
+
+// IsOrderFullyDelivered reports whether every ordered unit is in a delivered shipment.
+func IsOrderFullyDelivered(order *models.Order) bool {
+	if len(order.ShopItems) == 0 {
+		return false
+	}
+	for _, item := range order.ShopItems {
+		if GetShippedQuantity(order, item.ID, true) < item.Quantity {
+			return false
+		}
+	}
+	return true
+}
+
+// GetOrderAggregateID get order aggregate id for eventstoredb, the tenant of the stream is dropped
 func GetOrderAggregateID(eventAggregateID string) string {
-	return strings.ReplaceAll(eventAggregateID, "order-", "")
+	_, id := es.SplitTenantAggregateID(strings.TrimPrefix(eventAggregateID, string(OrderAggregateType)+"-"))
+	return id
+}
+
+// loadExistingAggregate loads the aggregate events, a missing stream leaves it at its start version.
+func loadExistingAggregate(ctx context.Context, eventStore store.AggregateStore, aggregate es.Aggregate) error {
+	err := eventStore.Exists(ctx, aggregate.GetID())
+	if errors.Is(err, esdb.ErrStreamNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	return eventStore.Load(ctx, aggregate)
 }
 
 func IsAggregateNotFound(aggregate es.Aggregate) bool {
@@ -37,6 +384,7 @@ func LoadOrderAggregate(ctx context.Context, eventStore store.AggregateStore, ag
 	span.LogFields(log.String("AggregateID", aggregateID))
 
 	order := NewOrderAggregateWithID(aggregateID)
+	order.SetTenant(es.TenantFromContext(ctx))
 
 	err := eventStore.Exists(ctx, order.GetID())
 	if err != nil && !errors.Is(err, esdb.ErrStreamNotFound) {
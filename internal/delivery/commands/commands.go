@@ -4,27 +4,43 @@ import (
 	"time"
 
 	"github.com/wassef911/eventually/internal/delivery/models"
+	"github.com/wassef911/eventually/internal/delivery/payment"
 	"github.com/wassef911/eventually/internal/infrastructure/es"
 )
 
+// CreateOrderCommand orders of a registered customer default to the customer email,
+// items are resolved from the product catalog.
 type CreateOrderCommand struct {
 	es.BaseCommand
-	ShopItems       []*models.ShopItem `json:"shopItems" bson:"shopItems,omitempty" validate:"required"`
-	AccountEmail    string             `json:"accountEmail" bson:"accountEmail,omitempty" validate:"required,email"`
-	DeliveryAddress string             `json:"deliveryAddress" bson:"deliveryAddress,omitempty" validate:"required"`
+	Items           []*models.OrderItem `json:"items" bson:"items,omitempty" validate:"required,min=1,dive,required"`
+	CustomerID      string              `json:"customerId,omitempty" bson:"customerId,omitempty"`
+	AccountEmail    string              `json:"accountEmail" bson:"accountEmail,omitempty" validate:"required_without=CustomerID,omitempty,email"`
+	DeliveryAddress models.Address      `json:"deliveryAddress" bson:"deliveryAddress,omitempty"`
 }
 
-func NewCreateOrderCommand(aggregateID string, shopItems []*models.ShopItem, accountEmail, deliveryAddress string) *CreateOrderCommand {
-	return &CreateOrderCommand{BaseCommand: es.NewBaseCommand(aggregateID), ShopItems: shopItems, AccountEmail: accountEmail, DeliveryAddress: deliveryAddress}
+func NewCreateOrderCommand(aggregateID string, items []*models.OrderItem, customerID string, accountEmail string, deliveryAddress models.Address) *CreateOrderCommand {
+	return &CreateOrderCommand{BaseCommand: es.NewBaseCommand(aggregateID), Items: items, CustomerID: customerID, AccountEmail: accountEmail, DeliveryAddress: deliveryAddress}
 }
 
 type PayOrderCommand struct {
-	models.Payment
 	es.BaseCommand
+	PaymentToken string `json:"paymentToken" validate:"required"`
 }
 
-func NewPayOrderCommand(payment models.Payment, aggregateID string) *PayOrderCommand {
-	return &PayOrderCommand{Payment: payment, BaseCommand: es.NewBaseCommand(aggregateID)}
+func NewPayOrderCommand(aggregateID string, paymentToken string) *PayOrderCommand {
+	return &PayOrderCommand{BaseCommand: es.NewBaseCommand(aggregateID), PaymentToken: paymentToken}
+}
+
+// PaymentWebhookCommand asynchronous outcome of a pending payment reported by the provider.
+type PaymentWebhookCommand struct {
+	es.BaseCommand
+	Type      payment.WebhookEventType `json:"type" validate:"required"`
+	PaymentID string                   `json:"paymentId" validate:"required"`
+	Reason    string                   `json:"reason,omitempty"`
+}
+
+func NewPaymentWebhookCommand(aggregateID string, eventType payment.WebhookEventType, paymentID string, reason string) *PaymentWebhookCommand {
+	return &PaymentWebhookCommand{BaseCommand: es.NewBaseCommand(aggregateID), Type: eventType, PaymentID: paymentID, Reason: reason}
 }
 
 type SubmitOrderCommand struct {
@@ -37,22 +53,43 @@ func NewSubmitOrderCommand(aggregateID string) *SubmitOrderCommand {
 
 type UpdateShoppingCartCommand struct {
 	es.BaseCommand
-	ShopItems []*models.ShopItem `json:"shopItems" bson:"shopItems,omitempty" validate:"required"`
+	Items []*models.OrderItem `json:"items" bson:"items,omitempty" validate:"required,min=1,dive,required"`
 }
 
-func NewUpdateShoppingCartCommand(aggregateID string, shopItems []*models.ShopItem) *UpdateShoppingCartCommand {
-	return &UpdateShoppingCartCommand{BaseCommand: es.NewBaseCommand(aggregateID), ShopItems: shopItems}
+func NewUpdateShoppingCartCommand(aggregateID string, items []*models.OrderItem) *UpdateShoppingCartCommand {
+	return &UpdateShoppingCartCommand{BaseCommand: es.NewBaseCommand(aggregateID), Items: items}
 }
 
 type CancelOrderCommand struct {
 	es.BaseCommand
 	CancelReason string `json:"cancelReason" validate:"required"`
+	// UnpaidOnly the order is kept when it was paid in the meantime.
+	UnpaidOnly bool `json:"unpaidOnly,omitempty"`
 }
 
 func NewCancelOrderCommand(aggregateID string, cancelReason string) *CancelOrderCommand {
 	return &CancelOrderCommand{BaseCommand: es.NewBaseCommand(aggregateID), CancelReason: cancelReason}
 }
 
+type RejectOrderCommand struct {
+	es.BaseCommand
+	RejectReason string `json:"rejectReason" validate:"required"`
+}
+
+func NewRejectOrderCommand(aggregateID string, rejectReason string) *RejectOrderCommand {
+	return &RejectOrderCommand{BaseCommand: es.NewBaseCommand(aggregateID), RejectReason: rejectReason}
+}
+
+type RemindPaymentCommand struct {
+	es.BaseCommand
+	Reminder  int       `json:"reminder" validate:"required,gt=0"`
+	ExpiresAt time.Time `json:"expiresAt" validate:"required"`
+}
+
+func NewRemindPaymentCommand(aggregateID string, reminder int, expiresAt time.Time) *RemindPaymentCommand {
+	return &RemindPaymentCommand{BaseCommand: es.NewBaseCommand(aggregateID), Reminder: reminder, ExpiresAt: expiresAt}
+}
+
 type CompleteOrderCommand struct {
 	es.BaseCommand
 	DeliveryTimestamp time.Time `json:"deliveryTimestamp" validate:"required"`
@@ -64,9 +101,262 @@ func NewCompleteOrderCommand(aggregateID string, deliveryTimestamp time.Time) *C
 
 type ChangeDeliveryAddressCommand struct {
 	es.BaseCommand
-	DeliveryAddress string `json:"deliveryAddress" bson:"deliveryAddress,omitempty" validate:"required"`
+	DeliveryAddress models.Address `json:"deliveryAddress" bson:"deliveryAddress,omitempty"`
 }
 
-func NewChangeDeliveryAddressCommand(aggregateID string, deliveryAddress string) *ChangeDeliveryAddressCommand {
+func NewChangeDeliveryAddressCommand(aggregateID string, deliveryAddress models.Address) *ChangeDeliveryAddressCommand {
 	return &ChangeDeliveryAddressCommand{BaseCommand: es.NewBaseCommand(aggregateID), DeliveryAddress: deliveryAddress}
 }
+
+type RefundOrderCommand struct {
+	es.BaseCommand
+	RefundID string               `json:"refundId" validate:"required"`
+	Items    []*models.RefundItem `json:"items,omitempty" validate:"omitempty,dive"`
+	Amount   *models.Money        `json:"amount,omitempty"`
+	Reason   string               `json:"reason" validate:"required"`
+}
+
+func NewRefundOrderCommand(aggregateID string, refundID string, items []*models.RefundItem, amount *models.Money, reason string) *RefundOrderCommand {
+	return &RefundOrderCommand{BaseCommand: es.NewBaseCommand(aggregateID), RefundID: refundID, Items: items, Amount: amount, Reason: reason}
+}
+
+type RequestReturnCommand struct {
+	es.BaseCommand
+	ReturnID string               `json:"returnId" validate:"required"`
+	Items    []*models.ReturnItem `json:"items" validate:"required,dive"`
+	Reason   string               `json:"reason" validate:"required"`
+}
+
+func NewRequestReturnCommand(aggregateID string, returnID string, items []*models.ReturnItem, reason string) *RequestReturnCommand {
+	return &RequestReturnCommand{BaseCommand: es.NewBaseCommand(aggregateID), ReturnID: returnID, Items: items, Reason: reason}
+}
+
+type ApproveReturnCommand struct {
+	es.BaseCommand
+	ReturnID string `json:"returnId" validate:"required"`
+}
+
+func NewApproveReturnCommand(aggregateID string, returnID string) *ApproveReturnCommand {
+	return &ApproveReturnCommand{BaseCommand: es.NewBaseCommand(aggregateID), ReturnID: returnID}
+}
+
+type RejectReturnCommand struct {
+	es.BaseCommand
+	ReturnID     string `json:"returnId" validate:"required"`
+	RejectReason string `json:"rejectReason" validate:"required"`
+}
+
+func NewRejectReturnCommand(aggregateID string, returnID string, rejectReason string) *RejectReturnCommand {
+	return &RejectReturnCommand{BaseCommand: es.NewBaseCommand(aggregateID), ReturnID: returnID, RejectReason: rejectReason}
+}
+
+type ReceiveReturnCommand struct {
+	es.BaseCommand
+	ReturnID string `json:"returnId" validate:"required"`
+	RefundID string `json:"refundId" validate:"required"`
+}
+
+func NewReceiveReturnCommand(aggregateID string, returnID string, refundID string) *ReceiveReturnCommand {
+	return &ReceiveReturnCommand{BaseCommand: es.NewBaseCommand(aggregateID), ReturnID: returnID, RefundID: refundID}
+}
+
+type CreateShipmentCommand struct {
+	es.BaseCommand
+	ShipmentID string                 `json:"shipmentId" validate:"required"`
+	Items      []*models.ShipmentItem `json:"items" validate:"required,dive"`
+}
+
+func NewCreateShipmentCommand(aggregateID string, shipmentID string, items []*models.ShipmentItem) *CreateShipmentCommand {
+	return &CreateShipmentCommand{BaseCommand: es.NewBaseCommand(aggregateID), ShipmentID: shipmentID, Items: items}
+}
+
+type PackShipmentCommand struct {
+	es.BaseCommand
+	ShipmentID string `json:"shipmentId" validate:"required"`
+}
+
+func NewPackShipmentCommand(aggregateID string, shipmentID string) *PackShipmentCommand {
+	return &PackShipmentCommand{BaseCommand: es.NewBaseCommand(aggregateID), ShipmentID: shipmentID}
+}
+
+type DispatchShipmentCommand struct {
+	es.BaseCommand
+	ShipmentID     string `json:"shipmentId" validate:"required"`
+	Carrier        string `json:"carrier" validate:"required"`
+	TrackingNumber string `json:"trackingNumber" validate:"required"`
+}
+
+func NewDispatchShipmentCommand(aggregateID string, shipmentID, carrier, trackingNumber string) *DispatchShipmentCommand {
+	return &DispatchShipmentCommand{BaseCommand: es.NewBaseCommand(aggregateID), ShipmentID: shipmentID, Carrier: carrier, TrackingNumber: trackingNumber}
+}
+
+type DeliverShipmentCommand struct {
+	es.BaseCommand
+	ShipmentID  string    `json:"shipmentId" validate:"required"`
+	DeliveredAt time.Time `json:"deliveredAt" validate:"required"`
+}
+
+func NewDeliverShipmentCommand(aggregateID string, shipmentID string, deliveredAt time.Time) *DeliverShipmentCommand {
+	return &DeliverShipmentCommand{BaseCommand: es.NewBaseCommand(aggregateID), ShipmentID: shipmentID, DeliveredAt: deliveredAt}
+}
+
+type AddItemCommand struct {
+	es.BaseCommand
+	Item *models.OrderItem `json:"item" validate:"required"`
+}
+
+func NewAddItemCommand(aggregateID string, item *models.OrderItem) *AddItemCommand {
+	return &AddItemCommand{BaseCommand: es.NewBaseCommand(aggregateID), Item: item}
+}
+
+type RemoveItemCommand struct {
+	es.BaseCommand
+	ShopItemID string `json:"shopItemId" validate:"required"`
+}
+
+func NewRemoveItemCommand(aggregateID string, shopItemID string) *RemoveItemCommand {
+	return &RemoveItemCommand{BaseCommand: es.NewBaseCommand(aggregateID), ShopItemID: shopItemID}
+}
+
+type ChangeItemQuantityCommand struct {
+	es.BaseCommand
+	ShopItemID string `json:"shopItemId" validate:"required"`
+	Quantity   uint64 `json:"quantity" validate:"required,gt=0"`
+}
+
+func NewChangeItemQuantityCommand(aggregateID string, shopItemID string, quantity uint64) *ChangeItemQuantityCommand {
+	return &ChangeItemQuantityCommand{BaseCommand: es.NewBaseCommand(aggregateID), ShopItemID: shopItemID, Quantity: quantity}
+}
+
+type ApplyCouponCommand struct {
+	es.BaseCommand
+	Code string `json:"code" validate:"required"`
+}
+
+func NewApplyCouponCommand(aggregateID string, code string) *ApplyCouponCommand {
+	return &ApplyCouponCommand{BaseCommand: es.NewBaseCommand(aggregateID), Code: code}
+}
+
+type RemoveCouponCommand struct {
+	es.BaseCommand
+	Code string `json:"code" validate:"required"`
+}
+
+func NewRemoveCouponCommand(aggregateID string, code string) *RemoveCouponCommand {
+	return &RemoveCouponCommand{BaseCommand: es.NewBaseCommand(aggregateID), Code: code}
+}
+
+// AddStockCommand inventory commands use the SKU as aggregate id.
+type AddStockCommand struct {
+	es.BaseCommand
+	Quantity uint64 `json:"quantity" validate:"required,gt=0"`
+}
+
+func NewAddStockCommand(sku string, quantity uint64) *AddStockCommand {
+	return &AddStockCommand{BaseCommand: es.NewBaseCommand(sku), Quantity: quantity}
+}
+
+type ReserveStockCommand struct {
+	es.BaseCommand
+	OrderID  string `json:"orderId" validate:"required"`
+	Quantity uint64 `json:"quantity"`
+}
+
+func NewReserveStockCommand(sku string, orderID string, quantity uint64) *ReserveStockCommand {
+	return &ReserveStockCommand{BaseCommand: es.NewBaseCommand(sku), OrderID: orderID, Quantity: quantity}
+}
+
+type ReleaseStockCommand struct {
+	es.BaseCommand
+	OrderID string `json:"orderId" validate:"required"`
+}
+
+func NewReleaseStockCommand(sku string, orderID string) *ReleaseStockCommand {
+	return &ReleaseStockCommand{BaseCommand: es.NewBaseCommand(sku), OrderID: orderID}
+}
+
+type CommitStockCommand struct {
+	es.BaseCommand
+	OrderID string `json:"orderId" validate:"required"`
+}
+
+func NewCommitStockCommand(sku string, orderID string) *CommitStockCommand {
+	return &CommitStockCommand{BaseCommand: es.NewBaseCommand(sku), OrderID: orderID}
+}
+
+// RegisterCustomerCommand customer commands use the customer id as aggregate id.
+type RegisterCustomerCommand struct {
+	es.BaseCommand
+	Email string `json:"email" validate:"required,email"`
+	Name  string `json:"name" validate:"required"`
+}
+
+func NewRegisterCustomerCommand(customerID string, email string, name string) *RegisterCustomerCommand {
+	return &RegisterCustomerCommand{BaseCommand: es.NewBaseCommand(customerID), Email: email, Name: name}
+}
+
+type ChangeCustomerEmailCommand struct {
+	es.BaseCommand
+	Email string `json:"email" validate:"required,email"`
+}
+
+func NewChangeCustomerEmailCommand(customerID string, email string) *ChangeCustomerEmailCommand {
+	return &ChangeCustomerEmailCommand{BaseCommand: es.NewBaseCommand(customerID), Email: email}
+}
+
+type SaveCustomerAddressCommand struct {
+	es.BaseCommand
+	Address models.SavedAddress `json:"address"`
+}
+
+func NewSaveCustomerAddressCommand(customerID string, address models.SavedAddress) *SaveCustomerAddressCommand {
+	return &SaveCustomerAddressCommand{BaseCommand: es.NewBaseCommand(customerID), Address: address}
+}
+
+type RemoveCustomerAddressCommand struct {
+	es.BaseCommand
+	AddressID string `json:"addressId" validate:"required"`
+}
+
+func NewRemoveCustomerAddressCommand(customerID string, addressID string) *RemoveCustomerAddressCommand {
+	return &RemoveCustomerAddressCommand{BaseCommand: es.NewBaseCommand(customerID), AddressID: addressID}
+}
+
+type UpdateCustomerPreferencesCommand struct {
+	es.BaseCommand
+	Preferences models.CustomerPreferences `json:"preferences"`
+}
+
+func NewUpdateCustomerPreferencesCommand(customerID string, preferences models.CustomerPreferences) *UpdateCustomerPreferencesCommand {
+	return &UpdateCustomerPreferencesCommand{BaseCommand: es.NewBaseCommand(customerID), Preferences: preferences}
+}
+
+type CreateProductCommand struct {
+	es.BaseCommand
+	Title       string       `json:"title" validate:"required"`
+	Description string       `json:"description"`
+	Price       models.Money `json:"price" validate:"required"`
+	TaxCategory string       `json:"taxCategory,omitempty"`
+}
+
+func NewCreateProductCommand(productID string, title string, description string, price models.Money, taxCategory string) *CreateProductCommand {
+	return &CreateProductCommand{BaseCommand: es.NewBaseCommand(productID), Title: title, Description: description, Price: price, TaxCategory: taxCategory}
+}
+
+type RepriceProductCommand struct {
+	es.BaseCommand
+	Price models.Money `json:"price" validate:"required"`
+}
+
+func NewRepriceProductCommand(productID string, price models.Money) *RepriceProductCommand {
+	return &RepriceProductCommand{BaseCommand: es.NewBaseCommand(productID), Price: price}
+}
+
+type DiscontinueProductCommand struct {
+	es.BaseCommand
+	Reason string `json:"reason,omitempty"`
+}
+
+func NewDiscontinueProductCommand(productID string, reason string) *DiscontinueProductCommand {
+	return &DiscontinueProductCommand{BaseCommand: es.NewBaseCommand(productID), Reason: reason}
+}
@@ -2,26 +2,65 @@ package commands
 
 import (
 	"context"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/EventStore/EventStore-Client-Go/esdb"
 	"github.com/opentracing/opentracing-go"
 	"github.com/opentracing/opentracing-go/log"
 	"github.com/pkg/errors"
+	uuid "github.com/satori/go.uuid"
+	"go.mongodb.org/mongo-driver/mongo"
 
 	"github.com/wassef911/eventually/internal/delivery/aggregate"
+	"github.com/wassef911/eventually/internal/delivery/models"
+	"github.com/wassef911/eventually/internal/delivery/payment"
+	"github.com/wassef911/eventually/internal/delivery/repository"
+	"github.com/wassef911/eventually/internal/infrastructure/es"
 	"github.com/wassef911/eventually/internal/infrastructure/es/store"
+	"github.com/wassef911/eventually/internal/infrastructure/tracing"
 	"github.com/wassef911/eventually/pkg/config"
 	"github.com/wassef911/eventually/pkg/logger"
 )
 
 // all handlers implement commandHandler
 var _ commandHandler[*CancelOrderCommand] = &cancelOrderCommandHandler{}
+var _ commandHandler[*RejectOrderCommand] = &rejectOrderCommandHandler{}
+var _ commandHandler[*RemindPaymentCommand] = &remindPaymentCommandHandler{}
 var _ commandHandler[*ChangeDeliveryAddressCommand] = &changeDeliveryAddressCommandHandler{}
 var _ commandHandler[*CompleteOrderCommand] = &completeOrderCommandHandler{}
 var _ commandHandler[*CreateOrderCommand] = &createOrderHandler{}
 var _ commandHandler[*PayOrderCommand] = &payOrderCommandHandler{}
+var _ commandHandler[*PaymentWebhookCommand] = &paymentWebhookCommandHandler{}
 var _ commandHandler[*SubmitOrderCommand] = &submitOrderCommandHandler{}
 var _ commandHandler[*UpdateShoppingCartCommand] = &updateShoppingCartCommandHandler{}
+var _ commandHandler[*RefundOrderCommand] = &refundOrderCommandHandler{}
+var _ commandHandler[*RequestReturnCommand] = &requestReturnCommandHandler{}
+var _ commandHandler[*ApproveReturnCommand] = &approveReturnCommandHandler{}
+var _ commandHandler[*RejectReturnCommand] = &rejectReturnCommandHandler{}
+var _ commandHandler[*ReceiveReturnCommand] = &receiveReturnCommandHandler{}
+var _ commandHandler[*CreateShipmentCommand] = &createShipmentCommandHandler{}
+var _ commandHandler[*PackShipmentCommand] = &packShipmentCommandHandler{}
+var _ commandHandler[*DispatchShipmentCommand] = &dispatchShipmentCommandHandler{}
+var _ commandHandler[*DeliverShipmentCommand] = &deliverShipmentCommandHandler{}
+var _ commandHandler[*AddItemCommand] = &addItemCommandHandler{}
+var _ commandHandler[*RemoveItemCommand] = &removeItemCommandHandler{}
+var _ commandHandler[*ChangeItemQuantityCommand] = &changeItemQuantityCommandHandler{}
+var _ commandHandler[*ApplyCouponCommand] = &applyCouponCommandHandler{}
+var _ commandHandler[*RemoveCouponCommand] = &removeCouponCommandHandler{}
+var _ commandHandler[*AddStockCommand] = &addStockCommandHandler{}
+var _ commandHandler[*ReserveStockCommand] = &reserveStockCommandHandler{}
+var _ commandHandler[*ReleaseStockCommand] = &releaseStockCommandHandler{}
+var _ commandHandler[*CommitStockCommand] = &commitStockCommandHandler{}
+var _ commandHandler[*RegisterCustomerCommand] = &registerCustomerCommandHandler{}
+var _ commandHandler[*ChangeCustomerEmailCommand] = &changeCustomerEmailCommandHandler{}
+var _ commandHandler[*SaveCustomerAddressCommand] = &saveCustomerAddressCommandHandler{}
+var _ commandHandler[*RemoveCustomerAddressCommand] = &removeCustomerAddressCommandHandler{}
+var _ commandHandler[*UpdateCustomerPreferencesCommand] = &updateCustomerPreferencesCommandHandler{}
+var _ commandHandler[*CreateProductCommand] = &createProductCommandHandler{}
+var _ commandHandler[*RepriceProductCommand] = &repriceProductCommandHandler{}
+var _ commandHandler[*DiscontinueProductCommand] = &discontinueProductCommandHandler{}
 
 type cancelOrderCommandHandler struct {
 	baseCommandHandler
@@ -41,6 +80,10 @@ func (c *cancelOrderCommandHandler) Handle(ctx context.Context, command *CancelO
 		return err
 	}
 
+	if command.UnpaidOnly && order.Order.Paid() {
+		return aggregate.ErrAlreadyPaid
+	}
+
 	if err := order.CancelOrder(ctx, command.CancelReason); err != nil {
 		return err
 	}
@@ -48,12 +91,63 @@ func (c *cancelOrderCommandHandler) Handle(ctx context.Context, command *CancelO
 	return c.es.Save(ctx, order)
 }
 
+type remindPaymentCommandHandler struct {
+	baseCommandHandler
+}
+
+func NewRemindPaymentCommandHandler(log logger.Logger, config *config.Config, es store.AggregateStore) *remindPaymentCommandHandler {
+	return &remindPaymentCommandHandler{baseCommandHandler{log: log, config: config, es: es}}
+}
+
+func (c *remindPaymentCommandHandler) Handle(ctx context.Context, command *RemindPaymentCommand) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "remindPaymentCommandHandler.Handle")
+	defer span.Finish()
+	span.LogFields(log.String("AggregateID", command.GetAggregateID()))
+
+	order, err := aggregate.LoadOrderAggregate(ctx, c.es, command.GetAggregateID())
+	if err != nil {
+		return err
+	}
+
+	if err := order.RemindPayment(ctx, command.Reminder, command.ExpiresAt); err != nil {
+		return err
+	}
+
+	return c.es.Save(ctx, order)
+}
+
+type rejectOrderCommandHandler struct {
+	baseCommandHandler
+}
+
+func NewRejectOrderCommandHandler(log logger.Logger, config *config.Config, es store.AggregateStore) *rejectOrderCommandHandler {
+	return &rejectOrderCommandHandler{baseCommandHandler{log: log, config: config, es: es}}
+}
+
+func (c *rejectOrderCommandHandler) Handle(ctx context.Context, command *RejectOrderCommand) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "rejectOrderCommandHandler.Handle")
+	defer span.Finish()
+	span.LogFields(log.String("AggregateID", command.GetAggregateID()))
+
+	order, err := aggregate.LoadOrderAggregate(ctx, c.es, command.GetAggregateID())
+	if err != nil {
+		return err
+	}
+
+	if err := order.RejectOrder(ctx, command.RejectReason); err != nil {
+		return err
+	}
+
+	return c.es.Save(ctx, order)
+}
+
 type changeDeliveryAddressCommandHandler struct {
 	baseCommandHandler
+	taxCalculator aggregate.TaxCalculator
 }
 
-func NewchangeDeliveryAddressCommandHandler(log logger.Logger, config *config.Config, es store.AggregateStore) *changeDeliveryAddressCommandHandler {
-	return &changeDeliveryAddressCommandHandler{baseCommandHandler{log: log, config: config, es: es}}
+func NewchangeDeliveryAddressCommandHandler(log logger.Logger, config *config.Config, es store.AggregateStore, taxCalculator aggregate.TaxCalculator) *changeDeliveryAddressCommandHandler {
+	return &changeDeliveryAddressCommandHandler{baseCommandHandler: baseCommandHandler{log: log, config: config, es: es}, taxCalculator: taxCalculator}
 }
 
 func (c *changeDeliveryAddressCommandHandler) Handle(ctx context.Context, command *ChangeDeliveryAddressCommand) error {
@@ -65,6 +159,7 @@ func (c *changeDeliveryAddressCommandHandler) Handle(ctx context.Context, comman
 	if err != nil {
 		return err
 	}
+	order.SetTaxCalculator(c.taxCalculator)
 
 	if err := order.ChangeDeliveryAddress(ctx, command.DeliveryAddress); err != nil {
 		return err
@@ -100,10 +195,11 @@ func (c *completeOrderCommandHandler) Handle(ctx context.Context, command *Compl
 
 type createOrderHandler struct {
 	baseCommandHandler
+	taxCalculator aggregate.TaxCalculator
 }
 
-func NewCreateOrderHandler(log logger.Logger, config *config.Config, es store.AggregateStore) *createOrderHandler {
-	return &createOrderHandler{baseCommandHandler{log: log, config: config, es: es}}
+func NewCreateOrderHandler(log logger.Logger, config *config.Config, es store.AggregateStore, taxCalculator aggregate.TaxCalculator) *createOrderHandler {
+	return &createOrderHandler{baseCommandHandler: baseCommandHandler{log: log, config: config, es: es}, taxCalculator: taxCalculator}
 }
 
 func (c *createOrderHandler) Handle(ctx context.Context, command *CreateOrderCommand) error {
@@ -112,12 +208,36 @@ func (c *createOrderHandler) Handle(ctx context.Context, command *CreateOrderCom
 	span.LogFields(log.String("AggregateID", command.GetAggregateID()))
 
 	order := aggregate.NewOrderAggregateWithID(command.AggregateID)
+	order.SetTenant(es.TenantFromContext(ctx))
+	order.SetTaxCalculator(c.taxCalculator)
 	err := c.es.Exists(ctx, order.GetID())
 	if err != nil && !errors.Is(err, esdb.ErrStreamNotFound) {
 		return err
 	}
 
-	if err := order.CreateOrder(ctx, command.ShopItems, command.AccountEmail, command.DeliveryAddress); err != nil {
+	accountEmail := command.AccountEmail
+	if command.CustomerID != "" {
+		customer, err := aggregate.LoadCustomerAggregate(ctx, c.es, command.CustomerID)
+		if err != nil {
+			return err
+		}
+		if !customer.IsRegistered() {
+			return errors.Wrapf(aggregate.ErrCustomerNotFound, "customerID: {%s}", command.CustomerID)
+		}
+		// the account email must be the one the customer registered, customers can not order for another account
+		if accountEmail == "" {
+			accountEmail = customer.Customer.Email
+		} else if !strings.EqualFold(accountEmail, customer.Customer.Email) {
+			return errors.Wrapf(aggregate.ErrCustomerEmailMismatch, "customerID: {%s}", command.CustomerID)
+		}
+	}
+
+	shopItems, err := resolveShopItems(ctx, c.es, command.Items)
+	if err != nil {
+		return err
+	}
+
+	if err := order.CreateOrder(ctx, shopItems, command.CustomerID, accountEmail, command.DeliveryAddress, c.config.OrdersOf(es.TenantFromContext(ctx)).ShippingFee); err != nil {
 		return err
 	}
 
@@ -127,12 +247,15 @@ func (c *createOrderHandler) Handle(ctx context.Context, command *CreateOrderCom
 
 type payOrderCommandHandler struct {
 	baseCommandHandler
+	gateway payment.Gateway
 }
 
-func NewOrderPaidHandler(log logger.Logger, config *config.Config, es store.AggregateStore) *payOrderCommandHandler {
-	return &payOrderCommandHandler{baseCommandHandler{log: log, config: config, es: es}}
+func NewOrderPaidHandler(log logger.Logger, config *config.Config, es store.AggregateStore, gateway payment.Gateway) *payOrderCommandHandler {
+	return &payOrderCommandHandler{baseCommandHandler: baseCommandHandler{log: log, config: config, es: es}, gateway: gateway}
 }
 
+// Handle authorizes and captures the order total with the payment provider,
+// every step is saved so a crash leaves the attempt visible on the order.
 func (c *payOrderCommandHandler) Handle(ctx context.Context, command *PayOrderCommand) error {
 	span, ctx := opentracing.StartSpanFromContext(ctx, "payOrderCommandHandler.Handle")
 	defer span.Finish()
@@ -143,10 +266,127 @@ func (c *payOrderCommandHandler) Handle(ctx context.Context, command *PayOrderCo
 		return err
 	}
 
-	if err := order.PayOrder(ctx, command.Payment); err != nil {
+	attemptID := uuid.NewV4().String()
+	if err := order.StartPayment(ctx, attemptID, c.gateway.Name()); err != nil {
+		return err
+	}
+	if err := c.es.Save(ctx, order); err != nil {
+		return err
+	}
+
+	amount := order.Order.TotalPrice
+	authorization, err := c.gateway.Authorize(ctx, payment.AuthorizeRequest{
+		OrderID:   command.GetAggregateID(),
+		AttemptID: attemptID,
+		Amount:    amount,
+		Token:     command.PaymentToken,
+	})
+	if err != nil {
+		tracing.TraceErr(span, err)
+		return c.failPayment(ctx, order, attemptID, err)
+	}
+
+	pending := authorization.Status == payment.AuthorizationPending
+	if err := order.AuthorizePayment(ctx, attemptID, authorization.PaymentID, pending); err != nil {
+		return err
+	}
+	if pending {
+		// the provider confirms the payment through its webhook
+		return c.es.Save(ctx, order)
+	}
+
+	if err := c.gateway.Capture(ctx, authorization.PaymentID, amount); err != nil {
+		tracing.TraceErr(span, err)
+		if voidErr := c.gateway.Void(ctx, authorization.PaymentID); voidErr != nil {
+			c.log.Errorf("(payOrderCommandHandler) gateway.Void paymentID: {%s}, err: {%v}", authorization.PaymentID, voidErr)
+		}
+		return c.failPayment(ctx, order, attemptID, err)
+	}
+
+	orderPayment := models.Payment{PaymentID: authorization.PaymentID, Timestamp: time.Now().UTC(), Provider: c.gateway.Name(), Amount: amount}
+	if err := order.PayOrder(ctx, orderPayment); err != nil {
+		return err
+	}
+
+	return c.es.Save(ctx, order)
+}
+
+// failPayment records the provider error on the attempt and returns it to the caller.
+func (c *payOrderCommandHandler) failPayment(ctx context.Context, order *aggregate.OrderAggregate, attemptID string, gatewayErr error) error {
+	if err := order.FailPayment(ctx, attemptID, gatewayErr.Error()); err != nil {
+		return err
+	}
+	if err := c.es.Save(ctx, order); err != nil {
+		return err
+	}
+	return gatewayErr
+}
+
+// PaymentCapturedTooLateReason fails an attempt the provider captured after the order was canceled or paid otherwise.
+const PaymentCapturedTooLateReason = "captured after the order closed, refunded"
+
+type paymentWebhookCommandHandler struct {
+	baseCommandHandler
+	gateway payment.Gateway
+}
+
+func NewPaymentWebhookCommandHandler(log logger.Logger, config *config.Config, es store.AggregateStore, gateway payment.Gateway) *paymentWebhookCommandHandler {
+	return &paymentWebhookCommandHandler{baseCommandHandler: baseCommandHandler{log: log, config: config, es: es}, gateway: gateway}
+}
+
+// Handle providers deliver webhooks at least once, a replayed outcome is a no-op.
+// A payment captured once the order can no longer be paid is refunded and its attempt failed.
+func (c *paymentWebhookCommandHandler) Handle(ctx context.Context, command *PaymentWebhookCommand) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "paymentWebhookCommandHandler.Handle")
+	defer span.Finish()
+	span.LogFields(log.String("AggregateID", command.GetAggregateID()), log.String("PaymentID", command.PaymentID))
+
+	order, err := aggregate.LoadOrderAggregate(ctx, c.es, command.GetAggregateID())
+	if err != nil {
 		return err
 	}
 
+	attempt := aggregate.GetPaymentAttempt(order.Order, "", command.PaymentID)
+	if attempt == nil {
+		return aggregate.ErrPaymentAttemptNotFound
+	}
+
+	switch command.Type {
+	case payment.WebhookPaymentCaptured:
+		if order.Order.Paid() && order.Order.Payment.PaymentID == command.PaymentID {
+			return nil
+		}
+		if err := order.CanTransition(aggregate.ActionPayOrder); err != nil {
+			tracing.TraceErr(span, err)
+			return c.refundCapture(ctx, order, attempt)
+		}
+		orderPayment := models.Payment{PaymentID: command.PaymentID, Timestamp: time.Now().UTC(), Provider: attempt.Provider, Amount: attempt.Amount}
+		if err := order.PayOrder(ctx, orderPayment); err != nil {
+			return err
+		}
+
+	case payment.WebhookPaymentFailed:
+		if err := order.FailPayment(ctx, attempt.AttemptID, command.Reason); err != nil {
+			return err
+		}
+
+	default:
+		return errors.Wrapf(payment.ErrUnknownWebhook, "type: {%s}", command.Type)
+	}
+
+	return c.es.Save(ctx, order)
+}
+
+// refundCapture the attempt id keys the refund, so a replayed webhook does not refund twice.
+func (c *paymentWebhookCommandHandler) refundCapture(ctx context.Context, order *aggregate.OrderAggregate, attempt *models.PaymentAttempt) error {
+	if err := c.gateway.Refund(ctx, attempt.PaymentID, attempt.AttemptID, attempt.Amount); err != nil {
+		return errors.Wrapf(err, "gateway.Refund paymentID: {%s}", attempt.PaymentID)
+	}
+	c.log.Warnf("(paymentWebhookCommandHandler) refunded paymentID: {%s} captured for order: {%s} in status: {%s}", attempt.PaymentID, order.GetID(), order.Order.Status)
+
+	if err := order.FailPayment(ctx, attempt.AttemptID, PaymentCapturedTooLateReason); err != nil {
+		return err
+	}
 	return c.es.Save(ctx, order)
 }
 
@@ -177,10 +417,11 @@ func (c *submitOrderCommandHandler) Handle(ctx context.Context, command *SubmitO
 
 type updateShoppingCartCommandHandler struct {
 	baseCommandHandler
+	taxCalculator aggregate.TaxCalculator
 }
 
-func NewupdateShoppingCartCommandHandler(log logger.Logger, config *config.Config, es store.AggregateStore) *updateShoppingCartCommandHandler {
-	return &updateShoppingCartCommandHandler{baseCommandHandler{log: log, config: config, es: es}}
+func NewupdateShoppingCartCommandHandler(log logger.Logger, config *config.Config, es store.AggregateStore, taxCalculator aggregate.TaxCalculator) *updateShoppingCartCommandHandler {
+	return &updateShoppingCartCommandHandler{baseCommandHandler: baseCommandHandler{log: log, config: config, es: es}, taxCalculator: taxCalculator}
 }
 
 func (c *updateShoppingCartCommandHandler) Handle(ctx context.Context, command *UpdateShoppingCartCommand) error {
@@ -188,14 +429,772 @@ func (c *updateShoppingCartCommandHandler) Handle(ctx context.Context, command *
 	defer span.Finish()
 	span.LogFields(log.String("AggregateID", command.GetAggregateID()))
 
+	order, err := aggregate.LoadOrderAggregate(ctx, c.es, command.GetAggregateID())
+	if err != nil {
+		return err
+	}
+	order.SetTaxCalculator(c.taxCalculator)
+
+	shopItems, err := resolveShopItems(ctx, c.es, command.Items)
+	if err != nil {
+		return err
+	}
+
+	if err := order.UpdateShoppingCart(ctx, shopItems); err != nil {
+		return err
+	}
+
+	return c.es.Save(ctx, order)
+}
+
+type refundOrderCommandHandler struct {
+	baseCommandHandler
+	gateway payment.Gateway
+}
+
+func NewRefundOrderCommandHandler(log logger.Logger, config *config.Config, es store.AggregateStore, gateway payment.Gateway) *refundOrderCommandHandler {
+	return &refundOrderCommandHandler{baseCommandHandler: baseCommandHandler{log: log, config: config, es: es}, gateway: gateway}
+}
+
+func (c *refundOrderCommandHandler) Handle(ctx context.Context, command *RefundOrderCommand) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "refundOrderCommandHandler.Handle")
+	defer span.Finish()
+	span.LogFields(log.String("AggregateID", command.GetAggregateID()))
+
+	order, err := aggregate.LoadOrderAggregate(ctx, c.es, command.GetAggregateID())
+	if err != nil {
+		return err
+	}
+
+	if err := order.RefundOrder(ctx, command.RefundID, command.Items, command.Amount, command.Reason); err != nil {
+		return err
+	}
+	if err := c.es.Save(ctx, order); err != nil {
+		return err
+	}
+
+	return settleRefunds(ctx, c.es, c.gateway, order)
+}
+
+type requestReturnCommandHandler struct {
+	baseCommandHandler
+}
+
+func NewRequestReturnCommandHandler(log logger.Logger, config *config.Config, es store.AggregateStore) *requestReturnCommandHandler {
+	return &requestReturnCommandHandler{baseCommandHandler{log: log, config: config, es: es}}
+}
+
+func (c *requestReturnCommandHandler) Handle(ctx context.Context, command *RequestReturnCommand) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "requestReturnCommandHandler.Handle")
+	defer span.Finish()
+	span.LogFields(log.String("AggregateID", command.GetAggregateID()))
+
+	order, err := aggregate.LoadOrderAggregate(ctx, c.es, command.GetAggregateID())
+	if err != nil {
+		return err
+	}
+
+	returnWindow := c.config.OrdersOf(es.TenantFromContext(ctx)).ReturnWindow
+	if returnWindow == 0 {
+		returnWindow = aggregate.DefaultReturnWindow
+	}
+
+	if err := order.RequestReturn(ctx, command.ReturnID, command.Items, command.Reason, returnWindow); err != nil {
+		return err
+	}
+
+	return c.es.Save(ctx, order)
+}
+
+type approveReturnCommandHandler struct {
+	baseCommandHandler
+}
+
+func NewApproveReturnCommandHandler(log logger.Logger, config *config.Config, es store.AggregateStore) *approveReturnCommandHandler {
+	return &approveReturnCommandHandler{baseCommandHandler{log: log, config: config, es: es}}
+}
+
+func (c *approveReturnCommandHandler) Handle(ctx context.Context, command *ApproveReturnCommand) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "approveReturnCommandHandler.Handle")
+	defer span.Finish()
+	span.LogFields(log.String("AggregateID", command.GetAggregateID()))
+
+	order, err := aggregate.LoadOrderAggregate(ctx, c.es, command.GetAggregateID())
+	if err != nil {
+		return err
+	}
+
+	if err := order.ApproveReturn(ctx, command.ReturnID); err != nil {
+		return err
+	}
+
+	return c.es.Save(ctx, order)
+}
+
+type rejectReturnCommandHandler struct {
+	baseCommandHandler
+}
+
+func NewRejectReturnCommandHandler(log logger.Logger, config *config.Config, es store.AggregateStore) *rejectReturnCommandHandler {
+	return &rejectReturnCommandHandler{baseCommandHandler{log: log, config: config, es: es}}
+}
+
+func (c *rejectReturnCommandHandler) Handle(ctx context.Context, command *RejectReturnCommand) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "rejectReturnCommandHandler.Handle")
+	defer span.Finish()
+	span.LogFields(log.String("AggregateID", command.GetAggregateID()))
+
+	order, err := aggregate.LoadOrderAggregate(ctx, c.es, command.GetAggregateID())
+	if err != nil {
+		return err
+	}
+
+	if err := order.RejectReturn(ctx, command.ReturnID, command.RejectReason); err != nil {
+		return err
+	}
+
+	return c.es.Save(ctx, order)
+}
+
+type receiveReturnCommandHandler struct {
+	baseCommandHandler
+	gateway payment.Gateway
+}
+
+func NewReceiveReturnCommandHandler(log logger.Logger, config *config.Config, es store.AggregateStore, gateway payment.Gateway) *receiveReturnCommandHandler {
+	return &receiveReturnCommandHandler{baseCommandHandler: baseCommandHandler{log: log, config: config, es: es}, gateway: gateway}
+}
+
+func (c *receiveReturnCommandHandler) Handle(ctx context.Context, command *ReceiveReturnCommand) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "receiveReturnCommandHandler.Handle")
+	defer span.Finish()
+	span.LogFields(log.String("AggregateID", command.GetAggregateID()))
+
+	order, err := aggregate.LoadOrderAggregate(ctx, c.es, command.GetAggregateID())
+	if err != nil {
+		return err
+	}
+
+	if err := order.ReceiveReturn(ctx, command.ReturnID, command.RefundID); err != nil {
+		return err
+	}
+	if err := c.es.Save(ctx, order); err != nil {
+		return err
+	}
+
+	return settleRefunds(ctx, c.es, c.gateway, order)
+}
+
+type createShipmentCommandHandler struct {
+	baseCommandHandler
+}
+
+func NewCreateShipmentCommandHandler(log logger.Logger, config *config.Config, es store.AggregateStore) *createShipmentCommandHandler {
+	return &createShipmentCommandHandler{baseCommandHandler{log: log, config: config, es: es}}
+}
+
+func (c *createShipmentCommandHandler) Handle(ctx context.Context, command *CreateShipmentCommand) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "createShipmentCommandHandler.Handle")
+	defer span.Finish()
+	span.LogFields(log.String("AggregateID", command.GetAggregateID()))
+
+	order, err := aggregate.LoadOrderAggregate(ctx, c.es, command.GetAggregateID())
+	if err != nil {
+		return err
+	}
+
+	if err := order.CreateShipment(ctx, command.ShipmentID, command.Items); err != nil {
+		return err
+	}
+
+	return c.es.Save(ctx, order)
+}
+
+type packShipmentCommandHandler struct {
+	baseCommandHandler
+}
+
+func NewPackShipmentCommandHandler(log logger.Logger, config *config.Config, es store.AggregateStore) *packShipmentCommandHandler {
+	return &packShipmentCommandHandler{baseCommandHandler{log: log, config: config, es: es}}
+}
+
+func (c *packShipmentCommandHandler) Handle(ctx context.Context, command *PackShipmentCommand) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "packShipmentCommandHandler.Handle")
+	defer span.Finish()
+	span.LogFields(log.String("AggregateID", command.GetAggregateID()))
+
+	order, err := aggregate.LoadOrderAggregate(ctx, c.es, command.GetAggregateID())
+	if err != nil {
+		return err
+	}
+
+	if err := order.PackShipment(ctx, command.ShipmentID); err != nil {
+		return err
+	}
+
+	return c.es.Save(ctx, order)
+}
+
+type dispatchShipmentCommandHandler struct {
+	baseCommandHandler
+}
+
+func NewDispatchShipmentCommandHandler(log logger.Logger, config *config.Config, es store.AggregateStore) *dispatchShipmentCommandHandler {
+	return &dispatchShipmentCommandHandler{baseCommandHandler{log: log, config: config, es: es}}
+}
+
+func (c *dispatchShipmentCommandHandler) Handle(ctx context.Context, command *DispatchShipmentCommand) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "dispatchShipmentCommandHandler.Handle")
+	defer span.Finish()
+	span.LogFields(log.String("AggregateID", command.GetAggregateID()))
+
 	order, err := aggregate.LoadOrderAggregate(ctx, c.es, command.GetAggregateID())
 	if err != nil {
 		return err
 	}
 
-	if err := order.UpdateShoppingCart(ctx, command.ShopItems); err != nil {
+	if err := order.DispatchShipment(ctx, command.ShipmentID, command.Carrier, command.TrackingNumber); err != nil {
 		return err
 	}
 
 	return c.es.Save(ctx, order)
 }
+
+type deliverShipmentCommandHandler struct {
+	baseCommandHandler
+}
+
+func NewDeliverShipmentCommandHandler(log logger.Logger, config *config.Config, es store.AggregateStore) *deliverShipmentCommandHandler {
+	return &deliverShipmentCommandHandler{baseCommandHandler{log: log, config: config, es: es}}
+}
+
+func (c *deliverShipmentCommandHandler) Handle(ctx context.Context, command *DeliverShipmentCommand) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "deliverShipmentCommandHandler.Handle")
+	defer span.Finish()
+	span.LogFields(log.String("AggregateID", command.GetAggregateID()))
+
+	order, err := aggregate.LoadOrderAggregate(ctx, c.es, command.GetAggregateID())
+	if err != nil {
+		return err
+	}
+
+	if err := order.DeliverShipment(ctx, command.ShipmentID, command.DeliveredAt); err != nil {
+		return err
+	}
+
+	return c.es.Save(ctx, order)
+}
+
+type addItemCommandHandler struct {
+	baseCommandHandler
+	taxCalculator aggregate.TaxCalculator
+}
+
+func NewAddItemCommandHandler(log logger.Logger, config *config.Config, es store.AggregateStore, taxCalculator aggregate.TaxCalculator) *addItemCommandHandler {
+	return &addItemCommandHandler{baseCommandHandler: baseCommandHandler{log: log, config: config, es: es}, taxCalculator: taxCalculator}
+}
+
+func (c *addItemCommandHandler) Handle(ctx context.Context, command *AddItemCommand) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "addItemCommandHandler.Handle")
+	defer span.Finish()
+	span.LogFields(log.String("AggregateID", command.GetAggregateID()))
+
+	order, err := aggregate.LoadOrderAggregate(ctx, c.es, command.GetAggregateID())
+	if err != nil {
+		return err
+	}
+	order.SetTaxCalculator(c.taxCalculator)
+
+	shopItems, err := resolveShopItems(ctx, c.es, []*models.OrderItem{command.Item})
+	if err != nil {
+		return err
+	}
+
+	if err := order.AddItem(ctx, shopItems[0]); err != nil {
+		return err
+	}
+
+	return c.es.Save(ctx, order)
+}
+
+type removeItemCommandHandler struct {
+	baseCommandHandler
+	taxCalculator aggregate.TaxCalculator
+}
+
+func NewRemoveItemCommandHandler(log logger.Logger, config *config.Config, es store.AggregateStore, taxCalculator aggregate.TaxCalculator) *removeItemCommandHandler {
+	return &removeItemCommandHandler{baseCommandHandler: baseCommandHandler{log: log, config: config, es: es}, taxCalculator: taxCalculator}
+}
+
+func (c *removeItemCommandHandler) Handle(ctx context.Context, command *RemoveItemCommand) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "removeItemCommandHandler.Handle")
+	defer span.Finish()
+	span.LogFields(log.String("AggregateID", command.GetAggregateID()))
+
+	order, err := aggregate.LoadOrderAggregate(ctx, c.es, command.GetAggregateID())
+	if err != nil {
+		return err
+	}
+	order.SetTaxCalculator(c.taxCalculator)
+
+	if err := order.RemoveItem(ctx, command.ShopItemID); err != nil {
+		return err
+	}
+
+	return c.es.Save(ctx, order)
+}
+
+type changeItemQuantityCommandHandler struct {
+	baseCommandHandler
+	taxCalculator aggregate.TaxCalculator
+}
+
+func NewChangeItemQuantityCommandHandler(log logger.Logger, config *config.Config, es store.AggregateStore, taxCalculator aggregate.TaxCalculator) *changeItemQuantityCommandHandler {
+	return &changeItemQuantityCommandHandler{baseCommandHandler: baseCommandHandler{log: log, config: config, es: es}, taxCalculator: taxCalculator}
+}
+
+func (c *changeItemQuantityCommandHandler) Handle(ctx context.Context, command *ChangeItemQuantityCommand) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "changeItemQuantityCommandHandler.Handle")
+	defer span.Finish()
+	span.LogFields(log.String("AggregateID", command.GetAggregateID()))
+
+	order, err := aggregate.LoadOrderAggregate(ctx, c.es, command.GetAggregateID())
+	if err != nil {
+		return err
+	}
+	order.SetTaxCalculator(c.taxCalculator)
+
+	if err := order.ChangeItemQuantity(ctx, command.ShopItemID, command.Quantity); err != nil {
+		return err
+	}
+
+	return c.es.Save(ctx, order)
+}
+
+type applyCouponCommandHandler struct {
+	baseCommandHandler
+	couponRepo    repository.CouponRepository
+	taxCalculator aggregate.TaxCalculator
+}
+
+func NewApplyCouponCommandHandler(log logger.Logger, config *config.Config, es store.AggregateStore, couponRepo repository.CouponRepository, taxCalculator aggregate.TaxCalculator) *applyCouponCommandHandler {
+	return &applyCouponCommandHandler{baseCommandHandler: baseCommandHandler{log: log, config: config, es: es}, couponRepo: couponRepo, taxCalculator: taxCalculator}
+}
+
+func (c *applyCouponCommandHandler) Handle(ctx context.Context, command *ApplyCouponCommand) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "applyCouponCommandHandler.Handle")
+	defer span.Finish()
+	span.LogFields(log.String("AggregateID", command.GetAggregateID()), log.String("Code", command.Code))
+
+	coupon, err := c.couponRepo.GetByCode(ctx, command.Code)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return errors.Wrapf(aggregate.ErrCouponNotFound, "code: %s", command.Code)
+		}
+		return err
+	}
+
+	order, err := aggregate.LoadOrderAggregate(ctx, c.es, command.GetAggregateID())
+	if err != nil {
+		return err
+	}
+	order.SetTaxCalculator(c.taxCalculator)
+
+	if err := order.ApplyCoupon(ctx, coupon, time.Now()); err != nil {
+		return err
+	}
+
+	return c.es.Save(ctx, order)
+}
+
+type removeCouponCommandHandler struct {
+	baseCommandHandler
+	taxCalculator aggregate.TaxCalculator
+}
+
+func NewRemoveCouponCommandHandler(log logger.Logger, config *config.Config, es store.AggregateStore, taxCalculator aggregate.TaxCalculator) *removeCouponCommandHandler {
+	return &removeCouponCommandHandler{baseCommandHandler: baseCommandHandler{log: log, config: config, es: es}, taxCalculator: taxCalculator}
+}
+
+func (c *removeCouponCommandHandler) Handle(ctx context.Context, command *RemoveCouponCommand) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "removeCouponCommandHandler.Handle")
+	defer span.Finish()
+	span.LogFields(log.String("AggregateID", command.GetAggregateID()), log.String("Code", command.Code))
+
+	order, err := aggregate.LoadOrderAggregate(ctx, c.es, command.GetAggregateID())
+	if err != nil {
+		return err
+	}
+	order.SetTaxCalculator(c.taxCalculator)
+
+	if err := order.RemoveCoupon(ctx, command.Code); err != nil {
+		return err
+	}
+
+	return c.es.Save(ctx, order)
+}
+
+type addStockCommandHandler struct {
+	baseCommandHandler
+}
+
+func NewAddStockCommandHandler(log logger.Logger, config *config.Config, es store.AggregateStore) *addStockCommandHandler {
+	return &addStockCommandHandler{baseCommandHandler{log: log, config: config, es: es}}
+}
+
+func (c *addStockCommandHandler) Handle(ctx context.Context, command *AddStockCommand) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "addStockCommandHandler.Handle")
+	defer span.Finish()
+	span.LogFields(log.String("SKU", command.GetAggregateID()))
+
+	inventory, err := aggregate.LoadInventoryAggregate(ctx, c.es, command.GetAggregateID())
+	if err != nil {
+		return err
+	}
+
+	if err := inventory.AddStock(ctx, command.Quantity); err != nil {
+		return err
+	}
+
+	return c.es.Save(ctx, inventory)
+}
+
+type reserveStockCommandHandler struct {
+	baseCommandHandler
+}
+
+func NewReserveStockCommandHandler(log logger.Logger, config *config.Config, es store.AggregateStore) *reserveStockCommandHandler {
+	return &reserveStockCommandHandler{baseCommandHandler{log: log, config: config, es: es}}
+}
+
+func (c *reserveStockCommandHandler) Handle(ctx context.Context, command *ReserveStockCommand) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "reserveStockCommandHandler.Handle")
+	defer span.Finish()
+	span.LogFields(log.String("SKU", command.GetAggregateID()), log.String("OrderID", command.OrderID))
+
+	inventory, err := aggregate.LoadInventoryAggregate(ctx, c.es, command.GetAggregateID())
+	if err != nil {
+		return err
+	}
+	if !inventory.IsTracked() {
+		return nil
+	}
+
+	// the inventory saga rejects the order on the recorded rejection, the command itself succeeds
+	if err := inventory.ReserveStock(ctx, command.OrderID, command.Quantity); err != nil {
+		if !errors.Is(err, aggregate.ErrInsufficientStock) {
+			return err
+		}
+		if err := inventory.RejectReservation(ctx, command.OrderID, command.Quantity); err != nil {
+			return err
+		}
+	}
+
+	return c.es.Save(ctx, inventory)
+}
+
+type releaseStockCommandHandler struct {
+	baseCommandHandler
+}
+
+func NewReleaseStockCommandHandler(log logger.Logger, config *config.Config, es store.AggregateStore) *releaseStockCommandHandler {
+	return &releaseStockCommandHandler{baseCommandHandler{log: log, config: config, es: es}}
+}
+
+func (c *releaseStockCommandHandler) Handle(ctx context.Context, command *ReleaseStockCommand) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "releaseStockCommandHandler.Handle")
+	defer span.Finish()
+	span.LogFields(log.String("SKU", command.GetAggregateID()), log.String("OrderID", command.OrderID))
+
+	inventory, err := aggregate.LoadInventoryAggregate(ctx, c.es, command.GetAggregateID())
+	if err != nil {
+		return err
+	}
+
+	if err := inventory.ReleaseStock(ctx, command.OrderID); err != nil {
+		return err
+	}
+
+	return c.es.Save(ctx, inventory)
+}
+
+type commitStockCommandHandler struct {
+	baseCommandHandler
+}
+
+func NewCommitStockCommandHandler(log logger.Logger, config *config.Config, es store.AggregateStore) *commitStockCommandHandler {
+	return &commitStockCommandHandler{baseCommandHandler{log: log, config: config, es: es}}
+}
+
+func (c *commitStockCommandHandler) Handle(ctx context.Context, command *CommitStockCommand) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "commitStockCommandHandler.Handle")
+	defer span.Finish()
+	span.LogFields(log.String("SKU", command.GetAggregateID()), log.String("OrderID", command.OrderID))
+
+	inventory, err := aggregate.LoadInventoryAggregate(ctx, c.es, command.GetAggregateID())
+	if err != nil {
+		return err
+	}
+
+	if err := inventory.CommitStock(ctx, command.OrderID); err != nil {
+		return err
+	}
+
+	return c.es.Save(ctx, inventory)
+}
+
+type registerCustomerCommandHandler struct {
+	baseCommandHandler
+}
+
+func NewRegisterCustomerCommandHandler(log logger.Logger, config *config.Config, es store.AggregateStore) *registerCustomerCommandHandler {
+	return &registerCustomerCommandHandler{baseCommandHandler{log: log, config: config, es: es}}
+}
+
+func (c *registerCustomerCommandHandler) Handle(ctx context.Context, command *RegisterCustomerCommand) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "registerCustomerCommandHandler.Handle")
+	defer span.Finish()
+	span.LogFields(log.String("CustomerID", command.GetAggregateID()))
+
+	customer, err := aggregate.LoadCustomerAggregate(ctx, c.es, command.GetAggregateID())
+	if err != nil {
+		return err
+	}
+
+	if err := customer.Register(ctx, command.Email, command.Name); err != nil {
+		return err
+	}
+
+	return c.es.Save(ctx, customer)
+}
+
+type changeCustomerEmailCommandHandler struct {
+	baseCommandHandler
+}
+
+func NewChangeCustomerEmailCommandHandler(log logger.Logger, config *config.Config, es store.AggregateStore) *changeCustomerEmailCommandHandler {
+	return &changeCustomerEmailCommandHandler{baseCommandHandler{log: log, config: config, es: es}}
+}
+
+func (c *changeCustomerEmailCommandHandler) Handle(ctx context.Context, command *ChangeCustomerEmailCommand) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "changeCustomerEmailCommandHandler.Handle")
+	defer span.Finish()
+	span.LogFields(log.String("CustomerID", command.GetAggregateID()))
+
+	customer, err := aggregate.LoadCustomerAggregate(ctx, c.es, command.GetAggregateID())
+	if err != nil {
+		return err
+	}
+
+	if err := customer.ChangeEmail(ctx, command.Email); err != nil {
+		return err
+	}
+
+	return c.es.Save(ctx, customer)
+}
+
+type saveCustomerAddressCommandHandler struct {
+	baseCommandHandler
+}
+
+func NewSaveCustomerAddressCommandHandler(log logger.Logger, config *config.Config, es store.AggregateStore) *saveCustomerAddressCommandHandler {
+	return &saveCustomerAddressCommandHandler{baseCommandHandler{log: log, config: config, es: es}}
+}
+
+func (c *saveCustomerAddressCommandHandler) Handle(ctx context.Context, command *SaveCustomerAddressCommand) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "saveCustomerAddressCommandHandler.Handle")
+	defer span.Finish()
+	span.LogFields(log.String("CustomerID", command.GetAggregateID()))
+
+	customer, err := aggregate.LoadCustomerAggregate(ctx, c.es, command.GetAggregateID())
+	if err != nil {
+		return err
+	}
+
+	if err := customer.SaveAddress(ctx, command.Address); err != nil {
+		return err
+	}
+
+	return c.es.Save(ctx, customer)
+}
+
+type removeCustomerAddressCommandHandler struct {
+	baseCommandHandler
+}
+
+func NewRemoveCustomerAddressCommandHandler(log logger.Logger, config *config.Config, es store.AggregateStore) *removeCustomerAddressCommandHandler {
+	return &removeCustomerAddressCommandHandler{baseCommandHandler{log: log, config: config, es: es}}
+}
+
+func (c *removeCustomerAddressCommandHandler) Handle(ctx context.Context, command *RemoveCustomerAddressCommand) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "removeCustomerAddressCommandHandler.Handle")
+	defer span.Finish()
+	span.LogFields(log.String("CustomerID", command.GetAggregateID()))
+
+	customer, err := aggregate.LoadCustomerAggregate(ctx, c.es, command.GetAggregateID())
+	if err != nil {
+		return err
+	}
+
+	if err := customer.RemoveAddress(ctx, command.AddressID); err != nil {
+		return err
+	}
+
+	return c.es.Save(ctx, customer)
+}
+
+type updateCustomerPreferencesCommandHandler struct {
+	baseCommandHandler
+}
+
+func NewUpdateCustomerPreferencesCommandHandler(log logger.Logger, config *config.Config, es store.AggregateStore) *updateCustomerPreferencesCommandHandler {
+	return &updateCustomerPreferencesCommandHandler{baseCommandHandler{log: log, config: config, es: es}}
+}
+
+func (c *updateCustomerPreferencesCommandHandler) Handle(ctx context.Context, command *UpdateCustomerPreferencesCommand) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "updateCustomerPreferencesCommandHandler.Handle")
+	defer span.Finish()
+	span.LogFields(log.String("CustomerID", command.GetAggregateID()))
+
+	customer, err := aggregate.LoadCustomerAggregate(ctx, c.es, command.GetAggregateID())
+	if err != nil {
+		return err
+	}
+
+	if err := customer.UpdatePreferences(ctx, command.Preferences); err != nil {
+		return err
+	}
+
+	return c.es.Save(ctx, customer)
+}
+
+// settleRefunds sends the saved pending refunds of the order to the payment provider and records their outcome.
+// The refund id keys the provider call, a refund left pending by an unknown outcome is sent again by the next refund
+// of the order instead of being refunded anew.
+func settleRefunds(ctx context.Context, aggregateStore store.AggregateStore, gateway payment.Gateway, order *aggregate.OrderAggregate) error {
+	var refundErr error
+	for _, refund := range slices.Clone(order.Order.PendingRefunds) {
+		err := gateway.Refund(ctx, order.Order.Payment.PaymentID, refund.RefundID, refund.Amount)
+		switch {
+		case err == nil:
+			if err := order.CompleteRefund(ctx, refund.RefundID); err != nil {
+				return err
+			}
+		case errors.Is(err, payment.ErrPaymentDeclined), errors.Is(err, payment.ErrPaymentNotFound):
+			if err := order.FailRefund(ctx, refund.RefundID, err.Error()); err != nil {
+				return err
+			}
+			refundErr = errors.Wrapf(err, "gateway.Refund refundID: {%s}", refund.RefundID)
+		default:
+			// the provider may have refunded it, the refund stays pending
+			refundErr = errors.Wrapf(err, "gateway.Refund refundID: {%s}", refund.RefundID)
+		}
+	}
+
+	if err := aggregateStore.Save(ctx, order); err != nil {
+		return err
+	}
+	return refundErr
+}
+
+// resolveShopItems snapshots the catalog title and price of the ordered products,
+// the client only chooses the products and their quantities.
+func resolveShopItems(ctx context.Context, eventStore store.AggregateStore, items []*models.OrderItem) ([]*models.ShopItem, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "resolveShopItems")
+	defer span.Finish()
+
+	shopItems := make([]*models.ShopItem, 0, len(items))
+	for _, item := range items {
+		if item == nil || item.ProductID == "" {
+			return nil, aggregate.ErrShopItemIDRequired
+		}
+
+		product, err := aggregate.LoadProductAggregate(ctx, eventStore, item.ProductID)
+		if err != nil {
+			return nil, err
+		}
+
+		shopItem, err := product.ResolveShopItem(item.Quantity)
+		if err != nil {
+			return nil, err
+		}
+		shopItems = append(shopItems, shopItem)
+	}
+
+	return shopItems, nil
+}
+
+type createProductCommandHandler struct {
+	baseCommandHandler
+}
+
+func NewCreateProductCommandHandler(log logger.Logger, config *config.Config, es store.AggregateStore) *createProductCommandHandler {
+	return &createProductCommandHandler{baseCommandHandler{log: log, config: config, es: es}}
+}
+
+func (c *createProductCommandHandler) Handle(ctx context.Context, command *CreateProductCommand) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "createProductCommandHandler.Handle")
+	defer span.Finish()
+	span.LogFields(log.String("ProductID", command.GetAggregateID()))
+
+	product, err := aggregate.LoadProductAggregate(ctx, c.es, command.GetAggregateID())
+	if err != nil {
+		return err
+	}
+
+	if err := product.CreateProduct(ctx, command.Title, command.Description, command.Price, command.TaxCategory); err != nil {
+		return err
+	}
+
+	return c.es.Save(ctx, product)
+}
+
+type repriceProductCommandHandler struct {
+	baseCommandHandler
+}
+
+func NewRepriceProductCommandHandler(log logger.Logger, config *config.Config, es store.AggregateStore) *repriceProductCommandHandler {
+	return &repriceProductCommandHandler{baseCommandHandler{log: log, config: config, es: es}}
+}
+
+func (c *repriceProductCommandHandler) Handle(ctx context.Context, command *RepriceProductCommand) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "repriceProductCommandHandler.Handle")
+	defer span.Finish()
+	span.LogFields(log.String("ProductID", command.GetAggregateID()))
+
+	product, err := aggregate.LoadProductAggregate(ctx, c.es, command.GetAggregateID())
+	if err != nil {
+		return err
+	}
+
+	if err := product.Reprice(ctx, command.Price); err != nil {
+		return err
+	}
+
+	return c.es.Save(ctx, product)
+}
+
+type discontinueProductCommandHandler struct {
+	baseCommandHandler
+}
+
+func NewDiscontinueProductCommandHandler(log logger.Logger, config *config.Config, es store.AggregateStore) *discontinueProductCommandHandler {
+	return &discontinueProductCommandHandler{baseCommandHandler{log: log, config: config, es: es}}
+}
+
+func (c *discontinueProductCommandHandler) Handle(ctx context.Context, command *DiscontinueProductCommand) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "discontinueProductCommandHandler.Handle")
+	defer span.Finish()
+	span.LogFields(log.String("ProductID", command.GetAggregateID()))
+
+	product, err := aggregate.LoadProductAggregate(ctx, c.es, command.GetAggregateID())
+	if err != nil {
+		return err
+	}
+
+	if err := product.Discontinue(ctx, command.Reason); err != nil {
+		return err
+	}
+
+	return c.es.Save(ctx, product)
+}
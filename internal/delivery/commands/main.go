@@ -3,29 +3,143 @@ package commands
 type OrderCommand struct {
 	CreateOrder                createOrderHandler
 	OrderPaid                  payOrderCommandHandler
+	PaymentWebhook             paymentWebhookCommandHandler
 	SubmitOrder                submitOrderCommandHandler
 	UpdateOrder                updateShoppingCartCommandHandler
 	CancelOrder                cancelOrderCommandHandler
+	RejectOrder                rejectOrderCommandHandler
+	RemindPayment              remindPaymentCommandHandler
 	CompleteOrder              completeOrderCommandHandler
 	ChangeOrderDeliveryAddress changeDeliveryAddressCommandHandler
+	RefundOrder                refundOrderCommandHandler
+	RequestReturn              requestReturnCommandHandler
+	ApproveReturn              approveReturnCommandHandler
+	RejectReturn               rejectReturnCommandHandler
+	ReceiveReturn              receiveReturnCommandHandler
+	CreateShipment             createShipmentCommandHandler
+	PackShipment               packShipmentCommandHandler
+	DispatchShipment           dispatchShipmentCommandHandler
+	DeliverShipment            deliverShipmentCommandHandler
+	AddItem                    addItemCommandHandler
+	RemoveItem                 removeItemCommandHandler
+	ChangeItemQuantity         changeItemQuantityCommandHandler
+	ApplyCoupon                applyCouponCommandHandler
+	RemoveCoupon               removeCouponCommandHandler
 }
 
 func New(
 	createOrder createOrderHandler,
 	orderPaid payOrderCommandHandler,
+	paymentWebhook paymentWebhookCommandHandler,
 	submitOrder submitOrderCommandHandler,
 	updateOrder updateShoppingCartCommandHandler,
 	cancelOrder cancelOrderCommandHandler,
+	rejectOrder rejectOrderCommandHandler,
+	remindPayment remindPaymentCommandHandler,
 	completeOrder completeOrderCommandHandler,
 	changeOrderDeliveryAddress changeDeliveryAddressCommandHandler,
+	refundOrder refundOrderCommandHandler,
+	requestReturn requestReturnCommandHandler,
+	approveReturn approveReturnCommandHandler,
+	rejectReturn rejectReturnCommandHandler,
+	receiveReturn receiveReturnCommandHandler,
+	createShipment createShipmentCommandHandler,
+	packShipment packShipmentCommandHandler,
+	dispatchShipment dispatchShipmentCommandHandler,
+	deliverShipment deliverShipmentCommandHandler,
+	addItem addItemCommandHandler,
+	removeItem removeItemCommandHandler,
+	changeItemQuantity changeItemQuantityCommandHandler,
+	applyCoupon applyCouponCommandHandler,
+	removeCoupon removeCouponCommandHandler,
 ) *OrderCommand {
 	return &OrderCommand{
 		CreateOrder:                createOrder,
 		OrderPaid:                  orderPaid,
+		PaymentWebhook:             paymentWebhook,
 		SubmitOrder:                submitOrder,
 		UpdateOrder:                updateOrder,
 		CancelOrder:                cancelOrder,
+		RejectOrder:                rejectOrder,
+		RemindPayment:              remindPayment,
 		CompleteOrder:              completeOrder,
 		ChangeOrderDeliveryAddress: changeOrderDeliveryAddress,
+		RefundOrder:                refundOrder,
+		RequestReturn:              requestReturn,
+		ApproveReturn:              approveReturn,
+		RejectReturn:               rejectReturn,
+		ReceiveReturn:              receiveReturn,
+		CreateShipment:             createShipment,
+		PackShipment:               packShipment,
+		DispatchShipment:           dispatchShipment,
+		DeliverShipment:            deliverShipment,
+		AddItem:                    addItem,
+		RemoveItem:                 removeItem,
+		ChangeItemQuantity:         changeItemQuantity,
+		ApplyCoupon:                applyCoupon,
+		RemoveCoupon:               removeCoupon,
+	}
+}
+
+type InventoryCommand struct {
+	AddStock     addStockCommandHandler
+	ReserveStock reserveStockCommandHandler
+	ReleaseStock releaseStockCommandHandler
+	CommitStock  commitStockCommandHandler
+}
+
+func NewInventoryCommand(
+	addStock addStockCommandHandler,
+	reserveStock reserveStockCommandHandler,
+	releaseStock releaseStockCommandHandler,
+	commitStock commitStockCommandHandler,
+) *InventoryCommand {
+	return &InventoryCommand{
+		AddStock:     addStock,
+		ReserveStock: reserveStock,
+		ReleaseStock: releaseStock,
+		CommitStock:  commitStock,
+	}
+}
+
+type CustomerCommand struct {
+	RegisterCustomer          registerCustomerCommandHandler
+	ChangeCustomerEmail       changeCustomerEmailCommandHandler
+	SaveCustomerAddress       saveCustomerAddressCommandHandler
+	RemoveCustomerAddress     removeCustomerAddressCommandHandler
+	UpdateCustomerPreferences updateCustomerPreferencesCommandHandler
+}
+
+func NewCustomerCommand(
+	registerCustomer registerCustomerCommandHandler,
+	changeCustomerEmail changeCustomerEmailCommandHandler,
+	saveCustomerAddress saveCustomerAddressCommandHandler,
+	removeCustomerAddress removeCustomerAddressCommandHandler,
+	updateCustomerPreferences updateCustomerPreferencesCommandHandler,
+) *CustomerCommand {
+	return &CustomerCommand{
+		RegisterCustomer:          registerCustomer,
+		ChangeCustomerEmail:       changeCustomerEmail,
+		SaveCustomerAddress:       saveCustomerAddress,
+		RemoveCustomerAddress:     removeCustomerAddress,
+		UpdateCustomerPreferences: updateCustomerPreferences,
+	}
+}
+
+type ProductCommand struct {
+	CreateProduct      createProductCommandHandler
+	RepriceProduct     repriceProductCommandHandler
+	DiscontinueProduct discontinueProductCommandHandler
+}
+
+func NewProductCommand(
+	createProduct createProductCommandHandler,
+	repriceProduct repriceProductCommandHandler,
+	discontinueProduct discontinueProductCommandHandler,
+) *ProductCommand {
+	return &ProductCommand{
+		CreateProduct:      createProduct,
+		RepriceProduct:     repriceProduct,
+		DiscontinueProduct: discontinueProduct,
 	}
 }
@@ -0,0 +1,48 @@
+// Package domain classifies the errors of the domain, every error has a stable code clients can rely on
+// and a kind telling how the apis answer it.
+package domain
+
+import "github.com/pkg/errors"
+
+type Kind string
+
+const (
+	// KindNotFound the error names something that does not exist.
+	KindNotFound Kind = "not_found"
+	// KindConflict the request is valid but not in the current state of the aggregate.
+	KindConflict Kind = "conflict"
+	// KindInvalid the request breaks an invariant of the aggregate, sending it again can not succeed.
+	KindInvalid Kind = "invalid"
+)
+
+// Error domain error, Code is stable while Message may change.
+type Error struct {
+	Kind    Kind
+	Code    string
+	Message string
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+func NewNotFoundError(code string, message string) error {
+	return &Error{Kind: KindNotFound, Code: code, Message: message}
+}
+
+func NewConflictError(code string, message string) error {
+	return &Error{Kind: KindConflict, Code: code, Message: message}
+}
+
+func NewInvalidError(code string, message string) error {
+	return &Error{Kind: KindInvalid, Code: code, Message: message}
+}
+
+// AsError domain error err wraps, the outermost one when several are wrapped.
+func AsError(err error) (*Error, bool) {
+	var domainErr *Error
+	if errors.As(err, &domainErr) {
+		return domainErr, true
+	}
+	return nil, false
+}
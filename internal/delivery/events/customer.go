@@ -0,0 +1,84 @@
+package events
+
+import (
+	"time"
+
+	"github.com/wassef911/eventually/internal/delivery/models"
+	"github.com/wassef911/eventually/internal/infrastructure/es"
+)
+
+const (
+	CustomerRegistered         = "CUSTOMER_REGISTERED"
+	CustomerEmailChanged       = "CUSTOMER_EMAIL_CHANGED"
+	CustomerAddressSaved       = "CUSTOMER_ADDRESS_SAVED"
+	CustomerAddressRemoved     = "CUSTOMER_ADDRESS_REMOVED"
+	CustomerPreferencesUpdated = "CUSTOMER_PREFERENCES_UPDATED"
+)
+
+type CustomerRegisteredEvent struct {
+	Email        string    `json:"email"`
+	Name         string    `json:"name"`
+	RegisteredAt time.Time `json:"registeredAt"`
+}
+
+func NewCustomerRegisteredEvent(aggregate es.Aggregate, email string, name string, registeredAt time.Time) (es.Event, error) {
+	eventData := CustomerRegisteredEvent{Email: email, Name: name, RegisteredAt: registeredAt}
+	event := es.NewBaseEvent(aggregate, CustomerRegistered)
+	if err := event.SetJsonData(&eventData); err != nil {
+		return es.Event{}, err
+	}
+	return event, nil
+}
+
+type CustomerEmailChangedEvent struct {
+	Email         string `json:"email"`
+	PreviousEmail string `json:"previousEmail"`
+}
+
+func NewCustomerEmailChangedEvent(aggregate es.Aggregate, email string, previousEmail string) (es.Event, error) {
+	eventData := CustomerEmailChangedEvent{Email: email, PreviousEmail: previousEmail}
+	event := es.NewBaseEvent(aggregate, CustomerEmailChanged)
+	if err := event.SetJsonData(&eventData); err != nil {
+		return es.Event{}, err
+	}
+	return event, nil
+}
+
+type CustomerAddressSavedEvent struct {
+	models.SavedAddress
+}
+
+func NewCustomerAddressSavedEvent(aggregate es.Aggregate, address models.SavedAddress) (es.Event, error) {
+	eventData := CustomerAddressSavedEvent{SavedAddress: address}
+	event := es.NewBaseEvent(aggregate, CustomerAddressSaved)
+	if err := event.SetJsonData(&eventData); err != nil {
+		return es.Event{}, err
+	}
+	return event, nil
+}
+
+type CustomerAddressRemovedEvent struct {
+	AddressID string `json:"addressId"`
+}
+
+func NewCustomerAddressRemovedEvent(aggregate es.Aggregate, addressID string) (es.Event, error) {
+	eventData := CustomerAddressRemovedEvent{AddressID: addressID}
+	event := es.NewBaseEvent(aggregate, CustomerAddressRemoved)
+	if err := event.SetJsonData(&eventData); err != nil {
+		return es.Event{}, err
+	}
+	return event, nil
+}
+
+type CustomerPreferencesUpdatedEvent struct {
+	models.CustomerPreferences
+}
+
+func NewCustomerPreferencesUpdatedEvent(aggregate es.Aggregate, preferences models.CustomerPreferences) (es.Event, error) {
+	eventData := CustomerPreferencesUpdatedEvent{CustomerPreferences: preferences}
+	event := es.NewBaseEvent(aggregate, CustomerPreferencesUpdated)
+	if err := event.SetJsonData(&eventData); err != nil {
+		return es.Event{}, err
+	}
+	return event, nil
+}
@@ -0,0 +1,87 @@
+package events
+
+import (
+	"github.com/wassef911/eventually/internal/infrastructure/es"
+)
+
+const (
+	StockAdded     = "STOCK_ADDED"
+	StockReserved  = "STOCK_RESERVED"
+	StockReleased  = "STOCK_RELEASED"
+	StockCommitted = "STOCK_COMMITTED"
+
+	StockReservationRejected = "STOCK_RESERVATION_REJECTED"
+)
+
+type StockAddedEvent struct {
+	Quantity uint64 `json:"quantity"`
+}
+
+func NewStockAddedEvent(aggregate es.Aggregate, quantity uint64) (es.Event, error) {
+	eventData := StockAddedEvent{Quantity: quantity}
+	event := es.NewBaseEvent(aggregate, StockAdded)
+	if err := event.SetJsonData(&eventData); err != nil {
+		return es.Event{}, err
+	}
+	return event, nil
+}
+
+// StockReservedEvent Quantity is the total reserved for the order, not a delta, so replays are idempotent.
+type StockReservedEvent struct {
+	OrderID  string `json:"orderId"`
+	Quantity uint64 `json:"quantity"`
+}
+
+func NewStockReservedEvent(aggregate es.Aggregate, orderID string, quantity uint64) (es.Event, error) {
+	eventData := StockReservedEvent{OrderID: orderID, Quantity: quantity}
+	event := es.NewBaseEvent(aggregate, StockReserved)
+	if err := event.SetJsonData(&eventData); err != nil {
+		return es.Event{}, err
+	}
+	return event, nil
+}
+
+type StockReleasedEvent struct {
+	OrderID  string `json:"orderId"`
+	Quantity uint64 `json:"quantity"`
+}
+
+func NewStockReleasedEvent(aggregate es.Aggregate, orderID string, quantity uint64) (es.Event, error) {
+	eventData := StockReleasedEvent{OrderID: orderID, Quantity: quantity}
+	event := es.NewBaseEvent(aggregate, StockReleased)
+	if err := event.SetJsonData(&eventData); err != nil {
+		return es.Event{}, err
+	}
+	return event, nil
+}
+
+// StockCommittedEvent the reserved Quantity left the warehouse with the order.
+type StockCommittedEvent struct {
+	OrderID  string `json:"orderId"`
+	Quantity uint64 `json:"quantity"`
+}
+
+func NewStockCommittedEvent(aggregate es.Aggregate, orderID string, quantity uint64) (es.Event, error) {
+	eventData := StockCommittedEvent{OrderID: orderID, Quantity: quantity}
+	event := es.NewBaseEvent(aggregate, StockCommitted)
+	if err := event.SetJsonData(&eventData); err != nil {
+		return es.Event{}, err
+	}
+	return event, nil
+}
+
+// StockReservationRejectedEvent the order asked for more than the Available stock, its reservation is unchanged.
+type StockReservationRejectedEvent struct {
+	OrderID   string `json:"orderId"`
+	Quantity  uint64 `json:"quantity"`
+	Available uint64 `json:"available"`
+}
+
+func NewStockReservationRejectedEvent(aggregate es.Aggregate, orderID string, quantity uint64, available uint64) (es.Event, error) {
+	eventData := StockReservationRejectedEvent{OrderID: orderID, Quantity: quantity, Available: available}
+	event := es.NewBaseEvent(aggregate, StockReservationRejected)
+	if err := event.SetJsonData(&eventData); err != nil {
+		return es.Event{}, err
+	}
+	return event, nil
+}
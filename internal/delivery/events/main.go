@@ -8,26 +8,53 @@ import (
 )
 
 const (
-	OrderCreated           = "ORDER_CREATED"
-	OrderPaid              = "ORDER_PAID"
-	OrderSubmitted         = "ORDER_SUBMITTED"
-	OrderCompleted         = "ORDER_COMPLETED"
-	OrderCanceled          = "ORDER_CANCELED"
-	ShoppingCartUpdated    = "SHOPPING_CART_UPDATED"
-	DeliveryAddressChanged = "DELIVERY_ADDRESS_CHANGED"
+	OrderCreated            = "ORDER_CREATED"
+	OrderPaid               = "ORDER_PAID"
+	OrderSubmitted          = "ORDER_SUBMITTED"
+	OrderCompleted          = "ORDER_COMPLETED"
+	OrderCanceled           = "ORDER_CANCELED"
+	OrderRejected           = "ORDER_REJECTED"
+	ShoppingCartUpdated     = "SHOPPING_CART_UPDATED"
+	DeliveryAddressChanged  = "DELIVERY_ADDRESS_CHANGED"
+	OrderRefunded           = "ORDER_REFUNDED"
+	RefundRequested         = "REFUND_REQUESTED"
+	RefundFailed            = "REFUND_FAILED"
+	ReturnRequested         = "RETURN_REQUESTED"
+	ReturnApproved          = "RETURN_APPROVED"
+	ReturnRejected          = "RETURN_REJECTED"
+	ReturnReceived          = "RETURN_RECEIVED"
+	ShipmentCreated         = "SHIPMENT_CREATED"
+	ShipmentPacked          = "SHIPMENT_PACKED"
+	ShipmentDispatched      = "SHIPMENT_DISPATCHED"
+	ShipmentDelivered       = "SHIPMENT_DELIVERED"
+	ShopItemAdded           = "SHOP_ITEM_ADDED"
+	ShopItemRemoved         = "SHOP_ITEM_REMOVED"
+	ShopItemQuantityChanged = "SHOP_ITEM_QUANTITY_CHANGED"
+	CouponApplied           = "COUPON_APPLIED"
+	CouponRemoved           = "COUPON_REMOVED"
+	PaymentReminderSent     = "PAYMENT_REMINDER_SENT"
+	PaymentAttempted        = "PAYMENT_ATTEMPTED"
+	PaymentAuthorized       = "PAYMENT_AUTHORIZED"
+	PaymentFailed           = "PAYMENT_FAILED"
 )
 
+// OrderCreatedEvent Pricing is missing from events recorded before coupons were introduced,
+// CustomerID is empty for guest orders.
 type OrderCreatedEvent struct {
-	ShopItems       []*models.ShopItem `json:"shopItems" bson:"shopItems,omitempty"`
-	AccountEmail    string             `json:"accountEmail" bson:"accountEmail,omitempty"`
-	DeliveryAddress string             `json:"deliveryAddress" bson:"deliveryAddress,omitempty"`
+	ShopItems       []*models.ShopItem   `json:"shopItems" bson:"shopItems,omitempty"`
+	CustomerID      string               `json:"customerId,omitempty" bson:"customerId,omitempty"`
+	AccountEmail    string               `json:"accountEmail" bson:"accountEmail,omitempty"`
+	DeliveryAddress models.Address       `json:"deliveryAddress" bson:"deliveryAddress,omitempty"`
+	Pricing         *models.OrderPricing `json:"pricing,omitempty" bson:"pricing,omitempty"`
 }
 
-func NewOrderCreatedEvent(aggregate es.Aggregate, shopItems []*models.ShopItem, accountEmail, deliveryAddress string) (es.Event, error) {
+func NewOrderCreatedEvent(aggregate es.Aggregate, shopItems []*models.ShopItem, customerID string, accountEmail string, deliveryAddress models.Address, pricing models.OrderPricing) (es.Event, error) {
 	eventData := OrderCreatedEvent{
 		ShopItems:       shopItems,
+		CustomerID:      customerID,
 		AccountEmail:    accountEmail,
 		DeliveryAddress: deliveryAddress,
+		Pricing:         &pricing,
 	}
 	event := es.NewBaseEvent(aggregate, OrderCreated)
 	if err := event.SetJsonData(&eventData); err != nil {
@@ -49,11 +76,12 @@ func NewSubmitOrderEvent(aggregate es.Aggregate) (es.Event, error) {
 }
 
 type ShoppingCartUpdatedEvent struct {
-	ShopItems []*models.ShopItem `json:"shopItems" bson:"shopItems,omitempty"`
+	ShopItems []*models.ShopItem   `json:"shopItems" bson:"shopItems,omitempty"`
+	Pricing   *models.OrderPricing `json:"pricing,omitempty" bson:"pricing,omitempty"`
 }
 
-func NewShoppingCartUpdatedEvent(aggregate es.Aggregate, shopItems []*models.ShopItem) (es.Event, error) {
-	eventData := ShoppingCartUpdatedEvent{ShopItems: shopItems}
+func NewShoppingCartUpdatedEvent(aggregate es.Aggregate, shopItems []*models.ShopItem, pricing models.OrderPricing) (es.Event, error) {
+	eventData := ShoppingCartUpdatedEvent{ShopItems: shopItems, Pricing: &pricing}
 	event := es.NewBaseEvent(aggregate, ShoppingCartUpdated)
 	if err := event.SetJsonData(&eventData); err != nil {
 		return es.Event{}, err
@@ -61,12 +89,14 @@ func NewShoppingCartUpdatedEvent(aggregate es.Aggregate, shopItems []*models.Sho
 	return event, nil
 }
 
+// OrderDeliveryAddressChangedEvent Pricing is set when the new address changed the taxes of the cart.
 type OrderDeliveryAddressChangedEvent struct {
-	DeliveryAddress string `json:"deliveryAddress" bson:"deliveryAddress,omitempty"`
+	DeliveryAddress models.Address       `json:"deliveryAddress" bson:"deliveryAddress,omitempty"`
+	Pricing         *models.OrderPricing `json:"pricing,omitempty" bson:"pricing,omitempty"`
 }
 
-func NewDeliveryAddressChangedEvent(aggregate es.Aggregate, deliveryAddress string) (es.Event, error) {
-	eventData := OrderDeliveryAddressChangedEvent{DeliveryAddress: deliveryAddress}
+func NewDeliveryAddressChangedEvent(aggregate es.Aggregate, deliveryAddress models.Address, pricing *models.OrderPricing) (es.Event, error) {
+	eventData := OrderDeliveryAddressChangedEvent{DeliveryAddress: deliveryAddress, Pricing: pricing}
 	event := es.NewBaseEvent(aggregate, DeliveryAddressChanged)
 	if err := event.SetJsonData(&eventData); err != nil {
 		return es.Event{}, err
@@ -88,6 +118,20 @@ func NewOrderCanceledEvent(aggregate es.Aggregate, cancelReason string) (es.Even
 	return event, nil
 }
 
+// OrderRejectedEvent the order could not be fulfilled, e.g. items out of stock.
+type OrderRejectedEvent struct {
+	RejectReason string `json:"rejectReason"`
+}
+
+func NewOrderRejectedEvent(aggregate es.Aggregate, rejectReason string) (es.Event, error) {
+	eventData := OrderRejectedEvent{RejectReason: rejectReason}
+	event := es.NewBaseEvent(aggregate, OrderRejected)
+	if err := event.SetJsonData(&eventData); err != nil {
+		return es.Event{}, err
+	}
+	return event, nil
+}
+
 type OrderCompletedEvent struct {
 	DeliveryTimestamp time.Time `json:"deliveryTimestamp"`
 }
@@ -101,3 +145,300 @@ func NewOrderCompletedEvent(aggregate es.Aggregate, deliveryTimestamp time.Time)
 	}
 	return event, nil
 }
+
+type OrderRefundedEvent struct {
+	models.Refund
+	TotalRefunded models.Money `json:"totalRefunded"`
+	FullyRefunded bool         `json:"fullyRefunded"`
+}
+
+func NewOrderRefundedEvent(aggregate es.Aggregate, refund models.Refund, totalRefunded models.Money, fullyRefunded bool) (es.Event, error) {
+	eventData := OrderRefundedEvent{Refund: refund, TotalRefunded: totalRefunded, FullyRefunded: fullyRefunded}
+	event := es.NewBaseEvent(aggregate, OrderRefunded)
+	if err := event.SetJsonData(&eventData); err != nil {
+		return es.Event{}, err
+	}
+	return event, nil
+}
+
+// RefundRequestedEvent refund saved before the payment provider is called, OrderRefunded records it once refunded.
+type RefundRequestedEvent struct {
+	models.Refund
+}
+
+func NewRefundRequestedEvent(aggregate es.Aggregate, refund models.Refund) (es.Event, error) {
+	eventData := RefundRequestedEvent{Refund: refund}
+	event := es.NewBaseEvent(aggregate, RefundRequested)
+	if err := event.SetJsonData(&eventData); err != nil {
+		return es.Event{}, err
+	}
+	return event, nil
+}
+
+type RefundFailedEvent struct {
+	RefundID string `json:"refundId"`
+	Reason   string `json:"reason"`
+}
+
+func NewRefundFailedEvent(aggregate es.Aggregate, refundID string, reason string) (es.Event, error) {
+	eventData := RefundFailedEvent{RefundID: refundID, Reason: reason}
+	event := es.NewBaseEvent(aggregate, RefundFailed)
+	if err := event.SetJsonData(&eventData); err != nil {
+		return es.Event{}, err
+	}
+	return event, nil
+}
+
+type ReturnRequestedEvent struct {
+	ReturnID    string               `json:"returnId"`
+	Items       []*models.ReturnItem `json:"items"`
+	Reason      string               `json:"reason"`
+	RequestedAt time.Time            `json:"requestedAt"`
+}
+
+func NewReturnRequestedEvent(aggregate es.Aggregate, returnID string, items []*models.ReturnItem, reason string, requestedAt time.Time) (es.Event, error) {
+	eventData := ReturnRequestedEvent{ReturnID: returnID, Items: items, Reason: reason, RequestedAt: requestedAt}
+	event := es.NewBaseEvent(aggregate, ReturnRequested)
+	if err := event.SetJsonData(&eventData); err != nil {
+		return es.Event{}, err
+	}
+	return event, nil
+}
+
+type ReturnApprovedEvent struct {
+	ReturnID string `json:"returnId"`
+}
+
+func NewReturnApprovedEvent(aggregate es.Aggregate, returnID string) (es.Event, error) {
+	eventData := ReturnApprovedEvent{ReturnID: returnID}
+	event := es.NewBaseEvent(aggregate, ReturnApproved)
+	if err := event.SetJsonData(&eventData); err != nil {
+		return es.Event{}, err
+	}
+	return event, nil
+}
+
+type ReturnRejectedEvent struct {
+	ReturnID     string `json:"returnId"`
+	RejectReason string `json:"rejectReason"`
+}
+
+func NewReturnRejectedEvent(aggregate es.Aggregate, returnID string, rejectReason string) (es.Event, error) {
+	eventData := ReturnRejectedEvent{ReturnID: returnID, RejectReason: rejectReason}
+	event := es.NewBaseEvent(aggregate, ReturnRejected)
+	if err := event.SetJsonData(&eventData); err != nil {
+		return es.Event{}, err
+	}
+	return event, nil
+}
+
+type ReturnReceivedEvent struct {
+	ReturnID   string    `json:"returnId"`
+	RefundID   string    `json:"refundId"`
+	ReceivedAt time.Time `json:"receivedAt"`
+}
+
+func NewReturnReceivedEvent(aggregate es.Aggregate, returnID string, refundID string, receivedAt time.Time) (es.Event, error) {
+	eventData := ReturnReceivedEvent{ReturnID: returnID, RefundID: refundID, ReceivedAt: receivedAt}
+	event := es.NewBaseEvent(aggregate, ReturnReceived)
+	if err := event.SetJsonData(&eventData); err != nil {
+		return es.Event{}, err
+	}
+	return event, nil
+}
+
+type ShipmentCreatedEvent struct {
+	ShipmentID string                 `json:"shipmentId"`
+	Items      []*models.ShipmentItem `json:"items"`
+	CreatedAt  time.Time              `json:"createdAt"`
+}
+
+func NewShipmentCreatedEvent(aggregate es.Aggregate, shipmentID string, items []*models.ShipmentItem, createdAt time.Time) (es.Event, error) {
+	eventData := ShipmentCreatedEvent{ShipmentID: shipmentID, Items: items, CreatedAt: createdAt}
+	event := es.NewBaseEvent(aggregate, ShipmentCreated)
+	if err := event.SetJsonData(&eventData); err != nil {
+		return es.Event{}, err
+	}
+	return event, nil
+}
+
+type ShipmentPackedEvent struct {
+	ShipmentID string    `json:"shipmentId"`
+	PackedAt   time.Time `json:"packedAt"`
+}
+
+func NewShipmentPackedEvent(aggregate es.Aggregate, shipmentID string, packedAt time.Time) (es.Event, error) {
+	eventData := ShipmentPackedEvent{ShipmentID: shipmentID, PackedAt: packedAt}
+	event := es.NewBaseEvent(aggregate, ShipmentPacked)
+	if err := event.SetJsonData(&eventData); err != nil {
+		return es.Event{}, err
+	}
+	return event, nil
+}
+
+type ShipmentDispatchedEvent struct {
+	ShipmentID     string    `json:"shipmentId"`
+	Carrier        string    `json:"carrier"`
+	TrackingNumber string    `json:"trackingNumber"`
+	DispatchedAt   time.Time `json:"dispatchedAt"`
+}
+
+func NewShipmentDispatchedEvent(aggregate es.Aggregate, shipmentID, carrier, trackingNumber string, dispatchedAt time.Time) (es.Event, error) {
+	eventData := ShipmentDispatchedEvent{ShipmentID: shipmentID, Carrier: carrier, TrackingNumber: trackingNumber, DispatchedAt: dispatchedAt}
+	event := es.NewBaseEvent(aggregate, ShipmentDispatched)
+	if err := event.SetJsonData(&eventData); err != nil {
+		return es.Event{}, err
+	}
+	return event, nil
+}
+
+type ShipmentDeliveredEvent struct {
+	ShipmentID  string    `json:"shipmentId"`
+	DeliveredAt time.Time `json:"deliveredAt"`
+}
+
+func NewShipmentDeliveredEvent(aggregate es.Aggregate, shipmentID string, deliveredAt time.Time) (es.Event, error) {
+	eventData := ShipmentDeliveredEvent{ShipmentID: shipmentID, DeliveredAt: deliveredAt}
+	event := es.NewBaseEvent(aggregate, ShipmentDelivered)
+	if err := event.SetJsonData(&eventData); err != nil {
+		return es.Event{}, err
+	}
+	return event, nil
+}
+
+// ShopItemAddedEvent TotalPrice is the order total after the change, Pricing its breakdown.
+type ShopItemAddedEvent struct {
+	ShopItem   *models.ShopItem     `json:"shopItem"`
+	TotalPrice models.Money         `json:"totalPrice"`
+	Pricing    *models.OrderPricing `json:"pricing,omitempty"`
+}
+
+func NewShopItemAddedEvent(aggregate es.Aggregate, shopItem *models.ShopItem, pricing models.OrderPricing) (es.Event, error) {
+	eventData := ShopItemAddedEvent{ShopItem: shopItem, TotalPrice: pricing.Total, Pricing: &pricing}
+	event := es.NewBaseEvent(aggregate, ShopItemAdded)
+	if err := event.SetJsonData(&eventData); err != nil {
+		return es.Event{}, err
+	}
+	return event, nil
+}
+
+type ShopItemRemovedEvent struct {
+	ShopItemID string               `json:"shopItemId"`
+	TotalPrice models.Money         `json:"totalPrice"`
+	Pricing    *models.OrderPricing `json:"pricing,omitempty"`
+}
+
+func NewShopItemRemovedEvent(aggregate es.Aggregate, shopItemID string, pricing models.OrderPricing) (es.Event, error) {
+	eventData := ShopItemRemovedEvent{ShopItemID: shopItemID, TotalPrice: pricing.Total, Pricing: &pricing}
+	event := es.NewBaseEvent(aggregate, ShopItemRemoved)
+	if err := event.SetJsonData(&eventData); err != nil {
+		return es.Event{}, err
+	}
+	return event, nil
+}
+
+type ShopItemQuantityChangedEvent struct {
+	ShopItemID string               `json:"shopItemId"`
+	Quantity   uint64               `json:"quantity"`
+	TotalPrice models.Money         `json:"totalPrice"`
+	Pricing    *models.OrderPricing `json:"pricing,omitempty"`
+}
+
+func NewShopItemQuantityChangedEvent(aggregate es.Aggregate, shopItemID string, quantity uint64, pricing models.OrderPricing) (es.Event, error) {
+	eventData := ShopItemQuantityChangedEvent{ShopItemID: shopItemID, Quantity: quantity, TotalPrice: pricing.Total, Pricing: &pricing}
+	event := es.NewBaseEvent(aggregate, ShopItemQuantityChanged)
+	if err := event.SetJsonData(&eventData); err != nil {
+		return es.Event{}, err
+	}
+	return event, nil
+}
+
+// CouponAppliedEvent records the coupon definition at redemption time and the resulting pricing.
+type CouponAppliedEvent struct {
+	Coupon  models.Coupon       `json:"coupon"`
+	Pricing models.OrderPricing `json:"pricing"`
+}
+
+func NewCouponAppliedEvent(aggregate es.Aggregate, coupon models.Coupon, pricing models.OrderPricing) (es.Event, error) {
+	eventData := CouponAppliedEvent{Coupon: coupon, Pricing: pricing}
+	event := es.NewBaseEvent(aggregate, CouponApplied)
+	if err := event.SetJsonData(&eventData); err != nil {
+		return es.Event{}, err
+	}
+	return event, nil
+}
+
+type CouponRemovedEvent struct {
+	Code    string              `json:"code"`
+	Pricing models.OrderPricing `json:"pricing"`
+}
+
+func NewCouponRemovedEvent(aggregate es.Aggregate, code string, pricing models.OrderPricing) (es.Event, error) {
+	eventData := CouponRemovedEvent{Code: code, Pricing: pricing}
+	event := es.NewBaseEvent(aggregate, CouponRemoved)
+	if err := event.SetJsonData(&eventData); err != nil {
+		return es.Event{}, err
+	}
+	return event, nil
+}
+
+// PaymentReminderSentEvent Reminder counts the reminders of the order, ExpiresAt is when an unpaid order is canceled.
+type PaymentReminderSentEvent struct {
+	Reminder  int       `json:"reminder"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+func NewPaymentReminderSentEvent(aggregate es.Aggregate, reminder int, expiresAt time.Time) (es.Event, error) {
+	eventData := PaymentReminderSentEvent{Reminder: reminder, ExpiresAt: expiresAt}
+	event := es.NewBaseEvent(aggregate, PaymentReminderSent)
+	if err := event.SetJsonData(&eventData); err != nil {
+		return es.Event{}, err
+	}
+	return event, nil
+}
+
+type PaymentAttemptedEvent struct {
+	AttemptID string       `json:"attemptId"`
+	Provider  string       `json:"provider"`
+	Amount    models.Money `json:"amount"`
+	Timestamp time.Time    `json:"timestamp"`
+}
+
+func NewPaymentAttemptedEvent(aggregate es.Aggregate, attemptID string, provider string, amount models.Money, timestamp time.Time) (es.Event, error) {
+	eventData := PaymentAttemptedEvent{AttemptID: attemptID, Provider: provider, Amount: amount, Timestamp: timestamp}
+	event := es.NewBaseEvent(aggregate, PaymentAttempted)
+	if err := event.SetJsonData(&eventData); err != nil {
+		return es.Event{}, err
+	}
+	return event, nil
+}
+
+// PaymentAuthorizedEvent Pending authorizations are confirmed by the provider webhook.
+type PaymentAuthorizedEvent struct {
+	AttemptID string `json:"attemptId"`
+	PaymentID string `json:"paymentId"`
+	Pending   bool   `json:"pending"`
+}
+
+func NewPaymentAuthorizedEvent(aggregate es.Aggregate, attemptID string, paymentID string, pending bool) (es.Event, error) {
+	eventData := PaymentAuthorizedEvent{AttemptID: attemptID, PaymentID: paymentID, Pending: pending}
+	event := es.NewBaseEvent(aggregate, PaymentAuthorized)
+	if err := event.SetJsonData(&eventData); err != nil {
+		return es.Event{}, err
+	}
+	return event, nil
+}
+
+type PaymentFailedEvent struct {
+	AttemptID string `json:"attemptId"`
+	Reason    string `json:"reason"`
+}
+
+func NewPaymentFailedEvent(aggregate es.Aggregate, attemptID string, reason string) (es.Event, error) {
+	eventData := PaymentFailedEvent{AttemptID: attemptID, Reason: reason}
+	event := es.NewBaseEvent(aggregate, PaymentFailed)
+	if err := event.SetJsonData(&eventData); err != nil {
+		return es.Event{}, err
+	}
+	return event, nil
+}
@@ -0,0 +1,60 @@
+package events
+
+import (
+	"time"
+
+	"github.com/wassef911/eventually/internal/delivery/models"
+	"github.com/wassef911/eventually/internal/infrastructure/es"
+)
+
+const (
+	ProductCreated      = "PRODUCT_CREATED"
+	ProductRepriced     = "PRODUCT_REPRICED"
+	ProductDiscontinued = "PRODUCT_DISCONTINUED"
+)
+
+type ProductCreatedEvent struct {
+	Title       string       `json:"title"`
+	Description string       `json:"description"`
+	Price       models.Money `json:"price"`
+	TaxCategory string       `json:"taxCategory,omitempty"`
+	CreatedAt   time.Time    `json:"createdAt"`
+}
+
+func NewProductCreatedEvent(aggregate es.Aggregate, title string, description string, price models.Money, taxCategory string, createdAt time.Time) (es.Event, error) {
+	eventData := ProductCreatedEvent{Title: title, Description: description, Price: price, TaxCategory: taxCategory, CreatedAt: createdAt}
+	event := es.NewBaseEvent(aggregate, ProductCreated)
+	if err := event.SetJsonData(&eventData); err != nil {
+		return es.Event{}, err
+	}
+	return event, nil
+}
+
+type ProductRepricedEvent struct {
+	Price         models.Money `json:"price"`
+	PreviousPrice models.Money `json:"previousPrice"`
+	RepricedAt    time.Time    `json:"repricedAt"`
+}
+
+func NewProductRepricedEvent(aggregate es.Aggregate, price models.Money, previousPrice models.Money, repricedAt time.Time) (es.Event, error) {
+	eventData := ProductRepricedEvent{Price: price, PreviousPrice: previousPrice, RepricedAt: repricedAt}
+	event := es.NewBaseEvent(aggregate, ProductRepriced)
+	if err := event.SetJsonData(&eventData); err != nil {
+		return es.Event{}, err
+	}
+	return event, nil
+}
+
+type ProductDiscontinuedEvent struct {
+	Reason         string    `json:"reason,omitempty"`
+	DiscontinuedAt time.Time `json:"discontinuedAt"`
+}
+
+func NewProductDiscontinuedEvent(aggregate es.Aggregate, reason string, discontinuedAt time.Time) (es.Event, error) {
+	eventData := ProductDiscontinuedEvent{Reason: reason, DiscontinuedAt: discontinuedAt}
+	event := es.NewBaseEvent(aggregate, ProductDiscontinued)
+	if err := event.SetJsonData(&eventData); err != nil {
+		return es.Event{}, err
+	}
+	return event, nil
+}
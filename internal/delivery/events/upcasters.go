@@ -0,0 +1,97 @@
+package events
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/pkg/errors"
+
+	"github.com/wassef911/eventually/internal/delivery/models"
+	"github.com/wassef911/eventually/internal/infrastructure/es"
+)
+
+const (
+	shopItemsField       = "shopItems"
+	deliveryAddressField = "deliveryAddress"
+)
+
+func init() {
+	es.RegisterUpcaster(OrderCreated, upcastFloatShopItemPrices)
+	es.RegisterUpcaster(OrderCreated, upcastStringDeliveryAddress)
+	es.RegisterUpcaster(ShoppingCartUpdated, upcastFloatShopItemPrices)
+	es.RegisterUpcaster(DeliveryAddressChanged, upcastStringDeliveryAddress)
+}
+
+// upcastFloatShopItemPrices converts legacy shop items whose price was a float64 in major units
+// into models.Money in minor units of models.DefaultCurrency.
+func upcastFloatShopItemPrices(evt es.Event) (es.Event, error) {
+	var data map[string]json.RawMessage
+	if err := evt.GetJsonData(&data); err != nil {
+		return es.Event{}, errors.Wrap(err, "GetJsonData")
+	}
+
+	rawShopItems, ok := data[shopItemsField]
+	if !ok || bytes.Equal(rawShopItems, []byte("null")) {
+		return evt, nil
+	}
+
+	var shopItems []map[string]json.RawMessage
+	if err := json.Unmarshal(rawShopItems, &shopItems); err != nil {
+		return es.Event{}, errors.Wrap(err, "json.Unmarshal")
+	}
+
+	upcasted := false
+	for _, item := range shopItems {
+		var legacyPrice float64
+		if err := json.Unmarshal(item["price"], &legacyPrice); err != nil {
+			continue
+		}
+
+		price, err := json.Marshal(models.NewMoneyFromFloat(legacyPrice, models.DefaultCurrency))
+		if err != nil {
+			return es.Event{}, errors.Wrap(err, "json.Marshal")
+		}
+		item["price"] = price
+		upcasted = true
+	}
+
+	if !upcasted {
+		return evt, nil
+	}
+
+	shopItemsBytes, err := json.Marshal(shopItems)
+	if err != nil {
+		return es.Event{}, errors.Wrap(err, "json.Marshal")
+	}
+	data[shopItemsField] = shopItemsBytes
+
+	if err := evt.SetJsonData(data); err != nil {
+		return es.Event{}, errors.Wrap(err, "SetJsonData")
+	}
+	return evt, nil
+}
+
+// upcastStringDeliveryAddress converts a legacy free-text delivery address into models.Address,
+// the whole text is kept in Line1 since its parts can't be told apart reliably.
+func upcastStringDeliveryAddress(evt es.Event) (es.Event, error) {
+	var data map[string]json.RawMessage
+	if err := evt.GetJsonData(&data); err != nil {
+		return es.Event{}, errors.Wrap(err, "GetJsonData")
+	}
+
+	var legacyAddress string
+	if err := json.Unmarshal(data[deliveryAddressField], &legacyAddress); err != nil {
+		return evt, nil
+	}
+
+	address, err := json.Marshal(models.Address{Line1: legacyAddress})
+	if err != nil {
+		return es.Event{}, errors.Wrap(err, "json.Marshal")
+	}
+	data[deliveryAddressField] = address
+
+	if err := evt.SetJsonData(data); err != nil {
+		return es.Event{}, errors.Wrap(err, "SetJsonData")
+	}
+	return evt, nil
+}
@@ -0,0 +1,49 @@
+package events_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/wassef911/eventually/internal/delivery/events"
+	"github.com/wassef911/eventually/internal/delivery/models"
+	"github.com/wassef911/eventually/internal/infrastructure/es"
+)
+
+func TestUpcastLegacyFloatPrices(t *testing.T) {
+	legacy := es.Event{
+		EventType: events.OrderCreated,
+		Data:      []byte(`{"shopItems":[{"id":"item1","title":"Item 1","quantity":2,"price":10.5}],"accountEmail":"test@example.com","deliveryAddress":"123 Main St"}`),
+	}
+
+	upcasted, err := es.Upcast(legacy)
+	assert.NoError(t, err)
+
+	var eventData events.OrderCreatedEvent
+	assert.NoError(t, upcasted.GetJsonData(&eventData))
+	assert.Equal(t, models.NewMoney(1050, models.DefaultCurrency), eventData.ShopItems[0].Price)
+	assert.Equal(t, "test@example.com", eventData.AccountEmail)
+	assert.Equal(t, models.Address{Line1: "123 Main St"}, eventData.DeliveryAddress)
+
+	again, err := es.Upcast(upcasted)
+	assert.NoError(t, err)
+	assert.JSONEq(t, string(upcasted.Data), string(again.Data))
+}
+
+func TestUpcastLegacyDeliveryAddress(t *testing.T) {
+	legacy := es.Event{
+		EventType: events.DeliveryAddressChanged,
+		Data:      []byte(`{"deliveryAddress":"456 Elm St"}`),
+	}
+
+	upcasted, err := es.Upcast(legacy)
+	assert.NoError(t, err)
+
+	var eventData events.OrderDeliveryAddressChangedEvent
+	assert.NoError(t, upcasted.GetJsonData(&eventData))
+	assert.Equal(t, models.Address{Line1: "456 Elm St"}, eventData.DeliveryAddress)
+
+	again, err := es.Upcast(upcasted)
+	assert.NoError(t, err)
+	assert.JSONEq(t, string(upcasted.Data), string(again.Data))
+}
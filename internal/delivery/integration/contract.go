@@ -0,0 +1,207 @@
+// Package integration holds the public contract of the events published to other services.
+// The payloads are versioned and independent of the internal event structs, an internal
+// change must not change a published payload, a breaking change gets a new Version.
+package integration
+
+import (
+	"encoding/json"
+	"time"
+)
+
+const (
+	Source = "orders"
+	// Version of the payloads published by this service.
+	Version = 1
+
+	OrderCreated                = "order.created"
+	OrderCartReplaced           = "order.cart_replaced"
+	OrderItemAdded              = "order.item_added"
+	OrderItemRemoved            = "order.item_removed"
+	OrderItemQuantityChanged    = "order.item_quantity_changed"
+	OrderCouponApplied          = "order.coupon_applied"
+	OrderCouponRemoved          = "order.coupon_removed"
+	OrderDeliveryAddressChanged = "order.delivery_address_changed"
+	OrderPaymentFailed          = "order.payment_failed"
+	OrderPaid                   = "order.paid"
+	OrderSubmitted              = "order.submitted"
+	OrderCanceled               = "order.canceled"
+	OrderRejected               = "order.rejected"
+	OrderCompleted              = "order.completed"
+	OrderRefunded               = "order.refunded"
+	OrderReturnRequested        = "order.return_requested"
+	OrderReturnApproved         = "order.return_approved"
+	OrderReturnRejected         = "order.return_rejected"
+	OrderReturnReceived         = "order.return_received"
+	OrderShipmentCreated        = "order.shipment_created"
+	OrderShipmentPacked         = "order.shipment_packed"
+	OrderShipmentDispatched     = "order.shipment_dispatched"
+	OrderShipmentDelivered      = "order.shipment_delivered"
+)
+
+// EventTypes every type published by this service.
+var EventTypes = []string{
+	OrderCreated,
+	OrderCartReplaced,
+	OrderItemAdded,
+	OrderItemRemoved,
+	OrderItemQuantityChanged,
+	OrderCouponApplied,
+	OrderCouponRemoved,
+	OrderDeliveryAddressChanged,
+	OrderPaymentFailed,
+	OrderPaid,
+	OrderSubmitted,
+	OrderCanceled,
+	OrderRejected,
+	OrderCompleted,
+	OrderRefunded,
+	OrderReturnRequested,
+	OrderReturnApproved,
+	OrderReturnRejected,
+	OrderReturnReceived,
+	OrderShipmentCreated,
+	OrderShipmentPacked,
+	OrderShipmentDispatched,
+	OrderShipmentDelivered,
+}
+
+// IsEventType reports whether eventType is published by this service.
+func IsEventType(eventType string) bool {
+	for _, published := range EventTypes {
+		if published == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// Event envelope of every published event, ID is the id of the domain event and stays the same
+// when the event is published again, consumers deduplicate on it. Tenant is empty for the default tenant.
+type Event struct {
+	ID         string          `json:"id"`
+	Tenant     string          `json:"tenant,omitempty"`
+	Type       string          `json:"type"`
+	Version    int             `json:"version"`
+	Source     string          `json:"source"`
+	OrderID    string          `json:"orderId"`
+	Sequence   int64           `json:"sequence"`
+	OccurredAt time.Time       `json:"occurredAt"`
+	Data       json.RawMessage `json:"data"`
+}
+
+type Money struct {
+	Amount   int64  `json:"amount"`
+	Currency string `json:"currency"`
+}
+
+// Totals price breakdown of the order after the event, missing for orders created before pricing was recorded.
+type Totals struct {
+	Subtotal Money `json:"subtotal"`
+	Discount Money `json:"discount"`
+	Shipping Money `json:"shipping"`
+	Tax      Money `json:"tax"`
+	Total    Money `json:"total"`
+}
+
+type LineItem struct {
+	ProductID string `json:"productId"`
+	Title     string `json:"title"`
+	Quantity  uint64 `json:"quantity"`
+	UnitPrice Money  `json:"unitPrice"`
+}
+
+type ItemQuantity struct {
+	ProductID string `json:"productId"`
+	Quantity  uint64 `json:"quantity"`
+}
+
+type Address struct {
+	Recipient  string `json:"recipient"`
+	Line1      string `json:"line1"`
+	Line2      string `json:"line2,omitempty"`
+	City       string `json:"city"`
+	PostalCode string `json:"postalCode,omitempty"`
+	Region     string `json:"region,omitempty"`
+	Country    string `json:"country"`
+}
+
+type OrderCreatedData struct {
+	CustomerID      string     `json:"customerId,omitempty"`
+	AccountEmail    string     `json:"accountEmail"`
+	Items           []LineItem `json:"items"`
+	DeliveryAddress Address    `json:"deliveryAddress"`
+	Totals          *Totals    `json:"totals,omitempty"`
+}
+
+type OrderCartReplacedData struct {
+	Items  []LineItem `json:"items"`
+	Totals *Totals    `json:"totals,omitempty"`
+}
+
+type OrderItemAddedData struct {
+	Item   LineItem `json:"item"`
+	Totals *Totals  `json:"totals,omitempty"`
+}
+
+type OrderItemRemovedData struct {
+	ProductID string  `json:"productId"`
+	Totals    *Totals `json:"totals,omitempty"`
+}
+
+type OrderItemQuantityChangedData struct {
+	ProductID string  `json:"productId"`
+	Quantity  uint64  `json:"quantity"`
+	Totals    *Totals `json:"totals,omitempty"`
+}
+
+type OrderCouponData struct {
+	Code   string  `json:"code"`
+	Totals *Totals `json:"totals,omitempty"`
+}
+
+type OrderDeliveryAddressChangedData struct {
+	DeliveryAddress Address `json:"deliveryAddress"`
+	Totals          *Totals `json:"totals,omitempty"`
+}
+
+type OrderPaymentFailedData struct {
+	Reason string `json:"reason"`
+}
+
+type OrderPaidData struct {
+	PaymentID string    `json:"paymentId"`
+	Amount    *Money    `json:"amount,omitempty"`
+	PaidAt    time.Time `json:"paidAt"`
+}
+
+type OrderReasonData struct {
+	Reason string `json:"reason"`
+}
+
+type OrderCompletedData struct {
+	DeliveredAt time.Time `json:"deliveredAt"`
+}
+
+type OrderRefundedData struct {
+	RefundID      string         `json:"refundId"`
+	Amount        Money          `json:"amount"`
+	Items         []ItemQuantity `json:"items,omitempty"`
+	Reason        string         `json:"reason"`
+	TotalRefunded Money          `json:"totalRefunded"`
+	FullyRefunded bool           `json:"fullyRefunded"`
+}
+
+type OrderReturnData struct {
+	ReturnID string         `json:"returnId"`
+	Items    []ItemQuantity `json:"items,omitempty"`
+	Reason   string         `json:"reason,omitempty"`
+	RefundID string         `json:"refundId,omitempty"`
+}
+
+type OrderShipmentData struct {
+	ShipmentID     string         `json:"shipmentId"`
+	Items          []ItemQuantity `json:"items,omitempty"`
+	Carrier        string         `json:"carrier,omitempty"`
+	TrackingNumber string         `json:"trackingNumber,omitempty"`
+	At             time.Time      `json:"at"`
+}
@@ -0,0 +1,339 @@
+package integration
+
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+
+	"github.com/wassef911/eventually/internal/delivery/aggregate"
+	"github.com/wassef911/eventually/internal/delivery/events"
+	"github.com/wassef911/eventually/internal/delivery/models"
+	"github.com/wassef911/eventually/internal/infrastructure/es"
+)
+
+// FromDomainEvent maps an order event to its integration event, events internal to the order
+// (payment attempts and reminders) are not published and return nil.
+func FromDomainEvent(evt es.Event) (*Event, error) {
+	mapper, exists := mappers[evt.GetEventType()]
+	if !exists {
+		return nil, nil
+	}
+
+	eventType, data, err := mapper(evt)
+	if err != nil {
+		return nil, errors.Wrapf(err, "eventType: {%s}", evt.GetEventType())
+	}
+
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return nil, errors.Wrap(err, "json.Marshal")
+	}
+
+	return &Event{
+		ID:         evt.GetEventID(),
+		Tenant:     evt.GetTenant(),
+		Type:       eventType,
+		Version:    Version,
+		Source:     Source,
+		OrderID:    aggregate.GetOrderAggregateID(evt.GetAggregateID()),
+		Sequence:   evt.GetVersion(),
+		OccurredAt: evt.GetTimeStamp(),
+		Data:       payload,
+	}, nil
+}
+
+type mapper func(evt es.Event) (string, interface{}, error)
+
+var mappers = map[string]mapper{
+	events.OrderCreated:            mapOrderCreated,
+	events.ShoppingCartUpdated:     mapCartReplaced,
+	events.ShopItemAdded:           mapItemAdded,
+	events.ShopItemRemoved:         mapItemRemoved,
+	events.ShopItemQuantityChanged: mapItemQuantityChanged,
+	events.CouponApplied:           mapCouponApplied,
+	events.CouponRemoved:           mapCouponRemoved,
+	events.DeliveryAddressChanged:  mapDeliveryAddressChanged,
+	events.PaymentFailed:           mapPaymentFailed,
+	events.OrderPaid:               mapOrderPaid,
+	events.OrderSubmitted:          mapOrderSubmitted,
+	events.OrderCanceled:           mapOrderCanceled,
+	events.OrderRejected:           mapOrderRejected,
+	events.OrderCompleted:          mapOrderCompleted,
+	events.OrderRefunded:           mapOrderRefunded,
+	events.ReturnRequested:         mapReturnRequested,
+	events.ReturnApproved:          mapReturnApproved,
+	events.ReturnRejected:          mapReturnRejected,
+	events.ReturnReceived:          mapReturnReceived,
+	events.ShipmentCreated:         mapShipmentCreated,
+	events.ShipmentPacked:          mapShipmentPacked,
+	events.ShipmentDispatched:      mapShipmentDispatched,
+	events.ShipmentDelivered:       mapShipmentDelivered,
+}
+
+func mapOrderCreated(evt es.Event) (string, interface{}, error) {
+	var eventData events.OrderCreatedEvent
+	if err := evt.GetJsonData(&eventData); err != nil {
+		return "", nil, err
+	}
+	return OrderCreated, OrderCreatedData{
+		CustomerID:      eventData.CustomerID,
+		AccountEmail:    eventData.AccountEmail,
+		Items:           lineItemsFrom(eventData.ShopItems),
+		DeliveryAddress: addressFrom(eventData.DeliveryAddress),
+		Totals:          totalsFrom(eventData.Pricing),
+	}, nil
+}
+
+func mapCartReplaced(evt es.Event) (string, interface{}, error) {
+	var eventData events.ShoppingCartUpdatedEvent
+	if err := evt.GetJsonData(&eventData); err != nil {
+		return "", nil, err
+	}
+	return OrderCartReplaced, OrderCartReplacedData{Items: lineItemsFrom(eventData.ShopItems), Totals: totalsFrom(eventData.Pricing)}, nil
+}
+
+func mapItemAdded(evt es.Event) (string, interface{}, error) {
+	var eventData events.ShopItemAddedEvent
+	if err := evt.GetJsonData(&eventData); err != nil {
+		return "", nil, err
+	}
+	return OrderItemAdded, OrderItemAddedData{Item: lineItemFrom(eventData.ShopItem), Totals: totalsFrom(eventData.Pricing)}, nil
+}
+
+func mapItemRemoved(evt es.Event) (string, interface{}, error) {
+	var eventData events.ShopItemRemovedEvent
+	if err := evt.GetJsonData(&eventData); err != nil {
+		return "", nil, err
+	}
+	return OrderItemRemoved, OrderItemRemovedData{ProductID: eventData.ShopItemID, Totals: totalsFrom(eventData.Pricing)}, nil
+}
+
+func mapItemQuantityChanged(evt es.Event) (string, interface{}, error) {
+	var eventData events.ShopItemQuantityChangedEvent
+	if err := evt.GetJsonData(&eventData); err != nil {
+		return "", nil, err
+	}
+	return OrderItemQuantityChanged, OrderItemQuantityChangedData{
+		ProductID: eventData.ShopItemID,
+		Quantity:  eventData.Quantity,
+		Totals:    totalsFrom(eventData.Pricing),
+	}, nil
+}
+
+func mapCouponApplied(evt es.Event) (string, interface{}, error) {
+	var eventData events.CouponAppliedEvent
+	if err := evt.GetJsonData(&eventData); err != nil {
+		return "", nil, err
+	}
+	return OrderCouponApplied, OrderCouponData{Code: eventData.Coupon.Code, Totals: totalsFrom(&eventData.Pricing)}, nil
+}
+
+func mapCouponRemoved(evt es.Event) (string, interface{}, error) {
+	var eventData events.CouponRemovedEvent
+	if err := evt.GetJsonData(&eventData); err != nil {
+		return "", nil, err
+	}
+	return OrderCouponRemoved, OrderCouponData{Code: eventData.Code, Totals: totalsFrom(&eventData.Pricing)}, nil
+}
+
+func mapDeliveryAddressChanged(evt es.Event) (string, interface{}, error) {
+	var eventData events.OrderDeliveryAddressChangedEvent
+	if err := evt.GetJsonData(&eventData); err != nil {
+		return "", nil, err
+	}
+	return OrderDeliveryAddressChanged, OrderDeliveryAddressChangedData{
+		DeliveryAddress: addressFrom(eventData.DeliveryAddress),
+		Totals:          totalsFrom(eventData.Pricing),
+	}, nil
+}
+
+func mapPaymentFailed(evt es.Event) (string, interface{}, error) {
+	var eventData events.PaymentFailedEvent
+	if err := evt.GetJsonData(&eventData); err != nil {
+		return "", nil, err
+	}
+	return OrderPaymentFailed, OrderPaymentFailedData{Reason: eventData.Reason}, nil
+}
+
+func mapOrderPaid(evt es.Event) (string, interface{}, error) {
+	var payment models.Payment
+	if err := evt.GetJsonData(&payment); err != nil {
+		return "", nil, err
+	}
+
+	data := OrderPaidData{PaymentID: payment.PaymentID, PaidAt: payment.Timestamp}
+	// payments recorded before the gateway integration have no amount
+	if payment.Amount.Currency != "" {
+		amount := moneyFrom(payment.Amount)
+		data.Amount = &amount
+	}
+	return OrderPaid, data, nil
+}
+
+func mapOrderSubmitted(evt es.Event) (string, interface{}, error) {
+	return OrderSubmitted, struct{}{}, nil
+}
+
+func mapOrderCanceled(evt es.Event) (string, interface{}, error) {
+	var eventData events.OrderCanceledEvent
+	if err := evt.GetJsonData(&eventData); err != nil {
+		return "", nil, err
+	}
+	return OrderCanceled, OrderReasonData{Reason: eventData.CancelReason}, nil
+}
+
+func mapOrderRejected(evt es.Event) (string, interface{}, error) {
+	var eventData events.OrderRejectedEvent
+	if err := evt.GetJsonData(&eventData); err != nil {
+		return "", nil, err
+	}
+	return OrderRejected, OrderReasonData{Reason: eventData.RejectReason}, nil
+}
+
+func mapOrderCompleted(evt es.Event) (string, interface{}, error) {
+	var eventData events.OrderCompletedEvent
+	if err := evt.GetJsonData(&eventData); err != nil {
+		return "", nil, err
+	}
+	return OrderCompleted, OrderCompletedData{DeliveredAt: eventData.DeliveryTimestamp}, nil
+}
+
+func mapOrderRefunded(evt es.Event) (string, interface{}, error) {
+	var eventData events.OrderRefundedEvent
+	if err := evt.GetJsonData(&eventData); err != nil {
+		return "", nil, err
+	}
+
+	items := make([]ItemQuantity, 0, len(eventData.Items))
+	for _, item := range eventData.Items {
+		items = append(items, ItemQuantity{ProductID: item.ShopItemID, Quantity: item.Quantity})
+	}
+	return OrderRefunded, OrderRefundedData{
+		RefundID:      eventData.RefundID,
+		Amount:        moneyFrom(eventData.Amount),
+		Items:         items,
+		Reason:        eventData.Reason,
+		TotalRefunded: moneyFrom(eventData.TotalRefunded),
+		FullyRefunded: eventData.FullyRefunded,
+	}, nil
+}
+
+func mapReturnRequested(evt es.Event) (string, interface{}, error) {
+	var eventData events.ReturnRequestedEvent
+	if err := evt.GetJsonData(&eventData); err != nil {
+		return "", nil, err
+	}
+
+	items := make([]ItemQuantity, 0, len(eventData.Items))
+	for _, item := range eventData.Items {
+		items = append(items, ItemQuantity{ProductID: item.ShopItemID, Quantity: item.Quantity})
+	}
+	return OrderReturnRequested, OrderReturnData{ReturnID: eventData.ReturnID, Items: items, Reason: eventData.Reason}, nil
+}
+
+func mapReturnApproved(evt es.Event) (string, interface{}, error) {
+	var eventData events.ReturnApprovedEvent
+	if err := evt.GetJsonData(&eventData); err != nil {
+		return "", nil, err
+	}
+	return OrderReturnApproved, OrderReturnData{ReturnID: eventData.ReturnID}, nil
+}
+
+func mapReturnRejected(evt es.Event) (string, interface{}, error) {
+	var eventData events.ReturnRejectedEvent
+	if err := evt.GetJsonData(&eventData); err != nil {
+		return "", nil, err
+	}
+	return OrderReturnRejected, OrderReturnData{ReturnID: eventData.ReturnID, Reason: eventData.RejectReason}, nil
+}
+
+func mapReturnReceived(evt es.Event) (string, interface{}, error) {
+	var eventData events.ReturnReceivedEvent
+	if err := evt.GetJsonData(&eventData); err != nil {
+		return "", nil, err
+	}
+	return OrderReturnReceived, OrderReturnData{ReturnID: eventData.ReturnID, RefundID: eventData.RefundID}, nil
+}
+
+func mapShipmentCreated(evt es.Event) (string, interface{}, error) {
+	var eventData events.ShipmentCreatedEvent
+	if err := evt.GetJsonData(&eventData); err != nil {
+		return "", nil, err
+	}
+
+	items := make([]ItemQuantity, 0, len(eventData.Items))
+	for _, item := range eventData.Items {
+		items = append(items, ItemQuantity{ProductID: item.ShopItemID, Quantity: item.Quantity})
+	}
+	return OrderShipmentCreated, OrderShipmentData{ShipmentID: eventData.ShipmentID, Items: items, At: eventData.CreatedAt}, nil
+}
+
+func mapShipmentPacked(evt es.Event) (string, interface{}, error) {
+	var eventData events.ShipmentPackedEvent
+	if err := evt.GetJsonData(&eventData); err != nil {
+		return "", nil, err
+	}
+	return OrderShipmentPacked, OrderShipmentData{ShipmentID: eventData.ShipmentID, At: eventData.PackedAt}, nil
+}
+
+func mapShipmentDispatched(evt es.Event) (string, interface{}, error) {
+	var eventData events.ShipmentDispatchedEvent
+	if err := evt.GetJsonData(&eventData); err != nil {
+		return "", nil, err
+	}
+	return OrderShipmentDispatched, OrderShipmentData{
+		ShipmentID:     eventData.ShipmentID,
+		Carrier:        eventData.Carrier,
+		TrackingNumber: eventData.TrackingNumber,
+		At:             eventData.DispatchedAt,
+	}, nil
+}
+
+func mapShipmentDelivered(evt es.Event) (string, interface{}, error) {
+	var eventData events.ShipmentDeliveredEvent
+	if err := evt.GetJsonData(&eventData); err != nil {
+		return "", nil, err
+	}
+	return OrderShipmentDelivered, OrderShipmentData{ShipmentID: eventData.ShipmentID, At: eventData.DeliveredAt}, nil
+}
+
+func moneyFrom(money models.Money) Money {
+	return Money{Amount: money.Amount, Currency: money.Currency}
+}
+
+func totalsFrom(pricing *models.OrderPricing) *Totals {
+	if pricing == nil {
+		return nil
+	}
+	return &Totals{
+		Subtotal: moneyFrom(pricing.Subtotal),
+		Discount: moneyFrom(pricing.DiscountTotal),
+		Shipping: moneyFrom(pricing.ShippingPrice),
+		Tax:      moneyFrom(pricing.TaxTotal),
+		Total:    moneyFrom(pricing.Total),
+	}
+}
+
+func lineItemFrom(item *models.ShopItem) LineItem {
+	return LineItem{ProductID: item.ID, Title: item.Title, Quantity: item.Quantity, UnitPrice: moneyFrom(item.Price)}
+}
+
+func lineItemsFrom(items []*models.ShopItem) []LineItem {
+	lineItems := make([]LineItem, 0, len(items))
+	for _, item := range items {
+		lineItems = append(lineItems, lineItemFrom(item))
+	}
+	return lineItems
+}
+
+func addressFrom(address models.Address) Address {
+	return Address{
+		Recipient:  address.Recipient,
+		Line1:      address.Line1,
+		Line2:      address.Line2,
+		City:       address.City,
+		PostalCode: address.PostalCode,
+		Region:     address.Region,
+		Country:    address.Country,
+	}
+}
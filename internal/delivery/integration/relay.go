@@ -0,0 +1,165 @@
+package integration
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/EventStore/EventStore-Client-Go/esdb"
+	"github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/log"
+	"github.com/pkg/errors"
+
+	"github.com/wassef911/eventually/internal/api/constants"
+	"github.com/wassef911/eventually/internal/infrastructure/es"
+	"github.com/wassef911/eventually/internal/infrastructure/messaging"
+	"github.com/wassef911/eventually/pkg/config"
+	"github.com/wassef911/eventually/pkg/logger"
+)
+
+const (
+	TypeHeader        = "type"
+	ContentTypeHeader = "content-type"
+	contentType       = "application/json"
+
+	minPublishBackoff = 100 * time.Millisecond
+	maxPublishBackoff = 5 * time.Second
+)
+
+// OutboxRelay publishes the order events recorded in the event store, the event store is the outbox
+// and the persistent subscription checkpoints what was published. An event is acknowledged only once
+// the broker accepted it, so every event is published at least once.
+type OutboxRelay struct {
+	log       logger.Logger
+	db        *esdb.Client
+	publisher messaging.Publisher
+	cfg       *config.Config
+}
+
+func NewOutboxRelay(log logger.Logger, db *esdb.Client, publisher messaging.Publisher, cfg *config.Config) *OutboxRelay {
+	return &OutboxRelay{log: log, db: db, publisher: publisher, cfg: cfg}
+}
+
+// Subscribe runs a single worker and retries a failed publish in place instead of Nacking it,
+// a Nack would let the next events of the order overtake it.
+func (o *OutboxRelay) Subscribe(ctx context.Context) error {
+	o.log.Infof("(starting outbox relay subscription) prefixes: {%+v}", []string{o.cfg.Subscriptions.OrderPrefix})
+
+	err := o.db.CreatePersistentSubscriptionAll(ctx, o.cfg.Subscriptions.OutboxGroupName, esdb.PersistentAllSubscriptionOptions{
+		Filter: &esdb.SubscriptionFilter{Type: esdb.StreamFilterType, Prefixes: []string{o.cfg.Subscriptions.OrderPrefix}},
+	})
+	if err != nil {
+		if subscriptionError, ok := err.(*esdb.PersistentSubscriptionError); !ok || ok && (subscriptionError.Code != 6) {
+			return err
+		}
+	}
+
+	stream, err := o.db.ConnectToPersistentSubscription(ctx, constants.EsAll, o.cfg.Subscriptions.OutboxGroupName, esdb.ConnectToPersistentSubscriptionOptions{})
+	if err != nil {
+		return err
+	}
+	defer stream.Close()
+
+	return o.ProcessEvents(ctx, stream)
+}
+
+func (o *OutboxRelay) ProcessEvents(ctx context.Context, stream *esdb.PersistentSubscription) error {
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		event := stream.Recv()
+
+		switch {
+		case event.SubscriptionDropped != nil:
+			return errors.Wrap(event.SubscriptionDropped.Error, "subscription dropped")
+
+		case event.EventAppeared != nil:
+			if err := o.processSingleEvent(ctx, stream, event.EventAppeared); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (o *OutboxRelay) processSingleEvent(ctx context.Context, stream *esdb.PersistentSubscription, event *esdb.ResolvedEvent) error {
+	o.log.ProjectionEvent("(OutboxRelay)", o.cfg.Subscriptions.OutboxGroupName, event, 0)
+
+	esEvent, err := es.Upcast(es.NewEventFromRecorded(event.Event))
+	if err == nil {
+		err = o.HandleEvent(ctx, esEvent)
+	}
+	if err != nil {
+		// only an event that can not be mapped or a canceled context end up here
+		o.log.Warnf("(OutboxRelay) [HandleEvent] eventType: {%s}, err: {%v}", event.Event.EventType, err)
+		if nackErr := stream.Nack(err.Error(), esdb.Nack_Retry, event); nackErr != nil {
+			return errors.Wrap(nackErr, "failed to Nack event")
+		}
+		return nil
+	}
+
+	if ackErr := stream.Ack(event); ackErr != nil {
+		return errors.Wrap(ackErr, "failed to Ack event")
+	}
+	return nil
+}
+
+// HandleEvent publishes the integration event of evt, retrying with backoff until the broker accepts it
+// or the context is canceled. Events without an integration event are skipped.
+func (o *OutboxRelay) HandleEvent(ctx context.Context, evt es.Event) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "OutboxRelay.HandleEvent")
+	defer span.Finish()
+	span.LogFields(log.String("AggregateID", evt.GetAggregateID()), log.String("EventType", evt.GetEventType()))
+
+	integrationEvent, err := FromDomainEvent(evt)
+	if err != nil {
+		return err
+	}
+	if integrationEvent == nil {
+		return nil
+	}
+
+	message, err := ToMessage(integrationEvent)
+	if err != nil {
+		return err
+	}
+
+	backoff := minPublishBackoff
+	for {
+		err := o.publisher.Publish(ctx, message)
+		if err == nil {
+			return nil
+		}
+		o.log.Warnf("(OutboxRelay) [Publish] eventID: {%s}, retry in: {%s}, err: {%v}", integrationEvent.ID, backoff, err)
+
+		select {
+		case <-ctx.Done():
+			return errors.Wrap(ctx.Err(), "publish canceled")
+		case <-time.After(backoff):
+		}
+
+		backoff *= 2
+		if backoff > maxPublishBackoff {
+			backoff = maxPublishBackoff
+		}
+	}
+}
+
+// ToMessage the order id is the message key, so the events of an order are delivered in order.
+func ToMessage(evt *Event) (messaging.Message, error) {
+	value, err := json.Marshal(evt)
+	if err != nil {
+		return messaging.Message{}, errors.Wrap(err, "json.Marshal")
+	}
+
+	return messaging.Message{
+		Key:   evt.OrderID,
+		Value: value,
+		Headers: map[string]string{
+			messaging.MessageIDHeader: evt.ID,
+			TypeHeader:                evt.Type,
+			ContentTypeHeader:         contentType,
+		},
+	}, nil
+}
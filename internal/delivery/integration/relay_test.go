@@ -0,0 +1,86 @@
+package integration_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wassef911/eventually/internal/delivery/aggregate"
+	"github.com/wassef911/eventually/internal/delivery/events"
+	"github.com/wassef911/eventually/internal/delivery/integration"
+	"github.com/wassef911/eventually/internal/delivery/models"
+	"github.com/wassef911/eventually/internal/infrastructure/messaging"
+	"github.com/wassef911/eventually/pkg/config"
+	"github.com/wassef911/eventually/pkg/logger"
+)
+
+func TestFromDomainEventOrderCreated(t *testing.T) {
+	order := aggregate.NewOrderAggregateWithID("order1")
+	items := []*models.ShopItem{{ID: "sku1", Title: "Mug", Quantity: 2, Price: models.Money{Amount: 500, Currency: "EUR"}}}
+	pricing := models.OrderPricing{
+		Subtotal: models.Money{Amount: 1000, Currency: "EUR"},
+		TaxTotal: models.Money{Amount: 200, Currency: "EUR"},
+		Total:    models.Money{Amount: 1200, Currency: "EUR"},
+	}
+	created, err := events.NewOrderCreatedEvent(order, items, "customer1", "jane@example.com", models.Address{City: "Tunis", Country: "TN"}, pricing)
+	require.NoError(t, err)
+
+	integrationEvent, err := integration.FromDomainEvent(created)
+	require.NoError(t, err)
+	require.NotNil(t, integrationEvent)
+	assert.Equal(t, created.GetEventID(), integrationEvent.ID)
+	assert.Equal(t, integration.OrderCreated, integrationEvent.Type)
+	assert.Equal(t, integration.Version, integrationEvent.Version)
+	assert.Equal(t, "order1", integrationEvent.OrderID)
+
+	var data integration.OrderCreatedData
+	require.NoError(t, json.Unmarshal(integrationEvent.Data, &data))
+	assert.Equal(t, "customer1", data.CustomerID)
+	require.Len(t, data.Items, 1)
+	assert.Equal(t, integration.LineItem{ProductID: "sku1", Title: "Mug", Quantity: 2, UnitPrice: integration.Money{Amount: 500, Currency: "EUR"}}, data.Items[0])
+	assert.Equal(t, "TN", data.DeliveryAddress.Country)
+	require.NotNil(t, data.Totals)
+	assert.Equal(t, integration.Money{Amount: 1200, Currency: "EUR"}, data.Totals.Total)
+}
+
+func TestFromDomainEventSkipsInternalEvents(t *testing.T) {
+	order := aggregate.NewOrderAggregateWithID("order1")
+	reminder, err := events.NewPaymentReminderSentEvent(order, 1, time.Now())
+	require.NoError(t, err)
+
+	integrationEvent, err := integration.FromDomainEvent(reminder)
+	require.NoError(t, err)
+	assert.Nil(t, integrationEvent)
+}
+
+func TestOutboxRelayRetriesUntilPublished(t *testing.T) {
+	appLogger := logger.NewAppLogger(&logger.Config{LogLevel: "fatal"})
+	appLogger.InitLogger()
+	publisher := messaging.NewMemoryPublisher()
+	publisher.SetErr(errors.New("broker unavailable"))
+	relay := integration.NewOutboxRelay(appLogger, nil, publisher, &config.Config{})
+
+	order := aggregate.NewOrderAggregateWithID("order1")
+	paid, err := events.NewOrderPaidEvent(order, &models.Payment{PaymentID: "payment1", Timestamp: time.Now()})
+	require.NoError(t, err)
+
+	go func() {
+		time.Sleep(150 * time.Millisecond)
+		publisher.SetErr(nil)
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	require.NoError(t, relay.HandleEvent(ctx, paid))
+
+	messages := publisher.Messages()
+	require.Len(t, messages, 1)
+	assert.Equal(t, "order1", messages[0].Key)
+	assert.Equal(t, paid.GetEventID(), messages[0].Headers[messaging.MessageIDHeader])
+	assert.Equal(t, integration.OrderPaid, messages[0].Headers[integration.TypeHeader])
+}
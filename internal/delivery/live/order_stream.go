@@ -0,0 +1,93 @@
+// Package live pushes the events of an order to connected clients as they are recorded.
+package live
+
+import (
+	"context"
+
+	"github.com/EventStore/EventStore-Client-Go/esdb"
+	"github.com/pkg/errors"
+
+	"github.com/wassef911/eventually/internal/delivery/aggregate"
+	"github.com/wassef911/eventually/internal/delivery/integration"
+	"github.com/wassef911/eventually/internal/infrastructure/es"
+	"github.com/wassef911/eventually/pkg/logger"
+)
+
+// FromStart version to follow an order from its first event.
+const FromStart int64 = -1
+
+// OrderStream follows an order with a catch-up subscription on its stream. Clients get the
+// public integration events, their Sequence is the event version and resumes the stream.
+type OrderStream struct {
+	log logger.Logger
+	db  *esdb.Client
+}
+
+func NewOrderStream(log logger.Logger, db *esdb.Client) *OrderStream {
+	return &OrderStream{log: log, db: db}
+}
+
+// Subscribe sends the events of the order recorded after version `after` until ctx is canceled.
+// The error channel receives the reason the subscription stopped, events internal to the order are skipped.
+func (s *OrderStream) Subscribe(ctx context.Context, orderID string, after int64) (<-chan *integration.Event, <-chan error) {
+	events := make(chan *integration.Event)
+	errCh := make(chan error, 1)
+
+	go func() {
+		defer close(events)
+		errCh <- s.follow(ctx, orderID, after, events)
+	}()
+
+	return events, errCh
+}
+
+func (s *OrderStream) follow(ctx context.Context, orderID string, after int64, events chan<- *integration.Event) error {
+	order := aggregate.NewOrderAggregateWithID(orderID)
+	order.SetTenant(es.TenantFromContext(ctx))
+	subscription, err := s.db.SubscribeToStream(ctx, order.GetID(), esdb.SubscribeToStreamOptions{From: streamPosition(after)})
+	if err != nil {
+		return errors.Wrap(err, "db.SubscribeToStream")
+	}
+	defer subscription.Close()
+
+	for {
+		event := subscription.Recv()
+
+		if event.SubscriptionDropped != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return errors.Wrap(event.SubscriptionDropped.Error, "subscription dropped")
+		}
+		if event.EventAppeared == nil {
+			continue
+		}
+
+		esEvent, err := es.Upcast(es.NewEventFromRecorded(event.EventAppeared.Event))
+		if err != nil {
+			return err
+		}
+
+		integrationEvent, err := integration.FromDomainEvent(esEvent)
+		if err != nil {
+			return err
+		}
+		if integrationEvent == nil {
+			continue
+		}
+
+		select {
+		case events <- integrationEvent:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// streamPosition the subscription starts after the given revision.
+func streamPosition(after int64) esdb.StreamPosition {
+	if after < 0 {
+		return esdb.Start{}
+	}
+	return esdb.Revision(uint64(after))
+}
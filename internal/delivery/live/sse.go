@@ -0,0 +1,47 @@
+package live
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/wassef911/eventually/internal/delivery/integration"
+)
+
+// LastEventIDHeader sent by browsers when an event source reconnects.
+const LastEventIDHeader = "Last-Event-ID"
+
+// ParseLastEventID version to resume after, FromStart when the client has not seen any event.
+func ParseLastEventID(value string) (int64, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return FromStart, nil
+	}
+
+	version, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || version < 0 {
+		return 0, errors.Errorf("invalid last event id: {%s}", value)
+	}
+	return version, nil
+}
+
+// WriteEvent writes evt as a server-sent event, its id is the event version.
+func WriteEvent(w io.Writer, evt *integration.Event) error {
+	data, err := json.Marshal(evt)
+	if err != nil {
+		return errors.Wrap(err, "json.Marshal")
+	}
+
+	_, err = fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", evt.Sequence, evt.Type, data)
+	return err
+}
+
+// WriteHeartbeat writes a comment line, it keeps idle connections open through proxies.
+func WriteHeartbeat(w io.Writer) error {
+	_, err := io.WriteString(w, ": heartbeat\n\n")
+	return err
+}
@@ -0,0 +1,45 @@
+package live_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wassef911/eventually/internal/delivery/integration"
+	"github.com/wassef911/eventually/internal/delivery/live"
+)
+
+func TestParseLastEventID(t *testing.T) {
+	after, err := live.ParseLastEventID("")
+	require.NoError(t, err)
+	assert.Equal(t, live.FromStart, after)
+
+	after, err = live.ParseLastEventID(" 7 ")
+	require.NoError(t, err)
+	assert.Equal(t, int64(7), after)
+
+	_, err = live.ParseLastEventID("-3")
+	assert.Error(t, err)
+	_, err = live.ParseLastEventID("abc")
+	assert.Error(t, err)
+}
+
+func TestWriteEvent(t *testing.T) {
+	evt := &integration.Event{ID: "event1", Type: integration.OrderPaid, OrderID: "order1", Sequence: 4, Data: json.RawMessage(`{}`)}
+
+	var buf bytes.Buffer
+	require.NoError(t, live.WriteEvent(&buf, evt))
+
+	lines := bytes.Split(buf.Bytes(), []byte("\n"))
+	require.Len(t, lines, 5)
+	assert.Equal(t, "id: 4", string(lines[0]))
+	assert.Equal(t, "event: order.paid", string(lines[1]))
+
+	var written integration.Event
+	require.NoError(t, json.Unmarshal(bytes.TrimPrefix(lines[2], []byte("data: ")), &written))
+	assert.Equal(t, "order1", written.OrderID)
+	assert.Empty(t, lines[3], "events end with a blank line")
+}
@@ -0,0 +1,90 @@
+package models
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/wassef911/eventually/internal/delivery/domain"
+)
+
+var ErrInvalidAddress = domain.NewInvalidError("invalid_address", "invalid address")
+
+var (
+	countryCodeRegexp = regexp.MustCompile(`^[A-Z]{2}$`)
+	phoneRegexp       = regexp.MustCompile(`^\+?[0-9 ()-]{6,20}$`)
+)
+
+// addressRule is the country specific part of address validation.
+type addressRule struct {
+	postalCode     *regexp.Regexp
+	regionRequired bool
+}
+
+// addressRules countries without a rule only require the common fields.
+var addressRules = map[string]addressRule{
+	"AU": {postalCode: regexp.MustCompile(`^\d{4}$`), regionRequired: true},
+	"CA": {postalCode: regexp.MustCompile(`^[A-Z]\d[A-Z] ?\d[A-Z]\d$`), regionRequired: true},
+	"DE": {postalCode: regexp.MustCompile(`^\d{5}$`)},
+	"FR": {postalCode: regexp.MustCompile(`^\d{5}$`)},
+	"GB": {postalCode: regexp.MustCompile(`^[A-Z]{1,2}\d[A-Z\d]? ?\d[A-Z]{2}$`)},
+	"IT": {postalCode: regexp.MustCompile(`^\d{5}$`)},
+	"NL": {postalCode: regexp.MustCompile(`^\d{4} ?[A-Z]{2}$`)},
+	"TN": {postalCode: regexp.MustCompile(`^\d{4}$`)},
+	"US": {postalCode: regexp.MustCompile(`^\d{5}(-\d{4})?$`), regionRequired: true},
+}
+
+// Address is a delivery address, Country is an ISO 3166-1 alpha-2 code.
+type Address struct {
+	Recipient  string `json:"recipient,omitempty" bson:"recipient,omitempty"`
+	Line1      string `json:"line1" bson:"line1,omitempty"`
+	Line2      string `json:"line2,omitempty" bson:"line2,omitempty"`
+	City       string `json:"city,omitempty" bson:"city,omitempty"`
+	PostalCode string `json:"postalCode,omitempty" bson:"postalCode,omitempty"`
+	Region     string `json:"region,omitempty" bson:"region,omitempty"`
+	Country    string `json:"country,omitempty" bson:"country,omitempty"`
+	Phone      string `json:"phone,omitempty" bson:"phone,omitempty"`
+}
+
+// Validate checks the common fields and the postal code and region rules of the country.
+func (a Address) Validate() error {
+	switch {
+	case strings.TrimSpace(a.Recipient) == "":
+		return errors.Wrap(ErrInvalidAddress, "recipient is required")
+	case strings.TrimSpace(a.Line1) == "":
+		return errors.Wrap(ErrInvalidAddress, "line1 is required")
+	case strings.TrimSpace(a.City) == "":
+		return errors.Wrap(ErrInvalidAddress, "city is required")
+	case !countryCodeRegexp.MatchString(a.Country):
+		return errors.Wrapf(ErrInvalidAddress, "country: {%s}", a.Country)
+	case a.Phone != "" && !phoneRegexp.MatchString(a.Phone):
+		return errors.Wrapf(ErrInvalidAddress, "phone: {%s}", a.Phone)
+	}
+
+	rule, ok := addressRules[a.Country]
+	if !ok {
+		return nil
+	}
+	if !rule.postalCode.MatchString(strings.ToUpper(a.PostalCode)) {
+		return errors.Wrapf(ErrInvalidAddress, "postal code: {%s} country: {%s}", a.PostalCode, a.Country)
+	}
+	if rule.regionRequired && strings.TrimSpace(a.Region) == "" {
+		return errors.Wrapf(ErrInvalidAddress, "region is required for country: {%s}", a.Country)
+	}
+	return nil
+}
+
+func (a Address) IsEmpty() bool {
+	return a == Address{}
+}
+
+func (a Address) String() string {
+	parts := make([]string, 0, 7)
+	for _, part := range []string{a.Recipient, a.Line1, a.Line2, a.City, a.Region, a.PostalCode, a.Country} {
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,44 @@
+package models_test
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/wassef911/eventually/internal/delivery/models"
+)
+
+func TestAddressValidate(t *testing.T) {
+	valid := models.Address{Recipient: "John Doe", Line1: "123 Main St", City: "Springfield", PostalCode: "62701", Region: "IL", Country: "US", Phone: "+1 (555) 010-2000"}
+
+	tests := []struct {
+		name    string
+		mutate  func(a *models.Address)
+		wantErr bool
+	}{
+		{name: "valid", mutate: func(a *models.Address) {}},
+		{name: "zip+4", mutate: func(a *models.Address) { a.PostalCode = "62701-1234" }},
+		{name: "missing recipient", mutate: func(a *models.Address) { a.Recipient = "" }, wantErr: true},
+		{name: "missing city", mutate: func(a *models.Address) { a.City = " " }, wantErr: true},
+		{name: "lowercase country", mutate: func(a *models.Address) { a.Country = "us" }, wantErr: true},
+		{name: "bad us postal code", mutate: func(a *models.Address) { a.PostalCode = "ABC" }, wantErr: true},
+		{name: "us region required", mutate: func(a *models.Address) { a.Region = "" }, wantErr: true},
+		{name: "bad phone", mutate: func(a *models.Address) { a.Phone = "call me" }, wantErr: true},
+		{name: "gb postal code", mutate: func(a *models.Address) { a.Country, a.PostalCode, a.Region = "GB", "SW1A 1AA", "" }},
+		{name: "unknown country", mutate: func(a *models.Address) { a.Country, a.PostalCode, a.Region = "ZZ", "", "" }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			address := valid
+			tt.mutate(&address)
+			err := address.Validate()
+			if tt.wantErr {
+				assert.True(t, errors.Is(err, models.ErrInvalidAddress))
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
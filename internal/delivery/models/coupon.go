@@ -0,0 +1,74 @@
+package models
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/wassef911/eventually/internal/delivery/domain"
+)
+
+type PromotionType string
+
+const (
+	PromotionPercentage   PromotionType = "PERCENTAGE"
+	PromotionFixedAmount  PromotionType = "FIXED_AMOUNT"
+	PromotionBuyXGetY     PromotionType = "BUY_X_GET_Y"
+	PromotionFreeShipping PromotionType = "FREE_SHIPPING"
+)
+
+var ErrInvalidCoupon = domain.NewInvalidError("invalid_coupon", "invalid coupon definition")
+
+// Coupon is a promotion redeemable by code. When ShopItemID is set the discount
+// only applies to that line item, otherwise it applies to the whole order.
+type Coupon struct {
+	Code        string        `json:"code" bson:"code"`
+	Type        PromotionType `json:"type" bson:"type"`
+	Percentage  uint64        `json:"percentage,omitempty" bson:"percentage,omitempty"`
+	Amount      Money         `json:"amount,omitempty" bson:"amount,omitempty"`
+	ShopItemID  string        `json:"shopItemId,omitempty" bson:"shopItemId,omitempty"`
+	BuyQuantity uint64        `json:"buyQuantity,omitempty" bson:"buyQuantity,omitempty"`
+	GetQuantity uint64        `json:"getQuantity,omitempty" bson:"getQuantity,omitempty"`
+	ExpiresAt   time.Time     `json:"expiresAt,omitempty" bson:"expiresAt,omitempty"`
+	Disabled    bool          `json:"disabled,omitempty" bson:"disabled,omitempty"`
+}
+
+// Validate checks the parameters required by the promotion type.
+func (c *Coupon) Validate() error {
+	if c.Code == "" {
+		return errors.Wrap(ErrInvalidCoupon, "code is required")
+	}
+
+	switch c.Type {
+	case PromotionPercentage:
+		if c.Percentage == 0 || c.Percentage > 100 {
+			return errors.Wrap(ErrInvalidCoupon, "percentage must be between 1 and 100")
+		}
+	case PromotionFixedAmount:
+		if err := c.Amount.Validate(); err != nil {
+			return errors.Wrap(ErrInvalidCoupon, err.Error())
+		}
+		if c.Amount.IsZero() {
+			return errors.Wrap(ErrInvalidCoupon, "amount is required")
+		}
+	case PromotionBuyXGetY:
+		if c.BuyQuantity == 0 || c.GetQuantity == 0 {
+			return errors.Wrap(ErrInvalidCoupon, "buy and get quantities are required")
+		}
+	case PromotionFreeShipping:
+	default:
+		return errors.Wrapf(ErrInvalidCoupon, "unknown type: {%s}", c.Type)
+	}
+
+	return nil
+}
+
+// IsRedeemable reports whether the coupon is enabled and not expired at the given time.
+func (c *Coupon) IsRedeemable(now time.Time) bool {
+	return !c.Disabled && (c.ExpiresAt.IsZero() || now.Before(c.ExpiresAt))
+}
+
+func (c *Coupon) String() string {
+	return fmt.Sprintf("Code: {%s}, Type: {%s}, ShopItemID: {%s}", c.Code, c.Type, c.ShopItemID)
+}
@@ -0,0 +1,86 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+// SavedAddress address kept on the customer account, at most one address is the default.
+type SavedAddress struct {
+	AddressID string  `json:"addressId" bson:"addressId,omitempty"`
+	Label     string  `json:"label,omitempty" bson:"label,omitempty"`
+	Address   Address `json:"address" bson:"address,omitempty"`
+	Default   bool    `json:"default" bson:"default"`
+}
+
+type CustomerPreferences struct {
+	Language       string `json:"language,omitempty" bson:"language,omitempty"`
+	Currency       string `json:"currency,omitempty" bson:"currency,omitempty"`
+	MarketingOptIn bool   `json:"marketingOptIn" bson:"marketingOptIn"`
+}
+
+type Customer struct {
+	CustomerID   string              `json:"customerId" bson:"customerId,omitempty"`
+	Email        string              `json:"email" bson:"email,omitempty"`
+	Name         string              `json:"name" bson:"name,omitempty"`
+	Addresses    []*SavedAddress     `json:"addresses" bson:"addresses"`
+	Preferences  CustomerPreferences `json:"preferences" bson:"preferences"`
+	RegisteredAt time.Time           `json:"registeredAt" bson:"registeredAt,omitempty"`
+}
+
+func NewCustomer() *Customer {
+	return &Customer{Addresses: make([]*SavedAddress, 0)}
+}
+
+// SaveAddress adds or replaces the address with the same id, a new default address clears the previous one.
+func (c *Customer) SaveAddress(address *SavedAddress) {
+	if address.Default {
+		for _, saved := range c.Addresses {
+			saved.Default = false
+		}
+	}
+
+	for i, saved := range c.Addresses {
+		if saved.AddressID == address.AddressID {
+			c.Addresses[i] = address
+			return
+		}
+	}
+	c.Addresses = append(c.Addresses, address)
+}
+
+func (c *Customer) RemoveAddress(addressID string) {
+	addresses := make([]*SavedAddress, 0, len(c.Addresses))
+	for _, saved := range c.Addresses {
+		if saved.AddressID != addressID {
+			addresses = append(addresses, saved)
+		}
+	}
+	c.Addresses = addresses
+}
+
+func (c *Customer) GetAddress(addressID string) *SavedAddress {
+	for _, saved := range c.Addresses {
+		if saved.AddressID == addressID {
+			return saved
+		}
+	}
+	return nil
+}
+
+func (c *Customer) String() string {
+	return fmt.Sprintf("CustomerID: {%s}, Email: {%s}, Name: {%s}, Addresses: {%d}, Preferences: {%+v}, RegisteredAt: {%s}",
+		c.CustomerID,
+		c.Email,
+		c.Name,
+		len(c.Addresses),
+		c.Preferences,
+		c.RegisteredAt.UTC().String(),
+	)
+}
+
+// CustomerProjection customer read model, orders reference it by CustomerID.
+type CustomerProjection struct {
+	ID       string `json:"id" bson:"_id,omitempty"`
+	Customer `bson:",inline"`
+}
@@ -0,0 +1,44 @@
+package models
+
+import "fmt"
+
+// Inventory stock level of one SKU, the SKU of a shop item is its id.
+type Inventory struct {
+	SKU      string `json:"sku"`
+	OnHand   uint64 `json:"onHand"`
+	Reserved uint64 `json:"reserved"`
+	// Reservations reserved quantity per order id.
+	Reservations map[string]uint64 `json:"reservations"`
+}
+
+func NewInventory() *Inventory {
+	return &Inventory{Reservations: make(map[string]uint64)}
+}
+
+// Available quantity that can still be reserved.
+func (i *Inventory) Available() uint64 {
+	if i.Reserved > i.OnHand {
+		return 0
+	}
+	return i.OnHand - i.Reserved
+}
+
+func (i *Inventory) String() string {
+	return fmt.Sprintf("SKU: {%s}, OnHand: {%d}, Reserved: {%d}, Reservations: {%v}", i.SKU, i.OnHand, i.Reserved, i.Reservations)
+}
+
+// ReservationStatus state of the stock reserved for an order.
+type ReservationStatus string
+
+const (
+	ReservationReserved  ReservationStatus = "RESERVED"
+	ReservationReleased  ReservationStatus = "RELEASED"
+	ReservationCommitted ReservationStatus = "COMMITTED"
+)
+
+// OrderReservation stock the inventory saga holds for one order, Items is the quantity per SKU of the order cart.
+type OrderReservation struct {
+	Items         map[string]uint64 `json:"items"`
+	Status        ReservationStatus `json:"status"`
+	ReleaseReason string            `json:"releaseReason,omitempty"`
+}
@@ -0,0 +1,116 @@
+package models
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+
+	"github.com/wassef911/eventually/internal/delivery/domain"
+)
+
+// DefaultCurrency is assumed for legacy events recorded before prices carried a currency.
+const DefaultCurrency = "USD"
+
+// minorUnitsPerMajor is the number of minor units (cents) in one major unit.
+const minorUnitsPerMajor = 100
+
+var currencyCodeRegexp = regexp.MustCompile(`^[A-Z]{3}$`)
+
+var (
+	ErrCurrencyMismatch = domain.NewInvalidError("currency_mismatch", "currency mismatch")
+	ErrInvalidCurrency  = domain.NewInvalidError("invalid_currency", "invalid currency code")
+	ErrNegativeAmount   = domain.NewInvalidError("negative_amount", "amount must not be negative")
+)
+
+// Money is an amount expressed in integer minor units of an ISO 4217 currency.
+type Money struct {
+	Amount   int64  `json:"amount" bson:"amount"`
+	Currency string `json:"currency" bson:"currency,omitempty"`
+}
+
+func NewMoney(amount int64, currency string) Money {
+	return Money{Amount: amount, Currency: currency}
+}
+
+// NewMoneyFromFloat converts a major-unit float (e.g. 19.99) into Money, rounding to the nearest minor unit.
+func NewMoneyFromFloat(amount float64, currency string) Money {
+	return Money{Amount: int64(math.Round(amount * minorUnitsPerMajor)), Currency: currency}
+}
+
+// Zero returns an empty amount in the given currency.
+func Zero(currency string) Money {
+	return Money{Currency: currency}
+}
+
+// Validate checks the currency code and that the amount is not negative.
+func (m Money) Validate() error {
+	if !currencyCodeRegexp.MatchString(m.Currency) {
+		return errors.Wrapf(ErrInvalidCurrency, "currency: {%s}", m.Currency)
+	}
+	if m.Amount < 0 {
+		return ErrNegativeAmount
+	}
+	return nil
+}
+
+func (m Money) IsZero() bool {
+	return m.Amount == 0
+}
+
+func (m Money) SameCurrency(other Money) bool {
+	return m.Currency == other.Currency
+}
+
+func (m Money) Add(other Money) (Money, error) {
+	if !m.SameCurrency(other) {
+		return Money{}, errors.Wrapf(ErrCurrencyMismatch, "%s + %s", m.Currency, other.Currency)
+	}
+	return Money{Amount: m.Amount + other.Amount, Currency: m.Currency}, nil
+}
+
+func (m Money) Sub(other Money) (Money, error) {
+	if !m.SameCurrency(other) {
+		return Money{}, errors.Wrapf(ErrCurrencyMismatch, "%s - %s", m.Currency, other.Currency)
+	}
+	return Money{Amount: m.Amount - other.Amount, Currency: m.Currency}, nil
+}
+
+func (m Money) Multiply(quantity uint64) Money {
+	return Money{Amount: m.Amount * int64(quantity), Currency: m.Currency}
+}
+
+func (m Money) GreaterThan(other Money) bool {
+	return m.Amount > other.Amount
+}
+
+// UnmarshalBSONValue also reads the projections written before prices carried a currency,
+// which stored them as a double in major units of DefaultCurrency.
+func (m *Money) UnmarshalBSONValue(t bsontype.Type, data []byte) error {
+	raw := bson.RawValue{Type: t, Value: data}
+	if amount, ok := raw.DoubleOK(); ok {
+		*m = NewMoneyFromFloat(amount, DefaultCurrency)
+		return nil
+	}
+
+	type money Money
+	var decoded money
+	if err := raw.Unmarshal(&decoded); err != nil {
+		return errors.Wrap(err, "raw.Unmarshal")
+	}
+	*m = Money(decoded)
+	return nil
+}
+
+func (m Money) String() string {
+	sign := ""
+	amount := m.Amount
+	if amount < 0 {
+		sign = "-"
+		amount = -amount
+	}
+	return fmt.Sprintf("%s%d.%02d %s", sign, amount/minorUnitsPerMajor, amount%minorUnitsPerMajor, m.Currency)
+}
@@ -0,0 +1,51 @@
+package models_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+
+	"github.com/wassef911/eventually/internal/delivery/models"
+)
+
+func TestMoneyArithmetic(t *testing.T) {
+	price := models.NewMoney(1999, "EUR")
+
+	total, err := price.Multiply(3).Add(models.NewMoney(1, "EUR"))
+	assert.NoError(t, err)
+	assert.Equal(t, models.NewMoney(5998, "EUR"), total)
+	assert.Equal(t, "59.98 EUR", total.String())
+
+	_, err = price.Add(models.NewMoney(100, "USD"))
+	assert.ErrorIs(t, err, models.ErrCurrencyMismatch)
+}
+
+func TestNewMoneyFromFloat(t *testing.T) {
+	assert.Equal(t, models.NewMoney(1999, "USD"), models.NewMoneyFromFloat(19.99, "USD"))
+	assert.Equal(t, models.NewMoney(30, "USD"), models.NewMoneyFromFloat(0.1+0.2, "USD"))
+}
+
+func TestMoneyValidate(t *testing.T) {
+	assert.NoError(t, models.NewMoney(100, "USD").Validate())
+	assert.ErrorIs(t, models.NewMoney(100, "usd").Validate(), models.ErrInvalidCurrency)
+	assert.ErrorIs(t, models.NewMoney(-1, "USD").Validate(), models.ErrNegativeAmount)
+}
+
+func TestMoneyUnmarshalBSONValue(t *testing.T) {
+	legacy, err := bson.Marshal(bson.M{"totalPrice": 19.99, "shopItems": bson.A{bson.M{"id": "item1", "price": 5.5}}})
+	require.NoError(t, err)
+
+	var order models.OrderProjection
+	require.NoError(t, bson.Unmarshal(legacy, &order))
+	assert.Equal(t, models.NewMoney(1999, models.DefaultCurrency), order.TotalPrice)
+	require.Len(t, order.ShopItems, 1)
+	assert.Equal(t, models.NewMoney(550, models.DefaultCurrency), order.ShopItems[0].Price)
+
+	current, err := bson.Marshal(bson.M{"totalPrice": models.NewMoney(1999, "EUR")})
+	require.NoError(t, err)
+	order = models.OrderProjection{}
+	require.NoError(t, bson.Unmarshal(current, &order))
+	assert.Equal(t, models.NewMoney(1999, "EUR"), order.TotalPrice)
+}
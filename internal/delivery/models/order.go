@@ -6,44 +6,70 @@ import (
 )
 
 type Order struct {
-	ID              string      `json:"id" bson:"_id,omitempty"`
-	ShopItems       []*ShopItem `json:"shopItems" bson:"shopItems,omitempty"`
-	AccountEmail    string      `json:"accountEmail" bson:"accountEmail,omitempty"`
-	DeliveryAddress string      `json:"deliveryAddress" bson:"deliveryAddress,omitempty"`
-	CancelReason    string      `json:"cancelReason" bson:"cancelReason,omitempty"`
-	TotalPrice      float64     `json:"totalPrice" bson:"totalPrice,omitempty"`
-	DeliveredTime   time.Time   `json:"deliveredTime" bson:"deliveredTime,omitempty"`
-	Paid            bool        `json:"paid" bson:"paid,omitempty"`
-	Submitted       bool        `json:"submitted" bson:"submitted,omitempty"`
-	Completed       bool        `json:"completed" bson:"completed,omitempty"`
-	Canceled        bool        `json:"canceled" bson:"canceled,omitempty"`
-	Payment         Payment     `json:"payment" bson:"payment,omitempty"`
+	ID               string            `json:"id" bson:"_id,omitempty"`
+	ShopItems        []*ShopItem       `json:"shopItems" bson:"shopItems,omitempty"`
+	CustomerID       string            `json:"customerId" bson:"customerId,omitempty"`
+	AccountEmail     string            `json:"accountEmail" bson:"accountEmail,omitempty"`
+	DeliveryAddress  Address           `json:"deliveryAddress" bson:"deliveryAddress,omitempty"`
+	CancelReason     string            `json:"cancelReason" bson:"cancelReason,omitempty"`
+	RejectReason     string            `json:"rejectReason" bson:"rejectReason,omitempty"`
+	TotalPrice       Money             `json:"totalPrice" bson:"totalPrice,omitempty"`
+	Subtotal         Money             `json:"subtotal" bson:"subtotal,omitempty"`
+	Discounts        []*Discount       `json:"discounts" bson:"discounts,omitempty"`
+	DiscountTotal    Money             `json:"discountTotal" bson:"discountTotal,omitempty"`
+	ShippingPrice    Money             `json:"shippingPrice" bson:"shippingPrice,omitempty"`
+	TaxTotal         Money             `json:"taxTotal" bson:"taxTotal,omitempty"`
+	Taxes            []*TaxLine        `json:"taxes" bson:"taxes,omitempty"`
+	TaxRegion        string            `json:"taxRegion" bson:"taxRegion,omitempty"`
+	Coupons          []*Coupon         `json:"coupons" bson:"coupons,omitempty"`
+	CreatedAt        time.Time         `json:"createdAt" bson:"createdAt,omitempty"`
+	DeliveredTime    time.Time         `json:"deliveredTime" bson:"deliveredTime,omitempty"`
+	Status           OrderStatus       `json:"status" bson:"status,omitempty"`
+	Payment          Payment           `json:"payment" bson:"payment,omitempty"`
+	Refunds          []*Refund         `json:"refunds" bson:"refunds,omitempty"`
+	RefundedAmount   Money             `json:"refundedAmount" bson:"refundedAmount,omitempty"`
+	Refunded         bool              `json:"refunded" bson:"refunded,omitempty"`
+	PendingRefunds   []*Refund         `json:"pendingRefunds,omitempty" bson:"pendingRefunds,omitempty"`
+	Returns          []*OrderReturn    `json:"returns" bson:"returns,omitempty"`
+	Shipments        []*Shipment       `json:"shipments" bson:"shipments,omitempty"`
+	PaymentAttempts  []*PaymentAttempt `json:"paymentAttempts" bson:"paymentAttempts,omitempty"`
+	PaymentReminders int               `json:"paymentReminders" bson:"paymentReminders,omitempty"`
 }
 
 func (o *Order) String() string {
-	return fmt.Sprintf("ID: {%s}, ShopItems: {%+v}, Paid: {%v}, Submitted: {%v}, "+
-		"Completed: {%v}, Canceled: {%v}, CancelReason: {%s}, TotalPrice: {%v}, AccountEmail: {%s}, DeliveryAddress: {%s}, DeliveredTime: {%s}, Payment: {%s}",
+	return fmt.Sprintf("ID: {%s}, ShopItems: {%+v}, Status: {%s}, Paid: {%v}, Submitted: {%v}, "+
+		"Completed: {%v}, Canceled: {%v}, CancelReason: {%s}, TotalPrice: {%s}, AccountEmail: {%s}, DeliveryAddress: {%s}, DeliveredTime: {%s}, Payment: {%s}, RefundedAmount: {%s}, Refunded: {%v}",
 		o.ID,
 		o.ShopItems,
-		o.Paid,
-		o.Submitted,
-		o.Completed,
-		o.Canceled,
+		o.Status,
+		o.Paid(),
+		o.Status.Submitted(),
+		o.Status.Completed(),
+		o.Status.Canceled(),
 		o.CancelReason,
-		o.TotalPrice,
+		o.TotalPrice.String(),
 		o.AccountEmail,
 		o.DeliveryAddress,
 		o.DeliveredTime.UTC().String(),
 		o.Payment.String(),
+		o.RefundedAmount.String(),
+		o.Refunded,
 	)
 }
 
+// Paid the order was paid, it stays paid once canceled, rejected or refunded. The other lifecycle flags are derived from Status.
+func (o *Order) Paid() bool {
+	return o.Payment.PaymentID != ""
+}
+
 func NewOrder() *Order {
 	return &Order{
 		ShopItems: make([]*ShopItem, 0),
-		Paid:      false,
-		Submitted: false,
-		Completed: false,
-		Canceled:  false,
+		Refunds:   make([]*Refund, 0),
+		Returns:   make([]*OrderReturn, 0),
+		Shipments: make([]*Shipment, 0),
+		Discounts: make([]*Discount, 0),
+		Coupons:   make([]*Coupon, 0),
+		Status:    OrderStatusNew,
 	}
 }
@@ -0,0 +1,29 @@
+package models
+
+import "time"
+
+// OrderFilter narrows order searches, empty fields match every order.
+type OrderFilter struct {
+	Status  OrderStatus
+	City    string
+	Country string
+}
+
+func (f OrderFilter) IsEmpty() bool {
+	return f == OrderFilter{}
+}
+
+// OrderListFilter narrows order listings, zero fields match every order.
+// Date ranges include their start and exclude their end, total prices are in minor units.
+type OrderListFilter struct {
+	AccountEmail  string      `json:"accountEmail" validate:"omitempty,email"`
+	Status        OrderStatus `json:"status" validate:"omitempty,oneof=NEW CREATED PAID SUBMITTED COMPLETED CANCELED REFUNDED REJECTED"`
+	CreatedFrom   time.Time   `json:"createdFrom"`
+	CreatedTo     time.Time   `json:"createdTo"`
+	PaidFrom      time.Time   `json:"paidFrom"`
+	PaidTo        time.Time   `json:"paidTo"`
+	DeliveredFrom time.Time   `json:"deliveredFrom"`
+	DeliveredTo   time.Time   `json:"deliveredTo"`
+	MinTotal      *int64      `json:"minTotal" validate:"omitempty,min=0"`
+	MaxTotal      *int64      `json:"maxTotal" validate:"omitempty,min=0"`
+}
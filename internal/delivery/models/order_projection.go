@@ -6,35 +6,58 @@ import (
 )
 
 type OrderProjection struct {
-	ID              string      `json:"id" bson:"_id,omitempty"`
-	OrderID         string      `json:"orderId,omitempty" bson:"orderId,omitempty"`
-	ShopItems       []*ShopItem `json:"shopItems,omitempty" bson:"shopItems,omitempty"`
-	AccountEmail    string      `json:"accountEmail,omitempty" bson:"accountEmail,omitempty" validate:"required,email"`
-	DeliveryAddress string      `json:"deliveryAddress,omitempty" bson:"deliveryAddress,omitempty"`
-	CancelReason    string      `json:"cancelReason,omitempty" bson:"cancelReason,omitempty"`
-	TotalPrice      float64     `json:"totalPrice,omitempty" bson:"totalPrice,omitempty"`
-	DeliveredTime   time.Time   `json:"deliveredTime,omitempty" bson:"deliveredTime,omitempty"`
-	Paid            bool        `json:"paid,omitempty" bson:"paid,omitempty"`
-	Submitted       bool        `json:"submitted,omitempty" bson:"submitted,omitempty"`
-	Completed       bool        `json:"completed,omitempty" bson:"completed,omitempty"`
-	Canceled        bool        `json:"canceled,omitempty" bson:"canceled,omitempty"`
-	Payment         Payment     `json:"payment,omitempty" bson:"payment,omitempty"`
+	ID               string            `json:"id" bson:"_id,omitempty"`
+	OrderID          string            `json:"orderId,omitempty" bson:"orderId,omitempty"`
+	ShopItems        []*ShopItem       `json:"shopItems,omitempty" bson:"shopItems,omitempty"`
+	CustomerID       string            `json:"customerId,omitempty" bson:"customerId,omitempty"`
+	AccountEmail     string            `json:"accountEmail,omitempty" bson:"accountEmail,omitempty" validate:"required,email"`
+	DeliveryAddress  Address           `json:"deliveryAddress,omitempty" bson:"deliveryAddress,omitempty"`
+	CancelReason     string            `json:"cancelReason,omitempty" bson:"cancelReason,omitempty"`
+	RejectReason     string            `json:"rejectReason,omitempty" bson:"rejectReason,omitempty"`
+	TotalPrice       Money             `json:"totalPrice,omitempty" bson:"totalPrice,omitempty"`
+	Subtotal         Money             `json:"subtotal,omitempty" bson:"subtotal,omitempty"`
+	Discounts        []*Discount       `json:"discounts,omitempty" bson:"discounts,omitempty"`
+	DiscountTotal    Money             `json:"discountTotal,omitempty" bson:"discountTotal,omitempty"`
+	ShippingPrice    Money             `json:"shippingPrice,omitempty" bson:"shippingPrice,omitempty"`
+	TaxTotal         Money             `json:"taxTotal,omitempty" bson:"taxTotal,omitempty"`
+	Taxes            []*TaxLine        `json:"taxes,omitempty" bson:"taxes,omitempty"`
+	TaxRegion        string            `json:"taxRegion,omitempty" bson:"taxRegion,omitempty"`
+	Coupons          []*Coupon         `json:"coupons,omitempty" bson:"coupons,omitempty"`
+	CreatedAt        time.Time         `json:"createdAt,omitempty" bson:"createdAt,omitempty"`
+	DeliveredTime    time.Time         `json:"deliveredTime,omitempty" bson:"deliveredTime,omitempty"`
+	Status           OrderStatus       `json:"status,omitempty" bson:"status,omitempty"`
+	Payment          Payment           `json:"payment,omitempty" bson:"payment,omitempty"`
+	Refunds          []*Refund         `json:"refunds,omitempty" bson:"refunds,omitempty"`
+	RefundedAmount   Money             `json:"refundedAmount,omitempty" bson:"refundedAmount,omitempty"`
+	Refunded         bool              `json:"refunded,omitempty" bson:"refunded,omitempty"`
+	Returns          []*OrderReturn    `json:"returns,omitempty" bson:"returns,omitempty"`
+	Shipments        []*Shipment       `json:"shipments,omitempty" bson:"shipments,omitempty"`
+	PaymentAttempts  []*PaymentAttempt `json:"paymentAttempts,omitempty" bson:"paymentAttempts,omitempty"`
+	PaymentReminders int               `json:"paymentReminders,omitempty" bson:"paymentReminders,omitempty"`
 }
 
 func (o *OrderProjection) String() string {
-	return fmt.Sprintf("ID: {%s}, ShopItems: {%+v}, Paid: {%v}, Submitted: {%v}, "+
-		"Completed: {%v}, Canceled: {%v}, CancelReason: {%s}, TotalPrice: {%v}, AccountEmail: {%s}, DeliveryAddress: {%s}, DeliveredTime: {%s}, Payment: {%s}",
+	return fmt.Sprintf("ID: {%s}, ShopItems: {%+v}, Status: {%s}, Paid: {%v}, Submitted: {%v}, "+
+		"Completed: {%v}, Canceled: {%v}, CancelReason: {%s}, TotalPrice: {%s}, AccountEmail: {%s}, DeliveryAddress: {%s}, DeliveredTime: {%s}, Payment: {%s}, RefundedAmount: {%s}, Refunded: {%v}",
 		o.ID,
 		o.ShopItems,
-		o.Paid,
-		o.Submitted,
-		o.Completed,
-		o.Canceled,
+		o.Status,
+		o.Paid(),
+		o.Status.Submitted(),
+		o.Status.Completed(),
+		o.Status.Canceled(),
 		o.CancelReason,
-		o.TotalPrice,
+		o.TotalPrice.String(),
 		o.AccountEmail,
 		o.DeliveryAddress,
 		o.DeliveredTime.UTC().String(),
 		o.Payment.String(),
+		o.RefundedAmount.String(),
+		o.Refunded,
 	)
 }
+
+// Paid the order was paid, it stays paid once canceled, rejected or refunded. The other lifecycle flags are derived from Status.
+func (o *OrderProjection) Paid() bool {
+	return o.Payment.PaymentID != ""
+}
@@ -0,0 +1,46 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+type ReturnStatus string
+
+const (
+	ReturnRequested ReturnStatus = "REQUESTED"
+	ReturnApproved  ReturnStatus = "APPROVED"
+	ReturnRejected  ReturnStatus = "REJECTED"
+	ReturnReceived  ReturnStatus = "RECEIVED"
+)
+
+// ReturnItem quantity of a single order shop item sent back by the customer.
+type ReturnItem struct {
+	ShopItemID string `json:"shopItemId" bson:"shopItemId,omitempty" validate:"required"`
+	Quantity   uint64 `json:"quantity" bson:"quantity,omitempty" validate:"required,gt=0"`
+}
+
+// OrderReturn return merchandise authorization of a completed order.
+type OrderReturn struct {
+	ReturnID     string        `json:"returnId" bson:"returnId,omitempty"`
+	Items        []*ReturnItem `json:"items" bson:"items,omitempty"`
+	Reason       string        `json:"reason" bson:"reason,omitempty"`
+	Status       ReturnStatus  `json:"status" bson:"status,omitempty"`
+	RejectReason string        `json:"rejectReason,omitempty" bson:"rejectReason,omitempty"`
+	RefundID     string        `json:"refundId,omitempty" bson:"refundId,omitempty"`
+	RequestedAt  time.Time     `json:"requestedAt" bson:"requestedAt,omitempty"`
+	ReceivedAt   time.Time     `json:"receivedAt,omitempty" bson:"receivedAt,omitempty"`
+}
+
+func (r *OrderReturn) String() string {
+	return fmt.Sprintf("ReturnID: {%s}, Items: {%+v}, Reason: {%s}, Status: {%s}, RejectReason: {%s}, RefundID: {%s}, RequestedAt: {%s}, ReceivedAt: {%s}",
+		r.ReturnID,
+		r.Items,
+		r.Reason,
+		r.Status,
+		r.RejectReason,
+		r.RefundID,
+		r.RequestedAt.UTC().String(),
+		r.ReceivedAt.UTC().String(),
+	)
+}
@@ -0,0 +1,40 @@
+package models
+
+// OrderStatus is the lifecycle state of an order, see aggregate.OrderTransitions for the allowed moves.
+type OrderStatus string
+
+const (
+	OrderStatusNew       OrderStatus = "NEW"
+	OrderStatusCreated   OrderStatus = "CREATED"
+	OrderStatusPaid      OrderStatus = "PAID"
+	OrderStatusSubmitted OrderStatus = "SUBMITTED"
+	OrderStatusCompleted OrderStatus = "COMPLETED"
+	OrderStatusCanceled  OrderStatus = "CANCELED"
+	OrderStatusRefunded  OrderStatus = "REFUNDED"
+	OrderStatusRejected  OrderStatus = "REJECTED"
+)
+
+// OrderStatuses lists every order status in lifecycle order.
+var OrderStatuses = []OrderStatus{
+	OrderStatusNew,
+	OrderStatusCreated,
+	OrderStatusPaid,
+	OrderStatusSubmitted,
+	OrderStatusCompleted,
+	OrderStatusCanceled,
+	OrderStatusRefunded,
+	OrderStatusRejected,
+}
+
+// Submitted the order was handed over for fulfillment, completed orders were submitted first.
+func (s OrderStatus) Submitted() bool {
+	return s == OrderStatusSubmitted || s == OrderStatusCompleted
+}
+
+func (s OrderStatus) Completed() bool {
+	return s == OrderStatusCompleted
+}
+
+func (s OrderStatus) Canceled() bool {
+	return s == OrderStatusCanceled
+}
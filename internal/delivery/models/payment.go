@@ -5,11 +5,53 @@ import (
 	"time"
 )
 
+// Payment captured payment of an order, Provider is empty for orders paid before the payment gateway.
 type Payment struct {
 	PaymentID string    `json:"paymentID" bson:"paymentID,omitempty" validate:"required"`
 	Timestamp time.Time `json:"timestamp" bson:"timestamp,omitempty" validate:"required"`
+	Provider  string    `json:"provider,omitempty" bson:"provider,omitempty"`
+	Amount    Money     `json:"amount,omitempty" bson:"amount,omitempty"`
 }
 
 func (p *Payment) String() string {
-	return fmt.Sprintf("PaymentID: {%s}, Timestamp: {%s}", p.PaymentID, p.Timestamp.UTC().String())
+	return fmt.Sprintf("PaymentID: {%s}, Timestamp: {%s}, Provider: {%s}, Amount: {%s}", p.PaymentID, p.Timestamp.UTC().String(), p.Provider, p.Amount.String())
+}
+
+type PaymentAttemptStatus string
+
+const (
+	PaymentAttemptStarted    PaymentAttemptStatus = "STARTED"
+	PaymentAttemptAuthorized PaymentAttemptStatus = "AUTHORIZED"
+	// PaymentAttemptPending the provider confirms the payment asynchronously through its webhook.
+	PaymentAttemptPending  PaymentAttemptStatus = "PENDING"
+	PaymentAttemptCaptured PaymentAttemptStatus = "CAPTURED"
+	PaymentAttemptFailed   PaymentAttemptStatus = "FAILED"
+)
+
+// PaymentAttempt one try to pay the order through the payment gateway.
+type PaymentAttempt struct {
+	AttemptID     string               `json:"attemptId" bson:"attemptId,omitempty"`
+	PaymentID     string               `json:"paymentId,omitempty" bson:"paymentId,omitempty"`
+	Provider      string               `json:"provider" bson:"provider,omitempty"`
+	Amount        Money                `json:"amount" bson:"amount,omitempty"`
+	Status        PaymentAttemptStatus `json:"status" bson:"status,omitempty"`
+	FailureReason string               `json:"failureReason,omitempty" bson:"failureReason,omitempty"`
+	Timestamp     time.Time            `json:"timestamp" bson:"timestamp,omitempty"`
+}
+
+// IsOpen the attempt may still be authorized, captured or failed.
+func (p *PaymentAttempt) IsOpen() bool {
+	return p.Status != PaymentAttemptCaptured && p.Status != PaymentAttemptFailed
+}
+
+func (p *PaymentAttempt) String() string {
+	return fmt.Sprintf("AttemptID: {%s}, PaymentID: {%s}, Provider: {%s}, Amount: {%s}, Status: {%s}, FailureReason: {%s}, Timestamp: {%s}",
+		p.AttemptID,
+		p.PaymentID,
+		p.Provider,
+		p.Amount.String(),
+		p.Status,
+		p.FailureReason,
+		p.Timestamp.UTC().String(),
+	)
 }
@@ -0,0 +1,13 @@
+package models
+
+import "time"
+
+// PaymentDeadline unpaid order tracked by the payment saga, the order is canceled at ExpiresAt
+// unless a payment attempt is still waiting for the provider.
+type PaymentDeadline struct {
+	CreatedAt time.Time `json:"createdAt"`
+	ExpiresAt time.Time `json:"expiresAt"`
+	Reminders int       `json:"reminders"`
+	// OpenAttempts ids of the payment attempts neither captured nor failed yet.
+	OpenAttempts []string `json:"openAttempts,omitempty"`
+}
@@ -0,0 +1,51 @@
+package models
+
+// Discount is the amount taken off the order by one coupon.
+type Discount struct {
+	Code   string `json:"code" bson:"code"`
+	Amount Money  `json:"amount" bson:"amount"`
+}
+
+// OrderPricing is the price breakdown of an order: Total = Subtotal - DiscountTotal + ShippingPrice + TaxTotal.
+type OrderPricing struct {
+	Subtotal      Money       `json:"subtotal"`
+	Discounts     []*Discount `json:"discounts"`
+	DiscountTotal Money       `json:"discountTotal"`
+	ShippingPrice Money       `json:"shippingPrice"`
+	TaxTotal      Money       `json:"taxTotal"`
+	Taxes         []*TaxLine  `json:"taxes,omitempty"`
+	TaxRegion     string      `json:"taxRegion,omitempty"`
+	Total         Money       `json:"total"`
+}
+
+func (o *Order) SetPricing(pricing OrderPricing) {
+	o.Subtotal = pricing.Subtotal
+	o.Discounts = pricing.Discounts
+	o.DiscountTotal = pricing.DiscountTotal
+	o.ShippingPrice = pricing.ShippingPrice
+	o.TaxTotal = pricing.TaxTotal
+	o.Taxes = pricing.Taxes
+	o.TaxRegion = pricing.TaxRegion
+	o.TotalPrice = pricing.Total
+}
+
+func (o *OrderProjection) SetPricing(pricing OrderPricing) {
+	o.Subtotal = pricing.Subtotal
+	o.Discounts = pricing.Discounts
+	o.DiscountTotal = pricing.DiscountTotal
+	o.ShippingPrice = pricing.ShippingPrice
+	o.TaxTotal = pricing.TaxTotal
+	o.Taxes = pricing.Taxes
+	o.TaxRegion = pricing.TaxRegion
+	o.TotalPrice = pricing.Total
+}
+
+// SetEventPricing sets the pricing recorded in a cart event, events recorded before
+// pricing was introduced only carry the total.
+func (o *OrderProjection) SetEventPricing(pricing *OrderPricing, totalPrice Money) {
+	if pricing == nil {
+		o.TotalPrice = totalPrice
+		return
+	}
+	o.SetPricing(*pricing)
+}
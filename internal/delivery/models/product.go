@@ -0,0 +1,57 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+// Product catalog entry, the product id is the SKU of the shop items ordered from it.
+type Product struct {
+	ProductID      string    `json:"productId" bson:"productId,omitempty"`
+	Title          string    `json:"title" bson:"title,omitempty"`
+	Description    string    `json:"description" bson:"description,omitempty"`
+	Price          Money     `json:"price" bson:"price,omitempty"`
+	TaxCategory    string    `json:"taxCategory,omitempty" bson:"taxCategory,omitempty"`
+	Discontinued   bool      `json:"discontinued" bson:"discontinued"`
+	DiscontinuedAt time.Time `json:"discontinuedAt,omitempty" bson:"discontinuedAt,omitempty"`
+	CreatedAt      time.Time `json:"createdAt" bson:"createdAt,omitempty"`
+	UpdatedAt      time.Time `json:"updatedAt" bson:"updatedAt,omitempty"`
+}
+
+func NewProduct() *Product {
+	return &Product{}
+}
+
+// ShopItem snapshot of the product ordered in the given quantity.
+func (p *Product) ShopItem(quantity uint64) *ShopItem {
+	return &ShopItem{
+		ID:          p.ProductID,
+		Title:       p.Title,
+		Description: p.Description,
+		Quantity:    quantity,
+		Price:       p.Price,
+		TaxCategory: p.TaxCategory,
+	}
+}
+
+func (p *Product) String() string {
+	return fmt.Sprintf("ProductID: {%s}, Title: {%s}, Price: {%s}, TaxCategory: {%s}, Discontinued: {%v}",
+		p.ProductID,
+		p.Title,
+		p.Price.String(),
+		p.TaxCategory,
+		p.Discontinued,
+	)
+}
+
+// ProductProjection catalog read model.
+type ProductProjection struct {
+	ID      string `json:"id" bson:"_id,omitempty"`
+	Product `bson:",inline"`
+}
+
+// OrderItem product requested by the client, title and price are resolved from the catalog.
+type OrderItem struct {
+	ProductID string `json:"productId" validate:"required"`
+	Quantity  uint64 `json:"quantity" validate:"required,gt=0"`
+}
@@ -0,0 +1,30 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+// RefundItem quantity of a single order shop item to refund.
+type RefundItem struct {
+	ShopItemID string `json:"shopItemId" bson:"shopItemId,omitempty" validate:"required"`
+	Quantity   uint64 `json:"quantity" bson:"quantity,omitempty" validate:"required,gt=0"`
+}
+
+type Refund struct {
+	RefundID  string        `json:"refundId" bson:"refundId,omitempty"`
+	Amount    Money         `json:"amount" bson:"amount,omitempty"`
+	Items     []*RefundItem `json:"items,omitempty" bson:"items,omitempty"`
+	Reason    string        `json:"reason" bson:"reason,omitempty"`
+	Timestamp time.Time     `json:"timestamp" bson:"timestamp,omitempty"`
+}
+
+func (r *Refund) String() string {
+	return fmt.Sprintf("RefundID: {%s}, Amount: {%s}, Items: {%+v}, Reason: {%s}, Timestamp: {%s}",
+		r.RefundID,
+		r.Amount.String(),
+		r.Items,
+		r.Reason,
+		r.Timestamp.UTC().String(),
+	)
+}
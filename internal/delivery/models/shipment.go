@@ -0,0 +1,47 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+type ShipmentStatus string
+
+const (
+	ShipmentCreated    ShipmentStatus = "CREATED"
+	ShipmentPacked     ShipmentStatus = "PACKED"
+	ShipmentDispatched ShipmentStatus = "DISPATCHED"
+	ShipmentDelivered  ShipmentStatus = "DELIVERED"
+)
+
+// ShipmentItem quantity of a single order shop item in a shipment.
+type ShipmentItem struct {
+	ShopItemID string `json:"shopItemId" bson:"shopItemId,omitempty" validate:"required"`
+	Quantity   uint64 `json:"quantity" bson:"quantity,omitempty" validate:"required,gt=0"`
+}
+
+// Shipment parcel fulfilling some or all of the order shop items.
+type Shipment struct {
+	ShipmentID     string          `json:"shipmentId" bson:"shipmentId,omitempty"`
+	Items          []*ShipmentItem `json:"items" bson:"items,omitempty"`
+	Status         ShipmentStatus  `json:"status" bson:"status,omitempty"`
+	Carrier        string          `json:"carrier,omitempty" bson:"carrier,omitempty"`
+	TrackingNumber string          `json:"trackingNumber,omitempty" bson:"trackingNumber,omitempty"`
+	CreatedAt      time.Time       `json:"createdAt" bson:"createdAt,omitempty"`
+	PackedAt       time.Time       `json:"packedAt,omitempty" bson:"packedAt,omitempty"`
+	DispatchedAt   time.Time       `json:"dispatchedAt,omitempty" bson:"dispatchedAt,omitempty"`
+	DeliveredAt    time.Time       `json:"deliveredAt,omitempty" bson:"deliveredAt,omitempty"`
+}
+
+func (s *Shipment) String() string {
+	return fmt.Sprintf("ShipmentID: {%s}, Items: {%+v}, Status: {%s}, Carrier: {%s}, TrackingNumber: {%s}, CreatedAt: {%s}, DispatchedAt: {%s}, DeliveredAt: {%s}",
+		s.ShipmentID,
+		s.Items,
+		s.Status,
+		s.Carrier,
+		s.TrackingNumber,
+		s.CreatedAt.UTC().String(),
+		s.DispatchedAt.UTC().String(),
+		s.DeliveredAt.UTC().String(),
+	)
+}
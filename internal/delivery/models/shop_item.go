@@ -5,19 +5,20 @@ import (
 )
 
 type ShopItem struct {
-	ID          string  `json:"id" bson:"id,omitempty"`
-	Title       string  `json:"title" bson:"title,omitempty"`
-	Description string  `json:"description" bson:"description,omitempty"`
-	Quantity    uint64  `json:"quantity" bson:"quantity,omitempty"`
-	Price       float64 `json:"price" bson:"price,omitempty"`
+	ID          string `json:"id" bson:"id,omitempty"`
+	Title       string `json:"title" bson:"title,omitempty"`
+	Description string `json:"description" bson:"description,omitempty"`
+	Quantity    uint64 `json:"quantity" bson:"quantity,omitempty"`
+	Price       Money  `json:"price" bson:"price,omitempty"`
+	TaxCategory string `json:"taxCategory,omitempty" bson:"taxCategory,omitempty"`
 }
 
 func (s *ShopItem) String() string {
-	return fmt.Sprintf("ID: {%s}, Title: {%s}, Description: {%s}, Quantity: {%v}, Price: {%v},",
+	return fmt.Sprintf("ID: {%s}, Title: {%s}, Description: {%s}, Quantity: {%v}, Price: {%s},",
 		s.ID,
 		s.Title,
 		s.Description,
 		s.Quantity,
-		s.Price,
+		s.Price.String(),
 	)
 }
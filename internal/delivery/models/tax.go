@@ -0,0 +1,12 @@
+package models
+
+// DefaultTaxCategory is used for shop items without a tax category.
+const DefaultTaxCategory = "standard"
+
+// TaxLine is the tax charged on one line item. Rate is in basis points, 1% = 100.
+type TaxLine struct {
+	ShopItemID  string `json:"shopItemId" bson:"shopItemId"`
+	TaxCategory string `json:"taxCategory" bson:"taxCategory"`
+	Rate        uint64 `json:"rate" bson:"rate"`
+	Amount      Money  `json:"amount" bson:"amount"`
+}
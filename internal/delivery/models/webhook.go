@@ -0,0 +1,68 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+// Webhook partner endpoint notified of order events, an empty EventTypes filter subscribes to every event.
+// A webhook is disabled once ConsecutiveFailures reaches the configured limit.
+type Webhook struct {
+	WebhookID           string    `json:"webhookId" bson:"webhookId"`
+	URL                 string    `json:"url" bson:"url"`
+	EventTypes          []string  `json:"eventTypes,omitempty" bson:"eventTypes,omitempty"`
+	Secret              string    `json:"-" bson:"secret"`
+	Disabled            bool      `json:"disabled" bson:"disabled"`
+	DisabledAt          time.Time `json:"disabledAt,omitempty" bson:"disabledAt,omitempty"`
+	ConsecutiveFailures int       `json:"consecutiveFailures" bson:"consecutiveFailures"`
+	CreatedAt           time.Time `json:"createdAt" bson:"createdAt"`
+	UpdatedAt           time.Time `json:"updatedAt" bson:"updatedAt"`
+}
+
+// Subscribes reports whether the webhook is notified of events of eventType.
+func (w *Webhook) Subscribes(eventType string) bool {
+	if len(w.EventTypes) == 0 {
+		return true
+	}
+	for _, subscribed := range w.EventTypes {
+		if subscribed == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// Enable turns a disabled webhook back on with a clean failure count.
+func (w *Webhook) Enable(now time.Time) {
+	w.Disabled = false
+	w.DisabledAt = time.Time{}
+	w.ConsecutiveFailures = 0
+	w.UpdatedAt = now
+}
+
+func (w *Webhook) String() string {
+	return fmt.Sprintf("WebhookID: {%s}, URL: {%s}, EventTypes: {%v}, Disabled: {%v}, ConsecutiveFailures: {%d}",
+		w.WebhookID,
+		w.URL,
+		w.EventTypes,
+		w.Disabled,
+		w.ConsecutiveFailures,
+	)
+}
+
+// WebhookDelivery log of one delivery attempt. A failed attempt with attempts left keeps the payload
+// until it is retried at NextAttemptAt.
+type WebhookDelivery struct {
+	DeliveryID    string     `json:"deliveryId" bson:"deliveryId"`
+	WebhookID     string     `json:"webhookId" bson:"webhookId"`
+	EventID       string     `json:"eventId" bson:"eventId"`
+	EventType     string     `json:"eventType" bson:"eventType"`
+	Attempt       int        `json:"attempt" bson:"attempt"`
+	StatusCode    int        `json:"statusCode,omitempty" bson:"statusCode,omitempty"`
+	Error         string     `json:"error,omitempty" bson:"error,omitempty"`
+	Succeeded     bool       `json:"succeeded" bson:"succeeded"`
+	Duration      int64      `json:"durationMs" bson:"durationMs"`
+	AttemptedAt   time.Time  `json:"attemptedAt" bson:"attemptedAt"`
+	NextAttemptAt *time.Time `json:"nextAttemptAt,omitempty" bson:"nextAttemptAt,omitempty"`
+	Payload       []byte     `json:"-" bson:"payload,omitempty"`
+}
@@ -0,0 +1,161 @@
+package payment
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"sync"
+
+	"github.com/pkg/errors"
+	uuid "github.com/satori/go.uuid"
+
+	"github.com/wassef911/eventually/internal/delivery/models"
+)
+
+const (
+	FakeProvider = "fake"
+
+	// FakeTokenDeclined is refused on authorization, FakeTokenPending is confirmed through the webhook,
+	// every other token is approved.
+	FakeTokenDeclined = "tok_declined"
+	FakeTokenPending  = "tok_pending"
+)
+
+var _ Gateway = &FakeGateway{}
+
+type fakePayment struct {
+	orderID  string
+	amount   models.Money
+	captured bool
+	voided   bool
+	refunds  map[string]models.Money
+}
+
+// FakeGateway in memory provider for local development and tests, webhooks are signed with
+// the hex encoded HMAC-SHA256 of the payload.
+type FakeGateway struct {
+	secret   []byte
+	mu       sync.Mutex
+	payments map[string]*fakePayment
+	attempts map[string]string
+}
+
+func NewFakeGateway(webhookSecret string) *FakeGateway {
+	return &FakeGateway{
+		secret:   []byte(webhookSecret),
+		payments: make(map[string]*fakePayment),
+		attempts: make(map[string]string),
+	}
+}
+
+func (g *FakeGateway) Name() string {
+	return FakeProvider
+}
+
+func (g *FakeGateway) Authorize(ctx context.Context, request AuthorizeRequest) (*Authorization, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if request.Token == FakeTokenDeclined {
+		return nil, errors.Wrapf(ErrPaymentDeclined, "token: {%s}", request.Token)
+	}
+
+	status := AuthorizationApproved
+	if request.Token == FakeTokenPending {
+		status = AuthorizationPending
+	}
+
+	if paymentID, ok := g.attempts[request.AttemptID]; ok {
+		return &Authorization{PaymentID: paymentID, Status: status}, nil
+	}
+
+	paymentID := uuid.NewV4().String()
+	g.attempts[request.AttemptID] = paymentID
+	g.payments[paymentID] = &fakePayment{orderID: request.OrderID, amount: request.Amount, refunds: make(map[string]models.Money)}
+	return &Authorization{PaymentID: paymentID, Status: status}, nil
+}
+
+func (g *FakeGateway) Capture(ctx context.Context, paymentID string, amount models.Money) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	payment, ok := g.payments[paymentID]
+	if !ok || payment.voided {
+		return errors.Wrapf(ErrPaymentNotFound, "paymentID: {%s}", paymentID)
+	}
+	if amount.GreaterThan(payment.amount) {
+		return errors.Wrapf(ErrPaymentDeclined, "capture of %s exceeds the authorized %s", amount.String(), payment.amount.String())
+	}
+	payment.captured = true
+	return nil
+}
+
+func (g *FakeGateway) Void(ctx context.Context, paymentID string) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	payment, ok := g.payments[paymentID]
+	if !ok {
+		return errors.Wrapf(ErrPaymentNotFound, "paymentID: {%s}", paymentID)
+	}
+	payment.voided = true
+	return nil
+}
+
+func (g *FakeGateway) Refund(ctx context.Context, paymentID string, refundID string, amount models.Money) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	payment, ok := g.payments[paymentID]
+	if !ok || !payment.captured {
+		return errors.Wrapf(ErrPaymentNotFound, "paymentID: {%s}", paymentID)
+	}
+	payment.refunds[refundID] = amount
+	return nil
+}
+
+func (g *FakeGateway) VerifyWebhook(payload []byte, signature string) (*WebhookEvent, error) {
+	expected, err := hex.DecodeString(signature)
+	if err != nil || !hmac.Equal(expected, g.sign(payload)) {
+		return nil, ErrInvalidSignature
+	}
+
+	var event WebhookEvent
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return nil, errors.Wrap(err, "json.Unmarshal")
+	}
+	return &event, nil
+}
+
+// Webhook callback the fake provider sends for a pending payment, it names the order id the payment was authorized for.
+func (g *FakeGateway) Webhook(paymentID string, eventType WebhookEventType, reason string) ([]byte, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	payment, ok := g.payments[paymentID]
+	if !ok {
+		return nil, errors.Wrapf(ErrPaymentNotFound, "paymentID: {%s}", paymentID)
+	}
+	if eventType == WebhookPaymentCaptured {
+		payment.captured = true
+	}
+
+	payload, err := json.Marshal(WebhookEvent{Type: eventType, OrderID: payment.orderID, PaymentID: paymentID, Reason: reason})
+	if err != nil {
+		return nil, errors.Wrap(err, "json.Marshal")
+	}
+	return payload, nil
+}
+
+// SignWebhook signs a payload the way the fake provider does, to simulate its callbacks.
+func (g *FakeGateway) SignWebhook(payload []byte) string {
+	return hex.EncodeToString(g.sign(payload))
+}
+
+func (g *FakeGateway) sign(payload []byte) []byte {
+	mac := hmac.New(sha256.New, g.secret)
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
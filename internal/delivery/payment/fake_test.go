@@ -0,0 +1,57 @@
+package payment_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wassef911/eventually/internal/delivery/models"
+	"github.com/wassef911/eventually/internal/delivery/payment"
+)
+
+func TestFakeGateway(t *testing.T) {
+	ctx := context.Background()
+	gateway := payment.NewFakeGateway("secret")
+	amount := models.NewMoney(1500, "USD")
+
+	authorization, err := gateway.Authorize(ctx, payment.AuthorizeRequest{OrderID: "order1", AttemptID: "attempt1", Amount: amount, Token: "tok_visa"})
+	require.NoError(t, err)
+	assert.Equal(t, payment.AuthorizationApproved, authorization.Status)
+
+	retried, err := gateway.Authorize(ctx, payment.AuthorizeRequest{OrderID: "order1", AttemptID: "attempt1", Amount: amount, Token: "tok_visa"})
+	require.NoError(t, err)
+	assert.Equal(t, authorization.PaymentID, retried.PaymentID)
+
+	err = gateway.Refund(ctx, authorization.PaymentID, "refund1", amount)
+	assert.True(t, errors.Is(err, payment.ErrPaymentNotFound))
+
+	require.NoError(t, gateway.Capture(ctx, authorization.PaymentID, amount))
+	require.NoError(t, gateway.Refund(ctx, authorization.PaymentID, "refund1", amount))
+
+	_, err = gateway.Authorize(ctx, payment.AuthorizeRequest{OrderID: "order2", AttemptID: "attempt2", Amount: amount, Token: payment.FakeTokenDeclined})
+	assert.True(t, errors.Is(err, payment.ErrPaymentDeclined))
+
+	pending, err := gateway.Authorize(ctx, payment.AuthorizeRequest{OrderID: "order3", AttemptID: "attempt3", Amount: amount, Token: payment.FakeTokenPending})
+	require.NoError(t, err)
+	assert.Equal(t, payment.AuthorizationPending, pending.Status)
+}
+
+func TestFakeGatewayVerifyWebhook(t *testing.T) {
+	gateway := payment.NewFakeGateway("secret")
+	payload := []byte(`{"type": "payment.captured", "orderId": "order1", "paymentId": "pay1"}`)
+
+	event, err := gateway.VerifyWebhook(payload, gateway.SignWebhook(payload))
+	require.NoError(t, err)
+	assert.Equal(t, payment.WebhookPaymentCaptured, event.Type)
+	assert.Equal(t, "order1", event.OrderID)
+	assert.Equal(t, "pay1", event.PaymentID)
+
+	_, err = gateway.VerifyWebhook(payload, payment.NewFakeGateway("other").SignWebhook(payload))
+	assert.True(t, errors.Is(err, payment.ErrInvalidSignature))
+
+	_, err = gateway.VerifyWebhook(payload, "not-hex")
+	assert.True(t, errors.Is(err, payment.ErrInvalidSignature))
+}
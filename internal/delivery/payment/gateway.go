@@ -0,0 +1,71 @@
+package payment
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/wassef911/eventually/internal/delivery/domain"
+	"github.com/wassef911/eventually/internal/delivery/models"
+)
+
+// SignatureHeader carries the webhook signature checked by VerifyWebhook.
+const SignatureHeader = "X-Payment-Signature"
+
+var (
+	ErrPaymentDeclined  = domain.NewConflictError("payment_declined", "payment declined")
+	ErrPaymentNotFound  = domain.NewNotFoundError("payment_not_found", "payment not found")
+	ErrInvalidSignature = errors.New("invalid webhook signature")
+	ErrUnknownProvider  = errors.New("unknown payment provider")
+	ErrUnknownWebhook   = domain.NewInvalidError("unknown_payment_webhook", "unknown payment webhook event")
+)
+
+// Gateway payment provider used by the payment commands. Every call is keyed by an id the caller
+// generated, so providers can deduplicate retried calls.
+type Gateway interface {
+	// Name identifies the provider in payment events.
+	Name() string
+	// Authorize reserves the amount, ErrPaymentDeclined when the provider refuses it.
+	Authorize(ctx context.Context, request AuthorizeRequest) (*Authorization, error)
+	Capture(ctx context.Context, paymentID string, amount models.Money) error
+	Void(ctx context.Context, paymentID string) error
+	Refund(ctx context.Context, paymentID string, refundID string, amount models.Money) error
+	// VerifyWebhook checks the signature of a provider callback and decodes it.
+	VerifyWebhook(payload []byte, signature string) (*WebhookEvent, error)
+}
+
+type AuthorizeRequest struct {
+	OrderID   string
+	AttemptID string
+	Amount    models.Money
+	// Token payment method collected by the provider on the client side.
+	Token string
+}
+
+type AuthorizationStatus string
+
+const (
+	AuthorizationApproved AuthorizationStatus = "APPROVED"
+	// AuthorizationPending the provider reports the outcome later through its webhook.
+	AuthorizationPending AuthorizationStatus = "PENDING"
+)
+
+type Authorization struct {
+	PaymentID string
+	Status    AuthorizationStatus
+}
+
+type WebhookEventType string
+
+const (
+	WebhookPaymentCaptured WebhookEventType = "payment.captured"
+	WebhookPaymentFailed   WebhookEventType = "payment.failed"
+)
+
+// WebhookEvent asynchronous outcome of a pending payment.
+type WebhookEvent struct {
+	Type      WebhookEventType `json:"type"`
+	OrderID   string           `json:"orderId"`
+	PaymentID string           `json:"paymentId"`
+	Reason    string           `json:"reason,omitempty"`
+}
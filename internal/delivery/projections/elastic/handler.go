@@ -25,12 +25,21 @@ func (o *elasticProjection) onOrderCreate(ctx context.Context, evt es.Event) err
 		return errors.Wrap(err, "evt.GetJsonData")
 	}
 
+	pricing, err := aggregate.GetEventPricing(eventData.Pricing, eventData.ShopItems)
+	if err != nil {
+		tracing.TraceErr(span, err)
+		return errors.Wrap(err, "GetEventPricing")
+	}
+
 	op := &models.OrderProjection{
 		OrderID:      aggregate.GetOrderAggregateID(evt.AggregateID),
 		ShopItems:    eventData.ShopItems,
+		CustomerID:   eventData.CustomerID,
 		AccountEmail: eventData.AccountEmail,
-		TotalPrice:   aggregate.GetShopItemsTotalPrice(eventData.ShopItems),
+		Status:       models.OrderStatusCreated,
+		CreatedAt:    evt.GetTimeStamp(),
 	}
+	op.SetPricing(pricing)
 
 	return o.elasticRepository.IndexOrder(ctx, op)
 }
@@ -49,12 +58,96 @@ func (o *elasticProjection) onOrderPaid(ctx context.Context, evt es.Event) error
 	if err != nil {
 		return err
 	}
-	projection.Paid = true
+	projection.Status = models.OrderStatusPaid
 	projection.Payment = payment
+	for _, attempt := range projection.PaymentAttempts {
+		if attempt.PaymentID == payment.PaymentID {
+			attempt.Status = models.PaymentAttemptCaptured
+		}
+	}
+
+	return o.elasticRepository.UpdateOrder(ctx, projection)
+}
+
+func (o *elasticProjection) onPaymentAttempted(ctx context.Context, evt es.Event) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "elasticProjection.onPaymentAttempted")
+	defer span.Finish()
+	span.LogFields(log.String("AggregateID", evt.GetAggregateID()))
+
+	var eventData events.PaymentAttemptedEvent
+	if err := evt.GetJsonData(&eventData); err != nil {
+		tracing.TraceErr(span, err)
+		return errors.Wrap(err, "evt.GetJsonData")
+	}
+
+	projection, err := o.elasticRepository.GetByID(ctx, aggregate.GetOrderAggregateID(evt.AggregateID))
+	if err != nil {
+		return err
+	}
+	projection.PaymentAttempts = append(projection.PaymentAttempts, &models.PaymentAttempt{
+		AttemptID: eventData.AttemptID,
+		Provider:  eventData.Provider,
+		Amount:    eventData.Amount,
+		Status:    models.PaymentAttemptStarted,
+		Timestamp: eventData.Timestamp,
+	})
 
 	return o.elasticRepository.UpdateOrder(ctx, projection)
 }
 
+func (o *elasticProjection) onPaymentAuthorized(ctx context.Context, evt es.Event) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "elasticProjection.onPaymentAuthorized")
+	defer span.Finish()
+	span.LogFields(log.String("AggregateID", evt.GetAggregateID()))
+
+	var eventData events.PaymentAuthorizedEvent
+	if err := evt.GetJsonData(&eventData); err != nil {
+		tracing.TraceErr(span, err)
+		return errors.Wrap(err, "evt.GetJsonData")
+	}
+
+	return o.updatePaymentAttempt(ctx, evt, eventData.AttemptID, func(attempt *models.PaymentAttempt) {
+		attempt.PaymentID = eventData.PaymentID
+		attempt.Status = models.PaymentAttemptAuthorized
+		if eventData.Pending {
+			attempt.Status = models.PaymentAttemptPending
+		}
+	})
+}
+
+func (o *elasticProjection) onPaymentFailed(ctx context.Context, evt es.Event) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "elasticProjection.onPaymentFailed")
+	defer span.Finish()
+	span.LogFields(log.String("AggregateID", evt.GetAggregateID()))
+
+	var eventData events.PaymentFailedEvent
+	if err := evt.GetJsonData(&eventData); err != nil {
+		tracing.TraceErr(span, err)
+		return errors.Wrap(err, "evt.GetJsonData")
+	}
+
+	return o.updatePaymentAttempt(ctx, evt, eventData.AttemptID, func(attempt *models.PaymentAttempt) {
+		attempt.Status = models.PaymentAttemptFailed
+		attempt.FailureReason = eventData.Reason
+	})
+}
+
+func (o *elasticProjection) updatePaymentAttempt(ctx context.Context, evt es.Event, attemptID string, update func(attempt *models.PaymentAttempt)) error {
+	projection, err := o.elasticRepository.GetByID(ctx, aggregate.GetOrderAggregateID(evt.AggregateID))
+	if err != nil {
+		return err
+	}
+
+	for _, attempt := range projection.PaymentAttempts {
+		if attempt.AttemptID == attemptID {
+			update(attempt)
+			return o.elasticRepository.UpdateOrder(ctx, projection)
+		}
+	}
+
+	return errors.Wrapf(aggregate.ErrPaymentAttemptNotFound, "attemptID: {%s}", attemptID)
+}
+
 func (o *elasticProjection) onSubmit(ctx context.Context, evt es.Event) error {
 	span, ctx := opentracing.StartSpanFromContext(ctx, "elasticProjection.onSubmit")
 	defer span.Finish()
@@ -64,7 +157,7 @@ func (o *elasticProjection) onSubmit(ctx context.Context, evt es.Event) error {
 	if err != nil {
 		return err
 	}
-	projection.Submitted = true
+	projection.Status = models.OrderStatusSubmitted
 
 	return o.elasticRepository.UpdateOrder(ctx, projection)
 }
@@ -80,12 +173,18 @@ func (o *elasticProjection) onShoppingCartUpdate(ctx context.Context, evt es.Eve
 		return errors.Wrap(err, "evt.GetJsonData")
 	}
 
+	pricing, err := aggregate.GetEventPricing(eventData.Pricing, eventData.ShopItems)
+	if err != nil {
+		tracing.TraceErr(span, err)
+		return errors.Wrap(err, "GetEventPricing")
+	}
+
 	projection, err := o.elasticRepository.GetByID(ctx, aggregate.GetOrderAggregateID(evt.AggregateID))
 	if err != nil {
 		return err
 	}
 	projection.ShopItems = eventData.ShopItems
-	projection.TotalPrice = aggregate.GetShopItemsTotalPrice(eventData.ShopItems)
+	projection.SetPricing(pricing)
 
 	return o.elasticRepository.UpdateOrder(ctx, projection)
 }
@@ -105,13 +204,53 @@ func (o *elasticProjection) onCancel(ctx context.Context, evt es.Event) error {
 	if err != nil {
 		return err
 	}
-	projection.Canceled = true
-	projection.Completed = false
+	projection.Status = models.OrderStatusCanceled
 	projection.CancelReason = eventData.CancelReason
 
 	return o.elasticRepository.UpdateOrder(ctx, projection)
 }
 
+func (o *elasticProjection) onReject(ctx context.Context, evt es.Event) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "elasticProjection.onReject")
+	defer span.Finish()
+	span.LogFields(log.String("AggregateID", evt.GetAggregateID()))
+
+	var eventData events.OrderRejectedEvent
+	if err := evt.GetJsonData(&eventData); err != nil {
+		tracing.TraceErr(span, err)
+		return errors.Wrap(err, "evt.GetJsonData")
+	}
+
+	projection, err := o.elasticRepository.GetByID(ctx, aggregate.GetOrderAggregateID(evt.AggregateID))
+	if err != nil {
+		return err
+	}
+	projection.Status = models.OrderStatusRejected
+	projection.RejectReason = eventData.RejectReason
+
+	return o.elasticRepository.UpdateOrder(ctx, projection)
+}
+
+func (o *elasticProjection) onPaymentReminderSent(ctx context.Context, evt es.Event) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "elasticProjection.onPaymentReminderSent")
+	defer span.Finish()
+	span.LogFields(log.String("AggregateID", evt.GetAggregateID()))
+
+	var eventData events.PaymentReminderSentEvent
+	if err := evt.GetJsonData(&eventData); err != nil {
+		tracing.TraceErr(span, err)
+		return errors.Wrap(err, "evt.GetJsonData")
+	}
+
+	projection, err := o.elasticRepository.GetByID(ctx, aggregate.GetOrderAggregateID(evt.AggregateID))
+	if err != nil {
+		return err
+	}
+	projection.PaymentReminders = eventData.Reminder
+
+	return o.elasticRepository.UpdateOrder(ctx, projection)
+}
+
 func (o *elasticProjection) onComplete(ctx context.Context, evt es.Event) error {
 	span, ctx := opentracing.StartSpanFromContext(ctx, "elasticProjection.onComplete")
 	defer span.Finish()
@@ -127,7 +266,7 @@ func (o *elasticProjection) onComplete(ctx context.Context, evt es.Event) error
 	if err != nil {
 		return err
 	}
-	projection.Completed = true
+	projection.Status = models.OrderStatusCompleted
 	projection.DeliveredTime = eventData.DeliveryTimestamp
 
 	return o.elasticRepository.UpdateOrder(ctx, projection)
@@ -149,7 +288,343 @@ func (o *elasticProjection) onDeliveryAddressChanged(ctx context.Context, evt es
 		return err
 	}
 	projection.DeliveryAddress = eventData.DeliveryAddress
+	if eventData.Pricing != nil {
+		projection.SetPricing(*eventData.Pricing)
+	}
+
+	return o.elasticRepository.UpdateOrder(ctx, projection)
+
+}
+
+func (o *elasticProjection) onRefund(ctx context.Context, evt es.Event) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "elasticProjection.onRefund")
+	defer span.Finish()
+	span.LogFields(log.String("AggregateID", evt.GetAggregateID()))
+
+	var eventData events.OrderRefundedEvent
+	if err := evt.GetJsonData(&eventData); err != nil {
+		tracing.TraceErr(span, err)
+		return errors.Wrap(err, "evt.GetJsonData")
+	}
+
+	projection, err := o.elasticRepository.GetByID(ctx, aggregate.GetOrderAggregateID(evt.AggregateID))
+	if err != nil {
+		return err
+	}
+	projection.Refunds = append(projection.Refunds, &eventData.Refund)
+	projection.RefundedAmount = eventData.TotalRefunded
+	projection.Refunded = eventData.FullyRefunded
+	if eventData.FullyRefunded {
+		projection.Status = models.OrderStatusRefunded
+	}
+
+	return o.elasticRepository.UpdateOrder(ctx, projection)
+}
+
+func (o *elasticProjection) onReturnRequested(ctx context.Context, evt es.Event) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "elasticProjection.onReturnRequested")
+	defer span.Finish()
+	span.LogFields(log.String("AggregateID", evt.GetAggregateID()))
+
+	var eventData events.ReturnRequestedEvent
+	if err := evt.GetJsonData(&eventData); err != nil {
+		tracing.TraceErr(span, err)
+		return errors.Wrap(err, "evt.GetJsonData")
+	}
+
+	projection, err := o.elasticRepository.GetByID(ctx, aggregate.GetOrderAggregateID(evt.AggregateID))
+	if err != nil {
+		return err
+	}
+	projection.Returns = append(projection.Returns, &models.OrderReturn{
+		ReturnID:    eventData.ReturnID,
+		Items:       eventData.Items,
+		Reason:      eventData.Reason,
+		Status:      models.ReturnRequested,
+		RequestedAt: eventData.RequestedAt,
+	})
 
 	return o.elasticRepository.UpdateOrder(ctx, projection)
+}
 
+func (o *elasticProjection) onReturnApproved(ctx context.Context, evt es.Event) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "elasticProjection.onReturnApproved")
+	defer span.Finish()
+	span.LogFields(log.String("AggregateID", evt.GetAggregateID()))
+
+	var eventData events.ReturnApprovedEvent
+	if err := evt.GetJsonData(&eventData); err != nil {
+		tracing.TraceErr(span, err)
+		return errors.Wrap(err, "evt.GetJsonData")
+	}
+
+	return o.updateReturn(ctx, evt, eventData.ReturnID, func(orderReturn *models.OrderReturn) {
+		orderReturn.Status = models.ReturnApproved
+	})
+}
+
+func (o *elasticProjection) onReturnRejected(ctx context.Context, evt es.Event) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "elasticProjection.onReturnRejected")
+	defer span.Finish()
+	span.LogFields(log.String("AggregateID", evt.GetAggregateID()))
+
+	var eventData events.ReturnRejectedEvent
+	if err := evt.GetJsonData(&eventData); err != nil {
+		tracing.TraceErr(span, err)
+		return errors.Wrap(err, "evt.GetJsonData")
+	}
+
+	return o.updateReturn(ctx, evt, eventData.ReturnID, func(orderReturn *models.OrderReturn) {
+		orderReturn.Status = models.ReturnRejected
+		orderReturn.RejectReason = eventData.RejectReason
+	})
+}
+
+func (o *elasticProjection) onReturnReceived(ctx context.Context, evt es.Event) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "elasticProjection.onReturnReceived")
+	defer span.Finish()
+	span.LogFields(log.String("AggregateID", evt.GetAggregateID()))
+
+	var eventData events.ReturnReceivedEvent
+	if err := evt.GetJsonData(&eventData); err != nil {
+		tracing.TraceErr(span, err)
+		return errors.Wrap(err, "evt.GetJsonData")
+	}
+
+	return o.updateReturn(ctx, evt, eventData.ReturnID, func(orderReturn *models.OrderReturn) {
+		orderReturn.Status = models.ReturnReceived
+		orderReturn.RefundID = eventData.RefundID
+		orderReturn.ReceivedAt = eventData.ReceivedAt
+	})
+}
+
+func (o *elasticProjection) updateReturn(ctx context.Context, evt es.Event, returnID string, update func(orderReturn *models.OrderReturn)) error {
+	projection, err := o.elasticRepository.GetByID(ctx, aggregate.GetOrderAggregateID(evt.AggregateID))
+	if err != nil {
+		return err
+	}
+
+	for _, orderReturn := range projection.Returns {
+		if orderReturn.ReturnID == returnID {
+			update(orderReturn)
+			return o.elasticRepository.UpdateOrder(ctx, projection)
+		}
+	}
+
+	return errors.Wrapf(aggregate.ErrReturnNotFound, "returnID: {%s}", returnID)
+}
+
+func (o *elasticProjection) onShipmentCreated(ctx context.Context, evt es.Event) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "elasticProjection.onShipmentCreated")
+	defer span.Finish()
+	span.LogFields(log.String("AggregateID", evt.GetAggregateID()))
+
+	var eventData events.ShipmentCreatedEvent
+	if err := evt.GetJsonData(&eventData); err != nil {
+		tracing.TraceErr(span, err)
+		return errors.Wrap(err, "evt.GetJsonData")
+	}
+
+	projection, err := o.elasticRepository.GetByID(ctx, aggregate.GetOrderAggregateID(evt.AggregateID))
+	if err != nil {
+		return err
+	}
+	projection.Shipments = append(projection.Shipments, &models.Shipment{
+		ShipmentID: eventData.ShipmentID,
+		Items:      eventData.Items,
+		Status:     models.ShipmentCreated,
+		CreatedAt:  eventData.CreatedAt,
+	})
+
+	return o.elasticRepository.UpdateOrder(ctx, projection)
+}
+
+func (o *elasticProjection) onShipmentPacked(ctx context.Context, evt es.Event) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "elasticProjection.onShipmentPacked")
+	defer span.Finish()
+	span.LogFields(log.String("AggregateID", evt.GetAggregateID()))
+
+	var eventData events.ShipmentPackedEvent
+	if err := evt.GetJsonData(&eventData); err != nil {
+		tracing.TraceErr(span, err)
+		return errors.Wrap(err, "evt.GetJsonData")
+	}
+
+	return o.updateShipment(ctx, evt, eventData.ShipmentID, func(shipment *models.Shipment) {
+		shipment.Status = models.ShipmentPacked
+		shipment.PackedAt = eventData.PackedAt
+	})
+}
+
+func (o *elasticProjection) onShipmentDispatched(ctx context.Context, evt es.Event) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "elasticProjection.onShipmentDispatched")
+	defer span.Finish()
+	span.LogFields(log.String("AggregateID", evt.GetAggregateID()))
+
+	var eventData events.ShipmentDispatchedEvent
+	if err := evt.GetJsonData(&eventData); err != nil {
+		tracing.TraceErr(span, err)
+		return errors.Wrap(err, "evt.GetJsonData")
+	}
+
+	return o.updateShipment(ctx, evt, eventData.ShipmentID, func(shipment *models.Shipment) {
+		shipment.Status = models.ShipmentDispatched
+		shipment.Carrier = eventData.Carrier
+		shipment.TrackingNumber = eventData.TrackingNumber
+		shipment.DispatchedAt = eventData.DispatchedAt
+	})
+}
+
+func (o *elasticProjection) onShipmentDelivered(ctx context.Context, evt es.Event) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "elasticProjection.onShipmentDelivered")
+	defer span.Finish()
+	span.LogFields(log.String("AggregateID", evt.GetAggregateID()))
+
+	var eventData events.ShipmentDeliveredEvent
+	if err := evt.GetJsonData(&eventData); err != nil {
+		tracing.TraceErr(span, err)
+		return errors.Wrap(err, "evt.GetJsonData")
+	}
+
+	return o.updateShipment(ctx, evt, eventData.ShipmentID, func(shipment *models.Shipment) {
+		shipment.Status = models.ShipmentDelivered
+		shipment.DeliveredAt = eventData.DeliveredAt
+	})
+}
+
+func (o *elasticProjection) updateShipment(ctx context.Context, evt es.Event, shipmentID string, update func(shipment *models.Shipment)) error {
+	projection, err := o.elasticRepository.GetByID(ctx, aggregate.GetOrderAggregateID(evt.AggregateID))
+	if err != nil {
+		return err
+	}
+
+	for _, shipment := range projection.Shipments {
+		if shipment.ShipmentID == shipmentID {
+			update(shipment)
+			return o.elasticRepository.UpdateOrder(ctx, projection)
+		}
+	}
+
+	return errors.Wrapf(aggregate.ErrShipmentNotFound, "shipmentID: {%s}", shipmentID)
+}
+
+func (o *elasticProjection) onShopItemAdded(ctx context.Context, evt es.Event) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "elasticProjection.onShopItemAdded")
+	defer span.Finish()
+	span.LogFields(log.String("AggregateID", evt.GetAggregateID()))
+
+	var eventData events.ShopItemAddedEvent
+	if err := evt.GetJsonData(&eventData); err != nil {
+		tracing.TraceErr(span, err)
+		return errors.Wrap(err, "evt.GetJsonData")
+	}
+
+	projection, err := o.elasticRepository.GetByID(ctx, aggregate.GetOrderAggregateID(evt.AggregateID))
+	if err != nil {
+		return err
+	}
+	projection.ShopItems = append(projection.ShopItems, eventData.ShopItem)
+	projection.SetEventPricing(eventData.Pricing, eventData.TotalPrice)
+
+	return o.elasticRepository.UpdateOrder(ctx, projection)
+}
+
+func (o *elasticProjection) onShopItemRemoved(ctx context.Context, evt es.Event) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "elasticProjection.onShopItemRemoved")
+	defer span.Finish()
+	span.LogFields(log.String("AggregateID", evt.GetAggregateID()))
+
+	var eventData events.ShopItemRemovedEvent
+	if err := evt.GetJsonData(&eventData); err != nil {
+		tracing.TraceErr(span, err)
+		return errors.Wrap(err, "evt.GetJsonData")
+	}
+
+	projection, err := o.elasticRepository.GetByID(ctx, aggregate.GetOrderAggregateID(evt.AggregateID))
+	if err != nil {
+		return err
+	}
+	shopItems := make([]*models.ShopItem, 0, len(projection.ShopItems))
+	for _, item := range projection.ShopItems {
+		if item.ID != eventData.ShopItemID {
+			shopItems = append(shopItems, item)
+		}
+	}
+	projection.ShopItems = shopItems
+	projection.SetEventPricing(eventData.Pricing, eventData.TotalPrice)
+
+	return o.elasticRepository.UpdateOrder(ctx, projection)
+}
+
+func (o *elasticProjection) onShopItemQuantityChanged(ctx context.Context, evt es.Event) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "elasticProjection.onShopItemQuantityChanged")
+	defer span.Finish()
+	span.LogFields(log.String("AggregateID", evt.GetAggregateID()))
+
+	var eventData events.ShopItemQuantityChangedEvent
+	if err := evt.GetJsonData(&eventData); err != nil {
+		tracing.TraceErr(span, err)
+		return errors.Wrap(err, "evt.GetJsonData")
+	}
+
+	projection, err := o.elasticRepository.GetByID(ctx, aggregate.GetOrderAggregateID(evt.AggregateID))
+	if err != nil {
+		return err
+	}
+	for _, item := range projection.ShopItems {
+		if item.ID == eventData.ShopItemID {
+			item.Quantity = eventData.Quantity
+		}
+	}
+	projection.SetEventPricing(eventData.Pricing, eventData.TotalPrice)
+
+	return o.elasticRepository.UpdateOrder(ctx, projection)
+}
+
+func (o *elasticProjection) onCouponApplied(ctx context.Context, evt es.Event) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "elasticProjection.onCouponApplied")
+	defer span.Finish()
+	span.LogFields(log.String("AggregateID", evt.GetAggregateID()))
+
+	var eventData events.CouponAppliedEvent
+	if err := evt.GetJsonData(&eventData); err != nil {
+		tracing.TraceErr(span, err)
+		return errors.Wrap(err, "evt.GetJsonData")
+	}
+
+	projection, err := o.elasticRepository.GetByID(ctx, aggregate.GetOrderAggregateID(evt.AggregateID))
+	if err != nil {
+		return err
+	}
+	projection.Coupons = append(projection.Coupons, &eventData.Coupon)
+	projection.SetPricing(eventData.Pricing)
+
+	return o.elasticRepository.UpdateOrder(ctx, projection)
+}
+
+func (o *elasticProjection) onCouponRemoved(ctx context.Context, evt es.Event) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "elasticProjection.onCouponRemoved")
+	defer span.Finish()
+	span.LogFields(log.String("AggregateID", evt.GetAggregateID()))
+
+	var eventData events.CouponRemovedEvent
+	if err := evt.GetJsonData(&eventData); err != nil {
+		tracing.TraceErr(span, err)
+		return errors.Wrap(err, "evt.GetJsonData")
+	}
+
+	projection, err := o.elasticRepository.GetByID(ctx, aggregate.GetOrderAggregateID(evt.AggregateID))
+	if err != nil {
+		return err
+	}
+	coupons := make([]*models.Coupon, 0, len(projection.Coupons))
+	for _, coupon := range projection.Coupons {
+		if coupon.Code != eventData.Code {
+			coupons = append(coupons, coupon)
+		}
+	}
+	projection.Coupons = coupons
+	projection.SetPricing(eventData.Pricing)
+
+	return o.elasticRepository.UpdateOrder(ctx, projection)
 }
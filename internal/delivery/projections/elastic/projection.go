@@ -90,7 +90,10 @@ func (o *elasticProjection) processSingleEvent(
 		workerID,
 	)
 
-	err := o.When(ctx, es.NewEventFromRecorded(event.Event))
+	esEvent, err := es.Upcast(es.NewEventFromRecorded(event.Event))
+	if err == nil {
+		err = o.When(ctx, esEvent)
+	}
 	if err != nil {
 		if nackErr := stream.Nack(err.Error(), esdb.Nack_Retry, event); nackErr != nil {
 			return errors.Wrap(nackErr, "failed to Nack event")
@@ -105,6 +108,8 @@ func (o *elasticProjection) processSingleEvent(
 	return nil
 }
 func (o *elasticProjection) When(ctx context.Context, evt es.Event) error {
+	// the document of the event lives in the index of the tenant of its stream
+	ctx = es.ContextWithTenant(ctx, evt.GetTenant())
 	ctx, span := tracing.StartProjectionTracerSpan(ctx, "elasticProjection.When", evt)
 	defer span.Finish()
 	span.LogFields(log.String("AggregateID", evt.GetAggregateID()))
@@ -121,10 +126,51 @@ func (o *elasticProjection) When(ctx context.Context, evt es.Event) error {
 		return o.onShoppingCartUpdate(ctx, evt)
 	case events.OrderCanceled:
 		return o.onCancel(ctx, evt)
+	case events.OrderRejected:
+		return o.onReject(ctx, evt)
 	case events.OrderCompleted:
 		return o.onComplete(ctx, evt)
 	case events.DeliveryAddressChanged:
 		return o.onDeliveryAddressChanged(ctx, evt)
+	case events.OrderRefunded:
+		return o.onRefund(ctx, evt)
+	case events.RefundRequested, events.RefundFailed:
+		// refunds are indexed once the payment provider refunded them
+		return nil
+	case events.ReturnRequested:
+		return o.onReturnRequested(ctx, evt)
+	case events.ReturnApproved:
+		return o.onReturnApproved(ctx, evt)
+	case events.ReturnRejected:
+		return o.onReturnRejected(ctx, evt)
+	case events.ReturnReceived:
+		return o.onReturnReceived(ctx, evt)
+	case events.ShipmentCreated:
+		return o.onShipmentCreated(ctx, evt)
+	case events.ShipmentPacked:
+		return o.onShipmentPacked(ctx, evt)
+	case events.ShipmentDispatched:
+		return o.onShipmentDispatched(ctx, evt)
+	case events.ShipmentDelivered:
+		return o.onShipmentDelivered(ctx, evt)
+	case events.ShopItemAdded:
+		return o.onShopItemAdded(ctx, evt)
+	case events.ShopItemRemoved:
+		return o.onShopItemRemoved(ctx, evt)
+	case events.ShopItemQuantityChanged:
+		return o.onShopItemQuantityChanged(ctx, evt)
+	case events.CouponApplied:
+		return o.onCouponApplied(ctx, evt)
+	case events.CouponRemoved:
+		return o.onCouponRemoved(ctx, evt)
+	case events.PaymentReminderSent:
+		return o.onPaymentReminderSent(ctx, evt)
+	case events.PaymentAttempted:
+		return o.onPaymentAttempted(ctx, evt)
+	case events.PaymentAuthorized:
+		return o.onPaymentAuthorized(ctx, evt)
+	case events.PaymentFailed:
+		return o.onPaymentFailed(ctx, evt)
 
 	default:
 		o.log.Warnf("(elasticProjection) [When unknown EventType] eventType: {%s}", evt.EventType)
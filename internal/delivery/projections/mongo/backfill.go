@@ -0,0 +1,93 @@
+package mongo
+
+import (
+	"context"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+
+	"github.com/wassef911/eventually/internal/delivery/aggregate"
+	"github.com/wassef911/eventually/internal/delivery/models"
+	"github.com/wassef911/eventually/internal/delivery/repository"
+	"github.com/wassef911/eventually/internal/infrastructure/es/store"
+	"github.com/wassef911/eventually/pkg/logger"
+)
+
+// backfillBatchSize orders read from the projection at once.
+const backfillBatchSize = 100
+
+// BackfillCreatedAt sets the creation time of the orders projected before the projection recorded it, taken from
+// the event that created each order. Returns the number of orders it set.
+func BackfillCreatedAt(ctx context.Context, log logger.Logger, mongoRepo repository.OrderMongoRepository, aggregateStore store.AggregateStore) (int, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "mongoProjection.BackfillCreatedAt")
+	defer span.Finish()
+
+	return backfillOrders(ctx, aggregateStore, mongoRepo.ListWithoutCreatedAt, func(order *aggregate.OrderAggregate) (bool, error) {
+		if order.Order.CreatedAt.IsZero() {
+			// the stream of the order is gone, the order keeps sorting before every dated order
+			log.Warnf("(BackfillCreatedAt) order without created event, orderID: {%s}", order.Order.ID)
+			return false, nil
+		}
+		return true, errors.Wrap(mongoRepo.SetCreatedAt(ctx, order.Order.ID, order.Order.CreatedAt), "mongoRepo.SetCreatedAt")
+	})
+}
+
+// BackfillStatus sets the status of the orders projected while the projection stored paid, submitted, completed
+// and canceled flags instead, replayed from the stream of each order. Returns the number of orders it set.
+func BackfillStatus(ctx context.Context, log logger.Logger, mongoRepo repository.OrderMongoRepository, aggregateStore store.AggregateStore) (int, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "mongoProjection.BackfillStatus")
+	defer span.Finish()
+
+	return backfillOrders(ctx, aggregateStore, mongoRepo.ListWithoutStatus, func(order *aggregate.OrderAggregate) (bool, error) {
+		if order.Order.Status == models.OrderStatusNew {
+			log.Warnf("(BackfillStatus) order without created event, orderID: {%s}", order.Order.ID)
+			return false, nil
+		}
+		return true, errors.Wrap(mongoRepo.SetStatus(ctx, order.Order.ID, order.Order.Status), "mongoRepo.SetStatus")
+	})
+}
+
+// backfillOrders replays every order list returns and hands it to set until list returns no order set accepts,
+// orders set skips are not read again.
+func backfillOrders(
+	ctx context.Context,
+	aggregateStore store.AggregateStore,
+	list func(ctx context.Context, limit int) ([]string, error),
+	set func(order *aggregate.OrderAggregate) (bool, error),
+) (int, error) {
+	backfilled := 0
+	skipped := make(map[string]bool)
+	for {
+		orderIDs, err := list(ctx, backfillBatchSize+len(skipped))
+		if err != nil {
+			return backfilled, errors.Wrap(err, "list")
+		}
+
+		progressed := false
+		for _, orderID := range orderIDs {
+			if skipped[orderID] {
+				continue
+			}
+
+			order, err := aggregate.LoadOrderAggregate(ctx, aggregateStore, orderID)
+			if err != nil {
+				return backfilled, errors.Wrapf(err, "LoadOrderAggregate orderID: {%s}", orderID)
+			}
+
+			ok, err := set(order)
+			if err != nil {
+				return backfilled, errors.Wrapf(err, "orderID: {%s}", orderID)
+			}
+			if !ok {
+				skipped[orderID] = true
+				continue
+			}
+			backfilled++
+			progressed = true
+		}
+
+		if !progressed {
+			return backfilled, nil
+		}
+	}
+}
@@ -0,0 +1,153 @@
+package mongo
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/EventStore/EventStore-Client-Go/esdb"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wassef911/eventually/internal/delivery/aggregate"
+	"github.com/wassef911/eventually/internal/delivery/models"
+	"github.com/wassef911/eventually/internal/delivery/repository"
+	"github.com/wassef911/eventually/internal/infrastructure/es"
+	"github.com/wassef911/eventually/pkg/logger"
+)
+
+type memoryStore struct {
+	streams map[string][]es.Event
+}
+
+func (m *memoryStore) Load(ctx context.Context, aggregate es.Aggregate) error {
+	for _, event := range m.streams[aggregate.GetID()] {
+		if err := aggregate.RaiseEvent(event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *memoryStore) Save(ctx context.Context, aggregate es.Aggregate) error {
+	m.streams[aggregate.GetID()] = append(m.streams[aggregate.GetID()], aggregate.GetUncommittedEvents()...)
+	aggregate.ClearUncommittedEvents()
+	return nil
+}
+
+func (m *memoryStore) Exists(ctx context.Context, streamID string) error {
+	if _, ok := m.streams[streamID]; !ok {
+		return errors.Wrap(esdb.ErrStreamNotFound, "Exists")
+	}
+	return nil
+}
+
+// legacyOrderRepository orders projection whose documents may lack createdAt or status.
+type legacyOrderRepository struct {
+	repository.OrderMongoRepository
+	createdAt map[string]time.Time
+	status    map[string]models.OrderStatus
+}
+
+func (r *legacyOrderRepository) ListWithoutCreatedAt(ctx context.Context, limit int) ([]string, error) {
+	orderIDs := make([]string, 0)
+	for orderID, createdAt := range r.createdAt {
+		if createdAt.IsZero() {
+			orderIDs = append(orderIDs, orderID)
+		}
+	}
+	sort.Strings(orderIDs)
+	if len(orderIDs) > limit {
+		orderIDs = orderIDs[:limit]
+	}
+	return orderIDs, nil
+}
+
+func (r *legacyOrderRepository) SetCreatedAt(ctx context.Context, orderID string, createdAt time.Time) error {
+	r.createdAt[orderID] = createdAt
+	return nil
+}
+
+func (r *legacyOrderRepository) ListWithoutStatus(ctx context.Context, limit int) ([]string, error) {
+	orderIDs := make([]string, 0)
+	for orderID, status := range r.status {
+		if status == "" {
+			orderIDs = append(orderIDs, orderID)
+		}
+	}
+	sort.Strings(orderIDs)
+	if len(orderIDs) > limit {
+		orderIDs = orderIDs[:limit]
+	}
+	return orderIDs, nil
+}
+
+func (r *legacyOrderRepository) SetStatus(ctx context.Context, orderID string, status models.OrderStatus) error {
+	r.status[orderID] = status
+	return nil
+}
+
+func TestBackfillCreatedAt(t *testing.T) {
+	ctx := context.Background()
+	appLogger := logger.NewAppLogger(&logger.Config{LogLevel: "fatal"})
+	appLogger.InitLogger()
+	aggregateStore := &memoryStore{streams: make(map[string][]es.Event)}
+
+	order := aggregate.NewOrderAggregateWithID("order-1")
+	shopItems := []*models.ShopItem{{ID: "item1", Title: "Item 1", Quantity: 1, Price: models.NewMoney(1000, "USD")}}
+	address := models.Address{Recipient: "Jane Doe", Line1: "1 Main St", City: "Springfield", PostalCode: "62701", Region: "IL", Country: "US"}
+	require.NoError(t, order.CreateOrder(ctx, shopItems, "", "jane@example.com", address, 0))
+	createdAt := order.GetUncommittedEvents()[0].GetTimeStamp()
+	require.NoError(t, aggregateStore.Save(ctx, order))
+
+	projectedAt := time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC)
+	mongoRepo := &legacyOrderRepository{createdAt: map[string]time.Time{
+		"order-1": {},
+		"order-2": {},
+		"order-3": projectedAt,
+	}}
+
+	backfilled, err := BackfillCreatedAt(ctx, appLogger, mongoRepo, aggregateStore)
+	require.NoError(t, err)
+	assert.Equal(t, 1, backfilled)
+	assert.True(t, createdAt.Equal(mongoRepo.createdAt["order-1"]), "taken from the created event")
+	assert.True(t, mongoRepo.createdAt["order-2"].IsZero(), "an order without stream is skipped")
+	assert.Equal(t, projectedAt, mongoRepo.createdAt["order-3"])
+
+	backfilled, err = BackfillCreatedAt(ctx, appLogger, mongoRepo, aggregateStore)
+	require.NoError(t, err)
+	assert.Zero(t, backfilled)
+}
+
+func TestBackfillStatus(t *testing.T) {
+	ctx := context.Background()
+	appLogger := logger.NewAppLogger(&logger.Config{LogLevel: "fatal"})
+	appLogger.InitLogger()
+	aggregateStore := &memoryStore{streams: make(map[string][]es.Event)}
+
+	order := aggregate.NewOrderAggregateWithID("order-1")
+	shopItems := []*models.ShopItem{{ID: "item1", Title: "Item 1", Quantity: 1, Price: models.NewMoney(1000, "USD")}}
+	address := models.Address{Recipient: "Jane Doe", Line1: "1 Main St", City: "Springfield", PostalCode: "62701", Region: "IL", Country: "US"}
+	require.NoError(t, order.CreateOrder(ctx, shopItems, "", "jane@example.com", address, 0))
+	require.NoError(t, order.CancelOrder(ctx, "changed my mind"))
+	require.NoError(t, aggregateStore.Save(ctx, order))
+
+	mongoRepo := &legacyOrderRepository{status: map[string]models.OrderStatus{
+		"order-1": "",
+		"order-2": "",
+		"order-3": models.OrderStatusPaid,
+	}}
+
+	backfilled, err := BackfillStatus(ctx, appLogger, mongoRepo, aggregateStore)
+	require.NoError(t, err)
+	assert.Equal(t, 1, backfilled)
+	assert.Equal(t, models.OrderStatusCanceled, mongoRepo.status["order-1"], "replayed from the stream")
+	assert.Empty(t, mongoRepo.status["order-2"], "an order without stream is skipped")
+	assert.Equal(t, models.OrderStatusPaid, mongoRepo.status["order-3"])
+
+	backfilled, err = BackfillStatus(ctx, appLogger, mongoRepo, aggregateStore)
+	require.NoError(t, err)
+	assert.Zero(t, backfilled)
+}
@@ -0,0 +1,237 @@
+package mongo
+
+import (
+	"context"
+
+	"github.com/EventStore/EventStore-Client-Go/esdb"
+	"github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/log"
+	"github.com/pkg/errors"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/wassef911/eventually/internal/api/constants"
+	"github.com/wassef911/eventually/internal/delivery/aggregate"
+	"github.com/wassef911/eventually/internal/delivery/events"
+	"github.com/wassef911/eventually/internal/delivery/models"
+	"github.com/wassef911/eventually/internal/delivery/repository"
+	"github.com/wassef911/eventually/internal/infrastructure/es"
+	"github.com/wassef911/eventually/internal/infrastructure/tracing"
+	"github.com/wassef911/eventually/pkg/config"
+	"github.com/wassef911/eventually/pkg/logger"
+)
+
+// customerProjection keeps the customers collection in sync with the customer streams,
+// it runs on its own persistent subscription group.
+type customerProjection struct {
+	log          logger.Logger
+	db           *esdb.Client
+	config       *config.Config
+	customerRepo repository.CustomerRepository
+}
+
+func NewCustomerProjection(log logger.Logger, db *esdb.Client, customerRepo repository.CustomerRepository, config *config.Config) *customerProjection {
+	return &customerProjection{log: log, db: db, customerRepo: customerRepo, config: config}
+}
+
+func (o *customerProjection) Subscribe(ctx context.Context, prefixes []string, poolSize int, worker Worker) error {
+
+	err := o.db.CreatePersistentSubscriptionAll(ctx, o.config.Subscriptions.CustomerProjectionGroupName, esdb.PersistentAllSubscriptionOptions{
+		Filter: &esdb.SubscriptionFilter{Type: esdb.StreamFilterType, Prefixes: prefixes},
+	})
+	if err != nil {
+		if subscriptionError, ok := err.(*esdb.PersistentSubscriptionError); !ok || ok && (subscriptionError.Code != 6) {
+			return err
+		}
+	}
+
+	stream, err := o.db.ConnectToPersistentSubscription(
+		ctx,
+		constants.EsAll,
+		o.config.Subscriptions.CustomerProjectionGroupName,
+		esdb.ConnectToPersistentSubscriptionOptions{},
+	)
+	if err != nil {
+		return err
+	}
+	defer stream.Close()
+
+	g, ctx := errgroup.WithContext(ctx)
+	for i := 0; i <= poolSize; i++ {
+		g.Go(func() error { return worker(ctx, stream, i) })
+	}
+	return g.Wait()
+}
+
+func (o *customerProjection) ProcessEvents(ctx context.Context, stream *esdb.PersistentSubscription, workerID int) error {
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		event := stream.Recv()
+
+		switch {
+		case event.SubscriptionDropped != nil:
+			return errors.Wrap(event.SubscriptionDropped.Error, "subscription dropped")
+
+		case event.EventAppeared != nil:
+			return o.processSingleEvent(ctx, stream, event.EventAppeared, workerID)
+		}
+	}
+}
+
+func (o *customerProjection) processSingleEvent(
+	ctx context.Context,
+	stream *esdb.PersistentSubscription,
+	event *esdb.ResolvedEvent,
+	workerID int,
+) error {
+	o.log.ProjectionEvent(
+		constants.MongoProjection,
+		o.config.Subscriptions.CustomerProjectionGroupName,
+		event,
+		workerID,
+	)
+
+	esEvent, err := es.Upcast(es.NewEventFromRecorded(event.Event))
+	if err == nil {
+		err = o.When(ctx, esEvent)
+	}
+	if err != nil {
+		if nackErr := stream.Nack(err.Error(), esdb.Nack_Retry, event); nackErr != nil {
+			return errors.Wrap(nackErr, "failed to Nack event")
+		}
+		return nil
+	}
+
+	if ackErr := stream.Ack(event); ackErr != nil {
+		return errors.Wrap(ackErr, "failed to Ack event")
+	}
+
+	return nil
+}
+
+func (o *customerProjection) When(ctx context.Context, evt es.Event) error {
+	// the document of the event lives in the collections of the tenant of its stream
+	ctx = es.ContextWithTenant(ctx, evt.GetTenant())
+	ctx, span := tracing.StartProjectionTracerSpan(ctx, "customerProjection.When", evt)
+	defer span.Finish()
+	span.LogFields(
+		log.String("AggregateID", evt.GetAggregateID()),
+		log.String("EventType", evt.GetEventType()),
+	)
+
+	handlers := map[string]func(context.Context, es.Event) error{
+		events.CustomerRegistered:         o.onRegistered,
+		events.CustomerEmailChanged:       o.onEmailChanged,
+		events.CustomerAddressSaved:       o.onAddressSaved,
+		events.CustomerAddressRemoved:     o.onAddressRemoved,
+		events.CustomerPreferencesUpdated: o.onPreferencesUpdated,
+	}
+
+	handler, exists := handlers[evt.GetEventType()]
+	if !exists {
+		o.log.Warnf("(customerProjection) [When unknown EventType] eventType: {%s}", evt.GetEventType())
+		return es.ErrInvalidEventType
+	}
+
+	return handler(ctx, evt)
+}
+
+func (o *customerProjection) onRegistered(ctx context.Context, evt es.Event) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "customerProjection.onRegistered")
+	defer span.Finish()
+	span.LogFields(log.String("AggregateID", evt.GetAggregateID()))
+
+	var eventData events.CustomerRegisteredEvent
+	if err := evt.GetJsonData(&eventData); err != nil {
+		tracing.TraceErr(span, err)
+		return errors.Wrap(err, "evt.GetJsonData")
+	}
+
+	customer := models.NewCustomer()
+	customer.CustomerID = aggregate.GetCustomerAggregateID(evt.AggregateID)
+	customer.Email = eventData.Email
+	customer.Name = eventData.Name
+	customer.RegisteredAt = eventData.RegisteredAt
+
+	return o.customerRepo.Insert(ctx, &models.CustomerProjection{Customer: *customer})
+}
+
+func (o *customerProjection) onEmailChanged(ctx context.Context, evt es.Event) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "customerProjection.onEmailChanged")
+	defer span.Finish()
+	span.LogFields(log.String("AggregateID", evt.GetAggregateID()))
+
+	var eventData events.CustomerEmailChangedEvent
+	if err := evt.GetJsonData(&eventData); err != nil {
+		tracing.TraceErr(span, err)
+		return errors.Wrap(err, "evt.GetJsonData")
+	}
+
+	return o.updateCustomer(ctx, evt, func(customer *models.CustomerProjection) {
+		customer.Email = eventData.Email
+	})
+}
+
+func (o *customerProjection) onAddressSaved(ctx context.Context, evt es.Event) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "customerProjection.onAddressSaved")
+	defer span.Finish()
+	span.LogFields(log.String("AggregateID", evt.GetAggregateID()))
+
+	var eventData events.CustomerAddressSavedEvent
+	if err := evt.GetJsonData(&eventData); err != nil {
+		tracing.TraceErr(span, err)
+		return errors.Wrap(err, "evt.GetJsonData")
+	}
+
+	return o.updateCustomer(ctx, evt, func(customer *models.CustomerProjection) {
+		customer.SaveAddress(&eventData.SavedAddress)
+	})
+}
+
+func (o *customerProjection) onAddressRemoved(ctx context.Context, evt es.Event) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "customerProjection.onAddressRemoved")
+	defer span.Finish()
+	span.LogFields(log.String("AggregateID", evt.GetAggregateID()))
+
+	var eventData events.CustomerAddressRemovedEvent
+	if err := evt.GetJsonData(&eventData); err != nil {
+		tracing.TraceErr(span, err)
+		return errors.Wrap(err, "evt.GetJsonData")
+	}
+
+	return o.updateCustomer(ctx, evt, func(customer *models.CustomerProjection) {
+		customer.RemoveAddress(eventData.AddressID)
+	})
+}
+
+func (o *customerProjection) onPreferencesUpdated(ctx context.Context, evt es.Event) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "customerProjection.onPreferencesUpdated")
+	defer span.Finish()
+	span.LogFields(log.String("AggregateID", evt.GetAggregateID()))
+
+	var eventData events.CustomerPreferencesUpdatedEvent
+	if err := evt.GetJsonData(&eventData); err != nil {
+		tracing.TraceErr(span, err)
+		return errors.Wrap(err, "evt.GetJsonData")
+	}
+
+	return o.updateCustomer(ctx, evt, func(customer *models.CustomerProjection) {
+		customer.Preferences = eventData.CustomerPreferences
+	})
+}
+
+// updateCustomer applies the change to the stored read model and replaces it.
+func (o *customerProjection) updateCustomer(ctx context.Context, evt es.Event, apply func(customer *models.CustomerProjection)) error {
+	customer, err := o.customerRepo.GetByID(ctx, aggregate.GetCustomerAggregateID(evt.AggregateID))
+	if err != nil {
+		return err
+	}
+	if customer.Addresses == nil {
+		customer.Addresses = make([]*models.SavedAddress, 0)
+	}
+
+	apply(customer)
+	return o.customerRepo.Update(ctx, customer)
+}
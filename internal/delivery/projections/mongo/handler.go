@@ -26,15 +26,24 @@ func (o *mongoProjection) onOrderCreate(ctx context.Context, evt es.Event) error
 	}
 	span.LogFields(log.String("AccountEmail", eventData.AccountEmail))
 
+	pricing, err := aggregate.GetEventPricing(eventData.Pricing, eventData.ShopItems)
+	if err != nil {
+		tracing.TraceErr(span, err)
+		return errors.Wrap(err, "GetEventPricing")
+	}
+
 	op := &models.OrderProjection{
 		OrderID:         aggregate.GetOrderAggregateID(evt.AggregateID),
 		ShopItems:       eventData.ShopItems,
+		CustomerID:      eventData.CustomerID,
 		AccountEmail:    eventData.AccountEmail,
-		TotalPrice:      aggregate.GetShopItemsTotalPrice(eventData.ShopItems),
 		DeliveryAddress: eventData.DeliveryAddress,
+		Status:          models.OrderStatusCreated,
+		CreatedAt:       evt.GetTimeStamp(),
 	}
+	op.SetPricing(pricing)
 
-	_, err := o.mongoRepo.Insert(ctx, op)
+	_, err = o.mongoRepo.Insert(ctx, op)
 	if err != nil {
 		return err
 	}
@@ -52,8 +61,79 @@ func (o *mongoProjection) onOrderPaid(ctx context.Context, evt es.Event) error {
 		return errors.Wrap(err, "GetJsonData")
 	}
 
-	op := &models.OrderProjection{OrderID: aggregate.GetOrderAggregateID(evt.AggregateID), Paid: true, Payment: payment}
-	return o.mongoRepo.UpdatePayment(ctx, op)
+	op := &models.OrderProjection{OrderID: aggregate.GetOrderAggregateID(evt.AggregateID), Status: models.OrderStatusPaid, Payment: payment}
+	if err := o.mongoRepo.UpdatePayment(ctx, op); err != nil {
+		return err
+	}
+
+	// payments recorded before the gateway integration have no attempt
+	if payment.Provider == "" {
+		return nil
+	}
+	attempt := &models.PaymentAttempt{PaymentID: payment.PaymentID, Status: models.PaymentAttemptCaptured}
+	return o.mongoRepo.UpdatePaymentAttempt(ctx, op.OrderID, attempt)
+}
+
+func (o *mongoProjection) onPaymentAttempted(ctx context.Context, evt es.Event) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "mongoProjection.onPaymentAttempted")
+	defer span.Finish()
+	span.LogFields(log.String("AggregateID", evt.GetAggregateID()))
+
+	var eventData events.PaymentAttemptedEvent
+	if err := evt.GetJsonData(&eventData); err != nil {
+		tracing.TraceErr(span, err)
+		return errors.Wrap(err, "evt.GetJsonData")
+	}
+
+	op := &models.OrderProjection{
+		OrderID: aggregate.GetOrderAggregateID(evt.AggregateID),
+		PaymentAttempts: []*models.PaymentAttempt{{
+			AttemptID: eventData.AttemptID,
+			Provider:  eventData.Provider,
+			Amount:    eventData.Amount,
+			Status:    models.PaymentAttemptStarted,
+			Timestamp: eventData.Timestamp,
+		}},
+	}
+	return o.mongoRepo.AddPaymentAttempt(ctx, op)
+}
+
+func (o *mongoProjection) onPaymentAuthorized(ctx context.Context, evt es.Event) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "mongoProjection.onPaymentAuthorized")
+	defer span.Finish()
+	span.LogFields(log.String("AggregateID", evt.GetAggregateID()))
+
+	var eventData events.PaymentAuthorizedEvent
+	if err := evt.GetJsonData(&eventData); err != nil {
+		tracing.TraceErr(span, err)
+		return errors.Wrap(err, "evt.GetJsonData")
+	}
+
+	attempt := &models.PaymentAttempt{AttemptID: eventData.AttemptID, PaymentID: eventData.PaymentID, Status: models.PaymentAttemptAuthorized}
+	if eventData.Pending {
+		attempt.Status = models.PaymentAttemptPending
+	}
+	return o.mongoRepo.UpdatePaymentAttempt(ctx, aggregate.GetOrderAggregateID(evt.AggregateID), attempt)
+}
+
+func (o *mongoProjection) onPaymentFailed(ctx context.Context, evt es.Event) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "mongoProjection.onPaymentFailed")
+	defer span.Finish()
+	span.LogFields(log.String("AggregateID", evt.GetAggregateID()))
+
+	var eventData events.PaymentFailedEvent
+	if err := evt.GetJsonData(&eventData); err != nil {
+		tracing.TraceErr(span, err)
+		return errors.Wrap(err, "evt.GetJsonData")
+	}
+
+	attempt := &models.PaymentAttempt{AttemptID: eventData.AttemptID, Status: models.PaymentAttemptFailed, FailureReason: eventData.Reason}
+	return o.mongoRepo.UpdatePaymentAttempt(ctx, aggregate.GetOrderAggregateID(evt.AggregateID), attempt)
+}
+
+// skipPendingRefund refunds are projected once the payment provider refunded them, by OrderRefunded.
+func (o *mongoProjection) skipPendingRefund(ctx context.Context, evt es.Event) error {
+	return nil
 }
 
 func (o *mongoProjection) onSubmit(ctx context.Context, evt es.Event) error {
@@ -61,7 +141,7 @@ func (o *mongoProjection) onSubmit(ctx context.Context, evt es.Event) error {
 	defer span.Finish()
 	span.LogFields(log.String("AggregateID", evt.GetAggregateID()))
 
-	op := &models.OrderProjection{OrderID: aggregate.GetOrderAggregateID(evt.AggregateID), Submitted: true}
+	op := &models.OrderProjection{OrderID: aggregate.GetOrderAggregateID(evt.AggregateID), Status: models.OrderStatusSubmitted}
 	return o.mongoRepo.UpdateSubmit(ctx, op)
 }
 
@@ -76,8 +156,14 @@ func (o *mongoProjection) onShoppingCartUpdate(ctx context.Context, evt es.Event
 		return errors.Wrap(err, "evt.GetJsonData")
 	}
 
+	pricing, err := aggregate.GetEventPricing(eventData.Pricing, eventData.ShopItems)
+	if err != nil {
+		tracing.TraceErr(span, err)
+		return errors.Wrap(err, "GetEventPricing")
+	}
+
 	op := &models.OrderProjection{OrderID: aggregate.GetOrderAggregateID(evt.AggregateID), ShopItems: eventData.ShopItems}
-	op.TotalPrice = aggregate.GetShopItemsTotalPrice(eventData.ShopItems)
+	op.SetPricing(pricing)
 	return o.mongoRepo.UpdateOrder(ctx, op)
 }
 
@@ -94,13 +180,49 @@ func (o *mongoProjection) onCancel(ctx context.Context, evt es.Event) error {
 
 	op := &models.OrderProjection{
 		OrderID:      aggregate.GetOrderAggregateID(evt.AggregateID),
-		Canceled:     true,
-		Completed:    false,
+		Status:       models.OrderStatusCanceled,
 		CancelReason: eventData.CancelReason,
 	}
 	return o.mongoRepo.UpdateCancel(ctx, op)
 }
 
+func (o *mongoProjection) onReject(ctx context.Context, evt es.Event) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "mongoProjection.onReject")
+	defer span.Finish()
+	span.LogFields(log.String("AggregateID", evt.GetAggregateID()))
+
+	var eventData events.OrderRejectedEvent
+	if err := evt.GetJsonData(&eventData); err != nil {
+		tracing.TraceErr(span, err)
+		return errors.Wrap(err, "evt.GetJsonData")
+	}
+
+	op := &models.OrderProjection{
+		OrderID:      aggregate.GetOrderAggregateID(evt.AggregateID),
+		Status:       models.OrderStatusRejected,
+		RejectReason: eventData.RejectReason,
+	}
+	return o.mongoRepo.UpdateReject(ctx, op)
+}
+
+func (o *mongoProjection) onPaymentReminderSent(ctx context.Context, evt es.Event) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "mongoProjection.onPaymentReminderSent")
+	defer span.Finish()
+	span.LogFields(log.String("AggregateID", evt.GetAggregateID()))
+
+	var eventData events.PaymentReminderSentEvent
+	if err := evt.GetJsonData(&eventData); err != nil {
+		tracing.TraceErr(span, err)
+		return errors.Wrap(err, "evt.GetJsonData")
+	}
+
+	op := &models.OrderProjection{
+		OrderID:          aggregate.GetOrderAggregateID(evt.AggregateID),
+		PaymentReminders: eventData.Reminder,
+	}
+	return o.mongoRepo.UpdatePaymentReminders(ctx, op)
+}
+
 func (o *mongoProjection) onCompleted(ctx context.Context, evt es.Event) error {
 	span, ctx := opentracing.StartSpanFromContext(ctx, "mongoProjection.onCompleted")
 	defer span.Finish()
@@ -114,8 +236,7 @@ func (o *mongoProjection) onCompleted(ctx context.Context, evt es.Event) error {
 
 	op := &models.OrderProjection{
 		OrderID:       aggregate.GetOrderAggregateID(evt.AggregateID),
-		Canceled:      false,
-		Completed:     true,
+		Status:        models.OrderStatusCompleted,
 		DeliveredTime: eventData.DeliveryTimestamp,
 	}
 	return o.mongoRepo.Complete(ctx, op)
@@ -136,5 +257,262 @@ func (o *mongoProjection) onDeliveryAddressChanged(ctx context.Context, evt es.E
 		OrderID:         aggregate.GetOrderAggregateID(evt.AggregateID),
 		DeliveryAddress: eventData.DeliveryAddress,
 	}
+	if eventData.Pricing != nil {
+		op.SetPricing(*eventData.Pricing)
+	}
 	return o.mongoRepo.UpdateDeliveryAddress(ctx, op)
 }
+
+func (o *mongoProjection) onRefund(ctx context.Context, evt es.Event) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "mongoProjection.onRefund")
+	defer span.Finish()
+	span.LogFields(log.String("AggregateID", evt.GetAggregateID()))
+
+	var eventData events.OrderRefundedEvent
+	if err := evt.GetJsonData(&eventData); err != nil {
+		tracing.TraceErr(span, err)
+		return errors.Wrap(err, "evt.GetJsonData")
+	}
+
+	op := &models.OrderProjection{
+		OrderID:        aggregate.GetOrderAggregateID(evt.AggregateID),
+		Refunds:        []*models.Refund{&eventData.Refund},
+		RefundedAmount: eventData.TotalRefunded,
+		Refunded:       eventData.FullyRefunded,
+	}
+	if eventData.FullyRefunded {
+		op.Status = models.OrderStatusRefunded
+	}
+	return o.mongoRepo.AddRefund(ctx, op)
+}
+
+func (o *mongoProjection) onReturnRequested(ctx context.Context, evt es.Event) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "mongoProjection.onReturnRequested")
+	defer span.Finish()
+	span.LogFields(log.String("AggregateID", evt.GetAggregateID()))
+
+	var eventData events.ReturnRequestedEvent
+	if err := evt.GetJsonData(&eventData); err != nil {
+		tracing.TraceErr(span, err)
+		return errors.Wrap(err, "evt.GetJsonData")
+	}
+
+	op := &models.OrderProjection{
+		OrderID: aggregate.GetOrderAggregateID(evt.AggregateID),
+		Returns: []*models.OrderReturn{{
+			ReturnID:    eventData.ReturnID,
+			Items:       eventData.Items,
+			Reason:      eventData.Reason,
+			Status:      models.ReturnRequested,
+			RequestedAt: eventData.RequestedAt,
+		}},
+	}
+	return o.mongoRepo.AddReturn(ctx, op)
+}
+
+func (o *mongoProjection) onReturnApproved(ctx context.Context, evt es.Event) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "mongoProjection.onReturnApproved")
+	defer span.Finish()
+	span.LogFields(log.String("AggregateID", evt.GetAggregateID()))
+
+	var eventData events.ReturnApprovedEvent
+	if err := evt.GetJsonData(&eventData); err != nil {
+		tracing.TraceErr(span, err)
+		return errors.Wrap(err, "evt.GetJsonData")
+	}
+
+	orderReturn := &models.OrderReturn{ReturnID: eventData.ReturnID, Status: models.ReturnApproved}
+	return o.mongoRepo.UpdateReturn(ctx, aggregate.GetOrderAggregateID(evt.AggregateID), orderReturn)
+}
+
+func (o *mongoProjection) onReturnRejected(ctx context.Context, evt es.Event) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "mongoProjection.onReturnRejected")
+	defer span.Finish()
+	span.LogFields(log.String("AggregateID", evt.GetAggregateID()))
+
+	var eventData events.ReturnRejectedEvent
+	if err := evt.GetJsonData(&eventData); err != nil {
+		tracing.TraceErr(span, err)
+		return errors.Wrap(err, "evt.GetJsonData")
+	}
+
+	orderReturn := &models.OrderReturn{ReturnID: eventData.ReturnID, Status: models.ReturnRejected, RejectReason: eventData.RejectReason}
+	return o.mongoRepo.UpdateReturn(ctx, aggregate.GetOrderAggregateID(evt.AggregateID), orderReturn)
+}
+
+func (o *mongoProjection) onReturnReceived(ctx context.Context, evt es.Event) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "mongoProjection.onReturnReceived")
+	defer span.Finish()
+	span.LogFields(log.String("AggregateID", evt.GetAggregateID()))
+
+	var eventData events.ReturnReceivedEvent
+	if err := evt.GetJsonData(&eventData); err != nil {
+		tracing.TraceErr(span, err)
+		return errors.Wrap(err, "evt.GetJsonData")
+	}
+
+	orderReturn := &models.OrderReturn{
+		ReturnID:   eventData.ReturnID,
+		Status:     models.ReturnReceived,
+		RefundID:   eventData.RefundID,
+		ReceivedAt: eventData.ReceivedAt,
+	}
+	return o.mongoRepo.UpdateReturn(ctx, aggregate.GetOrderAggregateID(evt.AggregateID), orderReturn)
+}
+
+func (o *mongoProjection) onShipmentCreated(ctx context.Context, evt es.Event) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "mongoProjection.onShipmentCreated")
+	defer span.Finish()
+	span.LogFields(log.String("AggregateID", evt.GetAggregateID()))
+
+	var eventData events.ShipmentCreatedEvent
+	if err := evt.GetJsonData(&eventData); err != nil {
+		tracing.TraceErr(span, err)
+		return errors.Wrap(err, "evt.GetJsonData")
+	}
+
+	op := &models.OrderProjection{
+		OrderID: aggregate.GetOrderAggregateID(evt.AggregateID),
+		Shipments: []*models.Shipment{{
+			ShipmentID: eventData.ShipmentID,
+			Items:      eventData.Items,
+			Status:     models.ShipmentCreated,
+			CreatedAt:  eventData.CreatedAt,
+		}},
+	}
+	return o.mongoRepo.AddShipment(ctx, op)
+}
+
+func (o *mongoProjection) onShipmentPacked(ctx context.Context, evt es.Event) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "mongoProjection.onShipmentPacked")
+	defer span.Finish()
+	span.LogFields(log.String("AggregateID", evt.GetAggregateID()))
+
+	var eventData events.ShipmentPackedEvent
+	if err := evt.GetJsonData(&eventData); err != nil {
+		tracing.TraceErr(span, err)
+		return errors.Wrap(err, "evt.GetJsonData")
+	}
+
+	shipment := &models.Shipment{ShipmentID: eventData.ShipmentID, Status: models.ShipmentPacked, PackedAt: eventData.PackedAt}
+	return o.mongoRepo.UpdateShipment(ctx, aggregate.GetOrderAggregateID(evt.AggregateID), shipment)
+}
+
+func (o *mongoProjection) onShipmentDispatched(ctx context.Context, evt es.Event) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "mongoProjection.onShipmentDispatched")
+	defer span.Finish()
+	span.LogFields(log.String("AggregateID", evt.GetAggregateID()))
+
+	var eventData events.ShipmentDispatchedEvent
+	if err := evt.GetJsonData(&eventData); err != nil {
+		tracing.TraceErr(span, err)
+		return errors.Wrap(err, "evt.GetJsonData")
+	}
+
+	shipment := &models.Shipment{
+		ShipmentID:     eventData.ShipmentID,
+		Status:         models.ShipmentDispatched,
+		Carrier:        eventData.Carrier,
+		TrackingNumber: eventData.TrackingNumber,
+		DispatchedAt:   eventData.DispatchedAt,
+	}
+	return o.mongoRepo.UpdateShipment(ctx, aggregate.GetOrderAggregateID(evt.AggregateID), shipment)
+}
+
+func (o *mongoProjection) onShipmentDelivered(ctx context.Context, evt es.Event) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "mongoProjection.onShipmentDelivered")
+	defer span.Finish()
+	span.LogFields(log.String("AggregateID", evt.GetAggregateID()))
+
+	var eventData events.ShipmentDeliveredEvent
+	if err := evt.GetJsonData(&eventData); err != nil {
+		tracing.TraceErr(span, err)
+		return errors.Wrap(err, "evt.GetJsonData")
+	}
+
+	shipment := &models.Shipment{ShipmentID: eventData.ShipmentID, Status: models.ShipmentDelivered, DeliveredAt: eventData.DeliveredAt}
+	return o.mongoRepo.UpdateShipment(ctx, aggregate.GetOrderAggregateID(evt.AggregateID), shipment)
+}
+
+func (o *mongoProjection) onShopItemAdded(ctx context.Context, evt es.Event) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "mongoProjection.onShopItemAdded")
+	defer span.Finish()
+	span.LogFields(log.String("AggregateID", evt.GetAggregateID()))
+
+	var eventData events.ShopItemAddedEvent
+	if err := evt.GetJsonData(&eventData); err != nil {
+		tracing.TraceErr(span, err)
+		return errors.Wrap(err, "evt.GetJsonData")
+	}
+
+	op := &models.OrderProjection{
+		OrderID:   aggregate.GetOrderAggregateID(evt.AggregateID),
+		ShopItems: []*models.ShopItem{eventData.ShopItem},
+	}
+	op.SetEventPricing(eventData.Pricing, eventData.TotalPrice)
+	return o.mongoRepo.AddShopItem(ctx, op)
+}
+
+func (o *mongoProjection) onShopItemRemoved(ctx context.Context, evt es.Event) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "mongoProjection.onShopItemRemoved")
+	defer span.Finish()
+	span.LogFields(log.String("AggregateID", evt.GetAggregateID()))
+
+	var eventData events.ShopItemRemovedEvent
+	if err := evt.GetJsonData(&eventData); err != nil {
+		tracing.TraceErr(span, err)
+		return errors.Wrap(err, "evt.GetJsonData")
+	}
+
+	op := &models.OrderProjection{OrderID: aggregate.GetOrderAggregateID(evt.AggregateID)}
+	op.SetEventPricing(eventData.Pricing, eventData.TotalPrice)
+	return o.mongoRepo.RemoveShopItem(ctx, op, eventData.ShopItemID)
+}
+
+func (o *mongoProjection) onShopItemQuantityChanged(ctx context.Context, evt es.Event) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "mongoProjection.onShopItemQuantityChanged")
+	defer span.Finish()
+	span.LogFields(log.String("AggregateID", evt.GetAggregateID()))
+
+	var eventData events.ShopItemQuantityChangedEvent
+	if err := evt.GetJsonData(&eventData); err != nil {
+		tracing.TraceErr(span, err)
+		return errors.Wrap(err, "evt.GetJsonData")
+	}
+
+	op := &models.OrderProjection{OrderID: aggregate.GetOrderAggregateID(evt.AggregateID)}
+	op.SetEventPricing(eventData.Pricing, eventData.TotalPrice)
+	return o.mongoRepo.ChangeShopItemQuantity(ctx, op, eventData.ShopItemID, eventData.Quantity)
+}
+
+func (o *mongoProjection) onCouponApplied(ctx context.Context, evt es.Event) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "mongoProjection.onCouponApplied")
+	defer span.Finish()
+	span.LogFields(log.String("AggregateID", evt.GetAggregateID()))
+
+	var eventData events.CouponAppliedEvent
+	if err := evt.GetJsonData(&eventData); err != nil {
+		tracing.TraceErr(span, err)
+		return errors.Wrap(err, "evt.GetJsonData")
+	}
+
+	op := &models.OrderProjection{OrderID: aggregate.GetOrderAggregateID(evt.AggregateID), Coupons: []*models.Coupon{&eventData.Coupon}}
+	op.SetPricing(eventData.Pricing)
+	return o.mongoRepo.AddCoupon(ctx, op)
+}
+
+func (o *mongoProjection) onCouponRemoved(ctx context.Context, evt es.Event) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "mongoProjection.onCouponRemoved")
+	defer span.Finish()
+	span.LogFields(log.String("AggregateID", evt.GetAggregateID()))
+
+	var eventData events.CouponRemovedEvent
+	if err := evt.GetJsonData(&eventData); err != nil {
+		tracing.TraceErr(span, err)
+		return errors.Wrap(err, "evt.GetJsonData")
+	}
+
+	op := &models.OrderProjection{OrderID: aggregate.GetOrderAggregateID(evt.AggregateID)}
+	op.SetPricing(eventData.Pricing)
+	return o.mongoRepo.RemoveCoupon(ctx, op, eventData.Code)
+}
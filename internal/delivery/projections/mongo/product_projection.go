@@ -0,0 +1,205 @@
+package mongo
+
+import (
+	"context"
+
+	"github.com/EventStore/EventStore-Client-Go/esdb"
+	"github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/log"
+	"github.com/pkg/errors"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/wassef911/eventually/internal/api/constants"
+	"github.com/wassef911/eventually/internal/delivery/aggregate"
+	"github.com/wassef911/eventually/internal/delivery/events"
+	"github.com/wassef911/eventually/internal/delivery/models"
+	"github.com/wassef911/eventually/internal/delivery/repository"
+	"github.com/wassef911/eventually/internal/infrastructure/es"
+	"github.com/wassef911/eventually/internal/infrastructure/tracing"
+	"github.com/wassef911/eventually/pkg/config"
+	"github.com/wassef911/eventually/pkg/logger"
+)
+
+// productProjection keeps the catalog read model in sync with the product streams.
+type productProjection struct {
+	log         logger.Logger
+	db          *esdb.Client
+	config      *config.Config
+	productRepo repository.ProductRepository
+}
+
+func NewProductProjection(log logger.Logger, db *esdb.Client, productRepo repository.ProductRepository, config *config.Config) *productProjection {
+	return &productProjection{log: log, db: db, productRepo: productRepo, config: config}
+}
+
+func (o *productProjection) Subscribe(ctx context.Context, prefixes []string, poolSize int, worker Worker) error {
+
+	err := o.db.CreatePersistentSubscriptionAll(ctx, o.config.Subscriptions.ProductProjectionGroupName, esdb.PersistentAllSubscriptionOptions{
+		Filter: &esdb.SubscriptionFilter{Type: esdb.StreamFilterType, Prefixes: prefixes},
+	})
+	if err != nil {
+		if subscriptionError, ok := err.(*esdb.PersistentSubscriptionError); !ok || ok && (subscriptionError.Code != 6) {
+			return err
+		}
+	}
+
+	stream, err := o.db.ConnectToPersistentSubscription(
+		ctx,
+		constants.EsAll,
+		o.config.Subscriptions.ProductProjectionGroupName,
+		esdb.ConnectToPersistentSubscriptionOptions{},
+	)
+	if err != nil {
+		return err
+	}
+	defer stream.Close()
+
+	g, ctx := errgroup.WithContext(ctx)
+	for i := 0; i <= poolSize; i++ {
+		g.Go(func() error { return worker(ctx, stream, i) })
+	}
+	return g.Wait()
+}
+
+func (o *productProjection) ProcessEvents(ctx context.Context, stream *esdb.PersistentSubscription, workerID int) error {
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		event := stream.Recv()
+
+		switch {
+		case event.SubscriptionDropped != nil:
+			return errors.Wrap(event.SubscriptionDropped.Error, "subscription dropped")
+
+		case event.EventAppeared != nil:
+			return o.processSingleEvent(ctx, stream, event.EventAppeared, workerID)
+		}
+	}
+}
+
+func (o *productProjection) processSingleEvent(
+	ctx context.Context,
+	stream *esdb.PersistentSubscription,
+	event *esdb.ResolvedEvent,
+	workerID int,
+) error {
+	o.log.ProjectionEvent(
+		constants.MongoProjection,
+		o.config.Subscriptions.ProductProjectionGroupName,
+		event,
+		workerID,
+	)
+
+	esEvent, err := es.Upcast(es.NewEventFromRecorded(event.Event))
+	if err == nil {
+		err = o.When(ctx, esEvent)
+	}
+	if err != nil {
+		if nackErr := stream.Nack(err.Error(), esdb.Nack_Retry, event); nackErr != nil {
+			return errors.Wrap(nackErr, "failed to Nack event")
+		}
+		return nil
+	}
+
+	if ackErr := stream.Ack(event); ackErr != nil {
+		return errors.Wrap(ackErr, "failed to Ack event")
+	}
+
+	return nil
+}
+
+func (o *productProjection) When(ctx context.Context, evt es.Event) error {
+	// the document of the event lives in the collections of the tenant of its stream
+	ctx = es.ContextWithTenant(ctx, evt.GetTenant())
+	ctx, span := tracing.StartProjectionTracerSpan(ctx, "productProjection.When", evt)
+	defer span.Finish()
+	span.LogFields(
+		log.String("AggregateID", evt.GetAggregateID()),
+		log.String("EventType", evt.GetEventType()),
+	)
+
+	handlers := map[string]func(context.Context, es.Event) error{
+		events.ProductCreated:      o.onCreated,
+		events.ProductRepriced:     o.onRepriced,
+		events.ProductDiscontinued: o.onDiscontinued,
+	}
+
+	handler, exists := handlers[evt.GetEventType()]
+	if !exists {
+		o.log.Warnf("(productProjection) [When unknown EventType] eventType: {%s}", evt.GetEventType())
+		return es.ErrInvalidEventType
+	}
+
+	return handler(ctx, evt)
+}
+
+func (o *productProjection) onCreated(ctx context.Context, evt es.Event) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "productProjection.onCreated")
+	defer span.Finish()
+	span.LogFields(log.String("AggregateID", evt.GetAggregateID()))
+
+	var eventData events.ProductCreatedEvent
+	if err := evt.GetJsonData(&eventData); err != nil {
+		tracing.TraceErr(span, err)
+		return errors.Wrap(err, "evt.GetJsonData")
+	}
+
+	product := models.Product{
+		ProductID:   aggregate.GetProductAggregateID(evt.AggregateID),
+		Title:       eventData.Title,
+		Description: eventData.Description,
+		Price:       eventData.Price,
+		TaxCategory: eventData.TaxCategory,
+		CreatedAt:   eventData.CreatedAt,
+		UpdatedAt:   eventData.CreatedAt,
+	}
+
+	return o.productRepo.Insert(ctx, &models.ProductProjection{Product: product})
+}
+
+func (o *productProjection) onRepriced(ctx context.Context, evt es.Event) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "productProjection.onRepriced")
+	defer span.Finish()
+	span.LogFields(log.String("AggregateID", evt.GetAggregateID()))
+
+	var eventData events.ProductRepricedEvent
+	if err := evt.GetJsonData(&eventData); err != nil {
+		tracing.TraceErr(span, err)
+		return errors.Wrap(err, "evt.GetJsonData")
+	}
+
+	return o.updateProduct(ctx, evt, func(product *models.ProductProjection) {
+		product.Price = eventData.Price
+		product.UpdatedAt = eventData.RepricedAt
+	})
+}
+
+func (o *productProjection) onDiscontinued(ctx context.Context, evt es.Event) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "productProjection.onDiscontinued")
+	defer span.Finish()
+	span.LogFields(log.String("AggregateID", evt.GetAggregateID()))
+
+	var eventData events.ProductDiscontinuedEvent
+	if err := evt.GetJsonData(&eventData); err != nil {
+		tracing.TraceErr(span, err)
+		return errors.Wrap(err, "evt.GetJsonData")
+	}
+
+	return o.updateProduct(ctx, evt, func(product *models.ProductProjection) {
+		product.Discontinued = true
+		product.DiscontinuedAt = eventData.DiscontinuedAt
+		product.UpdatedAt = eventData.DiscontinuedAt
+	})
+}
+
+func (o *productProjection) updateProduct(ctx context.Context, evt es.Event, apply func(product *models.ProductProjection)) error {
+	product, err := o.productRepo.GetByID(ctx, aggregate.GetProductAggregateID(evt.AggregateID))
+	if err != nil {
+		return err
+	}
+
+	apply(product)
+	return o.productRepo.Update(ctx, product)
+}
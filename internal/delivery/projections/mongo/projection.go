@@ -90,7 +90,10 @@ func (o *mongoProjection) processSingleEvent(
 		workerID,
 	)
 
-	err := o.When(ctx, es.NewEventFromRecorded(event.Event))
+	esEvent, err := es.Upcast(es.NewEventFromRecorded(event.Event))
+	if err == nil {
+		err = o.When(ctx, esEvent)
+	}
 	if err != nil {
 		if nackErr := stream.Nack(err.Error(), esdb.Nack_Retry, event); nackErr != nil {
 			return errors.Wrap(nackErr, "failed to Nack event")
@@ -106,6 +109,8 @@ func (o *mongoProjection) processSingleEvent(
 }
 
 func (o *mongoProjection) When(ctx context.Context, evt es.Event) error {
+	// the document of the event lives in the collections of the tenant of its stream
+	ctx = es.ContextWithTenant(ctx, evt.GetTenant())
 	ctx, span := tracing.StartProjectionTracerSpan(ctx, "mongoProjection.When", evt)
 	defer span.Finish()
 	span.LogFields(
@@ -114,13 +119,34 @@ func (o *mongoProjection) When(ctx context.Context, evt es.Event) error {
 	)
 
 	handlers := map[string]func(context.Context, es.Event) error{
-		events.OrderCreated:           o.onOrderCreate,
-		events.OrderPaid:              o.onOrderPaid,
-		events.OrderSubmitted:         o.onSubmit,
-		events.ShoppingCartUpdated:    o.onShoppingCartUpdate,
-		events.OrderCanceled:          o.onCancel,
-		events.OrderCompleted:         o.onCompleted,
-		events.DeliveryAddressChanged: o.onDeliveryAddressChanged,
+		events.OrderCreated:            o.onOrderCreate,
+		events.OrderPaid:               o.onOrderPaid,
+		events.OrderSubmitted:          o.onSubmit,
+		events.ShoppingCartUpdated:     o.onShoppingCartUpdate,
+		events.OrderCanceled:           o.onCancel,
+		events.OrderRejected:           o.onReject,
+		events.OrderCompleted:          o.onCompleted,
+		events.DeliveryAddressChanged:  o.onDeliveryAddressChanged,
+		events.OrderRefunded:           o.onRefund,
+		events.RefundRequested:         o.skipPendingRefund,
+		events.RefundFailed:            o.skipPendingRefund,
+		events.ReturnRequested:         o.onReturnRequested,
+		events.ReturnApproved:          o.onReturnApproved,
+		events.ReturnRejected:          o.onReturnRejected,
+		events.ReturnReceived:          o.onReturnReceived,
+		events.ShipmentCreated:         o.onShipmentCreated,
+		events.ShipmentPacked:          o.onShipmentPacked,
+		events.ShipmentDispatched:      o.onShipmentDispatched,
+		events.ShipmentDelivered:       o.onShipmentDelivered,
+		events.ShopItemAdded:           o.onShopItemAdded,
+		events.ShopItemRemoved:         o.onShopItemRemoved,
+		events.ShopItemQuantityChanged: o.onShopItemQuantityChanged,
+		events.CouponApplied:           o.onCouponApplied,
+		events.CouponRemoved:           o.onCouponRemoved,
+		events.PaymentReminderSent:     o.onPaymentReminderSent,
+		events.PaymentAttempted:        o.onPaymentAttempted,
+		events.PaymentAuthorized:       o.onPaymentAuthorized,
+		events.PaymentFailed:           o.onPaymentFailed,
 	}
 
 	handler, exists := handlers[evt.GetEventType()]
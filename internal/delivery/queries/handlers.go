@@ -3,6 +3,7 @@ package queries
 import (
 	"context"
 
+	"github.com/EventStore/EventStore-Client-Go/esdb"
 	"github.com/opentracing/opentracing-go"
 	"github.com/opentracing/opentracing-go/log"
 	"github.com/pkg/errors"
@@ -13,6 +14,7 @@ import (
 	"github.com/wassef911/eventually/internal/delivery/aggregate"
 	"github.com/wassef911/eventually/internal/delivery/models"
 	"github.com/wassef911/eventually/internal/delivery/repository"
+	"github.com/wassef911/eventually/internal/infrastructure/es"
 	"github.com/wassef911/eventually/internal/infrastructure/es/store"
 	"github.com/wassef911/eventually/pkg/config"
 	"github.com/wassef911/eventually/pkg/logger"
@@ -36,9 +38,33 @@ func NewSearchOrdersHandler(log logger.Logger, config *config.Config, es store.A
 func (s *searchOrdersHandler) Handle(ctx context.Context, query *SearchOrdersQuery) (*dto.OrderSearchResponseDto, error) {
 	span, ctx := opentracing.StartSpanFromContext(ctx, "searchOrdersHandler.Handle")
 	defer span.Finish()
-	span.LogFields(log.String("SearchText", query.SearchText))
+	span.LogFields(log.String("SearchText", query.SearchText), log.String("Status", query.Status))
 
-	return s.elasticRepository.Search(ctx, query.SearchText, query.Pq)
+	filter := models.OrderFilter{Status: models.OrderStatus(query.Status), City: query.City, Country: query.Country}
+	return s.elasticRepository.Search(ctx, query.SearchText, filter, query.Pq)
+}
+
+type ListOrdersQueryHandler interface {
+	Handle(ctx context.Context, query *ListOrdersQuery) (*dto.OrderListResponseDto, error)
+}
+
+type listOrdersHandler struct {
+	log       logger.Logger
+	config    *config.Config
+	mongoRepo repository.OrderMongoRepository
+}
+
+func NewListOrdersHandler(log logger.Logger, config *config.Config, mongoRepo repository.OrderMongoRepository) *listOrdersHandler {
+	return &listOrdersHandler{log: log, config: config, mongoRepo: mongoRepo}
+}
+
+// Handle orders are listed from the mongo read model, they may lag behind the event store.
+func (q *listOrdersHandler) Handle(ctx context.Context, query *ListOrdersQuery) (*dto.OrderListResponseDto, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "listOrdersHandler.Handle")
+	defer span.Finish()
+	span.LogFields(log.String("Status", string(query.Filter.Status)), log.String("Sort", query.Cp.Sort))
+
+	return q.mongoRepo.List(ctx, query.Filter, query.Cp)
 }
 
 type GetOrderByIDQueryHandler interface {
@@ -70,6 +96,7 @@ func (q *getOrderByIDHandler) Handle(ctx context.Context, query *GetOrderByIDQue
 	}
 
 	order := aggregate.NewOrderAggregateWithID(query.ID)
+	order.SetTenant(es.TenantFromContext(ctx))
 	if err := q.es.Load(ctx, order); err != nil {
 		return nil, err
 	}
@@ -87,3 +114,218 @@ func (q *getOrderByIDHandler) Handle(ctx context.Context, query *GetOrderByIDQue
 
 	return orderProjection, nil
 }
+
+type GetOrderEventsQueryHandler interface {
+	Handle(ctx context.Context, query *GetOrderEventsQuery) ([]es.Event, error)
+}
+
+type getOrderEventsHandler struct {
+	log        logger.Logger
+	config     *config.Config
+	eventStore store.EventStore
+}
+
+func NewGetOrderEventsHandler(log logger.Logger, config *config.Config, eventStore store.EventStore) *getOrderEventsHandler {
+	return &getOrderEventsHandler{log: log, config: config, eventStore: eventStore}
+}
+
+// Handle the recorded history of the order, oldest event first.
+func (q *getOrderEventsHandler) Handle(ctx context.Context, query *GetOrderEventsQuery) ([]es.Event, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "getOrderEventsHandler.Handle")
+	defer span.Finish()
+	span.LogFields(log.String("AggregateID", query.ID))
+
+	order := aggregate.NewOrderAggregateWithID(query.ID)
+	order.SetTenant(es.TenantFromContext(ctx))
+	events, err := q.eventStore.LoadEvents(ctx, order.GetID())
+	if errors.Is(err, esdb.ErrStreamNotFound) {
+		return nil, aggregate.ErrOrderNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
+
+type GetInventoryBySKUQueryHandler interface {
+	Handle(ctx context.Context, query *GetInventoryBySKUQuery) (*models.Inventory, error)
+}
+
+type getInventoryBySKUHandler struct {
+	log    logger.Logger
+	config *config.Config
+	es     store.AggregateStore
+}
+
+func NewGetInventoryBySKUHandler(log logger.Logger, config *config.Config, es store.AggregateStore) *getInventoryBySKUHandler {
+	return &getInventoryBySKUHandler{log: log, config: config, es: es}
+}
+
+// Handle stock levels are read from the event store, there is no inventory projection.
+func (q *getInventoryBySKUHandler) Handle(ctx context.Context, query *GetInventoryBySKUQuery) (*models.Inventory, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "getInventoryBySKUHandler.Handle")
+	defer span.Finish()
+	span.LogFields(log.String("SKU", query.SKU))
+
+	inventory, err := aggregate.LoadInventoryAggregate(ctx, q.es, query.SKU)
+	if err != nil {
+		return nil, err
+	}
+	if !inventory.IsTracked() {
+		return nil, aggregate.ErrInventoryNotFound
+	}
+
+	return inventory.Inventory, nil
+}
+
+type GetCustomerByIDQueryHandler interface {
+	Handle(ctx context.Context, query *GetCustomerByIDQuery) (*models.CustomerProjection, error)
+}
+
+type getCustomerByIDHandler struct {
+	log          logger.Logger
+	config       *config.Config
+	es           store.AggregateStore
+	customerRepo repository.CustomerRepository
+}
+
+func NewGetCustomerByIDHandler(log logger.Logger, config *config.Config, es store.AggregateStore, customerRepo repository.CustomerRepository) *getCustomerByIDHandler {
+	return &getCustomerByIDHandler{log: log, config: config, es: es, customerRepo: customerRepo}
+}
+
+func (q *getCustomerByIDHandler) Handle(ctx context.Context, query *GetCustomerByIDQuery) (*models.CustomerProjection, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "getCustomerByIDHandler.Handle")
+	defer span.Finish()
+	span.LogFields(log.String("CustomerID", query.ID))
+
+	return getCustomer(ctx, q.es, q.customerRepo, query.ID)
+}
+
+type GetCustomerOrdersQueryHandler interface {
+	Handle(ctx context.Context, query *GetCustomerOrdersQuery) (*dto.OrderSearchResponseDto, error)
+}
+
+type getCustomerOrdersHandler struct {
+	log          logger.Logger
+	config       *config.Config
+	es           store.AggregateStore
+	customerRepo repository.CustomerRepository
+	mongoRepo    repository.OrderMongoRepository
+}
+
+func NewGetCustomerOrdersHandler(
+	log logger.Logger,
+	config *config.Config,
+	es store.AggregateStore,
+	customerRepo repository.CustomerRepository,
+	mongoRepo repository.OrderMongoRepository,
+) *getCustomerOrdersHandler {
+	return &getCustomerOrdersHandler{log: log, config: config, es: es, customerRepo: customerRepo, mongoRepo: mongoRepo}
+}
+
+// Handle orders are linked by customer id, so the history survives email changes.
+func (q *getCustomerOrdersHandler) Handle(ctx context.Context, query *GetCustomerOrdersQuery) (*dto.OrderSearchResponseDto, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "getCustomerOrdersHandler.Handle")
+	defer span.Finish()
+	span.LogFields(log.String("CustomerID", query.CustomerID))
+
+	if _, err := getCustomer(ctx, q.es, q.customerRepo, query.CustomerID); err != nil {
+		return nil, err
+	}
+
+	return q.mongoRepo.GetByCustomerID(ctx, query.CustomerID, query.Pq)
+}
+
+// getCustomer reads the customer projection, rebuilding it from the event store when the projection lags behind.
+func getCustomer(ctx context.Context, eventStore store.AggregateStore, customerRepo repository.CustomerRepository, customerID string) (*models.CustomerProjection, error) {
+	customerProjection, err := customerRepo.GetByID(ctx, customerID)
+	if err != nil && !errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, err
+	}
+	if customerProjection != nil {
+		return customerProjection, nil
+	}
+
+	customer, err := aggregate.LoadCustomerAggregate(ctx, eventStore, customerID)
+	if err != nil {
+		return nil, err
+	}
+	if !customer.IsRegistered() {
+		return nil, aggregate.ErrCustomerNotFound
+	}
+
+	customerProjection = &models.CustomerProjection{Customer: *customer.Customer}
+	if err := customerRepo.Insert(ctx, customerProjection); err != nil {
+		return nil, err
+	}
+
+	return customerProjection, nil
+}
+
+type GetProductByIDQueryHandler interface {
+	Handle(ctx context.Context, query *GetProductByIDQuery) (*models.ProductProjection, error)
+}
+
+type getProductByIDHandler struct {
+	log         logger.Logger
+	config      *config.Config
+	es          store.AggregateStore
+	productRepo repository.ProductRepository
+}
+
+func NewGetProductByIDHandler(log logger.Logger, config *config.Config, es store.AggregateStore, productRepo repository.ProductRepository) *getProductByIDHandler {
+	return &getProductByIDHandler{log: log, config: config, es: es, productRepo: productRepo}
+}
+
+func (q *getProductByIDHandler) Handle(ctx context.Context, query *GetProductByIDQuery) (*models.ProductProjection, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "getProductByIDHandler.Handle")
+	defer span.Finish()
+	span.LogFields(log.String("ProductID", query.ID))
+
+	productProjection, err := q.productRepo.GetByID(ctx, query.ID)
+	if err != nil && !errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, err
+	}
+	if productProjection != nil {
+		return productProjection, nil
+	}
+
+	product, err := aggregate.LoadProductAggregate(ctx, q.es, query.ID)
+	if err != nil {
+		return nil, err
+	}
+	if !product.IsCreated() {
+		return nil, aggregate.ErrProductNotFound
+	}
+
+	productProjection = &models.ProductProjection{Product: *product.Product}
+	if err := q.productRepo.Insert(ctx, productProjection); err != nil {
+		return nil, err
+	}
+
+	return productProjection, nil
+}
+
+type ListProductsQueryHandler interface {
+	Handle(ctx context.Context, query *ListProductsQuery) (*dto.ProductListResponseDto, error)
+}
+
+type listProductsHandler struct {
+	log         logger.Logger
+	config      *config.Config
+	es          store.AggregateStore
+	productRepo repository.ProductRepository
+}
+
+func NewListProductsHandler(log logger.Logger, config *config.Config, es store.AggregateStore, productRepo repository.ProductRepository) *listProductsHandler {
+	return &listProductsHandler{log: log, config: config, es: es, productRepo: productRepo}
+}
+
+func (q *listProductsHandler) Handle(ctx context.Context, query *ListProductsQuery) (*dto.ProductListResponseDto, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "listProductsHandler.Handle")
+	defer span.Finish()
+	span.LogFields(log.Bool("IncludeDiscontinued", query.IncludeDiscontinued))
+
+	return q.productRepo.List(ctx, query.IncludeDiscontinued, query.Pq)
+}
@@ -1,14 +1,24 @@
 package queries
 
-import "github.com/wassef911/eventually/internal/api/utils"
+import (
+	"github.com/wassef911/eventually/internal/api/utils"
+	"github.com/wassef911/eventually/internal/delivery/models"
+)
 
 type OrderQueries struct {
-	GetOrderByID GetOrderByIDQueryHandler
-	SearchOrders SearchOrdersQueryHandler
+	GetOrderByID   GetOrderByIDQueryHandler
+	SearchOrders   SearchOrdersQueryHandler
+	ListOrders     ListOrdersQueryHandler
+	GetOrderEvents GetOrderEventsQueryHandler
 }
 
-func NewOrderQueries(getOrderByID GetOrderByIDQueryHandler, searchOrders SearchOrdersQueryHandler) *OrderQueries {
-	return &OrderQueries{GetOrderByID: getOrderByID, SearchOrders: searchOrders}
+func NewOrderQueries(
+	getOrderByID GetOrderByIDQueryHandler,
+	searchOrders SearchOrdersQueryHandler,
+	listOrders ListOrdersQueryHandler,
+	getOrderEvents GetOrderEventsQueryHandler,
+) *OrderQueries {
+	return &OrderQueries{GetOrderByID: getOrderByID, SearchOrders: searchOrders, ListOrders: listOrders, GetOrderEvents: getOrderEvents}
 }
 
 type GetOrderByIDQuery struct {
@@ -19,11 +29,99 @@ func NewGetOrderByIDQuery(ID string) *GetOrderByIDQuery {
 	return &GetOrderByIDQuery{ID: ID}
 }
 
+type GetOrderEventsQuery struct {
+	ID string
+}
+
+func NewGetOrderEventsQuery(ID string) *GetOrderEventsQuery {
+	return &GetOrderEventsQuery{ID: ID}
+}
+
 type SearchOrdersQuery struct {
 	SearchText string `json:"searchText"`
+	Status     string `json:"status" validate:"omitempty,oneof=NEW CREATED PAID SUBMITTED COMPLETED CANCELED REFUNDED REJECTED"`
+	City       string `json:"city"`
+	Country    string `json:"country" validate:"omitempty,len=2"`
+	Pq         *utils.Pagination
+}
+
+func NewSearchOrdersQuery(searchText string, status string, city string, country string, pq *utils.Pagination) *SearchOrdersQuery {
+	return &SearchOrdersQuery{SearchText: searchText, Status: status, City: city, Country: country, Pq: pq}
+}
+
+type ListOrdersQuery struct {
+	Filter models.OrderListFilter
+	Cp     *utils.CursorPagination
+}
+
+func NewListOrdersQuery(filter models.OrderListFilter, cp *utils.CursorPagination) *ListOrdersQuery {
+	return &ListOrdersQuery{Filter: filter, Cp: cp}
+}
+
+type InventoryQueries struct {
+	GetInventoryBySKU GetInventoryBySKUQueryHandler
+}
+
+func NewInventoryQueries(getInventoryBySKU GetInventoryBySKUQueryHandler) *InventoryQueries {
+	return &InventoryQueries{GetInventoryBySKU: getInventoryBySKU}
+}
+
+type GetInventoryBySKUQuery struct {
+	SKU string
+}
+
+func NewGetInventoryBySKUQuery(sku string) *GetInventoryBySKUQuery {
+	return &GetInventoryBySKUQuery{SKU: sku}
+}
+
+type CustomerQueries struct {
+	GetCustomerByID   GetCustomerByIDQueryHandler
+	GetCustomerOrders GetCustomerOrdersQueryHandler
+}
+
+func NewCustomerQueries(getCustomerByID GetCustomerByIDQueryHandler, getCustomerOrders GetCustomerOrdersQueryHandler) *CustomerQueries {
+	return &CustomerQueries{GetCustomerByID: getCustomerByID, GetCustomerOrders: getCustomerOrders}
+}
+
+type GetCustomerByIDQuery struct {
+	ID string
+}
+
+func NewGetCustomerByIDQuery(ID string) *GetCustomerByIDQuery {
+	return &GetCustomerByIDQuery{ID: ID}
+}
+
+type GetCustomerOrdersQuery struct {
+	CustomerID string
 	Pq         *utils.Pagination
 }
 
-func NewSearchOrdersQuery(searchText string, pq *utils.Pagination) *SearchOrdersQuery {
-	return &SearchOrdersQuery{SearchText: searchText, Pq: pq}
+func NewGetCustomerOrdersQuery(customerID string, pq *utils.Pagination) *GetCustomerOrdersQuery {
+	return &GetCustomerOrdersQuery{CustomerID: customerID, Pq: pq}
+}
+
+type ProductQueries struct {
+	GetProductByID GetProductByIDQueryHandler
+	ListProducts   ListProductsQueryHandler
+}
+
+func NewProductQueries(getProductByID GetProductByIDQueryHandler, listProducts ListProductsQueryHandler) *ProductQueries {
+	return &ProductQueries{GetProductByID: getProductByID, ListProducts: listProducts}
+}
+
+type GetProductByIDQuery struct {
+	ID string
+}
+
+func NewGetProductByIDQuery(ID string) *GetProductByIDQuery {
+	return &GetProductByIDQuery{ID: ID}
+}
+
+type ListProductsQuery struct {
+	IncludeDiscontinued bool
+	Pq                  *utils.Pagination
+}
+
+func NewListProductsQuery(includeDiscontinued bool, pq *utils.Pagination) *ListProductsQuery {
+	return &ListProductsQuery{IncludeDiscontinued: includeDiscontinued, Pq: pq}
 }
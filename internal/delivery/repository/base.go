@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/wassef911/eventually/internal/api/dto"
 	"github.com/wassef911/eventually/internal/api/utils"
@@ -14,15 +15,80 @@ type OrderMongoRepository interface {
 	UpdateOrder(ctx context.Context, order *models.OrderProjection) error
 
 	UpdateCancel(ctx context.Context, order *models.OrderProjection) error
+	UpdateReject(ctx context.Context, order *models.OrderProjection) error
+	UpdatePaymentReminders(ctx context.Context, order *models.OrderProjection) error
 	UpdatePayment(ctx context.Context, order *models.OrderProjection) error
+	AddPaymentAttempt(ctx context.Context, order *models.OrderProjection) error
+	UpdatePaymentAttempt(ctx context.Context, orderID string, attempt *models.PaymentAttempt) error
 	Complete(ctx context.Context, order *models.OrderProjection) error
 	UpdateDeliveryAddress(ctx context.Context, order *models.OrderProjection) error
 	UpdateSubmit(ctx context.Context, order *models.OrderProjection) error
+	AddRefund(ctx context.Context, order *models.OrderProjection) error
+	AddReturn(ctx context.Context, order *models.OrderProjection) error
+	UpdateReturn(ctx context.Context, orderID string, orderReturn *models.OrderReturn) error
+	AddShipment(ctx context.Context, order *models.OrderProjection) error
+	UpdateShipment(ctx context.Context, orderID string, shipment *models.Shipment) error
+	AddShopItem(ctx context.Context, order *models.OrderProjection) error
+	RemoveShopItem(ctx context.Context, order *models.OrderProjection, shopItemID string) error
+	ChangeShopItemQuantity(ctx context.Context, order *models.OrderProjection, shopItemID string, quantity uint64) error
+	AddCoupon(ctx context.Context, order *models.OrderProjection) error
+	RemoveCoupon(ctx context.Context, order *models.OrderProjection, code string) error
+	GetByCustomerID(ctx context.Context, customerID string, pq *utils.Pagination) (*dto.OrderSearchResponseDto, error)
+	List(ctx context.Context, filter models.OrderListFilter, cp *utils.CursorPagination) (*dto.OrderListResponseDto, error)
+	// ListWithoutCreatedAt ids of the orders projected before their creation time was recorded.
+	ListWithoutCreatedAt(ctx context.Context, limit int) ([]string, error)
+	SetCreatedAt(ctx context.Context, orderID string, createdAt time.Time) error
+	// ListWithoutStatus ids of the orders projected before their status was recorded.
+	ListWithoutStatus(ctx context.Context, limit int) ([]string, error)
+	// SetStatus records the status of the order and drops the lifecycle flags the status replaced.
+	SetStatus(ctx context.Context, orderID string, status models.OrderStatus) error
+}
+
+type CustomerRepository interface {
+	Insert(ctx context.Context, customer *models.CustomerProjection) error
+	GetByID(ctx context.Context, customerID string) (*models.CustomerProjection, error)
+	Update(ctx context.Context, customer *models.CustomerProjection) error
+}
+
+type ProductRepository interface {
+	Insert(ctx context.Context, product *models.ProductProjection) error
+	GetByID(ctx context.Context, productID string) (*models.ProductProjection, error)
+	Update(ctx context.Context, product *models.ProductProjection) error
+	List(ctx context.Context, includeDiscontinued bool, pq *utils.Pagination) (*dto.ProductListResponseDto, error)
+}
+
+type WebhookRepository interface {
+	Insert(ctx context.Context, webhook *models.Webhook) error
+	GetByID(ctx context.Context, webhookID string) (*models.Webhook, error)
+	Update(ctx context.Context, webhook *models.Webhook) error
+	Delete(ctx context.Context, webhookID string) error
+	ListActive(ctx context.Context) ([]*models.Webhook, error)
+	// RecordSuccess resets the failure count after a delivery succeeded.
+	RecordSuccess(ctx context.Context, webhookID string, now time.Time) error
+	// RecordFailure counts a delivery that failed every attempt and disables the webhook once disableAfter
+	// consecutive deliveries failed. It reports whether this failure disabled the webhook.
+	RecordFailure(ctx context.Context, webhookID string, now time.Time, disableAfter int) (bool, error)
+}
+
+type WebhookDeliveryRepository interface {
+	Insert(ctx context.Context, delivery *models.WebhookDelivery) error
+	ListByWebhookID(ctx context.Context, webhookID string, pq *utils.Pagination) (*dto.WebhookDeliveryListResponseDto, error)
+	// ListDue failed deliveries whose retry is due at now, the longest waiting first.
+	ListDue(ctx context.Context, now time.Time, limit int) ([]*models.WebhookDelivery, error)
+	// ClaimRetry moves the retry of the delivery to until, false when another dispatcher claimed it first.
+	ClaimRetry(ctx context.Context, delivery *models.WebhookDelivery, until time.Time) (bool, error)
+	// CompleteRetry drops the retry of the delivery once its next attempt is logged.
+	CompleteRetry(ctx context.Context, deliveryID string) error
+}
+
+type CouponRepository interface {
+	Insert(ctx context.Context, coupon *models.Coupon) error
+	GetByCode(ctx context.Context, code string) (*models.Coupon, error)
 }
 
 type ElasticOrderRepository interface {
 	IndexOrder(ctx context.Context, order *models.OrderProjection) error
 	GetByID(ctx context.Context, orderID string) (*models.OrderProjection, error)
 	UpdateOrder(ctx context.Context, order *models.OrderProjection) error
-	Search(ctx context.Context, text string, pq *utils.Pagination) (*dto.OrderSearchResponseDto, error)
+	Search(ctx context.Context, text string, filter models.OrderFilter, pq *utils.Pagination) (*dto.OrderSearchResponseDto, error)
 }
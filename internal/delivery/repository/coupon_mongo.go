@@ -0,0 +1,58 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/log"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"github.com/wassef911/eventually/internal/api/constants"
+	"github.com/wassef911/eventually/internal/delivery/models"
+	"github.com/wassef911/eventually/internal/infrastructure/es"
+	"github.com/wassef911/eventually/internal/infrastructure/tracing"
+	"github.com/wassef911/eventually/pkg/config"
+	"github.com/wassef911/eventually/pkg/logger"
+)
+
+type MongoCouponRepository struct {
+	log    logger.Logger
+	config *config.Config
+	db     *mongo.Client
+}
+
+func NewMongoCouponRepository(log logger.Logger, config *config.Config, db *mongo.Client) *MongoCouponRepository {
+	return &MongoCouponRepository{log: log, config: config, db: db}
+}
+
+func (m *MongoCouponRepository) Insert(ctx context.Context, coupon *models.Coupon) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "mongoCouponRepository.Insert")
+	defer span.Finish()
+	span.LogFields(log.String("Code", coupon.Code))
+
+	if _, err := m.getCouponsCollection(ctx).InsertOne(ctx, coupon); err != nil {
+		tracing.TraceErr(span, err)
+		return err
+	}
+
+	return nil
+}
+
+func (m *MongoCouponRepository) GetByCode(ctx context.Context, code string) (*models.Coupon, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "mongoCouponRepository.GetByCode")
+	defer span.Finish()
+	span.LogFields(log.String("Code", code))
+
+	var coupon models.Coupon
+	if err := m.getCouponsCollection(ctx).FindOne(ctx, bson.M{constants.Code: code}).Decode(&coupon); err != nil {
+		tracing.TraceErr(span, err)
+		return nil, err
+	}
+
+	return &coupon, nil
+}
+
+func (m *MongoCouponRepository) getCouponsCollection(ctx context.Context) *mongo.Collection {
+	return m.db.Database(m.config.Mongo.Db).Collection(es.TenantName(es.TenantFromContext(ctx), m.config.MongoCollections.Coupons))
+}
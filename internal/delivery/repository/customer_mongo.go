@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/log"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"github.com/wassef911/eventually/internal/api/constants"
+	"github.com/wassef911/eventually/internal/delivery/models"
+	"github.com/wassef911/eventually/internal/infrastructure/es"
+	"github.com/wassef911/eventually/internal/infrastructure/tracing"
+	"github.com/wassef911/eventually/pkg/config"
+	"github.com/wassef911/eventually/pkg/logger"
+)
+
+type MongoCustomerRepository struct {
+	log    logger.Logger
+	config *config.Config
+	db     *mongo.Client
+}
+
+var _ CustomerRepository = &MongoCustomerRepository{}
+
+func NewMongoCustomerRepository(log logger.Logger, config *config.Config, db *mongo.Client) *MongoCustomerRepository {
+	return &MongoCustomerRepository{log: log, config: config, db: db}
+}
+
+func (m *MongoCustomerRepository) Insert(ctx context.Context, customer *models.CustomerProjection) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "mongoCustomerRepository.Insert")
+	defer span.Finish()
+	span.LogFields(log.String("CustomerID", customer.CustomerID))
+
+	if _, err := m.getCustomersCollection(ctx).InsertOne(ctx, customer); err != nil {
+		tracing.TraceErr(span, err)
+		return err
+	}
+
+	return nil
+}
+
+func (m *MongoCustomerRepository) GetByID(ctx context.Context, customerID string) (*models.CustomerProjection, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "mongoCustomerRepository.GetByID")
+	defer span.Finish()
+	span.LogFields(log.String("CustomerID", customerID))
+
+	var customer models.CustomerProjection
+	if err := m.getCustomersCollection(ctx).FindOne(ctx, bson.M{constants.CustomerID: customerID}).Decode(&customer); err != nil {
+		tracing.TraceErr(span, err)
+		return nil, err
+	}
+
+	return &customer, nil
+}
+
+// Update replaces the customer document, the customer projection applies events to the whole read model.
+func (m *MongoCustomerRepository) Update(ctx context.Context, customer *models.CustomerProjection) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "mongoCustomerRepository.Update")
+	defer span.Finish()
+	span.LogFields(log.String("CustomerID", customer.CustomerID))
+
+	res, err := m.getCustomersCollection(ctx).ReplaceOne(ctx, bson.M{constants.CustomerID: customer.CustomerID}, customer)
+	if err != nil {
+		tracing.TraceErr(span, err)
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+
+	return nil
+}
+
+func (m *MongoCustomerRepository) getCustomersCollection(ctx context.Context) *mongo.Collection {
+	return m.db.Database(m.config.Mongo.Db).Collection(es.TenantName(es.TenantFromContext(ctx), m.config.MongoCollections.Customers))
+}
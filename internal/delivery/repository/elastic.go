@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"encoding/json"
+	"strings"
 
 	v7 "github.com/olivere/elastic/v7"
 	"github.com/opentracing/opentracing-go"
@@ -12,6 +13,7 @@ import (
 	"github.com/wassef911/eventually/internal/api/dto"
 	"github.com/wassef911/eventually/internal/api/utils"
 	"github.com/wassef911/eventually/internal/delivery/models"
+	"github.com/wassef911/eventually/internal/infrastructure/es"
 	"github.com/wassef911/eventually/internal/infrastructure/tracing"
 	"github.com/wassef911/eventually/pkg/config"
 	"github.com/wassef911/eventually/pkg/logger"
@@ -20,6 +22,9 @@ import (
 const (
 	shopItemTitle            = "shopItems.title"
 	shopItemDescription      = "shopItems.description"
+	orderStatus              = "status"
+	deliveryCity             = "deliveryAddress.city"
+	deliveryCountry          = "deliveryAddress.country"
 	minimumNumberShouldMatch = 1
 )
 
@@ -38,7 +43,7 @@ func (e ElasticRepository) IndexOrder(ctx context.Context, order *models.OrderPr
 	defer span.Finish()
 	span.LogFields(log.String("OrderID", order.OrderID))
 
-	_, err := e.elasticClient.Index().Index(e.config.ElasticIndexes.Orders).BodyJson(order).Id(order.OrderID).Do(ctx)
+	_, err := e.elasticClient.Index().Index(e.ordersIndex(ctx)).BodyJson(order).Id(order.OrderID).Do(ctx)
 	if err != nil {
 		tracing.TraceErr(span, err)
 		return errors.Wrap(err, "elasticClient.Index")
@@ -52,7 +57,7 @@ func (e ElasticRepository) GetByID(ctx context.Context, orderID string) (*models
 	defer span.Finish()
 	span.LogFields(log.String("OrderID", orderID))
 
-	result, err := e.elasticClient.Get().Index(e.config.ElasticIndexes.Orders).Id(orderID).FetchSource(true).Do(ctx)
+	result, err := e.elasticClient.Get().Index(e.ordersIndex(ctx)).Id(orderID).FetchSource(true).Do(ctx)
 	if err != nil {
 		tracing.TraceErr(span, err)
 		return nil, errors.Wrap(err, "elasticClient.Get")
@@ -78,7 +83,7 @@ func (e ElasticRepository) UpdateOrder(ctx context.Context, order *models.OrderP
 	defer span.Finish()
 	span.LogFields(log.String("OrderID", order.OrderID))
 
-	_, err := e.elasticClient.Update().Index(e.config.ElasticIndexes.Orders).Id(order.OrderID).Doc(order).FetchSource(false).Do(ctx)
+	_, err := e.elasticClient.Update().Index(e.ordersIndex(ctx)).Id(order.OrderID).Doc(order).FetchSource(false).Do(ctx)
 	if err != nil {
 		tracing.TraceErr(span, err)
 		return errors.Wrap(err, "elasticClient.Update")
@@ -87,16 +92,28 @@ func (e ElasticRepository) UpdateOrder(ctx context.Context, order *models.OrderP
 	return nil
 }
 
-func (e ElasticRepository) Search(ctx context.Context, text string, pq *utils.Pagination) (*dto.OrderSearchResponseDto, error) {
+func (e ElasticRepository) Search(ctx context.Context, text string, filter models.OrderFilter, pq *utils.Pagination) (*dto.OrderSearchResponseDto, error) {
 	span, ctx := opentracing.StartSpanFromContext(ctx, "elasticRepository.Search")
 	defer span.Finish()
-	span.LogFields(log.String("Search", text))
+	span.LogFields(log.String("Search", text), log.String("Status", string(filter.Status)), log.String("City", filter.City), log.String("Country", filter.Country))
 
-	shouldMatch := v7.NewBoolQuery().
-		Should(v7.NewMatchPhrasePrefixQuery(shopItemTitle, text), v7.NewMatchPhrasePrefixQuery(shopItemDescription, text)).
-		MinimumNumberShouldMatch(minimumNumberShouldMatch)
+	shouldMatch := v7.NewBoolQuery()
+	if text != "" || filter.IsEmpty() {
+		shouldMatch = shouldMatch.
+			Should(v7.NewMatchPhrasePrefixQuery(shopItemTitle, text), v7.NewMatchPhrasePrefixQuery(shopItemDescription, text)).
+			MinimumNumberShouldMatch(minimumNumberShouldMatch)
+	}
+	if filter.Status != "" {
+		shouldMatch = shouldMatch.Filter(v7.NewTermQuery(orderStatus, filter.Status))
+	}
+	if filter.City != "" {
+		shouldMatch = shouldMatch.Filter(v7.NewMatchQuery(deliveryCity, filter.City).Operator("and"))
+	}
+	if filter.Country != "" {
+		shouldMatch = shouldMatch.Filter(v7.NewTermQuery(deliveryCountry, strings.ToUpper(filter.Country)))
+	}
 
-	searchResult, err := e.elasticClient.Search(e.config.ElasticIndexes.Orders).
+	searchResult, err := e.elasticClient.Search(e.ordersIndex(ctx)).
 		Query(shouldMatch).
 		From(pq.GetOffset()).
 		Explain(e.config.Elastic.Explain).
@@ -136,3 +153,8 @@ func (e ElasticRepository) Search(ctx context.Context, text string, pq *utils.Pa
 		Orders: utils.OrdersResponseFrom(orders),
 	}, nil
 }
+
+// ordersIndex every tenant searches its own orders index.
+func (e ElasticRepository) ordersIndex(ctx context.Context) string {
+	return es.TenantName(es.TenantFromContext(ctx), e.config.ElasticIndexes.Orders)
+}
@@ -0,0 +1,201 @@
+package repository
+
+// OrdersIndexVersion version of OrdersIndexMapping, bump it on every mapping change: the orders are then reindexed
+// into a new index when the service starts.
+const OrdersIndexVersion = 1
+
+// OrdersIndexMapping explicit mapping of the orders index, money amounts are stored in minor units.
+const OrdersIndexMapping = `{
+	"mappings": {
+		"properties": {
+			"orderId": {"type": "keyword"},
+			"customerId": {"type": "keyword"},
+			"accountEmail": {"type": "keyword"},
+			"deliveryAddress": {
+				"properties": {
+					"recipient": {"type": "text"},
+					"line1": {"type": "text"},
+					"line2": {"type": "text"},
+					"city": {"type": "text", "fields": {"keyword": {"type": "keyword"}}},
+					"postalCode": {"type": "keyword"},
+					"region": {"type": "keyword"},
+					"country": {"type": "keyword"},
+					"phone": {"type": "keyword"}
+				}
+			},
+			"cancelReason": {"type": "text"},
+			"rejectReason": {"type": "text"},
+			"paymentReminders": {"type": "integer"},
+			"createdAt": {"type": "date"},
+			"deliveredTime": {"type": "date"},
+			"status": {"type": "keyword"},
+			"totalPrice": {
+				"properties": {
+					"amount": {"type": "long"},
+					"currency": {"type": "keyword"}
+				}
+			},
+			"subtotal": {
+				"properties": {
+					"amount": {"type": "long"},
+					"currency": {"type": "keyword"}
+				}
+			},
+			"discountTotal": {
+				"properties": {
+					"amount": {"type": "long"},
+					"currency": {"type": "keyword"}
+				}
+			},
+			"shippingPrice": {
+				"properties": {
+					"amount": {"type": "long"},
+					"currency": {"type": "keyword"}
+				}
+			},
+			"taxTotal": {
+				"properties": {
+					"amount": {"type": "long"},
+					"currency": {"type": "keyword"}
+				}
+			},
+			"taxRegion": {"type": "keyword"},
+			"taxes": {
+				"properties": {
+					"shopItemId": {"type": "keyword"},
+					"taxCategory": {"type": "keyword"},
+					"rate": {"type": "long"},
+					"amount": {
+						"properties": {
+							"amount": {"type": "long"},
+							"currency": {"type": "keyword"}
+						}
+					}
+				}
+			},
+			"discounts": {
+				"properties": {
+					"code": {"type": "keyword"},
+					"amount": {
+						"properties": {
+							"amount": {"type": "long"},
+							"currency": {"type": "keyword"}
+						}
+					}
+				}
+			},
+			"coupons": {
+				"properties": {
+					"code": {"type": "keyword"},
+					"type": {"type": "keyword"},
+					"shopItemId": {"type": "keyword"}
+				}
+			},
+			"shopItems": {
+				"properties": {
+					"id": {"type": "keyword"},
+					"title": {"type": "text"},
+					"description": {"type": "text"},
+					"quantity": {"type": "long"},
+					"taxCategory": {"type": "keyword"},
+					"price": {
+						"properties": {
+							"amount": {"type": "long"},
+							"currency": {"type": "keyword"}
+						}
+					}
+				}
+			},
+			"refunded": {"type": "boolean"},
+			"refundedAmount": {
+				"properties": {
+					"amount": {"type": "long"},
+					"currency": {"type": "keyword"}
+				}
+			},
+			"refunds": {
+				"properties": {
+					"refundId": {"type": "keyword"},
+					"reason": {"type": "text"},
+					"timestamp": {"type": "date"},
+					"amount": {
+						"properties": {
+							"amount": {"type": "long"},
+							"currency": {"type": "keyword"}
+						}
+					},
+					"items": {
+						"properties": {
+							"shopItemId": {"type": "keyword"},
+							"quantity": {"type": "long"}
+						}
+					}
+				}
+			},
+			"paymentAttempts": {
+				"properties": {
+					"attemptId": {"type": "keyword"},
+					"paymentId": {"type": "keyword"},
+					"provider": {"type": "keyword"},
+					"status": {"type": "keyword"},
+					"failureReason": {"type": "text"},
+					"timestamp": {"type": "date"},
+					"amount": {
+						"properties": {
+							"amount": {"type": "long"},
+							"currency": {"type": "keyword"}
+						}
+					}
+				}
+			},
+			"returns": {
+				"properties": {
+					"returnId": {"type": "keyword"},
+					"reason": {"type": "text"},
+					"status": {"type": "keyword"},
+					"rejectReason": {"type": "text"},
+					"refundId": {"type": "keyword"},
+					"requestedAt": {"type": "date"},
+					"receivedAt": {"type": "date"},
+					"items": {
+						"properties": {
+							"shopItemId": {"type": "keyword"},
+							"quantity": {"type": "long"}
+						}
+					}
+				}
+			},
+			"shipments": {
+				"properties": {
+					"shipmentId": {"type": "keyword"},
+					"status": {"type": "keyword"},
+					"carrier": {"type": "keyword"},
+					"trackingNumber": {"type": "keyword"},
+					"createdAt": {"type": "date"},
+					"packedAt": {"type": "date"},
+					"dispatchedAt": {"type": "date"},
+					"deliveredAt": {"type": "date"},
+					"items": {
+						"properties": {
+							"shopItemId": {"type": "keyword"},
+							"quantity": {"type": "long"}
+						}
+					}
+				}
+			},
+			"payment": {
+				"properties": {
+					"paymentID": {"type": "keyword"},
+					"timestamp": {"type": "date"},
+					"provider": {"type": "keyword"},
+					"amount": {
+						"properties": {
+							"amount": {"type": "long"},
+							"currency": {"type": "keyword"}
+						}
+					}
+				}
+			}
+		}
+	}
+}`
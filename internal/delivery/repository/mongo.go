@@ -10,7 +10,10 @@ import (
 	"go.mongodb.org/mongo-driver/mongo/options"
 
 	"github.com/wassef911/eventually/internal/api/constants"
+	"github.com/wassef911/eventually/internal/api/dto"
+	"github.com/wassef911/eventually/internal/api/utils"
 	"github.com/wassef911/eventually/internal/delivery/models"
+	"github.com/wassef911/eventually/internal/infrastructure/es"
 	"github.com/wassef911/eventually/internal/infrastructure/tracing"
 	"github.com/wassef911/eventually/pkg/config"
 	"github.com/wassef911/eventually/pkg/logger"
@@ -31,7 +34,7 @@ func (m *MongoRepository) Insert(ctx context.Context, order *models.OrderProject
 	defer span.Finish()
 	span.LogFields(log.String("OrderID", order.OrderID))
 
-	_, err := m.getOrdersCollection().InsertOne(ctx, order, &options.InsertOneOptions{})
+	_, err := m.getOrdersCollection(ctx).InsertOne(ctx, order, &options.InsertOneOptions{})
 	if err != nil {
 		tracing.TraceErr(span, err)
 		return "", err
@@ -46,7 +49,7 @@ func (m *MongoRepository) GetByID(ctx context.Context, orderID string) (*models.
 	span.LogFields(log.String("OrderID", orderID))
 
 	var orderProjection models.OrderProjection
-	if err := m.getOrdersCollection().FindOne(ctx, bson.M{constants.OrderId: orderID}).Decode(&orderProjection); err != nil {
+	if err := m.getOrdersCollection(ctx).FindOne(ctx, bson.M{constants.OrderId: orderID}).Decode(&orderProjection); err != nil {
 		tracing.TraceErr(span, err)
 		return nil, err
 	}
@@ -64,7 +67,7 @@ func (m *MongoRepository) UpdateOrder(ctx context.Context, order *models.OrderPr
 	ops.SetUpsert(false)
 
 	var res models.OrderProjection
-	if err := m.getOrdersCollection().FindOneAndUpdate(ctx, bson.M{constants.OrderId: order.OrderID}, bson.M{"$set": order}, ops).Decode(&res); err != nil {
+	if err := m.getOrdersCollection(ctx).FindOneAndUpdate(ctx, bson.M{constants.OrderId: order.OrderID}, bson.M{"$set": order}, ops).Decode(&res); err != nil {
 		tracing.TraceErr(span, err)
 		return err
 	}
@@ -81,9 +84,47 @@ func (m *MongoRepository) UpdateCancel(ctx context.Context, order *models.OrderP
 	ops.SetReturnDocument(options.After)
 	ops.SetUpsert(false)
 
-	update := bson.M{"$set": bson.M{constants.Canceled: order.Canceled, constants.CancelReason: order.CancelReason}}
+	update := bson.M{"$set": bson.M{constants.Status: order.Status, constants.CancelReason: order.CancelReason}}
 	var res models.OrderProjection
-	if err := m.getOrdersCollection().FindOneAndUpdate(ctx, bson.M{constants.OrderId: order.OrderID}, update, ops).Decode(&res); err != nil {
+	if err := m.getOrdersCollection(ctx).FindOneAndUpdate(ctx, bson.M{constants.OrderId: order.OrderID}, update, ops).Decode(&res); err != nil {
+		tracing.TraceErr(span, err)
+		return err
+	}
+
+	return nil
+}
+
+func (m *MongoRepository) UpdateReject(ctx context.Context, order *models.OrderProjection) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "mongoRepository.UpdateReject")
+	defer span.Finish()
+	span.LogFields(log.String("OrderID", order.OrderID))
+
+	ops := options.FindOneAndUpdate()
+	ops.SetReturnDocument(options.After)
+	ops.SetUpsert(false)
+
+	update := bson.M{"$set": bson.M{constants.Status: order.Status, constants.RejectReason: order.RejectReason}}
+	var res models.OrderProjection
+	if err := m.getOrdersCollection(ctx).FindOneAndUpdate(ctx, bson.M{constants.OrderId: order.OrderID}, update, ops).Decode(&res); err != nil {
+		tracing.TraceErr(span, err)
+		return err
+	}
+
+	return nil
+}
+
+func (m *MongoRepository) UpdatePaymentReminders(ctx context.Context, order *models.OrderProjection) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "mongoRepository.UpdatePaymentReminders")
+	defer span.Finish()
+	span.LogFields(log.String("OrderID", order.OrderID))
+
+	ops := options.FindOneAndUpdate()
+	ops.SetReturnDocument(options.After)
+	ops.SetUpsert(false)
+
+	update := bson.M{"$set": bson.M{constants.PaymentReminders: order.PaymentReminders}}
+	var res models.OrderProjection
+	if err := m.getOrdersCollection(ctx).FindOneAndUpdate(ctx, bson.M{constants.OrderId: order.OrderID}, update, ops).Decode(&res); err != nil {
 		tracing.TraceErr(span, err)
 		return err
 	}
@@ -100,9 +141,9 @@ func (m *MongoRepository) UpdatePayment(ctx context.Context, order *models.Order
 	ops.SetReturnDocument(options.After)
 	ops.SetUpsert(false)
 
-	update := bson.M{"$set": bson.M{constants.Payment: order.Payment, constants.Paid: order.Paid}}
+	update := bson.M{"$set": bson.M{constants.Status: order.Status, constants.Payment: order.Payment}}
 	var res models.OrderProjection
-	if err := m.getOrdersCollection().FindOneAndUpdate(ctx, bson.M{constants.OrderId: order.OrderID}, update, ops).Decode(&res); err != nil {
+	if err := m.getOrdersCollection(ctx).FindOneAndUpdate(ctx, bson.M{constants.OrderId: order.OrderID}, update, ops).Decode(&res); err != nil {
 		tracing.TraceErr(span, err)
 		return err
 	}
@@ -119,9 +160,9 @@ func (m *MongoRepository) Complete(ctx context.Context, order *models.OrderProje
 	ops.SetReturnDocument(options.After)
 	ops.SetUpsert(false)
 
-	update := bson.M{"$set": bson.M{constants.Completed: order.Completed, constants.DeliveredTime: order.DeliveredTime}}
+	update := bson.M{"$set": bson.M{constants.Status: order.Status, constants.DeliveredTime: order.DeliveredTime}}
 	var res models.OrderProjection
-	if err := m.getOrdersCollection().FindOneAndUpdate(ctx, bson.M{constants.OrderId: order.OrderID}, update, ops).Decode(&res); err != nil {
+	if err := m.getOrdersCollection(ctx).FindOneAndUpdate(ctx, bson.M{constants.OrderId: order.OrderID}, update, ops).Decode(&res); err != nil {
 		tracing.TraceErr(span, err)
 		return err
 	}
@@ -138,9 +179,14 @@ func (m *MongoRepository) UpdateDeliveryAddress(ctx context.Context, order *mode
 	ops.SetReturnDocument(options.After)
 	ops.SetUpsert(false)
 
-	update := bson.M{"$set": bson.M{constants.DeliveryAddress: order.DeliveryAddress}}
+	fields := pricingFields(order)
+	if order.TotalPrice.Currency == "" {
+		fields = bson.M{}
+	}
+	fields[constants.DeliveryAddress] = order.DeliveryAddress
+	update := bson.M{"$set": fields}
 	var res models.OrderProjection
-	if err := m.getOrdersCollection().FindOneAndUpdate(ctx, bson.M{constants.OrderId: order.OrderID}, update, ops).Decode(&res); err != nil {
+	if err := m.getOrdersCollection(ctx).FindOneAndUpdate(ctx, bson.M{constants.OrderId: order.OrderID}, update, ops).Decode(&res); err != nil {
 		tracing.TraceErr(span, err)
 		return err
 	}
@@ -157,9 +203,105 @@ func (m *MongoRepository) UpdateSubmit(ctx context.Context, order *models.OrderP
 	ops.SetReturnDocument(options.After)
 	ops.SetUpsert(false)
 
-	update := bson.M{"$set": bson.M{constants.Submitted: order.Submitted}}
+	update := bson.M{"$set": bson.M{constants.Status: order.Status}}
+	var res models.OrderProjection
+	if err := m.getOrdersCollection(ctx).FindOneAndUpdate(ctx, bson.M{constants.OrderId: order.OrderID}, update, ops).Decode(&res); err != nil {
+		tracing.TraceErr(span, err)
+		return err
+	}
+
+	return nil
+}
+
+func (m *MongoRepository) AddRefund(ctx context.Context, order *models.OrderProjection) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "mongoRepository.AddRefund")
+	defer span.Finish()
+	span.LogFields(log.String("OrderID", order.OrderID))
+
+	ops := options.FindOneAndUpdate()
+	ops.SetReturnDocument(options.After)
+	ops.SetUpsert(false)
+
+	fields := bson.M{constants.RefundedAmount: order.RefundedAmount, constants.Refunded: order.Refunded}
+	if order.Status != "" {
+		fields[constants.Status] = order.Status
+	}
+	update := bson.M{
+		"$push": bson.M{constants.Refunds: bson.M{"$each": order.Refunds}},
+		"$set":  fields,
+	}
+	var res models.OrderProjection
+	if err := m.getOrdersCollection(ctx).FindOneAndUpdate(ctx, bson.M{constants.OrderId: order.OrderID}, update, ops).Decode(&res); err != nil {
+		tracing.TraceErr(span, err)
+		return err
+	}
+
+	return nil
+}
+
+func (m *MongoRepository) AddPaymentAttempt(ctx context.Context, order *models.OrderProjection) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "mongoRepository.AddPaymentAttempt")
+	defer span.Finish()
+	span.LogFields(log.String("OrderID", order.OrderID))
+
+	ops := options.FindOneAndUpdate()
+	ops.SetReturnDocument(options.After)
+	ops.SetUpsert(false)
+
+	update := bson.M{"$push": bson.M{constants.PaymentAttempts: bson.M{"$each": order.PaymentAttempts}}}
+	var res models.OrderProjection
+	if err := m.getOrdersCollection(ctx).FindOneAndUpdate(ctx, bson.M{constants.OrderId: order.OrderID}, update, ops).Decode(&res); err != nil {
+		tracing.TraceErr(span, err)
+		return err
+	}
+
+	return nil
+}
+
+// UpdatePaymentAttempt set the status and the non-empty outcome fields of an existing payment attempt,
+// the attempt is matched by attempt id or, when it is empty, by payment id.
+func (m *MongoRepository) UpdatePaymentAttempt(ctx context.Context, orderID string, attempt *models.PaymentAttempt) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "mongoRepository.UpdatePaymentAttempt")
+	defer span.Finish()
+	span.LogFields(log.String("OrderID", orderID), log.String("AttemptID", attempt.AttemptID))
+
+	ops := options.FindOneAndUpdate()
+	ops.SetReturnDocument(options.After)
+	ops.SetUpsert(false)
+
+	fields := bson.M{constants.PaymentAttempts + ".$.status": attempt.Status}
+	if attempt.PaymentID != "" {
+		fields[constants.PaymentAttempts+".$.paymentId"] = attempt.PaymentID
+	}
+	if attempt.FailureReason != "" {
+		fields[constants.PaymentAttempts+".$.failureReason"] = attempt.FailureReason
+	}
+
+	filter := bson.M{constants.OrderId: orderID, constants.PaymentAttempts + "." + constants.PaymentID: attempt.PaymentID}
+	if attempt.AttemptID != "" {
+		filter = bson.M{constants.OrderId: orderID, constants.PaymentAttempts + "." + constants.AttemptID: attempt.AttemptID}
+	}
+	var res models.OrderProjection
+	if err := m.getOrdersCollection(ctx).FindOneAndUpdate(ctx, filter, bson.M{"$set": fields}, ops).Decode(&res); err != nil {
+		tracing.TraceErr(span, err)
+		return err
+	}
+
+	return nil
+}
+
+func (m *MongoRepository) AddReturn(ctx context.Context, order *models.OrderProjection) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "mongoRepository.AddReturn")
+	defer span.Finish()
+	span.LogFields(log.String("OrderID", order.OrderID))
+
+	ops := options.FindOneAndUpdate()
+	ops.SetReturnDocument(options.After)
+	ops.SetUpsert(false)
+
+	update := bson.M{"$push": bson.M{constants.Returns: bson.M{"$each": order.Returns}}}
 	var res models.OrderProjection
-	if err := m.getOrdersCollection().FindOneAndUpdate(ctx, bson.M{constants.OrderId: order.OrderID}, update, ops).Decode(&res); err != nil {
+	if err := m.getOrdersCollection(ctx).FindOneAndUpdate(ctx, bson.M{constants.OrderId: order.OrderID}, update, ops).Decode(&res); err != nil {
 		tracing.TraceErr(span, err)
 		return err
 	}
@@ -167,6 +309,262 @@ func (m *MongoRepository) UpdateSubmit(ctx context.Context, order *models.OrderP
 	return nil
 }
 
-func (m *MongoRepository) getOrdersCollection() *mongo.Collection {
-	return m.db.Database(m.config.Mongo.Db).Collection(m.config.MongoCollections.Orders)
+// UpdateReturn set the status and the non-empty outcome fields of an existing order return.
+func (m *MongoRepository) UpdateReturn(ctx context.Context, orderID string, orderReturn *models.OrderReturn) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "mongoRepository.UpdateReturn")
+	defer span.Finish()
+	span.LogFields(log.String("OrderID", orderID), log.String("ReturnID", orderReturn.ReturnID))
+
+	ops := options.FindOneAndUpdate()
+	ops.SetReturnDocument(options.After)
+	ops.SetUpsert(false)
+
+	fields := bson.M{constants.Returns + ".$.status": orderReturn.Status}
+	if orderReturn.RejectReason != "" {
+		fields[constants.Returns+".$.rejectReason"] = orderReturn.RejectReason
+	}
+	if orderReturn.RefundID != "" {
+		fields[constants.Returns+".$.refundId"] = orderReturn.RefundID
+	}
+	if !orderReturn.ReceivedAt.IsZero() {
+		fields[constants.Returns+".$.receivedAt"] = orderReturn.ReceivedAt
+	}
+
+	filter := bson.M{constants.OrderId: orderID, constants.Returns + "." + constants.ReturnID: orderReturn.ReturnID}
+	var res models.OrderProjection
+	if err := m.getOrdersCollection(ctx).FindOneAndUpdate(ctx, filter, bson.M{"$set": fields}, ops).Decode(&res); err != nil {
+		tracing.TraceErr(span, err)
+		return err
+	}
+
+	return nil
+}
+
+func (m *MongoRepository) AddShipment(ctx context.Context, order *models.OrderProjection) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "mongoRepository.AddShipment")
+	defer span.Finish()
+	span.LogFields(log.String("OrderID", order.OrderID))
+
+	ops := options.FindOneAndUpdate()
+	ops.SetReturnDocument(options.After)
+	ops.SetUpsert(false)
+
+	update := bson.M{"$push": bson.M{constants.Shipments: bson.M{"$each": order.Shipments}}}
+	var res models.OrderProjection
+	if err := m.getOrdersCollection(ctx).FindOneAndUpdate(ctx, bson.M{constants.OrderId: order.OrderID}, update, ops).Decode(&res); err != nil {
+		tracing.TraceErr(span, err)
+		return err
+	}
+
+	return nil
+}
+
+// UpdateShipment sets the status and the non-empty tracking fields of an existing shipment.
+func (m *MongoRepository) UpdateShipment(ctx context.Context, orderID string, shipment *models.Shipment) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "mongoRepository.UpdateShipment")
+	defer span.Finish()
+	span.LogFields(log.String("OrderID", orderID), log.String("ShipmentID", shipment.ShipmentID))
+
+	ops := options.FindOneAndUpdate()
+	ops.SetReturnDocument(options.After)
+	ops.SetUpsert(false)
+
+	fields := bson.M{constants.Shipments + ".$.status": shipment.Status}
+	if shipment.Carrier != "" {
+		fields[constants.Shipments+".$.carrier"] = shipment.Carrier
+	}
+	if shipment.TrackingNumber != "" {
+		fields[constants.Shipments+".$.trackingNumber"] = shipment.TrackingNumber
+	}
+	if !shipment.PackedAt.IsZero() {
+		fields[constants.Shipments+".$.packedAt"] = shipment.PackedAt
+	}
+	if !shipment.DispatchedAt.IsZero() {
+		fields[constants.Shipments+".$.dispatchedAt"] = shipment.DispatchedAt
+	}
+	if !shipment.DeliveredAt.IsZero() {
+		fields[constants.Shipments+".$.deliveredAt"] = shipment.DeliveredAt
+	}
+
+	filter := bson.M{constants.OrderId: orderID, constants.Shipments + "." + constants.ShipmentID: shipment.ShipmentID}
+	var res models.OrderProjection
+	if err := m.getOrdersCollection(ctx).FindOneAndUpdate(ctx, filter, bson.M{"$set": fields}, ops).Decode(&res); err != nil {
+		tracing.TraceErr(span, err)
+		return err
+	}
+
+	return nil
+}
+
+func (m *MongoRepository) AddShopItem(ctx context.Context, order *models.OrderProjection) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "mongoRepository.AddShopItem")
+	defer span.Finish()
+	span.LogFields(log.String("OrderID", order.OrderID))
+
+	ops := options.FindOneAndUpdate()
+	ops.SetReturnDocument(options.After)
+	ops.SetUpsert(false)
+
+	update := bson.M{
+		"$push": bson.M{constants.ShopItems: bson.M{"$each": order.ShopItems}},
+		"$set":  pricingFields(order),
+	}
+	var res models.OrderProjection
+	if err := m.getOrdersCollection(ctx).FindOneAndUpdate(ctx, bson.M{constants.OrderId: order.OrderID}, update, ops).Decode(&res); err != nil {
+		tracing.TraceErr(span, err)
+		return err
+	}
+
+	return nil
+}
+
+func (m *MongoRepository) RemoveShopItem(ctx context.Context, order *models.OrderProjection, shopItemID string) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "mongoRepository.RemoveShopItem")
+	defer span.Finish()
+	span.LogFields(log.String("OrderID", order.OrderID), log.String("ShopItemID", shopItemID))
+
+	ops := options.FindOneAndUpdate()
+	ops.SetReturnDocument(options.After)
+	ops.SetUpsert(false)
+
+	update := bson.M{
+		"$pull": bson.M{constants.ShopItems: bson.M{constants.ID: shopItemID}},
+		"$set":  pricingFields(order),
+	}
+	var res models.OrderProjection
+	if err := m.getOrdersCollection(ctx).FindOneAndUpdate(ctx, bson.M{constants.OrderId: order.OrderID}, update, ops).Decode(&res); err != nil {
+		tracing.TraceErr(span, err)
+		return err
+	}
+
+	return nil
+}
+
+func (m *MongoRepository) ChangeShopItemQuantity(ctx context.Context, order *models.OrderProjection, shopItemID string, quantity uint64) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "mongoRepository.ChangeShopItemQuantity")
+	defer span.Finish()
+	span.LogFields(log.String("OrderID", order.OrderID), log.String("ShopItemID", shopItemID))
+
+	ops := options.FindOneAndUpdate()
+	ops.SetReturnDocument(options.After)
+	ops.SetUpsert(false)
+
+	filter := bson.M{constants.OrderId: order.OrderID, constants.ShopItems + "." + constants.ID: shopItemID}
+	fields := pricingFields(order)
+	fields[constants.ShopItems+".$.quantity"] = quantity
+	update := bson.M{"$set": fields}
+	var res models.OrderProjection
+	if err := m.getOrdersCollection(ctx).FindOneAndUpdate(ctx, filter, update, ops).Decode(&res); err != nil {
+		tracing.TraceErr(span, err)
+		return err
+	}
+
+	return nil
+}
+
+func (m *MongoRepository) AddCoupon(ctx context.Context, order *models.OrderProjection) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "mongoRepository.AddCoupon")
+	defer span.Finish()
+	span.LogFields(log.String("OrderID", order.OrderID))
+
+	ops := options.FindOneAndUpdate()
+	ops.SetReturnDocument(options.After)
+	ops.SetUpsert(false)
+
+	update := bson.M{
+		"$push": bson.M{constants.Coupons: bson.M{"$each": order.Coupons}},
+		"$set":  pricingFields(order),
+	}
+	var res models.OrderProjection
+	if err := m.getOrdersCollection(ctx).FindOneAndUpdate(ctx, bson.M{constants.OrderId: order.OrderID}, update, ops).Decode(&res); err != nil {
+		tracing.TraceErr(span, err)
+		return err
+	}
+
+	return nil
+}
+
+func (m *MongoRepository) RemoveCoupon(ctx context.Context, order *models.OrderProjection, code string) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "mongoRepository.RemoveCoupon")
+	defer span.Finish()
+	span.LogFields(log.String("OrderID", order.OrderID), log.String("Code", code))
+
+	ops := options.FindOneAndUpdate()
+	ops.SetReturnDocument(options.After)
+	ops.SetUpsert(false)
+
+	update := bson.M{
+		"$pull": bson.M{constants.Coupons: bson.M{constants.Code: code}},
+		"$set":  pricingFields(order),
+	}
+	var res models.OrderProjection
+	if err := m.getOrdersCollection(ctx).FindOneAndUpdate(ctx, bson.M{constants.OrderId: order.OrderID}, update, ops).Decode(&res); err != nil {
+		tracing.TraceErr(span, err)
+		return err
+	}
+
+	return nil
+}
+
+// pricingFields sets the total and, when known, the pricing breakdown of the order.
+func pricingFields(order *models.OrderProjection) bson.M {
+	fields := bson.M{constants.TotalPrice: order.TotalPrice}
+	if order.Subtotal.Currency == "" {
+		return fields
+	}
+
+	fields[constants.Subtotal] = order.Subtotal
+	fields[constants.Discounts] = order.Discounts
+	fields[constants.DiscountTotal] = order.DiscountTotal
+	fields[constants.ShippingPrice] = order.ShippingPrice
+	fields[constants.TaxTotal] = order.TaxTotal
+	fields[constants.Taxes] = order.Taxes
+	fields[constants.TaxRegion] = order.TaxRegion
+	return fields
+}
+
+// GetByCustomerID orders of the customer, newest first.
+func (m *MongoRepository) GetByCustomerID(ctx context.Context, customerID string, pq *utils.Pagination) (*dto.OrderSearchResponseDto, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "mongoRepository.GetByCustomerID")
+	defer span.Finish()
+	span.LogFields(log.String("CustomerID", customerID))
+
+	filter := bson.M{constants.CustomerID: customerID}
+	totalCount, err := m.getOrdersCollection(ctx).CountDocuments(ctx, filter)
+	if err != nil {
+		tracing.TraceErr(span, err)
+		return nil, err
+	}
+
+	ops := options.Find().
+		SetSort(bson.D{{Key: "_id", Value: -1}}).
+		SetSkip(int64(pq.GetOffset())).
+		SetLimit(int64(pq.GetLimit()))
+	cursor, err := m.getOrdersCollection(ctx).Find(ctx, filter, ops)
+	if err != nil {
+		tracing.TraceErr(span, err)
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	orders := make([]*models.OrderProjection, 0, pq.GetSize())
+	if err := cursor.All(ctx, &orders); err != nil {
+		tracing.TraceErr(span, err)
+		return nil, err
+	}
+
+	return &dto.OrderSearchResponseDto{
+		Pagination: dto.Pagination{
+			TotalCount: totalCount,
+			TotalPages: int64(pq.GetTotalPages(int(totalCount))),
+			Page:       int64(pq.GetPage()),
+			Size:       int64(pq.GetSize()),
+			HasMore:    pq.GetHasMore(int(totalCount)),
+		},
+		Orders: utils.OrdersResponseFrom(orders),
+	}, nil
+}
+
+func (m *MongoRepository) getOrdersCollection(ctx context.Context) *mongo.Collection {
+	return m.db.Database(m.config.Mongo.Db).Collection(es.TenantName(es.TenantFromContext(ctx), m.config.MongoCollections.Orders))
 }
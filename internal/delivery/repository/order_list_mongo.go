@@ -0,0 +1,226 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/log"
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/wassef911/eventually/internal/api/constants"
+	"github.com/wassef911/eventually/internal/api/dto"
+	"github.com/wassef911/eventually/internal/api/utils"
+	"github.com/wassef911/eventually/internal/delivery/models"
+	"github.com/wassef911/eventually/internal/infrastructure/tracing"
+)
+
+// orderSortKeys projection field of every sort, ties are broken by order id.
+var orderSortKeys = map[string]string{
+	utils.SortCreatedAt:  constants.CreatedAt,
+	utils.SortTotalPrice: constants.TotalPrice + "." + constants.Amount,
+}
+
+// List orders matching the filter, one page after the cursor of cp.
+func (m *MongoRepository) List(ctx context.Context, filter models.OrderListFilter, cp *utils.CursorPagination) (*dto.OrderListResponseDto, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "mongoRepository.List")
+	defer span.Finish()
+	span.LogFields(log.String("Sort", cp.Sort), log.String("Order", cp.Order), log.Int("Size", cp.GetSize()))
+
+	sortKey, ok := orderSortKeys[cp.Sort]
+	if !ok {
+		return nil, errors.Errorf("unknown sort {%s}", cp.Sort)
+	}
+	direction := 1
+	if cp.IsDescending() {
+		direction = -1
+	}
+
+	query := orderListQuery(filter)
+	if cp.Cursor != nil {
+		query = bson.M{"$and": bson.A{query, afterOrderCursor(sortKey, cp)}}
+	}
+
+	// one more order than the page tells whether another page follows
+	ops := options.Find().
+		SetSort(bson.D{{Key: sortKey, Value: direction}, {Key: constants.OrderId, Value: direction}}).
+		SetLimit(int64(cp.GetSize() + 1))
+	cursor, err := m.getOrdersCollection(ctx).Find(ctx, query, ops)
+	if err != nil {
+		tracing.TraceErr(span, err)
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	orders := make([]*models.OrderProjection, 0, cp.GetSize()+1)
+	if err := cursor.All(ctx, &orders); err != nil {
+		tracing.TraceErr(span, err)
+		return nil, err
+	}
+
+	hasMore := len(orders) > cp.GetSize()
+	if hasMore {
+		orders = orders[:cp.GetSize()]
+	}
+
+	res := &dto.OrderListResponseDto{
+		Orders:  utils.OrdersResponseFrom(orders),
+		Size:    int64(len(orders)),
+		HasMore: hasMore,
+	}
+	if hasMore {
+		nextCursor, err := utils.NewOrderCursor(cp.Sort, orders[len(orders)-1]).Encode()
+		if err != nil {
+			tracing.TraceErr(span, err)
+			return nil, err
+		}
+		res.NextCursor = nextCursor
+	}
+
+	return res, nil
+}
+
+// orderListQuery mongo query of the filter, time ranges include their start and exclude their end.
+func orderListQuery(filter models.OrderListFilter) bson.M {
+	query := bson.M{}
+	if filter.AccountEmail != "" {
+		query[constants.AccountEmail] = filter.AccountEmail
+	}
+	if filter.Status != "" {
+		query[constants.Status] = filter.Status
+	}
+	addTimeRange(query, constants.CreatedAt, filter.CreatedFrom, filter.CreatedTo)
+	addTimeRange(query, constants.Payment+"."+constants.Timestamp, filter.PaidFrom, filter.PaidTo)
+	addTimeRange(query, constants.DeliveredTime, filter.DeliveredFrom, filter.DeliveredTo)
+
+	totalRange := bson.M{}
+	if filter.MinTotal != nil {
+		totalRange["$gte"] = *filter.MinTotal
+	}
+	if filter.MaxTotal != nil {
+		totalRange["$lte"] = *filter.MaxTotal
+	}
+	if len(totalRange) > 0 {
+		query[constants.TotalPrice+"."+constants.Amount] = totalRange
+	}
+	return query
+}
+
+func addTimeRange(query bson.M, key string, from time.Time, to time.Time) {
+	timeRange := bson.M{}
+	if !from.IsZero() {
+		timeRange["$gte"] = from
+	}
+	if !to.IsZero() {
+		timeRange["$lt"] = to
+	}
+	if len(timeRange) > 0 {
+		query[key] = timeRange
+	}
+}
+
+// afterOrderCursor matches the orders sorted after the cursor: further on the sort key, or tied and further on the order id.
+// Orders without a creation time sort before every other order, as they do in mongo.
+func afterOrderCursor(sortKey string, cp *utils.CursorPagination) bson.M {
+	operator := "$gt"
+	if cp.IsDescending() {
+		operator = "$lt"
+	}
+
+	var value interface{} = cp.Cursor.CreatedAt
+	if cp.Sort == utils.SortTotalPrice {
+		value = cp.Cursor.TotalPrice
+	} else if cp.Cursor.CreatedAt.IsZero() {
+		// the cursor points to an order without a creation time
+		if cp.IsDescending() {
+			return bson.M{sortKey: nil, constants.OrderId: bson.M{operator: cp.Cursor.OrderID}}
+		}
+		return bson.M{"$or": bson.A{
+			bson.M{sortKey: bson.M{"$ne": nil}},
+			bson.M{sortKey: nil, constants.OrderId: bson.M{operator: cp.Cursor.OrderID}},
+		}}
+	}
+
+	after := bson.A{
+		bson.M{sortKey: bson.M{operator: value}},
+		bson.M{sortKey: value, constants.OrderId: bson.M{operator: cp.Cursor.OrderID}},
+	}
+	if cp.Sort == utils.SortCreatedAt && cp.IsDescending() {
+		after = append(after, bson.M{sortKey: nil})
+	}
+	return bson.M{"$or": after}
+}
+
+// ListWithoutCreatedAt ids of the orders projected before their creation time was recorded.
+func (m *MongoRepository) ListWithoutCreatedAt(ctx context.Context, limit int) ([]string, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "mongoRepository.ListWithoutCreatedAt")
+	defer span.Finish()
+
+	return m.listOrderIDs(ctx, span, bson.M{constants.CreatedAt: nil}, limit)
+}
+
+// ListWithoutStatus ids of the orders projected before their status was recorded.
+func (m *MongoRepository) ListWithoutStatus(ctx context.Context, limit int) ([]string, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "mongoRepository.ListWithoutStatus")
+	defer span.Finish()
+
+	return m.listOrderIDs(ctx, span, bson.M{constants.Status: nil}, limit)
+}
+
+func (m *MongoRepository) listOrderIDs(ctx context.Context, span opentracing.Span, filter bson.M, limit int) ([]string, error) {
+	ops := options.Find().
+		SetProjection(bson.M{constants.OrderId: 1}).
+		SetSort(bson.D{{Key: constants.OrderId, Value: 1}}).
+		SetLimit(int64(limit))
+	cursor, err := m.getOrdersCollection(ctx).Find(ctx, filter, ops)
+	if err != nil {
+		tracing.TraceErr(span, err)
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	orders := make([]*models.OrderProjection, 0, limit)
+	if err := cursor.All(ctx, &orders); err != nil {
+		tracing.TraceErr(span, err)
+		return nil, err
+	}
+
+	orderIDs := make([]string, 0, len(orders))
+	for _, order := range orders {
+		orderIDs = append(orderIDs, order.OrderID)
+	}
+	return orderIDs, nil
+}
+
+func (m *MongoRepository) SetCreatedAt(ctx context.Context, orderID string, createdAt time.Time) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "mongoRepository.SetCreatedAt")
+	defer span.Finish()
+	span.LogFields(log.String("OrderID", orderID))
+
+	update := bson.M{"$set": bson.M{constants.CreatedAt: createdAt}}
+	if _, err := m.getOrdersCollection(ctx).UpdateOne(ctx, bson.M{constants.OrderId: orderID}, update); err != nil {
+		tracing.TraceErr(span, err)
+		return err
+	}
+
+	return nil
+}
+
+func (m *MongoRepository) SetStatus(ctx context.Context, orderID string, status models.OrderStatus) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "mongoRepository.SetStatus")
+	defer span.Finish()
+	span.LogFields(log.String("OrderID", orderID))
+
+	update := bson.M{
+		"$set":   bson.M{constants.Status: status},
+		"$unset": bson.M{constants.Paid: "", constants.Submitted: "", constants.Completed: "", constants.Canceled: ""},
+	}
+	if _, err := m.getOrdersCollection(ctx).UpdateOne(ctx, bson.M{constants.OrderId: orderID}, update); err != nil {
+		tracing.TraceErr(span, err)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+
+	"github.com/wassef911/eventually/internal/api/utils"
+	"github.com/wassef911/eventually/internal/delivery/models"
+)
+
+func TestOrderListQuery(t *testing.T) {
+	assert.Equal(t, bson.M{}, orderListQuery(models.OrderListFilter{}))
+
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 1, 0)
+	minTotal, maxTotal := int64(0), int64(5000)
+	filter := models.OrderListFilter{
+		AccountEmail: "jane@example.com",
+		Status:       models.OrderStatusPaid,
+		CreatedFrom:  from,
+		CreatedTo:    to,
+		PaidFrom:     from,
+		DeliveredTo:  to,
+		MinTotal:     &minTotal,
+		MaxTotal:     &maxTotal,
+	}
+
+	assert.Equal(t, bson.M{
+		"accountEmail":      "jane@example.com",
+		"status":            models.OrderStatusPaid,
+		"createdAt":         bson.M{"$gte": from, "$lt": to},
+		"payment.timestamp": bson.M{"$gte": from},
+		"deliveredTime":     bson.M{"$lt": to},
+		"totalPrice.amount": bson.M{"$gte": int64(0), "$lte": int64(5000)},
+	}, orderListQuery(filter))
+}
+
+func TestAfterOrderCursor(t *testing.T) {
+	createdAt := time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC)
+	cp := &utils.CursorPagination{Sort: utils.SortCreatedAt, Order: utils.SortDesc, Cursor: &utils.OrderCursor{Sort: utils.SortCreatedAt, CreatedAt: createdAt, OrderID: "order-1"}}
+	assert.Equal(t, bson.M{"$or": bson.A{
+		bson.M{"createdAt": bson.M{"$lt": createdAt}},
+		bson.M{"createdAt": createdAt, "orderId": bson.M{"$lt": "order-1"}},
+		bson.M{"createdAt": nil},
+	}}, afterOrderCursor(orderSortKeys[cp.Sort], cp), "orders without a creation time come last")
+
+	cp = &utils.CursorPagination{Sort: utils.SortTotalPrice, Order: utils.SortAsc, Cursor: &utils.OrderCursor{Sort: utils.SortTotalPrice, TotalPrice: 2500, OrderID: "order-1"}}
+	assert.Equal(t, bson.M{"$or": bson.A{
+		bson.M{"totalPrice.amount": bson.M{"$gt": int64(2500)}},
+		bson.M{"totalPrice.amount": int64(2500), "orderId": bson.M{"$gt": "order-1"}},
+	}}, afterOrderCursor(orderSortKeys[cp.Sort], cp))
+}
+
+func TestAfterOrderCursor_WithoutCreatedAt(t *testing.T) {
+	legacy := &utils.OrderCursor{Sort: utils.SortCreatedAt, OrderID: "order-1"}
+
+	cp := &utils.CursorPagination{Sort: utils.SortCreatedAt, Order: utils.SortDesc, Cursor: legacy}
+	assert.Equal(t, bson.M{"createdAt": nil, "orderId": bson.M{"$lt": "order-1"}}, afterOrderCursor(orderSortKeys[cp.Sort], cp))
+
+	cp = &utils.CursorPagination{Sort: utils.SortCreatedAt, Order: utils.SortAsc, Cursor: legacy}
+	assert.Equal(t, bson.M{"$or": bson.A{
+		bson.M{"createdAt": bson.M{"$ne": nil}},
+		bson.M{"createdAt": nil, "orderId": bson.M{"$gt": "order-1"}},
+	}}, afterOrderCursor(orderSortKeys[cp.Sort], cp))
+
+	createdAt := time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC)
+	cp = &utils.CursorPagination{Sort: utils.SortCreatedAt, Order: utils.SortAsc, Cursor: &utils.OrderCursor{Sort: utils.SortCreatedAt, CreatedAt: createdAt, OrderID: "order-1"}}
+	assert.Equal(t, bson.M{"$or": bson.A{
+		bson.M{"createdAt": bson.M{"$gt": createdAt}},
+		bson.M{"createdAt": createdAt, "orderId": bson.M{"$gt": "order-1"}},
+	}}, afterOrderCursor(orderSortKeys[cp.Sort], cp), "orders without a creation time came first")
+}
@@ -0,0 +1,126 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/log"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/wassef911/eventually/internal/api/constants"
+	"github.com/wassef911/eventually/internal/api/dto"
+	"github.com/wassef911/eventually/internal/api/utils"
+	"github.com/wassef911/eventually/internal/delivery/models"
+	"github.com/wassef911/eventually/internal/infrastructure/es"
+	"github.com/wassef911/eventually/internal/infrastructure/tracing"
+	"github.com/wassef911/eventually/pkg/config"
+	"github.com/wassef911/eventually/pkg/logger"
+)
+
+type MongoProductRepository struct {
+	log    logger.Logger
+	config *config.Config
+	db     *mongo.Client
+}
+
+var _ ProductRepository = &MongoProductRepository{}
+
+func NewMongoProductRepository(log logger.Logger, config *config.Config, db *mongo.Client) *MongoProductRepository {
+	return &MongoProductRepository{log: log, config: config, db: db}
+}
+
+func (m *MongoProductRepository) Insert(ctx context.Context, product *models.ProductProjection) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "mongoProductRepository.Insert")
+	defer span.Finish()
+	span.LogFields(log.String("ProductID", product.ProductID))
+
+	if _, err := m.getProductsCollection(ctx).InsertOne(ctx, product); err != nil {
+		tracing.TraceErr(span, err)
+		return err
+	}
+
+	return nil
+}
+
+func (m *MongoProductRepository) GetByID(ctx context.Context, productID string) (*models.ProductProjection, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "mongoProductRepository.GetByID")
+	defer span.Finish()
+	span.LogFields(log.String("ProductID", productID))
+
+	var product models.ProductProjection
+	if err := m.getProductsCollection(ctx).FindOne(ctx, bson.M{constants.ProductID: productID}).Decode(&product); err != nil {
+		tracing.TraceErr(span, err)
+		return nil, err
+	}
+
+	return &product, nil
+}
+
+func (m *MongoProductRepository) Update(ctx context.Context, product *models.ProductProjection) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "mongoProductRepository.Update")
+	defer span.Finish()
+	span.LogFields(log.String("ProductID", product.ProductID))
+
+	res, err := m.getProductsCollection(ctx).ReplaceOne(ctx, bson.M{constants.ProductID: product.ProductID}, product)
+	if err != nil {
+		tracing.TraceErr(span, err)
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+
+	return nil
+}
+
+// List catalog ordered by product id, discontinued products are hidden unless requested.
+func (m *MongoProductRepository) List(ctx context.Context, includeDiscontinued bool, pq *utils.Pagination) (*dto.ProductListResponseDto, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "mongoProductRepository.List")
+	defer span.Finish()
+	span.LogFields(log.Bool("IncludeDiscontinued", includeDiscontinued))
+
+	filter := bson.M{}
+	if !includeDiscontinued {
+		filter[constants.Discontinued] = false
+	}
+
+	totalCount, err := m.getProductsCollection(ctx).CountDocuments(ctx, filter)
+	if err != nil {
+		tracing.TraceErr(span, err)
+		return nil, err
+	}
+
+	ops := options.Find().
+		SetSort(bson.D{{Key: constants.ProductID, Value: 1}}).
+		SetSkip(int64(pq.GetOffset())).
+		SetLimit(int64(pq.GetLimit()))
+	cursor, err := m.getProductsCollection(ctx).Find(ctx, filter, ops)
+	if err != nil {
+		tracing.TraceErr(span, err)
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	products := make([]*models.ProductProjection, 0, pq.GetSize())
+	if err := cursor.All(ctx, &products); err != nil {
+		tracing.TraceErr(span, err)
+		return nil, err
+	}
+
+	return &dto.ProductListResponseDto{
+		Pagination: dto.Pagination{
+			TotalCount: totalCount,
+			TotalPages: int64(pq.GetTotalPages(int(totalCount))),
+			Page:       int64(pq.GetPage()),
+			Size:       int64(pq.GetSize()),
+			HasMore:    pq.GetHasMore(int(totalCount)),
+		},
+		Products: utils.ProductsResponseFrom(products),
+	}, nil
+}
+
+func (m *MongoProductRepository) getProductsCollection(ctx context.Context) *mongo.Collection {
+	return m.db.Database(m.config.Mongo.Db).Collection(es.TenantName(es.TenantFromContext(ctx), m.config.MongoCollections.Products))
+}
@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/log"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/wassef911/eventually/internal/api/constants"
+	"github.com/wassef911/eventually/internal/infrastructure/es"
+	"github.com/wassef911/eventually/internal/infrastructure/tracing"
+	"github.com/wassef911/eventually/pkg/config"
+	"github.com/wassef911/eventually/pkg/logger"
+)
+
+// sagaDeadline one document per running saga with a deadline.
+type sagaDeadline struct {
+	ID            string    `bson:"_id"`
+	Saga          string    `bson:"saga"`
+	CorrelationID string    `bson:"correlationId"`
+	Deadline      time.Time `bson:"deadline"`
+}
+
+// MongoSagaDeadlineRepository es.DeadlineStore backed by mongo.
+type MongoSagaDeadlineRepository struct {
+	log    logger.Logger
+	config *config.Config
+	db     *mongo.Client
+}
+
+var _ es.DeadlineStore = &MongoSagaDeadlineRepository{}
+
+func NewMongoSagaDeadlineRepository(log logger.Logger, config *config.Config, db *mongo.Client) *MongoSagaDeadlineRepository {
+	return &MongoSagaDeadlineRepository{log: log, config: config, db: db}
+}
+
+func (m *MongoSagaDeadlineRepository) Schedule(ctx context.Context, saga string, correlationID string, deadline time.Time) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "mongoSagaDeadlineRepository.Schedule")
+	defer span.Finish()
+	span.LogFields(log.String("Saga", saga), log.String("CorrelationID", correlationID))
+
+	document := sagaDeadline{ID: sagaDeadlineID(saga, correlationID), Saga: saga, CorrelationID: correlationID, Deadline: deadline}
+	ops := options.Replace().SetUpsert(true)
+	if _, err := m.getSagaDeadlinesCollection().ReplaceOne(ctx, bson.M{"_id": document.ID}, document, ops); err != nil {
+		tracing.TraceErr(span, err)
+		return err
+	}
+
+	return nil
+}
+
+func (m *MongoSagaDeadlineRepository) Cancel(ctx context.Context, saga string, correlationID string) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "mongoSagaDeadlineRepository.Cancel")
+	defer span.Finish()
+	span.LogFields(log.String("Saga", saga), log.String("CorrelationID", correlationID))
+
+	if _, err := m.getSagaDeadlinesCollection().DeleteOne(ctx, bson.M{"_id": sagaDeadlineID(saga, correlationID)}); err != nil {
+		tracing.TraceErr(span, err)
+		return err
+	}
+
+	return nil
+}
+
+func (m *MongoSagaDeadlineRepository) Due(ctx context.Context, saga string, now time.Time) ([]string, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "mongoSagaDeadlineRepository.Due")
+	defer span.Finish()
+	span.LogFields(log.String("Saga", saga))
+
+	filter := bson.M{constants.Saga: saga, constants.Deadline: bson.M{"$lte": now}}
+	ops := options.Find().SetSort(bson.D{{Key: constants.Deadline, Value: 1}})
+	cursor, err := m.getSagaDeadlinesCollection().Find(ctx, filter, ops)
+	if err != nil {
+		tracing.TraceErr(span, err)
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var deadlines []sagaDeadline
+	if err := cursor.All(ctx, &deadlines); err != nil {
+		tracing.TraceErr(span, err)
+		return nil, err
+	}
+
+	correlationIDs := make([]string, 0, len(deadlines))
+	for _, deadline := range deadlines {
+		correlationIDs = append(correlationIDs, deadline.CorrelationID)
+	}
+	return correlationIDs, nil
+}
+
+func (m *MongoSagaDeadlineRepository) getSagaDeadlinesCollection() *mongo.Collection {
+	return m.db.Database(m.config.Mongo.Db).Collection(m.config.MongoCollections.SagaDeadlines)
+}
+
+func sagaDeadlineID(saga string, correlationID string) string {
+	return saga + ":" + correlationID
+}
@@ -0,0 +1,147 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/log"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/wassef911/eventually/internal/api/constants"
+	"github.com/wassef911/eventually/internal/api/dto"
+	"github.com/wassef911/eventually/internal/api/utils"
+	"github.com/wassef911/eventually/internal/delivery/models"
+	"github.com/wassef911/eventually/internal/infrastructure/es"
+	"github.com/wassef911/eventually/internal/infrastructure/tracing"
+	"github.com/wassef911/eventually/pkg/config"
+	"github.com/wassef911/eventually/pkg/logger"
+)
+
+type MongoWebhookDeliveryRepository struct {
+	log    logger.Logger
+	config *config.Config
+	db     *mongo.Client
+}
+
+var _ WebhookDeliveryRepository = &MongoWebhookDeliveryRepository{}
+
+func NewMongoWebhookDeliveryRepository(log logger.Logger, config *config.Config, db *mongo.Client) *MongoWebhookDeliveryRepository {
+	return &MongoWebhookDeliveryRepository{log: log, config: config, db: db}
+}
+
+func (m *MongoWebhookDeliveryRepository) Insert(ctx context.Context, delivery *models.WebhookDelivery) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "mongoWebhookDeliveryRepository.Insert")
+	defer span.Finish()
+	span.LogFields(log.String("WebhookID", delivery.WebhookID), log.String("EventID", delivery.EventID))
+
+	if _, err := m.getDeliveriesCollection(ctx).InsertOne(ctx, delivery); err != nil {
+		tracing.TraceErr(span, err)
+		return err
+	}
+
+	return nil
+}
+
+// ListByWebhookID delivery attempts of the webhook, latest first.
+func (m *MongoWebhookDeliveryRepository) ListByWebhookID(ctx context.Context, webhookID string, pq *utils.Pagination) (*dto.WebhookDeliveryListResponseDto, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "mongoWebhookDeliveryRepository.ListByWebhookID")
+	defer span.Finish()
+	span.LogFields(log.String("WebhookID", webhookID))
+
+	filter := bson.M{constants.WebhookID: webhookID}
+	totalCount, err := m.getDeliveriesCollection(ctx).CountDocuments(ctx, filter)
+	if err != nil {
+		tracing.TraceErr(span, err)
+		return nil, err
+	}
+
+	ops := options.Find().
+		SetSort(bson.D{{Key: constants.AttemptedAt, Value: -1}}).
+		SetSkip(int64(pq.GetOffset())).
+		SetLimit(int64(pq.GetLimit()))
+	cursor, err := m.getDeliveriesCollection(ctx).Find(ctx, filter, ops)
+	if err != nil {
+		tracing.TraceErr(span, err)
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	deliveries := make([]*models.WebhookDelivery, 0, pq.GetSize())
+	if err := cursor.All(ctx, &deliveries); err != nil {
+		tracing.TraceErr(span, err)
+		return nil, err
+	}
+
+	return &dto.WebhookDeliveryListResponseDto{
+		Pagination: dto.Pagination{
+			TotalCount: totalCount,
+			TotalPages: int64(pq.GetTotalPages(int(totalCount))),
+			Page:       int64(pq.GetPage()),
+			Size:       int64(pq.GetSize()),
+			HasMore:    pq.GetHasMore(int(totalCount)),
+		},
+		Deliveries: utils.WebhookDeliveriesResponseFrom(deliveries),
+	}, nil
+}
+
+// ListDue failed deliveries whose retry is due at now, the longest waiting first.
+func (m *MongoWebhookDeliveryRepository) ListDue(ctx context.Context, now time.Time, limit int) ([]*models.WebhookDelivery, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "mongoWebhookDeliveryRepository.ListDue")
+	defer span.Finish()
+
+	ops := options.Find().
+		SetSort(bson.D{{Key: constants.NextAttemptAt, Value: 1}}).
+		SetLimit(int64(limit))
+	cursor, err := m.getDeliveriesCollection(ctx).Find(ctx, bson.M{constants.NextAttemptAt: bson.M{"$lte": now}}, ops)
+	if err != nil {
+		tracing.TraceErr(span, err)
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	deliveries := make([]*models.WebhookDelivery, 0, limit)
+	if err := cursor.All(ctx, &deliveries); err != nil {
+		tracing.TraceErr(span, err)
+		return nil, err
+	}
+
+	return deliveries, nil
+}
+
+// ClaimRetry moves the retry of the delivery to until, the dispatcher claiming it first wins. A claimed retry whose
+// next attempt is never logged is due again at until.
+func (m *MongoWebhookDeliveryRepository) ClaimRetry(ctx context.Context, delivery *models.WebhookDelivery, until time.Time) (bool, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "mongoWebhookDeliveryRepository.ClaimRetry")
+	defer span.Finish()
+	span.LogFields(log.String("DeliveryID", delivery.DeliveryID))
+
+	filter := bson.M{constants.DeliveryID: delivery.DeliveryID, constants.NextAttemptAt: delivery.NextAttemptAt}
+	res, err := m.getDeliveriesCollection(ctx).UpdateOne(ctx, filter, bson.M{"$set": bson.M{constants.NextAttemptAt: until}})
+	if err != nil {
+		tracing.TraceErr(span, err)
+		return false, err
+	}
+
+	return res.ModifiedCount == 1, nil
+}
+
+func (m *MongoWebhookDeliveryRepository) CompleteRetry(ctx context.Context, deliveryID string) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "mongoWebhookDeliveryRepository.CompleteRetry")
+	defer span.Finish()
+	span.LogFields(log.String("DeliveryID", deliveryID))
+
+	update := bson.M{"$unset": bson.M{constants.NextAttemptAt: "", constants.Payload: ""}}
+	if _, err := m.getDeliveriesCollection(ctx).UpdateOne(ctx, bson.M{constants.DeliveryID: deliveryID}, update); err != nil {
+		tracing.TraceErr(span, err)
+		return err
+	}
+
+	return nil
+}
+
+func (m *MongoWebhookDeliveryRepository) getDeliveriesCollection(ctx context.Context) *mongo.Collection {
+	return m.db.Database(m.config.Mongo.Db).Collection(es.TenantName(es.TenantFromContext(ctx), m.config.MongoCollections.WebhookDeliveries))
+}
@@ -0,0 +1,160 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/log"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"github.com/wassef911/eventually/internal/api/constants"
+	"github.com/wassef911/eventually/internal/delivery/models"
+	"github.com/wassef911/eventually/internal/infrastructure/es"
+	"github.com/wassef911/eventually/internal/infrastructure/tracing"
+	"github.com/wassef911/eventually/pkg/config"
+	"github.com/wassef911/eventually/pkg/logger"
+)
+
+type MongoWebhookRepository struct {
+	log    logger.Logger
+	config *config.Config
+	db     *mongo.Client
+}
+
+var _ WebhookRepository = &MongoWebhookRepository{}
+
+func NewMongoWebhookRepository(log logger.Logger, config *config.Config, db *mongo.Client) *MongoWebhookRepository {
+	return &MongoWebhookRepository{log: log, config: config, db: db}
+}
+
+func (m *MongoWebhookRepository) Insert(ctx context.Context, webhook *models.Webhook) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "mongoWebhookRepository.Insert")
+	defer span.Finish()
+	span.LogFields(log.String("WebhookID", webhook.WebhookID))
+
+	if _, err := m.getWebhooksCollection(ctx).InsertOne(ctx, webhook); err != nil {
+		tracing.TraceErr(span, err)
+		return err
+	}
+
+	return nil
+}
+
+func (m *MongoWebhookRepository) GetByID(ctx context.Context, webhookID string) (*models.Webhook, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "mongoWebhookRepository.GetByID")
+	defer span.Finish()
+	span.LogFields(log.String("WebhookID", webhookID))
+
+	var webhook models.Webhook
+	if err := m.getWebhooksCollection(ctx).FindOne(ctx, bson.M{constants.WebhookID: webhookID}).Decode(&webhook); err != nil {
+		tracing.TraceErr(span, err)
+		return nil, err
+	}
+
+	return &webhook, nil
+}
+
+func (m *MongoWebhookRepository) Update(ctx context.Context, webhook *models.Webhook) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "mongoWebhookRepository.Update")
+	defer span.Finish()
+	span.LogFields(log.String("WebhookID", webhook.WebhookID))
+
+	res, err := m.getWebhooksCollection(ctx).ReplaceOne(ctx, bson.M{constants.WebhookID: webhook.WebhookID}, webhook)
+	if err != nil {
+		tracing.TraceErr(span, err)
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+
+	return nil
+}
+
+func (m *MongoWebhookRepository) Delete(ctx context.Context, webhookID string) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "mongoWebhookRepository.Delete")
+	defer span.Finish()
+	span.LogFields(log.String("WebhookID", webhookID))
+
+	res, err := m.getWebhooksCollection(ctx).DeleteOne(ctx, bson.M{constants.WebhookID: webhookID})
+	if err != nil {
+		tracing.TraceErr(span, err)
+		return err
+	}
+	if res.DeletedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+
+	return nil
+}
+
+// ListActive webhooks that are not disabled.
+func (m *MongoWebhookRepository) ListActive(ctx context.Context) ([]*models.Webhook, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "mongoWebhookRepository.ListActive")
+	defer span.Finish()
+
+	cursor, err := m.getWebhooksCollection(ctx).Find(ctx, bson.M{constants.Disabled: false})
+	if err != nil {
+		tracing.TraceErr(span, err)
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	webhooks := make([]*models.Webhook, 0)
+	if err := cursor.All(ctx, &webhooks); err != nil {
+		tracing.TraceErr(span, err)
+		return nil, err
+	}
+
+	return webhooks, nil
+}
+
+// RecordSuccess updates the stored count in place, deliveries of the webhook running at the same time may record theirs.
+// A webhook deleted meanwhile is not recreated.
+func (m *MongoWebhookRepository) RecordSuccess(ctx context.Context, webhookID string, now time.Time) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "mongoWebhookRepository.RecordSuccess")
+	defer span.Finish()
+	span.LogFields(log.String("WebhookID", webhookID))
+
+	filter := bson.M{constants.WebhookID: webhookID, constants.Failures: bson.M{"$gt": 0}}
+	update := bson.M{"$set": bson.M{constants.Failures: 0, constants.UpdatedAt: now}}
+	if _, err := m.getWebhooksCollection(ctx).UpdateOne(ctx, filter, update); err != nil {
+		tracing.TraceErr(span, err)
+		return err
+	}
+
+	return nil
+}
+
+// RecordFailure increments the stored count, only the failure reaching disableAfter disables the webhook.
+// A webhook deleted meanwhile is not recreated.
+func (m *MongoWebhookRepository) RecordFailure(ctx context.Context, webhookID string, now time.Time, disableAfter int) (bool, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "mongoWebhookRepository.RecordFailure")
+	defer span.Finish()
+	span.LogFields(log.String("WebhookID", webhookID))
+
+	update := bson.M{"$inc": bson.M{constants.Failures: 1}, "$set": bson.M{constants.UpdatedAt: now}}
+	res, err := m.getWebhooksCollection(ctx).UpdateOne(ctx, bson.M{constants.WebhookID: webhookID}, update)
+	if err != nil {
+		tracing.TraceErr(span, err)
+		return false, err
+	}
+	if res.MatchedCount == 0 || disableAfter <= 0 {
+		return false, nil
+	}
+
+	filter := bson.M{constants.WebhookID: webhookID, constants.Disabled: false, constants.Failures: bson.M{"$gte": disableAfter}}
+	res, err = m.getWebhooksCollection(ctx).UpdateOne(ctx, filter, bson.M{"$set": bson.M{constants.Disabled: true, constants.DisabledAt: now}})
+	if err != nil {
+		tracing.TraceErr(span, err)
+		return false, err
+	}
+
+	return res.ModifiedCount == 1, nil
+}
+
+func (m *MongoWebhookRepository) getWebhooksCollection(ctx context.Context) *mongo.Collection {
+	return m.db.Database(m.config.Mongo.Db).Collection(es.TenantName(es.TenantFromContext(ctx), m.config.MongoCollections.Webhooks))
+}
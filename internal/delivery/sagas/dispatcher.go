@@ -0,0 +1,153 @@
+package sagas
+
+import (
+	"context"
+
+	"github.com/EventStore/EventStore-Client-Go/esdb"
+	"github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/log"
+	"github.com/pkg/errors"
+
+	"github.com/wassef911/eventually/internal/delivery/aggregate"
+	"github.com/wassef911/eventually/internal/delivery/commands"
+	service "github.com/wassef911/eventually/internal/delivery/services"
+	"github.com/wassef911/eventually/internal/infrastructure/es"
+	"github.com/wassef911/eventually/internal/infrastructure/es/store"
+	"github.com/wassef911/eventually/internal/infrastructure/tracing"
+	"github.com/wassef911/eventually/pkg/logger"
+)
+
+const (
+	ReserveStockCommandType  = "RESERVE_STOCK"
+	ReleaseStockCommandType  = "RELEASE_STOCK"
+	CommitStockCommandType   = "COMMIT_STOCK"
+	RejectOrderCommandType   = "REJECT_ORDER"
+	CancelOrderCommandType   = "CANCEL_ORDER"
+	RemindPaymentCommandType = "REMIND_PAYMENT"
+)
+
+var ErrUnknownCommandType = errors.New("unknown saga command type")
+
+// commandDispatcher sends saga commands to the service command handlers. The events a command raises record
+// its id, a command whose events are already in the stream of its aggregate is not handled again.
+type commandDispatcher struct {
+	log              logger.Logger
+	es               store.AggregateStore
+	orderService     *service.OrderService
+	inventoryService *service.InventoryService
+}
+
+var _ es.CommandDispatcher = &commandDispatcher{}
+
+func NewCommandDispatcher(log logger.Logger, es store.AggregateStore, orderService *service.OrderService, inventoryService *service.InventoryService) *commandDispatcher {
+	return &commandDispatcher{log: log, es: es, orderService: orderService, inventoryService: inventoryService}
+}
+
+func (d *commandDispatcher) Dispatch(ctx context.Context, sagaCommand es.SagaCommand) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "commandDispatcher.Dispatch")
+	defer span.Finish()
+	span.LogFields(log.String("CommandType", sagaCommand.CommandType), log.String("AggregateID", sagaCommand.AggregateID))
+
+	handled, err := d.isHandled(ctx, sagaCommand)
+	if err != nil {
+		tracing.TraceErr(span, err)
+		return err
+	}
+	if handled {
+		d.log.Infof("(commandDispatcher) command: {%s} already handled, commandType: {%s}", sagaCommand.ID, sagaCommand.CommandType)
+		return nil
+	}
+	ctx = es.ContextWithCommandID(ctx, sagaCommand.ID)
+
+	switch sagaCommand.CommandType {
+	case ReserveStockCommandType:
+		var command commands.ReserveStockCommand
+		if err := sagaCommand.GetJsonData(&command); err != nil {
+			return errors.Wrap(err, "GetJsonData")
+		}
+		err = d.inventoryService.Commands.ReserveStock.Handle(ctx, &command)
+
+	case ReleaseStockCommandType:
+		var command commands.ReleaseStockCommand
+		if err := sagaCommand.GetJsonData(&command); err != nil {
+			return errors.Wrap(err, "GetJsonData")
+		}
+		err = d.inventoryService.Commands.ReleaseStock.Handle(ctx, &command)
+
+	case CommitStockCommandType:
+		var command commands.CommitStockCommand
+		if err := sagaCommand.GetJsonData(&command); err != nil {
+			return errors.Wrap(err, "GetJsonData")
+		}
+		err = d.inventoryService.Commands.CommitStock.Handle(ctx, &command)
+
+	case RejectOrderCommandType:
+		var command commands.RejectOrderCommand
+		if err := sagaCommand.GetJsonData(&command); err != nil {
+			return errors.Wrap(err, "GetJsonData")
+		}
+		// the order may have been canceled or completed in the meantime
+		err = d.orderService.Commands.RejectOrder.Handle(ctx, &command)
+		if errors.Is(err, aggregate.ErrInvalidStatusTransition) {
+			d.log.Infof("(commandDispatcher) order: {%s} not rejected, err: {%v}", command.GetAggregateID(), err)
+			err = nil
+		}
+
+	case CancelOrderCommandType:
+		var command commands.CancelOrderCommand
+		if err := sagaCommand.GetJsonData(&command); err != nil {
+			return errors.Wrap(err, "GetJsonData")
+		}
+		err = d.orderService.Commands.CancelOrder.Handle(ctx, &command)
+		if errors.Is(err, aggregate.ErrAlreadyPaid) || errors.Is(err, aggregate.ErrInvalidStatusTransition) {
+			d.log.Infof("(commandDispatcher) order: {%s} not canceled, err: {%v}", command.GetAggregateID(), err)
+			err = nil
+		}
+
+	case RemindPaymentCommandType:
+		var command commands.RemindPaymentCommand
+		if err := sagaCommand.GetJsonData(&command); err != nil {
+			return errors.Wrap(err, "GetJsonData")
+		}
+		err = d.orderService.Commands.RemindPayment.Handle(ctx, &command)
+		if errors.Is(err, aggregate.ErrInvalidStatusTransition) {
+			err = nil
+		}
+
+	default:
+		err = errors.Wrapf(ErrUnknownCommandType, "commandType: {%s}", sagaCommand.CommandType)
+	}
+
+	if err != nil {
+		tracing.TraceErr(span, err)
+		return err
+	}
+	return nil
+}
+
+// isHandled loads the aggregate the command targets and looks for the events the command raised.
+func (d *commandDispatcher) isHandled(ctx context.Context, sagaCommand es.SagaCommand) (bool, error) {
+	var target es.Aggregate
+	switch sagaCommand.CommandType {
+	case ReserveStockCommandType, ReleaseStockCommandType, CommitStockCommandType:
+		target = aggregate.NewInventoryAggregateWithID(sagaCommand.AggregateID)
+	case RejectOrderCommandType, CancelOrderCommandType, RemindPaymentCommandType:
+		target = aggregate.NewOrderAggregateWithID(sagaCommand.AggregateID)
+	default:
+		return false, nil
+	}
+	target.SetTenant(es.TenantFromContext(ctx))
+
+	err := d.es.Exists(ctx, target.GetID())
+	if errors.Is(err, esdb.ErrStreamNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	if err := d.es.Load(ctx, target); err != nil {
+		return false, err
+	}
+	return target.HandledCommand(sagaCommand.ID), nil
+}
@@ -0,0 +1,81 @@
+package sagas_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/EventStore/EventStore-Client-Go/esdb"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wassef911/eventually/internal/delivery/aggregate"
+	"github.com/wassef911/eventually/internal/delivery/commands"
+	"github.com/wassef911/eventually/internal/delivery/models"
+	"github.com/wassef911/eventually/internal/delivery/sagas"
+	service "github.com/wassef911/eventually/internal/delivery/services"
+	"github.com/wassef911/eventually/internal/infrastructure/es"
+	"github.com/wassef911/eventually/pkg/config"
+	"github.com/wassef911/eventually/pkg/logger"
+)
+
+// memoryStore records the command id of the context in the saved events, like the event store.
+type memoryStore struct {
+	streams map[string][]es.Event
+}
+
+func (m *memoryStore) Load(ctx context.Context, aggregate es.Aggregate) error {
+	for _, event := range m.streams[aggregate.GetID()] {
+		if err := aggregate.RaiseEvent(event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *memoryStore) Save(ctx context.Context, aggregate es.Aggregate) error {
+	commandID, hasCommandID := es.CommandIDFromContext(ctx)
+	for _, event := range aggregate.GetUncommittedEvents() {
+		if hasCommandID {
+			if err := event.SetCommandID(commandID); err != nil {
+				return err
+			}
+		}
+		m.streams[aggregate.GetID()] = append(m.streams[aggregate.GetID()], event)
+	}
+	aggregate.ClearUncommittedEvents()
+	return nil
+}
+
+func (m *memoryStore) Exists(ctx context.Context, streamID string) error {
+	if _, ok := m.streams[streamID]; !ok {
+		return errors.Wrap(esdb.ErrStreamNotFound, "Exists")
+	}
+	return nil
+}
+
+func TestCommandDispatcherSkipsHandledCommand(t *testing.T) {
+	ctx := context.Background()
+	appLogger := logger.NewAppLogger(&logger.Config{LogLevel: "fatal"})
+	appLogger.InitLogger()
+	cfg := &config.Config{Logger: &logger.Config{}}
+	store := &memoryStore{streams: make(map[string][]es.Event)}
+
+	order := aggregate.NewOrderAggregateWithID("order1")
+	shopItems := []*models.ShopItem{{ID: "item1", Title: "Item 1", Quantity: 1, Price: models.NewMoney(1000, "USD")}}
+	require.NoError(t, order.CreateOrder(ctx, shopItems, "", "jane@example.com", models.Address{Recipient: "Jane Doe", Line1: "1 Main St", City: "Springfield", PostalCode: "62701", Region: "IL", Country: "US"}, 0))
+	require.NoError(t, store.Save(es.ContextWithCommandID(ctx, "command1"), order))
+
+	orderService := &service.OrderService{Commands: &commands.OrderCommand{CancelOrder: *commands.NewCancelOrderCommandHandler(appLogger, cfg, store)}}
+	dispatcher := sagas.NewCommandDispatcher(appLogger, store, orderService, nil)
+
+	// without a reason the order cannot be canceled, only a command that was not handled yet reaches the order
+	handled, err := es.NewSagaCommand(sagas.CancelOrderCommandType, commands.NewCancelOrderCommand("order1", ""))
+	require.NoError(t, err)
+	handled.ID = "command1"
+	assert.NoError(t, dispatcher.Dispatch(ctx, handled))
+
+	other, err := es.NewSagaCommand(sagas.CancelOrderCommandType, commands.NewCancelOrderCommand("order1", ""))
+	require.NoError(t, err)
+	assert.ErrorIs(t, dispatcher.Dispatch(ctx, other), aggregate.ErrCancelReasonRequired)
+}
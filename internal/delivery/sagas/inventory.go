@@ -0,0 +1,227 @@
+package sagas
+
+import (
+	"context"
+	"sort"
+
+	"github.com/opentracing/opentracing-go/log"
+	"github.com/pkg/errors"
+
+	"github.com/wassef911/eventually/internal/delivery/aggregate"
+	"github.com/wassef911/eventually/internal/delivery/commands"
+	"github.com/wassef911/eventually/internal/delivery/events"
+	"github.com/wassef911/eventually/internal/delivery/models"
+	"github.com/wassef911/eventually/internal/infrastructure/es"
+	"github.com/wassef911/eventually/internal/infrastructure/tracing"
+)
+
+const (
+	InventorySagaName      = "inventory_saga"
+	OutOfStockRejectReason = "out of stock"
+)
+
+// inventorySaga reserves stock for the items of every open order, releases it when the order
+// is canceled or rejected and commits it once the order is completed.
+// Orders asking for more than the available stock are rejected.
+// The cart is tracked from the order events, stock commands are absolute so they can be dispatched again.
+type inventorySaga struct{}
+
+var _ es.Saga[models.OrderReservation] = &inventorySaga{}
+
+func NewInventorySaga() *inventorySaga {
+	return &inventorySaga{}
+}
+
+func (s *inventorySaga) CorrelationID(evt es.Event) string {
+	switch evt.GetEventType() {
+	case events.OrderCreated, events.ShoppingCartUpdated, events.ShopItemAdded, events.ShopItemRemoved, events.ShopItemQuantityChanged,
+		events.OrderCanceled, events.OrderRejected, events.OrderCompleted:
+		return aggregate.GetOrderAggregateID(evt.GetAggregateID())
+
+	case events.StockReservationRejected:
+		var eventData events.StockReservationRejectedEvent
+		if err := evt.GetJsonData(&eventData); err != nil {
+			return ""
+		}
+		return eventData.OrderID
+
+	default:
+		return ""
+	}
+}
+
+func (s *inventorySaga) Handle(ctx context.Context, state *es.SagaState[models.OrderReservation], evt es.Event) ([]es.SagaCommand, error) {
+	ctx, span := tracing.StartProjectionTracerSpan(ctx, "inventorySaga.Handle", evt)
+	defer span.Finish()
+	span.LogFields(log.String("OrderID", state.CorrelationID), log.String("EventType", evt.GetEventType()))
+
+	orderID := state.CorrelationID
+	if state.Data.Items == nil {
+		state.Data.Items = make(map[string]uint64)
+		state.Data.Status = models.ReservationReserved
+	}
+
+	switch evt.GetEventType() {
+	case events.OrderCreated:
+		var eventData events.OrderCreatedEvent
+		if err := evt.GetJsonData(&eventData); err != nil {
+			tracing.TraceErr(span, err)
+			return nil, errors.Wrap(err, "evt.GetJsonData")
+		}
+		return s.updateCart(state, aggregate.GetStockItems(eventData.ShopItems))
+
+	case events.ShoppingCartUpdated:
+		var eventData events.ShoppingCartUpdatedEvent
+		if err := evt.GetJsonData(&eventData); err != nil {
+			tracing.TraceErr(span, err)
+			return nil, errors.Wrap(err, "evt.GetJsonData")
+		}
+		return s.updateCart(state, aggregate.GetStockItems(eventData.ShopItems))
+
+	case events.ShopItemAdded:
+		var eventData events.ShopItemAddedEvent
+		if err := evt.GetJsonData(&eventData); err != nil {
+			tracing.TraceErr(span, err)
+			return nil, errors.Wrap(err, "evt.GetJsonData")
+		}
+		items := copyItems(state.Data.Items)
+		items[eventData.ShopItem.ID] += eventData.ShopItem.Quantity
+		return s.updateCart(state, items)
+
+	case events.ShopItemRemoved:
+		var eventData events.ShopItemRemovedEvent
+		if err := evt.GetJsonData(&eventData); err != nil {
+			tracing.TraceErr(span, err)
+			return nil, errors.Wrap(err, "evt.GetJsonData")
+		}
+		items := copyItems(state.Data.Items)
+		delete(items, eventData.ShopItemID)
+		return s.updateCart(state, items)
+
+	case events.ShopItemQuantityChanged:
+		var eventData events.ShopItemQuantityChangedEvent
+		if err := evt.GetJsonData(&eventData); err != nil {
+			tracing.TraceErr(span, err)
+			return nil, errors.Wrap(err, "evt.GetJsonData")
+		}
+		items := copyItems(state.Data.Items)
+		items[eventData.ShopItemID] = eventData.Quantity
+		return s.updateCart(state, items)
+
+	case events.StockReservationRejected:
+		releaseCommands, err := s.release(state, OutOfStockRejectReason)
+		if err != nil {
+			return nil, err
+		}
+		rejectCommand, err := es.NewSagaCommand(RejectOrderCommandType, commands.NewRejectOrderCommand(orderID, OutOfStockRejectReason))
+		if err != nil {
+			return nil, err
+		}
+		return append(releaseCommands, rejectCommand), nil
+
+	case events.OrderCanceled:
+		var eventData events.OrderCanceledEvent
+		if err := evt.GetJsonData(&eventData); err != nil {
+			tracing.TraceErr(span, err)
+			return nil, errors.Wrap(err, "evt.GetJsonData")
+		}
+		return s.release(state, eventData.CancelReason)
+
+	case events.OrderRejected:
+		var eventData events.OrderRejectedEvent
+		if err := evt.GetJsonData(&eventData); err != nil {
+			tracing.TraceErr(span, err)
+			return nil, errors.Wrap(err, "evt.GetJsonData")
+		}
+		return s.release(state, eventData.RejectReason)
+
+	case events.OrderCompleted:
+		return s.commit(state)
+
+	default:
+		return nil, nil
+	}
+}
+
+// Timeout the inventory saga sets no deadline.
+func (s *inventorySaga) Timeout(ctx context.Context, state *es.SagaState[models.OrderReservation]) ([]es.SagaCommand, error) {
+	return nil, nil
+}
+
+// updateCart reserves the SKUs whose quantity changed and releases the SKUs that left the cart.
+func (s *inventorySaga) updateCart(state *es.SagaState[models.OrderReservation], items map[string]uint64) ([]es.SagaCommand, error) {
+	sagaCommands := make([]es.SagaCommand, 0, len(items))
+	for _, sku := range sortedSKUs(items) {
+		if quantity, ok := state.Data.Items[sku]; ok && quantity == items[sku] {
+			continue
+		}
+		command, err := es.NewSagaCommand(ReserveStockCommandType, commands.NewReserveStockCommand(sku, state.CorrelationID, items[sku]))
+		if err != nil {
+			return nil, err
+		}
+		sagaCommands = append(sagaCommands, command)
+	}
+
+	for _, sku := range sortedSKUs(state.Data.Items) {
+		if _, ok := items[sku]; ok {
+			continue
+		}
+		command, err := es.NewSagaCommand(ReleaseStockCommandType, commands.NewReleaseStockCommand(sku, state.CorrelationID))
+		if err != nil {
+			return nil, err
+		}
+		sagaCommands = append(sagaCommands, command)
+	}
+
+	state.Data.Items = items
+	return sagaCommands, nil
+}
+
+func (s *inventorySaga) release(state *es.SagaState[models.OrderReservation], reason string) ([]es.SagaCommand, error) {
+	sagaCommands := make([]es.SagaCommand, 0, len(state.Data.Items))
+	for _, sku := range sortedSKUs(state.Data.Items) {
+		command, err := es.NewSagaCommand(ReleaseStockCommandType, commands.NewReleaseStockCommand(sku, state.CorrelationID))
+		if err != nil {
+			return nil, err
+		}
+		sagaCommands = append(sagaCommands, command)
+	}
+
+	state.Data.Status = models.ReservationReleased
+	state.Data.ReleaseReason = reason
+	state.Completed = true
+	return sagaCommands, nil
+}
+
+func (s *inventorySaga) commit(state *es.SagaState[models.OrderReservation]) ([]es.SagaCommand, error) {
+	sagaCommands := make([]es.SagaCommand, 0, len(state.Data.Items))
+	for _, sku := range sortedSKUs(state.Data.Items) {
+		command, err := es.NewSagaCommand(CommitStockCommandType, commands.NewCommitStockCommand(sku, state.CorrelationID))
+		if err != nil {
+			return nil, err
+		}
+		sagaCommands = append(sagaCommands, command)
+	}
+
+	state.Data.Status = models.ReservationCommitted
+	state.Completed = true
+	return sagaCommands, nil
+}
+
+func copyItems(items map[string]uint64) map[string]uint64 {
+	copied := make(map[string]uint64, len(items))
+	for sku, quantity := range items {
+		copied[sku] = quantity
+	}
+	return copied
+}
+
+// sortedSKUs a stable order makes a step decide the same commands every time.
+func sortedSKUs(items map[string]uint64) []string {
+	skus := make([]string, 0, len(items))
+	for sku := range items {
+		skus = append(skus, sku)
+	}
+	sort.Strings(skus)
+	return skus
+}
@@ -0,0 +1,56 @@
+package sagas_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wassef911/eventually/internal/delivery/aggregate"
+	"github.com/wassef911/eventually/internal/delivery/events"
+	"github.com/wassef911/eventually/internal/delivery/models"
+	"github.com/wassef911/eventually/internal/delivery/sagas"
+	"github.com/wassef911/eventually/internal/infrastructure/es"
+)
+
+func commandTypes(commands []es.SagaCommand) []string {
+	types := make([]string, 0, len(commands))
+	for _, command := range commands {
+		types = append(types, command.CommandType+" "+command.AggregateID)
+	}
+	return types
+}
+
+func TestInventorySagaRejectsOutOfStockOrder(t *testing.T) {
+	ctx := context.Background()
+	saga := sagas.NewInventorySaga()
+	order := aggregate.NewOrderAggregateWithID("order1")
+	inventory := aggregate.NewInventoryAggregateWithID("item2")
+
+	created, err := events.NewOrderCreatedEvent(order, []*models.ShopItem{{ID: "item1", Quantity: 1}, {ID: "item2", Quantity: 2}}, "", "", models.Address{}, models.OrderPricing{})
+	require.NoError(t, err)
+	require.Equal(t, "order1", saga.CorrelationID(created))
+
+	state := &es.SagaState[models.OrderReservation]{CorrelationID: "order1"}
+	commands, err := saga.Handle(ctx, state, created)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"RESERVE_STOCK item1", "RESERVE_STOCK item2"}, commandTypes(commands))
+
+	removed, err := events.NewShopItemRemovedEvent(order, "item1", models.OrderPricing{})
+	require.NoError(t, err)
+	commands, err = saga.Handle(ctx, state, removed)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"RELEASE_STOCK item1"}, commandTypes(commands))
+
+	rejected, err := events.NewStockReservationRejectedEvent(inventory, "order1", 2, 1)
+	require.NoError(t, err)
+	require.Equal(t, "order1", saga.CorrelationID(rejected))
+
+	commands, err = saga.Handle(ctx, state, rejected)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"RELEASE_STOCK item2", "REJECT_ORDER order1"}, commandTypes(commands))
+	assert.True(t, state.Completed)
+	assert.Equal(t, models.ReservationReleased, state.Data.Status)
+	assert.Equal(t, sagas.OutOfStockRejectReason, state.Data.ReleaseReason)
+}
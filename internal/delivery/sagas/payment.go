@@ -0,0 +1,162 @@
+package sagas
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/log"
+	"github.com/pkg/errors"
+
+	"github.com/wassef911/eventually/internal/delivery/aggregate"
+	"github.com/wassef911/eventually/internal/delivery/commands"
+	"github.com/wassef911/eventually/internal/delivery/events"
+	"github.com/wassef911/eventually/internal/delivery/models"
+	"github.com/wassef911/eventually/internal/infrastructure/es"
+	"github.com/wassef911/eventually/internal/infrastructure/tracing"
+)
+
+const (
+	PaymentSagaName            = "payment_saga"
+	PaymentTimeoutCancelReason = "payment timeout"
+
+	// PaymentAttemptGrace the expiry is pushed back by it while a payment attempt is open.
+	PaymentAttemptGrace = 15 * time.Minute
+)
+
+// paymentSaga cancels orders that are not paid within the payment timeout and reminds the customer
+// every reminder interval until then. The saga deadline is the next reminder or the expiry.
+// An order is not canceled while the provider may still capture one of its payment attempts.
+type paymentSaga struct {
+	clock            es.Clock
+	timeout          time.Duration
+	reminderInterval time.Duration
+}
+
+var _ es.Saga[models.PaymentDeadline] = &paymentSaga{}
+
+func NewPaymentSaga(clock es.Clock, timeout time.Duration, reminderInterval time.Duration) *paymentSaga {
+	return &paymentSaga{clock: clock, timeout: timeout, reminderInterval: reminderInterval}
+}
+
+func (s *paymentSaga) CorrelationID(evt es.Event) string {
+	switch evt.GetEventType() {
+	case events.OrderCreated, events.OrderPaid, events.OrderCanceled, events.OrderRejected,
+		events.PaymentAttempted, events.PaymentFailed:
+		return aggregate.GetOrderAggregateID(evt.GetAggregateID())
+	default:
+		return ""
+	}
+}
+
+func (s *paymentSaga) Handle(ctx context.Context, state *es.SagaState[models.PaymentDeadline], evt es.Event) ([]es.SagaCommand, error) {
+	_, span := tracing.StartProjectionTracerSpan(ctx, "paymentSaga.Handle", evt)
+	defer span.Finish()
+	span.LogFields(log.String("OrderID", state.CorrelationID), log.String("EventType", evt.GetEventType()))
+
+	switch evt.GetEventType() {
+	case events.OrderCreated:
+		createdAt := evt.GetTimeStamp()
+		if createdAt.IsZero() {
+			createdAt = s.clock.Now()
+		}
+		state.Data.CreatedAt = createdAt
+		state.Data.ExpiresAt = createdAt.Add(s.timeout)
+		state.Deadline = s.nextDeadline(state.Data)
+		return nil, nil
+
+	case events.PaymentAttempted:
+		var eventData events.PaymentAttemptedEvent
+		if err := evt.GetJsonData(&eventData); err != nil {
+			tracing.TraceErr(span, err)
+			return nil, errors.Wrap(err, "GetJsonData")
+		}
+		if !slices.Contains(state.Data.OpenAttempts, eventData.AttemptID) {
+			state.Data.OpenAttempts = append(state.Data.OpenAttempts, eventData.AttemptID)
+		}
+		return nil, nil
+
+	case events.PaymentFailed:
+		var eventData events.PaymentFailedEvent
+		if err := evt.GetJsonData(&eventData); err != nil {
+			tracing.TraceErr(span, err)
+			return nil, errors.Wrap(err, "GetJsonData")
+		}
+		state.Data.OpenAttempts = slices.DeleteFunc(state.Data.OpenAttempts, func(attemptID string) bool {
+			return attemptID == eventData.AttemptID
+		})
+		return nil, nil
+
+	case events.OrderPaid, events.OrderCanceled, events.OrderRejected:
+		state.Completed = true
+		return nil, nil
+
+	default:
+		return nil, nil
+	}
+}
+
+// Timeout cancels the order once it expired, before that it sends the reminder that is due.
+// An expired order with an open payment attempt is checked again after PaymentAttemptGrace.
+func (s *paymentSaga) Timeout(ctx context.Context, state *es.SagaState[models.PaymentDeadline]) ([]es.SagaCommand, error) {
+	span, _ := opentracing.StartSpanFromContext(ctx, "paymentSaga.Timeout")
+	defer span.Finish()
+	span.LogFields(log.String("OrderID", state.CorrelationID))
+
+	now := s.clock.Now()
+	if !now.Before(state.Data.ExpiresAt) {
+		if len(state.Data.OpenAttempts) > 0 {
+			span.LogFields(log.Int("OpenAttempts", len(state.Data.OpenAttempts)))
+			state.Deadline = now.Add(PaymentAttemptGrace)
+			return nil, nil
+		}
+		command := commands.NewCancelOrderCommand(state.CorrelationID, PaymentTimeoutCancelReason)
+		command.UnpaidOnly = true
+		sagaCommand, err := es.NewSagaCommand(CancelOrderCommandType, command)
+		if err != nil {
+			return nil, err
+		}
+		state.Completed = true
+		return []es.SagaCommand{sagaCommand}, nil
+	}
+
+	// reminders missed while the service was down are sent as one
+	reminders := state.Data.Reminders
+	for !s.reminderAt(state.Data, reminders+1).After(now) {
+		reminders++
+	}
+	if reminders == state.Data.Reminders {
+		state.Deadline = s.nextDeadline(state.Data)
+		return nil, nil
+	}
+
+	state.Data.Reminders = reminders
+	sagaCommand, err := es.NewSagaCommand(RemindPaymentCommandType, commands.NewRemindPaymentCommand(state.CorrelationID, state.Data.Reminders, state.Data.ExpiresAt))
+	if err != nil {
+		return nil, err
+	}
+	state.Deadline = s.nextDeadline(state.Data)
+	return []es.SagaCommand{sagaCommand}, nil
+}
+
+// nextDeadline the next reminder when it is due before the expiry, otherwise the expiry.
+// A zero timeout keeps unpaid orders open.
+func (s *paymentSaga) nextDeadline(data models.PaymentDeadline) time.Time {
+	if s.timeout <= 0 {
+		return time.Time{}
+	}
+	if s.reminderInterval > 0 {
+		if reminder := s.reminderAt(data, data.Reminders+1); reminder.Before(data.ExpiresAt) {
+			return reminder
+		}
+	}
+	return data.ExpiresAt
+}
+
+func (s *paymentSaga) reminderAt(data models.PaymentDeadline, reminder int) time.Time {
+	if s.reminderInterval <= 0 {
+		return data.ExpiresAt
+	}
+	return data.CreatedAt.Add(time.Duration(reminder) * s.reminderInterval)
+}
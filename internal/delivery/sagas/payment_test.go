@@ -0,0 +1,124 @@
+package sagas_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wassef911/eventually/internal/delivery/aggregate"
+	"github.com/wassef911/eventually/internal/delivery/commands"
+	"github.com/wassef911/eventually/internal/delivery/events"
+	"github.com/wassef911/eventually/internal/delivery/models"
+	"github.com/wassef911/eventually/internal/delivery/sagas"
+	"github.com/wassef911/eventually/internal/infrastructure/es"
+)
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func TestPaymentSagaRemindsAndCancelsUnpaidOrder(t *testing.T) {
+	ctx := context.Background()
+	createdAt := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	clock := &fakeClock{now: createdAt}
+	saga := sagas.NewPaymentSaga(clock, 24*time.Hour, 8*time.Hour)
+	order := aggregate.NewOrderAggregateWithID("order1")
+
+	created, err := events.NewOrderCreatedEvent(order, []*models.ShopItem{{ID: "item1", Quantity: 1}}, "", "", models.Address{}, models.OrderPricing{})
+	require.NoError(t, err)
+	created.Timestamp = createdAt
+	require.Equal(t, "order1", saga.CorrelationID(created))
+
+	state := &es.SagaState[models.PaymentDeadline]{CorrelationID: "order1"}
+	sagaCommands, err := saga.Handle(ctx, state, created)
+	require.NoError(t, err)
+	assert.Empty(t, sagaCommands)
+	assert.Equal(t, createdAt.Add(8*time.Hour), state.Deadline)
+
+	clock.now = createdAt.Add(8 * time.Hour)
+	sagaCommands, err = saga.Timeout(ctx, state)
+	require.NoError(t, err)
+	require.Len(t, sagaCommands, 1)
+	assert.Equal(t, sagas.RemindPaymentCommandType, sagaCommands[0].CommandType)
+	var reminder commands.RemindPaymentCommand
+	require.NoError(t, sagaCommands[0].GetJsonData(&reminder))
+	assert.Equal(t, 1, reminder.Reminder)
+	assert.Equal(t, createdAt.Add(16*time.Hour), state.Deadline)
+
+	clock.now = createdAt.Add(25 * time.Hour)
+	sagaCommands, err = saga.Timeout(ctx, state)
+	require.NoError(t, err)
+	require.Len(t, sagaCommands, 1)
+	assert.Equal(t, sagas.CancelOrderCommandType, sagaCommands[0].CommandType)
+	var cancel commands.CancelOrderCommand
+	require.NoError(t, sagaCommands[0].GetJsonData(&cancel))
+	assert.Equal(t, sagas.PaymentTimeoutCancelReason, cancel.CancelReason)
+	assert.True(t, cancel.UnpaidOnly)
+	assert.True(t, state.Completed)
+}
+
+func TestPaymentSagaCompletesOnPayment(t *testing.T) {
+	ctx := context.Background()
+	saga := sagas.NewPaymentSaga(&fakeClock{now: time.Now()}, time.Hour, 0)
+	order := aggregate.NewOrderAggregateWithID("order1")
+
+	created, err := events.NewOrderCreatedEvent(order, []*models.ShopItem{{ID: "item1", Quantity: 1}}, "", "", models.Address{}, models.OrderPricing{})
+	require.NoError(t, err)
+	state := &es.SagaState[models.PaymentDeadline]{CorrelationID: "order1"}
+	_, err = saga.Handle(ctx, state, created)
+	require.NoError(t, err)
+	assert.True(t, created.Timestamp.Add(time.Hour).Equal(state.Deadline), "without reminders the deadline is the expiry")
+
+	paid, err := events.NewOrderPaidEvent(order, &models.Payment{PaymentID: "payment1", Timestamp: time.Now()})
+	require.NoError(t, err)
+	_, err = saga.Handle(ctx, state, paid)
+	require.NoError(t, err)
+	assert.True(t, state.Completed)
+}
+
+func TestPaymentSagaWaitsForOpenPaymentAttempt(t *testing.T) {
+	ctx := context.Background()
+	createdAt := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	clock := &fakeClock{now: createdAt}
+	saga := sagas.NewPaymentSaga(clock, time.Hour, 0)
+	order := aggregate.NewOrderAggregateWithID("order1")
+
+	created, err := events.NewOrderCreatedEvent(order, []*models.ShopItem{{ID: "item1", Quantity: 1}}, "", "", models.Address{}, models.OrderPricing{})
+	require.NoError(t, err)
+	created.Timestamp = createdAt
+	state := &es.SagaState[models.PaymentDeadline]{CorrelationID: "order1"}
+	_, err = saga.Handle(ctx, state, created)
+	require.NoError(t, err)
+
+	attempted, err := events.NewPaymentAttemptedEvent(order, "attempt1", "fake", models.NewMoney(1000, "USD"), createdAt)
+	require.NoError(t, err)
+	require.Equal(t, "order1", saga.CorrelationID(attempted))
+	_, err = saga.Handle(ctx, state, attempted)
+	require.NoError(t, err)
+
+	clock.now = createdAt.Add(2 * time.Hour)
+	sagaCommands, err := saga.Timeout(ctx, state)
+	require.NoError(t, err)
+	assert.Empty(t, sagaCommands, "a pending payment is not canceled")
+	assert.False(t, state.Completed)
+	assert.Equal(t, clock.now.Add(sagas.PaymentAttemptGrace), state.Deadline)
+
+	failed, err := events.NewPaymentFailedEvent(order, "attempt1", "declined")
+	require.NoError(t, err)
+	_, err = saga.Handle(ctx, state, failed)
+	require.NoError(t, err)
+
+	clock.now = state.Deadline
+	sagaCommands, err = saga.Timeout(ctx, state)
+	require.NoError(t, err)
+	require.Len(t, sagaCommands, 1)
+	assert.Equal(t, sagas.CancelOrderCommandType, sagaCommands[0].CommandType)
+	assert.True(t, state.Completed)
+}
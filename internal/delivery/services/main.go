@@ -1,7 +1,9 @@
 package service
 
 import (
+	"github.com/wassef911/eventually/internal/delivery/aggregate"
 	"github.com/wassef911/eventually/internal/delivery/commands"
+	"github.com/wassef911/eventually/internal/delivery/payment"
 	"github.com/wassef911/eventually/internal/delivery/queries"
 	"github.com/wassef911/eventually/internal/delivery/repository"
 	"github.com/wassef911/eventually/internal/infrastructure/es/store"
@@ -18,31 +20,154 @@ func New(
 	log logger.Logger,
 	config *config.Config,
 	es store.AggregateStore,
+	eventStore store.EventStore,
 	mongoRepo repository.OrderMongoRepository,
 	elasticRepo repository.ElasticOrderRepository,
+	couponRepo repository.CouponRepository,
+	taxCalculator aggregate.TaxCalculator,
+	gateway payment.Gateway,
 ) *OrderService {
 
-	createOrderHandler := commands.NewCreateOrderHandler(log, config, es)
-	orderPaidHandler := commands.NewOrderPaidHandler(log, config, es)
+	createOrderHandler := commands.NewCreateOrderHandler(log, config, es, taxCalculator)
+	orderPaidHandler := commands.NewOrderPaidHandler(log, config, es, gateway)
+	paymentWebhookHandler := commands.NewPaymentWebhookCommandHandler(log, config, es, gateway)
 	submitOrderHandler := commands.NewSubmitOrderHandler(log, config, es)
-	updateOrderCmdHandler := commands.NewupdateShoppingCartCommandHandler(log, config, es)
+	updateOrderCmdHandler := commands.NewupdateShoppingCartCommandHandler(log, config, es, taxCalculator)
 	cancelOrderCommandHandler := commands.NewCancelOrderCommandHandler(log, config, es)
+	rejectOrderCommandHandler := commands.NewRejectOrderCommandHandler(log, config, es)
+	remindPaymentCommandHandler := commands.NewRemindPaymentCommandHandler(log, config, es)
 	deliveryOrderCommandHandler := commands.NewCompleteOrderCommandHandler(log, config, es)
-	changeOrderDeliveryAddressCmdHandler := commands.NewchangeDeliveryAddressCommandHandler(log, config, es)
+	changeOrderDeliveryAddressCmdHandler := commands.NewchangeDeliveryAddressCommandHandler(log, config, es, taxCalculator)
+	refundOrderCommandHandler := commands.NewRefundOrderCommandHandler(log, config, es, gateway)
+	requestReturnCommandHandler := commands.NewRequestReturnCommandHandler(log, config, es)
+	approveReturnCommandHandler := commands.NewApproveReturnCommandHandler(log, config, es)
+	rejectReturnCommandHandler := commands.NewRejectReturnCommandHandler(log, config, es)
+	receiveReturnCommandHandler := commands.NewReceiveReturnCommandHandler(log, config, es, gateway)
+	createShipmentCommandHandler := commands.NewCreateShipmentCommandHandler(log, config, es)
+	packShipmentCommandHandler := commands.NewPackShipmentCommandHandler(log, config, es)
+	dispatchShipmentCommandHandler := commands.NewDispatchShipmentCommandHandler(log, config, es)
+	deliverShipmentCommandHandler := commands.NewDeliverShipmentCommandHandler(log, config, es)
+	addItemCommandHandler := commands.NewAddItemCommandHandler(log, config, es, taxCalculator)
+	removeItemCommandHandler := commands.NewRemoveItemCommandHandler(log, config, es, taxCalculator)
+	changeItemQuantityCommandHandler := commands.NewChangeItemQuantityCommandHandler(log, config, es, taxCalculator)
+	applyCouponCommandHandler := commands.NewApplyCouponCommandHandler(log, config, es, couponRepo, taxCalculator)
+	removeCouponCommandHandler := commands.NewRemoveCouponCommandHandler(log, config, es, taxCalculator)
 
 	getOrderByIDHandler := queries.NewGetOrderByIDHandler(log, config, es, mongoRepo)
 	searchOrdersHandler := queries.NewSearchOrdersHandler(log, config, es, elasticRepo)
+	listOrdersHandler := queries.NewListOrdersHandler(log, config, mongoRepo)
+	getOrderEventsHandler := queries.NewGetOrderEventsHandler(log, config, eventStore)
 
 	orderCommands := commands.New(
 		*createOrderHandler,
 		*orderPaidHandler,
+		*paymentWebhookHandler,
 		*submitOrderHandler,
 		*updateOrderCmdHandler,
 		*cancelOrderCommandHandler,
+		*rejectOrderCommandHandler,
+		*remindPaymentCommandHandler,
 		*deliveryOrderCommandHandler,
 		*changeOrderDeliveryAddressCmdHandler,
+		*refundOrderCommandHandler,
+		*requestReturnCommandHandler,
+		*approveReturnCommandHandler,
+		*rejectReturnCommandHandler,
+		*receiveReturnCommandHandler,
+		*createShipmentCommandHandler,
+		*packShipmentCommandHandler,
+		*dispatchShipmentCommandHandler,
+		*deliverShipmentCommandHandler,
+		*addItemCommandHandler,
+		*removeItemCommandHandler,
+		*changeItemQuantityCommandHandler,
+		*applyCouponCommandHandler,
+		*removeCouponCommandHandler,
 	)
-	orderQueries := queries.NewOrderQueries(getOrderByIDHandler, searchOrdersHandler)
+	orderQueries := queries.NewOrderQueries(getOrderByIDHandler, searchOrdersHandler, listOrdersHandler, getOrderEventsHandler)
 
 	return &OrderService{Commands: orderCommands, Queries: orderQueries}
 }
+
+type InventoryService struct {
+	Commands *commands.InventoryCommand
+	Queries  *queries.InventoryQueries
+}
+
+func NewInventoryService(log logger.Logger, config *config.Config, es store.AggregateStore) *InventoryService {
+
+	addStockHandler := commands.NewAddStockCommandHandler(log, config, es)
+	reserveStockHandler := commands.NewReserveStockCommandHandler(log, config, es)
+	releaseStockHandler := commands.NewReleaseStockCommandHandler(log, config, es)
+	commitStockHandler := commands.NewCommitStockCommandHandler(log, config, es)
+
+	getInventoryBySKUHandler := queries.NewGetInventoryBySKUHandler(log, config, es)
+
+	inventoryCommands := commands.NewInventoryCommand(
+		*addStockHandler,
+		*reserveStockHandler,
+		*releaseStockHandler,
+		*commitStockHandler,
+	)
+	inventoryQueries := queries.NewInventoryQueries(getInventoryBySKUHandler)
+
+	return &InventoryService{Commands: inventoryCommands, Queries: inventoryQueries}
+}
+
+type CustomerService struct {
+	Commands *commands.CustomerCommand
+	Queries  *queries.CustomerQueries
+}
+
+func NewCustomerService(
+	log logger.Logger,
+	config *config.Config,
+	es store.AggregateStore,
+	customerRepo repository.CustomerRepository,
+	mongoRepo repository.OrderMongoRepository,
+) *CustomerService {
+
+	registerCustomerHandler := commands.NewRegisterCustomerCommandHandler(log, config, es)
+	changeCustomerEmailHandler := commands.NewChangeCustomerEmailCommandHandler(log, config, es)
+	saveCustomerAddressHandler := commands.NewSaveCustomerAddressCommandHandler(log, config, es)
+	removeCustomerAddressHandler := commands.NewRemoveCustomerAddressCommandHandler(log, config, es)
+	updateCustomerPreferencesHandler := commands.NewUpdateCustomerPreferencesCommandHandler(log, config, es)
+
+	getCustomerByIDHandler := queries.NewGetCustomerByIDHandler(log, config, es, customerRepo)
+	getCustomerOrdersHandler := queries.NewGetCustomerOrdersHandler(log, config, es, customerRepo, mongoRepo)
+
+	customerCommands := commands.NewCustomerCommand(
+		*registerCustomerHandler,
+		*changeCustomerEmailHandler,
+		*saveCustomerAddressHandler,
+		*removeCustomerAddressHandler,
+		*updateCustomerPreferencesHandler,
+	)
+	customerQueries := queries.NewCustomerQueries(getCustomerByIDHandler, getCustomerOrdersHandler)
+
+	return &CustomerService{Commands: customerCommands, Queries: customerQueries}
+}
+
+type ProductService struct {
+	Commands *commands.ProductCommand
+	Queries  *queries.ProductQueries
+}
+
+func NewProductService(log logger.Logger, config *config.Config, es store.AggregateStore, productRepo repository.ProductRepository) *ProductService {
+
+	createProductHandler := commands.NewCreateProductCommandHandler(log, config, es)
+	repriceProductHandler := commands.NewRepriceProductCommandHandler(log, config, es)
+	discontinueProductHandler := commands.NewDiscontinueProductCommandHandler(log, config, es)
+
+	getProductByIDHandler := queries.NewGetProductByIDHandler(log, config, es, productRepo)
+	listProductsHandler := queries.NewListProductsHandler(log, config, es, productRepo)
+
+	productCommands := commands.NewProductCommand(
+		*createProductHandler,
+		*repriceProductHandler,
+		*discontinueProductHandler,
+	)
+	productQueries := queries.NewProductQueries(getProductByIDHandler, listProductsHandler)
+
+	return &ProductService{Commands: productCommands, Queries: productQueries}
+}
@@ -0,0 +1,87 @@
+package tax
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"strings"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/log"
+	"github.com/pkg/errors"
+
+	"github.com/wassef911/eventually/internal/delivery/aggregate"
+	"github.com/wassef911/eventually/internal/delivery/models"
+)
+
+const (
+	// AnyRegion matches delivery regions without their own rates.
+	AnyRegion = "*"
+
+	basisPoints = 10000
+)
+
+var _ aggregate.TaxCalculator = &TableCalculator{}
+
+// Rules maps a delivery region to tax rates per tax category, rates are in basis points.
+//
+//	{"regions": {"US": {"standard": 700, "food": 0}, "*": {"standard": 2000}}}
+type Rules struct {
+	Regions map[string]map[string]uint64 `json:"regions"`
+}
+
+// LoadRules reads table rules from a json file.
+func LoadRules(path string) (*Rules, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "os.ReadFile")
+	}
+
+	var rules Rules
+	if err := json.Unmarshal(data, &rules); err != nil {
+		return nil, errors.Wrap(err, "json.Unmarshal")
+	}
+	return &rules, nil
+}
+
+// TableCalculator looks up rates by region and tax category, unknown regions fall back
+// to AnyRegion and unknown categories are not taxed.
+type TableCalculator struct {
+	regions map[string]map[string]uint64
+}
+
+func NewTableCalculator(rules *Rules) *TableCalculator {
+	regions := make(map[string]map[string]uint64, len(rules.Regions))
+	for region, rates := range rules.Regions {
+		regions[strings.ToUpper(region)] = rates
+	}
+	return &TableCalculator{regions: regions}
+}
+
+func (t *TableCalculator) CalculateTax(ctx context.Context, region string, shopItems []*models.ShopItem) ([]*models.TaxLine, error) {
+	span, _ := opentracing.StartSpanFromContext(ctx, "TableCalculator.CalculateTax")
+	defer span.Finish()
+	span.LogFields(log.String("Region", region))
+
+	rates, ok := t.regions[strings.ToUpper(region)]
+	if !ok {
+		rates = t.regions[AnyRegion]
+	}
+
+	taxes := make([]*models.TaxLine, 0, len(shopItems))
+	for _, item := range shopItems {
+		category := item.TaxCategory
+		if category == "" {
+			category = models.DefaultTaxCategory
+		}
+		rate := rates[category]
+		lineTotal := item.Price.Multiply(item.Quantity)
+		taxes = append(taxes, &models.TaxLine{
+			ShopItemID:  item.ID,
+			TaxCategory: category,
+			Rate:        rate,
+			Amount:      models.NewMoney((lineTotal.Amount*int64(rate)+basisPoints/2)/basisPoints, lineTotal.Currency),
+		})
+	}
+	return taxes, nil
+}
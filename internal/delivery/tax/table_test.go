@@ -0,0 +1,73 @@
+package tax_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wassef911/eventually/internal/delivery/aggregate"
+	"github.com/wassef911/eventually/internal/delivery/models"
+	"github.com/wassef911/eventually/internal/delivery/tax"
+)
+
+func TestTableCalculator(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tax.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"regions": {"us": {"standard": 700, "food": 0}, "*": {"standard": 2000, "food": 550}}}`), 0o600))
+
+	rules, err := tax.LoadRules(path)
+	require.NoError(t, err)
+	calculator := tax.NewTableCalculator(rules)
+
+	shopItems := []*models.ShopItem{
+		{ID: "item1", Quantity: 3, Price: models.NewMoney(999, "USD")},
+		{ID: "item2", Quantity: 1, Price: models.NewMoney(1000, "USD"), TaxCategory: "food"},
+		{ID: "item3", Quantity: 1, Price: models.NewMoney(1000, "USD"), TaxCategory: "unknown"},
+	}
+
+	tests := []struct {
+		region  string
+		amounts []int64
+	}{
+		{region: "US", amounts: []int64{210, 0, 0}},
+		{region: "FR", amounts: []int64{599, 55, 0}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.region, func(t *testing.T) {
+			taxes, err := calculator.CalculateTax(context.Background(), tt.region, shopItems)
+			require.NoError(t, err)
+			require.Len(t, taxes, len(shopItems))
+			for i, amount := range tt.amounts {
+				assert.Equal(t, models.NewMoney(amount, "USD"), taxes[i].Amount)
+			}
+			assert.Equal(t, models.DefaultTaxCategory, taxes[0].TaxCategory)
+		})
+	}
+}
+
+func TestOrderAggregateTaxes(t *testing.T) {
+	ctx := context.Background()
+	order := aggregate.NewOrderAggregateWithID("order-taxes")
+	order.SetTaxCalculator(tax.NewTableCalculator(&tax.Rules{Regions: map[string]map[string]uint64{"US": {"standard": 1000}}}))
+
+	shopItems := []*models.ShopItem{{ID: "item1", Title: "Item 1", Quantity: 2, Price: models.NewMoney(1000, "USD")}}
+	address := models.Address{Recipient: "John Doe", Line1: "1 Main St", City: "Springfield", PostalCode: "62701", Region: "IL", Country: "US"}
+	require.NoError(t, order.CreateOrder(ctx, shopItems, "", "test@example.com", address, 500))
+	assert.Equal(t, "US", order.Order.TaxRegion)
+	assert.Equal(t, models.NewMoney(200, "USD"), order.Order.TaxTotal)
+	assert.Equal(t, models.NewMoney(2700, "USD"), order.Order.TotalPrice)
+
+	require.NoError(t, order.ChangeItemQuantity(ctx, "item1", 3))
+	assert.Equal(t, models.NewMoney(300, "USD"), order.Order.TaxTotal)
+	assert.Equal(t, models.NewMoney(3800, "USD"), order.Order.TotalPrice)
+
+	address = models.Address{Recipient: "John Doe", Line1: "1 Rue de Rivoli", City: "Paris", PostalCode: "75001", Country: "FR"}
+	require.NoError(t, order.ChangeDeliveryAddress(ctx, address))
+	assert.Equal(t, "FR", order.Order.TaxRegion)
+	assert.Equal(t, models.Zero("USD"), order.Order.TaxTotal)
+	assert.Equal(t, models.NewMoney(3500, "USD"), order.Order.TotalPrice)
+}
@@ -0,0 +1,343 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/EventStore/EventStore-Client-Go/esdb"
+	"github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/log"
+	"github.com/pkg/errors"
+	uuid "github.com/satori/go.uuid"
+	"go.mongodb.org/mongo-driver/mongo"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/wassef911/eventually/internal/api/constants"
+	"github.com/wassef911/eventually/internal/delivery/integration"
+	"github.com/wassef911/eventually/internal/delivery/models"
+	"github.com/wassef911/eventually/internal/delivery/repository"
+	"github.com/wassef911/eventually/internal/infrastructure/es"
+	"github.com/wassef911/eventually/pkg/config"
+	"github.com/wassef911/eventually/pkg/logger"
+)
+
+const (
+	defaultRetryBackoff  = time.Second
+	defaultRetryInterval = time.Second
+	defaultTimeout       = 10 * time.Second
+	// retryBatchSize due retries of a tenant taken on every tick.
+	retryBatchSize    = 100
+	contentTypeHeader = "Content-Type"
+	contentType       = "application/json"
+	// maxResponseError bytes of a failed response body kept in the delivery log.
+	maxResponseError = 512
+)
+
+// Dispatcher sends the integration events of orders to the subscribed webhooks.
+// The webhooks of an event are called concurrently and the event is acked after their first attempt, so a failing
+// webhook does not hold back the subscription. Failed deliveries are kept in the delivery log and retried from there,
+// a retried event may arrive after later events of its order, receivers order them by sequence.
+type Dispatcher struct {
+	log          logger.Logger
+	db           *esdb.Client
+	webhookRepo  repository.WebhookRepository
+	deliveryRepo repository.WebhookDeliveryRepository
+	client       *http.Client
+	cfg          *config.Config
+}
+
+func NewDispatcher(
+	log logger.Logger,
+	db *esdb.Client,
+	webhookRepo repository.WebhookRepository,
+	deliveryRepo repository.WebhookDeliveryRepository,
+	cfg *config.Config,
+) *Dispatcher {
+	timeout := cfg.Webhooks.Timeout
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+	return &Dispatcher{
+		log:          log,
+		db:           db,
+		webhookRepo:  webhookRepo,
+		deliveryRepo: deliveryRepo,
+		client:       &http.Client{Timeout: timeout},
+		cfg:          cfg,
+	}
+}
+
+// Run delivers the events of the subscription and retries the failed deliveries until ctx is done.
+func (d *Dispatcher) Run(ctx context.Context) error {
+	g, ctx := errgroup.WithContext(ctx)
+	g.Go(func() error { return d.Subscribe(ctx) })
+	g.Go(func() error { return d.runRetries(ctx) })
+	return g.Wait()
+}
+
+func (d *Dispatcher) Subscribe(ctx context.Context) error {
+	d.log.Infof("(starting webhook dispatcher subscription) prefixes: {%+v}", []string{d.cfg.Subscriptions.OrderPrefix})
+
+	err := d.db.CreatePersistentSubscriptionAll(ctx, d.cfg.Subscriptions.WebhookGroupName, esdb.PersistentAllSubscriptionOptions{
+		Filter: &esdb.SubscriptionFilter{Type: esdb.StreamFilterType, Prefixes: []string{d.cfg.Subscriptions.OrderPrefix}},
+	})
+	if err != nil {
+		if subscriptionError, ok := err.(*esdb.PersistentSubscriptionError); !ok || ok && (subscriptionError.Code != 6) {
+			return err
+		}
+	}
+
+	stream, err := d.db.ConnectToPersistentSubscription(ctx, constants.EsAll, d.cfg.Subscriptions.WebhookGroupName, esdb.ConnectToPersistentSubscriptionOptions{})
+	if err != nil {
+		return err
+	}
+	defer stream.Close()
+
+	return d.ProcessEvents(ctx, stream)
+}
+
+func (d *Dispatcher) ProcessEvents(ctx context.Context, stream *esdb.PersistentSubscription) error {
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		event := stream.Recv()
+
+		switch {
+		case event.SubscriptionDropped != nil:
+			return errors.Wrap(event.SubscriptionDropped.Error, "subscription dropped")
+
+		case event.EventAppeared != nil:
+			if err := d.processSingleEvent(ctx, stream, event.EventAppeared); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (d *Dispatcher) processSingleEvent(ctx context.Context, stream *esdb.PersistentSubscription, event *esdb.ResolvedEvent) error {
+	d.log.ProjectionEvent("(WebhookDispatcher)", d.cfg.Subscriptions.WebhookGroupName, event, 0)
+
+	esEvent, err := es.Upcast(es.NewEventFromRecorded(event.Event))
+	if err == nil {
+		err = d.HandleEvent(ctx, esEvent)
+	}
+	if err != nil {
+		d.log.Warnf("(WebhookDispatcher) [HandleEvent] eventType: {%s}, err: {%v}", event.Event.EventType, err)
+		if nackErr := stream.Nack(err.Error(), esdb.Nack_Retry, event); nackErr != nil {
+			return errors.Wrap(nackErr, "failed to Nack event")
+		}
+		return nil
+	}
+
+	if ackErr := stream.Ack(event); ackErr != nil {
+		return errors.Wrap(ackErr, "failed to Ack event")
+	}
+	return nil
+}
+
+// HandleEvent delivers the integration event of evt to every active webhook subscribed to its type.
+// A failed delivery is logged and scheduled for a retry, only storage errors are returned.
+func (d *Dispatcher) HandleEvent(ctx context.Context, evt es.Event) error {
+	// webhooks are registered per tenant
+	ctx = es.ContextWithTenant(ctx, evt.GetTenant())
+	span, ctx := opentracing.StartSpanFromContext(ctx, "WebhookDispatcher.HandleEvent")
+	defer span.Finish()
+	span.LogFields(log.String("AggregateID", evt.GetAggregateID()), log.String("EventType", evt.GetEventType()))
+
+	integrationEvent, err := integration.FromDomainEvent(evt)
+	if err != nil {
+		return err
+	}
+	if integrationEvent == nil {
+		return nil
+	}
+
+	payload, err := json.Marshal(integrationEvent)
+	if err != nil {
+		return errors.Wrap(err, "json.Marshal")
+	}
+
+	webhooks, err := d.webhookRepo.ListActive(ctx)
+	if err != nil {
+		return errors.Wrap(err, "webhookRepo.ListActive")
+	}
+
+	g, ctx := errgroup.WithContext(ctx)
+	for _, webhook := range webhooks {
+		if !webhook.Subscribes(integrationEvent.Type) {
+			continue
+		}
+		webhook := webhook
+		g.Go(func() error { return d.deliver(ctx, webhook, integrationEvent.ID, integrationEvent.Type, payload, 1) })
+	}
+	return g.Wait()
+}
+
+// RetryDue attempts the failed deliveries of every tenant whose retry is due at now.
+func (d *Dispatcher) RetryDue(ctx context.Context, now time.Time) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "WebhookDispatcher.RetryDue")
+	defer span.Finish()
+
+	for _, tenant := range d.cfg.Tenancy.TenantIDs() {
+		tenantCtx := es.ContextWithTenant(ctx, tenant)
+		due, err := d.deliveryRepo.ListDue(tenantCtx, now, retryBatchSize)
+		if err != nil {
+			return errors.Wrapf(err, "deliveryRepo.ListDue tenant: {%s}", tenant)
+		}
+
+		for _, delivery := range due {
+			if err := d.retry(tenantCtx, delivery, now); err != nil {
+				return errors.Wrapf(err, "deliveryID: {%s}", delivery.DeliveryID)
+			}
+		}
+	}
+	return nil
+}
+
+func (d *Dispatcher) runRetries(ctx context.Context) error {
+	interval := d.cfg.Webhooks.RetryInterval
+	if interval <= 0 {
+		interval = defaultRetryInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			if err := d.RetryDue(ctx, time.Now().UTC()); err != nil {
+				d.log.Warnf("(WebhookDispatcher) [RetryDue] err: {%v}", err)
+			}
+		}
+	}
+}
+
+// retry claims the retry of the delivery for the duration of an attempt, a dispatcher stopped before logging the
+// attempt leaves the retry due again once the claim expires.
+func (d *Dispatcher) retry(ctx context.Context, delivery *models.WebhookDelivery, now time.Time) error {
+	claimed, err := d.deliveryRepo.ClaimRetry(ctx, delivery, now.Add(2*d.client.Timeout))
+	if err != nil {
+		return errors.Wrap(err, "deliveryRepo.ClaimRetry")
+	}
+	if !claimed {
+		return nil
+	}
+
+	webhook, err := d.webhookRepo.GetByID(ctx, delivery.WebhookID)
+	if err != nil && !errors.Is(err, mongo.ErrNoDocuments) {
+		return errors.Wrap(err, "webhookRepo.GetByID")
+	}
+	// deleted and disabled webhooks drop their retries
+	if err == nil && !webhook.Disabled {
+		if err := d.deliver(ctx, webhook, delivery.EventID, delivery.EventType, delivery.Payload, delivery.Attempt+1); err != nil {
+			return err
+		}
+	}
+
+	if err := d.deliveryRepo.CompleteRetry(ctx, delivery.DeliveryID); err != nil {
+		return errors.Wrap(err, "deliveryRepo.CompleteRetry")
+	}
+	return nil
+}
+
+// deliver makes one attempt of the delivery, a failed attempt with attempts left is retried after a backoff
+// doubling with every attempt.
+func (d *Dispatcher) deliver(ctx context.Context, webhook *models.Webhook, eventID string, eventType string, payload []byte, attempt int) error {
+	delivery := d.send(ctx, webhook, eventID, eventType, payload, attempt)
+	if !delivery.Succeeded && attempt < d.cfg.Webhooks.MaxAttempts {
+		nextAttemptAt := delivery.AttemptedAt.Add(d.backoff(attempt))
+		delivery.NextAttemptAt = &nextAttemptAt
+		delivery.Payload = payload
+	}
+	if err := d.deliveryRepo.Insert(ctx, delivery); err != nil {
+		return errors.Wrap(err, "deliveryRepo.Insert")
+	}
+
+	// the subscription and the retries deliver to the same webhook concurrently, the counts are updated in place
+	switch {
+	case delivery.Succeeded:
+		if err := d.webhookRepo.RecordSuccess(ctx, webhook.WebhookID, time.Now().UTC()); err != nil {
+			return errors.Wrap(err, "webhookRepo.RecordSuccess")
+		}
+		return nil
+	case delivery.NextAttemptAt != nil:
+		return nil
+	}
+
+	disabled, err := d.webhookRepo.RecordFailure(ctx, webhook.WebhookID, time.Now().UTC(), d.cfg.Webhooks.DisableAfter)
+	if err != nil {
+		return errors.Wrap(err, "webhookRepo.RecordFailure")
+	}
+	if disabled {
+		d.log.Warnf("(WebhookDispatcher) webhook disabled after {%d} failed deliveries, webhookID: {%s}", d.cfg.Webhooks.DisableAfter, webhook.WebhookID)
+	}
+	return nil
+}
+
+// backoff wait after the failed attempt before the next one.
+func (d *Dispatcher) backoff(attempt int) time.Duration {
+	backoff := d.cfg.Webhooks.RetryBackoff
+	if backoff <= 0 {
+		backoff = defaultRetryBackoff
+	}
+	return backoff << (attempt - 1)
+}
+
+func (d *Dispatcher) send(ctx context.Context, webhook *models.Webhook, eventID string, eventType string, payload []byte, attempt int) *models.WebhookDelivery {
+	attemptedAt := time.Now().UTC()
+	delivery := &models.WebhookDelivery{
+		DeliveryID:  uuid.NewV4().String(),
+		WebhookID:   webhook.WebhookID,
+		EventID:     eventID,
+		EventType:   eventType,
+		Attempt:     attempt,
+		AttemptedAt: attemptedAt,
+	}
+
+	statusCode, err := d.post(ctx, webhook, delivery, payload)
+	delivery.Duration = time.Since(attemptedAt).Milliseconds()
+	delivery.StatusCode = statusCode
+	if err != nil {
+		delivery.Error = err.Error()
+		return delivery
+	}
+
+	delivery.Succeeded = true
+	return delivery
+}
+
+func (d *Dispatcher) post(ctx context.Context, webhook *models.Webhook, delivery *models.WebhookDelivery, payload []byte) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(payload))
+	if err != nil {
+		return 0, errors.Wrap(err, "http.NewRequest")
+	}
+
+	timestamp := delivery.AttemptedAt.Unix()
+	req.Header.Set(contentTypeHeader, contentType)
+	req.Header.Set(IDHeader, delivery.EventID)
+	req.Header.Set(TypeHeader, delivery.EventType)
+	req.Header.Set(TimestampHeader, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(SignatureHeader, Sign(webhook.Secret, timestamp, payload))
+
+	res, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < http.StatusOK || res.StatusCode >= http.StatusMultipleChoices {
+		body, _ := io.ReadAll(io.LimitReader(res.Body, maxResponseError))
+		return res.StatusCode, fmt.Errorf("unexpected status: {%d}, body: {%s}", res.StatusCode, body)
+	}
+	return res.StatusCode, nil
+}
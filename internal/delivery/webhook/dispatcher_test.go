@@ -0,0 +1,298 @@
+package webhook_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wassef911/eventually/internal/api/dto"
+	"github.com/wassef911/eventually/internal/api/utils"
+	"github.com/wassef911/eventually/internal/delivery/aggregate"
+	"github.com/wassef911/eventually/internal/delivery/events"
+	"github.com/wassef911/eventually/internal/delivery/integration"
+	"github.com/wassef911/eventually/internal/delivery/models"
+	"github.com/wassef911/eventually/internal/delivery/webhook"
+	"github.com/wassef911/eventually/internal/infrastructure/es"
+	"github.com/wassef911/eventually/pkg/config"
+	"github.com/wassef911/eventually/pkg/logger"
+)
+
+const secret = "0123456789abcdef"
+
+type memoryWebhookRepository struct {
+	mu       sync.Mutex
+	webhooks map[string]models.Webhook
+}
+
+func (r *memoryWebhookRepository) Insert(ctx context.Context, webhook *models.Webhook) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.webhooks[webhook.WebhookID] = *webhook
+	return nil
+}
+
+func (r *memoryWebhookRepository) GetByID(ctx context.Context, webhookID string) (*models.Webhook, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	webhook := r.webhooks[webhookID]
+	return &webhook, nil
+}
+
+func (r *memoryWebhookRepository) Update(ctx context.Context, webhook *models.Webhook) error {
+	return r.Insert(ctx, webhook)
+}
+
+func (r *memoryWebhookRepository) Delete(ctx context.Context, webhookID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.webhooks, webhookID)
+	return nil
+}
+
+func (r *memoryWebhookRepository) ListActive(ctx context.Context) ([]*models.Webhook, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	webhooks := make([]*models.Webhook, 0, len(r.webhooks))
+	for _, webhook := range r.webhooks {
+		if !webhook.Disabled {
+			webhook := webhook
+			webhooks = append(webhooks, &webhook)
+		}
+	}
+	return webhooks, nil
+}
+
+func (r *memoryWebhookRepository) RecordSuccess(ctx context.Context, webhookID string, now time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if webhook, ok := r.webhooks[webhookID]; ok {
+		webhook.ConsecutiveFailures = 0
+		webhook.UpdatedAt = now
+		r.webhooks[webhookID] = webhook
+	}
+	return nil
+}
+
+func (r *memoryWebhookRepository) RecordFailure(ctx context.Context, webhookID string, now time.Time, disableAfter int) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	webhook, ok := r.webhooks[webhookID]
+	if !ok {
+		return false, nil
+	}
+	webhook.ConsecutiveFailures++
+	webhook.UpdatedAt = now
+	disabled := !webhook.Disabled && disableAfter > 0 && webhook.ConsecutiveFailures >= disableAfter
+	if disabled {
+		webhook.Disabled = true
+		webhook.DisabledAt = now
+	}
+	r.webhooks[webhookID] = webhook
+	return disabled, nil
+}
+
+type memoryDeliveryRepository struct {
+	mu         sync.Mutex
+	deliveries []*models.WebhookDelivery
+}
+
+func (r *memoryDeliveryRepository) Insert(ctx context.Context, delivery *models.WebhookDelivery) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.deliveries = append(r.deliveries, delivery)
+	return nil
+}
+
+func (r *memoryDeliveryRepository) ListByWebhookID(ctx context.Context, webhookID string, pq *utils.Pagination) (*dto.WebhookDeliveryListResponseDto, error) {
+	return &dto.WebhookDeliveryListResponseDto{}, nil
+}
+
+func (r *memoryDeliveryRepository) ListDue(ctx context.Context, now time.Time, limit int) ([]*models.WebhookDelivery, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	due := make([]*models.WebhookDelivery, 0)
+	for _, delivery := range r.deliveries {
+		if delivery.NextAttemptAt != nil && !delivery.NextAttemptAt.After(now) && len(due) < limit {
+			copied := *delivery
+			due = append(due, &copied)
+		}
+	}
+	return due, nil
+}
+
+func (r *memoryDeliveryRepository) ClaimRetry(ctx context.Context, delivery *models.WebhookDelivery, until time.Time) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, stored := range r.deliveries {
+		if stored.DeliveryID == delivery.DeliveryID && stored.NextAttemptAt != nil && stored.NextAttemptAt.Equal(*delivery.NextAttemptAt) {
+			stored.NextAttemptAt = &until
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (r *memoryDeliveryRepository) CompleteRetry(ctx context.Context, deliveryID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, stored := range r.deliveries {
+		if stored.DeliveryID == deliveryID {
+			stored.NextAttemptAt = nil
+			stored.Payload = nil
+		}
+	}
+	return nil
+}
+
+// retryAll retries every scheduled delivery until none is left.
+func retryAll(t *testing.T, dispatcher *webhook.Dispatcher) {
+	for i := 0; i < 10; i++ {
+		require.NoError(t, dispatcher.RetryDue(context.Background(), time.Now().Add(time.Hour)))
+	}
+}
+
+func newDispatcher(t *testing.T, webhooks ...*models.Webhook) (*webhook.Dispatcher, *memoryWebhookRepository, *memoryDeliveryRepository) {
+	appLogger := logger.NewAppLogger(&logger.Config{LogLevel: "fatal"})
+	appLogger.InitLogger()
+
+	webhookRepo := &memoryWebhookRepository{webhooks: make(map[string]models.Webhook)}
+	for _, w := range webhooks {
+		require.NoError(t, webhookRepo.Insert(context.Background(), w))
+	}
+	deliveryRepo := &memoryDeliveryRepository{}
+
+	cfg := &config.Config{Webhooks: config.Webhooks{MaxAttempts: 3, RetryBackoff: time.Millisecond, Timeout: time.Second, DisableAfter: 2}}
+	return webhook.NewDispatcher(appLogger, nil, webhookRepo, deliveryRepo, cfg), webhookRepo, deliveryRepo
+}
+
+func orderPaidEvent(t *testing.T) es.Event {
+	order := aggregate.NewOrderAggregateWithID("order1")
+	paid, err := events.NewOrderPaidEvent(order, &models.Payment{PaymentID: "payment1", Timestamp: time.Now()})
+	require.NoError(t, err)
+	return paid
+}
+
+func TestDispatcherRetriesAndSignsDelivery(t *testing.T) {
+	var mu sync.Mutex
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		requests++
+		if requests == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		body, _ := io.ReadAll(r.Body)
+		timestamp, _ := strconv.ParseInt(r.Header.Get(webhook.TimestampHeader), 10, 64)
+		if !webhook.Verify(secret, timestamp, body, r.Header.Get(webhook.SignatureHeader)) {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		assert.Equal(t, integration.OrderPaid, r.Header.Get(webhook.TypeHeader))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	dispatcher, webhookRepo, deliveryRepo := newDispatcher(t, &models.Webhook{WebhookID: "webhook1", URL: server.URL, Secret: secret})
+	paid := orderPaidEvent(t)
+	require.NoError(t, dispatcher.HandleEvent(context.Background(), paid))
+
+	require.Len(t, deliveryRepo.deliveries, 1, "the event is handled after the first attempt")
+	assert.False(t, deliveryRepo.deliveries[0].Succeeded)
+	assert.Equal(t, http.StatusServiceUnavailable, deliveryRepo.deliveries[0].StatusCode)
+	require.NotNil(t, deliveryRepo.deliveries[0].NextAttemptAt)
+	assert.Equal(t, deliveryRepo.deliveries[0].AttemptedAt.Add(time.Millisecond), *deliveryRepo.deliveries[0].NextAttemptAt)
+
+	require.NoError(t, dispatcher.RetryDue(context.Background(), deliveryRepo.deliveries[0].AttemptedAt))
+	assert.Len(t, deliveryRepo.deliveries, 1, "the retry is not due yet")
+
+	require.NoError(t, dispatcher.RetryDue(context.Background(), time.Now().Add(time.Second)))
+	require.Len(t, deliveryRepo.deliveries, 2)
+	assert.Nil(t, deliveryRepo.deliveries[0].NextAttemptAt, "the retry is done")
+	assert.Empty(t, deliveryRepo.deliveries[0].Payload)
+	assert.True(t, deliveryRepo.deliveries[1].Succeeded)
+	assert.Nil(t, deliveryRepo.deliveries[1].NextAttemptAt)
+	assert.Equal(t, 2, deliveryRepo.deliveries[1].Attempt)
+	assert.Equal(t, paid.GetEventID(), deliveryRepo.deliveries[1].EventID)
+
+	stored, err := webhookRepo.GetByID(context.Background(), "webhook1")
+	require.NoError(t, err)
+	assert.Equal(t, 0, stored.ConsecutiveFailures)
+}
+
+func TestDispatcherDisablesFailingWebhook(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	dispatcher, webhookRepo, deliveryRepo := newDispatcher(t, &models.Webhook{WebhookID: "webhook1", URL: server.URL, Secret: secret})
+
+	require.NoError(t, dispatcher.HandleEvent(context.Background(), orderPaidEvent(t)))
+	stored, err := webhookRepo.GetByID(context.Background(), "webhook1")
+	require.NoError(t, err)
+	assert.Equal(t, 0, stored.ConsecutiveFailures, "the delivery has attempts left")
+
+	retryAll(t, dispatcher)
+	stored, err = webhookRepo.GetByID(context.Background(), "webhook1")
+	require.NoError(t, err)
+	assert.Equal(t, 1, stored.ConsecutiveFailures)
+	assert.False(t, stored.Disabled)
+
+	require.NoError(t, dispatcher.HandleEvent(context.Background(), orderPaidEvent(t)))
+	retryAll(t, dispatcher)
+	stored, err = webhookRepo.GetByID(context.Background(), "webhook1")
+	require.NoError(t, err)
+	assert.True(t, stored.Disabled)
+	assert.Len(t, deliveryRepo.deliveries, 6, "every attempt is logged")
+
+	require.NoError(t, dispatcher.HandleEvent(context.Background(), orderPaidEvent(t)))
+	assert.Len(t, deliveryRepo.deliveries, 6, "a disabled webhook is not called")
+}
+
+func TestDispatcherDropsRetriesOfDisabledWebhook(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	dispatcher, webhookRepo, deliveryRepo := newDispatcher(t, &models.Webhook{WebhookID: "webhook1", URL: server.URL, Secret: secret})
+	require.NoError(t, dispatcher.HandleEvent(context.Background(), orderPaidEvent(t)))
+	require.Len(t, deliveryRepo.deliveries, 1)
+
+	stored, err := webhookRepo.GetByID(context.Background(), "webhook1")
+	require.NoError(t, err)
+	stored.Disabled = true
+	require.NoError(t, webhookRepo.Update(context.Background(), stored))
+
+	retryAll(t, dispatcher)
+	assert.Len(t, deliveryRepo.deliveries, 1)
+	assert.Nil(t, deliveryRepo.deliveries[0].NextAttemptAt)
+}
+
+func TestDispatcherFiltersEventTypes(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	dispatcher, _, deliveryRepo := newDispatcher(t, &models.Webhook{
+		WebhookID:  "webhook1",
+		URL:        server.URL,
+		Secret:     secret,
+		EventTypes: []string{integration.OrderShipmentDelivered},
+	})
+
+	require.NoError(t, dispatcher.HandleEvent(context.Background(), orderPaidEvent(t)))
+	assert.Empty(t, deliveryRepo.deliveries)
+}
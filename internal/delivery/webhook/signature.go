@@ -0,0 +1,37 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+)
+
+const (
+	// SignatureHeader hex HMAC-SHA256 of "<timestamp>.<body>" keyed with the webhook secret.
+	SignatureHeader = "X-Webhook-Signature"
+	// TimestampHeader unix time the request was signed at, receivers reject stale requests with it.
+	TimestampHeader = "X-Webhook-Timestamp"
+	// IDHeader id of the event, the same on every attempt so receivers can deduplicate.
+	IDHeader   = "X-Webhook-Id"
+	TypeHeader = "X-Webhook-Event"
+)
+
+// Sign signature sent in SignatureHeader.
+func Sign(secret string, timestamp int64, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify checks a signature the way receivers are expected to.
+func Verify(secret string, timestamp int64, payload []byte, signature string) bool {
+	expected, err := hex.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+	actual, _ := hex.DecodeString(Sign(secret, timestamp, payload))
+	return hmac.Equal(expected, actual)
+}
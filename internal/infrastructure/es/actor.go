@@ -0,0 +1,73 @@
+package es
+
+import (
+	"context"
+	"encoding/json"
+)
+
+// Metadata keys of the actor, stored next to the tracing context of the event.
+const (
+	ActorIDMetadataKey    = "actorId"
+	ActorEmailMetadataKey = "actorEmail"
+	ActorRoleMetadataKey  = "actorRole"
+)
+
+// Actor who caused the events, events raised by sagas and projections have none.
+type Actor struct {
+	ID    string `json:"id"`
+	Email string `json:"email,omitempty"`
+	Role  string `json:"role,omitempty"`
+}
+
+type actorKey struct{}
+
+// ContextWithActor events saved with the returned context record the actor in their metadata.
+func ContextWithActor(ctx context.Context, actor Actor) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+func ActorFromContext(ctx context.Context) (Actor, bool) {
+	actor, ok := ctx.Value(actorKey{}).(Actor)
+	return actor, ok
+}
+
+// SetActor adds the actor to the metadata, the other metadata entries are kept.
+func (e *Event) SetActor(actor Actor) error {
+	metadata, err := e.metadataEntries()
+	if err != nil {
+		return err
+	}
+
+	metadata[ActorIDMetadataKey] = actor.ID
+	metadata[ActorEmailMetadataKey] = actor.Email
+	metadata[ActorRoleMetadataKey] = actor.Role
+	return e.SetMetadata(metadata)
+}
+
+// metadataEntries the metadata as a map, empty when the event has none.
+func (e *Event) metadataEntries() (map[string]string, error) {
+	metadata := make(map[string]string)
+	if len(e.GetMetadata()) > 0 {
+		if err := json.Unmarshal(e.GetMetadata(), &metadata); err != nil {
+			return nil, err
+		}
+	}
+	if metadata == nil {
+		metadata = make(map[string]string)
+	}
+	return metadata, nil
+}
+
+// GetActor the actor recorded in the metadata, false when the event has none.
+func (e *Event) GetActor() (Actor, bool) {
+	metadata := make(map[string]string)
+	if err := json.Unmarshal(e.GetMetadata(), &metadata); err != nil {
+		return Actor{}, false
+	}
+
+	actorID, ok := metadata[ActorIDMetadataKey]
+	if !ok {
+		return Actor{}, false
+	}
+	return Actor{ID: actorID, Email: metadata[ActorEmailMetadataKey], Role: metadata[ActorRoleMetadataKey]}, true
+}
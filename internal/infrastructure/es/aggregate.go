@@ -76,6 +76,8 @@ type AggregateRoot interface {
 	GetUncommittedEvents() []Event
 	GetID() string
 	SetID(id string) *AggregateBase
+	SetTenant(tenant string) *AggregateBase
+	GetTenant() string
 	GetVersion() int64
 	ClearUncommittedEvents()
 	ToSnapshot()
@@ -84,6 +86,7 @@ type AggregateRoot interface {
 	SetAppliedEvents(events []Event)
 	GetAppliedEvents() []Event
 	RaiseEvent(event Event) error
+	HandledCommand(commandID string) bool
 	String() string
 	Load
 	Apply
@@ -95,12 +98,15 @@ type AggregateType string
 // AggregateBase base aggregate contains all main necessary fields
 type AggregateBase struct {
 	ID                string
+	Tenant            string
+	aggregateID       string
 	Version           int64
 	AppliedEvents     []Event
 	UncommittedEvents []Event
 	Type              AggregateType
 	withAppliedEvents bool
 	when              when
+	handledCommands   map[string]struct{}
 }
 
 // NewAggregateBase AggregateBase constructor, contains all main fields and methods,
@@ -130,12 +136,27 @@ func NewAggregateBase(when when) *AggregateBase {
 	}
 }
 
-// SetID set AggregateBase ID
+// SetID set AggregateBase ID, the stream of an aggregate of a tenant is prefixed with the tenant: {type}-{tenant}~{id}
 func (a *AggregateBase) SetID(id string) *AggregateBase {
-	a.ID = fmt.Sprintf("%s-%s", a.GetType(), id)
+	a.aggregateID = id
+	a.ID = fmt.Sprintf("%s-%s", a.GetType(), TenantAggregateID(a.Tenant, id))
 	return a
 }
 
+// SetTenant moves the aggregate to the streams of the tenant, it must be set before any event is applied
+func (a *AggregateBase) SetTenant(tenant string) *AggregateBase {
+	a.Tenant = tenant
+	if a.aggregateID != "" {
+		a.SetID(a.aggregateID)
+	}
+	return a
+}
+
+// GetTenant get AggregateBase tenant, empty for the default tenant
+func (a *AggregateBase) GetTenant() string {
+	return a.Tenant
+}
+
 // GetID get AggregateBase ID
 func (a *AggregateBase) GetID() string {
 	return a.ID
@@ -187,6 +208,7 @@ func (a *AggregateBase) Load(events []Event) error {
 		if err := a.when(evt); err != nil {
 			return err
 		}
+		a.recordCommand(evt)
 
 		if a.withAppliedEvents {
 			a.AppliedEvents = append(a.AppliedEvents, evt)
@@ -229,6 +251,7 @@ func (a *AggregateBase) RaiseEvent(event Event) error {
 	if err := a.when(event); err != nil {
 		return err
 	}
+	a.recordCommand(event)
 
 	if a.withAppliedEvents {
 		a.AppliedEvents = append(a.AppliedEvents, event)
@@ -238,6 +261,23 @@ func (a *AggregateBase) RaiseEvent(event Event) error {
 	return nil
 }
 
+// HandledCommand reports whether a loaded event was raised by the command, see ContextWithCommandID.
+func (a *AggregateBase) HandledCommand(commandID string) bool {
+	_, ok := a.handledCommands[commandID]
+	return ok
+}
+
+func (a *AggregateBase) recordCommand(event Event) {
+	commandID, ok := event.GetCommandID()
+	if !ok {
+		return
+	}
+	if a.handledCommands == nil {
+		a.handledCommands = make(map[string]struct{})
+	}
+	a.handledCommands[commandID] = struct{}{}
+}
+
 // ToSnapshot prepare AggregateBase for saving Snapshot.
 func (a *AggregateBase) ToSnapshot() {
 	if a.withAppliedEvents {
@@ -0,0 +1,15 @@
+package es
+
+import "time"
+
+// Clock is the time source of sagas and deadlines, tests replace it to move time forward.
+type Clock interface {
+	Now() time.Time
+}
+
+// SystemClock reads the wall clock.
+type SystemClock struct{}
+
+func (SystemClock) Now() time.Time {
+	return time.Now()
+}
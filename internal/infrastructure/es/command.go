@@ -1,5 +1,13 @@
 package es
 
+import (
+	"context"
+	"encoding/json"
+)
+
+// CommandIDMetadataKey metadata key of the id of the saga command that raised the event.
+const CommandIDMetadataKey = "commandId"
+
 // Command commands interface for event sourcing.
 type Command interface {
 	GetAggregateID() string
@@ -16,3 +24,38 @@ func NewBaseCommand(aggregateID string) BaseCommand {
 func (c *BaseCommand) GetAggregateID() string {
 	return c.AggregateID
 }
+
+type commandIDKey struct{}
+
+// ContextWithCommandID events saved with the returned context record the command id in their metadata,
+// the aggregate they are loaded into then reports the command as handled.
+func ContextWithCommandID(ctx context.Context, commandID string) context.Context {
+	return context.WithValue(ctx, commandIDKey{}, commandID)
+}
+
+func CommandIDFromContext(ctx context.Context) (string, bool) {
+	commandID, ok := ctx.Value(commandIDKey{}).(string)
+	return commandID, ok && commandID != ""
+}
+
+// SetCommandID adds the command id to the metadata, the other metadata entries are kept.
+func (e *Event) SetCommandID(commandID string) error {
+	metadata, err := e.metadataEntries()
+	if err != nil {
+		return err
+	}
+
+	metadata[CommandIDMetadataKey] = commandID
+	return e.SetMetadata(metadata)
+}
+
+// GetCommandID the command id recorded in the metadata, false when the event has none.
+func (e *Event) GetCommandID() (string, bool) {
+	metadata := make(map[string]string)
+	if err := json.Unmarshal(e.GetMetadata(), &metadata); err != nil {
+		return "", false
+	}
+
+	commandID, ok := metadata[CommandIDMetadataKey]
+	return commandID, ok && commandID != ""
+}
@@ -1,6 +1,10 @@
 package es
 
-import "github.com/pkg/errors"
+import (
+	"github.com/pkg/errors"
+
+	"github.com/wassef911/eventually/internal/delivery/domain"
+)
 
 var (
 	ErrAlreadyExists       = errors.New("Already exists")
@@ -10,4 +14,5 @@ var (
 	ErrInvalidAggregate    = errors.New("invalid aggregate")
 	ErrInvalidAggregateID  = errors.New("invalid aggregate id")
 	ErrInvalidEventVersion = errors.New("invalid event version")
+	ErrTenantMismatch      = domain.NewConflictError("tenant_mismatch", "aggregate belongs to another tenant")
 )
@@ -0,0 +1,311 @@
+package es
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/EventStore/EventStore-Client-Go/esdb"
+	"github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/log"
+	"github.com/pkg/errors"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/wassef911/eventually/pkg/logger"
+)
+
+const (
+	defaultDeadlineInterval = 10 * time.Second
+	// deadlineRetryDelay a deadline that failed is retried after it, so it does not fail on every tick.
+	deadlineRetryDelay = time.Minute
+	esAll              = "$all"
+)
+
+// ProcessManagerConfig Name is the saga aggregate type, it must not match any of the subscribed Prefixes.
+type ProcessManagerConfig struct {
+	Name             string
+	GroupName        string
+	Prefixes         []string
+	DeadlineInterval time.Duration
+	// Clock decides when deadlines are due, the system clock by default.
+	Clock Clock
+}
+
+// ProcessManager runs a Saga on a persistent subscription.
+// Every step is recorded in the saga stream together with the commands it decided before any of them
+// is dispatched, a redelivered event is recognised by its id and only finishes dispatching the recorded commands.
+type ProcessManager[S any] struct {
+	log        logger.Logger
+	db         *esdb.Client
+	store      SagaStore
+	deadlines  DeadlineStore
+	dispatcher CommandDispatcher
+	saga       Saga[S]
+	config     ProcessManagerConfig
+	mu         sync.Mutex
+}
+
+func NewProcessManager[S any](
+	log logger.Logger,
+	db *esdb.Client,
+	store SagaStore,
+	deadlines DeadlineStore,
+	dispatcher CommandDispatcher,
+	saga Saga[S],
+	config ProcessManagerConfig,
+) *ProcessManager[S] {
+	if config.DeadlineInterval <= 0 {
+		config.DeadlineInterval = defaultDeadlineInterval
+	}
+	if config.Clock == nil {
+		config.Clock = SystemClock{}
+	}
+	return &ProcessManager[S]{
+		log:        log,
+		db:         db,
+		store:      store,
+		deadlines:  deadlines,
+		dispatcher: dispatcher,
+		saga:       saga,
+		config:     config,
+	}
+}
+
+// Run handles subscribed events and expired deadlines until the context is canceled.
+func (p *ProcessManager[S]) Run(ctx context.Context) error {
+	g, ctx := errgroup.WithContext(ctx)
+	g.Go(func() error { return p.Subscribe(ctx) })
+	g.Go(func() error { return p.runDeadlines(ctx) })
+	return g.Wait()
+}
+
+// Subscribe runs a single worker, so the events of a saga are handled in the order they were recorded.
+func (p *ProcessManager[S]) Subscribe(ctx context.Context) error {
+
+	err := p.db.CreatePersistentSubscriptionAll(ctx, p.config.GroupName, esdb.PersistentAllSubscriptionOptions{
+		Filter: &esdb.SubscriptionFilter{Type: esdb.StreamFilterType, Prefixes: p.config.Prefixes},
+	})
+	if err != nil {
+		if subscriptionError, ok := err.(*esdb.PersistentSubscriptionError); !ok || ok && (subscriptionError.Code != 6) {
+			return err
+		}
+	}
+
+	stream, err := p.db.ConnectToPersistentSubscription(ctx, esAll, p.config.GroupName, esdb.ConnectToPersistentSubscriptionOptions{})
+	if err != nil {
+		return err
+	}
+	defer stream.Close()
+
+	return p.ProcessEvents(ctx, stream)
+}
+
+func (p *ProcessManager[S]) ProcessEvents(ctx context.Context, stream *esdb.PersistentSubscription) error {
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		event := stream.Recv()
+
+		switch {
+		case event.SubscriptionDropped != nil:
+			return errors.Wrap(event.SubscriptionDropped.Error, "subscription dropped")
+
+		case event.EventAppeared != nil:
+			if err := p.processSingleEvent(ctx, stream, event.EventAppeared); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (p *ProcessManager[S]) processSingleEvent(ctx context.Context, stream *esdb.PersistentSubscription, event *esdb.ResolvedEvent) error {
+	p.log.ProjectionEvent(fmt.Sprintf("(%s)", p.config.Name), p.config.GroupName, event, 0)
+
+	esEvent, err := Upcast(NewEventFromRecorded(event.Event))
+	if err == nil {
+		err = p.HandleEvent(ctx, esEvent)
+	}
+	if err != nil {
+		p.log.Warnf("(ProcessManager) [HandleEvent] saga: {%s}, eventType: {%s}, err: {%v}", p.config.Name, event.Event.EventType, err)
+		if nackErr := stream.Nack(err.Error(), esdb.Nack_Retry, event); nackErr != nil {
+			return errors.Wrap(nackErr, "failed to Nack event")
+		}
+		return nil
+	}
+
+	if ackErr := stream.Ack(event); ackErr != nil {
+		return errors.Wrap(ackErr, "failed to Ack event")
+	}
+
+	return nil
+}
+
+// HandleEvent runs one saga step for the event, it is safe to call again with the same event.
+// The saga and its commands belong to the tenant of the event.
+func (p *ProcessManager[S]) HandleEvent(ctx context.Context, evt Event) error {
+	correlationID := p.saga.CorrelationID(evt)
+	if correlationID == "" {
+		return nil
+	}
+	ctx = ContextWithTenant(ctx, evt.GetTenant())
+
+	span, ctx := opentracing.StartSpanFromContext(ctx, "ProcessManager.HandleEvent")
+	defer span.Finish()
+	span.LogFields(log.String("Saga", p.config.Name), log.String("CorrelationID", correlationID), log.String("EventType", evt.GetEventType()))
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	saga, err := p.loadSaga(ctx, correlationID)
+	if err != nil {
+		return err
+	}
+
+	if !saga.State.Completed && !saga.isHandled(evt.GetEventID()) {
+		state := saga.State
+		commands, err := p.saga.Handle(ctx, &state, evt)
+		if err != nil {
+			return errors.Wrapf(err, "saga: {%s}, correlationID: {%s}", p.config.Name, correlationID)
+		}
+		if err := p.recordStep(ctx, saga, evt.GetEventID(), state, commands); err != nil {
+			return err
+		}
+	}
+
+	if err := p.syncDeadline(ctx, saga); err != nil {
+		return err
+	}
+	return p.dispatchPending(ctx, saga)
+}
+
+// HandleDeadlines times out the sagas whose deadline passed. A saga that fails does not hold back the others,
+// its deadline is retried after deadlineRetryDelay and the last failure is returned once every saga was handled.
+func (p *ProcessManager[S]) HandleDeadlines(ctx context.Context, now time.Time) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "ProcessManager.HandleDeadlines")
+	defer span.Finish()
+	span.LogFields(log.String("Saga", p.config.Name))
+
+	correlationIDs, err := p.deadlines.Due(ctx, p.config.Name, now)
+	if err != nil {
+		return errors.Wrap(err, "deadlines.Due")
+	}
+
+	// deadlines of every tenant share the index, their correlation ids carry the tenant
+	var deadlineErr error
+	failed := 0
+	for _, tenantCorrelationID := range correlationIDs {
+		tenant, correlationID := SplitTenantAggregateID(tenantCorrelationID)
+		if err := p.handleDeadline(ContextWithTenant(ctx, tenant), correlationID, now); err != nil {
+			deadlineErr = errors.Wrapf(err, "correlationID: {%s}", tenantCorrelationID)
+			failed++
+			if err := p.deadlines.Schedule(ctx, p.config.Name, tenantCorrelationID, now.Add(deadlineRetryDelay)); err != nil {
+				deadlineErr = errors.Wrapf(err, "deadlines.Schedule correlationID: {%s}", tenantCorrelationID)
+			}
+		}
+	}
+	if deadlineErr != nil {
+		return errors.Wrapf(deadlineErr, "%d of %d deadlines failed", failed, len(correlationIDs))
+	}
+	return nil
+}
+
+func (p *ProcessManager[S]) handleDeadline(ctx context.Context, correlationID string, now time.Time) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	saga, err := p.loadSaga(ctx, correlationID)
+	if err != nil {
+		return err
+	}
+
+	deadline := saga.State.Deadline
+	if !saga.State.Completed && !deadline.IsZero() && !deadline.After(now) {
+		state := saga.State
+		commands, err := p.saga.Timeout(ctx, &state)
+		if err != nil {
+			return errors.Wrapf(err, "saga: {%s}, correlationID: {%s}", p.config.Name, correlationID)
+		}
+		if state.Deadline.Equal(deadline) {
+			state.Deadline = time.Time{}
+		}
+		if err := p.recordStep(ctx, saga, fmt.Sprintf("deadline-%d", deadline.UnixNano()), state, commands); err != nil {
+			return err
+		}
+	}
+
+	if err := p.syncDeadline(ctx, saga); err != nil {
+		return err
+	}
+	return p.dispatchPending(ctx, saga)
+}
+
+func (p *ProcessManager[S]) runDeadlines(ctx context.Context) error {
+	ticker := time.NewTicker(p.config.DeadlineInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			if err := p.HandleDeadlines(ctx, p.config.Clock.Now()); err != nil {
+				p.log.Warnf("(ProcessManager) [HandleDeadlines] saga: {%s}, err: {%v}", p.config.Name, err)
+			}
+		}
+	}
+}
+
+func (p *ProcessManager[S]) loadSaga(ctx context.Context, correlationID string) (*sagaAggregate[S], error) {
+	saga := newSagaAggregate[S](p.config.Name, correlationID)
+	saga.SetTenant(TenantFromContext(ctx))
+
+	err := p.store.Exists(ctx, saga.GetID())
+	if errors.Is(err, esdb.ErrStreamNotFound) {
+		return saga, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := p.store.Load(ctx, saga); err != nil {
+		return nil, err
+	}
+	return saga, nil
+}
+
+func (p *ProcessManager[S]) recordStep(ctx context.Context, saga *sagaAggregate[S], eventID string, state SagaState[S], commands []SagaCommand) error {
+	if err := saga.recordStep(eventID, state, commands); err != nil {
+		return err
+	}
+	return p.store.Save(ctx, saga)
+}
+
+// syncDeadline the deadline index is updated after the step is recorded, redelivered events repair it.
+func (p *ProcessManager[S]) syncDeadline(ctx context.Context, saga *sagaAggregate[S]) error {
+	correlationID := TenantAggregateID(saga.GetTenant(), saga.State.CorrelationID)
+	if saga.State.Completed || saga.State.Deadline.IsZero() {
+		return p.deadlines.Cancel(ctx, p.config.Name, correlationID)
+	}
+	return p.deadlines.Schedule(ctx, p.config.Name, correlationID, saga.State.Deadline)
+}
+
+// dispatchPending dispatches the recorded commands in order, each dispatch is recorded on its own.
+func (p *ProcessManager[S]) dispatchPending(ctx context.Context, saga *sagaAggregate[S]) error {
+	for len(saga.pending) > 0 {
+		command := saga.pending[0]
+		if err := p.dispatcher.Dispatch(ctx, command); err != nil {
+			return errors.Wrapf(err, "Dispatch command: {%s}, aggregateID: {%s}", command.CommandType, command.AggregateID)
+		}
+
+		if err := saga.recordDispatched(command.ID); err != nil {
+			return err
+		}
+		if err := p.store.Save(ctx, saga); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,212 @@
+package es_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/EventStore/EventStore-Client-Go/esdb"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wassef911/eventually/internal/infrastructure/es"
+)
+
+type memoryStore struct {
+	streams map[string][]es.Event
+}
+
+func (m *memoryStore) Load(ctx context.Context, aggregate es.Aggregate) error {
+	for _, event := range m.streams[aggregate.GetID()] {
+		if err := aggregate.RaiseEvent(event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *memoryStore) Save(ctx context.Context, aggregate es.Aggregate) error {
+	m.streams[aggregate.GetID()] = append(m.streams[aggregate.GetID()], aggregate.GetUncommittedEvents()...)
+	aggregate.ClearUncommittedEvents()
+	return nil
+}
+
+func (m *memoryStore) Exists(ctx context.Context, streamID string) error {
+	if _, ok := m.streams[streamID]; !ok {
+		return errors.Wrap(esdb.ErrStreamNotFound, "Exists")
+	}
+	return nil
+}
+
+type memoryDeadlines struct {
+	deadlines map[string]time.Time
+}
+
+func (m *memoryDeadlines) Schedule(ctx context.Context, saga string, correlationID string, deadline time.Time) error {
+	m.deadlines[correlationID] = deadline
+	return nil
+}
+
+func (m *memoryDeadlines) Cancel(ctx context.Context, saga string, correlationID string) error {
+	delete(m.deadlines, correlationID)
+	return nil
+}
+
+func (m *memoryDeadlines) Due(ctx context.Context, saga string, now time.Time) ([]string, error) {
+	due := make([]string, 0)
+	for correlationID, deadline := range m.deadlines {
+		if !deadline.After(now) {
+			due = append(due, correlationID)
+		}
+	}
+	return due, nil
+}
+
+type recordingDispatcher struct {
+	commands []es.SagaCommand
+	failures int
+}
+
+func (r *recordingDispatcher) Dispatch(ctx context.Context, command es.SagaCommand) error {
+	if r.failures > 0 {
+		r.failures--
+		return errors.New("handler unavailable")
+	}
+	r.commands = append(r.commands, command)
+	return nil
+}
+
+type counterData struct {
+	Count int `json:"count"`
+}
+
+type notifyCommand struct {
+	es.BaseCommand
+	Count int `json:"count"`
+}
+
+// counterSaga counts the events of a stream and expires one minute after its first event.
+type counterSaga struct {
+	handled int
+	now     time.Time
+	// failing times out with an error for this correlation id
+	failing string
+}
+
+func (s *counterSaga) CorrelationID(evt es.Event) string {
+	return evt.GetAggregateID()
+}
+
+func (s *counterSaga) Handle(ctx context.Context, state *es.SagaState[counterData], evt es.Event) ([]es.SagaCommand, error) {
+	s.handled++
+	state.Data.Count++
+	if state.Deadline.IsZero() {
+		state.Deadline = s.now.Add(time.Minute)
+	}
+
+	command, err := es.NewSagaCommand("NOTIFY", &notifyCommand{BaseCommand: es.NewBaseCommand(state.CorrelationID), Count: state.Data.Count})
+	if err != nil {
+		return nil, err
+	}
+	return []es.SagaCommand{command}, nil
+}
+
+func (s *counterSaga) Timeout(ctx context.Context, state *es.SagaState[counterData]) ([]es.SagaCommand, error) {
+	if state.CorrelationID == s.failing {
+		return nil, errors.New("poisoned saga")
+	}
+	state.Completed = true
+	command, err := es.NewSagaCommand("EXPIRE", &notifyCommand{BaseCommand: es.NewBaseCommand(state.CorrelationID), Count: state.Data.Count})
+	if err != nil {
+		return nil, err
+	}
+	return []es.SagaCommand{command}, nil
+}
+
+func newTestProcessManager(saga *counterSaga, dispatcher *recordingDispatcher) (*es.ProcessManager[counterData], *memoryDeadlines) {
+	deadlines := &memoryDeadlines{deadlines: make(map[string]time.Time)}
+	store := &memoryStore{streams: make(map[string][]es.Event)}
+	config := es.ProcessManagerConfig{Name: "counter_saga", GroupName: "counter_saga", Prefixes: []string{"counter-"}}
+	return es.NewProcessManager[counterData](nil, nil, store, deadlines, dispatcher, saga, config), deadlines
+}
+
+func testEvent(id string) es.Event {
+	return es.Event{EventID: id, EventType: "COUNTED", AggregateID: "counter-1"}
+}
+
+func TestProcessManagerHandlesRedeliveredEventOnce(t *testing.T) {
+	ctx := context.Background()
+	saga := &counterSaga{now: time.Now()}
+	dispatcher := &recordingDispatcher{}
+	pm, _ := newTestProcessManager(saga, dispatcher)
+
+	require.NoError(t, pm.HandleEvent(ctx, testEvent("event-1")))
+	require.NoError(t, pm.HandleEvent(ctx, testEvent("event-1")))
+	require.NoError(t, pm.HandleEvent(ctx, testEvent("event-2")))
+
+	assert.Equal(t, 2, saga.handled)
+	require.Len(t, dispatcher.commands, 2)
+
+	var command notifyCommand
+	require.NoError(t, dispatcher.commands[1].GetJsonData(&command))
+	assert.Equal(t, 2, command.Count)
+	assert.Equal(t, "counter-1", dispatcher.commands[1].AggregateID)
+}
+
+func TestProcessManagerRetriesRecordedCommands(t *testing.T) {
+	ctx := context.Background()
+	saga := &counterSaga{now: time.Now()}
+	dispatcher := &recordingDispatcher{failures: 1}
+	pm, _ := newTestProcessManager(saga, dispatcher)
+
+	assert.Error(t, pm.HandleEvent(ctx, testEvent("event-1")))
+	assert.Empty(t, dispatcher.commands)
+
+	require.NoError(t, pm.HandleEvent(ctx, testEvent("event-1")))
+	assert.Equal(t, 1, saga.handled, "the redelivered event only dispatches the recorded command")
+	assert.Len(t, dispatcher.commands, 1)
+}
+
+func TestProcessManagerDeadlines(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	saga := &counterSaga{now: now}
+	dispatcher := &recordingDispatcher{}
+	pm, deadlines := newTestProcessManager(saga, dispatcher)
+
+	require.NoError(t, pm.HandleEvent(ctx, testEvent("event-1")))
+	assert.True(t, now.Add(time.Minute).Equal(deadlines.deadlines["counter-1"]))
+
+	require.NoError(t, pm.HandleDeadlines(ctx, now))
+	assert.Len(t, dispatcher.commands, 1)
+
+	require.NoError(t, pm.HandleDeadlines(ctx, now.Add(time.Minute)))
+	require.Len(t, dispatcher.commands, 2)
+	assert.Equal(t, "EXPIRE", dispatcher.commands[1].CommandType)
+	assert.Empty(t, deadlines.deadlines)
+
+	require.NoError(t, pm.HandleEvent(ctx, testEvent("event-2")))
+	assert.Equal(t, 1, saga.handled, "a completed saga ignores further events")
+	assert.Len(t, dispatcher.commands, 2)
+}
+
+func TestProcessManagerDeadlinesSkipFailingSaga(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	saga := &counterSaga{now: now, failing: "counter-1"}
+	dispatcher := &recordingDispatcher{}
+	pm, deadlines := newTestProcessManager(saga, dispatcher)
+
+	require.NoError(t, pm.HandleEvent(ctx, testEvent("event-1")))
+	require.NoError(t, pm.HandleEvent(ctx, es.Event{EventID: "event-2", EventType: "COUNTED", AggregateID: "counter-2"}))
+
+	expiresAt := now.Add(time.Minute)
+	assert.Error(t, pm.HandleDeadlines(ctx, expiresAt))
+	require.Len(t, dispatcher.commands, 3, "the other saga timed out")
+	assert.Equal(t, "EXPIRE", dispatcher.commands[2].CommandType)
+	assert.Equal(t, "counter-2", dispatcher.commands[2].AggregateID)
+	assert.True(t, expiresAt.Add(time.Minute).Equal(deadlines.deadlines["counter-1"]), "the failing deadline is retried later")
+
+	require.NoError(t, pm.HandleDeadlines(ctx, expiresAt.Add(time.Second)))
+}
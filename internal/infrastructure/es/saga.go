@@ -0,0 +1,166 @@
+package es
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/pkg/errors"
+	uuid "github.com/satori/go.uuid"
+)
+
+const (
+	SagaStepRecorded      = "SAGA_STEP_RECORDED"
+	SagaCommandDispatched = "SAGA_COMMAND_DISPATCHED"
+)
+
+// Saga reacts to events of other aggregates with commands, its state is kept per correlation id.
+// Handle and Timeout must not have side effects, everything they decide is returned as commands.
+type Saga[S any] interface {
+	// CorrelationID returns the saga instance the event belongs to, events with an empty id are ignored.
+	CorrelationID(evt Event) string
+	// Handle updates the state with the event and returns the commands to dispatch.
+	Handle(ctx context.Context, state *SagaState[S], evt Event) ([]SagaCommand, error)
+	// Timeout is called once the state deadline passed, the deadline is cleared unless Timeout moves it.
+	Timeout(ctx context.Context, state *SagaState[S]) ([]SagaCommand, error)
+}
+
+// SagaState is persisted after every step, a completed saga ignores further events.
+type SagaState[S any] struct {
+	CorrelationID string    `json:"correlationId"`
+	Data          S         `json:"data"`
+	Deadline      time.Time `json:"deadline,omitempty"`
+	Completed     bool      `json:"completed"`
+}
+
+// SagaCommand a command decided by a saga, it is recorded before it is dispatched.
+type SagaCommand struct {
+	ID          string          `json:"id"`
+	CommandType string          `json:"commandType"`
+	AggregateID string          `json:"aggregateId"`
+	Data        json.RawMessage `json:"data"`
+}
+
+func NewSagaCommand(commandType string, command Command) (SagaCommand, error) {
+	data, err := json.Marshal(command)
+	if err != nil {
+		return SagaCommand{}, errors.Wrap(err, "json.Marshal")
+	}
+	return SagaCommand{
+		ID:          uuid.NewV4().String(),
+		CommandType: commandType,
+		AggregateID: command.GetAggregateID(),
+		Data:        data,
+	}, nil
+}
+
+// GetJsonData decodes the command into its concrete type.
+func (c *SagaCommand) GetJsonData(command interface{}) error {
+	return json.Unmarshal(c.Data, command)
+}
+
+// CommandDispatcher sends saga commands to their handlers. A command is dispatched again when the
+// process stopped before its dispatch was recorded, dispatchers ignore the duplicates by SagaCommand.ID
+// saved with the events of the command, see ContextWithCommandID.
+type CommandDispatcher interface {
+	Dispatch(ctx context.Context, command SagaCommand) error
+}
+
+// DeadlineStore indexes the saga deadlines so they can be found without reading every saga stream.
+type DeadlineStore interface {
+	Schedule(ctx context.Context, saga string, correlationID string, deadline time.Time) error
+	Cancel(ctx context.Context, saga string, correlationID string) error
+	Due(ctx context.Context, saga string, now time.Time) ([]string, error)
+}
+
+// SagaStore persists saga state, store.AggregateStore implements it.
+type SagaStore interface {
+	Load(ctx context.Context, aggregate Aggregate) error
+	Save(ctx context.Context, aggregate Aggregate) error
+	Exists(ctx context.Context, streamID string) error
+}
+
+type sagaStepRecordedEvent[S any] struct {
+	EventID  string        `json:"eventId"`
+	State    SagaState[S]  `json:"state"`
+	Commands []SagaCommand `json:"commands,omitempty"`
+}
+
+type sagaCommandDispatchedEvent struct {
+	CommandID string `json:"commandId"`
+}
+
+// sagaAggregate event sourced saga state, its stream is "<saga name>-<correlation id>".
+type sagaAggregate[S any] struct {
+	*AggregateBase
+	State   SagaState[S]
+	handled map[string]struct{}
+	pending []SagaCommand
+}
+
+func newSagaAggregate[S any](name string, correlationID string) *sagaAggregate[S] {
+	sagaAggregate := &sagaAggregate[S]{
+		State:   SagaState[S]{CorrelationID: correlationID},
+		handled: make(map[string]struct{}),
+		pending: make([]SagaCommand, 0),
+	}
+	base := NewAggregateBase(sagaAggregate.When)
+	base.SetType(AggregateType(name))
+	base.SetID(correlationID)
+	sagaAggregate.AggregateBase = base
+	return sagaAggregate
+}
+
+func (a *sagaAggregate[S]) When(evt Event) error {
+
+	switch evt.GetEventType() {
+
+	case SagaStepRecorded:
+		var eventData sagaStepRecordedEvent[S]
+		if err := evt.GetJsonData(&eventData); err != nil {
+			return errors.Wrap(err, "GetJsonData")
+		}
+		a.State = eventData.State
+		a.handled[eventData.EventID] = struct{}{}
+		a.pending = append(a.pending, eventData.Commands...)
+		return nil
+
+	case SagaCommandDispatched:
+		var eventData sagaCommandDispatchedEvent
+		if err := evt.GetJsonData(&eventData); err != nil {
+			return errors.Wrap(err, "GetJsonData")
+		}
+		pending := make([]SagaCommand, 0, len(a.pending))
+		for _, command := range a.pending {
+			if command.ID != eventData.CommandID {
+				pending = append(pending, command)
+			}
+		}
+		a.pending = pending
+		return nil
+
+	default:
+		return ErrInvalidEventType
+	}
+}
+
+func (a *sagaAggregate[S]) isHandled(eventID string) bool {
+	_, ok := a.handled[eventID]
+	return ok
+}
+
+func (a *sagaAggregate[S]) recordStep(eventID string, state SagaState[S], commands []SagaCommand) error {
+	event := NewBaseEvent(a, SagaStepRecorded)
+	if err := event.SetJsonData(&sagaStepRecordedEvent[S]{EventID: eventID, State: state, Commands: commands}); err != nil {
+		return errors.Wrap(err, "SetJsonData")
+	}
+	return a.Apply(event)
+}
+
+func (a *sagaAggregate[S]) recordDispatched(commandID string) error {
+	event := NewBaseEvent(a, SagaCommandDispatched)
+	if err := event.SetJsonData(&sagaCommandDispatchedEvent{CommandID: commandID}); err != nil {
+		return errors.Wrap(err, "SetJsonData")
+	}
+	return a.Apply(event)
+}
@@ -33,6 +33,11 @@ func (a *aggregateStore) Load(ctx context.Context, aggregate es.Aggregate) error
 	defer span.Finish()
 	span.LogFields(log.String("AggregateID", aggregate.GetID()))
 
+	if err := checkTenant(ctx, aggregate); err != nil {
+		tracing.TraceErr(span, err)
+		return err
+	}
+
 	stream, err := a.db.ReadStream(ctx, aggregate.GetID(), esdb.ReadStreamOptions{}, count)
 	if err != nil {
 		tracing.TraceErr(span, err)
@@ -54,7 +59,12 @@ func (a *aggregateStore) Load(ctx context.Context, aggregate es.Aggregate) error
 			return errors.Wrap(err, "stream.Recv")
 		}
 
-		esEvent := es.NewEventFromRecorded(event.Event)
+		esEvent, err := es.Upcast(es.NewEventFromRecorded(event.Event))
+		if err != nil {
+			tracing.TraceErr(span, err)
+			return errors.Wrap(err, "Upcast")
+		}
+
 		if err := aggregate.RaiseEvent(esEvent); err != nil {
 			tracing.TraceErr(span, err)
 			return errors.Wrap(err, "RaiseEvent")
@@ -73,8 +83,27 @@ func (a *aggregateStore) Save(ctx context.Context, aggregate es.Aggregate) error
 		return nil
 	}
 
+	if err := checkTenant(ctx, aggregate); err != nil {
+		tracing.TraceErr(span, err)
+		return err
+	}
+
+	actor, hasActor := es.ActorFromContext(ctx)
+	commandID, hasCommandID := es.CommandIDFromContext(ctx)
 	eventsData := make([]esdb.EventData, 0, len(aggregate.GetUncommittedEvents()))
 	for _, event := range aggregate.GetUncommittedEvents() {
+		if hasActor {
+			if err := event.SetActor(actor); err != nil {
+				tracing.TraceErr(span, err)
+				return errors.Wrap(err, "SetActor")
+			}
+		}
+		if hasCommandID {
+			if err := event.SetCommandID(commandID); err != nil {
+				tracing.TraceErr(span, err)
+				return errors.Wrap(err, "SetCommandID")
+			}
+		}
 		eventsData = append(eventsData, event.ToEventData())
 	}
 
@@ -93,6 +122,7 @@ func (a *aggregateStore) Save(ctx context.Context, aggregate es.Aggregate) error
 			return errors.Wrap(err, "db.AppendToStream")
 		}
 
+		aggregate.ClearUncommittedEvents()
 		return nil
 	}
 
@@ -158,3 +188,13 @@ func (a *aggregateStore) Exists(ctx context.Context, streamID string) error {
 
 	return nil
 }
+
+// checkTenant aggregates are only read and written on behalf of their own tenant, in the stream of that tenant.
+// The stream check rejects ids carrying the tenant separator.
+func checkTenant(ctx context.Context, aggregate es.Aggregate) error {
+	tenant := es.TenantFromContext(ctx)
+	if aggregate.GetTenant() != tenant || es.StreamTenant(aggregate.GetID()) != tenant {
+		return errors.Wrapf(es.ErrTenantMismatch, "aggregate: {%s}, tenant: {%s}", aggregate.GetID(), tenant)
+	}
+	return nil
+}
@@ -49,11 +49,11 @@ func (e *eventStore) LoadEvents(ctx context.Context, streamID string) ([]es.Even
 
 	stream, err := e.db.ReadStream(ctx, streamID, esdb.ReadStreamOptions{
 		Direction: esdb.Forwards,
-		From:      esdb.Revision(1),
-	}, 100)
+		From:      esdb.Start{},
+	}, count)
 	if err != nil {
 		tracing.TraceErr(span, err)
-		return nil, err
+		return nil, errors.Wrap(err, "db.ReadStream")
 	}
 	defer stream.Close()
 
@@ -63,11 +63,17 @@ func (e *eventStore) LoadEvents(ctx context.Context, streamID string) ([]es.Even
 		if errors.Is(err, io.EOF) {
 			break
 		}
+		if err != nil {
+			tracing.TraceErr(span, err)
+			return nil, errors.Wrap(err, "stream.Recv")
+		}
+
+		esEvent, err := es.Upcast(es.NewEventFromRecorded(event.Event))
 		if err != nil {
 			tracing.TraceErr(span, err)
 			return nil, err
 		}
-		events = append(events, es.NewEventFromRecorded(event.Event))
+		events = append(events, esEvent)
 	}
 
 	return events, nil
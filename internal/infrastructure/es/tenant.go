@@ -0,0 +1,67 @@
+package es
+
+import (
+	"context"
+	"regexp"
+	"strings"
+)
+
+// TenantSeparator separates the tenant from the aggregate id in a stream id: {type}-{tenant}~{id}.
+// Aggregates of the default tenant keep the {type}-{id} stream, ids must not contain the separator.
+const TenantSeparator = "~"
+
+var tenantIDPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9-]{0,31}$`)
+
+// ValidTenantID tenant ids are lowercase so they can prefix mongo collections and elastic indices.
+func ValidTenantID(tenant string) bool {
+	return tenantIDPattern.MatchString(tenant)
+}
+
+type tenantKey struct{}
+
+// ContextWithTenant aggregates, projections and repositories used with the returned context belong to the tenant.
+func ContextWithTenant(ctx context.Context, tenant string) context.Context {
+	return context.WithValue(ctx, tenantKey{}, tenant)
+}
+
+// TenantFromContext the tenant of ctx, empty for the default tenant.
+func TenantFromContext(ctx context.Context) string {
+	tenant, _ := ctx.Value(tenantKey{}).(string)
+	return tenant
+}
+
+// TenantAggregateID id of the aggregate of the tenant inside its stream id.
+func TenantAggregateID(tenant string, id string) string {
+	if tenant == "" {
+		return id
+	}
+	return tenant + TenantSeparator + id
+}
+
+// SplitTenantAggregateID reverses TenantAggregateID.
+func SplitTenantAggregateID(tenantID string) (tenant string, id string) {
+	if tenant, id, ok := strings.Cut(tenantID, TenantSeparator); ok {
+		return tenant, id
+	}
+	return "", tenantID
+}
+
+// StreamTenant tenant of a {type}-{tenant}~{id} stream id, aggregate types do not contain a dash.
+func StreamTenant(streamID string) string {
+	_, tenantID, _ := strings.Cut(streamID, "-")
+	tenant, _ := SplitTenantAggregateID(tenantID)
+	return tenant
+}
+
+// TenantName scopes a collection or index name to the tenant, the default tenant keeps the name.
+func TenantName(tenant string, name string) string {
+	if tenant == "" {
+		return name
+	}
+	return tenant + "_" + name
+}
+
+// GetTenant tenant of the aggregate the event belongs to.
+func (e *Event) GetTenant() string {
+	return StreamTenant(e.GetAggregateID())
+}
@@ -0,0 +1,59 @@
+package es_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/wassef911/eventually/internal/infrastructure/es"
+)
+
+func TestTenantAggregateID(t *testing.T) {
+	assert.Equal(t, "1", es.TenantAggregateID("", "1"))
+	assert.Equal(t, "acme~1", es.TenantAggregateID("acme", "1"))
+
+	tenant, id := es.SplitTenantAggregateID("acme~1")
+	assert.Equal(t, "acme", tenant)
+	assert.Equal(t, "1", id)
+
+	tenant, id = es.SplitTenantAggregateID("1")
+	assert.Empty(t, tenant)
+	assert.Equal(t, "1", id)
+
+	assert.Equal(t, "acme", es.StreamTenant("order-acme~1"))
+	assert.Empty(t, es.StreamTenant("order-1"))
+	assert.Equal(t, "acme", (&es.Event{AggregateID: "inventory_saga-acme~1"}).GetTenant())
+}
+
+func TestTenantName(t *testing.T) {
+	assert.Equal(t, "orders", es.TenantName("", "orders"))
+	assert.Equal(t, "acme_orders", es.TenantName("acme", "orders"))
+
+	assert.Equal(t, "acme", es.TenantFromContext(es.ContextWithTenant(context.Background(), "acme")))
+	assert.Empty(t, es.TenantFromContext(context.Background()))
+}
+
+func TestValidTenantID(t *testing.T) {
+	assert.True(t, es.ValidTenantID("acme"))
+	assert.True(t, es.ValidTenantID("shop-2"))
+	assert.False(t, es.ValidTenantID(""))
+	assert.False(t, es.ValidTenantID("Acme"))
+	assert.False(t, es.ValidTenantID("acme~1"))
+	assert.False(t, es.ValidTenantID("-acme"))
+}
+
+func TestAggregateBase_SetTenant(t *testing.T) {
+	aggregate := es.NewAggregateBase(func(evt es.Event) error { return nil })
+	aggregate.SetType("order")
+
+	aggregate.SetID("1")
+	assert.Equal(t, "order-1", aggregate.GetID())
+
+	aggregate.SetTenant("acme")
+	assert.Equal(t, "order-acme~1", aggregate.GetID())
+	assert.Equal(t, "acme", aggregate.GetTenant())
+
+	aggregate.SetID("2")
+	assert.Equal(t, "order-acme~2", aggregate.GetID())
+}
@@ -0,0 +1,39 @@
+package es
+
+import (
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// Upcaster transforms an Event recorded with an older data schema into the current one.
+// Upcasters must be idempotent: events already in the current schema are returned unchanged.
+type Upcaster func(event Event) (Event, error)
+
+var (
+	upcastersMu sync.RWMutex
+	upcasters   = make(map[string][]Upcaster)
+)
+
+// RegisterUpcaster register Upcaster for the given event type, upcasters run in registration order.
+func RegisterUpcaster(eventType string, upcaster Upcaster) {
+	upcastersMu.Lock()
+	defer upcastersMu.Unlock()
+	upcasters[eventType] = append(upcasters[eventType], upcaster)
+}
+
+// Upcast apply all registered upcasters for the Event type, should be used on every Event read from the event store.
+func Upcast(event Event) (Event, error) {
+	upcastersMu.RLock()
+	chain := upcasters[event.GetEventType()]
+	upcastersMu.RUnlock()
+
+	for _, upcaster := range chain {
+		upcasted, err := upcaster(event)
+		if err != nil {
+			return Event{}, errors.Wrapf(err, "upcast eventType: {%s}", event.GetEventType())
+		}
+		event = upcasted
+	}
+	return event, nil
+}
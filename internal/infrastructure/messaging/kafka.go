@@ -0,0 +1,50 @@
+package messaging
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/segmentio/kafka-go"
+)
+
+const kafkaWriteTimeout = 10 * time.Second
+
+type KafkaConfig struct {
+	Brokers []string `mapstructure:"brokers"`
+}
+
+// KafkaPublisher messages are partitioned by key, so the messages of a key keep their order.
+type KafkaPublisher struct {
+	writer *kafka.Writer
+}
+
+func NewKafkaPublisher(topic string, config KafkaConfig) *KafkaPublisher {
+	return &KafkaPublisher{writer: &kafka.Writer{
+		Addr:         kafka.TCP(config.Brokers...),
+		Topic:        topic,
+		Balancer:     &kafka.Hash{},
+		RequiredAcks: kafka.RequireAll,
+		WriteTimeout: kafkaWriteTimeout,
+	}}
+}
+
+func (p *KafkaPublisher) Publish(ctx context.Context, messages ...Message) error {
+	kafkaMessages := make([]kafka.Message, 0, len(messages))
+	for _, message := range messages {
+		headers := make([]kafka.Header, 0, len(message.Headers))
+		for key, value := range message.Headers {
+			headers = append(headers, kafka.Header{Key: key, Value: []byte(value)})
+		}
+		kafkaMessages = append(kafkaMessages, kafka.Message{Key: []byte(message.Key), Value: message.Value, Headers: headers})
+	}
+
+	if err := p.writer.WriteMessages(ctx, kafkaMessages...); err != nil {
+		return errors.Wrap(err, "kafka.WriteMessages")
+	}
+	return nil
+}
+
+func (p *KafkaPublisher) Close() error {
+	return p.writer.Close()
+}
@@ -0,0 +1,48 @@
+package messaging
+
+import (
+	"context"
+	"sync"
+)
+
+// MemoryPublisher keeps the published messages in memory, used by tests and local runs without a broker.
+type MemoryPublisher struct {
+	mu       sync.Mutex
+	messages []Message
+	err      error
+}
+
+func NewMemoryPublisher() *MemoryPublisher {
+	return &MemoryPublisher{messages: make([]Message, 0)}
+}
+
+func (p *MemoryPublisher) Publish(ctx context.Context, messages ...Message) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.err != nil {
+		return p.err
+	}
+	p.messages = append(p.messages, messages...)
+	return nil
+}
+
+// SetErr makes Publish fail with err until it is set back to nil.
+func (p *MemoryPublisher) SetErr(err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.err = err
+}
+
+// Messages copy of the messages published so far.
+func (p *MemoryPublisher) Messages() []Message {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return append([]Message(nil), p.messages...)
+}
+
+func (p *MemoryPublisher) Close() error {
+	return nil
+}
@@ -0,0 +1,60 @@
+package messaging
+
+import (
+	"context"
+
+	"github.com/nats-io/nats.go"
+	"github.com/pkg/errors"
+)
+
+// MessageIDHeader header holding the message id, JetStream drops messages published twice with the same id.
+const MessageIDHeader = "id"
+
+type NatsConfig struct {
+	URL string `mapstructure:"url"`
+}
+
+// NatsPublisher publishes to JetStream on the subject "<topic>.<key>", the stream keeps the order of each subject.
+type NatsPublisher struct {
+	topic string
+	conn  *nats.Conn
+	js    nats.JetStreamContext
+}
+
+func NewNatsPublisher(topic string, config NatsConfig) (*NatsPublisher, error) {
+	conn, err := nats.Connect(config.URL)
+	if err != nil {
+		return nil, errors.Wrap(err, "nats.Connect")
+	}
+
+	js, err := conn.JetStream()
+	if err != nil {
+		conn.Close()
+		return nil, errors.Wrap(err, "conn.JetStream")
+	}
+
+	return &NatsPublisher{topic: topic, conn: conn, js: js}, nil
+}
+
+func (p *NatsPublisher) Publish(ctx context.Context, messages ...Message) error {
+	for _, message := range messages {
+		msg := nats.NewMsg(p.topic + "." + message.Key)
+		msg.Data = message.Value
+		for key, value := range message.Headers {
+			msg.Header.Set(key, value)
+		}
+
+		opts := []nats.PubOpt{nats.Context(ctx)}
+		if id := message.Headers[MessageIDHeader]; id != "" {
+			opts = append(opts, nats.MsgId(id))
+		}
+		if _, err := p.js.PublishMsg(msg, opts...); err != nil {
+			return errors.Wrap(err, "js.PublishMsg")
+		}
+	}
+	return nil
+}
+
+func (p *NatsPublisher) Close() error {
+	return p.conn.Drain()
+}
@@ -0,0 +1,50 @@
+package messaging
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	KafkaBroker  = "kafka"
+	NatsBroker   = "nats"
+	MemoryBroker = "memory"
+)
+
+var ErrUnknownBroker = errors.New("unknown message broker")
+
+type Config struct {
+	Broker string `mapstructure:"broker"`
+	// Topic kafka topic, or the subject prefix of the nats stream.
+	Topic string      `mapstructure:"topic"`
+	Kafka KafkaConfig `mapstructure:"kafka"`
+	Nats  NatsConfig  `mapstructure:"nats"`
+}
+
+// Message Key orders the messages, the messages of one key are delivered in the order they were published.
+type Message struct {
+	Key     string
+	Value   []byte
+	Headers map[string]string
+}
+
+// Publisher sends messages to a broker, Publish returns once the broker acknowledged every message.
+type Publisher interface {
+	Publish(ctx context.Context, messages ...Message) error
+	Close() error
+}
+
+// NewPublisher publisher of the configured broker.
+func NewPublisher(config Config) (Publisher, error) {
+	switch config.Broker {
+	case KafkaBroker:
+		return NewKafkaPublisher(config.Topic, config.Kafka), nil
+	case NatsBroker:
+		return NewNatsPublisher(config.Topic, config.Nats)
+	case MemoryBroker:
+		return NewMemoryPublisher(), nil
+	default:
+		return nil, errors.Wrapf(ErrUnknownBroker, "broker: {%s}", config.Broker)
+	}
+}
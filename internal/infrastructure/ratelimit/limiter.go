@@ -0,0 +1,131 @@
+package ratelimit
+
+import (
+	"context"
+	"crypto/subtle"
+	"math"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	MemoryBackend = "memory"
+	RedisBackend  = "redis"
+
+	KeyByIP     = "ip"
+	KeyByAPIKey = "apikey"
+	KeyByTenant = "tenant"
+)
+
+var ErrUnknownBackend = errors.New("unknown rate limit backend")
+
+type Config struct {
+	// Enabled limits the requests of every client to the api.
+	Enabled bool `mapstructure:"enabled"`
+	// Backend keeps the buckets: "memory" per instance, "redis" shared by every instance.
+	Backend string `mapstructure:"backend"`
+	// KeyBy client a bucket belongs to: "ip", "apikey" (falls back to the ip) or "tenant".
+	KeyBy string `mapstructure:"keyBy"`
+	// APIKeyHeader header carrying the api key of a client.
+	APIKeyHeader string `mapstructure:"apiKeyHeader"`
+	// APIKeys keys issued to the clients, any other key is limited by the ip of the request.
+	APIKeys []string `mapstructure:"apiKeys"`
+	// TrustedProxies CIDR ranges of the proxies in front of the api, only they may name the client in X-Forwarded-For.
+	TrustedProxies []string `mapstructure:"trustedProxies"`
+	// Commands limit of the requests changing state, Queries of the reads.
+	Commands Limit       `mapstructure:"commands"`
+	Queries  Limit       `mapstructure:"queries"`
+	Redis    RedisConfig `mapstructure:"redis"`
+}
+
+// IssuedAPIKey the key is one of the issued api keys, a client cannot get a fresh bucket by making keys up.
+func (c Config) IssuedAPIKey(key string) bool {
+	issued := false
+	for _, apiKey := range c.APIKeys {
+		if apiKey != "" && subtle.ConstantTimeCompare([]byte(apiKey), []byte(key)) == 1 {
+			issued = true
+		}
+	}
+	return issued
+}
+
+// Limit token bucket refilled with Requests tokens every Period, holding at most Burst tokens (Requests when zero).
+type Limit struct {
+	Requests int           `mapstructure:"requests"`
+	Period   time.Duration `mapstructure:"period"`
+	Burst    int           `mapstructure:"burst"`
+}
+
+// Unlimited limits without requests or period are not enforced.
+func (l Limit) Unlimited() bool {
+	return l.Requests <= 0 || l.Period <= 0
+}
+
+func (l Limit) capacity() float64 {
+	if l.Burst > 0 {
+		return float64(l.Burst)
+	}
+	return float64(l.Requests)
+}
+
+// rate tokens refilled per second.
+func (l Limit) rate() float64 {
+	return float64(l.Requests) / l.Period.Seconds()
+}
+
+// take refills the tokens left elapsed ago and takes one when available.
+func (l Limit) take(tokens float64, elapsed time.Duration) (float64, bool) {
+	tokens = math.Min(l.capacity(), tokens+math.Max(0, elapsed.Seconds())*l.rate())
+	if tokens < 1 {
+		return tokens, false
+	}
+	return tokens - 1, true
+}
+
+// result of a request that left tokens in the bucket.
+func (l Limit) result(tokens float64, allowed bool) Result {
+	result := Result{
+		Allowed:   allowed,
+		Limit:     int(l.capacity()),
+		Remaining: int(math.Floor(tokens)),
+		Reset:     l.after(l.capacity() - tokens),
+	}
+	if !allowed {
+		result.RetryAfter = l.after(1 - tokens)
+	}
+	return result
+}
+
+// after time until missing tokens are refilled.
+func (l Limit) after(missing float64) time.Duration {
+	return time.Duration(math.Ceil(missing / l.rate() * float64(time.Second)))
+}
+
+// Result Remaining requests a client may send right now, Reset time until its bucket is full again
+// and, for a rejected request, RetryAfter time until its next request is accepted.
+type Result struct {
+	Allowed    bool
+	Limit      int
+	Remaining  int
+	Reset      time.Duration
+	RetryAfter time.Duration
+}
+
+// Limiter takes a token of the bucket of key for every request.
+type Limiter interface {
+	Allow(ctx context.Context, key string, limit Limit) (Result, error)
+	Close() error
+}
+
+// NewLimiter limiter of the configured backend.
+func NewLimiter(config Config) (Limiter, error) {
+	switch config.Backend {
+	case MemoryBackend, "":
+		return NewMemoryLimiter(), nil
+	case RedisBackend:
+		return NewRedisLimiter(config.Redis), nil
+	default:
+		return nil, errors.Wrapf(ErrUnknownBackend, "backend: {%s}", config.Backend)
+	}
+}
@@ -0,0 +1,86 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryLimiter_TokenBucket(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	limiter := NewMemoryLimiter()
+	limiter.now = func() time.Time { return now }
+	limit := Limit{Requests: 60, Period: time.Minute, Burst: 2}
+
+	result, err := limiter.Allow(ctx, "client", limit)
+	require.NoError(t, err)
+	assert.Equal(t, Result{Allowed: true, Limit: 2, Remaining: 1, Reset: time.Second}, result)
+
+	result, err = limiter.Allow(ctx, "client", limit)
+	require.NoError(t, err)
+	assert.True(t, result.Allowed)
+	assert.Equal(t, 0, result.Remaining)
+
+	result, err = limiter.Allow(ctx, "client", limit)
+	require.NoError(t, err)
+	assert.False(t, result.Allowed, "burst used up")
+	assert.Equal(t, time.Second, result.RetryAfter)
+	assert.Equal(t, 2*time.Second, result.Reset)
+
+	result, err = limiter.Allow(ctx, "other", limit)
+	require.NoError(t, err)
+	assert.True(t, result.Allowed, "every key has its own bucket")
+
+	now = now.Add(time.Second)
+	result, err = limiter.Allow(ctx, "client", limit)
+	require.NoError(t, err)
+	assert.True(t, result.Allowed, "one token refilled")
+	assert.Equal(t, 0, result.Remaining)
+
+	now = now.Add(time.Hour)
+	result, err = limiter.Allow(ctx, "client", limit)
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Remaining, "refill stops at the burst")
+}
+
+func TestMemoryLimiter_SweepsFullBuckets(t *testing.T) {
+	now := time.Now()
+	limiter := NewMemoryLimiter()
+	limiter.now = func() time.Time { return now }
+	limit := Limit{Requests: 10, Period: time.Second}
+
+	_, err := limiter.Allow(context.Background(), "client", limit)
+	require.NoError(t, err)
+
+	now = now.Add(sweepInterval)
+	_, err = limiter.Allow(context.Background(), "other", limit)
+	require.NoError(t, err)
+	assert.NotContains(t, limiter.buckets, "client")
+	assert.Contains(t, limiter.buckets, "other")
+}
+
+func TestLimit(t *testing.T) {
+	assert.True(t, Limit{}.Unlimited())
+	assert.True(t, Limit{Requests: 10}.Unlimited())
+	assert.False(t, Limit{Requests: 10, Period: time.Second}.Unlimited())
+	assert.Equal(t, float64(10), Limit{Requests: 10, Period: time.Second}.capacity())
+}
+
+func TestNewLimiter(t *testing.T) {
+	limiter, err := NewLimiter(Config{Backend: MemoryBackend})
+	require.NoError(t, err)
+	assert.IsType(t, &MemoryLimiter{}, limiter)
+
+	limiter, err = NewLimiter(Config{Backend: RedisBackend, Redis: RedisConfig{Addr: "localhost:6379"}})
+	require.NoError(t, err)
+	assert.IsType(t, &RedisLimiter{}, limiter)
+	assert.NoError(t, limiter.Close())
+
+	_, err = NewLimiter(Config{Backend: "memcached"})
+	assert.True(t, errors.Is(err, ErrUnknownBackend))
+}
@@ -0,0 +1,72 @@
+package ratelimit
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/pkg/errors"
+	"github.com/redis/go-redis/v9"
+)
+
+const redisKeyPrefix = "ratelimit:"
+
+type RedisConfig struct {
+	Addr     string `mapstructure:"addr"`
+	Password string `mapstructure:"password"`
+	DB       int    `mapstructure:"db"`
+}
+
+// takeScript refills and takes a token of the bucket in one step, with the clock of the server so every
+// instance sees the same time. The bucket expires once it is full again.
+var takeScript = redis.NewScript(`
+local rate = tonumber(ARGV[1])
+local capacity = tonumber(ARGV[2])
+local time = redis.call('TIME')
+local now = tonumber(time[1]) * 1000000 + tonumber(time[2])
+
+local bucket = redis.call('HMGET', KEYS[1], 'tokens', 'updated')
+local tokens = tonumber(bucket[1]) or capacity
+local updated = tonumber(bucket[2]) or now
+
+tokens = math.min(capacity, tokens + math.max(0, now - updated) * rate / 1000000)
+local allowed = 0
+if tokens >= 1 then
+	tokens = tokens - 1
+	allowed = 1
+end
+
+redis.call('HSET', KEYS[1], 'tokens', tostring(tokens), 'updated', now)
+redis.call('PEXPIRE', KEYS[1], math.ceil((capacity - tokens) / rate * 1000) + 1000)
+return {allowed, tostring(tokens)}
+`)
+
+// RedisLimiter keeps the buckets in a redis compatible server shared by every instance.
+type RedisLimiter struct {
+	client *redis.Client
+}
+
+func NewRedisLimiter(config RedisConfig) *RedisLimiter {
+	return &RedisLimiter{client: redis.NewClient(&redis.Options{Addr: config.Addr, Password: config.Password, DB: config.DB})}
+}
+
+func (r *RedisLimiter) Allow(ctx context.Context, key string, limit Limit) (Result, error) {
+	reply, err := takeScript.Run(ctx, r.client, []string{redisKeyPrefix + key}, limit.rate(), limit.capacity()).Slice()
+	if err != nil {
+		return Result{}, errors.Wrap(err, "takeScript.Run")
+	}
+	if len(reply) != 2 {
+		return Result{}, errors.Errorf("unexpected takeScript reply: {%v}", reply)
+	}
+
+	allowed, _ := reply[0].(int64)
+	tokensReply, _ := reply[1].(string)
+	tokens, err := strconv.ParseFloat(tokensReply, 64)
+	if err != nil {
+		return Result{}, errors.Wrap(err, "strconv.ParseFloat")
+	}
+	return limit.result(tokens, allowed == 1), nil
+}
+
+func (r *RedisLimiter) Close() error {
+	return r.client.Close()
+}
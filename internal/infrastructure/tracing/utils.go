@@ -7,6 +7,7 @@ import (
 	"github.com/labstack/echo/v4"
 	"github.com/opentracing/opentracing-go"
 	"github.com/opentracing/opentracing-go/ext"
+	"google.golang.org/grpc/metadata"
 
 	"github.com/wassef911/eventually/internal/infrastructure/es"
 )
@@ -40,6 +41,33 @@ func StartHttpServerTracerSpan(c echo.Context, operationName string) (context.Co
 	return ctx, serverSpan
 }
 
+// StartGrpcServerTracerSpan continues the trace propagated in the incoming grpc metadata.
+func StartGrpcServerTracerSpan(ctx context.Context, operationName string) (context.Context, opentracing.Span) {
+	textMapCarrier := make(opentracing.TextMapCarrier)
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		for key, values := range md {
+			if len(values) > 0 {
+				textMapCarrier[key] = values[0]
+			}
+		}
+	}
+
+	var serverSpan opentracing.Span
+	spanCtx, err := opentracing.GlobalTracer().Extract(opentracing.TextMap, textMapCarrier)
+	if err != nil {
+		serverSpan = opentracing.GlobalTracer().StartSpan(operationName)
+	} else {
+		serverSpan = opentracing.GlobalTracer().StartSpan(
+			operationName,
+			ext.RPCServerOption(spanCtx),
+			opentracing.Tag{Key: string(ext.Component), Value: "gRPC"},
+			opentracing.Tag{Key: string(ext.SpanKind), Value: ext.SpanKindRPCServerEnum},
+		)
+	}
+
+	return opentracing.ContextWithSpan(ctx, serverSpan), serverSpan
+}
+
 func GetTextMapCarrierFromEvent(event es.Event) opentracing.TextMapCarrier {
 	metadataMap := make(opentracing.TextMapCarrier)
 	err := json.Unmarshal(event.GetMetadata(), &metadataMap)
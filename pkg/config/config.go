@@ -2,13 +2,16 @@ package config
 
 import (
 	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/spf13/viper"
 
 	"github.com/wassef911/eventually/internal/infrastructure/elasticsearch"
 	"github.com/wassef911/eventually/internal/infrastructure/eventstore"
+	"github.com/wassef911/eventually/internal/infrastructure/messaging"
 	"github.com/wassef911/eventually/internal/infrastructure/mongodb"
+	"github.com/wassef911/eventually/internal/infrastructure/ratelimit"
 	"github.com/wassef911/eventually/internal/infrastructure/tracing"
 	"github.com/wassef911/eventually/pkg/logger"
 )
@@ -23,26 +26,107 @@ type Config struct {
 	Subscriptions    Subscriptions               `mapstructure:"subscriptions"`
 	Elastic          elasticsearch.Config        `mapstructure:"elastic"`
 	ElasticIndexes   ElasticIndexes              `mapstructure:"elasticIndexes"`
+	Orders           Orders                      `mapstructure:"orders"`
+	Payments         Payments                    `mapstructure:"payments"`
+	Outbox           messaging.Config            `mapstructure:"outbox"`
+	Webhooks         Webhooks                    `mapstructure:"webhooks"`
+	Grpc             Grpc                        `mapstructure:"grpc"`
+	Auth             Auth                        `mapstructure:"auth"`
+	Tenancy          Tenancy                     `mapstructure:"tenancy"`
+	RateLimit        ratelimit.Config            `mapstructure:"rateLimit"`
 	Port             string                      `mapstructure:"port" validate:"required"`
 	Development      bool                        `mapstructure:"development"`
 	BasePath         string                      `mapstructure:"basePath" validate:"required"`
 }
 
 type MongoCollections struct {
-	Orders string `mapstructure:"orders" validate:"required"`
+	Orders        string `mapstructure:"orders" validate:"required"`
+	Coupons       string `mapstructure:"coupons" validate:"required"`
+	SagaDeadlines string `mapstructure:"sagaDeadlines" validate:"required"`
+	Customers     string `mapstructure:"customers" validate:"required"`
+	Products      string `mapstructure:"products" validate:"required"`
+	Webhooks      string `mapstructure:"webhooks" validate:"required"`
+	// WebhookDeliveries log of the webhook delivery attempts.
+	WebhookDeliveries string `mapstructure:"webhookDeliveries" validate:"required"`
 }
 
 type Subscriptions struct {
-	PoolSize                   int    `mapstructure:"poolSize" validate:"required,gte=0"`
-	OrderPrefix                string `mapstructure:"orderPrefix" validate:"required,gte=0"`
-	MongoProjectionGroupName   string `mapstructure:"mongoProjectionGroupName" validate:"required,gte=0"`
-	ElasticProjectionGroupName string `mapstructure:"elasticProjectionGroupName" validate:"required,gte=0"`
+	PoolSize                    int    `mapstructure:"poolSize" validate:"required,gte=0"`
+	OrderPrefix                 string `mapstructure:"orderPrefix" validate:"required,gte=0"`
+	MongoProjectionGroupName    string `mapstructure:"mongoProjectionGroupName" validate:"required,gte=0"`
+	ElasticProjectionGroupName  string `mapstructure:"elasticProjectionGroupName" validate:"required,gte=0"`
+	InventorySagaGroupName      string `mapstructure:"inventorySagaGroupName" validate:"required,gte=0"`
+	PaymentSagaGroupName        string `mapstructure:"paymentSagaGroupName" validate:"required,gte=0"`
+	InventoryPrefix             string `mapstructure:"inventoryPrefix" validate:"required,gte=0"`
+	CustomerPrefix              string `mapstructure:"customerPrefix" validate:"required,gte=0"`
+	CustomerProjectionGroupName string `mapstructure:"customerProjectionGroupName" validate:"required,gte=0"`
+	ProductPrefix               string `mapstructure:"productPrefix" validate:"required,gte=0"`
+	ProductProjectionGroupName  string `mapstructure:"productProjectionGroupName" validate:"required,gte=0"`
+	OutboxGroupName             string `mapstructure:"outboxGroupName" validate:"required,gte=0"`
+	WebhookGroupName            string `mapstructure:"webhookGroupName" validate:"required,gte=0"`
+	// SagaDeadlineInterval how often expired saga deadlines are looked up.
+	SagaDeadlineInterval time.Duration `mapstructure:"sagaDeadlineInterval"`
 }
 
 type ElasticIndexes struct {
 	Orders string `mapstructure:"orders" validate:"required"`
 }
 
+type Orders struct {
+	ReturnWindow time.Duration `mapstructure:"returnWindow"`
+	// ShippingFee flat shipping price in minor units of the order currency.
+	ShippingFee int64 `mapstructure:"shippingFee"`
+	// TaxRulesFile json tax table, no tax is charged when empty.
+	TaxRulesFile string `mapstructure:"taxRulesFile"`
+	// PaymentTimeout unpaid orders are canceled once it passed, zero keeps them open.
+	PaymentTimeout time.Duration `mapstructure:"paymentTimeout"`
+	// PaymentReminderInterval time between payment reminders of an unpaid order, zero sends none.
+	PaymentReminderInterval time.Duration `mapstructure:"paymentReminderInterval"`
+}
+
+type Payments struct {
+	// Provider payment gateway used to charge orders, "fake" is an in memory provider for local development.
+	Provider string `mapstructure:"provider" validate:"required"`
+	// WebhookSecret shared with the provider to sign its webhooks.
+	WebhookSecret string `mapstructure:"webhookSecret"`
+}
+
+type Webhooks struct {
+	// MaxAttempts delivery attempts of an event before it counts as failed.
+	MaxAttempts int `mapstructure:"maxAttempts" validate:"required,gt=0"`
+	// RetryBackoff wait before the first retry, doubled on every following retry.
+	RetryBackoff time.Duration `mapstructure:"retryBackoff"`
+	// RetryInterval how often the failed deliveries are checked for due retries.
+	RetryInterval time.Duration `mapstructure:"retryInterval"`
+	// Timeout of a single delivery request.
+	Timeout time.Duration `mapstructure:"timeout"`
+	// DisableAfter consecutive failed deliveries after which a webhook is disabled, zero never disables.
+	DisableAfter int `mapstructure:"disableAfter"`
+}
+
+type Grpc struct {
+	// Port address of the gRPC api, served next to the REST api.
+	Port string `mapstructure:"port" validate:"required"`
+	// Reflection lets tools like grpcurl discover the services.
+	Reflection bool `mapstructure:"reflection"`
+}
+
+type Auth struct {
+	// Enabled requires a bearer token on the order apis, without it every request acts as an anonymous admin.
+	Enabled bool `mapstructure:"enabled"`
+	// Algorithm signing algorithm of the tokens: HS256, HS384, HS512, RS256, RS384 or RS512.
+	Algorithm string `mapstructure:"algorithm"`
+	// Secret shared key of the HS algorithms.
+	Secret string `mapstructure:"secret"`
+	// PublicKeyFile PEM public key of the RS algorithms.
+	PublicKeyFile string `mapstructure:"publicKeyFile"`
+	// JWKSFile local JSON Web Key Set, RS keys are picked by the kid of the token.
+	JWKSFile string `mapstructure:"jwksFile"`
+	// Issuer and Audience are checked when set.
+	Issuer   string `mapstructure:"issuer"`
+	Audience string `mapstructure:"audience"`
+}
+
 func New() (*Config, error) {
 	// Set up viper to read from environment variables
 	viper.AutomaticEnv()
@@ -55,6 +139,14 @@ func New() (*Config, error) {
 	if err := viper.Unmarshal(config); err != nil {
 		return nil, errors.Wrap(err, "viper.Unmarshal")
 	}
+
+	if config.Tenancy.TenantsFile != "" {
+		tenants, err := LoadTenants(config.Tenancy.TenantsFile)
+		if err != nil {
+			return nil, errors.Wrap(err, "LoadTenants")
+		}
+		config.Tenancy.Tenants = tenants
+	}
 	return config, nil
 }
 func bindEnvVars() {
@@ -75,6 +167,12 @@ func bindEnvVars() {
 	viper.BindEnv("mongo.password", "MONGO_INITDB_ROOT_PASSWORD")
 	viper.BindEnv("mongo.db", "MONGO_INITDB_DATABASE")
 	viper.BindEnv("mongocollections.orders", "MONGO_COLLECTIONS_ORDERS")
+	viper.BindEnv("mongocollections.coupons", "MONGO_COLLECTIONS_COUPONS")
+	viper.BindEnv("mongocollections.sagadeadlines", "MONGO_COLLECTIONS_SAGA_DEADLINES")
+	viper.BindEnv("mongocollections.customers", "MONGO_COLLECTIONS_CUSTOMERS")
+	viper.BindEnv("mongocollections.products", "MONGO_COLLECTIONS_PRODUCTS")
+	viper.BindEnv("mongocollections.webhooks", "MONGO_COLLECTIONS_WEBHOOKS")
+	viper.BindEnv("mongocollections.webhookdeliveries", "MONGO_COLLECTIONS_WEBHOOK_DELIVERIES")
 
 	// Jaeger Configuration
 	viper.BindEnv("jaeger.enable", "JAEGER_ENABLE")
@@ -90,6 +188,16 @@ func bindEnvVars() {
 	viper.BindEnv("subscriptions.orderprefix", "SUBSCRIPTIONS_ORDER_PREFIX")
 	viper.BindEnv("subscriptions.mongoprojectiongroupname", "SUBSCRIPTIONS_MONGO_PROJECTION_GROUP_NAME")
 	viper.BindEnv("subscriptions.elasticprojectiongroupname", "SUBSCRIPTIONS_ELASTIC_PROJECTION_GROUP_NAME")
+	viper.BindEnv("subscriptions.inventorysagagroupname", "SUBSCRIPTIONS_INVENTORY_SAGA_GROUP_NAME")
+	viper.BindEnv("subscriptions.paymentsagagroupname", "SUBSCRIPTIONS_PAYMENT_SAGA_GROUP_NAME")
+	viper.BindEnv("subscriptions.inventoryprefix", "SUBSCRIPTIONS_INVENTORY_PREFIX")
+	viper.BindEnv("subscriptions.customerprefix", "SUBSCRIPTIONS_CUSTOMER_PREFIX")
+	viper.BindEnv("subscriptions.customerprojectiongroupname", "SUBSCRIPTIONS_CUSTOMER_PROJECTION_GROUP_NAME")
+	viper.BindEnv("subscriptions.productprefix", "SUBSCRIPTIONS_PRODUCT_PREFIX")
+	viper.BindEnv("subscriptions.productprojectiongroupname", "SUBSCRIPTIONS_PRODUCT_PROJECTION_GROUP_NAME")
+	viper.BindEnv("subscriptions.outboxgroupname", "SUBSCRIPTIONS_OUTBOX_GROUP_NAME")
+	viper.BindEnv("subscriptions.webhookgroupname", "SUBSCRIPTIONS_WEBHOOK_GROUP_NAME")
+	viper.BindEnv("subscriptions.sagadeadlineinterval", "SUBSCRIPTIONS_SAGA_DEADLINE_INTERVAL")
 
 	// ElasticSearch Configuration
 	viper.BindEnv("elastic.url", "ELASTIC_URL")
@@ -100,4 +208,64 @@ func bindEnvVars() {
 	viper.BindEnv("elastic.version", "ELASTIC_VERSION")
 	viper.BindEnv("elastic.pretty", "ELASTIC_PRETTY")
 	viper.BindEnv("elasticindexes.orders", "ELASTIC_INDEXES_ORDERS")
+
+	// Orders Configuration
+	viper.BindEnv("orders.returnwindow", "ORDERS_RETURN_WINDOW")
+	viper.BindEnv("orders.shippingfee", "ORDERS_SHIPPING_FEE")
+	viper.BindEnv("orders.taxrulesfile", "ORDERS_TAX_RULES_FILE")
+	viper.BindEnv("orders.paymenttimeout", "ORDERS_PAYMENT_TIMEOUT")
+	viper.BindEnv("orders.paymentreminderinterval", "ORDERS_PAYMENT_REMINDER_INTERVAL")
+
+	// Payments Configuration
+	viper.BindEnv("payments.provider", "PAYMENTS_PROVIDER")
+	viper.BindEnv("payments.webhooksecret", "PAYMENTS_WEBHOOK_SECRET")
+
+	// Outbox Configuration
+	viper.BindEnv("outbox.broker", "OUTBOX_BROKER")
+	viper.BindEnv("outbox.topic", "OUTBOX_TOPIC")
+	viper.BindEnv("outbox.kafka.brokers", "OUTBOX_KAFKA_BROKERS")
+	viper.BindEnv("outbox.nats.url", "OUTBOX_NATS_URL")
+
+	// Webhooks Configuration
+	viper.BindEnv("webhooks.maxattempts", "WEBHOOKS_MAX_ATTEMPTS")
+	viper.BindEnv("webhooks.retrybackoff", "WEBHOOKS_RETRY_BACKOFF")
+	viper.BindEnv("webhooks.retryinterval", "WEBHOOKS_RETRY_INTERVAL")
+	viper.BindEnv("webhooks.timeout", "WEBHOOKS_TIMEOUT")
+	viper.BindEnv("webhooks.disableafter", "WEBHOOKS_DISABLE_AFTER")
+
+	// Auth Configuration
+	viper.BindEnv("auth.enabled", "AUTH_ENABLED")
+	viper.BindEnv("auth.algorithm", "AUTH_ALGORITHM")
+	viper.BindEnv("auth.secret", "AUTH_SECRET")
+	viper.BindEnv("auth.publickeyfile", "AUTH_PUBLIC_KEY_FILE")
+	viper.BindEnv("auth.jwksfile", "AUTH_JWKS_FILE")
+	viper.BindEnv("auth.issuer", "AUTH_ISSUER")
+	viper.BindEnv("auth.audience", "AUTH_AUDIENCE")
+
+	// Tenancy Configuration
+	viper.BindEnv("tenancy.enabled", "TENANCY_ENABLED")
+	viper.BindEnv("tenancy.header", "TENANCY_HEADER")
+	viper.BindEnv("tenancy.domain", "TENANCY_DOMAIN")
+	viper.BindEnv("tenancy.tenantsfile", "TENANCY_TENANTS_FILE")
+
+	// Rate Limit Configuration
+	viper.BindEnv("ratelimit.enabled", "RATE_LIMIT_ENABLED")
+	viper.BindEnv("ratelimit.backend", "RATE_LIMIT_BACKEND")
+	viper.BindEnv("ratelimit.keyby", "RATE_LIMIT_KEY_BY")
+	viper.BindEnv("ratelimit.apikeyheader", "RATE_LIMIT_API_KEY_HEADER")
+	viper.BindEnv("ratelimit.apikeys", "RATE_LIMIT_API_KEYS")
+	viper.BindEnv("ratelimit.trustedproxies", "RATE_LIMIT_TRUSTED_PROXIES")
+	viper.BindEnv("ratelimit.commands.requests", "RATE_LIMIT_COMMANDS_REQUESTS")
+	viper.BindEnv("ratelimit.commands.period", "RATE_LIMIT_COMMANDS_PERIOD")
+	viper.BindEnv("ratelimit.commands.burst", "RATE_LIMIT_COMMANDS_BURST")
+	viper.BindEnv("ratelimit.queries.requests", "RATE_LIMIT_QUERIES_REQUESTS")
+	viper.BindEnv("ratelimit.queries.period", "RATE_LIMIT_QUERIES_PERIOD")
+	viper.BindEnv("ratelimit.queries.burst", "RATE_LIMIT_QUERIES_BURST")
+	viper.BindEnv("ratelimit.redis.addr", "RATE_LIMIT_REDIS_ADDR")
+	viper.BindEnv("ratelimit.redis.password", "RATE_LIMIT_REDIS_PASSWORD")
+	viper.BindEnv("ratelimit.redis.db", "RATE_LIMIT_REDIS_DB")
+
+	// gRPC Configuration
+	viper.BindEnv("grpc.port", "GRPC_PORT")
+	viper.BindEnv("grpc.reflection", "GRPC_REFLECTION")
 }
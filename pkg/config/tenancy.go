@@ -0,0 +1,112 @@
+package config
+
+import (
+	"encoding/json"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/wassef911/eventually/internal/infrastructure/es"
+)
+
+var (
+	ErrInvalidTenant = errors.New("invalid tenant id")
+	ErrNoTenants     = errors.New("tenancy is enabled without tenants")
+)
+
+type Tenancy struct {
+	// Enabled every api request must belong to one of the configured tenants.
+	Enabled bool `mapstructure:"enabled"`
+	// Header carrying the tenant id of a request.
+	Header string `mapstructure:"header"`
+	// Domain base domain of the shops, the subdomain of a request host names its tenant: acme.shop.example.com.
+	Domain string `mapstructure:"domain"`
+	// TenantsFile json settings of the tenants keyed by tenant id.
+	TenantsFile string `mapstructure:"tenantsFile"`
+	// Tenants loaded from TenantsFile.
+	Tenants map[string]Tenant `mapstructure:"-"`
+}
+
+// Tenant settings of a shop, unset order settings fall back to the Orders of the deployment.
+type Tenant struct {
+	Name         string    `json:"name"`
+	ShippingFee  *int64    `json:"shippingFee,omitempty"`
+	ReturnWindow *Duration `json:"returnWindow,omitempty"`
+}
+
+// Duration json duration written like "720h".
+type Duration struct {
+	time.Duration
+}
+
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return errors.Wrap(err, "json.Unmarshal")
+	}
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		return errors.Wrap(err, "time.ParseDuration")
+	}
+	d.Duration = duration
+	return nil
+}
+
+// LoadTenants reads the tenants from a json file, tenant ids prefix streams, collections and indices.
+func LoadTenants(path string) (map[string]Tenant, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "os.ReadFile")
+	}
+
+	var tenants map[string]Tenant
+	if err := json.Unmarshal(data, &tenants); err != nil {
+		return nil, errors.Wrap(err, "json.Unmarshal")
+	}
+	for tenant := range tenants {
+		if !es.ValidTenantID(tenant) {
+			return nil, errors.Wrapf(ErrInvalidTenant, "tenant: {%s}", tenant)
+		}
+	}
+	return tenants, nil
+}
+
+// TenantIDs tenants served by the deployment in order, only the default tenant while tenancy is disabled.
+func (t *Tenancy) TenantIDs() []string {
+	if !t.Enabled {
+		return []string{""}
+	}
+	tenants := make([]string, 0, len(t.Tenants))
+	for tenant := range t.Tenants {
+		tenants = append(tenants, tenant)
+	}
+	sort.Strings(tenants)
+	return tenants
+}
+
+// HasTenant the tenant is configured, the default tenant is only served while tenancy is disabled.
+func (t *Tenancy) HasTenant(tenant string) bool {
+	if !t.Enabled {
+		return tenant == ""
+	}
+	_, ok := t.Tenants[tenant]
+	return ok
+}
+
+// OrdersOf order settings of the tenant.
+func (c *Config) OrdersOf(tenant string) Orders {
+	orders := c.Orders
+	settings, ok := c.Tenancy.Tenants[tenant]
+	if !ok {
+		return orders
+	}
+	if settings.ShippingFee != nil {
+		orders.ShippingFee = *settings.ShippingFee
+	}
+	if settings.ReturnWindow != nil {
+		orders.ReturnWindow = settings.ReturnWindow.Duration
+	}
+	return orders
+}
@@ -0,0 +1,46 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeTenants(t *testing.T, tenants string) string {
+	path := filepath.Join(t.TempDir(), "tenants.json")
+	require.NoError(t, os.WriteFile(path, []byte(tenants), 0o600))
+	return path
+}
+
+func TestLoadTenants(t *testing.T) {
+	tenants, err := LoadTenants(writeTenants(t, `{
+		"acme": {"name": "Acme", "shippingFee": 0, "returnWindow": "720h"},
+		"globex": {"name": "Globex"}
+	}`))
+	require.NoError(t, err)
+	require.Len(t, tenants, 2)
+
+	cfg := &Config{
+		Orders:  Orders{ShippingFee: 500, ReturnWindow: 24 * time.Hour},
+		Tenancy: Tenancy{Enabled: true, Tenants: tenants},
+	}
+	assert.Equal(t, int64(0), cfg.OrdersOf("acme").ShippingFee)
+	assert.Equal(t, 720*time.Hour, cfg.OrdersOf("acme").ReturnWindow)
+	assert.Equal(t, cfg.Orders, cfg.OrdersOf("globex"))
+	assert.Equal(t, cfg.Orders, cfg.OrdersOf(""))
+
+	assert.True(t, cfg.Tenancy.HasTenant("acme"))
+	assert.False(t, cfg.Tenancy.HasTenant(""))
+	assert.True(t, (&Tenancy{}).HasTenant(""))
+
+	_, err = LoadTenants(writeTenants(t, `{"Acme~1": {"name": "Acme"}}`))
+	assert.True(t, errors.Is(err, ErrInvalidTenant))
+
+	_, err = LoadTenants(writeTenants(t, `{"acme": {"returnWindow": "a month"}}`))
+	assert.Error(t, err)
+}
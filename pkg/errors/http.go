@@ -6,21 +6,21 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
-	"strings"
 	"time"
 
 	"github.com/go-playground/validator"
 	"github.com/labstack/echo/v4"
 	"github.com/pkg/errors"
-
-	"github.com/wassef911/eventually/internal/api/constants"
+	"go.mongodb.org/mongo-driver/mongo"
 )
 
 const (
 	ErrBadRequest          = "Bad request"
 	ErrNotFound            = "Not Found"
 	ErrUnauthorized        = "Unauthorized"
+	ErrForbidden           = "Forbidden"
 	ErrRequestTimeout      = "Request Timeout"
+	ErrTooManyRequests     = "Too Many Requests"
 	ErrInvalidEmail        = "Invalid email"
 	ErrInvalidPassword     = "Invalid password"
 	ErrInvalidField        = "Invalid field"
@@ -33,6 +33,7 @@ var (
 	NotFound            = errors.New("Not Found")
 	Unauthorized        = errors.New("Unauthorized")
 	Forbidden           = errors.New("Forbidden")
+	TooManyRequests     = errors.New("Too Many Requests")
 	InternalServerError = errors.New("Internal Server Error")
 )
 
@@ -106,74 +107,47 @@ func NewRestErrorFromBytes(bytes []byte) (ApiErrorInterface, error) {
 
 // NewBadRequestError New Bad Request Error
 func NewBadRequestError(ctx echo.Context, causes interface{}, debug bool) error {
-	restError := RestError{
-		ErrStatus: http.StatusBadRequest,
-		ErrError:  BadRequest.Error(),
-		Timestamp: time.Now().UTC(),
-	}
-	if debug {
-		restError.ErrMessage = causes
-	}
-	return ctx.JSON(http.StatusBadRequest, restError)
+	return newProblemError(ctx, http.StatusBadRequest, causes, debug)
 }
 
 // NewNotFoundError New Not Found Error
 func NewNotFoundError(ctx echo.Context, causes interface{}, debug bool) error {
-	restError := RestError{
-		ErrStatus: http.StatusNotFound,
-		ErrError:  NotFound.Error(),
-		Timestamp: time.Now().UTC(),
-	}
-	if debug {
-		restError.ErrMessage = causes
-	}
-	return ctx.JSON(http.StatusNotFound, restError)
+	return newProblemError(ctx, http.StatusNotFound, causes, debug)
 }
 
 // NewUnauthorizedError New Unauthorized Error
 func NewUnauthorizedError(ctx echo.Context, causes interface{}, debug bool) error {
-
-	restError := RestError{
-		ErrStatus: http.StatusUnauthorized,
-		ErrError:  Unauthorized.Error(),
-		Timestamp: time.Now().UTC(),
-	}
-	if debug {
-		restError.ErrMessage = causes
-	}
-	return ctx.JSON(http.StatusUnauthorized, restError)
+	return newProblemError(ctx, http.StatusUnauthorized, causes, debug)
 }
 
 // NewForbiddenError New Forbidden Error
 func NewForbiddenError(ctx echo.Context, causes interface{}, debug bool) error {
-
-	restError := RestError{
-		ErrStatus: http.StatusForbidden,
-		ErrError:  Forbidden.Error(),
-		Timestamp: time.Now().UTC(),
-	}
-	if debug {
-		restError.ErrMessage = causes
-	}
-	return ctx.JSON(http.StatusForbidden, restError)
+	return newProblemError(ctx, http.StatusForbidden, causes, debug)
 }
 
 // NewInternalServerError New Internal Server Error
 func NewInternalServerError(ctx echo.Context, causes interface{}, debug bool) error {
+	return newProblemError(ctx, http.StatusInternalServerError, causes, debug)
+}
 
-	restError := RestError{
-		ErrStatus: http.StatusInternalServerError,
-		ErrError:  InternalServerError.Error(),
-		Timestamp: time.Now().UTC(),
-	}
-	if debug {
-		restError.ErrMessage = causes
+func newProblemError(ctx echo.Context, status int, causes interface{}, debug bool) error {
+	problem := NewProblem(status, fmt.Sprint(causes), debug)
+	if err, ok := causes.(error); ok {
+		if classification, ok := classify(err); ok {
+			problem.Code = classification.Code
+			problem.Detail = classification.Message
+		}
 	}
-	return ctx.JSON(http.StatusInternalServerError, restError)
+	return ProblemResponse(ctx, problem)
 }
 
-// ErrorToRest Parser of error string messages returns RestError
+// ErrorToRest RestError of err, errors are matched by identity: classified errors first, then the sentinels.
 func ErrorToRest(err error, debug bool) ApiErrorInterface {
+	if classification, ok := classify(err); ok {
+		return NewRestErrorWithMessage(classification.Status, http.StatusText(classification.Status), classification.Message)
+	}
+
+	var validationErrors validator.ValidationErrors
 	switch {
 	case errors.Is(err, sql.ErrNoRows):
 		return NewRestError(http.StatusNotFound, ErrNotFound, err.Error(), debug)
@@ -181,31 +155,18 @@ func ErrorToRest(err error, debug bool) ApiErrorInterface {
 		return NewRestError(http.StatusRequestTimeout, ErrRequestTimeout, err.Error(), debug)
 	case errors.Is(err, Unauthorized):
 		return NewRestError(http.StatusUnauthorized, ErrUnauthorized, err.Error(), debug)
-	case errors.Is(err, WrongCredentials):
-		return NewRestError(http.StatusUnauthorized, ErrUnauthorized, err.Error(), debug)
-	case strings.Contains(strings.ToLower(err.Error()), constants.SQLState):
-		return parseSqlErrors(err, debug)
-	case strings.Contains(strings.ToLower(err.Error()), "field validation"):
-		if validationErrors, ok := err.(validator.ValidationErrors); ok {
-			return NewRestError(http.StatusBadRequest, ErrBadRequest, validationErrors.Error(), debug)
-		}
-		return parseValidatorError(err, debug)
-	case strings.Contains(strings.ToLower(err.Error()), "required header"):
-		return NewRestError(http.StatusBadRequest, ErrBadRequest, err.Error(), debug)
-	case strings.Contains(strings.ToLower(err.Error()), constants.Base64):
+	case errors.Is(err, Forbidden):
+		return NewRestError(http.StatusForbidden, ErrForbidden, err.Error(), debug)
+	case errors.Is(err, BadRequest):
 		return NewRestError(http.StatusBadRequest, ErrBadRequest, err.Error(), debug)
-	case strings.Contains(strings.ToLower(err.Error()), constants.Unmarshal):
-		return NewRestError(http.StatusBadRequest, ErrBadRequest, err.Error(), debug)
-	case strings.Contains(strings.ToLower(err.Error()), constants.Uuid):
-		return NewRestError(http.StatusBadRequest, ErrBadRequest, err.Error(), debug)
-	case strings.Contains(strings.ToLower(err.Error()), constants.Cookie):
-		return NewRestError(http.StatusUnauthorized, ErrUnauthorized, err.Error(), debug)
-	case strings.Contains(strings.ToLower(err.Error()), constants.Token):
+	case errors.Is(err, TooManyRequests):
+		return NewRestError(http.StatusTooManyRequests, ErrTooManyRequests, err.Error(), debug)
+	case errors.Is(err, WrongCredentials):
 		return NewRestError(http.StatusUnauthorized, ErrUnauthorized, err.Error(), debug)
-	case strings.Contains(strings.ToLower(err.Error()), constants.Bcrypt):
-		return NewRestError(http.StatusBadRequest, ErrBadRequest, err.Error(), debug)
-	case strings.Contains(strings.ToLower(err.Error()), "no documents in result"):
+	case errors.Is(err, mongo.ErrNoDocuments):
 		return NewRestError(http.StatusNotFound, ErrNotFound, err.Error(), debug)
+	case errors.As(err, &validationErrors):
+		return NewRestError(http.StatusBadRequest, ErrBadRequest, validationErrors.Error(), debug)
 	default:
 		if restErr, ok := err.(*RestError); ok {
 			return restErr
@@ -214,27 +175,11 @@ func ErrorToRest(err error, debug bool) ApiErrorInterface {
 	}
 }
 
-func parseSqlErrors(err error, debug bool) ApiErrorInterface {
-	return NewRestError(http.StatusBadRequest, ErrBadRequest, err, debug)
-}
-
-func parseValidatorError(err error, debug bool) ApiErrorInterface {
-	if strings.Contains(err.Error(), "Password") {
-		return NewRestError(http.StatusBadRequest, ErrInvalidPassword, err, debug)
-	}
-
-	if strings.Contains(err.Error(), "Email") {
-		return NewRestError(http.StatusBadRequest, ErrInvalidEmail, err, debug)
-	}
-
-	return NewRestError(http.StatusBadRequest, ErrInvalidField, err, debug)
-}
-
 func ErrorResponse(err error, debug bool) (int, interface{}) {
 	return ErrorToRest(err, debug).Status(), ErrorToRest(err, debug)
 }
 
+// ErrorCtxResponse writes the problem details of err as application/problem+json.
 func ErrorCtxResponse(ctx echo.Context, err error, debug bool) error {
-	restError := ErrorToRest(err, debug)
-	return ctx.JSON(restError.Status(), restError)
+	return ProblemResponse(ctx, ErrorToProblem(err, debug))
 }
@@ -0,0 +1,162 @@
+package errors
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/go-playground/validator"
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+)
+
+const (
+	// MIMEApplicationProblemJSON media type of the error responses, RFC 7807.
+	MIMEApplicationProblemJSON = "application/problem+json"
+	// ProblemTypeDefault the problem has no more semantics than its status code.
+	ProblemTypeDefault = "about:blank"
+	// CodeValidationFailed code of the requests whose fields are invalid.
+	CodeValidationFailed = "validation_failed"
+)
+
+// Problem RFC 7807 problem details, Code is the stable machine readable code of the error.
+type Problem struct {
+	Type     string       `json:"type"`
+	Title    string       `json:"title"`
+	Status   int          `json:"status"`
+	Detail   string       `json:"detail,omitempty"`
+	Instance string       `json:"instance,omitempty"`
+	Code     string       `json:"code"`
+	Errors   []FieldError `json:"errors,omitempty"`
+}
+
+// FieldError invalid field of a request, Code is the failed validation.
+type FieldError struct {
+	Field   string `json:"field"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// Error  Error() interface method
+func (p *Problem) Error() string {
+	return fmt.Sprintf("status: %d - code: %s - detail: %s", p.Status, p.Code, p.Detail)
+}
+
+// NewProblem problem of status, detail is only kept in debug mode.
+func NewProblem(status int, detail string, debug bool) *Problem {
+	problem := &Problem{
+		Type:   ProblemTypeDefault,
+		Title:  http.StatusText(status),
+		Status: status,
+		Code:   statusCode(status),
+	}
+	if debug {
+		problem.Detail = detail
+	}
+	return problem
+}
+
+// Classification how an error this package does not know is answered, Code is its stable machine readable code.
+type Classification struct {
+	Status  int
+	Code    string
+	Message string
+}
+
+// Classifier classification of the errors of a layer, ok is false for the errors it does not know.
+type Classifier func(err error) (classification Classification, ok bool)
+
+var classifiers []Classifier
+
+// RegisterClassifier the apis register how the errors of the inner layers are answered, at init.
+func RegisterClassifier(classifier Classifier) {
+	classifiers = append(classifiers, classifier)
+}
+
+func classify(err error) (Classification, bool) {
+	for _, classifier := range classifiers {
+		if classification, ok := classifier(err); ok {
+			return classification, true
+		}
+	}
+	return Classification{}, false
+}
+
+// ErrorToProblem problem details of err, classified errors and invalid fields are always detailed,
+// other errors only carry their message in debug mode.
+func ErrorToProblem(err error, debug bool) *Problem {
+	if problem, ok := errors.Cause(err).(*Problem); ok {
+		return problem
+	}
+
+	if classification, ok := classify(err); ok {
+		problem := NewProblem(classification.Status, classification.Message, true)
+		problem.Code = classification.Code
+		if debug {
+			problem.Detail = err.Error()
+		}
+		return problem
+	}
+
+	var validationErrors validator.ValidationErrors
+	if errors.As(err, &validationErrors) {
+		problem := NewProblem(http.StatusBadRequest, "request validation failed", true)
+		problem.Code = CodeValidationFailed
+		problem.Errors = fieldErrors(validationErrors)
+		return problem
+	}
+
+	var httpErr *echo.HTTPError
+	if errors.As(err, &httpErr) {
+		return NewProblem(httpErr.Code, fmt.Sprint(httpErr.Message), true)
+	}
+
+	restErr := ErrorToRest(err, debug)
+	problem := NewProblem(restErr.Status(), "", debug)
+	if causes := restErr.Causes(); causes != nil {
+		problem.Detail = fmt.Sprint(causes)
+	}
+	return problem
+}
+
+func fieldErrors(validationErrors validator.ValidationErrors) []FieldError {
+	fields := make([]FieldError, 0, len(validationErrors))
+	for _, fieldErr := range validationErrors {
+		field := fieldErr.Namespace()
+		if i := strings.Index(field, "."); i >= 0 {
+			field = field[i+1:]
+		}
+		fields = append(fields, FieldError{
+			Field:   field,
+			Code:    fieldErr.Tag(),
+			Message: fmt.Sprintf("%s failed on the '%s' validation", field, fieldErr.Tag()),
+		})
+	}
+	return fields
+}
+
+// statusCode machine readable code of the status: Not Found is not_found.
+func statusCode(status int) string {
+	text := http.StatusText(status)
+	if text == "" {
+		return "unknown_error"
+	}
+	return strings.ReplaceAll(strings.ToLower(text), " ", "_")
+}
+
+// ProblemResponse writes the problem as application/problem+json.
+func ProblemResponse(ctx echo.Context, problem *Problem) error {
+	if problem.Instance == "" {
+		problem.Instance = ctx.Request().URL.Path
+	}
+	ctx.Response().Header().Set(echo.HeaderContentType, MIMEApplicationProblemJSON)
+	return ctx.JSON(problem.Status, problem)
+}
+
+// NewUnprocessableEntityError writes the problem of err with status 422, keeping the code of err.
+func NewUnprocessableEntityError(ctx echo.Context, err error, debug bool) error {
+	problem := ErrorToProblem(err, debug)
+	problem.Status = http.StatusUnprocessableEntity
+	problem.Title = http.StatusText(http.StatusUnprocessableEntity)
+	return ProblemResponse(ctx, problem)
+}
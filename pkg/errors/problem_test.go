@@ -0,0 +1,127 @@
+package errors
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-playground/validator"
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// codedError stands for the errors of the inner layers the apis classify.
+type codedError struct {
+	status  int
+	code    string
+	message string
+}
+
+func (e *codedError) Error() string {
+	return e.message
+}
+
+var (
+	errOrderNotFound = &codedError{status: http.StatusNotFound, code: "order_not_found", message: "order not found"}
+	errAlreadyPaid   = &codedError{status: http.StatusConflict, code: "order_already_paid", message: "already paid"}
+	errInvalidAmount = &codedError{status: http.StatusUnprocessableEntity, code: "invalid_refund_amount", message: "refund amount must be positive"}
+)
+
+func init() {
+	RegisterClassifier(func(err error) (Classification, bool) {
+		var coded *codedError
+		if !errors.As(err, &coded) {
+			return Classification{}, false
+		}
+		return Classification{Status: coded.status, Code: coded.code, Message: coded.message}, true
+	})
+}
+
+type createOrderReq struct {
+	AccountEmail string `validate:"required,email"`
+	Items        []item `validate:"required,dive"`
+}
+
+type item struct {
+	Quantity uint64 `validate:"gt=0"`
+}
+
+func TestErrorToProblem(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		status int
+		code   string
+		detail string
+	}{
+		{name: "not found", err: errors.Wrap(errOrderNotFound, "Load"), status: http.StatusNotFound, code: "order_not_found", detail: "order not found"},
+		{name: "conflict", err: errors.Wrap(errAlreadyPaid, "Pay"), status: http.StatusConflict, code: "order_already_paid", detail: "already paid"},
+		{name: "invariant", err: errInvalidAmount, status: http.StatusUnprocessableEntity, code: "invalid_refund_amount", detail: "refund amount must be positive"},
+		{name: "unauthorized", err: errors.Wrap(Unauthorized, "missing bearer token"), status: http.StatusUnauthorized, code: "unauthorized"},
+		{name: "too many requests", err: TooManyRequests, status: http.StatusTooManyRequests, code: "too_many_requests"},
+		{name: "echo", err: echo.NewHTTPError(http.StatusMethodNotAllowed), status: http.StatusMethodNotAllowed, code: "method_not_allowed", detail: "Method Not Allowed"},
+		{name: "no documents", err: errors.Wrap(mongo.ErrNoDocuments, "FindOne"), status: http.StatusNotFound, code: "not_found"},
+		{name: "message is not matched", err: errors.New("token: {abc} uuid: bad"), status: http.StatusInternalServerError, code: "internal_server_error"},
+		{name: "unknown", err: errors.New("boom"), status: http.StatusInternalServerError, code: "internal_server_error"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			problem := ErrorToProblem(tt.err, false)
+			assert.Equal(t, ProblemTypeDefault, problem.Type)
+			assert.Equal(t, http.StatusText(tt.status), problem.Title)
+			assert.Equal(t, tt.status, problem.Status)
+			assert.Equal(t, tt.code, problem.Code)
+			assert.Equal(t, tt.detail, problem.Detail)
+		})
+	}
+
+	assert.Equal(t, "Pay: already paid", ErrorToProblem(errors.Wrap(errAlreadyPaid, "Pay"), true).Detail)
+	assert.Equal(t, "boom", ErrorToProblem(errors.New("boom"), true).Detail)
+}
+
+func TestErrorToProblem_Validation(t *testing.T) {
+	err := validator.New().Struct(createOrderReq{AccountEmail: "nope", Items: []item{{Quantity: 0}}})
+	require.Error(t, err)
+
+	problem := ErrorToProblem(errors.Wrap(err, "StructCtx"), false)
+	assert.Equal(t, http.StatusBadRequest, problem.Status)
+	assert.Equal(t, CodeValidationFailed, problem.Code)
+	assert.Equal(t, []FieldError{
+		{Field: "AccountEmail", Code: "email", Message: "AccountEmail failed on the 'email' validation"},
+		{Field: "Items[0].Quantity", Code: "gt", Message: "Items[0].Quantity failed on the 'gt' validation"},
+	}, problem.Errors)
+}
+
+func TestErrorCtxResponse(t *testing.T) {
+	e := echo.New()
+	rec := httptest.NewRecorder()
+	ctx := e.NewContext(httptest.NewRequest(http.MethodPut, "/api/orders/1/pay", nil), rec)
+
+	require.NoError(t, ErrorCtxResponse(ctx, errors.Wrap(errAlreadyPaid, "Pay"), false))
+	assert.Equal(t, http.StatusConflict, rec.Code)
+	assert.Equal(t, MIMEApplicationProblemJSON, rec.Header().Get(echo.HeaderContentType))
+
+	var problem Problem
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &problem))
+	assert.Equal(t, "order_already_paid", problem.Code)
+	assert.Equal(t, "/api/orders/1/pay", problem.Instance)
+}
+
+func TestNewUnprocessableEntityError(t *testing.T) {
+	e := echo.New()
+	rec := httptest.NewRecorder()
+	ctx := e.NewContext(httptest.NewRequest(http.MethodPost, "/api/orders", nil), rec)
+
+	require.NoError(t, NewUnprocessableEntityError(ctx, errOrderNotFound, false))
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+
+	var problem Problem
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &problem))
+	assert.Equal(t, "order_not_found", problem.Code)
+	assert.Equal(t, http.StatusText(http.StatusUnprocessableEntity), problem.Title)
+}
@@ -33,6 +33,7 @@ type Logger interface {
 	Printf(template string, args ...interface{})
 	WithName(name string)
 	HttpMiddlewareAccessLogger(method string, uri string, status int, size int64, time time.Duration)
+	GrpcMiddlewareAccessLogger(method string, time time.Duration, metaData map[string][]string, err error)
 	ProjectionEvent(projectionName string, groupName string, event *esdb.ResolvedEvent, workerID int)
 }
 
@@ -183,6 +184,16 @@ func (l *appLogger) HttpMiddlewareAccessLogger(method, uri string, status int, s
 	)
 }
 
+func (l *appLogger) GrpcMiddlewareAccessLogger(method string, time time.Duration, metaData map[string][]string, err error) {
+	l.logger.Info(
+		constants.GRPC,
+		zap.String(constants.METHOD, method),
+		zap.Duration(constants.TIME, time),
+		zap.Any(constants.METADATA, metaData),
+		zap.Any(constants.ERROR, err),
+	)
+}
+
 func (l *appLogger) ProjectionEvent(projectionName string, groupName string, event *esdb.ResolvedEvent, workerID int) {
 	l.logger.Info(
 		projectionName,
@@ -0,0 +1,1005 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             (unknown)
+// source: proto/orders/orders.proto
+
+package orders
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// OrderServiceClient is the client API for OrderService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type OrderServiceClient interface {
+	CreateOrder(ctx context.Context, in *CreateOrderReq, opts ...grpc.CallOption) (*CreateOrderRes, error)
+	PayOrder(ctx context.Context, in *PayOrderReq, opts ...grpc.CallOption) (*OrderIDRes, error)
+	SubmitOrder(ctx context.Context, in *SubmitOrderReq, opts ...grpc.CallOption) (*OrderIDRes, error)
+	UpdateShoppingCart(ctx context.Context, in *UpdateShoppingCartReq, opts ...grpc.CallOption) (*OrderIDRes, error)
+	CancelOrder(ctx context.Context, in *CancelOrderReq, opts ...grpc.CallOption) (*OrderIDRes, error)
+	CompleteOrder(ctx context.Context, in *CompleteOrderReq, opts ...grpc.CallOption) (*OrderIDRes, error)
+	ChangeDeliveryAddress(ctx context.Context, in *ChangeDeliveryAddressReq, opts ...grpc.CallOption) (*OrderIDRes, error)
+	AddItem(ctx context.Context, in *AddItemReq, opts ...grpc.CallOption) (*OrderIDRes, error)
+	RemoveItem(ctx context.Context, in *RemoveItemReq, opts ...grpc.CallOption) (*OrderIDRes, error)
+	ChangeItemQuantity(ctx context.Context, in *ChangeItemQuantityReq, opts ...grpc.CallOption) (*OrderIDRes, error)
+	ApplyCoupon(ctx context.Context, in *ApplyCouponReq, opts ...grpc.CallOption) (*OrderIDRes, error)
+	RemoveCoupon(ctx context.Context, in *RemoveCouponReq, opts ...grpc.CallOption) (*OrderIDRes, error)
+	// RefundOrder refunds the given items, the given amount, or the whole remaining paid amount when both are empty.
+	RefundOrder(ctx context.Context, in *RefundOrderReq, opts ...grpc.CallOption) (*RefundIDRes, error)
+	RequestReturn(ctx context.Context, in *RequestReturnReq, opts ...grpc.CallOption) (*ReturnIDRes, error)
+	ApproveReturn(ctx context.Context, in *ApproveReturnReq, opts ...grpc.CallOption) (*ReturnIDRes, error)
+	RejectReturn(ctx context.Context, in *RejectReturnReq, opts ...grpc.CallOption) (*ReturnIDRes, error)
+	// ReceiveReturn refunds the received items, the refund id is empty when nothing was left to refund.
+	ReceiveReturn(ctx context.Context, in *ReceiveReturnReq, opts ...grpc.CallOption) (*RefundIDRes, error)
+	CreateShipment(ctx context.Context, in *CreateShipmentReq, opts ...grpc.CallOption) (*ShipmentIDRes, error)
+	PackShipment(ctx context.Context, in *PackShipmentReq, opts ...grpc.CallOption) (*ShipmentIDRes, error)
+	DispatchShipment(ctx context.Context, in *DispatchShipmentReq, opts ...grpc.CallOption) (*ShipmentIDRes, error)
+	DeliverShipment(ctx context.Context, in *DeliverShipmentReq, opts ...grpc.CallOption) (*ShipmentIDRes, error)
+	GetOrderByID(ctx context.Context, in *GetOrderByIDReq, opts ...grpc.CallOption) (*GetOrderByIDRes, error)
+	SearchOrders(ctx context.Context, in *SearchOrdersReq, opts ...grpc.CallOption) (*SearchOrdersRes, error)
+	// GetOrderLifecycle order statuses and the transitions allowed between them.
+	GetOrderLifecycle(ctx context.Context, in *GetOrderLifecycleReq, opts ...grpc.CallOption) (*GetOrderLifecycleRes, error)
+	// StreamOrderEvents events of the order recorded after last_event_id, from its first event when unset.
+	StreamOrderEvents(ctx context.Context, in *StreamOrderEventsReq, opts ...grpc.CallOption) (OrderService_StreamOrderEventsClient, error)
+}
+
+type orderServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewOrderServiceClient(cc grpc.ClientConnInterface) OrderServiceClient {
+	return &orderServiceClient{cc}
+}
+
+func (c *orderServiceClient) CreateOrder(ctx context.Context, in *CreateOrderReq, opts ...grpc.CallOption) (*CreateOrderRes, error) {
+	out := new(CreateOrderRes)
+	err := c.cc.Invoke(ctx, "/orders.v1.OrderService/CreateOrder", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) PayOrder(ctx context.Context, in *PayOrderReq, opts ...grpc.CallOption) (*OrderIDRes, error) {
+	out := new(OrderIDRes)
+	err := c.cc.Invoke(ctx, "/orders.v1.OrderService/PayOrder", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) SubmitOrder(ctx context.Context, in *SubmitOrderReq, opts ...grpc.CallOption) (*OrderIDRes, error) {
+	out := new(OrderIDRes)
+	err := c.cc.Invoke(ctx, "/orders.v1.OrderService/SubmitOrder", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) UpdateShoppingCart(ctx context.Context, in *UpdateShoppingCartReq, opts ...grpc.CallOption) (*OrderIDRes, error) {
+	out := new(OrderIDRes)
+	err := c.cc.Invoke(ctx, "/orders.v1.OrderService/UpdateShoppingCart", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) CancelOrder(ctx context.Context, in *CancelOrderReq, opts ...grpc.CallOption) (*OrderIDRes, error) {
+	out := new(OrderIDRes)
+	err := c.cc.Invoke(ctx, "/orders.v1.OrderService/CancelOrder", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) CompleteOrder(ctx context.Context, in *CompleteOrderReq, opts ...grpc.CallOption) (*OrderIDRes, error) {
+	out := new(OrderIDRes)
+	err := c.cc.Invoke(ctx, "/orders.v1.OrderService/CompleteOrder", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) ChangeDeliveryAddress(ctx context.Context, in *ChangeDeliveryAddressReq, opts ...grpc.CallOption) (*OrderIDRes, error) {
+	out := new(OrderIDRes)
+	err := c.cc.Invoke(ctx, "/orders.v1.OrderService/ChangeDeliveryAddress", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) AddItem(ctx context.Context, in *AddItemReq, opts ...grpc.CallOption) (*OrderIDRes, error) {
+	out := new(OrderIDRes)
+	err := c.cc.Invoke(ctx, "/orders.v1.OrderService/AddItem", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) RemoveItem(ctx context.Context, in *RemoveItemReq, opts ...grpc.CallOption) (*OrderIDRes, error) {
+	out := new(OrderIDRes)
+	err := c.cc.Invoke(ctx, "/orders.v1.OrderService/RemoveItem", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) ChangeItemQuantity(ctx context.Context, in *ChangeItemQuantityReq, opts ...grpc.CallOption) (*OrderIDRes, error) {
+	out := new(OrderIDRes)
+	err := c.cc.Invoke(ctx, "/orders.v1.OrderService/ChangeItemQuantity", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) ApplyCoupon(ctx context.Context, in *ApplyCouponReq, opts ...grpc.CallOption) (*OrderIDRes, error) {
+	out := new(OrderIDRes)
+	err := c.cc.Invoke(ctx, "/orders.v1.OrderService/ApplyCoupon", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) RemoveCoupon(ctx context.Context, in *RemoveCouponReq, opts ...grpc.CallOption) (*OrderIDRes, error) {
+	out := new(OrderIDRes)
+	err := c.cc.Invoke(ctx, "/orders.v1.OrderService/RemoveCoupon", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) RefundOrder(ctx context.Context, in *RefundOrderReq, opts ...grpc.CallOption) (*RefundIDRes, error) {
+	out := new(RefundIDRes)
+	err := c.cc.Invoke(ctx, "/orders.v1.OrderService/RefundOrder", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) RequestReturn(ctx context.Context, in *RequestReturnReq, opts ...grpc.CallOption) (*ReturnIDRes, error) {
+	out := new(ReturnIDRes)
+	err := c.cc.Invoke(ctx, "/orders.v1.OrderService/RequestReturn", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) ApproveReturn(ctx context.Context, in *ApproveReturnReq, opts ...grpc.CallOption) (*ReturnIDRes, error) {
+	out := new(ReturnIDRes)
+	err := c.cc.Invoke(ctx, "/orders.v1.OrderService/ApproveReturn", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) RejectReturn(ctx context.Context, in *RejectReturnReq, opts ...grpc.CallOption) (*ReturnIDRes, error) {
+	out := new(ReturnIDRes)
+	err := c.cc.Invoke(ctx, "/orders.v1.OrderService/RejectReturn", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) ReceiveReturn(ctx context.Context, in *ReceiveReturnReq, opts ...grpc.CallOption) (*RefundIDRes, error) {
+	out := new(RefundIDRes)
+	err := c.cc.Invoke(ctx, "/orders.v1.OrderService/ReceiveReturn", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) CreateShipment(ctx context.Context, in *CreateShipmentReq, opts ...grpc.CallOption) (*ShipmentIDRes, error) {
+	out := new(ShipmentIDRes)
+	err := c.cc.Invoke(ctx, "/orders.v1.OrderService/CreateShipment", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) PackShipment(ctx context.Context, in *PackShipmentReq, opts ...grpc.CallOption) (*ShipmentIDRes, error) {
+	out := new(ShipmentIDRes)
+	err := c.cc.Invoke(ctx, "/orders.v1.OrderService/PackShipment", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) DispatchShipment(ctx context.Context, in *DispatchShipmentReq, opts ...grpc.CallOption) (*ShipmentIDRes, error) {
+	out := new(ShipmentIDRes)
+	err := c.cc.Invoke(ctx, "/orders.v1.OrderService/DispatchShipment", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) DeliverShipment(ctx context.Context, in *DeliverShipmentReq, opts ...grpc.CallOption) (*ShipmentIDRes, error) {
+	out := new(ShipmentIDRes)
+	err := c.cc.Invoke(ctx, "/orders.v1.OrderService/DeliverShipment", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) GetOrderByID(ctx context.Context, in *GetOrderByIDReq, opts ...grpc.CallOption) (*GetOrderByIDRes, error) {
+	out := new(GetOrderByIDRes)
+	err := c.cc.Invoke(ctx, "/orders.v1.OrderService/GetOrderByID", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) SearchOrders(ctx context.Context, in *SearchOrdersReq, opts ...grpc.CallOption) (*SearchOrdersRes, error) {
+	out := new(SearchOrdersRes)
+	err := c.cc.Invoke(ctx, "/orders.v1.OrderService/SearchOrders", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) GetOrderLifecycle(ctx context.Context, in *GetOrderLifecycleReq, opts ...grpc.CallOption) (*GetOrderLifecycleRes, error) {
+	out := new(GetOrderLifecycleRes)
+	err := c.cc.Invoke(ctx, "/orders.v1.OrderService/GetOrderLifecycle", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) StreamOrderEvents(ctx context.Context, in *StreamOrderEventsReq, opts ...grpc.CallOption) (OrderService_StreamOrderEventsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &OrderService_ServiceDesc.Streams[0], "/orders.v1.OrderService/StreamOrderEvents", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &orderServiceStreamOrderEventsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type OrderService_StreamOrderEventsClient interface {
+	Recv() (*OrderEvent, error)
+	grpc.ClientStream
+}
+
+type orderServiceStreamOrderEventsClient struct {
+	grpc.ClientStream
+}
+
+func (x *orderServiceStreamOrderEventsClient) Recv() (*OrderEvent, error) {
+	m := new(OrderEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// OrderServiceServer is the server API for OrderService service.
+// All implementations must embed UnimplementedOrderServiceServer
+// for forward compatibility
+type OrderServiceServer interface {
+	CreateOrder(context.Context, *CreateOrderReq) (*CreateOrderRes, error)
+	PayOrder(context.Context, *PayOrderReq) (*OrderIDRes, error)
+	SubmitOrder(context.Context, *SubmitOrderReq) (*OrderIDRes, error)
+	UpdateShoppingCart(context.Context, *UpdateShoppingCartReq) (*OrderIDRes, error)
+	CancelOrder(context.Context, *CancelOrderReq) (*OrderIDRes, error)
+	CompleteOrder(context.Context, *CompleteOrderReq) (*OrderIDRes, error)
+	ChangeDeliveryAddress(context.Context, *ChangeDeliveryAddressReq) (*OrderIDRes, error)
+	AddItem(context.Context, *AddItemReq) (*OrderIDRes, error)
+	RemoveItem(context.Context, *RemoveItemReq) (*OrderIDRes, error)
+	ChangeItemQuantity(context.Context, *ChangeItemQuantityReq) (*OrderIDRes, error)
+	ApplyCoupon(context.Context, *ApplyCouponReq) (*OrderIDRes, error)
+	RemoveCoupon(context.Context, *RemoveCouponReq) (*OrderIDRes, error)
+	// RefundOrder refunds the given items, the given amount, or the whole remaining paid amount when both are empty.
+	RefundOrder(context.Context, *RefundOrderReq) (*RefundIDRes, error)
+	RequestReturn(context.Context, *RequestReturnReq) (*ReturnIDRes, error)
+	ApproveReturn(context.Context, *ApproveReturnReq) (*ReturnIDRes, error)
+	RejectReturn(context.Context, *RejectReturnReq) (*ReturnIDRes, error)
+	// ReceiveReturn refunds the received items, the refund id is empty when nothing was left to refund.
+	ReceiveReturn(context.Context, *ReceiveReturnReq) (*RefundIDRes, error)
+	CreateShipment(context.Context, *CreateShipmentReq) (*ShipmentIDRes, error)
+	PackShipment(context.Context, *PackShipmentReq) (*ShipmentIDRes, error)
+	DispatchShipment(context.Context, *DispatchShipmentReq) (*ShipmentIDRes, error)
+	DeliverShipment(context.Context, *DeliverShipmentReq) (*ShipmentIDRes, error)
+	GetOrderByID(context.Context, *GetOrderByIDReq) (*GetOrderByIDRes, error)
+	SearchOrders(context.Context, *SearchOrdersReq) (*SearchOrdersRes, error)
+	// GetOrderLifecycle order statuses and the transitions allowed between them.
+	GetOrderLifecycle(context.Context, *GetOrderLifecycleReq) (*GetOrderLifecycleRes, error)
+	// StreamOrderEvents events of the order recorded after last_event_id, from its first event when unset.
+	StreamOrderEvents(*StreamOrderEventsReq, OrderService_StreamOrderEventsServer) error
+	mustEmbedUnimplementedOrderServiceServer()
+}
+
+// UnimplementedOrderServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedOrderServiceServer struct {
+}
+
+func (UnimplementedOrderServiceServer) CreateOrder(context.Context, *CreateOrderReq) (*CreateOrderRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateOrder not implemented")
+}
+func (UnimplementedOrderServiceServer) PayOrder(context.Context, *PayOrderReq) (*OrderIDRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PayOrder not implemented")
+}
+func (UnimplementedOrderServiceServer) SubmitOrder(context.Context, *SubmitOrderReq) (*OrderIDRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SubmitOrder not implemented")
+}
+func (UnimplementedOrderServiceServer) UpdateShoppingCart(context.Context, *UpdateShoppingCartReq) (*OrderIDRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateShoppingCart not implemented")
+}
+func (UnimplementedOrderServiceServer) CancelOrder(context.Context, *CancelOrderReq) (*OrderIDRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelOrder not implemented")
+}
+func (UnimplementedOrderServiceServer) CompleteOrder(context.Context, *CompleteOrderReq) (*OrderIDRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CompleteOrder not implemented")
+}
+func (UnimplementedOrderServiceServer) ChangeDeliveryAddress(context.Context, *ChangeDeliveryAddressReq) (*OrderIDRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ChangeDeliveryAddress not implemented")
+}
+func (UnimplementedOrderServiceServer) AddItem(context.Context, *AddItemReq) (*OrderIDRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddItem not implemented")
+}
+func (UnimplementedOrderServiceServer) RemoveItem(context.Context, *RemoveItemReq) (*OrderIDRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveItem not implemented")
+}
+func (UnimplementedOrderServiceServer) ChangeItemQuantity(context.Context, *ChangeItemQuantityReq) (*OrderIDRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ChangeItemQuantity not implemented")
+}
+func (UnimplementedOrderServiceServer) ApplyCoupon(context.Context, *ApplyCouponReq) (*OrderIDRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ApplyCoupon not implemented")
+}
+func (UnimplementedOrderServiceServer) RemoveCoupon(context.Context, *RemoveCouponReq) (*OrderIDRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveCoupon not implemented")
+}
+func (UnimplementedOrderServiceServer) RefundOrder(context.Context, *RefundOrderReq) (*RefundIDRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RefundOrder not implemented")
+}
+func (UnimplementedOrderServiceServer) RequestReturn(context.Context, *RequestReturnReq) (*ReturnIDRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RequestReturn not implemented")
+}
+func (UnimplementedOrderServiceServer) ApproveReturn(context.Context, *ApproveReturnReq) (*ReturnIDRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ApproveReturn not implemented")
+}
+func (UnimplementedOrderServiceServer) RejectReturn(context.Context, *RejectReturnReq) (*ReturnIDRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RejectReturn not implemented")
+}
+func (UnimplementedOrderServiceServer) ReceiveReturn(context.Context, *ReceiveReturnReq) (*RefundIDRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReceiveReturn not implemented")
+}
+func (UnimplementedOrderServiceServer) CreateShipment(context.Context, *CreateShipmentReq) (*ShipmentIDRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateShipment not implemented")
+}
+func (UnimplementedOrderServiceServer) PackShipment(context.Context, *PackShipmentReq) (*ShipmentIDRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PackShipment not implemented")
+}
+func (UnimplementedOrderServiceServer) DispatchShipment(context.Context, *DispatchShipmentReq) (*ShipmentIDRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DispatchShipment not implemented")
+}
+func (UnimplementedOrderServiceServer) DeliverShipment(context.Context, *DeliverShipmentReq) (*ShipmentIDRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeliverShipment not implemented")
+}
+func (UnimplementedOrderServiceServer) GetOrderByID(context.Context, *GetOrderByIDReq) (*GetOrderByIDRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetOrderByID not implemented")
+}
+func (UnimplementedOrderServiceServer) SearchOrders(context.Context, *SearchOrdersReq) (*SearchOrdersRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchOrders not implemented")
+}
+func (UnimplementedOrderServiceServer) GetOrderLifecycle(context.Context, *GetOrderLifecycleReq) (*GetOrderLifecycleRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetOrderLifecycle not implemented")
+}
+func (UnimplementedOrderServiceServer) StreamOrderEvents(*StreamOrderEventsReq, OrderService_StreamOrderEventsServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamOrderEvents not implemented")
+}
+func (UnimplementedOrderServiceServer) mustEmbedUnimplementedOrderServiceServer() {}
+
+// UnsafeOrderServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to OrderServiceServer will
+// result in compilation errors.
+type UnsafeOrderServiceServer interface {
+	mustEmbedUnimplementedOrderServiceServer()
+}
+
+func RegisterOrderServiceServer(s grpc.ServiceRegistrar, srv OrderServiceServer) {
+	s.RegisterService(&OrderService_ServiceDesc, srv)
+}
+
+func _OrderService_CreateOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateOrderReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).CreateOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/orders.v1.OrderService/CreateOrder",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).CreateOrder(ctx, req.(*CreateOrderReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_PayOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PayOrderReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).PayOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/orders.v1.OrderService/PayOrder",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).PayOrder(ctx, req.(*PayOrderReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_SubmitOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SubmitOrderReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).SubmitOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/orders.v1.OrderService/SubmitOrder",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).SubmitOrder(ctx, req.(*SubmitOrderReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_UpdateShoppingCart_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateShoppingCartReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).UpdateShoppingCart(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/orders.v1.OrderService/UpdateShoppingCart",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).UpdateShoppingCart(ctx, req.(*UpdateShoppingCartReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_CancelOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelOrderReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).CancelOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/orders.v1.OrderService/CancelOrder",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).CancelOrder(ctx, req.(*CancelOrderReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_CompleteOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CompleteOrderReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).CompleteOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/orders.v1.OrderService/CompleteOrder",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).CompleteOrder(ctx, req.(*CompleteOrderReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_ChangeDeliveryAddress_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChangeDeliveryAddressReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).ChangeDeliveryAddress(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/orders.v1.OrderService/ChangeDeliveryAddress",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).ChangeDeliveryAddress(ctx, req.(*ChangeDeliveryAddressReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_AddItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddItemReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).AddItem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/orders.v1.OrderService/AddItem",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).AddItem(ctx, req.(*AddItemReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_RemoveItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveItemReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).RemoveItem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/orders.v1.OrderService/RemoveItem",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).RemoveItem(ctx, req.(*RemoveItemReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_ChangeItemQuantity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChangeItemQuantityReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).ChangeItemQuantity(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/orders.v1.OrderService/ChangeItemQuantity",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).ChangeItemQuantity(ctx, req.(*ChangeItemQuantityReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_ApplyCoupon_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ApplyCouponReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).ApplyCoupon(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/orders.v1.OrderService/ApplyCoupon",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).ApplyCoupon(ctx, req.(*ApplyCouponReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_RemoveCoupon_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveCouponReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).RemoveCoupon(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/orders.v1.OrderService/RemoveCoupon",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).RemoveCoupon(ctx, req.(*RemoveCouponReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_RefundOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RefundOrderReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).RefundOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/orders.v1.OrderService/RefundOrder",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).RefundOrder(ctx, req.(*RefundOrderReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_RequestReturn_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestReturnReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).RequestReturn(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/orders.v1.OrderService/RequestReturn",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).RequestReturn(ctx, req.(*RequestReturnReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_ApproveReturn_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ApproveReturnReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).ApproveReturn(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/orders.v1.OrderService/ApproveReturn",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).ApproveReturn(ctx, req.(*ApproveReturnReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_RejectReturn_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RejectReturnReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).RejectReturn(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/orders.v1.OrderService/RejectReturn",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).RejectReturn(ctx, req.(*RejectReturnReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_ReceiveReturn_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReceiveReturnReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).ReceiveReturn(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/orders.v1.OrderService/ReceiveReturn",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).ReceiveReturn(ctx, req.(*ReceiveReturnReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_CreateShipment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateShipmentReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).CreateShipment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/orders.v1.OrderService/CreateShipment",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).CreateShipment(ctx, req.(*CreateShipmentReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_PackShipment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PackShipmentReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).PackShipment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/orders.v1.OrderService/PackShipment",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).PackShipment(ctx, req.(*PackShipmentReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_DispatchShipment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DispatchShipmentReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).DispatchShipment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/orders.v1.OrderService/DispatchShipment",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).DispatchShipment(ctx, req.(*DispatchShipmentReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_DeliverShipment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeliverShipmentReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).DeliverShipment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/orders.v1.OrderService/DeliverShipment",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).DeliverShipment(ctx, req.(*DeliverShipmentReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_GetOrderByID_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetOrderByIDReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).GetOrderByID(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/orders.v1.OrderService/GetOrderByID",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).GetOrderByID(ctx, req.(*GetOrderByIDReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_SearchOrders_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchOrdersReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).SearchOrders(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/orders.v1.OrderService/SearchOrders",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).SearchOrders(ctx, req.(*SearchOrdersReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_GetOrderLifecycle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetOrderLifecycleReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).GetOrderLifecycle(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/orders.v1.OrderService/GetOrderLifecycle",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).GetOrderLifecycle(ctx, req.(*GetOrderLifecycleReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_StreamOrderEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamOrderEventsReq)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(OrderServiceServer).StreamOrderEvents(m, &orderServiceStreamOrderEventsServer{stream})
+}
+
+type OrderService_StreamOrderEventsServer interface {
+	Send(*OrderEvent) error
+	grpc.ServerStream
+}
+
+type orderServiceStreamOrderEventsServer struct {
+	grpc.ServerStream
+}
+
+func (x *orderServiceStreamOrderEventsServer) Send(m *OrderEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// OrderService_ServiceDesc is the grpc.ServiceDesc for OrderService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var OrderService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "orders.v1.OrderService",
+	HandlerType: (*OrderServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateOrder",
+			Handler:    _OrderService_CreateOrder_Handler,
+		},
+		{
+			MethodName: "PayOrder",
+			Handler:    _OrderService_PayOrder_Handler,
+		},
+		{
+			MethodName: "SubmitOrder",
+			Handler:    _OrderService_SubmitOrder_Handler,
+		},
+		{
+			MethodName: "UpdateShoppingCart",
+			Handler:    _OrderService_UpdateShoppingCart_Handler,
+		},
+		{
+			MethodName: "CancelOrder",
+			Handler:    _OrderService_CancelOrder_Handler,
+		},
+		{
+			MethodName: "CompleteOrder",
+			Handler:    _OrderService_CompleteOrder_Handler,
+		},
+		{
+			MethodName: "ChangeDeliveryAddress",
+			Handler:    _OrderService_ChangeDeliveryAddress_Handler,
+		},
+		{
+			MethodName: "AddItem",
+			Handler:    _OrderService_AddItem_Handler,
+		},
+		{
+			MethodName: "RemoveItem",
+			Handler:    _OrderService_RemoveItem_Handler,
+		},
+		{
+			MethodName: "ChangeItemQuantity",
+			Handler:    _OrderService_ChangeItemQuantity_Handler,
+		},
+		{
+			MethodName: "ApplyCoupon",
+			Handler:    _OrderService_ApplyCoupon_Handler,
+		},
+		{
+			MethodName: "RemoveCoupon",
+			Handler:    _OrderService_RemoveCoupon_Handler,
+		},
+		{
+			MethodName: "RefundOrder",
+			Handler:    _OrderService_RefundOrder_Handler,
+		},
+		{
+			MethodName: "RequestReturn",
+			Handler:    _OrderService_RequestReturn_Handler,
+		},
+		{
+			MethodName: "ApproveReturn",
+			Handler:    _OrderService_ApproveReturn_Handler,
+		},
+		{
+			MethodName: "RejectReturn",
+			Handler:    _OrderService_RejectReturn_Handler,
+		},
+		{
+			MethodName: "ReceiveReturn",
+			Handler:    _OrderService_ReceiveReturn_Handler,
+		},
+		{
+			MethodName: "CreateShipment",
+			Handler:    _OrderService_CreateShipment_Handler,
+		},
+		{
+			MethodName: "PackShipment",
+			Handler:    _OrderService_PackShipment_Handler,
+		},
+		{
+			MethodName: "DispatchShipment",
+			Handler:    _OrderService_DispatchShipment_Handler,
+		},
+		{
+			MethodName: "DeliverShipment",
+			Handler:    _OrderService_DeliverShipment_Handler,
+		},
+		{
+			MethodName: "GetOrderByID",
+			Handler:    _OrderService_GetOrderByID_Handler,
+		},
+		{
+			MethodName: "SearchOrders",
+			Handler:    _OrderService_SearchOrders_Handler,
+		},
+		{
+			MethodName: "GetOrderLifecycle",
+			Handler:    _OrderService_GetOrderLifecycle_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamOrderEvents",
+			Handler:       _OrderService_StreamOrderEvents_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "proto/orders/orders.proto",
+}